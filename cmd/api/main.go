@@ -2,30 +2,110 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/auth"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/graphqlapi"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/grpcapi"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/health"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/http/handlers"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/http/middleware"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/openapi"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/metrics"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/notify"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/postgres"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/telemetry"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	"github.com/maxwellsouza/go-factory-maintenance/migrations"
 	logrus "github.com/sirupsen/logrus"
 )
 
+const (
+	// shutdownDrainDelay dá tempo ao Kubernetes de observar /readyz falhando.
+	shutdownDrainDelay = 5 * time.Second
+	shutdownTimeout    = 15 * time.Second
+	// slaWarnBefore é a antecedência do alerta de SLA perto de vencer.
+	slaWarnBefore = time.Hour
+	// preventiveLead é a antecedência com que o agendador abre a OS preventiva.
+	preventiveLead = 7 * 24 * time.Hour
+	// slaInterval, escalationInterval, schedulerInterval e purgeInterval são os
+	// ciclos dos workers em background; cada um sinaliza um heartbeat ao fim do ciclo.
+	slaInterval        = time.Minute
+	escalationInterval = time.Minute
+	schedulerInterval  = time.Hour
+	purgeInterval      = time.Hour
+	// alertBacklogGrace e alertBacklogMax: o readiness falha com mais de
+	// alertBacklogMax alertas com nível vencido há mais de alertBacklogGrace sem entrega.
+	alertBacklogGrace = 15 * time.Minute
+	alertBacklogMax   = 50
+	// maxRequestBody limita o corpo das requisições (as importações têm limite próprio).
+	maxRequestBody = 1 << 20
+)
+
+// Cotas por cliente: a padrão vale para o conjunto das rotas sem cota própria.
+var (
+	defaultRateLimit = domain.RateLimit{Requests: 600, Per: time.Minute}
+	// ipRateLimit vale por IP antes da autenticação, com folga para vários
+	// usuários atrás do mesmo NAT.
+	ipRateLimit     = domain.RateLimit{Requests: 1200, Per: time.Minute}
+	routeRateLimits = map[string]domain.RateLimit{
+		"POST /work-orders":         {Requests: 60, Per: time.Minute},
+		"POST /requests":            {Requests: 30, Per: time.Minute},
+		"POST /imports/assets":      {Requests: 10, Per: time.Hour},
+		"POST /imports/work-orders": {Requests: 10, Per: time.Hour},
+		"POST /sync/push":           {Requests: 30, Per: time.Minute},
+	}
+)
+
+// Rotas sem versão: depreciadas com a publicação do /v1 e removidas no sunset.
+var (
+	legacyDeprecatedAt = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	legacySunset       = time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)
+)
+
 func main() {
 	// Configura logger
 	logrus.SetFormatter(&logrus.JSONFormatter{})
 	logrus.SetLevel(logrus.InfoLevel)
 
+	ctx := context.Background()
+
+	shutdownTracing, err := telemetry.SetupTracing(ctx, "factory-maintenance-api")
+	if err != nil {
+		log.Fatalf("❌ failed to setup tracing: %v", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logrus.Errorf("tracing shutdown: %v", err)
+		}
+	}()
+
+	// AUTH_SECRET assina os tokens de acesso (emitidos com cmd/token).
+	signer, err := auth.NewSigner([]byte(os.Getenv("AUTH_SECRET")))
+	if err != nil {
+		log.Fatalf("❌ failed to configure auth: %v", err)
+	}
+
+	reg := metrics.NewRegistry()
+	httpMetrics := metrics.NewHTTPMetrics(reg)
+
 	gin.SetMode(gin.DebugMode)
 	r := gin.New()
-	r.Use(gin.Recovery(), middleware.LoggerMiddleware())
-
-	r.GET("/healthz", func(c *gin.Context) {
-		c.String(200, "ok")
-	})
+	r.Use(gin.Recovery(), middleware.TracingMiddleware(), middleware.LoggerMiddleware(), httpMetrics.Middleware())
 
-	ctx := context.Background()
 	db, err := postgres.New(ctx)
 	if err != nil {
 		log.Fatalf("❌ failed to connect to database: %v", err)
@@ -34,18 +114,284 @@ func main() {
 
 	assetRepo := postgres.NewAssetRepo(db)
 	workOrderRepo := postgres.NewWorkOrderRepo(db)
+	indicatorRepo := postgres.NewIndicatorRepo(db)
+	reportRepo := postgres.NewReportRepo(db)
+	downtimeRepo := postgres.NewDowntimeRepo(db)
+	shiftRepo := postgres.NewShiftRepo(db)
+	productionRepo := postgres.NewProductionRepo(db)
+	failureCodeRepo := postgres.NewFailureCodeRepo(db)
+	slaRepo := postgres.NewSLARepo(db)
+	userRepo := postgres.NewUserRepo(db)
+	sparePartRepo := postgres.NewSparePartRepo(db)
+	planRepo := postgres.NewMaintenancePlanRepo(db)
+	technicianRepo := postgres.NewTechnicianRepo(db)
+	jobPlanRepo := postgres.NewJobPlanRepo(db)
+	assetClassRepo := postgres.NewAssetClassRepo(db)
+	requestRepo := postgres.NewMaintenanceRequestRepo(db)
+	siteRepo := postgres.NewSiteRepo(db)
+	checklistRepo := postgres.NewChecklistRepo(db)
+	calendarService := service.NewCalendarService(postgres.NewCalendarRepo(db), shiftRepo)
+
+	channels, err := notify.ChannelsFromEnv()
+	if err != nil {
+		log.Fatalf("❌ failed to configure notifications: %v", err)
+	}
+	notificationService := service.NewNotificationService(userRepo, postgres.NewEscalationRepo(db),
+		postgres.NewAlertRepo(db), channels)
+
+	syncRepo := postgres.NewSyncRepo(db)
+	assetService := service.NewAssetService(assetRepo, service.WithAssetClasses(assetClassRepo))
+	assetClassService := service.NewAssetClassService(assetClassRepo)
+	workOrderService := service.NewWorkOrderService(workOrderRepo,
+		service.WithAssets(assetRepo),
+		service.WithFailureCodes(failureCodeRepo),
+		service.WithSLA(slaRepo),
+		service.WithNotifier(notificationService),
+		service.WithCalendar(calendarService),
+		service.WithPlans(planRepo),
+		service.WithChecklists(jobPlanRepo, checklistRepo),
+		service.WithAssignees(technicianRepo),
+		service.WithTransactions(db),
+		service.WithChangeFeed(syncRepo, time.Second),
+	)
+	indicatorService := service.NewIndicatorService(indicatorRepo)
+	reportService := service.NewReportService(reportRepo, service.WithSiteDirectory(siteRepo))
+	downtimeService := service.NewDowntimeService(downtimeRepo, assetRepo, workOrderRepo)
+	shiftService := service.NewShiftService(shiftRepo)
+	productionService := service.NewProductionService(productionRepo, assetRepo)
+	failureCodeService := service.NewFailureCodeService(failureCodeRepo)
+	slaService := service.NewSLAService(slaRepo)
+	userService := service.NewUserService(userRepo)
+	sparePartService := service.NewSparePartService(sparePartRepo, notificationService)
+	planService := service.NewMaintenancePlanService(planRepo, assetRepo, jobPlanRepo)
+	jobPlanService := service.NewJobPlanService(jobPlanRepo, sparePartRepo)
+	requestService := service.NewMaintenanceRequestService(requestRepo, assetRepo, workOrderRepo, workOrderService)
+	technicianService := service.NewTechnicianService(technicianRepo, shiftRepo)
+	planningService := service.NewPlanningService(workOrderRepo, assetRepo, technicianRepo, shiftRepo, calendarService)
+	scheduler := service.NewPreventiveScheduler(planRepo, workOrderRepo, workOrderService, calendarService,
+		schedulerInterval, preventiveLead)
+
+	reg.MustRegister(
+		metrics.NewPoolCollector(db.Pool),
+		metrics.NewIndicatorCollector(indicatorService),
+	)
+	r.GET("/metrics", metrics.Handler(reg))
+
+	expectedSchema, err := migrations.LatestVersion()
+	if err != nil {
+		log.Fatalf("❌ failed to read migrations: %v", err)
+	}
 
-	assetService := service.NewAssetService(assetRepo)
-	workOrderService := service.NewWorkOrderService(workOrderRepo)
+	liveChecks := health.NewRegistry(2 * time.Second)
+	readyChecks := health.NewRegistry(2 * time.Second)
+	readyChecks.Register("postgres", health.PingCheck(db))
+	readyChecks.Register("migrations", health.MigrationCheck(db.SchemaVersion, expectedSchema))
+	// Worker sem heartbeat por mais de dois ciclos (mais uma folga) está travado ou parado.
+	var slaBeat, escalationBeat, schedulerBeat, idempotencyBeat, rateLimitBeat health.Heartbeat
+	readyChecks.Register("sla_monitor", slaBeat.Check(staleAfter(slaInterval)))
+	readyChecks.Register("alert_escalation", escalationBeat.Check(staleAfter(escalationInterval)))
+	readyChecks.Register("preventive_scheduler", schedulerBeat.Check(staleAfter(schedulerInterval)))
+	readyChecks.Register("idempotency_purge", idempotencyBeat.Check(staleAfter(purgeInterval)))
+	readyChecks.Register("rate_limit_purge", rateLimitBeat.Check(staleAfter(purgeInterval)))
+	readyChecks.Register("alert_backlog", health.BacklogCheck(func(ctx context.Context) (int64, error) {
+		return notificationService.PendingDeliveries(tenant.System(ctx), alertBacklogGrace)
+	}, alertBacklogMax))
+	handlers.NewHealthHandler(liveChecks, readyChecks).RegisterRoutes(r)
+	openapi.NewHandler().RegisterRoutes(r)
+
+	// Health checks, /metrics e a documentação (registrados acima) ficam sem
+	// autenticação; as rotas registradas daqui em diante contam na cota do IP (antes
+	// de olhar o token), têm limite de corpo, exigem token, só veem o site do
+	// usuário e contam na cota dele. As rotas descritas no
+	// contrato OpenAPI têm a entrada validada, e os POST com Idempotency-Key podem
+	// ser repetidos sem duplicar o cadastro.
+	contract, err := openapi.Load()
+	if err != nil {
+		log.Fatalf("❌ failed to load openapi contract: %v", err)
+	}
+	idempotencyService := service.NewIdempotencyService(postgres.NewIdempotencyRepo(db))
+	ipLimiter, rateLimiter, err := newRateLimiters(db)
+	if err != nil {
+		log.Fatalf("❌ failed to configure rate limiting: %v", err)
+	}
+	r.Use(
+		middleware.IPRateLimitMiddleware(ipLimiter),
+		middleware.BodyLimitMiddleware(maxRequestBody, map[string]int64{
+			"POST /imports/assets":      handlers.MaxImportSize,
+			"POST /imports/work-orders": handlers.MaxImportSize,
+		}),
+		middleware.AuthMiddleware(signer),
+		middleware.RateLimitMiddleware(rateLimiter),
+		openapi.RequestValidator(contract),
+		middleware.IdempotencyMiddleware(idempotencyService),
+	)
+
+	importService := service.NewImportService(assetRepo, workOrderRepo)
 
 	assetHandler := handlers.NewAssetHandler(assetService)
+	assetClassHandler := handlers.NewAssetClassHandler(assetClassService)
+	// SCAN_BASE_URL (ex: https://cmms.fabrica.local) faz o QR das etiquetas abrir /scan/:tag direto.
+	scanHandler := handlers.NewScanHandler(service.NewScanService(assetRepo, workOrderRepo, planRepo),
+		assetService, os.Getenv("SCAN_BASE_URL"))
 	workOrderHandler := handlers.NewWorkOrderHandler(workOrderService)
+	importHandler := handlers.NewImportHandler(importService)
+	reportHandler := handlers.NewReportHandler(reportService)
+	downtimeHandler := handlers.NewDowntimeHandler(downtimeService)
+	shiftHandler := handlers.NewShiftHandler(shiftService)
+	productionHandler := handlers.NewProductionHandler(productionService)
+	failureCodeHandler := handlers.NewFailureCodeHandler(failureCodeService)
+	slaHandler := handlers.NewSLAHandler(slaService)
+	userHandler := handlers.NewUserHandler(userService)
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	sparePartHandler := handlers.NewSparePartHandler(sparePartService)
+	planHandler := handlers.NewMaintenancePlanHandler(planService)
+	jobPlanHandler := handlers.NewJobPlanHandler(jobPlanService)
+	requestHandler := handlers.NewMaintenanceRequestHandler(requestService)
+	calendarHandler := handlers.NewCalendarHandler(calendarService, scheduler)
+	technicianHandler := handlers.NewTechnicianHandler(technicianService)
+	planningHandler := handlers.NewPlanningHandler(planningService)
+	siteHandler := handlers.NewSiteHandler(service.NewSiteService(siteRepo))
+	syncHandler := handlers.NewSyncHandler(service.NewSyncService(syncRepo, workOrderRepo,
+		checklistRepo, workOrderService, service.WithTechnicianScope(technicianRepo)))
+	graphqlHandler, err := graphqlapi.NewHandler(graphqlapi.Services{
+		Assets:           assetService,
+		WorkOrders:       workOrderService,
+		MaintenancePlans: planService,
+		Downtime:         downtimeService,
+		Reports:          reportService,
+	})
+	if err != nil {
+		log.Fatalf("❌ failed to build graphql schema: %v", err)
+	}
+
+	// A API fica em /v1; os caminhos antigos, sem versão, seguem respondendo igual
+	// com Deprecation/Sunset até o fim da transição.
+	v1 := r.Group("/v1")
+	legacy := r.Group("", middleware.DeprecatedMiddleware(legacyDeprecatedAt, legacySunset, "/v1"))
+	for _, h := range []interface{ RegisterRoutes(gin.IRouter) }{
+		assetHandler,
+		assetClassHandler,
+		scanHandler,
+		workOrderHandler,
+		importHandler,
+		reportHandler,
+		downtimeHandler,
+		shiftHandler,
+		productionHandler,
+		failureCodeHandler,
+		slaHandler,
+		userHandler,
+		notificationHandler,
+		sparePartHandler,
+		planHandler,
+		jobPlanHandler,
+		requestHandler,
+		calendarHandler,
+		technicianHandler,
+		planningHandler,
+		siteHandler,
+	} {
+		h.RegisterRoutes(v1)
+		h.RegisterRoutes(legacy)
+	}
+	// A sincronização e o GraphQL nasceram depois do versionamento: só existem em /v1.
+	syncHandler.RegisterRoutes(v1)
+	graphqlHandler.RegisterRoutes(v1)
 
-	assetHandler.RegisterRoutes(r)
-	workOrderHandler.RegisterRoutes(r)
+	srv := &http.Server{Addr: ":8080", Handler: r}
+	// Subscriptions GraphQL (SSE) não terminam sozinhas: fecham no início do Shutdown.
+	srv.RegisterOnShutdown(graphqlHandler.CloseStreams)
+	go func() {
+		logrus.Info("🚀 API (Postgres) running on :8080")
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Fatalf("server error: %v", err)
+		}
+	}()
 
-	logrus.Info("🚀 API (Postgres) running on :8080")
-	if err := r.Run(":8080"); err != nil {
-		logrus.Fatalf("server error: %v", err)
+	// A API gRPC (integrações MES) usa os mesmos serviços e o mesmo token.
+	grpcAddr := os.Getenv("GRPC_ADDR")
+	if grpcAddr == "" {
+		grpcAddr = ":9090"
 	}
+	grpcListener, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		log.Fatalf("❌ failed to listen on %s: %v", grpcAddr, err)
+	}
+	grpcSrv := grpcapi.NewServer(signer, grpcapi.Services{
+		Assets:           assetService,
+		WorkOrders:       workOrderService,
+		MaintenancePlans: planService,
+	})
+	go func() {
+		logrus.Infof("🚀 gRPC API running on %s", grpcAddr)
+		if err := grpcSrv.Serve(grpcListener); err != nil {
+			logrus.Fatalf("grpc server error: %v", err)
+		}
+	}()
+
+	stop, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	// Detector de atrasos de SLA, escalonamento de alertas, agendador de preventivas
+	// e limpeza das chaves de idempotência e dos baldes de cota parados param junto com o sinal de
+	// desligamento e atendem todos os sites.
+	jobs := tenant.System(stop)
+	go service.NewSLAMonitor(workOrderRepo, slaInterval,
+		service.WithSLAAlerts(notificationService, slaWarnBefore)).Run(jobs, &slaBeat)
+	go notificationService.RunEscalation(jobs, escalationInterval, &escalationBeat)
+	go scheduler.Run(jobs, &schedulerBeat)
+	go idempotencyService.RunPurge(jobs, purgeInterval, &idempotencyBeat)
+	go rateLimiter.RunPurge(jobs, purgeInterval, &rateLimitBeat)
+
+	<-stop.Done()
+
+	// Readiness falha primeiro para o balanceador tirar o pod de rotação,
+	// depois as conexões em andamento são drenadas.
+	logrus.Info("shutdown requested, draining")
+	readyChecks.Drain()
+	time.Sleep(shutdownDrainDelay)
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancelShutdown()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logrus.Errorf("server shutdown: %v", err)
+	}
+	// Streams de WatchWorkOrders não terminam sozinhos: o que sobrar no prazo é cortado.
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcSrv.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		grpcSrv.Stop()
+	}
+}
+
+// newRateLimiter monta o limitador com as cotas acima. RATE_LIMIT_STORE=postgres
+// divide as cotas entre as réplicas; o padrão (memory) conta por processo.
+// newRateLimiters monta o limitador por IP (antes da autenticação) e o por
+// usuário, no mesmo armazenamento de baldes. A limpeza do limitador por
+// usuário cobre os dois: a janela dele é a maior.
+func newRateLimiters(db *postgres.DB) (ip, user *service.RateLimiter, err error) {
+	var buckets repository.RateLimitRepository
+	switch store := os.Getenv("RATE_LIMIT_STORE"); store {
+	case "", "memory":
+		buckets = memory.NewRateLimitMemoryRepo()
+	case "postgres":
+		buckets = postgres.NewRateLimitRepo(db)
+	default:
+		return nil, nil, fmt.Errorf("unknown RATE_LIMIT_STORE %q", store)
+	}
+	var opts []service.RateLimiterOption
+	for route, limit := range routeRateLimits {
+		opts = append(opts, service.WithRouteLimit(route, limit))
+	}
+	return service.NewRateLimiter(buckets, ipRateLimit), service.NewRateLimiter(buckets, defaultRateLimit, opts...), nil
+}
+
+// staleAfter é a idade máxima do heartbeat de um worker com o ciclo dado.
+func staleAfter(interval time.Duration) time.Duration {
+	return 2*interval + time.Minute
 }
@@ -0,0 +1,115 @@
+// Comando ingest liga os sinais das máquinas ao sistema: assina os tópicos MQTT
+// do arquivo de mapeamento (payload JSON do CLP/gateway ou OPC UA PubSub em JSON)
+// e grava contadores, medições e marcha/parada dos ativos:
+//
+//	MQTT_BROKER=tcp://broker:1883 go run ./cmd/ingest -mapping signals.json
+//
+// Parada da máquina abre uma parada do ativo e a volta à marcha a encerra; o
+// contador dispara os planos por uso e as medições fora da faixa, os planos por
+// condição. MQTT_CLIENT_ID, MQTT_USERNAME e MQTT_PASSWORD são opcionais; o
+// MQTT_CLIENT_ID identifica a sessão persistente no broker e deve ser fixo.
+//
+// Pendente: a assinatura direta de nós OPC UA (opc.tcp, monitored items) não
+// está implementada. As máquinas OPC UA entram pelo PubSub JSON sobre MQTT
+// até o cliente OPC UA entrar nas dependências (ver README).
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ingest"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ingest/mqtt"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/notify"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/postgres"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/telemetry"
+	logrus "github.com/sirupsen/logrus"
+)
+
+func main() {
+	mappingPath := flag.String("mapping", "signals.json", "arquivo de mapeamento dos sinais")
+	flag.Parse()
+
+	logrus.SetFormatter(&logrus.JSONFormatter{})
+	logrus.SetLevel(logrus.InfoLevel)
+
+	mapping, err := ingest.LoadMapping(*mappingPath)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	broker := os.Getenv("MQTT_BROKER")
+	if broker == "" {
+		log.Fatalf("❌ MQTT_BROKER is required")
+	}
+	clientID := os.Getenv("MQTT_CLIENT_ID")
+	if clientID == "" {
+		clientID = "factory-maintenance-ingest"
+	}
+
+	ctx := context.Background()
+	shutdownTracing, err := telemetry.SetupTracing(ctx, "factory-maintenance-ingest")
+	if err != nil {
+		log.Fatalf("❌ failed to setup tracing: %v", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logrus.Errorf("tracing shutdown: %v", err)
+		}
+	}()
+
+	db, err := postgres.New(ctx)
+	if err != nil {
+		log.Fatalf("❌ failed to connect to database: %v", err)
+	}
+	defer db.Pool.Close()
+
+	// As OS abertas pelos sinais seguem as mesmas regras das abertas pela API
+	// (SLA, alertas, roteiro do plano). Os alertas ficam gravados e o
+	// escalonamento da API faz os envios.
+	assetRepo := postgres.NewAssetRepo(db)
+	workOrderRepo := postgres.NewWorkOrderRepo(db)
+	planRepo := postgres.NewMaintenancePlanRepo(db)
+	shiftRepo := postgres.NewShiftRepo(db)
+	userRepo := postgres.NewUserRepo(db)
+	calendarService := service.NewCalendarService(postgres.NewCalendarRepo(db), shiftRepo)
+	channels, err := notify.ChannelsFromEnv()
+	if err != nil {
+		log.Fatalf("❌ failed to configure notifications: %v", err)
+	}
+	notificationService := service.NewNotificationService(userRepo, postgres.NewEscalationRepo(db),
+		postgres.NewAlertRepo(db), channels)
+	workOrderService := service.NewWorkOrderService(workOrderRepo,
+		service.WithAssets(assetRepo),
+		service.WithSLA(postgres.NewSLARepo(db)),
+		service.WithNotifier(notificationService),
+		service.WithCalendar(calendarService),
+		service.WithPlans(planRepo),
+		service.WithChecklists(postgres.NewJobPlanRepo(db), postgres.NewChecklistRepo(db)),
+		service.WithTransactions(db),
+	)
+	downtimeService := service.NewDowntimeService(postgres.NewDowntimeRepo(db), assetRepo, workOrderRepo)
+	signalService := service.NewSignalService(postgres.NewSignalRepo(db), assetRepo, planRepo, workOrderRepo,
+		workOrderService, downtimeService)
+
+	bridge := ingest.NewBridge(mqtt.Config{
+		Broker:    broker,
+		ClientID:  clientID,
+		Username:  os.Getenv("MQTT_USERNAME"),
+		Password:  os.Getenv("MQTT_PASSWORD"),
+		KeepAlive: 30 * time.Second,
+	}, mapping, assetRepo, signalService)
+
+	stop, cancel := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+	logrus.Infof("🚀 ingest running: %d signals from %s", len(mapping.Signals), broker)
+	if err := bridge.Run(stop); err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	logrus.Info("ingest stopped")
+}
@@ -0,0 +1,50 @@
+// Comando token emite o token de acesso à API de um usuário cadastrado:
+//
+//	AUTH_SECRET=... go run ./cmd/token -user 7 -ttl 720h
+//
+// O token carrega o site e o perfil corporativo do usuário no momento da emissão.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/auth"
+	pg "github.com/maxwellsouza/go-factory-maintenance/internal/repository/postgres"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+func main() {
+	userID := flag.Int64("user", 0, "id do usuário")
+	ttl := flag.Duration("ttl", 30*24*time.Hour, "validade do token")
+	flag.Parse()
+
+	signer, err := auth.NewSigner([]byte(os.Getenv("AUTH_SECRET")))
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	ctx := tenant.System(context.Background())
+	db, err := pg.New(ctx)
+	if err != nil {
+		log.Fatalf("❌ DB connection failed: %v", err)
+	}
+	defer db.Pool.Close()
+
+	u, err := pg.NewUserRepo(db).FindByID(ctx, *userID)
+	if err != nil {
+		log.Fatalf("❌ user %d: %v", *userID, err)
+	}
+	if !u.Active {
+		log.Fatalf("❌ user %d is inactive", u.ID)
+	}
+	token, err := signer.Issue(u, *ttl)
+	if err != nil {
+		log.Fatalf("❌ issue token: %v", err)
+	}
+	fmt.Println(token)
+}
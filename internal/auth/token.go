@@ -0,0 +1,89 @@
+// Package auth emite e confere os tokens de acesso à API: as claims do usuário
+// (site e perfil corporativo) em JSON, assinadas com HMAC-SHA256.
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// minSecretLen evita segredos fracos o bastante para força bruta.
+const minSecretLen = 32
+
+var ErrWeakSecret = errors.New("auth secret must have at least 32 bytes")
+
+// Claims é o conteúdo do token.
+type Claims struct {
+	UserID    int64 `json:"sub"`
+	SiteID    int64 `json:"site"`
+	Corporate bool  `json:"corp,omitempty"`
+	ExpiresAt int64 `json:"exp"` // unix, segundos
+}
+
+// Principal converte as claims no principal usado no escopo das chamadas.
+func (c Claims) Principal() tenant.Principal {
+	return tenant.Principal{UserID: c.UserID, SiteID: c.SiteID, Corporate: c.Corporate}
+}
+
+// Signer assina e confere tokens com um segredo compartilhado (AUTH_SECRET).
+type Signer struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewSigner(secret []byte) (*Signer, error) {
+	if len(secret) < minSecretLen {
+		return nil, ErrWeakSecret
+	}
+	return &Signer{secret: secret, now: time.Now}, nil
+}
+
+// Issue gera o token do usuário válido por ttl.
+func (s *Signer) Issue(u *domain.User, ttl time.Duration) (string, error) {
+	if u.ID == 0 || u.SiteID == 0 || ttl <= 0 {
+		return "", domain.ErrInvalidInput
+	}
+	payload, err := json.Marshal(Claims{
+		UserID: u.ID, SiteID: u.SiteID, Corporate: u.Corporate, ExpiresAt: s.now().Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("encode claims: %w", err)
+	}
+	body := base64.RawURLEncoding.EncodeToString(payload)
+	return body + "." + s.sign(body), nil
+}
+
+// Verify confere assinatura e validade; qualquer falha é ErrUnauthorized.
+func (s *Signer) Verify(token string) (*Claims, error) {
+	body, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(sig), []byte(s.sign(body))) {
+		return nil, domain.ErrUnauthorized
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(body)
+	if err != nil {
+		return nil, domain.ErrUnauthorized
+	}
+	var c Claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, domain.ErrUnauthorized
+	}
+	if c.UserID == 0 || c.SiteID == 0 || s.now().Unix() >= c.ExpiresAt {
+		return nil, domain.ErrUnauthorized
+	}
+	return &c, nil
+}
+
+func (s *Signer) sign(body string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(body))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -1,6 +1,11 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // Criticality representa a criticidade operacional do ativo.
 type Criticality string
@@ -12,16 +17,80 @@ const (
 )
 
 type Asset struct {
-	ID          int64       `json:"id"`
-	Name        string      `json:"name"`
-	Location    string      `json:"location,omitempty"`
-	Criticality Criticality `json:"criticality,omitempty"` // A, B, C
-	CreatedAt   time.Time   `json:"created_at"`
-	UpdatedAt   time.Time   `json:"updated_at"`
+	ID           int64       `json:"id"`
+	SiteID       int64       `json:"site_id"`
+	Name         string      `json:"name"`
+	Location     string      `json:"location,omitempty"`
+	Criticality  Criticality `json:"criticality,omitempty"`   // A, B, C
+	ExternalCode string      `json:"external_code,omitempty"` // tag/plaqueta do ativo, única no site (planilhas/ERP)
+	// Dados de placa, usados na compra de peças.
+	Manufacturer  string `json:"manufacturer,omitempty"`
+	Model         string `json:"model,omitempty"`
+	SerialNumber  string `json:"serial_number,omitempty"`
+	InstalledOn   string `json:"installed_on,omitempty"`   // AAAA-MM-DD (instalação/comissionamento)
+	WarrantyUntil string `json:"warranty_until,omitempty"` // AAAA-MM-DD
+	// Class é o código da classe de ativo que define os atributos customizados aceitos.
+	Class      string         `json:"class,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// IdealRatePerHour é a cadência ideal (peças/hora), base do índice de performance do OEE.
+	IdealRatePerHour *float64  `json:"ideal_rate_per_hour,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	// Revision é a revisão de sincronização da última escrita (ver SyncChange).
+	Revision int64 `json:"-"`
 }
 
 func (a *Asset) Normalize() {
 	if a.Criticality == "" {
 		a.Criticality = CriticalityB
 	}
+	a.ExternalCode = strings.TrimSpace(a.ExternalCode)
+	a.Manufacturer = strings.TrimSpace(a.Manufacturer)
+	a.Model = strings.TrimSpace(a.Model)
+	a.SerialNumber = strings.TrimSpace(a.SerialNumber)
+	a.Class = strings.ToLower(strings.TrimSpace(a.Class))
+	if len(a.Attributes) == 0 {
+		a.Attributes = nil
+	}
+}
+
+// Validate confere as datas de placa; os atributos são validados contra a classe no serviço.
+func (a *Asset) Validate() error {
+	for _, d := range []string{a.InstalledOn, a.WarrantyUntil} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, d); err != nil {
+			return ErrInvalidInput
+		}
+	}
+	if a.Class == "" && len(a.Attributes) > 0 {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// assetTagPrefix identifica as tags geradas pelo sistema (etiquetas QR/código de barras).
+const assetTagPrefix = "AT-"
+
+// Tag é o identificador impresso na etiqueta. Deriva só do ID, então não muda
+// quando nome, localização ou código legado do ativo são alterados.
+func (a *Asset) Tag() string {
+	return AssetTag(a.ID)
+}
+
+func AssetTag(id int64) string {
+	return fmt.Sprintf("%s%06d", assetTagPrefix, id)
+}
+
+// ParseAssetTag extrai o ID de uma tag gerada pelo sistema (AT-000042, sem diferenciar maiúsculas).
+func ParseAssetTag(tag string) (int64, bool) {
+	if len(tag) <= len(assetTagPrefix) || !strings.EqualFold(tag[:len(assetTagPrefix)], assetTagPrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(tag[len(assetTagPrefix):], 10, 64)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return id, true
 }
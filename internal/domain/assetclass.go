@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AttributeType é o tipo de um atributo customizado da classe de ativo.
+type AttributeType string
+
+const (
+	AttrText    AttributeType = "text"
+	AttrNumber  AttributeType = "number"
+	AttrBoolean AttributeType = "boolean"
+	AttrDate    AttributeType = "date" // AAAA-MM-DD
+	AttrEnum    AttributeType = "enum" // um dos valores de Options
+)
+
+// AttributeDef define um atributo da classe (ex: tensão em V, potência em kW).
+// Min/Max só valem para number.
+type AttributeDef struct {
+	Key      string        `json:"key"`
+	Label    string        `json:"label,omitempty"`
+	Type     AttributeType `json:"type"`
+	Unit     string        `json:"unit,omitempty"`
+	Required bool          `json:"required"`
+	Options  []string      `json:"options,omitempty"`
+	Min      *float64      `json:"min,omitempty"`
+	Max      *float64      `json:"max,omitempty"`
+}
+
+// AssetClass agrupa ativos do mesmo tipo (motor, bomba, compressor) e define
+// quais atributos customizados eles aceitam.
+type AssetClass struct {
+	ID         int64          `json:"id"`
+	Code       string         `json:"code"`
+	Name       string         `json:"name"`
+	Attributes []AttributeDef `json:"attributes"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// attributeKey restringe as chaves ao que cabe em query string (attr.<chave>=...).
+var attributeKey = regexp.MustCompile(`^[a-z][a-z0-9_]{0,62}$`)
+
+func (c *AssetClass) Normalize() {
+	c.Code = strings.ToLower(strings.TrimSpace(c.Code))
+	c.Name = strings.TrimSpace(c.Name)
+	for i := range c.Attributes {
+		d := &c.Attributes[i]
+		d.Key = strings.ToLower(strings.TrimSpace(d.Key))
+		d.Label = strings.TrimSpace(d.Label)
+		d.Unit = strings.TrimSpace(d.Unit)
+	}
+	if c.Attributes == nil {
+		c.Attributes = []AttributeDef{}
+	}
+}
+
+func (c *AssetClass) Validate() error {
+	if !attributeKey.MatchString(c.Code) || c.Name == "" {
+		return ErrInvalidInput
+	}
+	seen := map[string]bool{}
+	for _, d := range c.Attributes {
+		if !attributeKey.MatchString(d.Key) || seen[d.Key] {
+			return ErrInvalidInput
+		}
+		seen[d.Key] = true
+		switch d.Type {
+		case AttrText, AttrBoolean, AttrDate:
+		case AttrNumber:
+			if d.Min != nil && d.Max != nil && *d.Min > *d.Max {
+				return ErrInvalidInput
+			}
+		case AttrEnum:
+			if len(d.Options) == 0 {
+				return ErrInvalidInput
+			}
+		default:
+			return ErrInvalidInput
+		}
+		if d.Type != AttrNumber && (d.Min != nil || d.Max != nil) {
+			return ErrInvalidInput
+		}
+		if d.Type != AttrEnum && len(d.Options) > 0 {
+			return ErrInvalidInput
+		}
+	}
+	return nil
+}
+
+// Definition retorna a definição do atributo (nil se a classe não o tiver).
+func (c *AssetClass) Definition(key string) *AttributeDef {
+	for i := range c.Attributes {
+		if c.Attributes[i].Key == key {
+			return &c.Attributes[i]
+		}
+	}
+	return nil
+}
+
+// CheckAttributes valida os atributos do ativo contra a classe: chaves
+// desconhecidas, obrigatórios ausentes e valores fora do tipo são ErrInvalidInput.
+func (c *AssetClass) CheckAttributes(attrs map[string]any) error {
+	for key, v := range attrs {
+		d := c.Definition(key)
+		if d == nil || !d.accepts(v) {
+			return ErrInvalidInput
+		}
+	}
+	for _, d := range c.Attributes {
+		if _, ok := attrs[d.Key]; d.Required && !ok {
+			return ErrInvalidInput
+		}
+	}
+	return nil
+}
+
+// accepts confere o valor já decodificado do JSON (números chegam como float64).
+func (d *AttributeDef) accepts(v any) bool {
+	switch d.Type {
+	case AttrText:
+		s, ok := v.(string)
+		return ok && s != ""
+	case AttrNumber:
+		n, ok := v.(float64)
+		return ok && (d.Min == nil || n >= *d.Min) && (d.Max == nil || n <= *d.Max)
+	case AttrBoolean:
+		_, ok := v.(bool)
+		return ok
+	case AttrDate:
+		s, ok := v.(string)
+		if !ok {
+			return false
+		}
+		_, err := time.Parse(time.DateOnly, s)
+		return err == nil
+	case AttrEnum:
+		s, ok := v.(string)
+		return ok && slices.Contains(d.Options, s)
+	}
+	return false
+}
+
+// AttributeText é a forma textual usada nos filtros (attr.voltage=380): o mesmo
+// texto que o operador ->> do Postgres produz para o valor JSON.
+func AttributeText(v any) string {
+	switch x := v.(type) {
+	case string:
+		return x
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(x)
+	}
+	return ""
+}
@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Holiday é um feriado: o dia inteiro (no fuso da planta) fica sem expediente.
+type Holiday struct {
+	ID     int64  `json:"id"`
+	SiteID int64  `json:"site_id"`
+	Date   string `json:"date"` // AAAA-MM-DD
+	Name   string `json:"name"`
+}
+
+func (h *Holiday) Validate() error {
+	h.Name = strings.TrimSpace(h.Name)
+	if _, err := time.Parse(time.DateOnly, h.Date); err != nil || h.Name == "" {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// Shutdown é uma parada programada da planta (manutenção geral, férias coletivas...).
+type Shutdown struct {
+	ID        int64     `json:"id"`
+	SiteID    int64     `json:"site_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s *Shutdown) Validate() error {
+	s.Reason = strings.TrimSpace(s.Reason)
+	if s.Reason == "" || !s.EndsAt.After(s.StartsAt) {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// CalendarSettings guarda os dias úteis da semana (0=domingo … 6=sábado).
+type CalendarSettings struct {
+	WorkingDays []int `json:"working_days"`
+}
+
+// DefaultCalendarSettings é segunda a sexta, como o banco após a migração.
+func DefaultCalendarSettings() CalendarSettings {
+	return CalendarSettings{WorkingDays: []int{1, 2, 3, 4, 5}}
+}
+
+func (s *CalendarSettings) Validate() error {
+	if len(s.WorkingDays) == 0 {
+		return ErrInvalidInput
+	}
+	seen := map[int]bool{}
+	for _, d := range s.WorkingDays {
+		if d < 0 || d > 6 || seen[d] {
+			return ErrInvalidInput
+		}
+		seen[d] = true
+	}
+	sort.Ints(s.WorkingDays)
+	return nil
+}
+
+// WorkingWindow é um intervalo contínuo de tempo útil.
+type WorkingWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// CalendarDay resume um dia do calendário da planta.
+type CalendarDay struct {
+	Date    string          `json:"date"`
+	Working bool            `json:"working"`
+	Holiday string          `json:"holiday,omitempty"`
+	Windows []WorkingWindow `json:"windows"`
+}
+
+// calendarSearchDays limita a busca por tempo útil (calendário sem expediente nenhum).
+const calendarSearchDays = 366
+
+// Calendar é o calendário da planta: tempo útil = dias úteis que não são feriado,
+// dentro dos turnos da planta toda (sem turnos, o dia inteiro) e fora das paradas programadas.
+// Um *Calendar nil trata todo o tempo como útil (24×7).
+type Calendar struct {
+	Location    *time.Location
+	WorkingDays []int
+	Shifts      []Shift
+	Holidays    []Holiday
+	Shutdowns   []Shutdown
+}
+
+// HolidayOn retorna o nome do feriado do dia (vazio se não for feriado).
+func (c *Calendar) HolidayOn(day time.Time) string {
+	date := day.In(c.Location).Format(time.DateOnly)
+	for _, h := range c.Holidays {
+		if h.Date == date {
+			return h.Name
+		}
+	}
+	return ""
+}
+
+// IsWorkingDay indica dia útil da semana que não é feriado.
+func (c *Calendar) IsWorkingDay(day time.Time) bool {
+	wd := int(day.In(c.Location).Weekday())
+	for _, d := range c.WorkingDays {
+		if d == wd {
+			return c.HolidayOn(day) == ""
+		}
+	}
+	return false
+}
+
+// Windows lista o tempo útil dentro de [from, to), em ordem e sem sobreposição.
+// Turnos que viram o dia pertencem ao dia em que começam.
+func (c *Calendar) Windows(from, to time.Time) []WorkingWindow {
+	if !from.Before(to) {
+		return nil
+	}
+	var raw []WorkingWindow
+	if len(c.Shifts) > 0 {
+		for i := range c.Shifts {
+			for _, w := range c.Shifts[i].Windows(from, to, c.Location) {
+				if c.IsWorkingDay(w.Start) {
+					raw = append(raw, WorkingWindow{Start: w.Start, End: w.End})
+				}
+			}
+		}
+	} else {
+		f := from.In(c.Location)
+		for day := time.Date(f.Year(), f.Month(), f.Day(), 0, 0, 0, 0, c.Location); day.Before(to); day = day.AddDate(0, 0, 1) {
+			if c.IsWorkingDay(day) {
+				raw = append(raw, WorkingWindow{Start: day, End: day.AddDate(0, 0, 1)})
+			}
+		}
+	}
+
+	sort.Slice(raw, func(i, j int) bool { return raw[i].Start.Before(raw[j].Start) })
+	var merged []WorkingWindow
+	for _, w := range raw {
+		if w.Start.Before(from) {
+			w.Start = from
+		}
+		if w.End.After(to) {
+			w.End = to
+		}
+		if !w.Start.Before(w.End) {
+			continue
+		}
+		if n := len(merged); n > 0 && !w.Start.After(merged[n-1].End) {
+			if w.End.After(merged[n-1].End) {
+				merged[n-1].End = w.End
+			}
+			continue
+		}
+		merged = append(merged, w)
+	}
+	return c.withoutShutdowns(merged)
+}
+
+// ShiftWindows lista o tempo de trabalho de um turno em [from, to): ocorrências que
+// começam em dia útil, com a pausa descontada no fim do turno e fora das paradas programadas.
+func (c *Calendar) ShiftWindows(s *Shift, from, to time.Time) []WorkingWindow {
+	var windows []WorkingWindow
+	brk := time.Duration(s.BreakMinutes) * time.Minute
+	for _, w := range s.Windows(from, to, c.Location) {
+		if !c.IsWorkingDay(w.Start) {
+			continue
+		}
+		ww := WorkingWindow{Start: w.Start, End: w.End.Add(-brk)}
+		if ww.Start.Before(from) {
+			ww.Start = from
+		}
+		if ww.End.After(to) {
+			ww.End = to
+		}
+		if ww.Start.Before(ww.End) {
+			windows = append(windows, ww)
+		}
+	}
+	return c.withoutShutdowns(windows)
+}
+
+// withoutShutdowns recorta as paradas programadas das janelas.
+func (c *Calendar) withoutShutdowns(windows []WorkingWindow) []WorkingWindow {
+	for _, s := range c.Shutdowns {
+		var out []WorkingWindow
+		for _, w := range windows {
+			if !s.StartsAt.Before(w.End) || !w.Start.Before(s.EndsAt) {
+				out = append(out, w)
+				continue
+			}
+			if w.Start.Before(s.StartsAt) {
+				out = append(out, WorkingWindow{Start: w.Start, End: s.StartsAt})
+			}
+			if s.EndsAt.Before(w.End) {
+				out = append(out, WorkingWindow{Start: s.EndsAt, End: w.End})
+			}
+		}
+		windows = out
+	}
+	return windows
+}
+
+// NextWorkingTime retorna t, se já for tempo útil, ou o início do próximo tempo útil.
+func (c *Calendar) NextWorkingTime(t time.Time) time.Time {
+	return c.AddWorkingTime(t, 0)
+}
+
+// AddWorkingTime soma d de tempo útil a start (o relógio para fora do expediente).
+// Sem tempo útil dentro do limite de busca, cai na soma corrida.
+func (c *Calendar) AddWorkingTime(start time.Time, d time.Duration) time.Time {
+	if c == nil {
+		return start.Add(d)
+	}
+	const week = 7 * 24 * time.Hour
+	remaining := d
+	for cursor, i := start, 0; i < calendarSearchDays/7+1; cursor, i = cursor.Add(week), i+1 {
+		for _, w := range c.Windows(cursor, cursor.Add(week)) {
+			avail := w.End.Sub(w.Start)
+			if remaining <= avail {
+				return w.Start.Add(remaining)
+			}
+			remaining -= avail
+		}
+	}
+	return start.Add(d)
+}
+
+// Days detalha cada dia de [from, to) (datas no fuso da planta).
+func (c *Calendar) Days(from, to time.Time) []CalendarDay {
+	var days []CalendarDay
+	f := from.In(c.Location)
+	for day := time.Date(f.Year(), f.Month(), f.Day(), 0, 0, 0, 0, c.Location); day.Before(to); day = day.AddDate(0, 0, 1) {
+		next := day.AddDate(0, 0, 1)
+		windows := c.Windows(day, next)
+		if windows == nil {
+			windows = []WorkingWindow{}
+		}
+		days = append(days, CalendarDay{
+			Date:    day.Format(time.DateOnly),
+			Working: len(windows) > 0,
+			Holiday: c.HolidayOn(day),
+			Windows: windows,
+		})
+	}
+	return days
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// DowntimeEvent é uma parada do ativo, com ou sem OS vinculada.
+// Enquanto EndedAt é nil a parada está em andamento.
+type DowntimeEvent struct {
+	ID          int64      `json:"id"`
+	AssetID     int64      `json:"asset_id"`
+	WorkOrderID *int64     `json:"work_order_id,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+	ReasonCode  string     `json:"reason_code"` // ex: MEC, ELE, SETUP, FALTA_MP
+	Planned     bool       `json:"planned"`     // parada programada (setup, preventiva) x quebra
+	Notes       string     `json:"notes,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// IsOpen indica parada em andamento.
+func (e *DowntimeEvent) IsOpen() bool {
+	return e.EndedAt == nil
+}
+
+// Minutes retorna a duração arredondada para baixo (paradas abertas contam até "now").
+func (e *DowntimeEvent) Minutes(now time.Time) int64 {
+	end := now
+	if e.EndedAt != nil {
+		end = *e.EndedAt
+	}
+	if end.Before(e.StartedAt) {
+		return 0
+	}
+	return int64(end.Sub(e.StartedAt) / time.Minute)
+}
+
+// Overlaps diz se a parada intercepta [start, end); end nil significa "em aberto".
+func (e *DowntimeEvent) Overlaps(start time.Time, end *time.Time) bool {
+	// [a1, a2) ∩ [b1, b2) ≠ ∅  ⇔  a1 < b2 && b1 < a2
+	if end != nil && !e.StartedAt.Before(*end) {
+		return false
+	}
+	if e.EndedAt != nil && !start.Before(*e.EndedAt) {
+		return false
+	}
+	return true
+}
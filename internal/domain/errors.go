@@ -10,4 +10,8 @@ var (
 	ErrPrecondition  = errors.New("precondition failed")
 	ErrUnauthorized  = errors.New("unauthorized")
 	ErrForbidden     = errors.New("forbidden")
+	// ErrKeyReused: Idempotency-Key já usada com outra requisição.
+	ErrKeyReused = errors.New("idempotency key reused with a different request")
+	// ErrRateLimited: cliente estourou a cota de requisições.
+	ErrRateLimited = errors.New("rate limit exceeded")
 )
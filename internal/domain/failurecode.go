@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// FailureCodeLevel é o nível do catálogo de falhas (hierarquia no estilo ISO 14224).
+type FailureCodeLevel string
+
+const (
+	FailureLevelMode   FailureCodeLevel = "mode"   // modo de falha (ex: vazamento)
+	FailureLevelCause  FailureCodeLevel = "cause"  // causa (ex: vedação desgastada), filho de um modo
+	FailureLevelAction FailureCodeLevel = "action" // ação (ex: substituir vedação), filho de uma causa
+)
+
+// ParentLevel retorna o nível exigido para o pai; modos não têm pai.
+func (l FailureCodeLevel) ParentLevel() (FailureCodeLevel, bool) {
+	switch l {
+	case FailureLevelCause:
+		return FailureLevelMode, true
+	case FailureLevelAction:
+		return FailureLevelCause, true
+	}
+	return "", false
+}
+
+func (l FailureCodeLevel) Valid() bool {
+	return l == FailureLevelMode || l == FailureLevelCause || l == FailureLevelAction
+}
+
+type FailureCode struct {
+	ID        int64            `json:"id"`
+	Code      string           `json:"code"` // único no catálogo (ex: VAZ, VAZ-VED, VAZ-VED-SUB)
+	Name      string           `json:"name"`
+	Level     FailureCodeLevel `json:"level"`
+	ParentID  *int64           `json:"parent_id,omitempty"`
+	Active    bool             `json:"active"` // inativos somem da seleção, mas o histórico continua válido
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
+
+// FailureCodeNode é a visão em árvore do catálogo.
+type FailureCodeNode struct {
+	FailureCode
+	Children []FailureCodeNode `json:"children,omitempty"`
+}
+
+// BuildFailureTree monta a árvore a partir da lista plana (ordem preservada).
+func BuildFailureTree(codes []FailureCode) []FailureCodeNode {
+	children := map[int64][]FailureCode{}
+	var roots []FailureCode
+	for _, c := range codes {
+		if c.ParentID == nil {
+			roots = append(roots, c)
+			continue
+		}
+		children[*c.ParentID] = append(children[*c.ParentID], c)
+	}
+
+	var build func(list []FailureCode) []FailureCodeNode
+	build = func(list []FailureCode) []FailureCodeNode {
+		nodes := make([]FailureCodeNode, 0, len(list))
+		for _, c := range list {
+			nodes = append(nodes, FailureCodeNode{FailureCode: c, Children: build(children[c.ID])})
+		}
+		return nodes
+	}
+	return build(roots)
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// AssetFilter restringe listagens/exportações de ativos; campos vazios não filtram.
+type AssetFilter struct {
+	// IDs restringe aos ativos listados (carga em lote, ex: GraphQL).
+	IDs         []int64
+	Location    string
+	Criticality Criticality
+	Class       string
+	// Attributes compara o texto de cada atributo customizado (ex: voltage → "380").
+	Attributes map[string]string
+}
+
+// Match aplica o filtro em memória (mesma semântica do SQL do repositório postgres).
+func (f AssetFilter) Match(a *Asset) bool {
+	if len(f.IDs) > 0 && !slices.Contains(f.IDs, a.ID) {
+		return false
+	}
+	if f.Location != "" && a.Location != f.Location {
+		return false
+	}
+	if f.Criticality != "" && a.Criticality != f.Criticality {
+		return false
+	}
+	if f.Class != "" && a.Class != f.Class {
+		return false
+	}
+	for key, want := range f.Attributes {
+		v, ok := a.Attributes[key]
+		if !ok || AttributeText(v) != want {
+			return false
+		}
+	}
+	return true
+}
+
+// WorkOrderFilter restringe listagens/exportações de OS; campos vazios não filtram.
+// From/To comparam a data de abertura (created_at), com To exclusivo.
+type WorkOrderFilter struct {
+	Status  WorkOrderStatus
+	Type    WorkOrderType
+	AssetID int64
+	// AssetIDs restringe às OS dos ativos listados (carga em lote, ex: GraphQL).
+	AssetIDs []int64
+	PlanID   int64
+	// Open traz só as OS em aberto (open ou in_progress).
+	Open bool
+	From *time.Time
+	To   *time.Time
+	// OverdueAt, quando preenchido, traz só as OS em atraso de SLA nesse instante.
+	OverdueAt *time.Time
+	// DueBefore, quando preenchido, traz só as OS em aberto com conclusão prevista antes dele.
+	DueBefore *time.Time
+	// PerAsset, quando maior que zero, traz só as PerAsset OS mais recentes de
+	// cada ativo (depois dos demais filtros).
+	PerAsset int
+}
+
+// Match aplica o filtro em memória (mesma semântica do SQL do repositório postgres).
+func (f WorkOrderFilter) Match(o *WorkOrder) bool {
+	if f.Status != "" && o.Status != f.Status {
+		return false
+	}
+	if f.Type != "" && o.Type != f.Type {
+		return false
+	}
+	if f.AssetID != 0 && o.AssetID != f.AssetID {
+		return false
+	}
+	if len(f.AssetIDs) > 0 && !slices.Contains(f.AssetIDs, o.AssetID) {
+		return false
+	}
+	if f.PlanID != 0 && (o.PlanID == nil || *o.PlanID != f.PlanID) {
+		return false
+	}
+	if f.Open && !o.IsOpen() {
+		return false
+	}
+	if f.From != nil && o.CreatedAt.Before(*f.From) {
+		return false
+	}
+	if f.To != nil && !o.CreatedAt.Before(*f.To) {
+		return false
+	}
+	if f.OverdueAt != nil && !o.IsOverdue(*f.OverdueAt) {
+		return false
+	}
+	if f.DueBefore != nil && (!o.IsOpen() || o.ResolutionDueAt == nil || !o.ResolutionDueAt.Before(*f.DueBefore)) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord guarda a resposta de um POST enviado com Idempotency-Key,
+// para devolvê-la quando o cliente repete a mesma requisição. Enquanto o
+// primeiro pedido roda, o registro fica em andamento (Status 0) e ExpiresAt é
+// o prazo da reserva; depois de concluído, ExpiresAt é o fim da retenção.
+type IdempotencyRecord struct {
+	UserID      int64
+	Key         string
+	RequestHash string // método, caminho e corpo
+	Status      int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// Done indica que a resposta original já foi gravada.
+func (r *IdempotencyRecord) Done() bool { return r.Status != 0 }
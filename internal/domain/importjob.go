@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// ImportKind identifica o que está sendo importado.
+type ImportKind string
+
+const (
+	ImportAssets     ImportKind = "assets"
+	ImportWorkOrders ImportKind = "work_orders"
+)
+
+// ImportStatus acompanha o ciclo de vida de um job de importação.
+type ImportStatus string
+
+const (
+	ImportStatusPending   ImportStatus = "pending"
+	ImportStatusRunning   ImportStatus = "running"
+	ImportStatusValidated ImportStatus = "validated" // dry-run concluído
+	ImportStatusDone      ImportStatus = "done"
+	ImportStatusFailed    ImportStatus = "failed"
+)
+
+// ImportRowError descreve um problema em uma linha da planilha (linha 1 = cabeçalho).
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportJob struct {
+	ID         string           `json:"id"`
+	SiteID     int64            `json:"site_id"` // site de quem enviou; só ele acompanha o job
+	Kind       ImportKind       `json:"kind"`
+	DryRun     bool             `json:"dry_run"`
+	Status     ImportStatus     `json:"status"`
+	TotalRows  int              `json:"total_rows"`
+	Processed  int              `json:"processed"` // linhas validadas/gravadas até agora
+	Inserted   int64            `json:"inserted"`
+	ErrorCount int              `json:"error_count"`
+	Errors     []ImportRowError `json:"errors,omitempty"` // limitado às primeiras ocorrências
+	Failure    string           `json:"failure,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+// Finished indica se o job não vai mais mudar.
+func (j *ImportJob) Finished() bool {
+	return j.Status == ImportStatusValidated || j.Status == ImportStatusDone || j.Status == ImportStatusFailed
+}
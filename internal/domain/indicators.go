@@ -0,0 +1,17 @@
+package domain
+
+// WorkOrderCount agrega OS abertas por status, tipo e criticidade do ativo.
+type WorkOrderCount struct {
+	Status      WorkOrderStatus `json:"status"`
+	Type        WorkOrderType   `json:"type"`
+	Criticality Criticality     `json:"criticality"`
+	Count       int64           `json:"count"`
+}
+
+// Indicators é a fotografia dos indicadores de manutenção expostos em /metrics.
+type Indicators struct {
+	OpenWorkOrders []WorkOrderCount `json:"open_work_orders"` // status open|in_progress
+	OverduePlans   int64            `json:"overdue_plans"`    // preventivas por tempo vencidas
+	AssetsDown     int64            `json:"assets_down"`      // ativos com parada em andamento
+	OverdueOrders  int64            `json:"overdue_orders"`   // OS em aberto com SLA vencido
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MeasurementSpec é a medição esperada em um passo, com tolerâncias opcionais.
+// FollowUp abre uma corretiva automaticamente quando o valor sai da faixa.
+type MeasurementSpec struct {
+	Unit     string   `json:"unit"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	FollowUp bool     `json:"follow_up"`
+}
+
+// Within indica se v está dentro da tolerância (limites inclusivos).
+func (m *MeasurementSpec) Within(v float64) bool {
+	return (m.Min == nil || v >= *m.Min) && (m.Max == nil || v <= *m.Max)
+}
+
+// JobPlanStep é um passo do roteiro; Seq define a ordem (1, 2, 3...).
+type JobPlanStep struct {
+	Seq         int              `json:"seq"`
+	Description string           `json:"description"`
+	Measurement *MeasurementSpec `json:"measurement,omitempty"`
+}
+
+// JobPlanPart é uma peça prevista para a execução.
+type JobPlanPart struct {
+	SparePartID int64   `json:"spare_part_id"`
+	Quantity    float64 `json:"quantity"`
+}
+
+// JobPlan é o roteiro padrão de uma preventiva: passos, medições, peças e duração.
+type JobPlan struct {
+	ID               int64         `json:"id"`
+	SiteID           int64         `json:"site_id"`
+	Name             string        `json:"name"`
+	Description      string        `json:"description,omitempty"`
+	Trade            string        `json:"trade,omitempty"`
+	EstimatedMinutes *int64        `json:"estimated_minutes,omitempty"`
+	Steps            []JobPlanStep `json:"steps"`
+	Parts            []JobPlanPart `json:"parts"`
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+}
+
+// Normalize renumera os passos na ordem recebida e padroniza textos.
+func (j *JobPlan) Normalize() {
+	j.Name = strings.TrimSpace(j.Name)
+	j.Trade = NormalizeTrade(j.Trade)
+	for i := range j.Steps {
+		j.Steps[i].Seq = i + 1
+		j.Steps[i].Description = strings.TrimSpace(j.Steps[i].Description)
+		if m := j.Steps[i].Measurement; m != nil {
+			m.Unit = strings.TrimSpace(m.Unit)
+		}
+	}
+	if j.Parts == nil {
+		j.Parts = []JobPlanPart{}
+	}
+}
+
+func (j *JobPlan) Validate() error {
+	if j.Name == "" || len(j.Steps) == 0 {
+		return ErrInvalidInput
+	}
+	if j.EstimatedMinutes != nil && *j.EstimatedMinutes <= 0 {
+		return ErrInvalidInput
+	}
+	for _, s := range j.Steps {
+		if s.Description == "" {
+			return ErrInvalidInput
+		}
+		if m := s.Measurement; m != nil && m.Min != nil && m.Max != nil && *m.Min > *m.Max {
+			return ErrInvalidInput
+		}
+	}
+	seen := map[int64]bool{}
+	for _, p := range j.Parts {
+		if p.SparePartID <= 0 || p.Quantity <= 0 || seen[p.SparePartID] {
+			return ErrInvalidInput
+		}
+		seen[p.SparePartID] = true
+	}
+	return nil
+}
+
+// Checklist copia os passos do roteiro para uma OS.
+func (j *JobPlan) Checklist() []ChecklistItem {
+	items := make([]ChecklistItem, len(j.Steps))
+	for i, s := range j.Steps {
+		items[i] = ChecklistItem{Step: s.Seq, Description: s.Description}
+		if s.Measurement != nil {
+			m := *s.Measurement
+			items[i].Measurement = &m
+		}
+	}
+	return items
+}
+
+// ChecklistItem é um passo do roteiro dentro da OS, preenchido pelo técnico.
+type ChecklistItem struct {
+	Step           int              `json:"step"`
+	Description    string           `json:"description"`
+	Measurement    *MeasurementSpec `json:"measurement,omitempty"`
+	Done           bool             `json:"done"`
+	Value          *float64         `json:"value,omitempty"`
+	Note           string           `json:"note,omitempty"`
+	OutOfTolerance bool             `json:"out_of_tolerance"`
+	FollowUpID     *int64           `json:"follow_up_id,omitempty"` // corretiva aberta pela medição
+	CompletedAt    *time.Time       `json:"completed_at,omitempty"`
+	UpdatedAt      time.Time        `json:"-"`
+	Revision       int64            `json:"-"` // revisão de sincronização (ver SyncChange)
+}
+
+// Record aplica o apontamento do técnico: passos com medição só concluem com valor.
+func (c *ChecklistItem) Record(done bool, value *float64, note string, now time.Time) error {
+	if c.Measurement == nil && value != nil {
+		return ErrInvalidInput
+	}
+	if value != nil {
+		v := *value
+		c.Value = &v
+	}
+	if done && c.Measurement != nil && c.Value == nil {
+		return ErrInvalidInput
+	}
+	if note != "" {
+		c.Note = strings.TrimSpace(note)
+	}
+	c.OutOfTolerance = c.Measurement != nil && c.Value != nil && !c.Measurement.Within(*c.Value)
+	c.Done = done
+	c.CompletedAt = nil
+	if done {
+		c.CompletedAt = &now
+	}
+	return nil
+}
+
+// ChecklistComplete indica se todos os passos foram concluídos.
+func ChecklistComplete(items []ChecklistItem) bool {
+	for _, it := range items {
+		if !it.Done {
+			return false
+		}
+	}
+	return true
+}
@@ -13,18 +13,99 @@ const (
 
 type MaintenancePlan struct {
 	ID            int64        `json:"id"`
+	SiteID        int64        `json:"site_id"` // sempre o site do ativo
 	AssetID       int64        `json:"asset_id"`
 	RuleType      PlanRuleType `json:"rule_type"`                // time|meter|condition
 	FrequencyDays *int64       `json:"frequency_days,omitempty"` // para "time"
 	MeterTarget   *int64       `json:"meter_target,omitempty"`   // para "meter" (se for usar)
 	LastExecution *time.Time   `json:"last_execution,omitempty"`
-	Active        bool         `json:"active"`
-	CreatedAt     time.Time    `json:"created_at"`
-	UpdatedAt     time.Time    `json:"updated_at"`
+	// Para "condition": a medição acompanhada (ex: vibration) e a faixa aceitável;
+	// leitura fora dela abre a OS de condição. Sem parâmetro, o plano não é disparado por sinal.
+	ConditionParameter string   `json:"condition_parameter,omitempty"`
+	ConditionMin       *float64 `json:"condition_min,omitempty"`
+	ConditionMax       *float64 `json:"condition_max,omitempty"`
+	// Copiados para as OS geradas (roteiro e planejamento de capacidade).
+	JobPlanID        *int64    `json:"job_plan_id,omitempty"`
+	Trade            string    `json:"trade,omitempty"`
+	EstimatedMinutes *int64    `json:"estimated_minutes,omitempty"`
+	Active           bool      `json:"active"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
 }
 
 func (p *MaintenancePlan) Normalize() {
 	if !p.Active {
 		p.Active = true
 	}
+	p.Trade = NormalizeTrade(p.Trade)
+	p.ConditionParameter = NormalizeParameter(p.ConditionParameter)
+}
+
+// Validate exige frequência positiva nos planos por tempo, meta nos planos por
+// uso e, nos planos por condição com parâmetro, ao menos um limite (mín < máx).
+func (p *MaintenancePlan) Validate() error {
+	limits := p.ConditionMin != nil || p.ConditionMax != nil
+	if p.RuleType != PlanRuleCondition && (p.ConditionParameter != "" || limits) {
+		return ErrInvalidInput
+	}
+	switch p.RuleType {
+	case PlanRuleTime:
+		if p.FrequencyDays == nil || *p.FrequencyDays <= 0 {
+			return ErrInvalidInput
+		}
+	case PlanRuleMeter:
+		if p.MeterTarget == nil || *p.MeterTarget <= 0 {
+			return ErrInvalidInput
+		}
+	case PlanRuleCondition:
+		if (p.ConditionParameter == "") == limits {
+			return ErrInvalidInput
+		}
+		if p.ConditionMin != nil && p.ConditionMax != nil && *p.ConditionMin >= *p.ConditionMax {
+			return ErrInvalidInput
+		}
+	default:
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// NextDue retorna quando a próxima preventiva vence (apenas planos por tempo).
+// Sem execução registrada, conta a partir da criação do plano.
+func (p *MaintenancePlan) NextDue() (time.Time, bool) {
+	if p.RuleType != PlanRuleTime || p.FrequencyDays == nil {
+		return time.Time{}, false
+	}
+	base := p.CreatedAt
+	if p.LastExecution != nil {
+		base = *p.LastExecution
+	}
+	return base.AddDate(0, 0, int(*p.FrequencyDays)), true
+}
+
+// IsOverdue indica se um plano ativo passou do vencimento em "now".
+func (p *MaintenancePlan) IsOverdue(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	due, ok := p.NextDue()
+	return ok && due.Before(now)
+}
+
+// OutOfLimits diz se a medição do parâmetro do plano por condição saiu da faixa aceitável.
+func (p *MaintenancePlan) OutOfLimits(parameter string, value float64) bool {
+	if p.RuleType != PlanRuleCondition || p.ConditionParameter == "" || p.ConditionParameter != parameter {
+		return false
+	}
+	return (p.ConditionMin != nil && value < *p.ConditionMin) || (p.ConditionMax != nil && value > *p.ConditionMax)
+}
+
+// PreventiveOccurrence é uma preventiva prevista na agenda: a OS já gerada pelo
+// agendador (WorkOrderID preenchido) ou uma projeção das próximas execuções do plano.
+type PreventiveOccurrence struct {
+	PlanID       int64     `json:"plan_id"`
+	AssetID      int64     `json:"asset_id"`
+	WorkOrderID  *int64    `json:"work_order_id,omitempty"`
+	Title        string    `json:"title"`
+	ScheduledFor time.Time `json:"scheduled_for"`
 }
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// NotificationEvent é o gatilho que abre um alerta.
+type NotificationEvent string
+
+const (
+	EventCriticalBreakdown NotificationEvent = "critical_breakdown" // corretiva nova em ativo criticidade A
+	EventSLAAtRisk         NotificationEvent = "sla_at_risk"        // OS em aberto perto do prazo de conclusão
+	EventPreventiveOverdue NotificationEvent = "preventive_overdue" // preventiva com SLA estourado
+	EventLowStock          NotificationEvent = "low_stock"          // peça abaixo do estoque mínimo
+)
+
+func (e NotificationEvent) Valid() bool {
+	switch e {
+	case EventCriticalBreakdown, EventSLAAtRisk, EventPreventiveOverdue, EventLowStock:
+		return true
+	}
+	return false
+}
+
+// NotificationChannel é o meio de entrega escolhido pelo usuário.
+type NotificationChannel string
+
+const (
+	ChannelEmail NotificationChannel = "email" // SMTP
+	ChannelSMS   NotificationChannel = "sms"   // gateway HTTP de SMS
+	ChannelChat  NotificationChannel = "chat"  // webhook de chat (Slack, Teams, Telegram)
+	ChannelLog   NotificationChannel = "log"   // só registra no log (testes e desenvolvimento)
+)
+
+func (c NotificationChannel) Valid() bool {
+	switch c {
+	case ChannelEmail, ChannelSMS, ChannelChat, ChannelLog:
+		return true
+	}
+	return false
+}
+
+// User é um destinatário de notificações, com os contatos de cada canal, e quem
+// acessa a API (ver auth). Corporate libera os relatórios de todos os sites.
+type User struct {
+	ID        int64  `json:"id"`
+	SiteID    int64  `json:"site_id"`
+	Name      string `json:"name"`
+	Email     string `json:"email,omitempty"`
+	Phone     string `json:"phone,omitempty"`   // SMS, formato E.164
+	ChatID    string `json:"chat_id,omitempty"` // id do chat (ex: Telegram) ou menção no Slack/Teams
+	Active    bool   `json:"active"`
+	Corporate bool   `json:"corporate"`
+	// Preferences escolhe os canais por evento; evento ausente usa e-mail e lista vazia silencia o evento.
+	Preferences map[NotificationEvent][]NotificationChannel `json:"preferences"`
+	CreatedAt   time.Time                                   `json:"created_at"`
+	UpdatedAt   time.Time                                   `json:"updated_at"`
+}
+
+func (u *User) Normalize() {
+	u.Name = strings.TrimSpace(u.Name)
+	u.Email = strings.TrimSpace(u.Email)
+	u.Phone = strings.TrimSpace(u.Phone)
+	u.ChatID = strings.TrimSpace(u.ChatID)
+	if u.Preferences == nil {
+		u.Preferences = map[NotificationEvent][]NotificationChannel{}
+	}
+}
+
+func (u *User) Validate() error {
+	if u.Name == "" {
+		return ErrInvalidInput
+	}
+	for event, channels := range u.Preferences {
+		if !event.Valid() {
+			return ErrInvalidInput
+		}
+		for _, ch := range channels {
+			if !ch.Valid() {
+				return ErrInvalidInput
+			}
+		}
+	}
+	return nil
+}
+
+// ChannelsFor retorna os canais em que o usuário quer receber o evento.
+func (u *User) ChannelsFor(event NotificationEvent) []NotificationChannel {
+	if channels, ok := u.Preferences[event]; ok {
+		return channels
+	}
+	if u.Email != "" {
+		return []NotificationChannel{ChannelEmail}
+	}
+	return nil
+}
+
+// EscalationRule é um nível de escalonamento: se o alerta não for reconhecido
+// em DelayMinutes (contados do disparo), os usuários do nível são avisados.
+type EscalationRule struct {
+	SiteID       int64             `json:"site_id"`
+	Event        NotificationEvent `json:"event"`
+	Tier         int               `json:"tier"` // 1 = primeiro nível
+	DelayMinutes int64             `json:"delay_minutes"`
+	UserIDs      []int64           `json:"user_ids"`
+}
+
+func (r *EscalationRule) Validate() error {
+	if !r.Event.Valid() || r.Tier < 1 || r.DelayMinutes < 0 || len(r.UserIDs) == 0 {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// AlertRefType identifica o registro que originou o alerta.
+type AlertRefType string
+
+const (
+	AlertRefWorkOrder AlertRefType = "work_order"
+	AlertRefSparePart AlertRefType = "spare_part"
+)
+
+// Alert é uma ocorrência de um evento. Há no máximo um alerta não resolvido por
+// evento e referência; o escalonamento para quando alguém reconhece (ack) ou
+// quando a condição some (resolved, ex: OS fechada, estoque reposto).
+type Alert struct {
+	ID      int64             `json:"id"`
+	SiteID  int64             `json:"site_id"` // site da referência (OS ou peça)
+	Event   NotificationEvent `json:"event"`
+	RefType AlertRefType      `json:"ref_type"`
+	RefID   int64             `json:"ref_id"`
+	Message string            `json:"message"`
+	// TriggeredAt é o instante do evento (ex: a quebra); os atrasos de escalonamento contam a partir dele.
+	TriggeredAt time.Time  `json:"triggered_at"`
+	Tier        int        `json:"tier"` // último nível avisado (0 = nenhum)
+	AckedAt     *time.Time `json:"acked_at,omitempty"`
+	AckedBy     *int64     `json:"acked_by,omitempty"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// Escalating indica se o alerta ainda pode subir de nível.
+func (a *Alert) Escalating() bool {
+	return a.AckedAt == nil && a.ResolvedAt == nil
+}
@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// OEEGranularity define o agrupamento temporal do relatório de OEE.
+type OEEGranularity string
+
+const (
+	OEEByShift OEEGranularity = "shift"
+	OEEByDay   OEEGranularity = "day"
+	OEEByWeek  OEEGranularity = "week"
+)
+
+func (g OEEGranularity) Valid() bool {
+	switch g {
+	case OEEByShift, OEEByDay, OEEByWeek:
+		return true
+	}
+	return false
+}
+
+// OEEFilter restringe o relatório a um ativo e/ou linha; campos vazios não filtram.
+type OEEFilter struct {
+	AssetID  int64
+	Location string
+}
+
+// Match aplica o filtro em memória (mesma semântica do SQL do repositório postgres).
+func (f OEEFilter) Match(a *Asset) bool {
+	if f.AssetID != 0 && a.ID != f.AssetID {
+		return false
+	}
+	return f.Location == "" || a.Location == f.Location
+}
+
+// OEEInputs reúne os dados brutos de um período para o cálculo do OEE.
+type OEEInputs struct {
+	Assets   []Asset
+	Shifts   []Shift
+	Downtime []DowntimeEvent   // paradas que interceptam o período
+	Counts   []ProductionCount // apontamentos que interceptam o período
+}
+
+// OEERow é o OEE de um ativo (ou de uma linha, com AssetID zero) em um período.
+// Os índices ficam nulos quando não há base de cálculo (sem turno, sem taxa
+// ideal cadastrada ou sem produção apontada).
+type OEERow struct {
+	Period          string    `json:"period"` // 2025-01-06, 2025-W02 ou "2025-01-06 Turno A"
+	PeriodStart     time.Time `json:"period_start"`
+	Shift           string    `json:"shift,omitempty"`
+	AssetID         int64     `json:"asset_id,omitempty"`
+	AssetName       string    `json:"asset_name,omitempty"`
+	Location        string    `json:"location"`
+	PlannedMinutes  float64   `json:"planned_minutes"`  // turno - pausas - paradas programadas
+	DowntimeMinutes float64   `json:"downtime_minutes"` // paradas não programadas dentro do turno
+	RunMinutes      float64   `json:"run_minutes"`
+	TotalCount      int64     `json:"total_count"`
+	GoodCount       int64     `json:"good_count"`
+	Availability    *float64  `json:"availability"`
+	Performance     *float64  `json:"performance"`
+	Quality         *float64  `json:"quality"`
+	OEE             *float64  `json:"oee"`
+}
+
+type OEEReport struct {
+	Granularity OEEGranularity `json:"granularity"`
+	From        time.Time      `json:"from"`
+	To          time.Time      `json:"to"`
+	Assets      []OEERow       `json:"assets"`
+	Locations   []OEERow       `json:"locations"` // consolidado por linha
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultEstimateMinutes é o esforço assumido para OS sem estimativa.
+const DefaultEstimateMinutes = 120
+
+// NormalizeTrade padroniza a especialidade (minúsculas, sem espaços nas pontas).
+func NormalizeTrade(t string) string {
+	return strings.ToLower(strings.TrimSpace(t))
+}
+
+// EstimatedWork é o esforço estimado da OS (DefaultEstimateMinutes sem estimativa).
+func (wo *WorkOrder) EstimatedWork() time.Duration {
+	if wo.EstimatedMinutes == nil {
+		return DefaultEstimateMinutes * time.Minute
+	}
+	return time.Duration(*wo.EstimatedMinutes) * time.Minute
+}
+
+// Technician é um técnico de manutenção: especialidades e turno de trabalho.
+type Technician struct {
+	ID        int64     `json:"id"`
+	SiteID    int64     `json:"site_id"` // sempre o site do turno
+	Name      string    `json:"name"`
+	Skills    []string  `json:"skills"`
+	ShiftID   int64     `json:"shift_id"`
+	UserID    *int64    `json:"user_id,omitempty"` // usuário do app offline (vê só as OS atribuídas ao técnico)
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Normalize padroniza as especialidades e remove repetições.
+func (t *Technician) Normalize() {
+	t.Name = strings.TrimSpace(t.Name)
+	seen := map[string]bool{}
+	skills := make([]string, 0, len(t.Skills))
+	for _, s := range t.Skills {
+		s = NormalizeTrade(s)
+		if s != "" && !seen[s] {
+			seen[s] = true
+			skills = append(skills, s)
+		}
+	}
+	sort.Strings(skills)
+	t.Skills = skills
+}
+
+func (t *Technician) Validate() error {
+	if t.Name == "" || t.ShiftID <= 0 {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// HasSkill indica se o técnico atende a especialidade; OS sem especialidade aceitam qualquer técnico.
+func (t *Technician) HasSkill(trade string) bool {
+	if trade == "" {
+		return true
+	}
+	for _, s := range t.Skills {
+		if s == trade {
+			return true
+		}
+	}
+	return false
+}
+
+// BacklogRow agrega as OS em aberto de uma semana por especialidade e criticidade do ativo.
+type BacklogRow struct {
+	Week           string      `json:"week"` // segunda-feira da semana (AAAA-MM-DD)
+	Trade          string      `json:"trade"`
+	Criticality    Criticality `json:"criticality"`
+	Orders         int64       `json:"orders"`
+	EstimatedHours float64     `json:"estimated_hours"`
+}
+
+// CapacityRow compara a carga da semana com as horas disponíveis dos técnicos da especialidade.
+// Técnicos com várias especialidades contam na capacidade de cada uma.
+type CapacityRow struct {
+	Week           string  `json:"week"`
+	Trade          string  `json:"trade"`
+	Technicians    int64   `json:"technicians"`
+	AvailableHours float64 `json:"available_hours"`
+	DemandHours    float64 `json:"demand_hours"`
+	BalanceHours   float64 `json:"balance_hours"` // negativo = carga acima da capacidade
+}
+
+// BacklogReport é a visão de backlog × capacidade do período.
+// OS sem data programada (ou atrasadas) entram na primeira semana.
+type BacklogReport struct {
+	From        time.Time     `json:"from"`
+	To          time.Time     `json:"to"`
+	Rows        []BacklogRow  `json:"rows"`
+	Capacity    []CapacityRow `json:"capacity"`
+	Unestimated int64         `json:"unestimated"` // OS contadas com DefaultEstimateMinutes
+}
+
+// Assignment é a proposta de execução de uma OS por um técnico.
+// O trabalho pode ser dividido em vários turnos: Start é o início do primeiro, End o fim do último.
+type Assignment struct {
+	WorkOrderID    int64     `json:"work_order_id"`
+	AssetID        int64     `json:"asset_id"`
+	Title          string    `json:"title"`
+	Trade          string    `json:"trade,omitempty"`
+	Priority       Priority  `json:"priority,omitempty"`
+	TechnicianID   int64     `json:"technician_id"`
+	TechnicianName string    `json:"technician_name"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+	EstimatedHours float64   `json:"estimated_hours"`
+	LateForSLA     bool      `json:"late_for_sla"` // termina depois do prazo de conclusão
+}
+
+// UnassignedReason explica por que a OS ficou fora da proposta.
+type UnassignedReason string
+
+const (
+	UnassignedNoSkill    UnassignedReason = "no_skill"    // nenhum técnico ativo com a especialidade
+	UnassignedNoCapacity UnassignedReason = "no_capacity" // não coube no horizonte
+)
+
+type UnassignedOrder struct {
+	WorkOrderID int64            `json:"work_order_id"`
+	Trade       string           `json:"trade,omitempty"`
+	Reason      UnassignedReason `json:"reason"`
+}
+
+// ScheduleProposal é o resultado do sequenciamento; nada é gravado nas OS.
+type ScheduleProposal struct {
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Assignments []Assignment      `json:"assignments"`
+	Unassigned  []UnassignedOrder `json:"unassigned"`
+}
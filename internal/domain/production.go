@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// ProductionCount é um apontamento de produção do ativo em um intervalo
+// (MES, CLP ou digitado). GoodCount são as peças aprovadas de TotalCount.
+type ProductionCount struct {
+	ID          int64     `json:"id"`
+	AssetID     int64     `json:"asset_id"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	TotalCount  int64     `json:"total_count"`
+	GoodCount   int64     `json:"good_count"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (p *ProductionCount) Validate() error {
+	if p.AssetID <= 0 || !p.PeriodEnd.After(p.PeriodStart) {
+		return ErrInvalidInput
+	}
+	if p.TotalCount < 0 || p.GoodCount < 0 || p.GoodCount > p.TotalCount {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// Midpoint decide em qual turno o apontamento é contabilizado.
+func (p *ProductionCount) Midpoint() time.Time {
+	return p.PeriodStart.Add(p.PeriodEnd.Sub(p.PeriodStart) / 2)
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimit é a cota de um cliente (token bucket): rajadas de até Requests
+// requisições, com as fichas repostas continuamente à razão de Requests a cada Per.
+type RateLimit struct {
+	Requests int
+	Per      time.Duration
+}
+
+// Rate é a reposição em fichas por segundo.
+func (l RateLimit) Rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// Status monta o resultado a partir das fichas que sobraram no balde.
+func (l RateLimit) Status(tokens float64, allowed bool) RateLimitStatus {
+	st := RateLimitStatus{
+		Allowed:   allowed,
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     seconds((float64(l.Requests) - tokens) / l.Rate()),
+	}
+	if !allowed {
+		st.RetryAfter = seconds((1 - tokens) / l.Rate())
+	}
+	return st
+}
+
+// RateLimitStatus é o resultado de consumir uma ficha do balde.
+type RateLimitStatus struct {
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration // até o balde encher de novo
+	RetryAfter time.Duration // até a próxima ficha, quando negada
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Max(0, s) * float64(time.Second))
+}
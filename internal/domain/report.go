@@ -0,0 +1,60 @@
+package domain
+
+// DowntimeReportRow consolida as paradas de um ativo em um mês (AAAA-MM, fuso da planta).
+// Fontes: paradas encerradas (downtime_events) e, para o histórico sem paradas
+// apontadas, o DowntimeMinutes das próprias OS.
+type DowntimeReportRow struct {
+	Month           string `json:"month"`
+	AssetID         int64  `json:"asset_id"`
+	AssetName       string `json:"asset_name"`
+	Location        string `json:"location,omitempty"`
+	Breakdowns      int64  `json:"breakdowns"`       // paradas não programadas
+	DowntimeMinutes int64  `json:"downtime_minutes"` // minutos de paradas não programadas
+	PlannedMinutes  int64  `json:"planned_minutes"`  // minutos de paradas programadas
+}
+
+// ParetoSort define o critério do ranking de modos de falha.
+type ParetoSort string
+
+const (
+	ParetoByCount    ParetoSort = "count"
+	ParetoByDowntime ParetoSort = "downtime"
+)
+
+// ParetoFilter restringe o Pareto a um ativo e/ou linha; campos vazios não filtram.
+type ParetoFilter struct {
+	AssetID  int64
+	Location string
+}
+
+// ParetoRow agrega as OS concluídas de um modo de falha.
+type ParetoRow struct {
+	FailureModeID   int64   `json:"failure_mode_id"`
+	Code            string  `json:"code"`
+	Name            string  `json:"name"`
+	Count           int64   `json:"count"`
+	DowntimeMinutes int64   `json:"downtime_minutes"`
+	Share           float64 `json:"share"`            // participação no critério do ranking
+	CumulativeShare float64 `json:"cumulative_share"` // acumulado (curva de Pareto)
+}
+
+type ParetoReport struct {
+	SortBy ParetoSort  `json:"sort_by"`
+	Rows   []ParetoRow `json:"rows"`
+	// Unclassified conta as OS concluídas sem modo de falha (fora do ranking).
+	Unclassified int64 `json:"unclassified"`
+}
+
+// SiteReportRow compara as plantas no período (relatório corporativo): paradas
+// somadas de todos os ativos e cumprimento de SLA das OS abertas no período.
+type SiteReportRow struct {
+	SiteID               int64    `json:"site_id"`
+	Code                 string   `json:"code"`
+	Name                 string   `json:"name"`
+	Breakdowns           int64    `json:"breakdowns"`
+	DowntimeMinutes      int64    `json:"downtime_minutes"`
+	PlannedMinutes       int64    `json:"planned_minutes"`
+	SLAOrders            int64    `json:"sla_orders"`
+	ResponseCompliance   *float64 `json:"response_compliance"`
+	ResolutionCompliance *float64 `json:"resolution_compliance"`
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// RequestStatus é o estado da solicitação de manutenção aberta pelo operador.
+type RequestStatus string
+
+const (
+	RequestSubmitted RequestStatus = "submitted" // aguardando triagem
+	RequestTriaged   RequestStatus = "triaged"   // revisada pela manutenção, aguardando decisão
+	RequestAccepted  RequestStatus = "accepted"  // virou OS (WorkOrderID)
+	RequestRejected  RequestStatus = "rejected"  // descartada (Resolution traz o motivo)
+	RequestMerged    RequestStatus = "merged"    // duplicada de uma OS em aberto (WorkOrderID)
+)
+
+// MaintenanceRequest é o pedido do chão de fábrica. Só vira OS depois da triagem,
+// o que evita duplicadas e títulos vagos no backlog.
+type MaintenanceRequest struct {
+	ID          int64         `json:"id"`
+	SiteID      int64         `json:"site_id"` // sempre o site do ativo
+	AssetID     int64         `json:"asset_id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description,omitempty"`
+	RequestedBy string        `json:"requested_by,omitempty"`
+	BreakdownAt *time.Time    `json:"breakdown_at,omitempty"` // máquina parada desde
+	Status      RequestStatus `json:"status"`
+	// TriageNote é a observação da triagem; Resolution, o motivo da rejeição ou da junção.
+	TriageNote  string     `json:"triage_note,omitempty"`
+	Resolution  string     `json:"resolution,omitempty"`
+	WorkOrderID *int64     `json:"work_order_id,omitempty"`
+	TriagedAt   *time.Time `json:"triaged_at,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func (r *MaintenanceRequest) Normalize() {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Description = strings.TrimSpace(r.Description)
+	r.RequestedBy = strings.TrimSpace(r.RequestedBy)
+	if r.Status == "" {
+		r.Status = RequestSubmitted
+	}
+}
+
+// requestTransitions: a decisão (aceitar, rejeitar, juntar) só vem depois da triagem.
+var requestTransitions = map[RequestStatus][]RequestStatus{
+	RequestSubmitted: {RequestTriaged},
+	RequestTriaged:   {RequestAccepted, RequestRejected, RequestMerged},
+}
+
+func (r *MaintenanceRequest) CanTransition(to RequestStatus) bool {
+	for _, s := range requestTransitions[r.Status] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPending indica solicitação ainda sem decisão.
+func (r *MaintenanceRequest) IsPending() bool {
+	return r.Status == RequestSubmitted || r.Status == RequestTriaged
+}
+
+// RequestFilter restringe a listagem de solicitações; campos vazios não filtram.
+type RequestFilter struct {
+	Status  RequestStatus
+	AssetID int64
+}
+
+func (f RequestFilter) Match(r *MaintenanceRequest) bool {
+	if f.Status != "" && r.Status != f.Status {
+		return false
+	}
+	return f.AssetID == 0 || r.AssetID == f.AssetID
+}
+
+// DuplicateCandidate é uma OS em aberto no mesmo ativo com título parecido.
+// Score vai de 0 a 1 (proporção de palavras em comum).
+type DuplicateCandidate struct {
+	WorkOrderID int64           `json:"work_order_id"`
+	Title       string          `json:"title"`
+	Status      WorkOrderStatus `json:"status"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Score       float64         `json:"score"`
+}
+
+// RequestSubmission é a resposta do envio: a solicitação e as possíveis duplicadas.
+type RequestSubmission struct {
+	Request    MaintenanceRequest   `json:"request"`
+	Duplicates []DuplicateCandidate `json:"duplicates"`
+}
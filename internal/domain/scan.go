@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// ScanResult é o que o operador vê ao ler a etiqueta do ativo: o resumo do
+// ativo, as OS em aberto, os planos ativos e uma solicitação de quebra pré-preenchida.
+type ScanResult struct {
+	Tag              string           `json:"tag"`
+	Asset            Asset            `json:"asset"`
+	OpenWorkOrders   []WorkOrder      `json:"open_work_orders"`
+	Plans            []ScanPlan       `json:"plans"`
+	BreakdownRequest BreakdownRequest `json:"breakdown_request"`
+}
+
+// ScanPlan é um plano ativo do ativo com a próxima data prevista (planos por tempo).
+type ScanPlan struct {
+	MaintenancePlan
+	NextDue *time.Time `json:"next_due,omitempty"`
+	Overdue bool       `json:"overdue"`
+}
+
+// BreakdownRequest é o corpo sugerido ao reportar uma quebra: serve para POST /requests
+// (portal, type é ignorado) e para POST /work-orders.
+type BreakdownRequest struct {
+	AssetID     int64         `json:"asset_id"`
+	Type        WorkOrderType `json:"type"`
+	Title       string        `json:"title"`
+	BreakdownAt time.Time     `json:"breakdown_at"`
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Shift é um turno de produção recorrente (calendário da planta).
+// Location vazio vale para a planta toda; se a linha tiver turnos próprios,
+// eles substituem os da planta para os ativos daquela linha.
+type Shift struct {
+	ID           int64     `json:"id"`
+	SiteID       int64     `json:"site_id"`
+	Name         string    `json:"name"`
+	Location     string    `json:"location,omitempty"`
+	StartTime    string    `json:"start_time"`    // HH:MM no fuso da planta
+	EndTime      string    `json:"end_time"`      // HH:MM; igual ou antes do início = vira o dia
+	Weekdays     []int     `json:"weekdays"`      // dias em que o turno começa: 0=domingo … 6=sábado
+	BreakMinutes int       `json:"break_minutes"` // refeição/pausas, fora do tempo planejado
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// ShiftWindow é uma ocorrência concreta do turno.
+type ShiftWindow struct {
+	Shift *Shift
+	Start time.Time
+	End   time.Time
+}
+
+// Validate confere horários, dias da semana e pausa.
+func (s *Shift) Validate() error {
+	if _, err := clockMinutes(s.StartTime); err != nil {
+		return ErrInvalidInput
+	}
+	if _, err := clockMinutes(s.EndTime); err != nil {
+		return ErrInvalidInput
+	}
+	if len(s.Weekdays) == 0 {
+		return ErrInvalidInput
+	}
+	for _, d := range s.Weekdays {
+		if d < 0 || d > 6 {
+			return ErrInvalidInput
+		}
+	}
+	if s.BreakMinutes < 0 || time.Duration(s.BreakMinutes)*time.Minute >= s.Duration() {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// Duration é a duração nominal do turno, pausas incluídas.
+func (s *Shift) Duration() time.Duration {
+	start, _ := clockMinutes(s.StartTime)
+	end, _ := clockMinutes(s.EndTime)
+	if end <= start {
+		end += 24 * 60
+	}
+	return time.Duration(end-start) * time.Minute
+}
+
+// Windows lista as ocorrências do turno que interceptam [from, to), sem recorte.
+func (s *Shift) Windows(from, to time.Time, loc *time.Location) []ShiftWindow {
+	start, err := clockMinutes(s.StartTime)
+	if err != nil {
+		return nil
+	}
+	days := map[time.Weekday]bool{}
+	for _, d := range s.Weekdays {
+		days[time.Weekday(d)] = true
+	}
+
+	var windows []ShiftWindow
+	f := from.In(loc)
+	// Começa um dia antes para pegar o turno da noite que vira para dentro do período.
+	day := time.Date(f.Year(), f.Month(), f.Day()-1, 0, 0, 0, 0, loc)
+	for ; day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !days[day.Weekday()] {
+			continue
+		}
+		ws := time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, loc)
+		we := ws.Add(s.Duration())
+		if ws.Before(to) && from.Before(we) {
+			windows = append(windows, ShiftWindow{Shift: s, Start: ws, End: we})
+		}
+	}
+	return windows
+}
+
+// clockMinutes converte "HH:MM" em minutos desde a meia-noite.
+func clockMinutes(v string) (int, error) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, fmt.Errorf("horário inválido %q: %w", v, err)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
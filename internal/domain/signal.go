@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// MeterReading é uma leitura do contador de uso do ativo (metros, ciclos, horas),
+// acumulado desde a instalação. Os planos por uso comparam a meta com o avanço
+// do contador desde a última execução.
+type MeterReading struct {
+	ID        int64     `json:"id"`
+	AssetID   int64     `json:"asset_id"`
+	Value     int64     `json:"value"`
+	ReadAt    time.Time `json:"read_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (m *MeterReading) Validate() error {
+	if m.AssetID <= 0 || m.Value < 0 || m.ReadAt.IsZero() {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// ConditionReading é uma medição de condição do ativo (vibração, temperatura,
+// pressão), comparada com a faixa dos planos por condição.
+type ConditionReading struct {
+	ID        int64     `json:"id"`
+	AssetID   int64     `json:"asset_id"`
+	Parameter string    `json:"parameter"` // ex: vibration, temperature
+	Value     float64   `json:"value"`
+	ReadAt    time.Time `json:"read_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (c *ConditionReading) Normalize() {
+	c.Parameter = NormalizeParameter(c.Parameter)
+}
+
+func (c *ConditionReading) Validate() error {
+	if c.AssetID <= 0 || c.Parameter == "" || c.ReadAt.IsZero() {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// NormalizeParameter padroniza o nome da medição (minúsculas, sem espaços nas pontas).
+func NormalizeParameter(p string) string {
+	return strings.ToLower(strings.TrimSpace(p))
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Site é uma planta da empresa. Ativos, OS, planos e usuários pertencem a um
+// site e só são vistos por quem está nele; usuários corporativos também
+// consultam relatórios de todos os sites.
+type Site struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"` // ex: "JOI" (Joinville)
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (s *Site) Normalize() {
+	s.Code = strings.ToUpper(strings.TrimSpace(s.Code))
+	s.Name = strings.TrimSpace(s.Name)
+}
+
+func (s *Site) Validate() error {
+	if s.Code == "" || s.Name == "" {
+		return ErrInvalidInput
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// Priority é a prioridade de atendimento da OS, derivada da matriz de SLA.
+type Priority string
+
+const (
+	PriorityUrgent Priority = "urgent"
+	PriorityHigh   Priority = "high"
+	PriorityNormal Priority = "normal"
+	PriorityLow    Priority = "low"
+)
+
+func (p Priority) Valid() bool {
+	switch p {
+	case PriorityUrgent, PriorityHigh, PriorityNormal, PriorityLow:
+		return true
+	}
+	return false
+}
+
+// SLAPolicy é uma célula da matriz criticidade × tipo de OS.
+// Prazos contam a partir da quebra (BreakdownAt), da data programada (preventivas) ou da abertura.
+type SLAPolicy struct {
+	Criticality       Criticality   `json:"criticality"`
+	Type              WorkOrderType `json:"type"`
+	Priority          Priority      `json:"priority"`
+	ResponseMinutes   int64         `json:"response_minutes"`   // até o início do atendimento
+	ResolutionMinutes int64         `json:"resolution_minutes"` // até a conclusão
+}
+
+func (p *SLAPolicy) Validate() error {
+	if !p.Priority.Valid() || p.ResponseMinutes <= 0 || p.ResolutionMinutes < p.ResponseMinutes {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// Apply define prioridade e prazos da OS a partir da política; openedAt é a
+// abertura da OS (o repositório só preenche CreatedAt depois do insert).
+// Os prazos contam em tempo útil do calendário (nil = 24×7).
+func (p *SLAPolicy) Apply(wo *WorkOrder, openedAt time.Time, cal *Calendar) {
+	base := openedAt
+	switch {
+	case wo.BreakdownAt != nil:
+		base = *wo.BreakdownAt
+	case wo.ScheduledFor != nil && wo.ScheduledFor.After(openedAt):
+		base = *wo.ScheduledFor // preventiva gerada antes da data programada
+	}
+	response := cal.AddWorkingTime(base, time.Duration(p.ResponseMinutes)*time.Minute)
+	resolution := cal.AddWorkingTime(base, time.Duration(p.ResolutionMinutes)*time.Minute)
+	wo.Priority = p.Priority
+	wo.ResponseDueAt = &response
+	wo.ResolutionDueAt = &resolution
+}
+
+// DefaultSLAMatrix é a matriz inicial (a mesma semeada pela migração).
+func DefaultSLAMatrix() []SLAPolicy {
+	const h, d = 60, 24 * 60
+	return []SLAPolicy{
+		{CriticalityA, WOTypeCorrective, PriorityUrgent, 30, 4 * h},
+		{CriticalityA, WOTypeCondition, PriorityHigh, 2 * h, 1 * d},
+		{CriticalityA, WOTypePreventive, PriorityNormal, 1 * d, 3 * d},
+		{CriticalityA, WOTypeImprovement, PriorityLow, 2 * d, 14 * d},
+		{CriticalityB, WOTypeCorrective, PriorityHigh, 2 * h, 1 * d},
+		{CriticalityB, WOTypeCondition, PriorityNormal, 8 * h, 2 * d},
+		{CriticalityB, WOTypePreventive, PriorityNormal, 2 * d, 7 * d},
+		{CriticalityB, WOTypeImprovement, PriorityLow, 3 * d, 14 * d},
+		{CriticalityC, WOTypeCorrective, PriorityNormal, 8 * h, 3 * d},
+		{CriticalityC, WOTypeCondition, PriorityLow, 1 * d, 7 * d},
+		{CriticalityC, WOTypePreventive, PriorityLow, 3 * d, 14 * d},
+		{CriticalityC, WOTypeImprovement, PriorityLow, 7 * d, 30 * d},
+	}
+}
+
+// SLAReportRow consolida o cumprimento de SLA das OS abertas em um mês (AAAA-MM, fuso da planta).
+// Só entram na conta prazos já decididos: atendidas/concluídas ou com o prazo vencido.
+type SLAReportRow struct {
+	Month                string   `json:"month"`
+	Orders               int64    `json:"orders"`
+	ResponseEvaluated    int64    `json:"response_evaluated"`
+	ResponseMet          int64    `json:"response_met"`
+	ResponseCompliance   *float64 `json:"response_compliance"`
+	ResolutionEvaluated  int64    `json:"resolution_evaluated"`
+	ResolutionMet        int64    `json:"resolution_met"`
+	ResolutionCompliance *float64 `json:"resolution_compliance"`
+}
+
+// FillCompliance calcula os percentuais (0–1) a partir das contagens.
+func (r *SLAReportRow) FillCompliance() {
+	r.ResponseCompliance = share(r.ResponseMet, r.ResponseEvaluated)
+	r.ResolutionCompliance = share(r.ResolutionMet, r.ResolutionEvaluated)
+}
+
+func share(part, total int64) *float64 {
+	if total == 0 {
+		return nil
+	}
+	v := math.Round(float64(part)/float64(total)*10000) / 10000
+	return &v
+}
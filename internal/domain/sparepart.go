@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// SparePart é uma peça de reposição do almoxarifado, com estoque mínimo para alerta.
+type SparePart struct {
+	ID          int64     `json:"id"`
+	SiteID      int64     `json:"site_id"`
+	Code        string    `json:"code"` // único no site (ex: ROL-6205)
+	Name        string    `json:"name"`
+	Unit        string    `json:"unit"` // un, m, kg...
+	Quantity    float64   `json:"quantity"`
+	MinQuantity float64   `json:"min_quantity"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func (p *SparePart) Normalize() {
+	p.Code = strings.ToUpper(strings.TrimSpace(p.Code))
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Unit = strings.TrimSpace(p.Unit); p.Unit == "" {
+		p.Unit = "un"
+	}
+}
+
+func (p *SparePart) Validate() error {
+	if p.Code == "" || p.Name == "" || p.Quantity < 0 || p.MinQuantity < 0 {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// IsLow indica estoque abaixo do mínimo.
+func (p *SparePart) IsLow() bool {
+	return p.Quantity < p.MinQuantity
+}
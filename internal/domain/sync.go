@@ -0,0 +1,36 @@
+package domain
+
+// SyncEntity é o tipo de registro que o app offline dos técnicos mantém no aparelho.
+type SyncEntity string
+
+const (
+	SyncAsset         SyncEntity = "asset"
+	SyncWorkOrder     SyncEntity = "work_order"
+	SyncChecklistItem SyncEntity = "checklist_item"
+)
+
+// SyncChange é a versão atual de um registro alterado. Toda escrita em ativo, OS
+// ou passo de checklist recebe uma revisão maior que qualquer token já entregue,
+// então "tudo depois da revisão N" é o delta desde a última sincronização.
+//
+// OS concluída ou cancelada sai do aparelho: vai como Deleted, junto com o
+// checklist. Para o técnico, OS reatribuída a outro também vai como Deleted (só
+// com o ID) e OS que passa a ser dele chega com o checklist inteiro.
+type SyncChange struct {
+	Entity   SyncEntity
+	Revision int64
+	Deleted  bool
+
+	Asset     *Asset
+	WorkOrder *WorkOrder
+	// Passo de checklist e a OS a que pertence.
+	WorkOrderID   int64
+	ChecklistItem *ChecklistItem
+}
+
+// SyncPage é uma página do delta; Next é a revisão a pedir na próxima chamada.
+type SyncPage struct {
+	Changes []SyncChange
+	Next    int64
+	HasMore bool
+}
@@ -24,6 +24,7 @@ const (
 
 type WorkOrder struct {
 	ID              int64           `json:"id"`
+	SiteID          int64           `json:"site_id"` // sempre o site do ativo
 	AssetID         int64           `json:"asset_id"`
 	Type            WorkOrderType   `json:"type"`                   // corrective|preventive|condition|improvement
 	Status          WorkOrderStatus `json:"status"`                 // open|in_progress|done|canceled
@@ -34,8 +35,65 @@ type WorkOrder struct {
 	DowntimeMinutes *int64          `json:"downtime_minutes,omitempty"`
 	Cause           string          `json:"cause,omitempty"`
 	Solution        string          `json:"solution,omitempty"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
+	// Códigos do catálogo de falhas (modo → causa → ação), informados no fechamento.
+	FailureModeID   *int64 `json:"failure_mode_id,omitempty"`
+	FailureCauseID  *int64 `json:"failure_cause_id,omitempty"`
+	FailureActionID *int64 `json:"failure_action_id,omitempty"`
+	// SLA (matriz criticidade × tipo): prioridade e prazos de atendimento/conclusão.
+	Priority        Priority   `json:"priority,omitempty"`
+	ResponseDueAt   *time.Time `json:"response_due_at,omitempty"`
+	ResolutionDueAt *time.Time `json:"resolution_due_at,omitempty"`
+	RespondedAt     *time.Time `json:"responded_at,omitempty"`    // início do atendimento
+	SLABreachedAt   *time.Time `json:"sla_breached_at,omitempty"` // marcado pelo detector de atrasos
+	// Planejamento: especialidade exigida (mecânica, elétrica...) e esforço estimado.
+	Trade            string `json:"trade,omitempty"`
+	EstimatedMinutes *int64 `json:"estimated_minutes,omitempty"`
+	// AssigneeID é o técnico responsável; o app offline dele só baixa as OS atribuídas.
+	AssigneeID *int64 `json:"assignee_id,omitempty"`
+	// Roteiro (job plan) copiado na abertura: peças previstas; o checklist fica em repositório próprio.
+	JobPlanID     *int64        `json:"job_plan_id,omitempty"`
+	RequiredParts []JobPlanPart `json:"required_parts,omitempty"`
+	// Preventivas geradas pelo agendador: plano de origem e data programada (em tempo útil).
+	PlanID       *int64     `json:"plan_id,omitempty"`
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty"`
+	// RequestID é a solicitação de manutenção aceita que originou a OS.
+	RequestID *int64    `json:"request_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Revision é a revisão de sincronização da última escrita (ver SyncChange).
+	Revision int64 `json:"-"`
+}
+
+// IsOpen indica se a OS ainda está no backlog (não concluída nem cancelada).
+func (wo *WorkOrder) IsOpen() bool {
+	return wo.Status == WOStatusOpen || wo.Status == WOStatusInProgress
+}
+
+// IsOverdue indica OS em aberto com prazo de atendimento ou de conclusão vencido em now.
+func (wo *WorkOrder) IsOverdue(now time.Time) bool {
+	if !wo.IsOpen() {
+		return false
+	}
+	if wo.ResolutionDueAt != nil && wo.ResolutionDueAt.Before(now) {
+		return true
+	}
+	return wo.RespondedAt == nil && wo.ResponseDueAt != nil && wo.ResponseDueAt.Before(now)
+}
+
+// transitions lista os próximos estados válidos; done e canceled são finais.
+var transitions = map[WorkOrderStatus][]WorkOrderStatus{
+	WOStatusOpen:       {WOStatusInProgress, WOStatusDone, WOStatusCanceled},
+	WOStatusInProgress: {WOStatusOpen, WOStatusDone, WOStatusCanceled},
+}
+
+// CanTransition indica se a OS pode passar do status atual para next.
+func (wo *WorkOrder) CanTransition(next WorkOrderStatus) bool {
+	for _, s := range transitions[wo.Status] {
+		if s == next {
+			return true
+		}
+	}
+	return false
 }
 
 func (wo *WorkOrder) Normalize() {
@@ -45,4 +103,5 @@ func (wo *WorkOrder) Normalize() {
 	if wo.Type == "" {
 		wo.Type = WOTypeCorrective
 	}
+	wo.Trade = NormalizeTrade(wo.Trade)
 }
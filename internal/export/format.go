@@ -0,0 +1,78 @@
+// Package export gera listagens em CSV, XLSX e PDF escrevendo linha a linha.
+package export
+
+import (
+	"errors"
+	"mime"
+	"strings"
+)
+
+// Format é o formato de saída negociado com o cliente.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatPDF  Format = "pdf"
+)
+
+// ErrUnsupportedFormat indica ?format= desconhecido.
+var ErrUnsupportedFormat = errors.New("unsupported export format")
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// Negotiate escolhe o formato: ?format= tem prioridade sobre o header Accept;
+// sem nenhum dos dois (ou com */*), a resposta é JSON.
+func Negotiate(query, accept string) (Format, error) {
+	switch strings.ToLower(query) {
+	case "":
+	case "json":
+		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	case "xlsx":
+		return FormatXLSX, nil
+	case "pdf":
+		return FormatPDF, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mt, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mt {
+		case "application/json", "*/*":
+			return FormatJSON, nil
+		case "text/csv":
+			return FormatCSV, nil
+		case xlsxContentType:
+			return FormatXLSX, nil
+		case "application/pdf":
+			return FormatPDF, nil
+		}
+	}
+	return FormatJSON, nil
+}
+
+// ContentType é o MIME type da resposta.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatXLSX:
+		return xlsxContentType
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Filename monta o nome sugerido no Content-Disposition.
+func (f Format) Filename(base string) string {
+	return base + "." + string(f)
+}
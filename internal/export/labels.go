@@ -0,0 +1,110 @@
+package export
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+)
+
+// dateTimeLayout é o formato brasileiro usado nas exportações.
+const dateTimeLayout = "02/01/2006 15:04"
+
+var workOrderTypeLabels = map[domain.WorkOrderType]string{
+	domain.WOTypeCorrective:  "Corretiva",
+	domain.WOTypePreventive:  "Preventiva",
+	domain.WOTypeCondition:   "Preditiva",
+	domain.WOTypeImprovement: "Melhoria",
+}
+
+var workOrderStatusLabels = map[domain.WorkOrderStatus]string{
+	domain.WOStatusOpen:       "Aberta",
+	domain.WOStatusInProgress: "Em andamento",
+	domain.WOStatusDone:       "Concluída",
+	domain.WOStatusCanceled:   "Cancelada",
+}
+
+// WorkOrderHeader são as colunas (pt-BR) da exportação de OS.
+var WorkOrderHeader = []string{
+	"OS", "Ativo", "Tipo", "Situação", "Título", "Descrição", "Aberta em",
+	"Quebra", "Encerramento", "Parada (min)", "Causa", "Solução",
+}
+
+// WorkOrderRow formata uma OS na ordem de WorkOrderHeader.
+func WorkOrderRow(o *domain.WorkOrder) []string {
+	return []string{
+		strconv.FormatInt(o.ID, 10),
+		strconv.FormatInt(o.AssetID, 10),
+		label(workOrderTypeLabels, o.Type),
+		label(workOrderStatusLabels, o.Status),
+		o.Title,
+		o.Description,
+		FormatTime(&o.CreatedAt),
+		FormatTime(o.BreakdownAt),
+		FormatTime(o.ClosedAt),
+		formatInt(o.DowntimeMinutes),
+		o.Cause,
+		o.Solution,
+	}
+}
+
+// AssetHeader são as colunas (pt-BR) da exportação de ativos.
+var AssetHeader = []string{"ID", "Nome", "Localização", "Criticidade", "Código", "Classe", "Fabricante", "Modelo",
+	"Nº de série", "Instalação", "Garantia até", "Cadastrado em"}
+
+// AssetRow formata um ativo na ordem de AssetHeader.
+func AssetRow(a *domain.Asset) []string {
+	return []string{
+		strconv.FormatInt(a.ID, 10),
+		a.Name,
+		a.Location,
+		string(a.Criticality),
+		a.ExternalCode,
+		a.Class,
+		a.Manufacturer,
+		a.Model,
+		a.SerialNumber,
+		a.InstalledOn,
+		a.WarrantyUntil,
+		FormatTime(&a.CreatedAt),
+	}
+}
+
+// DowntimeHeader são as colunas (pt-BR) do relatório mensal de paradas.
+var DowntimeHeader = []string{"Mês", "Ativo", "Nome", "Localização", "Quebras", "Parada (min)", "Parada programada (min)"}
+
+// DowntimeRow formata uma linha do relatório mensal de paradas.
+func DowntimeRow(r *domain.DowntimeReportRow) []string {
+	return []string{
+		r.Month,
+		strconv.FormatInt(r.AssetID, 10),
+		r.AssetName,
+		r.Location,
+		strconv.FormatInt(r.Breakdowns, 10),
+		strconv.FormatInt(r.DowntimeMinutes, 10),
+		strconv.FormatInt(r.PlannedMinutes, 10),
+	}
+}
+
+// FormatTime formata no fuso da planta; nil vira célula vazia.
+func FormatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.In(plant.Location()).Format(dateTimeLayout)
+}
+
+func formatInt(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
+func label[K ~string](labels map[K]string, k K) string {
+	if l, ok := labels[k]; ok {
+		return l
+	}
+	return string(k)
+}
@@ -0,0 +1,213 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/xuri/excelize/v2"
+)
+
+// Writer recebe o cabeçalho e as linhas em sequência; Close finaliza o arquivo.
+type Writer interface {
+	Header(cols []string) error
+	Row(values []string) error
+	Close() error
+}
+
+// NewWriter cria o writer do formato; title vira o nome da aba (XLSX) ou o título (PDF).
+func NewWriter(f Format, w io.Writer, title string) (Writer, error) {
+	switch f {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatXLSX:
+		return newXLSXWriter(w, title)
+	case FormatPDF:
+		return newPDFWriter(w, title), nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// csvFlushEvery controla de quantas em quantas linhas o CSV é enviado ao cliente.
+const csvFlushEvery = 200
+
+// csvWriter usa ";" e BOM para o Excel em pt-BR abrir acentos e colunas corretamente.
+type csvWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	if _, err := w.Write([]byte("\xEF\xBB\xBF")); err != nil {
+		return nil, err
+	}
+	cw := csv.NewWriter(w)
+	cw.Comma = ';'
+	return &csvWriter{w: cw}, nil
+}
+
+func (c *csvWriter) Header(cols []string) error { return c.w.Write(cols) }
+
+func (c *csvWriter) Row(values []string) error {
+	if err := c.w.Write(values); err != nil {
+		return err
+	}
+	c.rows++
+	if c.rows%csvFlushEvery == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// xlsxWriter usa o StreamWriter do excelize, que descarrega linhas em arquivo
+// temporário em vez de manter a planilha inteira em memória.
+type xlsxWriter struct {
+	out  io.Writer
+	file *excelize.File
+	sw   *excelize.StreamWriter
+	row  int
+}
+
+func newXLSXWriter(w io.Writer, title string) (*xlsxWriter, error) {
+	f := excelize.NewFile()
+	sheet := sheetName(title)
+	if err := f.SetSheetName("Sheet1", sheet); err != nil {
+		return nil, fmt.Errorf("xlsx sheet: %w", err)
+	}
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return nil, fmt.Errorf("xlsx stream writer: %w", err)
+	}
+	return &xlsxWriter{out: w, file: f, sw: sw, row: 1}, nil
+}
+
+func (x *xlsxWriter) Header(cols []string) error { return x.Row(cols) }
+
+func (x *xlsxWriter) Row(values []string) error {
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	row := make([]any, len(values))
+	for i, v := range values {
+		row[i] = v
+	}
+	x.row++
+	return x.sw.SetRow(cell, row)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.sw.Flush(); err != nil {
+		return fmt.Errorf("xlsx flush: %w", err)
+	}
+	return x.file.Write(x.out)
+}
+
+// sheetName respeita o limite de 31 caracteres do Excel.
+func sheetName(title string) string {
+	if title == "" {
+		return "Dados"
+	}
+	r := []rune(title)
+	if len(r) > 31 {
+		r = r[:31]
+	}
+	return string(r)
+}
+
+// pdfWriter gera uma tabela simples em A4 paisagem, repetindo o cabeçalho a cada página.
+type pdfWriter struct {
+	out    io.Writer
+	pdf    *fpdf.Fpdf
+	tr     func(string) string
+	header []string
+	widths []float64
+}
+
+const (
+	pdfLineHeight = 5.0
+	pdfFontSize   = 7.0
+)
+
+func newPDFWriter(w io.Writer, title string) *pdfWriter {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(8, 10, 8)
+	pdf.SetAutoPageBreak(true, 10)
+	p := &pdfWriter{out: w, pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+
+	pdf.SetHeaderFunc(func() {
+		pdf.SetFont("Helvetica", "B", 11)
+		pdf.CellFormat(0, 8, p.tr(title), "", 1, "L", false, 0, "")
+		p.writeHeaderRow()
+	})
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-8)
+		pdf.SetFont("Helvetica", "", 6)
+		pdf.CellFormat(0, 4, fmt.Sprintf("%d", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	return p
+}
+
+func (p *pdfWriter) Header(cols []string) error {
+	p.header = cols
+	pageW, _ := p.pdf.GetPageSize()
+	left, _, right, _ := p.pdf.GetMargins()
+	w := (pageW - left - right) / float64(len(cols))
+	p.widths = make([]float64, len(cols))
+	for i := range p.widths {
+		p.widths[i] = w
+	}
+	p.pdf.AddPage()
+	return p.pdf.Error()
+}
+
+func (p *pdfWriter) writeHeaderRow() {
+	if len(p.header) == 0 {
+		return
+	}
+	p.pdf.SetFont("Helvetica", "B", pdfFontSize)
+	p.pdf.SetFillColor(230, 230, 230)
+	for i, col := range p.header {
+		p.pdf.CellFormat(p.widths[i], pdfLineHeight, p.tr(col), "1", 0, "L", true, 0, "")
+	}
+	p.pdf.Ln(-1)
+	p.pdf.SetFont("Helvetica", "", pdfFontSize)
+}
+
+func (p *pdfWriter) Row(values []string) error {
+	for i, v := range values {
+		if i >= len(p.widths) {
+			break
+		}
+		p.pdf.CellFormat(p.widths[i], pdfLineHeight, p.fit(p.tr(v), p.widths[i]), "1", 0, "L", false, 0, "")
+	}
+	p.pdf.Ln(-1)
+	return p.pdf.Error()
+}
+
+// fit corta o texto para caber na célula (PDF é para leitura; o dado completo vai no CSV/XLSX).
+// O texto já vem em cp1252 (1 byte por caractere), então o corte é por byte.
+func (p *pdfWriter) fit(s string, width float64) string {
+	max := width - 2
+	if p.pdf.GetStringWidth(s) <= max {
+		return s
+	}
+	b := []byte(s)
+	for len(b) > 0 && p.pdf.GetStringWidth(string(b)+"...") > max {
+		b = b[:len(b)-1]
+	}
+	return string(b) + "..."
+}
+
+func (p *pdfWriter) Close() error {
+	return p.pdf.Output(p.out)
+}
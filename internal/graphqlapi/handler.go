@@ -0,0 +1,236 @@
+package graphqlapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	gqllog "github.com/graph-gophers/graphql-go/log"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	log "github.com/sirupsen/logrus"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const (
+	// heartbeat mantém abertas, atrás de proxies, as subscriptions sem eventos.
+	heartbeat = 15 * time.Second
+	// writeTimeout limita cada escrita no stream: cliente que não lê é
+	// desconectado e retoma com since, sem perder revisões.
+	writeTimeout = 10 * time.Second
+	// eventTimeout é o prazo do executor para resolver e entregar um evento da
+	// subscription; cobre o pior caso de escrita (evento + heartbeat).
+	eventTimeout = 3 * writeTimeout
+)
+
+type request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+type Handler struct {
+	svc    Services
+	schema *graphql.Schema
+	limits *limits
+	done   chan struct{}
+	close  sync.Once
+}
+
+func NewHandler(svc Services) (*Handler, error) {
+	schema, err := NewSchema(svc,
+		graphql.Logger(gqllog.LoggerFunc(func(_ context.Context, v any) {
+			log.WithField("panic", v).WithField("stack", string(debug.Stack())).Error("graphql resolver panicked")
+		})),
+		graphql.PanicHandler(internalPanic{}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	limits, err := newLimits()
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{svc: svc, schema: schema, limits: limits, done: make(chan struct{})}, nil
+}
+
+func (h *Handler) RegisterRoutes(r gin.IRouter) {
+	r.POST("/graphql", h.query)
+	r.GET("/graphql/schema", h.sdl)
+}
+
+// CloseStreams encerra as subscriptions abertas; chamado no desligamento do
+// servidor HTTP, que não termina enquanto houver stream.
+func (h *Handler) CloseStreams() {
+	h.close.Do(func() { close(h.done) })
+}
+
+// query executa {query, operationName, variables}. Erros de consulta e dos
+// resolvers voltam com 200 em errors (com o status HTTP equivalente em
+// extensions.status); corpo que não é JSON responde 422. Com
+// Accept: text/event-stream, subscriptions são entregues por Server-Sent Events.
+func (h *Handler) query(c *gin.Context) {
+	var req request
+	if err := json.NewDecoder(c.Request.Body).Decode(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		response.ValidationFailed(c, []response.ValidationDetail{{Field: "query", Rule: "required"}})
+		return
+	}
+
+	op, errs := h.limits.check(req)
+	if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		h.stream(c, req, errs)
+		return
+	}
+	if errs == nil && op == ast.Subscription {
+		errs = []*gqlerrors.QueryError{{Message: "subscription exige um stream de eventos", Extensions: code("GRAPHQL_VALIDATION_FAILED")}}
+	}
+	if errs != nil {
+		c.JSON(http.StatusOK, &graphql.Response{Errors: errs})
+		return
+	}
+	resp := h.schema.Exec(withLoaders(c.Request.Context(), h.svc), req.Query, req.OperationName, req.Variables)
+	formatErrors(resp.Errors)
+	c.JSON(http.StatusOK, resp)
+}
+
+// stream manda cada resposta como evento "next" e, ao fim da subscription
+// (ou da query), um evento "complete".
+func (h *Handler) stream(c *gin.Context, req request, errs []*gqlerrors.QueryError) {
+	var wg sync.WaitGroup
+	defer wg.Wait() // nada escreve na resposta depois que o handler retorna
+	ctx, cancel := context.WithCancel(c.Request.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-h.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Writer.WriteHeaderNow()
+	c.Writer.Flush()
+
+	rc := http.NewResponseController(c.Writer)
+	var mu sync.Mutex
+	write := func(event string, data []byte) error {
+		mu.Lock()
+		defer mu.Unlock()
+		var b bytes.Buffer
+		if event != "" {
+			fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", event, data)
+		} else {
+			b.WriteString(": ping\n\n")
+		}
+		_ = rc.SetWriteDeadline(time.Now().Add(writeTimeout))
+		if _, err := c.Writer.Write(b.Bytes()); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(heartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if write("", nil) != nil {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	var responses <-chan any
+	if errs != nil {
+		ch := make(chan any, 1)
+		ch <- &graphql.Response{Errors: errs}
+		close(ch)
+		responses = ch
+	} else {
+		var err error
+		if responses, err = h.schema.Subscribe(withLoaders(ctx, h.svc), req.Query, req.OperationName, req.Variables); err != nil {
+			log.WithError(err).Error("graphql subscribe failed")
+			return
+		}
+	}
+	for v := range responses {
+		resp := v.(*graphql.Response)
+		formatErrors(resp.Errors)
+		data, err := json.Marshal(resp)
+		if err == nil {
+			err = write("next", data)
+		}
+		if err != nil {
+			cancel()
+			return
+		}
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	_ = write("complete", []byte("{}"))
+}
+
+func (h *Handler) sdl(c *gin.Context) {
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(sdl))
+}
+
+// formatErrors completa os erros da execução: os dos resolvers e os de
+// contexto saem como na API REST (formatError); os demais são de validação.
+func formatErrors(errs []*gqlerrors.QueryError) {
+	for _, e := range errs {
+		switch {
+		case e.ResolverError != nil:
+			e.Message, e.Extensions = formatError(e.ResolverError)
+		case e.Err != nil:
+			e.Message, e.Extensions = formatError(e.Err)
+		case e.Extensions == nil:
+			e.Extensions = code("GRAPHQL_VALIDATION_FAILED")
+		}
+	}
+}
+
+// formatError traduz os erros dos resolvers como a API REST (response.Describe),
+// com o status HTTP em extensions. Erros internos vão para o log e saem sem detalhes.
+func formatError(err error) (string, map[string]any) {
+	if errors.Is(err, context.Canceled) {
+		return "requisição cancelada", map[string]any{"status": 499}
+	}
+	code, msg := response.Describe(err)
+	if code == http.StatusInternalServerError {
+		log.WithError(err).Error("graphql resolver failed")
+		msg = "erro interno"
+	}
+	return msg, map[string]any{"status": code}
+}
+
+// internalPanic responde o pânico de um resolver como erro interno; o
+// detalhe vai para o log (graphql.Logger).
+type internalPanic struct{}
+
+func (internalPanic) MakePanicError(context.Context, any) *gqlerrors.QueryError {
+	return &gqlerrors.QueryError{Message: "erro interno", Extensions: map[string]any{"status": http.StatusInternalServerError}}
+}
@@ -0,0 +1,276 @@
+package graphqlapi_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/graphqlapi"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// countingAssets e countingOrders contam as leituras para conferir o
+// carregamento em lote (os campos resolvem em paralelo).
+type countingAssets struct {
+	*memory.AssetMemoryRepo
+	streams atomic.Int32
+}
+
+func (r *countingAssets) Stream(ctx context.Context, f domain.AssetFilter, fn func(*domain.Asset) error) error {
+	r.streams.Add(1)
+	return r.AssetMemoryRepo.Stream(ctx, f, fn)
+}
+
+type countingOrders struct {
+	*memory.WorkOrderMemoryRepo
+	streams atomic.Int32
+}
+
+func (r *countingOrders) Stream(ctx context.Context, f domain.WorkOrderFilter, fn func(*domain.WorkOrder) error) error {
+	r.streams.Add(1)
+	return r.WorkOrderMemoryRepo.Stream(ctx, f, fn)
+}
+
+type fixture struct {
+	router     *gin.Engine
+	assets     *countingAssets
+	orders     *countingOrders
+	workOrders *service.WorkOrderService
+	ctx        context.Context
+}
+
+// setup monta o handler sobre repositórios em memória, com o usuário do site 1.
+func setup(t *testing.T) *fixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	assets := &countingAssets{AssetMemoryRepo: memory.NewAssetMemoryRepo()}
+	orders := &countingOrders{WorkOrderMemoryRepo: memory.NewWorkOrderMemoryRepo()}
+	checklists := memory.NewChecklistMemoryRepo(orders.WorkOrderMemoryRepo)
+	jobPlans := memory.NewJobPlanMemoryRepo()
+	events := memory.NewDowntimeMemoryRepo(assets.AssetMemoryRepo)
+	workOrders := service.NewWorkOrderService(orders,
+		service.WithAssets(assets),
+		service.WithChecklists(jobPlans, checklists),
+		service.WithChangeFeed(memory.NewSyncMemoryRepo(assets.AssetMemoryRepo, orders.WorkOrderMemoryRepo, checklists), 10*time.Millisecond),
+	)
+	h, err := graphqlapi.NewHandler(graphqlapi.Services{
+		Assets:           service.NewAssetService(assets),
+		WorkOrders:       workOrders,
+		MaintenancePlans: service.NewMaintenancePlanService(memory.NewMaintenancePlanMemoryRepo(), assets, jobPlans),
+		Downtime:         service.NewDowntimeService(events, assets, orders.WorkOrderMemoryRepo),
+		Reports: service.NewReportService(memory.NewReportMemoryRepo(assets.AssetMemoryRepo, orders.WorkOrderMemoryRepo, events,
+			memory.NewShiftMemoryRepo(), memory.NewProductionMemoryRepo(assets.AssetMemoryRepo), memory.NewFailureCodeMemoryRepo())),
+	})
+	if err != nil {
+		t.Fatalf("NewHandler: %v", err)
+	}
+	t.Cleanup(h.CloseStreams)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenant.WithPrincipal(c.Request.Context(), tenant.Principal{UserID: 1, SiteID: 1}))
+	})
+	h.RegisterRoutes(r.Group("/v1"))
+	return &fixture{
+		router:     r,
+		assets:     assets,
+		orders:     orders,
+		workOrders: workOrders,
+		ctx:        tenant.WithPrincipal(t.Context(), tenant.Principal{UserID: 1, SiteID: 1}),
+	}
+}
+
+func (f *fixture) query(t *testing.T, query string, vars map[string]any) map[string]any {
+	t.Helper()
+	body, _ := json.Marshal(map[string]any{"query": query, "variables": vars})
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/graphql", bytes.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", w.Code, w.Body)
+	}
+	var resp map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %s: %v", w.Body, err)
+	}
+	return resp
+}
+
+func TestGraphQL_DashboardQueryIsBatched(t *testing.T) {
+	f := setup(t)
+	var assetIDs []int64
+	for _, name := range []string{"Prensa", "Torno", "Fresa"} {
+		a := domain.Asset{Name: name, Criticality: domain.CriticalityA}
+		if err := f.assets.Create(f.ctx, &a); err != nil {
+			t.Fatalf("create asset: %v", err)
+		}
+		assetIDs = append(assetIDs, a.ID)
+		for _, title := range []string{"Vazamento", "Ruído"} {
+			if err := f.workOrders.Create(f.ctx, &domain.WorkOrder{AssetID: a.ID, Title: title + " " + name}); err != nil {
+				t.Fatalf("create work order: %v", err)
+			}
+		}
+	}
+
+	resp := f.query(t, `query Painel($n: Int) {
+		assets { name criticality workOrders(first: $n) { title asset { name } } }
+		workOrders(open: true, first: 2) { title status asset { name } }
+	}`, map[string]any{"n": 1})
+	if resp["errors"] != nil {
+		t.Fatalf("errors: %v", resp["errors"])
+	}
+	got, _ := json.Marshal(resp["data"])
+	want := `{"assets":[` +
+		`{"criticality":"A","name":"Prensa","workOrders":[{"asset":{"name":"Prensa"},"title":"Ruído Prensa"}]},` +
+		`{"criticality":"A","name":"Torno","workOrders":[{"asset":{"name":"Torno"},"title":"Ruído Torno"}]},` +
+		`{"criticality":"A","name":"Fresa","workOrders":[{"asset":{"name":"Fresa"},"title":"Ruído Fresa"}]}],` +
+		`"workOrders":[{"asset":{"name":"Fresa"},"status":"OPEN","title":"Ruído Fresa"},` +
+		`{"asset":{"name":"Fresa"},"status":"OPEN","title":"Vazamento Fresa"}]}`
+	if string(got) != want {
+		t.Fatalf("data:\n got %s\nwant %s", got, want)
+	}
+
+	// Uma leitura da lista de ativos e uma das OS dos três ativos; os ativos
+	// das OS saem do cache da execução.
+	f.assets.streams.Store(0)
+	f.orders.streams.Store(0)
+	f.query(t, `{ assets { workOrders { asset { name } } } }`, nil)
+	if a, o := f.assets.streams.Load(), f.orders.streams.Load(); a != 1 || o != 1 {
+		t.Fatalf("asset reads = %d, work order reads = %d, want 1 and 1", a, o)
+	}
+
+	f.assets.streams.Store(0)
+	resp = f.query(t, `{ workOrders { asset { name } } }`, nil)
+	if rows := resp["data"].(map[string]any)["workOrders"].([]any); len(rows) != 6 || f.assets.streams.Load() != 1 {
+		t.Fatalf("%d work orders with %d asset reads, want 6 with 1", len(rows), f.assets.streams.Load())
+	}
+}
+
+func TestGraphQL_ErrorsAndLimits(t *testing.T) {
+	f := setup(t)
+
+	resp := f.query(t, `{ workOrders(first: 1000) { id } }`, nil)
+	errs, _ := resp["errors"].([]any)
+	if len(errs) != 1 || resp["data"] != nil {
+		t.Fatalf("response = %v", resp)
+	}
+	if ext := errs[0].(map[string]any)["extensions"].(map[string]any); ext["status"] != float64(http.StatusBadRequest) {
+		t.Fatalf("extensions = %v, want status 400", ext)
+	}
+
+	if resp := f.query(t, `{ asset(id: 999) { name } }`, nil); resp["errors"] != nil || resp["data"].(map[string]any)["asset"] != nil {
+		t.Fatalf("missing asset = %v, want null without errors", resp)
+	}
+
+	deep := `{ assets { workOrders { asset { workOrders { asset { workOrders { asset { workOrders { id } } } } } } } } }`
+	resp = f.query(t, deep, nil)
+	errs, _ = resp["errors"].([]any)
+	if len(errs) != 1 || errs[0].(map[string]any)["extensions"].(map[string]any)["code"] != "QUERY_TOO_DEEP" {
+		t.Fatalf("deep query = %v", resp)
+	}
+	if _, ok := resp["data"]; ok {
+		t.Fatalf("rejected query must not carry data: %v", resp)
+	}
+
+	for query, code := range map[string]string{
+		`{ assets(first: 500) { workOrders(first: 500) { id } } }`: "QUERY_TOO_COMPLEX",
+		`{ assets { serial } }`:                          "GRAPHQL_VALIDATION_FAILED",
+		`{ assets { id }`:                                "GRAPHQL_PARSE_FAILED",
+		`subscription { workOrderChanged { revision } }`: "GRAPHQL_VALIDATION_FAILED",
+	} {
+		resp = f.query(t, query, nil)
+		errs, _ = resp["errors"].([]any)
+		if len(errs) != 1 || errs[0].(map[string]any)["extensions"].(map[string]any)["code"] != code || resp["data"] != nil {
+			t.Fatalf("%s = %v, want %s", query, resp, code)
+		}
+	}
+
+	// A introspecção não conta na profundidade nem na complexidade.
+	resp = f.query(t, `{ __type(name: "WorkOrderStatus") { enumValues { name } } }`, nil)
+	got, _ := json.Marshal(resp)
+	if string(got) != `{"data":{"__type":{"enumValues":[{"name":"OPEN"},{"name":"IN_PROGRESS"},{"name":"DONE"},{"name":"CANCELED"}]}}}` {
+		t.Fatalf("introspection = %s", got)
+	}
+	ide := `{ __schema { types { fields { type { ofType { ofType { ofType { ofType { ofType { name } } } } } } } } } }`
+	if resp = f.query(t, ide, nil); resp["errors"] != nil {
+		t.Fatalf("deep introspection = %v", resp["errors"])
+	}
+
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/graphql", strings.NewReader(`{"variables":{}}`)))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("without query: %d, want 422", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	f.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/graphql/schema", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "workOrderChanged(since: ID, assetId: ID, type: WorkOrderType): WorkOrderEvent!") {
+		t.Fatalf("schema = %d %s", w.Code, w.Body)
+	}
+}
+
+func TestGraphQL_SubscriptionOverSSE(t *testing.T) {
+	f := setup(t)
+	asset := domain.Asset{Name: "Prensa"}
+	if err := f.assets.Create(f.ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	wo := domain.WorkOrder{AssetID: asset.ID, Title: "Vazamento de óleo"}
+	if err := f.workOrders.Create(f.ctx, &wo); err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	srv := httptest.NewServer(f.router)
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	body := `{"query":"subscription { workOrderChanged { revision workOrder { title status asset { name } } } }"}`
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/v1/graphql", strings.NewReader(body))
+	req.Header.Set("Accept", "text/event-stream")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	defer res.Body.Close()
+	if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("content type = %q", ct)
+	}
+
+	events := bufio.NewScanner(res.Body)
+	next := func() map[string]any {
+		t.Helper()
+		for events.Scan() {
+			data, ok := strings.CutPrefix(events.Text(), "data: ")
+			if !ok {
+				continue
+			}
+			var resp map[string]any
+			if err := json.Unmarshal([]byte(data), &resp); err != nil {
+				t.Fatalf("decode %s: %v", data, err)
+			}
+			return resp["data"].(map[string]any)["workOrderChanged"].(map[string]any)["workOrder"].(map[string]any)
+		}
+		t.Fatalf("stream ended: %v", events.Err())
+		return nil
+	}
+
+	if got := next(); got["status"] != "OPEN" || got["asset"].(map[string]any)["name"] != "Prensa" {
+		t.Fatalf("first event = %v", got)
+	}
+	if _, err := f.workOrders.Transition(f.ctx, wo.ID, service.TransitionRequest{Status: domain.WOStatusInProgress}); err != nil {
+		t.Fatalf("transition: %v", err)
+	}
+	if got := next(); got["status"] != "IN_PROGRESS" || got["title"] != "Vazamento de óleo" {
+		t.Fatalf("second event = %v", got)
+	}
+}
@@ -0,0 +1,185 @@
+package graphqlapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	gqlerrors "github.com/graph-gophers/graphql-go/errors"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+const (
+	// listSize estima o tamanho das listas sem first (planos, linhas de relatório).
+	listSize = 10
+	// maxVisited corta a análise de consultas cujos fragmentos se multiplicam.
+	maxVisited = 10000
+)
+
+// reportFields são os campos de relatório, que custam reportCost.
+var reportFields = map[string]bool{
+	"Query.downtimeReport": true,
+	"Query.slaReport":      true,
+	"Query.pareto":         true,
+	"Query.oee":            true,
+}
+
+// limits valida a consulta contra o mesmo schema antes da execução e recusa a
+// que passa da profundidade ou da complexidade máximas. O executor não mede
+// custo, então a análise é feita aqui, com as variáveis e os argumentos padrão
+// já aplicados. Os campos de introspecção (__schema, __type, __typename) não
+// contam: a consulta de introspecção das IDEs passa da profundidade máxima.
+type limits struct {
+	schema *ast.Schema
+}
+
+func newLimits() (*limits, error) {
+	s, err := gqlparser.LoadSchema(&ast.Source{Name: "schema.graphql", Input: sdl})
+	if err != nil {
+		return nil, err
+	}
+	return &limits{schema: s}, nil
+}
+
+// check devolve o tipo da operação escolhida ou os erros a responder no lugar
+// da execução.
+func (l *limits) check(req request) (ast.Operation, []*gqlerrors.QueryError) {
+	doc, err := parser.ParseQuery(&ast.Source{Input: req.Query})
+	if err != nil {
+		return "", queryErrors("GRAPHQL_PARSE_FAILED", asGQLError(err))
+	}
+	if errs := validator.ValidateWithRules(l.schema, doc, nil); len(errs) > 0 {
+		return "", queryErrors("GRAPHQL_VALIDATION_FAILED", errs...)
+	}
+	op := doc.Operations.ForName(req.OperationName)
+	if op == nil {
+		msg := fmt.Sprintf("operação %q não encontrada", req.OperationName)
+		if req.OperationName == "" {
+			msg = "informe operationName para escolher entre as operações"
+		}
+		return "", []*gqlerrors.QueryError{{Message: msg, Extensions: code("GRAPHQL_VALIDATION_FAILED")}}
+	}
+	vars, err := validator.VariableValues(l.schema, op, req.Variables)
+	if err != nil {
+		return "", queryErrors("GRAPHQL_VALIDATION_FAILED", asGQLError(err))
+	}
+
+	w := &walker{vars: vars}
+	cost := w.walk(op.SelectionSet, 1)
+	at := []gqlerrors.Location{{Line: op.Position.Line, Column: op.Position.Column}}
+	switch {
+	case w.visited > maxVisited:
+		return "", []*gqlerrors.QueryError{{Message: "consulta grande demais", Locations: at,
+			Extensions: code("QUERY_TOO_COMPLEX")}}
+	case w.depth > maxDepth:
+		return "", []*gqlerrors.QueryError{{Message: fmt.Sprintf("consulta aninhada demais: profundidade %d, máximo %d", w.depth, maxDepth),
+			Locations: at, Extensions: code("QUERY_TOO_DEEP")}}
+	case cost > maxComplexity:
+		return "", []*gqlerrors.QueryError{{Message: fmt.Sprintf("consulta complexa demais: custo %d, máximo %d", cost, maxComplexity),
+			Locations: at, Extensions: code("QUERY_TOO_COMPLEX")}}
+	}
+	return op.Operation, nil
+}
+
+// walker soma o custo das seleções incluídas: cada campo vale 1 (relatórios,
+// reportCost) e o campo objeto soma o custo da seleção filha vezes o tamanho
+// da lista (first, ou listSize sem first).
+type walker struct {
+	vars    map[string]any
+	depth   int
+	visited int
+}
+
+func (w *walker) walk(sels ast.SelectionSet, depth int) int {
+	cost := 0
+	for _, sel := range sels {
+		if w.visited > maxVisited {
+			return cost
+		}
+		switch s := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(s.Name, "__") || !w.included(s.Directives) {
+				continue
+			}
+			w.visited++
+			w.depth = max(w.depth, depth)
+			fieldCost := 1
+			if reportFields[s.ObjectDefinition.Name+"."+s.Name] {
+				fieldCost = reportCost
+			}
+			if len(s.SelectionSet) > 0 {
+				fieldCost = addCost(fieldCost, mulCost(w.multiplier(s), w.walk(s.SelectionSet, depth+1)))
+			}
+			cost = addCost(cost, fieldCost)
+		case *ast.InlineFragment:
+			if w.included(s.Directives) {
+				cost = addCost(cost, w.walk(s.SelectionSet, depth))
+			}
+		case *ast.FragmentSpread:
+			if w.included(s.Directives) {
+				cost = addCost(cost, w.walk(s.Definition.SelectionSet, depth))
+			}
+		}
+	}
+	return cost
+}
+
+// included aplica @skip e @include.
+func (w *walker) included(dirs ast.DirectiveList) bool {
+	for _, d := range dirs {
+		cond, _ := d.ArgumentMap(w.vars)["if"].(bool)
+		if (d.Name == "skip" && cond) || (d.Name == "include" && !cond) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *walker) multiplier(f *ast.Field) int {
+	if f.Definition.Type.Elem == nil {
+		return 1
+	}
+	switch n := f.ArgumentMap(w.vars)["first"].(type) {
+	case int64:
+		return int(max(n, 0))
+	case float64:
+		return int(max(n, 0))
+	}
+	return listSize
+}
+
+func addCost(a, b int) int { return min(a+b, math.MaxInt32) }
+
+func mulCost(a, b int) int {
+	if a != 0 && b > math.MaxInt32/a {
+		return math.MaxInt32
+	}
+	return a * b
+}
+
+func code(c string) map[string]any { return map[string]any{"code": c} }
+
+// queryErrors converte os erros do parser e da validação.
+func queryErrors(c string, errs ...*gqlerror.Error) []*gqlerrors.QueryError {
+	out := make([]*gqlerrors.QueryError, len(errs))
+	for i, err := range errs {
+		out[i] = &gqlerrors.QueryError{Message: err.Message, Extensions: code(c)}
+		for _, loc := range err.Locations {
+			out[i].Locations = append(out[i].Locations, gqlerrors.Location{Line: loc.Line, Column: loc.Column})
+		}
+	}
+	return out
+}
+
+func asGQLError(err error) *gqlerror.Error {
+	var gqlErr *gqlerror.Error
+	if errors.As(err, &gqlErr) {
+		return gqlErr
+	}
+	return gqlerror.Wrap(err)
+}
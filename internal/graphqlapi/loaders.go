@@ -0,0 +1,232 @@
+package graphqlapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/graph-gophers/dataloader"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// loaders são os dataloaders de uma execução (uma consulta ou um evento de
+// subscription). Cada um junta os pedidos feitos em paralelo pelos itens de
+// um nível da resposta numa consulta só e guarda o resultado até o fim da
+// execução: o mesmo ativo pedido em pontos diferentes não volta ao banco.
+type loaders struct {
+	assets     *dataloader.Loader // *domain.Asset (nil se não existe) por id
+	workOrders *dataloader.Loader // []domain.WorkOrder por assetOrdersKey
+	downtime   *dataloader.Loader // []domain.DowntimeEvent por assetDowntimeKey
+	// allPlans traz todos os planos do site de uma vez (são poucos por site);
+	// os campos de plano por id e por ativo saem dessa lista.
+	allPlans *dataloader.Loader
+}
+
+func newLoaders(svc Services) *loaders {
+	return &loaders{
+		assets:     dataloader.NewBatchedLoader(batchAssets(svc)),
+		workOrders: dataloader.NewBatchedLoader(batchWorkOrders(svc)),
+		downtime:   dataloader.NewBatchedLoader(batchDowntime(svc)),
+		allPlans:   dataloader.NewBatchedLoader(batchPlans(svc)),
+	}
+}
+
+type loadersKey struct{}
+
+func withLoaders(ctx context.Context, svc Services) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(svc))
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+// load espera o lote da chave e devolve o valor com o tipo do loader.
+func load[T any](ctx context.Context, l *dataloader.Loader, key dataloader.Key) (T, error) {
+	v, err := l.Load(ctx, key)()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	t, _ := v.(T)
+	return t, nil
+}
+
+// failAll responde o mesmo erro para todas as chaves do lote.
+func failAll(keys dataloader.Keys, err error) []*dataloader.Result {
+	out := make([]*dataloader.Result, len(keys))
+	for i := range out {
+		out[i] = &dataloader.Result{Error: err}
+	}
+	return out
+}
+
+type idKey int64
+
+func (k idKey) String() string { return strconv.FormatInt(int64(k), 10) }
+func (k idKey) Raw() any       { return int64(k) }
+
+func (l *loaders) asset(ctx context.Context, id int64) (*domain.Asset, error) {
+	return load[*domain.Asset](ctx, l.assets, idKey(id))
+}
+
+// primeAsset guarda um ativo já lido por outro caminho (a lista de ativos).
+func (l *loaders) primeAsset(ctx context.Context, a *domain.Asset) {
+	l.assets.Prime(ctx, idKey(a.ID), a)
+}
+
+// assetResolver resolve o ativo referenciado por OS, planos e linhas de
+// relatório; ativo fora do escopo do usuário vira null.
+func (l *loaders) assetResolver(ctx context.Context, r *resolver, id int64) (*assetResolver, error) {
+	a, err := l.asset(ctx, id)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	return &assetResolver{r: r, l: l, a: a}, nil
+}
+
+func batchAssets(svc Services) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		ids := make([]int64, len(keys))
+		for i, k := range keys {
+			ids[i] = k.Raw().(int64)
+		}
+		list, err := svc.Assets.List(ctx, domain.AssetFilter{IDs: ids})
+		if err != nil {
+			return failAll(keys, err)
+		}
+		found := make(map[int64]*domain.Asset, len(list))
+		for i := range list {
+			found[list[i].ID] = &list[i]
+		}
+		out := make([]*dataloader.Result, len(keys))
+		for i, id := range ids {
+			out[i] = &dataloader.Result{Data: found[id]}
+		}
+		return out
+	}
+}
+
+// assetOrdersKey pede as OS de um ativo com os argumentos do campo; ativos
+// pedidos com os mesmos argumentos vão na mesma consulta (filtro PerAsset).
+type assetOrdersKey struct {
+	assetID int64
+	status  domain.WorkOrderStatus
+	typ     domain.WorkOrderType
+	open    bool
+	first   int
+}
+
+func (k assetOrdersKey) String() string {
+	return fmt.Sprintf("%d/%s/%s/%t/%d", k.assetID, k.status, k.typ, k.open, k.first)
+}
+
+func (k assetOrdersKey) Raw() any { return k }
+
+func (l *loaders) assetWorkOrders(ctx context.Context, key assetOrdersKey) ([]domain.WorkOrder, error) {
+	return load[[]domain.WorkOrder](ctx, l.workOrders, key)
+}
+
+func batchWorkOrders(svc Services) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		out := make([]*dataloader.Result, len(keys))
+		groups := map[assetOrdersKey][]int{} // argumentos (sem o ativo) → posições
+		for i, k := range keys {
+			args := k.Raw().(assetOrdersKey)
+			args.assetID = 0
+			groups[args] = append(groups[args], i)
+		}
+		for args, positions := range groups {
+			filter := domain.WorkOrderFilter{Status: args.status, Type: args.typ, Open: args.open, PerAsset: args.first}
+			for _, i := range positions {
+				filter.AssetIDs = append(filter.AssetIDs, keys[i].Raw().(assetOrdersKey).assetID)
+			}
+			list, err := svc.WorkOrders.List(ctx, filter)
+			byAsset := map[int64][]domain.WorkOrder{}
+			for _, o := range list {
+				byAsset[o.AssetID] = append(byAsset[o.AssetID], o)
+			}
+			for j, i := range positions {
+				if err != nil {
+					out[i] = &dataloader.Result{Error: err}
+					continue
+				}
+				out[i] = &dataloader.Result{Data: newest(byAsset[filter.AssetIDs[j]], args.first)}
+			}
+		}
+		return out
+	}
+}
+
+// assetDowntimeKey pede as paradas de um ativo iniciadas no período; to zero
+// deixa o período aberto (inclui as paradas em andamento).
+type assetDowntimeKey struct {
+	assetID  int64
+	from, to time.Time
+}
+
+func (k assetDowntimeKey) String() string {
+	return fmt.Sprintf("%d/%d/%d", k.assetID, k.from.UnixNano(), k.to.UnixNano())
+}
+
+func (k assetDowntimeKey) Raw() any { return k }
+
+func (l *loaders) assetDowntime(ctx context.Context, key assetDowntimeKey) ([]domain.DowntimeEvent, error) {
+	return load[[]domain.DowntimeEvent](ctx, l.downtime, key)
+}
+
+func batchDowntime(svc Services) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		type period struct{ from, to int64 }
+		out := make([]*dataloader.Result, len(keys))
+		groups := map[period][]int{}
+		for i, k := range keys {
+			key := k.Raw().(assetDowntimeKey)
+			p := period{key.from.UnixNano(), key.to.UnixNano()}
+			groups[p] = append(groups[p], i)
+		}
+		for _, positions := range groups {
+			first := keys[positions[0]].Raw().(assetDowntimeKey)
+			from := first.from
+			var to *time.Time
+			if !first.to.IsZero() {
+				to = &first.to
+			}
+			ids := make([]int64, len(positions))
+			for j, i := range positions {
+				ids[j] = keys[i].Raw().(assetDowntimeKey).assetID
+			}
+			byAsset, err := svc.Downtime.ListByAssets(ctx, ids, &from, to)
+			for j, i := range positions {
+				if err != nil {
+					out[i] = &dataloader.Result{Error: err}
+					continue
+				}
+				out[i] = &dataloader.Result{Data: byAsset[ids[j]]}
+			}
+		}
+		return out
+	}
+}
+
+var allPlansKey = dataloader.StringKey("all")
+
+// plans devolve os planos do site, lidos uma vez por execução.
+func (l *loaders) plans(ctx context.Context) ([]domain.MaintenancePlan, error) {
+	return load[[]domain.MaintenancePlan](ctx, l.allPlans, allPlansKey)
+}
+
+func batchPlans(svc Services) dataloader.BatchFunc {
+	return func(ctx context.Context, keys dataloader.Keys) []*dataloader.Result {
+		list, err := svc.MaintenancePlans.List(ctx)
+		if err != nil {
+			return failAll(keys, err)
+		}
+		out := make([]*dataloader.Result, len(keys))
+		for i := range out {
+			out[i] = &dataloader.Result{Data: list}
+		}
+		return out
+	}
+}
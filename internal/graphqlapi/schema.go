@@ -0,0 +1,433 @@
+// Package graphqlapi publica as consultas do dashboard em /v1/graphql: ativos,
+// OS, planos e relatórios sobre os mesmos serviços da API REST, no escopo de
+// site do usuário. O schema fica em schema.graphql e é executado pelo
+// graph-gophers/graphql-go; os campos aninhados (OS do ativo, ativo da OS,
+// paradas...) passam por dataloaders, que juntam numa consulta só os pedidos
+// do mesmo nível da resposta. A subscription workOrderChanged segue o feed de
+// revisões das OS.
+package graphqlapi
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+// Services são os serviços consultados pelo schema.
+type Services struct {
+	Assets           *service.AssetService
+	WorkOrders       *service.WorkOrderService
+	MaintenancePlans *service.MaintenancePlanService
+	Downtime         *service.DowntimeService
+	Reports          *service.ReportService
+}
+
+// Limites das consultas. Listas aceitam first até maxFirst (o padrão de cada
+// lista está no schema); os relatórios pesam reportCost na complexidade porque
+// agregam o período inteiro no banco.
+const (
+	maxDepth      = 8
+	maxComplexity = 5000
+	maxFirst      = 500
+	reportCost    = 50
+)
+
+//go:embed schema.graphql
+var sdl string
+
+// resolver é a raiz das queries e da subscription.
+type resolver struct {
+	svc Services
+	now func() time.Time
+}
+
+// NewSchema monta o executor sobre schema.graphql. Os campos de um nível e os
+// itens das listas resolvem em paralelo; o paralelismo acompanha maxFirst
+// para que os pedidos de uma lista inteira caiam no mesmo lote dos dataloaders.
+func NewSchema(svc Services, opts ...graphql.SchemaOpt) (*graphql.Schema, error) {
+	r := &resolver{svc: svc, now: time.Now}
+	return graphql.ParseSchema(sdl, r, append([]graphql.SchemaOpt{
+		graphql.UseStringDescriptions(),
+		graphql.UseFieldResolvers(),
+		graphql.MaxParallelism(maxFirst),
+		graphql.SubscribeResolverTimeout(eventTimeout),
+	}, opts...)...)
+}
+
+// Argumentos compartilhados.
+type (
+	periodArgs struct {
+		From *dateTime
+		To   *dateTime
+	}
+	filterArgs struct {
+		AssetID  *graphql.ID
+		Location *string
+	}
+)
+
+func (r *resolver) Asset(ctx context.Context, args struct{ ID graphql.ID }) (*assetResolver, error) {
+	id, err := parseID(&args.ID)
+	if err != nil {
+		return nil, err
+	}
+	l := loadersFrom(ctx)
+	a, err := l.asset(ctx, id)
+	if err != nil || a == nil {
+		return nil, err
+	}
+	return &assetResolver{r: r, l: l, a: a}, nil
+}
+
+func (r *resolver) Assets(ctx context.Context, args struct {
+	First       int32
+	Location    *string
+	Criticality *string
+	Class       *string
+}) ([]*assetResolver, error) {
+	n, err := first(args.First)
+	if err != nil {
+		return nil, err
+	}
+	filter := domain.AssetFilter{Location: deref(args.Location), Class: deref(args.Class)}
+	if args.Criticality != nil {
+		filter.Criticality = enumValue(*args.Criticality, domain.CriticalityA, domain.CriticalityB, domain.CriticalityC)
+	}
+
+	var list []domain.Asset
+	stop := errors.New("first atingido")
+	err = r.svc.Assets.Stream(ctx, filter, func(a *domain.Asset) error {
+		if len(list) == n {
+			return stop
+		}
+		list = append(list, *a)
+		return nil
+	})
+	if err != nil && !errors.Is(err, stop) {
+		return nil, err
+	}
+	l := loadersFrom(ctx)
+	out := make([]*assetResolver, len(list))
+	for i := range list {
+		l.primeAsset(ctx, &list[i])
+		out[i] = &assetResolver{r: r, l: l, a: &list[i]}
+	}
+	return out, nil
+}
+
+func (r *resolver) WorkOrder(ctx context.Context, args struct{ ID graphql.ID }) (*workOrderResolver, error) {
+	id, err := parseID(&args.ID)
+	if err != nil {
+		return nil, err
+	}
+	o, err := r.svc.WorkOrders.Get(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &workOrderResolver{r: r, l: loadersFrom(ctx), o: o}, nil
+}
+
+func (r *resolver) WorkOrders(ctx context.Context, args struct {
+	First   int32
+	Status  *string
+	Type    *string
+	AssetID *graphql.ID
+	Open    bool
+	Overdue bool
+	periodArgs
+}) ([]*workOrderResolver, error) {
+	n, err := first(args.First)
+	if err != nil {
+		return nil, err
+	}
+	filter := domain.WorkOrderFilter{Status: status(args.Status), Type: orderType(args.Type), Open: args.Open}
+	if filter.AssetID, err = parseID(args.AssetID); err != nil {
+		return nil, err
+	}
+	if args.Overdue {
+		now := r.now()
+		filter.OverdueAt = &now
+	}
+	filter.From, filter.To = args.From.ptr(), args.To.ptr()
+	list, err := r.svc.WorkOrders.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return r.workOrders(loadersFrom(ctx), newest(list, n)), nil
+}
+
+func (r *resolver) MaintenancePlans(ctx context.Context, args struct {
+	AssetID *graphql.ID
+	Active  *bool
+}) ([]*planResolver, error) {
+	assetID, err := parseID(args.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	l := loadersFrom(ctx)
+	all, err := l.plans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := []*planResolver{}
+	for i := range all {
+		p := &all[i]
+		if (assetID == 0 || p.AssetID == assetID) && (args.Active == nil || p.Active == *args.Active) {
+			out = append(out, &planResolver{r: r, l: l, p: p})
+		}
+	}
+	return out, nil
+}
+
+func (r *resolver) DowntimeReport(ctx context.Context, args periodArgs) ([]*downtimeReportRow, error) {
+	from, to := r.lastMonths()
+	from, to, err := args.period(from, to)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.svc.Reports.MonthlyDowntime(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	l := loadersFrom(ctx)
+	out := make([]*downtimeReportRow, len(rows))
+	for i, row := range rows {
+		out[i] = newDowntimeReportRow(r, l, row)
+	}
+	return out, nil
+}
+
+func (r *resolver) SLAReport(ctx context.Context, args periodArgs) ([]*slaReportRow, error) {
+	from, to := r.lastMonths()
+	from, to, err := args.period(from, to)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.svc.Reports.MonthlySLA(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]*slaReportRow, len(rows))
+	for i, row := range rows {
+		out[i] = newSLAReportRow(row)
+	}
+	return out, nil
+}
+
+func (r *resolver) Pareto(ctx context.Context, args struct {
+	periodArgs
+	SortBy string
+	filterArgs
+}) (*paretoReport, error) {
+	from, to := r.lastMonths()
+	from, to, err := args.period(from, to)
+	if err != nil {
+		return nil, err
+	}
+	filter := domain.ParetoFilter{Location: deref(args.Location)}
+	if filter.AssetID, err = parseID(args.AssetID); err != nil {
+		return nil, err
+	}
+	sortBy := enumValue(args.SortBy, domain.ParetoByCount, domain.ParetoByDowntime)
+	if sortBy == "" {
+		sortBy = domain.ParetoByCount
+	}
+	report, err := r.svc.Reports.Pareto(ctx, from, to, sortBy, filter)
+	if err != nil {
+		return nil, err
+	}
+	return newParetoReport(report), nil
+}
+
+func (r *resolver) OEE(ctx context.Context, args struct {
+	periodArgs
+	Granularity string
+	filterArgs
+}) (*oeeReport, error) {
+	from, to := r.lastDays(7)
+	from, to, err := args.period(from, to)
+	if err != nil {
+		return nil, err
+	}
+	filter := domain.OEEFilter{Location: deref(args.Location)}
+	if filter.AssetID, err = parseID(args.AssetID); err != nil {
+		return nil, err
+	}
+	g := enumValue(args.Granularity, domain.OEEByShift, domain.OEEByDay, domain.OEEByWeek)
+	if g == "" {
+		g = domain.OEEByDay
+	}
+	report, err := r.svc.Reports.OEE(ctx, from, to, g, filter)
+	if err != nil {
+		return nil, err
+	}
+	return newOEEReport(report), nil
+}
+
+// WorkOrderChanged entrega as OS em aberto e, depois, cada OS alterada. Cada
+// evento é resolvido com dataloaders próprios: o ativo lido num evento não
+// fica velho para o seguinte. Se o feed falhar, o último evento leva o erro.
+func (r *resolver) WorkOrderChanged(ctx context.Context, args struct {
+	Since   *graphql.ID
+	AssetID *graphql.ID
+	Type    *string
+}) (<-chan *workOrderEvent, error) {
+	var since int64
+	if args.Since != nil {
+		var err error
+		if since, err = strconv.ParseInt(string(*args.Since), 10, 64); err != nil {
+			return nil, domain.ErrInvalidInput
+		}
+	}
+	filter := domain.WorkOrderFilter{Type: orderType(args.Type)}
+	var err error
+	if filter.AssetID, err = parseID(args.AssetID); err != nil {
+		return nil, err
+	}
+
+	events := make(chan *workOrderEvent)
+	send := func(e *workOrderEvent) error {
+		select {
+		case events <- e:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	go func() {
+		defer close(events)
+		err := r.svc.WorkOrders.Watch(ctx, since, filter, func(o *domain.WorkOrder) error {
+			l := newLoaders(r.svc)
+			return send(&workOrderEvent{revision: o.Revision, order: &workOrderResolver{r: r, l: l, o: o}})
+		})
+		if err != nil && ctx.Err() == nil {
+			_ = send(&workOrderEvent{err: err})
+		}
+	}()
+	return events, nil
+}
+
+func (r *resolver) workOrders(l *loaders, list []domain.WorkOrder) []*workOrderResolver {
+	out := make([]*workOrderResolver, len(list))
+	for i := range list {
+		out[i] = &workOrderResolver{r: r, l: l, o: &list[i]}
+	}
+	return out
+}
+
+// newest devolve as n OS criadas por último, da mais nova para a mais antiga
+// (created_at e id decrescentes, o mesmo corte do filtro PerAsset).
+func newest(list []domain.WorkOrder, n int) []domain.WorkOrder {
+	list = slices.Clone(list)
+	slices.SortStableFunc(list, func(a, b domain.WorkOrder) int {
+		if c := b.CreatedAt.Compare(a.CreatedAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(b.ID, a.ID)
+	})
+	return list[:min(n, len(list))]
+}
+
+// latest devolve os n últimos itens (a lista vem em ordem de id) do mais
+// recente para o mais antigo.
+func latest[T any](list []T, n int) []T {
+	out := make([]T, 0, min(n, len(list)))
+	for i := len(list) - 1; i >= 0 && len(out) < n; i-- {
+		out = append(out, list[i])
+	}
+	return out
+}
+
+// parseID lê um argumento ID (inteiro positivo em texto); ausente vale 0.
+func parseID(v *graphql.ID) (int64, error) {
+	if v == nil {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(string(*v), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, domain.ErrInvalidInput
+	}
+	return id, nil
+}
+
+// first valida o tamanho pedido da lista (o padrão vem do schema).
+func first(n int32) (int, error) {
+	if n < 0 || n > maxFirst {
+		return 0, domain.ErrInvalidInput
+	}
+	return int(n), nil
+}
+
+// period aplica from/to sobre o período padrão.
+func (a periodArgs) period(from, to time.Time) (time.Time, time.Time, error) {
+	if a.From != nil {
+		from = a.From.t
+	}
+	if a.To != nil {
+		to = a.To.t
+	}
+	if !from.Before(to) {
+		return from, to, domain.ErrInvalidInput
+	}
+	return from, to, nil
+}
+
+// lastMonths é o período padrão dos relatórios REST: os últimos 12 meses
+// fechando no fim do mês corrente, no fuso da planta.
+func (r *resolver) lastMonths() (time.Time, time.Time) {
+	now := r.now().In(plant.Location())
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	return monthStart.AddDate(0, -11, 0), monthStart.AddDate(0, 1, 0)
+}
+
+// lastDays cobre os n dias até o fim de hoje, no fuso da planta.
+func (r *resolver) lastDays(n int) (time.Time, time.Time) {
+	now := r.now().In(plant.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return today.AddDate(0, 0, 1-n), today.AddDate(0, 0, 1)
+}
+
+// enumValue traduz o valor do enum GraphQL (IN_PROGRESS) para o do domínio
+// (in_progress). A validação da consulta já garantiu que o nome existe.
+func enumValue[T ~string](name string, values ...T) T {
+	for _, v := range values {
+		if strings.ToUpper(string(v)) == name {
+			return v
+		}
+	}
+	return ""
+}
+
+func status(name *string) domain.WorkOrderStatus {
+	if name == nil {
+		return ""
+	}
+	return enumValue(*name, domain.WOStatusOpen, domain.WOStatusInProgress, domain.WOStatusDone, domain.WOStatusCanceled)
+}
+
+func orderType(name *string) domain.WorkOrderType {
+	if name == nil {
+		return ""
+	}
+	return enumValue(*name, domain.WOTypeCorrective, domain.WOTypePreventive, domain.WOTypeCondition, domain.WOTypeImprovement)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,485 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+)
+
+// Os resolvers das entidades levam a raiz (serviços e relógio) e os
+// dataloaders da execução, por onde passam os campos aninhados.
+
+type assetResolver struct {
+	r *resolver
+	l *loaders
+	a *domain.Asset
+}
+
+func (a *assetResolver) ID() graphql.ID             { return id(a.a.ID) }
+func (a *assetResolver) SiteID() graphql.ID         { return id(a.a.SiteID) }
+func (a *assetResolver) Name() string               { return a.a.Name }
+func (a *assetResolver) Location() *string          { return optional(a.a.Location) }
+func (a *assetResolver) Criticality() *string       { return enumName(a.a.Criticality) }
+func (a *assetResolver) ExternalCode() *string      { return optional(a.a.ExternalCode) }
+func (a *assetResolver) Manufacturer() *string      { return optional(a.a.Manufacturer) }
+func (a *assetResolver) Model() *string             { return optional(a.a.Model) }
+func (a *assetResolver) SerialNumber() *string      { return optional(a.a.SerialNumber) }
+func (a *assetResolver) InstalledOn() *string       { return optional(a.a.InstalledOn) }
+func (a *assetResolver) WarrantyUntil() *string     { return optional(a.a.WarrantyUntil) }
+func (a *assetResolver) Class() *string             { return optional(a.a.Class) }
+func (a *assetResolver) IdealRatePerHour() *float64 { return a.a.IdealRatePerHour }
+func (a *assetResolver) CreatedAt() dateTime        { return dateTime{a.a.CreatedAt} }
+func (a *assetResolver) UpdatedAt() dateTime        { return dateTime{a.a.UpdatedAt} }
+
+func (a *assetResolver) Attributes() *jsonValue {
+	if a.a.Attributes == nil {
+		return nil
+	}
+	return &jsonValue{a.a.Attributes}
+}
+
+func (a *assetResolver) WorkOrders(ctx context.Context, args struct {
+	First  int32
+	Status *string
+	Type   *string
+	Open   bool
+}) ([]*workOrderResolver, error) {
+	n, err := first(args.First)
+	if err != nil {
+		return nil, err
+	}
+	list, err := a.l.assetWorkOrders(ctx, assetOrdersKey{
+		assetID: a.a.ID,
+		status:  status(args.Status),
+		typ:     orderType(args.Type),
+		open:    args.Open,
+		first:   n,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return a.r.workOrders(a.l, list), nil
+}
+
+func (a *assetResolver) MaintenancePlans(ctx context.Context) ([]*planResolver, error) {
+	all, err := a.l.plans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := []*planResolver{}
+	for i := range all {
+		if all[i].AssetID == a.a.ID {
+			out = append(out, &planResolver{r: a.r, l: a.l, p: &all[i]})
+		}
+	}
+	return out, nil
+}
+
+func (a *assetResolver) DowntimeEvents(ctx context.Context, args struct {
+	periodArgs
+	First int32
+}) ([]*downtimeResolver, error) {
+	n, err := first(args.First)
+	if err != nil {
+		return nil, err
+	}
+	events, err := a.downtime(ctx, args.periodArgs)
+	if err != nil {
+		return nil, err
+	}
+	events = latest(events, n)
+	out := make([]*downtimeResolver, len(events))
+	for i := range events {
+		out[i] = &downtimeResolver{r: a.r, e: &events[i]}
+	}
+	return out, nil
+}
+
+func (a *assetResolver) DowntimeMinutes(ctx context.Context, args periodArgs) (int32, error) {
+	events, err := a.downtime(ctx, args)
+	if err != nil {
+		return 0, err
+	}
+	now := a.r.now()
+	var minutes int64
+	for _, e := range events {
+		if !e.Planned {
+			minutes += e.Minutes(now)
+		}
+	}
+	return int32(minutes), nil
+}
+
+// downtime lê as paradas do ativo iniciadas no período (padrão: dos últimos
+// 30 dias até agora, inclusive as em andamento).
+func (a *assetResolver) downtime(ctx context.Context, args periodArgs) ([]domain.DowntimeEvent, error) {
+	key := assetDowntimeKey{assetID: a.a.ID, from: a.r.now().AddDate(0, 0, -30)}
+	if args.From != nil {
+		key.from = args.From.t
+	}
+	if args.To != nil {
+		if !key.from.Before(args.To.t) {
+			return nil, domain.ErrInvalidInput
+		}
+		key.to = args.To.t
+	}
+	return a.l.assetDowntime(ctx, key)
+}
+
+type workOrderResolver struct {
+	r *resolver
+	l *loaders
+	o *domain.WorkOrder
+}
+
+func (w *workOrderResolver) ID() graphql.ID             { return id(w.o.ID) }
+func (w *workOrderResolver) SiteID() graphql.ID         { return id(w.o.SiteID) }
+func (w *workOrderResolver) AssetID() graphql.ID        { return id(w.o.AssetID) }
+func (w *workOrderResolver) Type() string               { return strings.ToUpper(string(w.o.Type)) }
+func (w *workOrderResolver) Status() string             { return strings.ToUpper(string(w.o.Status)) }
+func (w *workOrderResolver) Priority() *string          { return enumName(w.o.Priority) }
+func (w *workOrderResolver) Title() string              { return w.o.Title }
+func (w *workOrderResolver) Description() *string       { return optional(w.o.Description) }
+func (w *workOrderResolver) BreakdownAt() *dateTime     { return optionalTime(w.o.BreakdownAt) }
+func (w *workOrderResolver) ClosedAt() *dateTime        { return optionalTime(w.o.ClosedAt) }
+func (w *workOrderResolver) DowntimeMinutes() *int32    { return optionalInt(w.o.DowntimeMinutes) }
+func (w *workOrderResolver) Cause() *string             { return optional(w.o.Cause) }
+func (w *workOrderResolver) Solution() *string          { return optional(w.o.Solution) }
+func (w *workOrderResolver) ResponseDueAt() *dateTime   { return optionalTime(w.o.ResponseDueAt) }
+func (w *workOrderResolver) ResolutionDueAt() *dateTime { return optionalTime(w.o.ResolutionDueAt) }
+func (w *workOrderResolver) RespondedAt() *dateTime     { return optionalTime(w.o.RespondedAt) }
+func (w *workOrderResolver) SLABreachedAt() *dateTime   { return optionalTime(w.o.SLABreachedAt) }
+func (w *workOrderResolver) Overdue() bool              { return w.o.IsOverdue(w.r.now()) }
+func (w *workOrderResolver) Trade() *string             { return optional(w.o.Trade) }
+func (w *workOrderResolver) EstimatedMinutes() *int32   { return optionalInt(w.o.EstimatedMinutes) }
+func (w *workOrderResolver) PlanID() *graphql.ID        { return optionalID(w.o.PlanID) }
+func (w *workOrderResolver) ScheduledFor() *dateTime    { return optionalTime(w.o.ScheduledFor) }
+func (w *workOrderResolver) CreatedAt() dateTime        { return dateTime{w.o.CreatedAt} }
+func (w *workOrderResolver) UpdatedAt() dateTime        { return dateTime{w.o.UpdatedAt} }
+
+func (w *workOrderResolver) Asset(ctx context.Context) (*assetResolver, error) {
+	return w.l.assetResolver(ctx, w.r, w.o.AssetID)
+}
+
+func (w *workOrderResolver) Plan(ctx context.Context) (*planResolver, error) {
+	if w.o.PlanID == nil {
+		return nil, nil
+	}
+	all, err := w.l.plans(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(all, func(p domain.MaintenancePlan) bool { return p.ID == *w.o.PlanID })
+	if i < 0 {
+		return nil, nil
+	}
+	return &planResolver{r: w.r, l: w.l, p: &all[i]}, nil
+}
+
+type planResolver struct {
+	r *resolver
+	l *loaders
+	p *domain.MaintenancePlan
+}
+
+func (p *planResolver) ID() graphql.ID              { return id(p.p.ID) }
+func (p *planResolver) SiteID() graphql.ID          { return id(p.p.SiteID) }
+func (p *planResolver) AssetID() graphql.ID         { return id(p.p.AssetID) }
+func (p *planResolver) RuleType() string            { return strings.ToUpper(string(p.p.RuleType)) }
+func (p *planResolver) FrequencyDays() *int32       { return optionalInt(p.p.FrequencyDays) }
+func (p *planResolver) MeterTarget() *int32         { return optionalInt(p.p.MeterTarget) }
+func (p *planResolver) LastExecution() *dateTime    { return optionalTime(p.p.LastExecution) }
+func (p *planResolver) ConditionParameter() *string { return optional(p.p.ConditionParameter) }
+func (p *planResolver) ConditionMin() *float64      { return p.p.ConditionMin }
+func (p *planResolver) ConditionMax() *float64      { return p.p.ConditionMax }
+func (p *planResolver) JobPlanID() *graphql.ID      { return optionalID(p.p.JobPlanID) }
+func (p *planResolver) Trade() *string              { return optional(p.p.Trade) }
+func (p *planResolver) EstimatedMinutes() *int32    { return optionalInt(p.p.EstimatedMinutes) }
+func (p *planResolver) Active() bool                { return p.p.Active }
+func (p *planResolver) CreatedAt() dateTime         { return dateTime{p.p.CreatedAt} }
+func (p *planResolver) UpdatedAt() dateTime         { return dateTime{p.p.UpdatedAt} }
+
+func (p *planResolver) Asset(ctx context.Context) (*assetResolver, error) {
+	return p.l.assetResolver(ctx, p.r, p.p.AssetID)
+}
+
+type downtimeResolver struct {
+	r *resolver
+	e *domain.DowntimeEvent
+}
+
+func (d *downtimeResolver) ID() graphql.ID           { return id(d.e.ID) }
+func (d *downtimeResolver) AssetID() graphql.ID      { return id(d.e.AssetID) }
+func (d *downtimeResolver) WorkOrderID() *graphql.ID { return optionalID(d.e.WorkOrderID) }
+func (d *downtimeResolver) StartedAt() dateTime      { return dateTime{d.e.StartedAt} }
+func (d *downtimeResolver) EndedAt() *dateTime       { return optionalTime(d.e.EndedAt) }
+func (d *downtimeResolver) ReasonCode() string       { return d.e.ReasonCode }
+func (d *downtimeResolver) Planned() bool            { return d.e.Planned }
+func (d *downtimeResolver) Notes() *string           { return optional(d.e.Notes) }
+func (d *downtimeResolver) Minutes() int32           { return int32(d.e.Minutes(d.r.now())) }
+
+// workOrderEvent é um evento da subscription; err é a falha do feed, entregue
+// como erro dos campos do último evento.
+type workOrderEvent struct {
+	revision int64
+	order    *workOrderResolver
+	err      error
+}
+
+func (e *workOrderEvent) Revision() (graphql.ID, error) {
+	return id(e.revision), e.err
+}
+
+func (e *workOrderEvent) WorkOrder() (*workOrderResolver, error) {
+	return e.order, e.err
+}
+
+// Linhas dos relatórios: dados prontos, lidos pelos campos do struct.
+
+type downtimeReportRow struct {
+	Month           string
+	AssetID         graphql.ID
+	AssetName       string
+	Location        *string
+	Breakdowns      int32
+	DowntimeMinutes int32
+	PlannedMinutes  int32
+
+	r        *resolver
+	l        *loaders
+	rowAsset int64
+}
+
+func newDowntimeReportRow(r *resolver, l *loaders, row domain.DowntimeReportRow) *downtimeReportRow {
+	return &downtimeReportRow{
+		Month:           row.Month,
+		AssetID:         id(row.AssetID),
+		AssetName:       row.AssetName,
+		Location:        optional(row.Location),
+		Breakdowns:      int32(row.Breakdowns),
+		DowntimeMinutes: int32(row.DowntimeMinutes),
+		PlannedMinutes:  int32(row.PlannedMinutes),
+		r:               r,
+		l:               l,
+		rowAsset:        row.AssetID,
+	}
+}
+
+func (d *downtimeReportRow) Asset(ctx context.Context) (*assetResolver, error) {
+	return d.l.assetResolver(ctx, d.r, d.rowAsset)
+}
+
+type slaReportRow struct {
+	Month                string
+	Orders               int32
+	ResponseEvaluated    int32
+	ResponseMet          int32
+	ResponseCompliance   *float64
+	ResolutionEvaluated  int32
+	ResolutionMet        int32
+	ResolutionCompliance *float64
+}
+
+func newSLAReportRow(row domain.SLAReportRow) *slaReportRow {
+	return &slaReportRow{
+		Month:                row.Month,
+		Orders:               int32(row.Orders),
+		ResponseEvaluated:    int32(row.ResponseEvaluated),
+		ResponseMet:          int32(row.ResponseMet),
+		ResponseCompliance:   row.ResponseCompliance,
+		ResolutionEvaluated:  int32(row.ResolutionEvaluated),
+		ResolutionMet:        int32(row.ResolutionMet),
+		ResolutionCompliance: row.ResolutionCompliance,
+	}
+}
+
+type paretoReport struct {
+	SortBy       string
+	Rows         []*paretoRow
+	Unclassified int32
+}
+
+type paretoRow struct {
+	FailureModeID   graphql.ID
+	Code            string
+	Name            string
+	Count           int32
+	DowntimeMinutes int32
+	Share           float64
+	CumulativeShare float64
+}
+
+func newParetoReport(report *domain.ParetoReport) *paretoReport {
+	out := &paretoReport{
+		SortBy:       strings.ToUpper(string(report.SortBy)),
+		Rows:         make([]*paretoRow, len(report.Rows)),
+		Unclassified: int32(report.Unclassified),
+	}
+	for i, row := range report.Rows {
+		out.Rows[i] = &paretoRow{
+			FailureModeID:   id(row.FailureModeID),
+			Code:            row.Code,
+			Name:            row.Name,
+			Count:           int32(row.Count),
+			DowntimeMinutes: int32(row.DowntimeMinutes),
+			Share:           row.Share,
+			CumulativeShare: row.CumulativeShare,
+		}
+	}
+	return out
+}
+
+type oeeReport struct {
+	Granularity string
+	From        dateTime
+	To          dateTime
+	Assets      []*oeeRow
+	Locations   []*oeeRow
+}
+
+type oeeRow struct {
+	Period          string
+	PeriodStart     dateTime
+	Shift           *string
+	AssetID         *graphql.ID // nulo nas linhas consolidadas por local
+	AssetName       *string
+	Location        string
+	PlannedMinutes  float64
+	DowntimeMinutes float64
+	RunMinutes      float64
+	TotalCount      int32
+	GoodCount       int32
+	Availability    *float64
+	Performance     *float64
+	Quality         *float64
+	OEE             *float64
+}
+
+func newOEEReport(report *domain.OEEReport) *oeeReport {
+	rows := func(list []domain.OEERow) []*oeeRow {
+		out := make([]*oeeRow, len(list))
+		for i, row := range list {
+			out[i] = &oeeRow{
+				Period:          row.Period,
+				PeriodStart:     dateTime{row.PeriodStart},
+				Shift:           optional(row.Shift),
+				AssetName:       optional(row.AssetName),
+				Location:        row.Location,
+				PlannedMinutes:  row.PlannedMinutes,
+				DowntimeMinutes: row.DowntimeMinutes,
+				RunMinutes:      row.RunMinutes,
+				TotalCount:      int32(row.TotalCount),
+				GoodCount:       int32(row.GoodCount),
+				Availability:    row.Availability,
+				Performance:     row.Performance,
+				Quality:         row.Quality,
+				OEE:             row.OEE,
+			}
+			if row.AssetID != 0 {
+				out[i].AssetID = optionalID(&row.AssetID)
+			}
+		}
+		return out
+	}
+	return &oeeReport{
+		Granularity: strings.ToUpper(string(report.Granularity)),
+		From:        dateTime{report.From},
+		To:          dateTime{report.To},
+		Assets:      rows(report.Assets),
+		Locations:   rows(report.Locations),
+	}
+}
+
+// dateTime é o escalar DateTime: RFC 3339 na saída; na entrada também aceita
+// AAAA-MM-DD, meia-noite no fuso da planta.
+type dateTime struct {
+	t time.Time
+}
+
+func (dateTime) ImplementsGraphQLType(name string) bool { return name == "DateTime" }
+
+func (d *dateTime) UnmarshalGraphQL(input any) error {
+	if s, ok := input.(string); ok {
+		if t, err := time.ParseInLocation("2006-01-02", s, plant.Location()); err == nil {
+			d.t = t
+			return nil
+		}
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			d.t = t
+			return nil
+		}
+	}
+	return fmt.Errorf("DateTime não aceita %v", input)
+}
+
+func (d dateTime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.t.Format(time.RFC3339Nano))
+}
+
+func (d *dateTime) ptr() *time.Time {
+	if d == nil {
+		return nil
+	}
+	return &d.t
+}
+
+// jsonValue é o escalar JSON (atributos customizados do ativo), só de saída.
+type jsonValue struct {
+	v any
+}
+
+func (jsonValue) ImplementsGraphQLType(name string) bool { return name == "JSON" }
+
+func (j *jsonValue) UnmarshalGraphQL(input any) error {
+	j.v = input
+	return nil
+}
+
+func (j jsonValue) MarshalJSON() ([]byte, error) { return json.Marshal(j.v) }
+
+func id(n int64) graphql.ID { return graphql.ID(strconv.FormatInt(n, 10)) }
+
+func optionalID(n *int64) *graphql.ID {
+	if n == nil {
+		return nil
+	}
+	v := id(*n)
+	return &v
+}
+
+// optional troca o texto vazio (campo não preenchido) por null.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// enumName publica o valor do domínio como nome do enum (in_progress →
+// IN_PROGRESS); vazio vira null.
+func enumName[T ~string](v T) *string {
+	return optional(strings.ToUpper(string(v)))
+}
+
+func optionalInt(n *int64) *int32 {
+	if n == nil {
+		return nil
+	}
+	v := int32(*n)
+	return &v
+}
+
+func optionalTime(t *time.Time) *dateTime {
+	if t == nil {
+		return nil
+	}
+	return &dateTime{*t}
+}
@@ -0,0 +1,158 @@
+package grpcapi
+
+import (
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	pb "github.com/maxwellsouza/go-factory-maintenance/internal/grpcapi/maintenancev1"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var criticalities = map[domain.Criticality]pb.Criticality{
+	domain.CriticalityA: pb.Criticality_CRITICALITY_A,
+	domain.CriticalityB: pb.Criticality_CRITICALITY_B,
+	domain.CriticalityC: pb.Criticality_CRITICALITY_C,
+}
+
+var workOrderTypes = map[domain.WorkOrderType]pb.WorkOrderType{
+	domain.WOTypeCorrective:  pb.WorkOrderType_WORK_ORDER_TYPE_CORRECTIVE,
+	domain.WOTypePreventive:  pb.WorkOrderType_WORK_ORDER_TYPE_PREVENTIVE,
+	domain.WOTypeCondition:   pb.WorkOrderType_WORK_ORDER_TYPE_CONDITION,
+	domain.WOTypeImprovement: pb.WorkOrderType_WORK_ORDER_TYPE_IMPROVEMENT,
+}
+
+var workOrderStatuses = map[domain.WorkOrderStatus]pb.WorkOrderStatus{
+	domain.WOStatusOpen:       pb.WorkOrderStatus_WORK_ORDER_STATUS_OPEN,
+	domain.WOStatusInProgress: pb.WorkOrderStatus_WORK_ORDER_STATUS_IN_PROGRESS,
+	domain.WOStatusDone:       pb.WorkOrderStatus_WORK_ORDER_STATUS_DONE,
+	domain.WOStatusCanceled:   pb.WorkOrderStatus_WORK_ORDER_STATUS_CANCELED,
+}
+
+var priorities = map[domain.Priority]pb.Priority{
+	domain.PriorityUrgent: pb.Priority_PRIORITY_URGENT,
+	domain.PriorityHigh:   pb.Priority_PRIORITY_HIGH,
+	domain.PriorityNormal: pb.Priority_PRIORITY_NORMAL,
+	domain.PriorityLow:    pb.Priority_PRIORITY_LOW,
+}
+
+var planRuleTypes = map[domain.PlanRuleType]pb.PlanRuleType{
+	domain.PlanRuleTime:      pb.PlanRuleType_PLAN_RULE_TYPE_TIME,
+	domain.PlanRuleMeter:     pb.PlanRuleType_PLAN_RULE_TYPE_METER,
+	domain.PlanRuleCondition: pb.PlanRuleType_PLAN_RULE_TYPE_CONDITION,
+}
+
+// fromEnum traduz o enum recebido; UNSPECIFIED vira "" (campo não informado) e
+// valores desconhecidos são entrada inválida.
+func fromEnum[D ~string, P ~int32](values map[D]P, v P) (D, error) {
+	if v == 0 {
+		return "", nil
+	}
+	for d, p := range values {
+		if p == v {
+			return d, nil
+		}
+	}
+	return "", domain.ErrInvalidInput
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func timePtr(ts *timestamppb.Timestamp) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+	t := ts.AsTime()
+	return &t, nil
+}
+
+func toAsset(a *domain.Asset) (*pb.Asset, error) {
+	out := &pb.Asset{
+		Id:               a.ID,
+		SiteId:           a.SiteID,
+		Name:             a.Name,
+		Location:         a.Location,
+		Criticality:      criticalities[a.Criticality],
+		ExternalCode:     a.ExternalCode,
+		Manufacturer:     a.Manufacturer,
+		Model:            a.Model,
+		SerialNumber:     a.SerialNumber,
+		InstalledOn:      a.InstalledOn,
+		WarrantyUntil:    a.WarrantyUntil,
+		Class:            a.Class,
+		IdealRatePerHour: a.IdealRatePerHour,
+		CreatedAt:        timestamppb.New(a.CreatedAt),
+		UpdatedAt:        timestamppb.New(a.UpdatedAt),
+	}
+	if len(a.Attributes) > 0 {
+		attrs, err := structpb.NewStruct(a.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		out.Attributes = attrs
+	}
+	return out, nil
+}
+
+func toWorkOrder(o *domain.WorkOrder) *pb.WorkOrder {
+	out := &pb.WorkOrder{
+		Id:               o.ID,
+		SiteId:           o.SiteID,
+		AssetId:          o.AssetID,
+		Type:             workOrderTypes[o.Type],
+		Status:           workOrderStatuses[o.Status],
+		Title:            o.Title,
+		Description:      o.Description,
+		BreakdownAt:      timestamp(o.BreakdownAt),
+		ClosedAt:         timestamp(o.ClosedAt),
+		DowntimeMinutes:  o.DowntimeMinutes,
+		Cause:            o.Cause,
+		Solution:         o.Solution,
+		FailureModeId:    o.FailureModeID,
+		FailureCauseId:   o.FailureCauseID,
+		FailureActionId:  o.FailureActionID,
+		Priority:         priorities[o.Priority],
+		ResponseDueAt:    timestamp(o.ResponseDueAt),
+		ResolutionDueAt:  timestamp(o.ResolutionDueAt),
+		RespondedAt:      timestamp(o.RespondedAt),
+		SlaBreachedAt:    timestamp(o.SLABreachedAt),
+		Trade:            o.Trade,
+		EstimatedMinutes: o.EstimatedMinutes,
+		JobPlanId:        o.JobPlanID,
+		PlanId:           o.PlanID,
+		ScheduledFor:     timestamp(o.ScheduledFor),
+		RequestId:        o.RequestID,
+		CreatedAt:        timestamppb.New(o.CreatedAt),
+		UpdatedAt:        timestamppb.New(o.UpdatedAt),
+	}
+	for _, p := range o.RequiredParts {
+		out.RequiredParts = append(out.RequiredParts, &pb.JobPlanPart{SparePartId: p.SparePartID, Quantity: p.Quantity})
+	}
+	return out
+}
+
+func toMaintenancePlan(p *domain.MaintenancePlan) *pb.MaintenancePlan {
+	return &pb.MaintenancePlan{
+		Id:               p.ID,
+		SiteId:           p.SiteID,
+		AssetId:          p.AssetID,
+		RuleType:         planRuleTypes[p.RuleType],
+		FrequencyDays:    p.FrequencyDays,
+		MeterTarget:      p.MeterTarget,
+		LastExecution:    timestamp(p.LastExecution),
+		JobPlanId:        p.JobPlanID,
+		Trade:            p.Trade,
+		EstimatedMinutes: p.EstimatedMinutes,
+		Active:           p.Active,
+		CreatedAt:        timestamppb.New(p.CreatedAt),
+		UpdatedAt:        timestamppb.New(p.UpdatedAt),
+	}
+}
@@ -0,0 +1,2167 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: maintenance/v1/maintenance.proto
+
+// API gRPC do CMMS: os mesmos serviços e regras da API REST /v1 para
+// integrações (MES). Toda chamada exige "authorization: Bearer <token>" nos
+// metadados e só enxerga o site do usuário.
+
+package maintenancev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Criticality int32
+
+const (
+	Criticality_CRITICALITY_UNSPECIFIED Criticality = 0
+	Criticality_CRITICALITY_A           Criticality = 1
+	Criticality_CRITICALITY_B           Criticality = 2
+	Criticality_CRITICALITY_C           Criticality = 3
+)
+
+// Enum value maps for Criticality.
+var (
+	Criticality_name = map[int32]string{
+		0: "CRITICALITY_UNSPECIFIED",
+		1: "CRITICALITY_A",
+		2: "CRITICALITY_B",
+		3: "CRITICALITY_C",
+	}
+	Criticality_value = map[string]int32{
+		"CRITICALITY_UNSPECIFIED": 0,
+		"CRITICALITY_A":           1,
+		"CRITICALITY_B":           2,
+		"CRITICALITY_C":           3,
+	}
+)
+
+func (x Criticality) Enum() *Criticality {
+	p := new(Criticality)
+	*p = x
+	return p
+}
+
+func (x Criticality) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Criticality) Descriptor() protoreflect.EnumDescriptor {
+	return file_maintenance_v1_maintenance_proto_enumTypes[0].Descriptor()
+}
+
+func (Criticality) Type() protoreflect.EnumType {
+	return &file_maintenance_v1_maintenance_proto_enumTypes[0]
+}
+
+func (x Criticality) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Criticality.Descriptor instead.
+func (Criticality) EnumDescriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{0}
+}
+
+type WorkOrderType int32
+
+const (
+	WorkOrderType_WORK_ORDER_TYPE_UNSPECIFIED WorkOrderType = 0
+	WorkOrderType_WORK_ORDER_TYPE_CORRECTIVE  WorkOrderType = 1
+	WorkOrderType_WORK_ORDER_TYPE_PREVENTIVE  WorkOrderType = 2
+	WorkOrderType_WORK_ORDER_TYPE_CONDITION   WorkOrderType = 3
+	WorkOrderType_WORK_ORDER_TYPE_IMPROVEMENT WorkOrderType = 4
+)
+
+// Enum value maps for WorkOrderType.
+var (
+	WorkOrderType_name = map[int32]string{
+		0: "WORK_ORDER_TYPE_UNSPECIFIED",
+		1: "WORK_ORDER_TYPE_CORRECTIVE",
+		2: "WORK_ORDER_TYPE_PREVENTIVE",
+		3: "WORK_ORDER_TYPE_CONDITION",
+		4: "WORK_ORDER_TYPE_IMPROVEMENT",
+	}
+	WorkOrderType_value = map[string]int32{
+		"WORK_ORDER_TYPE_UNSPECIFIED": 0,
+		"WORK_ORDER_TYPE_CORRECTIVE":  1,
+		"WORK_ORDER_TYPE_PREVENTIVE":  2,
+		"WORK_ORDER_TYPE_CONDITION":   3,
+		"WORK_ORDER_TYPE_IMPROVEMENT": 4,
+	}
+)
+
+func (x WorkOrderType) Enum() *WorkOrderType {
+	p := new(WorkOrderType)
+	*p = x
+	return p
+}
+
+func (x WorkOrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkOrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_maintenance_v1_maintenance_proto_enumTypes[1].Descriptor()
+}
+
+func (WorkOrderType) Type() protoreflect.EnumType {
+	return &file_maintenance_v1_maintenance_proto_enumTypes[1]
+}
+
+func (x WorkOrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkOrderType.Descriptor instead.
+func (WorkOrderType) EnumDescriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{1}
+}
+
+type WorkOrderStatus int32
+
+const (
+	WorkOrderStatus_WORK_ORDER_STATUS_UNSPECIFIED WorkOrderStatus = 0
+	WorkOrderStatus_WORK_ORDER_STATUS_OPEN        WorkOrderStatus = 1
+	WorkOrderStatus_WORK_ORDER_STATUS_IN_PROGRESS WorkOrderStatus = 2
+	WorkOrderStatus_WORK_ORDER_STATUS_DONE        WorkOrderStatus = 3
+	WorkOrderStatus_WORK_ORDER_STATUS_CANCELED    WorkOrderStatus = 4
+)
+
+// Enum value maps for WorkOrderStatus.
+var (
+	WorkOrderStatus_name = map[int32]string{
+		0: "WORK_ORDER_STATUS_UNSPECIFIED",
+		1: "WORK_ORDER_STATUS_OPEN",
+		2: "WORK_ORDER_STATUS_IN_PROGRESS",
+		3: "WORK_ORDER_STATUS_DONE",
+		4: "WORK_ORDER_STATUS_CANCELED",
+	}
+	WorkOrderStatus_value = map[string]int32{
+		"WORK_ORDER_STATUS_UNSPECIFIED": 0,
+		"WORK_ORDER_STATUS_OPEN":        1,
+		"WORK_ORDER_STATUS_IN_PROGRESS": 2,
+		"WORK_ORDER_STATUS_DONE":        3,
+		"WORK_ORDER_STATUS_CANCELED":    4,
+	}
+)
+
+func (x WorkOrderStatus) Enum() *WorkOrderStatus {
+	p := new(WorkOrderStatus)
+	*p = x
+	return p
+}
+
+func (x WorkOrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WorkOrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_maintenance_v1_maintenance_proto_enumTypes[2].Descriptor()
+}
+
+func (WorkOrderStatus) Type() protoreflect.EnumType {
+	return &file_maintenance_v1_maintenance_proto_enumTypes[2]
+}
+
+func (x WorkOrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WorkOrderStatus.Descriptor instead.
+func (WorkOrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{2}
+}
+
+type Priority int32
+
+const (
+	Priority_PRIORITY_UNSPECIFIED Priority = 0
+	Priority_PRIORITY_URGENT      Priority = 1
+	Priority_PRIORITY_HIGH        Priority = 2
+	Priority_PRIORITY_NORMAL      Priority = 3
+	Priority_PRIORITY_LOW         Priority = 4
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_URGENT",
+		2: "PRIORITY_HIGH",
+		3: "PRIORITY_NORMAL",
+		4: "PRIORITY_LOW",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_URGENT":      1,
+		"PRIORITY_HIGH":        2,
+		"PRIORITY_NORMAL":      3,
+		"PRIORITY_LOW":         4,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_maintenance_v1_maintenance_proto_enumTypes[3].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_maintenance_v1_maintenance_proto_enumTypes[3]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{3}
+}
+
+type PlanRuleType int32
+
+const (
+	PlanRuleType_PLAN_RULE_TYPE_UNSPECIFIED PlanRuleType = 0
+	PlanRuleType_PLAN_RULE_TYPE_TIME        PlanRuleType = 1
+	PlanRuleType_PLAN_RULE_TYPE_METER       PlanRuleType = 2
+	PlanRuleType_PLAN_RULE_TYPE_CONDITION   PlanRuleType = 3
+)
+
+// Enum value maps for PlanRuleType.
+var (
+	PlanRuleType_name = map[int32]string{
+		0: "PLAN_RULE_TYPE_UNSPECIFIED",
+		1: "PLAN_RULE_TYPE_TIME",
+		2: "PLAN_RULE_TYPE_METER",
+		3: "PLAN_RULE_TYPE_CONDITION",
+	}
+	PlanRuleType_value = map[string]int32{
+		"PLAN_RULE_TYPE_UNSPECIFIED": 0,
+		"PLAN_RULE_TYPE_TIME":        1,
+		"PLAN_RULE_TYPE_METER":       2,
+		"PLAN_RULE_TYPE_CONDITION":   3,
+	}
+)
+
+func (x PlanRuleType) Enum() *PlanRuleType {
+	p := new(PlanRuleType)
+	*p = x
+	return p
+}
+
+func (x PlanRuleType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PlanRuleType) Descriptor() protoreflect.EnumDescriptor {
+	return file_maintenance_v1_maintenance_proto_enumTypes[4].Descriptor()
+}
+
+func (PlanRuleType) Type() protoreflect.EnumType {
+	return &file_maintenance_v1_maintenance_proto_enumTypes[4]
+}
+
+func (x PlanRuleType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PlanRuleType.Descriptor instead.
+func (PlanRuleType) EnumDescriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{4}
+}
+
+type Asset struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SiteId       int64                  `protobuf:"varint,2,opt,name=site_id,json=siteId,proto3" json:"site_id,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Location     string                 `protobuf:"bytes,4,opt,name=location,proto3" json:"location,omitempty"`
+	Criticality  Criticality            `protobuf:"varint,5,opt,name=criticality,proto3,enum=maintenance.v1.Criticality" json:"criticality,omitempty"`
+	ExternalCode string                 `protobuf:"bytes,6,opt,name=external_code,json=externalCode,proto3" json:"external_code,omitempty"`
+	Manufacturer string                 `protobuf:"bytes,7,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	Model        string                 `protobuf:"bytes,8,opt,name=model,proto3" json:"model,omitempty"`
+	SerialNumber string                 `protobuf:"bytes,9,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// Datas de placa em AAAA-MM-DD.
+	InstalledOn      string                 `protobuf:"bytes,10,opt,name=installed_on,json=installedOn,proto3" json:"installed_on,omitempty"`
+	WarrantyUntil    string                 `protobuf:"bytes,11,opt,name=warranty_until,json=warrantyUntil,proto3" json:"warranty_until,omitempty"`
+	Class            string                 `protobuf:"bytes,12,opt,name=class,proto3" json:"class,omitempty"`
+	Attributes       *structpb.Struct       `protobuf:"bytes,13,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	IdealRatePerHour *float64               `protobuf:"fixed64,14,opt,name=ideal_rate_per_hour,json=idealRatePerHour,proto3,oneof" json:"ideal_rate_per_hour,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Asset) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Asset) GetSiteId() int64 {
+	if x != nil {
+		return x.SiteId
+	}
+	return 0
+}
+
+func (x *Asset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Asset) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *Asset) GetCriticality() Criticality {
+	if x != nil {
+		return x.Criticality
+	}
+	return Criticality_CRITICALITY_UNSPECIFIED
+}
+
+func (x *Asset) GetExternalCode() string {
+	if x != nil {
+		return x.ExternalCode
+	}
+	return ""
+}
+
+func (x *Asset) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *Asset) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Asset) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *Asset) GetInstalledOn() string {
+	if x != nil {
+		return x.InstalledOn
+	}
+	return ""
+}
+
+func (x *Asset) GetWarrantyUntil() string {
+	if x != nil {
+		return x.WarrantyUntil
+	}
+	return ""
+}
+
+func (x *Asset) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *Asset) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Asset) GetIdealRatePerHour() float64 {
+	if x != nil && x.IdealRatePerHour != nil {
+		return *x.IdealRatePerHour
+	}
+	return 0
+}
+
+func (x *Asset) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Asset) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateAssetRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Location string                 `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	// Sem criticidade, vale B.
+	Criticality      Criticality      `protobuf:"varint,3,opt,name=criticality,proto3,enum=maintenance.v1.Criticality" json:"criticality,omitempty"`
+	ExternalCode     string           `protobuf:"bytes,4,opt,name=external_code,json=externalCode,proto3" json:"external_code,omitempty"`
+	Manufacturer     string           `protobuf:"bytes,5,opt,name=manufacturer,proto3" json:"manufacturer,omitempty"`
+	Model            string           `protobuf:"bytes,6,opt,name=model,proto3" json:"model,omitempty"`
+	SerialNumber     string           `protobuf:"bytes,7,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	InstalledOn      string           `protobuf:"bytes,8,opt,name=installed_on,json=installedOn,proto3" json:"installed_on,omitempty"`
+	WarrantyUntil    string           `protobuf:"bytes,9,opt,name=warranty_until,json=warrantyUntil,proto3" json:"warranty_until,omitempty"`
+	Class            string           `protobuf:"bytes,10,opt,name=class,proto3" json:"class,omitempty"`
+	Attributes       *structpb.Struct `protobuf:"bytes,11,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	IdealRatePerHour *float64         `protobuf:"fixed64,12,opt,name=ideal_rate_per_hour,json=idealRatePerHour,proto3,oneof" json:"ideal_rate_per_hour,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateAssetRequest) Reset() {
+	*x = CreateAssetRequest{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAssetRequest) ProtoMessage() {}
+
+func (x *CreateAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAssetRequest.ProtoReflect.Descriptor instead.
+func (*CreateAssetRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateAssetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAssetRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *CreateAssetRequest) GetCriticality() Criticality {
+	if x != nil {
+		return x.Criticality
+	}
+	return Criticality_CRITICALITY_UNSPECIFIED
+}
+
+func (x *CreateAssetRequest) GetExternalCode() string {
+	if x != nil {
+		return x.ExternalCode
+	}
+	return ""
+}
+
+func (x *CreateAssetRequest) GetManufacturer() string {
+	if x != nil {
+		return x.Manufacturer
+	}
+	return ""
+}
+
+func (x *CreateAssetRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CreateAssetRequest) GetSerialNumber() string {
+	if x != nil {
+		return x.SerialNumber
+	}
+	return ""
+}
+
+func (x *CreateAssetRequest) GetInstalledOn() string {
+	if x != nil {
+		return x.InstalledOn
+	}
+	return ""
+}
+
+func (x *CreateAssetRequest) GetWarrantyUntil() string {
+	if x != nil {
+		return x.WarrantyUntil
+	}
+	return ""
+}
+
+func (x *CreateAssetRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *CreateAssetRequest) GetAttributes() *structpb.Struct {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *CreateAssetRequest) GetIdealRatePerHour() float64 {
+	if x != nil && x.IdealRatePerHour != nil {
+		return *x.IdealRatePerHour
+	}
+	return 0
+}
+
+type GetAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssetRequest) Reset() {
+	*x = GetAssetRequest{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssetRequest) ProtoMessage() {}
+
+func (x *GetAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssetRequest.ProtoReflect.Descriptor instead.
+func (*GetAssetRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAssetRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Campos vazios não filtram.
+type ListAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      string                 `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Criticality   Criticality            `protobuf:"varint,2,opt,name=criticality,proto3,enum=maintenance.v1.Criticality" json:"criticality,omitempty"`
+	Class         string                 `protobuf:"bytes,3,opt,name=class,proto3" json:"class,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsRequest) Reset() {
+	*x = ListAssetsRequest{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsRequest) ProtoMessage() {}
+
+func (x *ListAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAssetsRequest) GetLocation() string {
+	if x != nil {
+		return x.Location
+	}
+	return ""
+}
+
+func (x *ListAssetsRequest) GetCriticality() Criticality {
+	if x != nil {
+		return x.Criticality
+	}
+	return Criticality_CRITICALITY_UNSPECIFIED
+}
+
+func (x *ListAssetsRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+type ListAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsResponse) Reset() {
+	*x = ListAssetsResponse{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsResponse) ProtoMessage() {}
+
+func (x *ListAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsResponse.ProtoReflect.Descriptor instead.
+func (*ListAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListAssetsResponse) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+type JobPlanPart struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SparePartId   int64                  `protobuf:"varint,1,opt,name=spare_part_id,json=sparePartId,proto3" json:"spare_part_id,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JobPlanPart) Reset() {
+	*x = JobPlanPart{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JobPlanPart) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobPlanPart) ProtoMessage() {}
+
+func (x *JobPlanPart) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobPlanPart.ProtoReflect.Descriptor instead.
+func (*JobPlanPart) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *JobPlanPart) GetSparePartId() int64 {
+	if x != nil {
+		return x.SparePartId
+	}
+	return 0
+}
+
+func (x *JobPlanPart) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+type WorkOrder struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SiteId           int64                  `protobuf:"varint,2,opt,name=site_id,json=siteId,proto3" json:"site_id,omitempty"`
+	AssetId          int64                  `protobuf:"varint,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	Type             WorkOrderType          `protobuf:"varint,4,opt,name=type,proto3,enum=maintenance.v1.WorkOrderType" json:"type,omitempty"`
+	Status           WorkOrderStatus        `protobuf:"varint,5,opt,name=status,proto3,enum=maintenance.v1.WorkOrderStatus" json:"status,omitempty"`
+	Title            string                 `protobuf:"bytes,6,opt,name=title,proto3" json:"title,omitempty"`
+	Description      string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	BreakdownAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=breakdown_at,json=breakdownAt,proto3" json:"breakdown_at,omitempty"`
+	ClosedAt         *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	DowntimeMinutes  *int64                 `protobuf:"varint,10,opt,name=downtime_minutes,json=downtimeMinutes,proto3,oneof" json:"downtime_minutes,omitempty"`
+	Cause            string                 `protobuf:"bytes,11,opt,name=cause,proto3" json:"cause,omitempty"`
+	Solution         string                 `protobuf:"bytes,12,opt,name=solution,proto3" json:"solution,omitempty"`
+	FailureModeId    *int64                 `protobuf:"varint,13,opt,name=failure_mode_id,json=failureModeId,proto3,oneof" json:"failure_mode_id,omitempty"`
+	FailureCauseId   *int64                 `protobuf:"varint,14,opt,name=failure_cause_id,json=failureCauseId,proto3,oneof" json:"failure_cause_id,omitempty"`
+	FailureActionId  *int64                 `protobuf:"varint,15,opt,name=failure_action_id,json=failureActionId,proto3,oneof" json:"failure_action_id,omitempty"`
+	Priority         Priority               `protobuf:"varint,16,opt,name=priority,proto3,enum=maintenance.v1.Priority" json:"priority,omitempty"`
+	ResponseDueAt    *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=response_due_at,json=responseDueAt,proto3" json:"response_due_at,omitempty"`
+	ResolutionDueAt  *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=resolution_due_at,json=resolutionDueAt,proto3" json:"resolution_due_at,omitempty"`
+	RespondedAt      *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=responded_at,json=respondedAt,proto3" json:"responded_at,omitempty"`
+	SlaBreachedAt    *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=sla_breached_at,json=slaBreachedAt,proto3" json:"sla_breached_at,omitempty"`
+	Trade            string                 `protobuf:"bytes,21,opt,name=trade,proto3" json:"trade,omitempty"`
+	EstimatedMinutes *int64                 `protobuf:"varint,22,opt,name=estimated_minutes,json=estimatedMinutes,proto3,oneof" json:"estimated_minutes,omitempty"`
+	JobPlanId        *int64                 `protobuf:"varint,23,opt,name=job_plan_id,json=jobPlanId,proto3,oneof" json:"job_plan_id,omitempty"`
+	RequiredParts    []*JobPlanPart         `protobuf:"bytes,24,rep,name=required_parts,json=requiredParts,proto3" json:"required_parts,omitempty"`
+	PlanId           *int64                 `protobuf:"varint,25,opt,name=plan_id,json=planId,proto3,oneof" json:"plan_id,omitempty"`
+	ScheduledFor     *timestamppb.Timestamp `protobuf:"bytes,26,opt,name=scheduled_for,json=scheduledFor,proto3" json:"scheduled_for,omitempty"`
+	RequestId        *int64                 `protobuf:"varint,27,opt,name=request_id,json=requestId,proto3,oneof" json:"request_id,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,28,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,29,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *WorkOrder) Reset() {
+	*x = WorkOrder{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkOrder) ProtoMessage() {}
+
+func (x *WorkOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkOrder.ProtoReflect.Descriptor instead.
+func (*WorkOrder) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WorkOrder) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetSiteId() int64 {
+	if x != nil {
+		return x.SiteId
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetAssetId() int64 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetType() WorkOrderType {
+	if x != nil {
+		return x.Type
+	}
+	return WorkOrderType_WORK_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *WorkOrder) GetStatus() WorkOrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WorkOrderStatus_WORK_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *WorkOrder) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetBreakdownAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BreakdownAt
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetDowntimeMinutes() int64 {
+	if x != nil && x.DowntimeMinutes != nil {
+		return *x.DowntimeMinutes
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetCause() string {
+	if x != nil {
+		return x.Cause
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetSolution() string {
+	if x != nil {
+		return x.Solution
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetFailureModeId() int64 {
+	if x != nil && x.FailureModeId != nil {
+		return *x.FailureModeId
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetFailureCauseId() int64 {
+	if x != nil && x.FailureCauseId != nil {
+		return *x.FailureCauseId
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetFailureActionId() int64 {
+	if x != nil && x.FailureActionId != nil {
+		return *x.FailureActionId
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
+func (x *WorkOrder) GetResponseDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResponseDueAt
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetResolutionDueAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolutionDueAt
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetRespondedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RespondedAt
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetSlaBreachedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SlaBreachedAt
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetTrade() string {
+	if x != nil {
+		return x.Trade
+	}
+	return ""
+}
+
+func (x *WorkOrder) GetEstimatedMinutes() int64 {
+	if x != nil && x.EstimatedMinutes != nil {
+		return *x.EstimatedMinutes
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetJobPlanId() int64 {
+	if x != nil && x.JobPlanId != nil {
+		return *x.JobPlanId
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetRequiredParts() []*JobPlanPart {
+	if x != nil {
+		return x.RequiredParts
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetPlanId() int64 {
+	if x != nil && x.PlanId != nil {
+		return *x.PlanId
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetScheduledFor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetRequestId() int64 {
+	if x != nil && x.RequestId != nil {
+		return *x.RequestId
+	}
+	return 0
+}
+
+func (x *WorkOrder) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *WorkOrder) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateWorkOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	AssetId int64                  `protobuf:"varint,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// Sem tipo, corretiva; sem status, aberta.
+	Type             WorkOrderType          `protobuf:"varint,2,opt,name=type,proto3,enum=maintenance.v1.WorkOrderType" json:"type,omitempty"`
+	Status           WorkOrderStatus        `protobuf:"varint,3,opt,name=status,proto3,enum=maintenance.v1.WorkOrderStatus" json:"status,omitempty"`
+	Title            string                 `protobuf:"bytes,4,opt,name=title,proto3" json:"title,omitempty"`
+	Description      string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	BreakdownAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=breakdown_at,json=breakdownAt,proto3" json:"breakdown_at,omitempty"`
+	Trade            string                 `protobuf:"bytes,7,opt,name=trade,proto3" json:"trade,omitempty"`
+	EstimatedMinutes *int64                 `protobuf:"varint,8,opt,name=estimated_minutes,json=estimatedMinutes,proto3,oneof" json:"estimated_minutes,omitempty"`
+	JobPlanId        *int64                 `protobuf:"varint,9,opt,name=job_plan_id,json=jobPlanId,proto3,oneof" json:"job_plan_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateWorkOrderRequest) Reset() {
+	*x = CreateWorkOrderRequest{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateWorkOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateWorkOrderRequest) ProtoMessage() {}
+
+func (x *CreateWorkOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateWorkOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateWorkOrderRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateWorkOrderRequest) GetAssetId() int64 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *CreateWorkOrderRequest) GetType() WorkOrderType {
+	if x != nil {
+		return x.Type
+	}
+	return WorkOrderType_WORK_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *CreateWorkOrderRequest) GetStatus() WorkOrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WorkOrderStatus_WORK_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *CreateWorkOrderRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateWorkOrderRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateWorkOrderRequest) GetBreakdownAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BreakdownAt
+	}
+	return nil
+}
+
+func (x *CreateWorkOrderRequest) GetTrade() string {
+	if x != nil {
+		return x.Trade
+	}
+	return ""
+}
+
+func (x *CreateWorkOrderRequest) GetEstimatedMinutes() int64 {
+	if x != nil && x.EstimatedMinutes != nil {
+		return *x.EstimatedMinutes
+	}
+	return 0
+}
+
+func (x *CreateWorkOrderRequest) GetJobPlanId() int64 {
+	if x != nil && x.JobPlanId != nil {
+		return *x.JobPlanId
+	}
+	return 0
+}
+
+// Campos vazios não filtram.
+type ListWorkOrdersRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Status  WorkOrderStatus        `protobuf:"varint,1,opt,name=status,proto3,enum=maintenance.v1.WorkOrderStatus" json:"status,omitempty"`
+	Type    WorkOrderType          `protobuf:"varint,2,opt,name=type,proto3,enum=maintenance.v1.WorkOrderType" json:"type,omitempty"`
+	AssetId int64                  `protobuf:"varint,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	// Só OS com SLA vencido agora.
+	Overdue       bool `protobuf:"varint,4,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkOrdersRequest) Reset() {
+	*x = ListWorkOrdersRequest{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkOrdersRequest) ProtoMessage() {}
+
+func (x *ListWorkOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListWorkOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListWorkOrdersRequest) GetStatus() WorkOrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WorkOrderStatus_WORK_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *ListWorkOrdersRequest) GetType() WorkOrderType {
+	if x != nil {
+		return x.Type
+	}
+	return WorkOrderType_WORK_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *ListWorkOrdersRequest) GetAssetId() int64 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *ListWorkOrdersRequest) GetOverdue() bool {
+	if x != nil {
+		return x.Overdue
+	}
+	return false
+}
+
+type ListWorkOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WorkOrders    []*WorkOrder           `protobuf:"bytes,1,rep,name=work_orders,json=workOrders,proto3" json:"work_orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWorkOrdersResponse) Reset() {
+	*x = ListWorkOrdersResponse{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWorkOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWorkOrdersResponse) ProtoMessage() {}
+
+func (x *ListWorkOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWorkOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListWorkOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListWorkOrdersResponse) GetWorkOrders() []*WorkOrder {
+	if x != nil {
+		return x.WorkOrders
+	}
+	return nil
+}
+
+// Códigos de falha, causa e solução só valem no fechamento (DONE).
+type TransitionWorkOrderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status          WorkOrderStatus        `protobuf:"varint,2,opt,name=status,proto3,enum=maintenance.v1.WorkOrderStatus" json:"status,omitempty"`
+	FailureModeId   *int64                 `protobuf:"varint,3,opt,name=failure_mode_id,json=failureModeId,proto3,oneof" json:"failure_mode_id,omitempty"`
+	FailureCauseId  *int64                 `protobuf:"varint,4,opt,name=failure_cause_id,json=failureCauseId,proto3,oneof" json:"failure_cause_id,omitempty"`
+	FailureActionId *int64                 `protobuf:"varint,5,opt,name=failure_action_id,json=failureActionId,proto3,oneof" json:"failure_action_id,omitempty"`
+	Cause           string                 `protobuf:"bytes,6,opt,name=cause,proto3" json:"cause,omitempty"`
+	Solution        string                 `protobuf:"bytes,7,opt,name=solution,proto3" json:"solution,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TransitionWorkOrderRequest) Reset() {
+	*x = TransitionWorkOrderRequest{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransitionWorkOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransitionWorkOrderRequest) ProtoMessage() {}
+
+func (x *TransitionWorkOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransitionWorkOrderRequest.ProtoReflect.Descriptor instead.
+func (*TransitionWorkOrderRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TransitionWorkOrderRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TransitionWorkOrderRequest) GetStatus() WorkOrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WorkOrderStatus_WORK_ORDER_STATUS_UNSPECIFIED
+}
+
+func (x *TransitionWorkOrderRequest) GetFailureModeId() int64 {
+	if x != nil && x.FailureModeId != nil {
+		return *x.FailureModeId
+	}
+	return 0
+}
+
+func (x *TransitionWorkOrderRequest) GetFailureCauseId() int64 {
+	if x != nil && x.FailureCauseId != nil {
+		return *x.FailureCauseId
+	}
+	return 0
+}
+
+func (x *TransitionWorkOrderRequest) GetFailureActionId() int64 {
+	if x != nil && x.FailureActionId != nil {
+		return *x.FailureActionId
+	}
+	return 0
+}
+
+func (x *TransitionWorkOrderRequest) GetCause() string {
+	if x != nil {
+		return x.Cause
+	}
+	return ""
+}
+
+func (x *TransitionWorkOrderRequest) GetSolution() string {
+	if x != nil {
+		return x.Solution
+	}
+	return ""
+}
+
+type WatchWorkOrdersRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Revisão do último evento recebido, para retomar sem perder alterações;
+	// zero começa pelas OS em aberto.
+	SinceRevision int64 `protobuf:"varint,1,opt,name=since_revision,json=sinceRevision,proto3" json:"since_revision,omitempty"`
+	// Filtros opcionais.
+	Type          WorkOrderType `protobuf:"varint,2,opt,name=type,proto3,enum=maintenance.v1.WorkOrderType" json:"type,omitempty"`
+	AssetId       int64         `protobuf:"varint,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchWorkOrdersRequest) Reset() {
+	*x = WatchWorkOrdersRequest{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchWorkOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchWorkOrdersRequest) ProtoMessage() {}
+
+func (x *WatchWorkOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchWorkOrdersRequest.ProtoReflect.Descriptor instead.
+func (*WatchWorkOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WatchWorkOrdersRequest) GetSinceRevision() int64 {
+	if x != nil {
+		return x.SinceRevision
+	}
+	return 0
+}
+
+func (x *WatchWorkOrdersRequest) GetType() WorkOrderType {
+	if x != nil {
+		return x.Type
+	}
+	return WorkOrderType_WORK_ORDER_TYPE_UNSPECIFIED
+}
+
+func (x *WatchWorkOrdersRequest) GetAssetId() int64 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+type WorkOrderEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int64                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	WorkOrder     *WorkOrder             `protobuf:"bytes,2,opt,name=work_order,json=workOrder,proto3" json:"work_order,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkOrderEvent) Reset() {
+	*x = WorkOrderEvent{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkOrderEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkOrderEvent) ProtoMessage() {}
+
+func (x *WorkOrderEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkOrderEvent.ProtoReflect.Descriptor instead.
+func (*WorkOrderEvent) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *WorkOrderEvent) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *WorkOrderEvent) GetWorkOrder() *WorkOrder {
+	if x != nil {
+		return x.WorkOrder
+	}
+	return nil
+}
+
+type MaintenancePlan struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	SiteId           int64                  `protobuf:"varint,2,opt,name=site_id,json=siteId,proto3" json:"site_id,omitempty"`
+	AssetId          int64                  `protobuf:"varint,3,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	RuleType         PlanRuleType           `protobuf:"varint,4,opt,name=rule_type,json=ruleType,proto3,enum=maintenance.v1.PlanRuleType" json:"rule_type,omitempty"`
+	FrequencyDays    *int64                 `protobuf:"varint,5,opt,name=frequency_days,json=frequencyDays,proto3,oneof" json:"frequency_days,omitempty"`
+	MeterTarget      *int64                 `protobuf:"varint,6,opt,name=meter_target,json=meterTarget,proto3,oneof" json:"meter_target,omitempty"`
+	LastExecution    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_execution,json=lastExecution,proto3" json:"last_execution,omitempty"`
+	JobPlanId        *int64                 `protobuf:"varint,8,opt,name=job_plan_id,json=jobPlanId,proto3,oneof" json:"job_plan_id,omitempty"`
+	Trade            string                 `protobuf:"bytes,9,opt,name=trade,proto3" json:"trade,omitempty"`
+	EstimatedMinutes *int64                 `protobuf:"varint,10,opt,name=estimated_minutes,json=estimatedMinutes,proto3,oneof" json:"estimated_minutes,omitempty"`
+	Active           bool                   `protobuf:"varint,11,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MaintenancePlan) Reset() {
+	*x = MaintenancePlan{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenancePlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenancePlan) ProtoMessage() {}
+
+func (x *MaintenancePlan) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenancePlan.ProtoReflect.Descriptor instead.
+func (*MaintenancePlan) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MaintenancePlan) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MaintenancePlan) GetSiteId() int64 {
+	if x != nil {
+		return x.SiteId
+	}
+	return 0
+}
+
+func (x *MaintenancePlan) GetAssetId() int64 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *MaintenancePlan) GetRuleType() PlanRuleType {
+	if x != nil {
+		return x.RuleType
+	}
+	return PlanRuleType_PLAN_RULE_TYPE_UNSPECIFIED
+}
+
+func (x *MaintenancePlan) GetFrequencyDays() int64 {
+	if x != nil && x.FrequencyDays != nil {
+		return *x.FrequencyDays
+	}
+	return 0
+}
+
+func (x *MaintenancePlan) GetMeterTarget() int64 {
+	if x != nil && x.MeterTarget != nil {
+		return *x.MeterTarget
+	}
+	return 0
+}
+
+func (x *MaintenancePlan) GetLastExecution() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastExecution
+	}
+	return nil
+}
+
+func (x *MaintenancePlan) GetJobPlanId() int64 {
+	if x != nil && x.JobPlanId != nil {
+		return *x.JobPlanId
+	}
+	return 0
+}
+
+func (x *MaintenancePlan) GetTrade() string {
+	if x != nil {
+		return x.Trade
+	}
+	return ""
+}
+
+func (x *MaintenancePlan) GetEstimatedMinutes() int64 {
+	if x != nil && x.EstimatedMinutes != nil {
+		return *x.EstimatedMinutes
+	}
+	return 0
+}
+
+func (x *MaintenancePlan) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *MaintenancePlan) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MaintenancePlan) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateMaintenancePlanRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AssetId          int64                  `protobuf:"varint,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	RuleType         PlanRuleType           `protobuf:"varint,2,opt,name=rule_type,json=ruleType,proto3,enum=maintenance.v1.PlanRuleType" json:"rule_type,omitempty"`
+	FrequencyDays    *int64                 `protobuf:"varint,3,opt,name=frequency_days,json=frequencyDays,proto3,oneof" json:"frequency_days,omitempty"`
+	MeterTarget      *int64                 `protobuf:"varint,4,opt,name=meter_target,json=meterTarget,proto3,oneof" json:"meter_target,omitempty"`
+	LastExecution    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_execution,json=lastExecution,proto3" json:"last_execution,omitempty"`
+	JobPlanId        *int64                 `protobuf:"varint,6,opt,name=job_plan_id,json=jobPlanId,proto3,oneof" json:"job_plan_id,omitempty"`
+	Trade            string                 `protobuf:"bytes,7,opt,name=trade,proto3" json:"trade,omitempty"`
+	EstimatedMinutes *int64                 `protobuf:"varint,8,opt,name=estimated_minutes,json=estimatedMinutes,proto3,oneof" json:"estimated_minutes,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateMaintenancePlanRequest) Reset() {
+	*x = CreateMaintenancePlanRequest{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateMaintenancePlanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateMaintenancePlanRequest) ProtoMessage() {}
+
+func (x *CreateMaintenancePlanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateMaintenancePlanRequest.ProtoReflect.Descriptor instead.
+func (*CreateMaintenancePlanRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateMaintenancePlanRequest) GetAssetId() int64 {
+	if x != nil {
+		return x.AssetId
+	}
+	return 0
+}
+
+func (x *CreateMaintenancePlanRequest) GetRuleType() PlanRuleType {
+	if x != nil {
+		return x.RuleType
+	}
+	return PlanRuleType_PLAN_RULE_TYPE_UNSPECIFIED
+}
+
+func (x *CreateMaintenancePlanRequest) GetFrequencyDays() int64 {
+	if x != nil && x.FrequencyDays != nil {
+		return *x.FrequencyDays
+	}
+	return 0
+}
+
+func (x *CreateMaintenancePlanRequest) GetMeterTarget() int64 {
+	if x != nil && x.MeterTarget != nil {
+		return *x.MeterTarget
+	}
+	return 0
+}
+
+func (x *CreateMaintenancePlanRequest) GetLastExecution() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastExecution
+	}
+	return nil
+}
+
+func (x *CreateMaintenancePlanRequest) GetJobPlanId() int64 {
+	if x != nil && x.JobPlanId != nil {
+		return *x.JobPlanId
+	}
+	return 0
+}
+
+func (x *CreateMaintenancePlanRequest) GetTrade() string {
+	if x != nil {
+		return x.Trade
+	}
+	return ""
+}
+
+func (x *CreateMaintenancePlanRequest) GetEstimatedMinutes() int64 {
+	if x != nil && x.EstimatedMinutes != nil {
+		return *x.EstimatedMinutes
+	}
+	return 0
+}
+
+type ListMaintenancePlansRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMaintenancePlansRequest) Reset() {
+	*x = ListMaintenancePlansRequest{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenancePlansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenancePlansRequest) ProtoMessage() {}
+
+func (x *ListMaintenancePlansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenancePlansRequest.ProtoReflect.Descriptor instead.
+func (*ListMaintenancePlansRequest) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{15}
+}
+
+type ListMaintenancePlansResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MaintenancePlans []*MaintenancePlan     `protobuf:"bytes,1,rep,name=maintenance_plans,json=maintenancePlans,proto3" json:"maintenance_plans,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ListMaintenancePlansResponse) Reset() {
+	*x = ListMaintenancePlansResponse{}
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMaintenancePlansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMaintenancePlansResponse) ProtoMessage() {}
+
+func (x *ListMaintenancePlansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_maintenance_v1_maintenance_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMaintenancePlansResponse.ProtoReflect.Descriptor instead.
+func (*ListMaintenancePlansResponse) Descriptor() ([]byte, []int) {
+	return file_maintenance_v1_maintenance_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListMaintenancePlansResponse) GetMaintenancePlans() []*MaintenancePlan {
+	if x != nil {
+		return x.MaintenancePlans
+	}
+	return nil
+}
+
+var File_maintenance_v1_maintenance_proto protoreflect.FileDescriptor
+
+const file_maintenance_v1_maintenance_proto_rawDesc = "" +
+	"\n" +
+	" maintenance/v1/maintenance.proto\x12\x0emaintenance.v1\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xfe\x04\n" +
+	"\x05Asset\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\asite_id\x18\x02 \x01(\x03R\x06siteId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x04 \x01(\tR\blocation\x12=\n" +
+	"\vcriticality\x18\x05 \x01(\x0e2\x1b.maintenance.v1.CriticalityR\vcriticality\x12#\n" +
+	"\rexternal_code\x18\x06 \x01(\tR\fexternalCode\x12\"\n" +
+	"\fmanufacturer\x18\a \x01(\tR\fmanufacturer\x12\x14\n" +
+	"\x05model\x18\b \x01(\tR\x05model\x12#\n" +
+	"\rserial_number\x18\t \x01(\tR\fserialNumber\x12!\n" +
+	"\finstalled_on\x18\n" +
+	" \x01(\tR\vinstalledOn\x12%\n" +
+	"\x0ewarranty_until\x18\v \x01(\tR\rwarrantyUntil\x12\x14\n" +
+	"\x05class\x18\f \x01(\tR\x05class\x127\n" +
+	"\n" +
+	"attributes\x18\r \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x122\n" +
+	"\x13ideal_rate_per_hour\x18\x0e \x01(\x01H\x00R\x10idealRatePerHour\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x16\n" +
+	"\x14_ideal_rate_per_hour\"\xec\x03\n" +
+	"\x12CreateAssetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\blocation\x18\x02 \x01(\tR\blocation\x12=\n" +
+	"\vcriticality\x18\x03 \x01(\x0e2\x1b.maintenance.v1.CriticalityR\vcriticality\x12#\n" +
+	"\rexternal_code\x18\x04 \x01(\tR\fexternalCode\x12\"\n" +
+	"\fmanufacturer\x18\x05 \x01(\tR\fmanufacturer\x12\x14\n" +
+	"\x05model\x18\x06 \x01(\tR\x05model\x12#\n" +
+	"\rserial_number\x18\a \x01(\tR\fserialNumber\x12!\n" +
+	"\finstalled_on\x18\b \x01(\tR\vinstalledOn\x12%\n" +
+	"\x0ewarranty_until\x18\t \x01(\tR\rwarrantyUntil\x12\x14\n" +
+	"\x05class\x18\n" +
+	" \x01(\tR\x05class\x127\n" +
+	"\n" +
+	"attributes\x18\v \x01(\v2\x17.google.protobuf.StructR\n" +
+	"attributes\x122\n" +
+	"\x13ideal_rate_per_hour\x18\f \x01(\x01H\x00R\x10idealRatePerHour\x88\x01\x01B\x16\n" +
+	"\x14_ideal_rate_per_hour\"!\n" +
+	"\x0fGetAssetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x84\x01\n" +
+	"\x11ListAssetsRequest\x12\x1a\n" +
+	"\blocation\x18\x01 \x01(\tR\blocation\x12=\n" +
+	"\vcriticality\x18\x02 \x01(\x0e2\x1b.maintenance.v1.CriticalityR\vcriticality\x12\x14\n" +
+	"\x05class\x18\x03 \x01(\tR\x05class\"C\n" +
+	"\x12ListAssetsResponse\x12-\n" +
+	"\x06assets\x18\x01 \x03(\v2\x15.maintenance.v1.AssetR\x06assets\"M\n" +
+	"\vJobPlanPart\x12\"\n" +
+	"\rspare_part_id\x18\x01 \x01(\x03R\vsparePartId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\"\xde\v\n" +
+	"\tWorkOrder\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\asite_id\x18\x02 \x01(\x03R\x06siteId\x12\x19\n" +
+	"\basset_id\x18\x03 \x01(\x03R\aassetId\x121\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x1d.maintenance.v1.WorkOrderTypeR\x04type\x127\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1f.maintenance.v1.WorkOrderStatusR\x06status\x12\x14\n" +
+	"\x05title\x18\x06 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12=\n" +
+	"\fbreakdown_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vbreakdownAt\x127\n" +
+	"\tclosed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12.\n" +
+	"\x10downtime_minutes\x18\n" +
+	" \x01(\x03H\x00R\x0fdowntimeMinutes\x88\x01\x01\x12\x14\n" +
+	"\x05cause\x18\v \x01(\tR\x05cause\x12\x1a\n" +
+	"\bsolution\x18\f \x01(\tR\bsolution\x12+\n" +
+	"\x0ffailure_mode_id\x18\r \x01(\x03H\x01R\rfailureModeId\x88\x01\x01\x12-\n" +
+	"\x10failure_cause_id\x18\x0e \x01(\x03H\x02R\x0efailureCauseId\x88\x01\x01\x12/\n" +
+	"\x11failure_action_id\x18\x0f \x01(\x03H\x03R\x0ffailureActionId\x88\x01\x01\x124\n" +
+	"\bpriority\x18\x10 \x01(\x0e2\x18.maintenance.v1.PriorityR\bpriority\x12B\n" +
+	"\x0fresponse_due_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\rresponseDueAt\x12F\n" +
+	"\x11resolution_due_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\x0fresolutionDueAt\x12=\n" +
+	"\fresponded_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\vrespondedAt\x12B\n" +
+	"\x0fsla_breached_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\rslaBreachedAt\x12\x14\n" +
+	"\x05trade\x18\x15 \x01(\tR\x05trade\x120\n" +
+	"\x11estimated_minutes\x18\x16 \x01(\x03H\x04R\x10estimatedMinutes\x88\x01\x01\x12#\n" +
+	"\vjob_plan_id\x18\x17 \x01(\x03H\x05R\tjobPlanId\x88\x01\x01\x12B\n" +
+	"\x0erequired_parts\x18\x18 \x03(\v2\x1b.maintenance.v1.JobPlanPartR\rrequiredParts\x12\x1c\n" +
+	"\aplan_id\x18\x19 \x01(\x03H\x06R\x06planId\x88\x01\x01\x12?\n" +
+	"\rscheduled_for\x18\x1a \x01(\v2\x1a.google.protobuf.TimestampR\fscheduledFor\x12\"\n" +
+	"\n" +
+	"request_id\x18\x1b \x01(\x03H\aR\trequestId\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x1c \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x1d \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x13\n" +
+	"\x11_downtime_minutesB\x12\n" +
+	"\x10_failure_mode_idB\x13\n" +
+	"\x11_failure_cause_idB\x14\n" +
+	"\x12_failure_action_idB\x14\n" +
+	"\x12_estimated_minutesB\x0e\n" +
+	"\f_job_plan_idB\n" +
+	"\n" +
+	"\b_plan_idB\r\n" +
+	"\v_request_id\"\xa9\x03\n" +
+	"\x16CreateWorkOrderRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\x03R\aassetId\x121\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1d.maintenance.v1.WorkOrderTypeR\x04type\x127\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1f.maintenance.v1.WorkOrderStatusR\x06status\x12\x14\n" +
+	"\x05title\x18\x04 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12=\n" +
+	"\fbreakdown_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vbreakdownAt\x12\x14\n" +
+	"\x05trade\x18\a \x01(\tR\x05trade\x120\n" +
+	"\x11estimated_minutes\x18\b \x01(\x03H\x00R\x10estimatedMinutes\x88\x01\x01\x12#\n" +
+	"\vjob_plan_id\x18\t \x01(\x03H\x01R\tjobPlanId\x88\x01\x01B\x14\n" +
+	"\x12_estimated_minutesB\x0e\n" +
+	"\f_job_plan_id\"\xb8\x01\n" +
+	"\x15ListWorkOrdersRequest\x127\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x1f.maintenance.v1.WorkOrderStatusR\x06status\x121\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1d.maintenance.v1.WorkOrderTypeR\x04type\x12\x19\n" +
+	"\basset_id\x18\x03 \x01(\x03R\aassetId\x12\x18\n" +
+	"\aoverdue\x18\x04 \x01(\bR\aoverdue\"T\n" +
+	"\x16ListWorkOrdersResponse\x12:\n" +
+	"\vwork_orders\x18\x01 \x03(\v2\x19.maintenance.v1.WorkOrderR\n" +
+	"workOrders\"\xe3\x02\n" +
+	"\x1aTransitionWorkOrderRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x127\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1f.maintenance.v1.WorkOrderStatusR\x06status\x12+\n" +
+	"\x0ffailure_mode_id\x18\x03 \x01(\x03H\x00R\rfailureModeId\x88\x01\x01\x12-\n" +
+	"\x10failure_cause_id\x18\x04 \x01(\x03H\x01R\x0efailureCauseId\x88\x01\x01\x12/\n" +
+	"\x11failure_action_id\x18\x05 \x01(\x03H\x02R\x0ffailureActionId\x88\x01\x01\x12\x14\n" +
+	"\x05cause\x18\x06 \x01(\tR\x05cause\x12\x1a\n" +
+	"\bsolution\x18\a \x01(\tR\bsolutionB\x12\n" +
+	"\x10_failure_mode_idB\x13\n" +
+	"\x11_failure_cause_idB\x14\n" +
+	"\x12_failure_action_id\"\x8d\x01\n" +
+	"\x16WatchWorkOrdersRequest\x12%\n" +
+	"\x0esince_revision\x18\x01 \x01(\x03R\rsinceRevision\x121\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x1d.maintenance.v1.WorkOrderTypeR\x04type\x12\x19\n" +
+	"\basset_id\x18\x03 \x01(\x03R\aassetId\"f\n" +
+	"\x0eWorkOrderEvent\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x03R\brevision\x128\n" +
+	"\n" +
+	"work_order\x18\x02 \x01(\v2\x19.maintenance.v1.WorkOrderR\tworkOrder\"\xec\x04\n" +
+	"\x0fMaintenancePlan\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\asite_id\x18\x02 \x01(\x03R\x06siteId\x12\x19\n" +
+	"\basset_id\x18\x03 \x01(\x03R\aassetId\x129\n" +
+	"\trule_type\x18\x04 \x01(\x0e2\x1c.maintenance.v1.PlanRuleTypeR\bruleType\x12*\n" +
+	"\x0efrequency_days\x18\x05 \x01(\x03H\x00R\rfrequencyDays\x88\x01\x01\x12&\n" +
+	"\fmeter_target\x18\x06 \x01(\x03H\x01R\vmeterTarget\x88\x01\x01\x12A\n" +
+	"\x0elast_execution\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rlastExecution\x12#\n" +
+	"\vjob_plan_id\x18\b \x01(\x03H\x02R\tjobPlanId\x88\x01\x01\x12\x14\n" +
+	"\x05trade\x18\t \x01(\tR\x05trade\x120\n" +
+	"\x11estimated_minutes\x18\n" +
+	" \x01(\x03H\x03R\x10estimatedMinutes\x88\x01\x01\x12\x16\n" +
+	"\x06active\x18\v \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x11\n" +
+	"\x0f_frequency_daysB\x0f\n" +
+	"\r_meter_targetB\x0e\n" +
+	"\f_job_plan_idB\x14\n" +
+	"\x12_estimated_minutes\"\xc2\x03\n" +
+	"\x1cCreateMaintenancePlanRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\x03R\aassetId\x129\n" +
+	"\trule_type\x18\x02 \x01(\x0e2\x1c.maintenance.v1.PlanRuleTypeR\bruleType\x12*\n" +
+	"\x0efrequency_days\x18\x03 \x01(\x03H\x00R\rfrequencyDays\x88\x01\x01\x12&\n" +
+	"\fmeter_target\x18\x04 \x01(\x03H\x01R\vmeterTarget\x88\x01\x01\x12A\n" +
+	"\x0elast_execution\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rlastExecution\x12#\n" +
+	"\vjob_plan_id\x18\x06 \x01(\x03H\x02R\tjobPlanId\x88\x01\x01\x12\x14\n" +
+	"\x05trade\x18\a \x01(\tR\x05trade\x120\n" +
+	"\x11estimated_minutes\x18\b \x01(\x03H\x03R\x10estimatedMinutes\x88\x01\x01B\x11\n" +
+	"\x0f_frequency_daysB\x0f\n" +
+	"\r_meter_targetB\x0e\n" +
+	"\f_job_plan_idB\x14\n" +
+	"\x12_estimated_minutes\"\x1d\n" +
+	"\x1bListMaintenancePlansRequest\"l\n" +
+	"\x1cListMaintenancePlansResponse\x12L\n" +
+	"\x11maintenance_plans\x18\x01 \x03(\v2\x1f.maintenance.v1.MaintenancePlanR\x10maintenancePlans*c\n" +
+	"\vCriticality\x12\x1b\n" +
+	"\x17CRITICALITY_UNSPECIFIED\x10\x00\x12\x11\n" +
+	"\rCRITICALITY_A\x10\x01\x12\x11\n" +
+	"\rCRITICALITY_B\x10\x02\x12\x11\n" +
+	"\rCRITICALITY_C\x10\x03*\xb0\x01\n" +
+	"\rWorkOrderType\x12\x1f\n" +
+	"\x1bWORK_ORDER_TYPE_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aWORK_ORDER_TYPE_CORRECTIVE\x10\x01\x12\x1e\n" +
+	"\x1aWORK_ORDER_TYPE_PREVENTIVE\x10\x02\x12\x1d\n" +
+	"\x19WORK_ORDER_TYPE_CONDITION\x10\x03\x12\x1f\n" +
+	"\x1bWORK_ORDER_TYPE_IMPROVEMENT\x10\x04*\xaf\x01\n" +
+	"\x0fWorkOrderStatus\x12!\n" +
+	"\x1dWORK_ORDER_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16WORK_ORDER_STATUS_OPEN\x10\x01\x12!\n" +
+	"\x1dWORK_ORDER_STATUS_IN_PROGRESS\x10\x02\x12\x1a\n" +
+	"\x16WORK_ORDER_STATUS_DONE\x10\x03\x12\x1e\n" +
+	"\x1aWORK_ORDER_STATUS_CANCELED\x10\x04*s\n" +
+	"\bPriority\x12\x18\n" +
+	"\x14PRIORITY_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fPRIORITY_URGENT\x10\x01\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x02\x12\x13\n" +
+	"\x0fPRIORITY_NORMAL\x10\x03\x12\x10\n" +
+	"\fPRIORITY_LOW\x10\x04*\x7f\n" +
+	"\fPlanRuleType\x12\x1e\n" +
+	"\x1aPLAN_RULE_TYPE_UNSPECIFIED\x10\x00\x12\x17\n" +
+	"\x13PLAN_RULE_TYPE_TIME\x10\x01\x12\x18\n" +
+	"\x14PLAN_RULE_TYPE_METER\x10\x02\x12\x1c\n" +
+	"\x18PLAN_RULE_TYPE_CONDITION\x10\x032\xf1\x01\n" +
+	"\fAssetService\x12H\n" +
+	"\vCreateAsset\x12\".maintenance.v1.CreateAssetRequest\x1a\x15.maintenance.v1.Asset\x12B\n" +
+	"\bGetAsset\x12\x1f.maintenance.v1.GetAssetRequest\x1a\x15.maintenance.v1.Asset\x12S\n" +
+	"\n" +
+	"ListAssets\x12!.maintenance.v1.ListAssetsRequest\x1a\".maintenance.v1.ListAssetsResponse2\x84\x03\n" +
+	"\x10WorkOrderService\x12T\n" +
+	"\x0fCreateWorkOrder\x12&.maintenance.v1.CreateWorkOrderRequest\x1a\x19.maintenance.v1.WorkOrder\x12_\n" +
+	"\x0eListWorkOrders\x12%.maintenance.v1.ListWorkOrdersRequest\x1a&.maintenance.v1.ListWorkOrdersResponse\x12\\\n" +
+	"\x13TransitionWorkOrder\x12*.maintenance.v1.TransitionWorkOrderRequest\x1a\x19.maintenance.v1.WorkOrder\x12[\n" +
+	"\x0fWatchWorkOrders\x12&.maintenance.v1.WatchWorkOrdersRequest\x1a\x1e.maintenance.v1.WorkOrderEvent0\x012\xf3\x01\n" +
+	"\x16MaintenancePlanService\x12f\n" +
+	"\x15CreateMaintenancePlan\x12,.maintenance.v1.CreateMaintenancePlanRequest\x1a\x1f.maintenance.v1.MaintenancePlan\x12q\n" +
+	"\x14ListMaintenancePlans\x12+.maintenance.v1.ListMaintenancePlansRequest\x1a,.maintenance.v1.ListMaintenancePlansResponseB]Z[github.com/maxwellsouza/go-factory-maintenance/internal/grpcapi/maintenancev1;maintenancev1b\x06proto3"
+
+var (
+	file_maintenance_v1_maintenance_proto_rawDescOnce sync.Once
+	file_maintenance_v1_maintenance_proto_rawDescData []byte
+)
+
+func file_maintenance_v1_maintenance_proto_rawDescGZIP() []byte {
+	file_maintenance_v1_maintenance_proto_rawDescOnce.Do(func() {
+		file_maintenance_v1_maintenance_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_maintenance_v1_maintenance_proto_rawDesc), len(file_maintenance_v1_maintenance_proto_rawDesc)))
+	})
+	return file_maintenance_v1_maintenance_proto_rawDescData
+}
+
+var file_maintenance_v1_maintenance_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_maintenance_v1_maintenance_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_maintenance_v1_maintenance_proto_goTypes = []any{
+	(Criticality)(0),                     // 0: maintenance.v1.Criticality
+	(WorkOrderType)(0),                   // 1: maintenance.v1.WorkOrderType
+	(WorkOrderStatus)(0),                 // 2: maintenance.v1.WorkOrderStatus
+	(Priority)(0),                        // 3: maintenance.v1.Priority
+	(PlanRuleType)(0),                    // 4: maintenance.v1.PlanRuleType
+	(*Asset)(nil),                        // 5: maintenance.v1.Asset
+	(*CreateAssetRequest)(nil),           // 6: maintenance.v1.CreateAssetRequest
+	(*GetAssetRequest)(nil),              // 7: maintenance.v1.GetAssetRequest
+	(*ListAssetsRequest)(nil),            // 8: maintenance.v1.ListAssetsRequest
+	(*ListAssetsResponse)(nil),           // 9: maintenance.v1.ListAssetsResponse
+	(*JobPlanPart)(nil),                  // 10: maintenance.v1.JobPlanPart
+	(*WorkOrder)(nil),                    // 11: maintenance.v1.WorkOrder
+	(*CreateWorkOrderRequest)(nil),       // 12: maintenance.v1.CreateWorkOrderRequest
+	(*ListWorkOrdersRequest)(nil),        // 13: maintenance.v1.ListWorkOrdersRequest
+	(*ListWorkOrdersResponse)(nil),       // 14: maintenance.v1.ListWorkOrdersResponse
+	(*TransitionWorkOrderRequest)(nil),   // 15: maintenance.v1.TransitionWorkOrderRequest
+	(*WatchWorkOrdersRequest)(nil),       // 16: maintenance.v1.WatchWorkOrdersRequest
+	(*WorkOrderEvent)(nil),               // 17: maintenance.v1.WorkOrderEvent
+	(*MaintenancePlan)(nil),              // 18: maintenance.v1.MaintenancePlan
+	(*CreateMaintenancePlanRequest)(nil), // 19: maintenance.v1.CreateMaintenancePlanRequest
+	(*ListMaintenancePlansRequest)(nil),  // 20: maintenance.v1.ListMaintenancePlansRequest
+	(*ListMaintenancePlansResponse)(nil), // 21: maintenance.v1.ListMaintenancePlansResponse
+	(*structpb.Struct)(nil),              // 22: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),        // 23: google.protobuf.Timestamp
+}
+var file_maintenance_v1_maintenance_proto_depIdxs = []int32{
+	0,  // 0: maintenance.v1.Asset.criticality:type_name -> maintenance.v1.Criticality
+	22, // 1: maintenance.v1.Asset.attributes:type_name -> google.protobuf.Struct
+	23, // 2: maintenance.v1.Asset.created_at:type_name -> google.protobuf.Timestamp
+	23, // 3: maintenance.v1.Asset.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: maintenance.v1.CreateAssetRequest.criticality:type_name -> maintenance.v1.Criticality
+	22, // 5: maintenance.v1.CreateAssetRequest.attributes:type_name -> google.protobuf.Struct
+	0,  // 6: maintenance.v1.ListAssetsRequest.criticality:type_name -> maintenance.v1.Criticality
+	5,  // 7: maintenance.v1.ListAssetsResponse.assets:type_name -> maintenance.v1.Asset
+	1,  // 8: maintenance.v1.WorkOrder.type:type_name -> maintenance.v1.WorkOrderType
+	2,  // 9: maintenance.v1.WorkOrder.status:type_name -> maintenance.v1.WorkOrderStatus
+	23, // 10: maintenance.v1.WorkOrder.breakdown_at:type_name -> google.protobuf.Timestamp
+	23, // 11: maintenance.v1.WorkOrder.closed_at:type_name -> google.protobuf.Timestamp
+	3,  // 12: maintenance.v1.WorkOrder.priority:type_name -> maintenance.v1.Priority
+	23, // 13: maintenance.v1.WorkOrder.response_due_at:type_name -> google.protobuf.Timestamp
+	23, // 14: maintenance.v1.WorkOrder.resolution_due_at:type_name -> google.protobuf.Timestamp
+	23, // 15: maintenance.v1.WorkOrder.responded_at:type_name -> google.protobuf.Timestamp
+	23, // 16: maintenance.v1.WorkOrder.sla_breached_at:type_name -> google.protobuf.Timestamp
+	10, // 17: maintenance.v1.WorkOrder.required_parts:type_name -> maintenance.v1.JobPlanPart
+	23, // 18: maintenance.v1.WorkOrder.scheduled_for:type_name -> google.protobuf.Timestamp
+	23, // 19: maintenance.v1.WorkOrder.created_at:type_name -> google.protobuf.Timestamp
+	23, // 20: maintenance.v1.WorkOrder.updated_at:type_name -> google.protobuf.Timestamp
+	1,  // 21: maintenance.v1.CreateWorkOrderRequest.type:type_name -> maintenance.v1.WorkOrderType
+	2,  // 22: maintenance.v1.CreateWorkOrderRequest.status:type_name -> maintenance.v1.WorkOrderStatus
+	23, // 23: maintenance.v1.CreateWorkOrderRequest.breakdown_at:type_name -> google.protobuf.Timestamp
+	2,  // 24: maintenance.v1.ListWorkOrdersRequest.status:type_name -> maintenance.v1.WorkOrderStatus
+	1,  // 25: maintenance.v1.ListWorkOrdersRequest.type:type_name -> maintenance.v1.WorkOrderType
+	11, // 26: maintenance.v1.ListWorkOrdersResponse.work_orders:type_name -> maintenance.v1.WorkOrder
+	2,  // 27: maintenance.v1.TransitionWorkOrderRequest.status:type_name -> maintenance.v1.WorkOrderStatus
+	1,  // 28: maintenance.v1.WatchWorkOrdersRequest.type:type_name -> maintenance.v1.WorkOrderType
+	11, // 29: maintenance.v1.WorkOrderEvent.work_order:type_name -> maintenance.v1.WorkOrder
+	4,  // 30: maintenance.v1.MaintenancePlan.rule_type:type_name -> maintenance.v1.PlanRuleType
+	23, // 31: maintenance.v1.MaintenancePlan.last_execution:type_name -> google.protobuf.Timestamp
+	23, // 32: maintenance.v1.MaintenancePlan.created_at:type_name -> google.protobuf.Timestamp
+	23, // 33: maintenance.v1.MaintenancePlan.updated_at:type_name -> google.protobuf.Timestamp
+	4,  // 34: maintenance.v1.CreateMaintenancePlanRequest.rule_type:type_name -> maintenance.v1.PlanRuleType
+	23, // 35: maintenance.v1.CreateMaintenancePlanRequest.last_execution:type_name -> google.protobuf.Timestamp
+	18, // 36: maintenance.v1.ListMaintenancePlansResponse.maintenance_plans:type_name -> maintenance.v1.MaintenancePlan
+	6,  // 37: maintenance.v1.AssetService.CreateAsset:input_type -> maintenance.v1.CreateAssetRequest
+	7,  // 38: maintenance.v1.AssetService.GetAsset:input_type -> maintenance.v1.GetAssetRequest
+	8,  // 39: maintenance.v1.AssetService.ListAssets:input_type -> maintenance.v1.ListAssetsRequest
+	12, // 40: maintenance.v1.WorkOrderService.CreateWorkOrder:input_type -> maintenance.v1.CreateWorkOrderRequest
+	13, // 41: maintenance.v1.WorkOrderService.ListWorkOrders:input_type -> maintenance.v1.ListWorkOrdersRequest
+	15, // 42: maintenance.v1.WorkOrderService.TransitionWorkOrder:input_type -> maintenance.v1.TransitionWorkOrderRequest
+	16, // 43: maintenance.v1.WorkOrderService.WatchWorkOrders:input_type -> maintenance.v1.WatchWorkOrdersRequest
+	19, // 44: maintenance.v1.MaintenancePlanService.CreateMaintenancePlan:input_type -> maintenance.v1.CreateMaintenancePlanRequest
+	20, // 45: maintenance.v1.MaintenancePlanService.ListMaintenancePlans:input_type -> maintenance.v1.ListMaintenancePlansRequest
+	5,  // 46: maintenance.v1.AssetService.CreateAsset:output_type -> maintenance.v1.Asset
+	5,  // 47: maintenance.v1.AssetService.GetAsset:output_type -> maintenance.v1.Asset
+	9,  // 48: maintenance.v1.AssetService.ListAssets:output_type -> maintenance.v1.ListAssetsResponse
+	11, // 49: maintenance.v1.WorkOrderService.CreateWorkOrder:output_type -> maintenance.v1.WorkOrder
+	14, // 50: maintenance.v1.WorkOrderService.ListWorkOrders:output_type -> maintenance.v1.ListWorkOrdersResponse
+	11, // 51: maintenance.v1.WorkOrderService.TransitionWorkOrder:output_type -> maintenance.v1.WorkOrder
+	17, // 52: maintenance.v1.WorkOrderService.WatchWorkOrders:output_type -> maintenance.v1.WorkOrderEvent
+	18, // 53: maintenance.v1.MaintenancePlanService.CreateMaintenancePlan:output_type -> maintenance.v1.MaintenancePlan
+	21, // 54: maintenance.v1.MaintenancePlanService.ListMaintenancePlans:output_type -> maintenance.v1.ListMaintenancePlansResponse
+	46, // [46:55] is the sub-list for method output_type
+	37, // [37:46] is the sub-list for method input_type
+	37, // [37:37] is the sub-list for extension type_name
+	37, // [37:37] is the sub-list for extension extendee
+	0,  // [0:37] is the sub-list for field type_name
+}
+
+func init() { file_maintenance_v1_maintenance_proto_init() }
+func file_maintenance_v1_maintenance_proto_init() {
+	if File_maintenance_v1_maintenance_proto != nil {
+		return
+	}
+	file_maintenance_v1_maintenance_proto_msgTypes[0].OneofWrappers = []any{}
+	file_maintenance_v1_maintenance_proto_msgTypes[1].OneofWrappers = []any{}
+	file_maintenance_v1_maintenance_proto_msgTypes[6].OneofWrappers = []any{}
+	file_maintenance_v1_maintenance_proto_msgTypes[7].OneofWrappers = []any{}
+	file_maintenance_v1_maintenance_proto_msgTypes[10].OneofWrappers = []any{}
+	file_maintenance_v1_maintenance_proto_msgTypes[13].OneofWrappers = []any{}
+	file_maintenance_v1_maintenance_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_maintenance_v1_maintenance_proto_rawDesc), len(file_maintenance_v1_maintenance_proto_rawDesc)),
+			NumEnums:      5,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   3,
+		},
+		GoTypes:           file_maintenance_v1_maintenance_proto_goTypes,
+		DependencyIndexes: file_maintenance_v1_maintenance_proto_depIdxs,
+		EnumInfos:         file_maintenance_v1_maintenance_proto_enumTypes,
+		MessageInfos:      file_maintenance_v1_maintenance_proto_msgTypes,
+	}.Build()
+	File_maintenance_v1_maintenance_proto = out.File
+	file_maintenance_v1_maintenance_proto_goTypes = nil
+	file_maintenance_v1_maintenance_proto_depIdxs = nil
+}
@@ -0,0 +1,578 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: maintenance/v1/maintenance.proto
+
+// API gRPC do CMMS: os mesmos serviços e regras da API REST /v1 para
+// integrações (MES). Toda chamada exige "authorization: Bearer <token>" nos
+// metadados e só enxerga o site do usuário.
+
+package maintenancev1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AssetService_CreateAsset_FullMethodName = "/maintenance.v1.AssetService/CreateAsset"
+	AssetService_GetAsset_FullMethodName    = "/maintenance.v1.AssetService/GetAsset"
+	AssetService_ListAssets_FullMethodName  = "/maintenance.v1.AssetService/ListAssets"
+)
+
+// AssetServiceClient is the client API for AssetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AssetService cadastra e consulta ativos.
+type AssetServiceClient interface {
+	CreateAsset(ctx context.Context, in *CreateAssetRequest, opts ...grpc.CallOption) (*Asset, error)
+	GetAsset(ctx context.Context, in *GetAssetRequest, opts ...grpc.CallOption) (*Asset, error)
+	ListAssets(ctx context.Context, in *ListAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error)
+}
+
+type assetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAssetServiceClient(cc grpc.ClientConnInterface) AssetServiceClient {
+	return &assetServiceClient{cc}
+}
+
+func (c *assetServiceClient) CreateAsset(ctx context.Context, in *CreateAssetRequest, opts ...grpc.CallOption) (*Asset, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Asset)
+	err := c.cc.Invoke(ctx, AssetService_CreateAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) GetAsset(ctx context.Context, in *GetAssetRequest, opts ...grpc.CallOption) (*Asset, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Asset)
+	err := c.cc.Invoke(ctx, AssetService_GetAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetServiceClient) ListAssets(ctx context.Context, in *ListAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetService_ListAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AssetServiceServer is the server API for AssetService service.
+// All implementations must embed UnimplementedAssetServiceServer
+// for forward compatibility.
+//
+// AssetService cadastra e consulta ativos.
+type AssetServiceServer interface {
+	CreateAsset(context.Context, *CreateAssetRequest) (*Asset, error)
+	GetAsset(context.Context, *GetAssetRequest) (*Asset, error)
+	ListAssets(context.Context, *ListAssetsRequest) (*ListAssetsResponse, error)
+	mustEmbedUnimplementedAssetServiceServer()
+}
+
+// UnimplementedAssetServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAssetServiceServer struct{}
+
+func (UnimplementedAssetServiceServer) CreateAsset(context.Context, *CreateAssetRequest) (*Asset, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAsset not implemented")
+}
+func (UnimplementedAssetServiceServer) GetAsset(context.Context, *GetAssetRequest) (*Asset, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAsset not implemented")
+}
+func (UnimplementedAssetServiceServer) ListAssets(context.Context, *ListAssetsRequest) (*ListAssetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAssets not implemented")
+}
+func (UnimplementedAssetServiceServer) mustEmbedUnimplementedAssetServiceServer() {}
+func (UnimplementedAssetServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAssetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AssetServiceServer will
+// result in compilation errors.
+type UnsafeAssetServiceServer interface {
+	mustEmbedUnimplementedAssetServiceServer()
+}
+
+func RegisterAssetServiceServer(s grpc.ServiceRegistrar, srv AssetServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAssetServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AssetService_ServiceDesc, srv)
+}
+
+func _AssetService_CreateAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).CreateAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_CreateAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).CreateAsset(ctx, req.(*CreateAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_GetAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).GetAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_GetAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).GetAsset(ctx, req.(*GetAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetService_ListAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetServiceServer).ListAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetService_ListAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetServiceServer).ListAssets(ctx, req.(*ListAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AssetService_ServiceDesc is the grpc.ServiceDesc for AssetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AssetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "maintenance.v1.AssetService",
+	HandlerType: (*AssetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateAsset",
+			Handler:    _AssetService_CreateAsset_Handler,
+		},
+		{
+			MethodName: "GetAsset",
+			Handler:    _AssetService_GetAsset_Handler,
+		},
+		{
+			MethodName: "ListAssets",
+			Handler:    _AssetService_ListAssets_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "maintenance/v1/maintenance.proto",
+}
+
+const (
+	WorkOrderService_CreateWorkOrder_FullMethodName     = "/maintenance.v1.WorkOrderService/CreateWorkOrder"
+	WorkOrderService_ListWorkOrders_FullMethodName      = "/maintenance.v1.WorkOrderService/ListWorkOrders"
+	WorkOrderService_TransitionWorkOrder_FullMethodName = "/maintenance.v1.WorkOrderService/TransitionWorkOrder"
+	WorkOrderService_WatchWorkOrders_FullMethodName     = "/maintenance.v1.WorkOrderService/WatchWorkOrders"
+)
+
+// WorkOrderServiceClient is the client API for WorkOrderService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// WorkOrderService abre, lista e movimenta ordens de serviço.
+type WorkOrderServiceClient interface {
+	CreateWorkOrder(ctx context.Context, in *CreateWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrder, error)
+	ListWorkOrders(ctx context.Context, in *ListWorkOrdersRequest, opts ...grpc.CallOption) (*ListWorkOrdersResponse, error)
+	TransitionWorkOrder(ctx context.Context, in *TransitionWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrder, error)
+	// WatchWorkOrders envia as OS em aberto e, depois, cada OS alterada (inclusive
+	// as concluídas ou canceladas), em ordem de revisão, até o cliente cancelar.
+	WatchWorkOrders(ctx context.Context, in *WatchWorkOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WorkOrderEvent], error)
+}
+
+type workOrderServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWorkOrderServiceClient(cc grpc.ClientConnInterface) WorkOrderServiceClient {
+	return &workOrderServiceClient{cc}
+}
+
+func (c *workOrderServiceClient) CreateWorkOrder(ctx context.Context, in *CreateWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkOrder)
+	err := c.cc.Invoke(ctx, WorkOrderService_CreateWorkOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workOrderServiceClient) ListWorkOrders(ctx context.Context, in *ListWorkOrdersRequest, opts ...grpc.CallOption) (*ListWorkOrdersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWorkOrdersResponse)
+	err := c.cc.Invoke(ctx, WorkOrderService_ListWorkOrders_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workOrderServiceClient) TransitionWorkOrder(ctx context.Context, in *TransitionWorkOrderRequest, opts ...grpc.CallOption) (*WorkOrder, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkOrder)
+	err := c.cc.Invoke(ctx, WorkOrderService_TransitionWorkOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workOrderServiceClient) WatchWorkOrders(ctx context.Context, in *WatchWorkOrdersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WorkOrderEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &WorkOrderService_ServiceDesc.Streams[0], WorkOrderService_WatchWorkOrders_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchWorkOrdersRequest, WorkOrderEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkOrderService_WatchWorkOrdersClient = grpc.ServerStreamingClient[WorkOrderEvent]
+
+// WorkOrderServiceServer is the server API for WorkOrderService service.
+// All implementations must embed UnimplementedWorkOrderServiceServer
+// for forward compatibility.
+//
+// WorkOrderService abre, lista e movimenta ordens de serviço.
+type WorkOrderServiceServer interface {
+	CreateWorkOrder(context.Context, *CreateWorkOrderRequest) (*WorkOrder, error)
+	ListWorkOrders(context.Context, *ListWorkOrdersRequest) (*ListWorkOrdersResponse, error)
+	TransitionWorkOrder(context.Context, *TransitionWorkOrderRequest) (*WorkOrder, error)
+	// WatchWorkOrders envia as OS em aberto e, depois, cada OS alterada (inclusive
+	// as concluídas ou canceladas), em ordem de revisão, até o cliente cancelar.
+	WatchWorkOrders(*WatchWorkOrdersRequest, grpc.ServerStreamingServer[WorkOrderEvent]) error
+	mustEmbedUnimplementedWorkOrderServiceServer()
+}
+
+// UnimplementedWorkOrderServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedWorkOrderServiceServer struct{}
+
+func (UnimplementedWorkOrderServiceServer) CreateWorkOrder(context.Context, *CreateWorkOrderRequest) (*WorkOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWorkOrder not implemented")
+}
+func (UnimplementedWorkOrderServiceServer) ListWorkOrders(context.Context, *ListWorkOrdersRequest) (*ListWorkOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWorkOrders not implemented")
+}
+func (UnimplementedWorkOrderServiceServer) TransitionWorkOrder(context.Context, *TransitionWorkOrderRequest) (*WorkOrder, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransitionWorkOrder not implemented")
+}
+func (UnimplementedWorkOrderServiceServer) WatchWorkOrders(*WatchWorkOrdersRequest, grpc.ServerStreamingServer[WorkOrderEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchWorkOrders not implemented")
+}
+func (UnimplementedWorkOrderServiceServer) mustEmbedUnimplementedWorkOrderServiceServer() {}
+func (UnimplementedWorkOrderServiceServer) testEmbeddedByValue()                          {}
+
+// UnsafeWorkOrderServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WorkOrderServiceServer will
+// result in compilation errors.
+type UnsafeWorkOrderServiceServer interface {
+	mustEmbedUnimplementedWorkOrderServiceServer()
+}
+
+func RegisterWorkOrderServiceServer(s grpc.ServiceRegistrar, srv WorkOrderServiceServer) {
+	// If the following call pancis, it indicates UnimplementedWorkOrderServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&WorkOrderService_ServiceDesc, srv)
+}
+
+func _WorkOrderService_CreateWorkOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWorkOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkOrderServiceServer).CreateWorkOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkOrderService_CreateWorkOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkOrderServiceServer).CreateWorkOrder(ctx, req.(*CreateWorkOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkOrderService_ListWorkOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWorkOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkOrderServiceServer).ListWorkOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkOrderService_ListWorkOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkOrderServiceServer).ListWorkOrders(ctx, req.(*ListWorkOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkOrderService_TransitionWorkOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransitionWorkOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkOrderServiceServer).TransitionWorkOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WorkOrderService_TransitionWorkOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkOrderServiceServer).TransitionWorkOrder(ctx, req.(*TransitionWorkOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkOrderService_WatchWorkOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchWorkOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkOrderServiceServer).WatchWorkOrders(m, &grpc.GenericServerStream[WatchWorkOrdersRequest, WorkOrderEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type WorkOrderService_WatchWorkOrdersServer = grpc.ServerStreamingServer[WorkOrderEvent]
+
+// WorkOrderService_ServiceDesc is the grpc.ServiceDesc for WorkOrderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WorkOrderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "maintenance.v1.WorkOrderService",
+	HandlerType: (*WorkOrderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateWorkOrder",
+			Handler:    _WorkOrderService_CreateWorkOrder_Handler,
+		},
+		{
+			MethodName: "ListWorkOrders",
+			Handler:    _WorkOrderService_ListWorkOrders_Handler,
+		},
+		{
+			MethodName: "TransitionWorkOrder",
+			Handler:    _WorkOrderService_TransitionWorkOrder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchWorkOrders",
+			Handler:       _WorkOrderService_WatchWorkOrders_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "maintenance/v1/maintenance.proto",
+}
+
+const (
+	MaintenancePlanService_CreateMaintenancePlan_FullMethodName = "/maintenance.v1.MaintenancePlanService/CreateMaintenancePlan"
+	MaintenancePlanService_ListMaintenancePlans_FullMethodName  = "/maintenance.v1.MaintenancePlanService/ListMaintenancePlans"
+)
+
+// MaintenancePlanServiceClient is the client API for MaintenancePlanService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MaintenancePlanService cadastra os planos de preventiva.
+type MaintenancePlanServiceClient interface {
+	CreateMaintenancePlan(ctx context.Context, in *CreateMaintenancePlanRequest, opts ...grpc.CallOption) (*MaintenancePlan, error)
+	ListMaintenancePlans(ctx context.Context, in *ListMaintenancePlansRequest, opts ...grpc.CallOption) (*ListMaintenancePlansResponse, error)
+}
+
+type maintenancePlanServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMaintenancePlanServiceClient(cc grpc.ClientConnInterface) MaintenancePlanServiceClient {
+	return &maintenancePlanServiceClient{cc}
+}
+
+func (c *maintenancePlanServiceClient) CreateMaintenancePlan(ctx context.Context, in *CreateMaintenancePlanRequest, opts ...grpc.CallOption) (*MaintenancePlan, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MaintenancePlan)
+	err := c.cc.Invoke(ctx, MaintenancePlanService_CreateMaintenancePlan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *maintenancePlanServiceClient) ListMaintenancePlans(ctx context.Context, in *ListMaintenancePlansRequest, opts ...grpc.CallOption) (*ListMaintenancePlansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMaintenancePlansResponse)
+	err := c.cc.Invoke(ctx, MaintenancePlanService_ListMaintenancePlans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MaintenancePlanServiceServer is the server API for MaintenancePlanService service.
+// All implementations must embed UnimplementedMaintenancePlanServiceServer
+// for forward compatibility.
+//
+// MaintenancePlanService cadastra os planos de preventiva.
+type MaintenancePlanServiceServer interface {
+	CreateMaintenancePlan(context.Context, *CreateMaintenancePlanRequest) (*MaintenancePlan, error)
+	ListMaintenancePlans(context.Context, *ListMaintenancePlansRequest) (*ListMaintenancePlansResponse, error)
+	mustEmbedUnimplementedMaintenancePlanServiceServer()
+}
+
+// UnimplementedMaintenancePlanServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMaintenancePlanServiceServer struct{}
+
+func (UnimplementedMaintenancePlanServiceServer) CreateMaintenancePlan(context.Context, *CreateMaintenancePlanRequest) (*MaintenancePlan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateMaintenancePlan not implemented")
+}
+func (UnimplementedMaintenancePlanServiceServer) ListMaintenancePlans(context.Context, *ListMaintenancePlansRequest) (*ListMaintenancePlansResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMaintenancePlans not implemented")
+}
+func (UnimplementedMaintenancePlanServiceServer) mustEmbedUnimplementedMaintenancePlanServiceServer() {
+}
+func (UnimplementedMaintenancePlanServiceServer) testEmbeddedByValue() {}
+
+// UnsafeMaintenancePlanServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MaintenancePlanServiceServer will
+// result in compilation errors.
+type UnsafeMaintenancePlanServiceServer interface {
+	mustEmbedUnimplementedMaintenancePlanServiceServer()
+}
+
+func RegisterMaintenancePlanServiceServer(s grpc.ServiceRegistrar, srv MaintenancePlanServiceServer) {
+	// If the following call pancis, it indicates UnimplementedMaintenancePlanServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MaintenancePlanService_ServiceDesc, srv)
+}
+
+func _MaintenancePlanService_CreateMaintenancePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateMaintenancePlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenancePlanServiceServer).CreateMaintenancePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MaintenancePlanService_CreateMaintenancePlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenancePlanServiceServer).CreateMaintenancePlan(ctx, req.(*CreateMaintenancePlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MaintenancePlanService_ListMaintenancePlans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMaintenancePlansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MaintenancePlanServiceServer).ListMaintenancePlans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MaintenancePlanService_ListMaintenancePlans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MaintenancePlanServiceServer).ListMaintenancePlans(ctx, req.(*ListMaintenancePlansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MaintenancePlanService_ServiceDesc is the grpc.ServiceDesc for MaintenancePlanService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MaintenancePlanService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "maintenance.v1.MaintenancePlanService",
+	HandlerType: (*MaintenancePlanServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateMaintenancePlan",
+			Handler:    _MaintenancePlanService_CreateMaintenancePlan_Handler,
+		},
+		{
+			MethodName: "ListMaintenancePlans",
+			Handler:    _MaintenancePlanService_ListMaintenancePlans_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "maintenance/v1/maintenance.proto",
+}
@@ -0,0 +1,204 @@
+// Package grpcapi serve a API gRPC (proto/maintenance/v1) sobre os mesmos
+// serviços da API REST: as regras de negócio, o escopo por site e os erros de
+// domínio são os mesmos, só o transporte muda.
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/auth"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	pb "github.com/maxwellsouza/go-factory-maintenance/internal/grpcapi/maintenancev1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// TokenVerifier confere o token Bearer (auth.Signer em produção).
+type TokenVerifier interface {
+	Verify(token string) (*auth.Claims, error)
+}
+
+// Services são os serviços expostos; todos obrigatórios.
+type Services struct {
+	Assets           *service.AssetService
+	WorkOrders       *service.WorkOrderService
+	MaintenancePlans *service.MaintenancePlanService
+}
+
+// NewServer monta o servidor gRPC com trace, log e autenticação por chamada e
+// registra os serviços (e a reflection, para grpcurl e afins).
+func NewServer(v TokenVerifier, svc Services, opts ...grpc.ServerOption) *grpc.Server {
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaryInterceptor(v)),
+		grpc.ChainStreamInterceptor(streamInterceptor(v)),
+	}, opts...)
+	s := grpc.NewServer(opts...)
+	pb.RegisterAssetServiceServer(s, &assetServer{svc: svc.Assets})
+	pb.RegisterWorkOrderServiceServer(s, &workOrderServer{svc: svc.WorkOrders})
+	pb.RegisterMaintenancePlanServiceServer(s, &maintenancePlanServer{svc: svc.MaintenancePlans})
+	reflection.Register(s)
+	return s
+}
+
+var tracer = otel.Tracer("github.com/maxwellsouza/go-factory-maintenance/internal/grpcapi")
+
+func unaryInterceptor(v TokenVerifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var resp any
+		err := serve(ctx, v, info.FullMethod, func(ctx context.Context) error {
+			var err error
+			resp, err = handler(ctx, req)
+			return err
+		})
+		return resp, err
+	}
+}
+
+func streamInterceptor(v TokenVerifier) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, "/grpc.reflection.") {
+			return handler(srv, ss)
+		}
+		return serve(ss.Context(), v, info.FullMethod, func(ctx context.Context) error {
+			return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+		})
+	}
+}
+
+// serve faz, para cada chamada, o que TracingMiddleware, LoggerMiddleware e
+// AuthMiddleware fazem na API REST.
+func serve(ctx context.Context, v TokenVerifier, method string, call func(context.Context) error) error {
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	ctx, span := tracer.Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attribute.String("rpc.system", "grpc"), attribute.String("rpc.method", method)),
+	)
+	defer span.End()
+
+	err := authenticate(&ctx, v, md)
+	if err == nil {
+		err = call(ctx)
+	}
+	err = statusError(err)
+
+	code := status.Code(err)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", code.String()))
+	fields := log.Fields{"method": method, "code": code.String(), "latency": time.Since(start)}
+	if sc := span.SpanContext(); sc.IsValid() {
+		fields["trace_id"] = sc.TraceID().String()
+	}
+	switch code {
+	case codes.Internal, codes.Unknown, codes.Unavailable:
+		span.SetStatus(otelcodes.Error, code.String())
+		log.WithFields(fields).WithError(err).Error("rpc failed")
+	default:
+		log.WithFields(fields).Info("rpc completed")
+	}
+	return err
+}
+
+// authenticate exige "authorization: Bearer <token>" e coloca o principal no contexto.
+func authenticate(ctx *context.Context, v TokenVerifier, md metadata.MD) error {
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return domain.ErrUnauthorized
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok || strings.TrimSpace(token) == "" {
+		return domain.ErrUnauthorized
+	}
+	claims, err := v.Verify(strings.TrimSpace(token))
+	if err != nil {
+		return err
+	}
+	trace.SpanFromContext(*ctx).SetAttributes(
+		attribute.Int64("enduser.id", claims.UserID),
+		attribute.Int64("site.id", claims.SiteID),
+	)
+	*ctx = tenant.WithPrincipal(*ctx, claims.Principal())
+	return nil
+}
+
+// statusError converte os erros de domínio nos códigos gRPC equivalentes aos
+// status HTTP da API REST.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	var code codes.Code
+	var msg string
+	switch {
+	case errors.Is(err, context.Canceled):
+		code, msg = codes.Canceled, "chamada cancelada"
+	case errors.Is(err, context.DeadlineExceeded):
+		code, msg = codes.DeadlineExceeded, "prazo da chamada esgotado"
+	case errors.Is(err, domain.ErrNotFound):
+		code, msg = codes.NotFound, "registro não encontrado"
+	case errors.Is(err, domain.ErrInvalidInput):
+		code, msg = codes.InvalidArgument, "entrada inválida"
+	case errors.Is(err, domain.ErrAlreadyExists):
+		code, msg = codes.AlreadyExists, "registro já existente"
+	case errors.Is(err, domain.ErrConflict):
+		code, msg = codes.FailedPrecondition, "conflito com o estado atual"
+	case errors.Is(err, domain.ErrPrecondition):
+		code, msg = codes.FailedPrecondition, "pré-condição não atendida"
+	case errors.Is(err, domain.ErrUnauthorized):
+		code, msg = codes.Unauthenticated, "não autorizado"
+	case errors.Is(err, domain.ErrForbidden):
+		code, msg = codes.PermissionDenied, "acesso negado"
+	case errors.Is(err, domain.ErrRateLimited):
+		code, msg = codes.ResourceExhausted, "limite de requisições excedido"
+	default:
+		code, msg = codes.Internal, err.Error()
+	}
+	return status.Error(code, msg)
+}
+
+// serverStream troca o contexto do stream pelo contexto autenticado.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context { return s.ctx }
+
+// metadataCarrier lê o traceparent dos metadados da chamada.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if v := metadata.MD(c).Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) { metadata.MD(c).Set(key, value) }
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+var _ propagation.TextMapCarrier = metadataCarrier(nil)
@@ -0,0 +1,153 @@
+package grpcapi_test
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/auth"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/grpcapi"
+	pb "github.com/maxwellsouza/go-factory-maintenance/internal/grpcapi/maintenancev1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// setupServer sobe o servidor sobre bufconn (sem rede) com repositórios em
+// memória e devolve a conexão do cliente e um contexto autenticado no site 1.
+func setupServer(t *testing.T) (*grpc.ClientConn, context.Context) {
+	t.Helper()
+	signer, err := auth.NewSigner([]byte("grpc-test-secret-with-32-bytes!!"))
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	checklists := memory.NewChecklistMemoryRepo(orders)
+	jobPlans := memory.NewJobPlanMemoryRepo()
+	srv := grpcapi.NewServer(signer, grpcapi.Services{
+		Assets: service.NewAssetService(assets),
+		WorkOrders: service.NewWorkOrderService(orders,
+			service.WithAssets(assets),
+			service.WithChecklists(jobPlans, checklists),
+			service.WithChangeFeed(memory.NewSyncMemoryRepo(assets, orders, checklists), 10*time.Millisecond),
+		),
+		MaintenancePlans: service.NewMaintenancePlanService(memory.NewMaintenancePlanMemoryRepo(), assets, jobPlans),
+	})
+	lis := bufconn.Listen(1 << 20)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	token, err := signer.Issue(&domain.User{ID: 1, SiteID: 1}, time.Hour)
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	return conn, metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestGRPC_AssetsAndErrorCodes(t *testing.T) {
+	conn, ctx := setupServer(t)
+	assets := pb.NewAssetServiceClient(conn)
+
+	if _, err := assets.ListAssets(context.Background(), &pb.ListAssetsRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("without token: %v, want Unauthenticated", err)
+	}
+
+	created, err := assets.CreateAsset(ctx, &pb.CreateAssetRequest{Name: "Prensa", Criticality: pb.Criticality_CRITICALITY_A})
+	if err != nil {
+		t.Fatalf("CreateAsset: %v", err)
+	}
+	if created.GetId() == 0 || created.GetSiteId() != 1 || created.GetCriticality() != pb.Criticality_CRITICALITY_A {
+		t.Fatalf("created = %+v", created)
+	}
+	got, err := assets.GetAsset(ctx, &pb.GetAssetRequest{Id: created.GetId()})
+	if err != nil || got.GetName() != "Prensa" {
+		t.Fatalf("GetAsset = %+v, %v", got, err)
+	}
+
+	cases := []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"not found", func() error {
+			_, err := assets.GetAsset(ctx, &pb.GetAssetRequest{Id: 999})
+			return err
+		}, codes.NotFound},
+		{"invalid name", func() error {
+			_, err := assets.CreateAsset(ctx, &pb.CreateAssetRequest{Name: "P"})
+			return err
+		}, codes.InvalidArgument},
+		{"unknown enum", func() error {
+			_, err := assets.ListAssets(ctx, &pb.ListAssetsRequest{Criticality: 42})
+			return err
+		}, codes.InvalidArgument},
+	}
+	for _, tc := range cases {
+		if err := tc.call(); status.Code(err) != tc.want {
+			t.Errorf("%s: %v, want %s", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestGRPC_WorkOrderTransitionAndWatch(t *testing.T) {
+	conn, ctx := setupServer(t)
+	asset, err := pb.NewAssetServiceClient(conn).CreateAsset(ctx, &pb.CreateAssetRequest{Name: "Torno"})
+	if err != nil {
+		t.Fatalf("CreateAsset: %v", err)
+	}
+	orders := pb.NewWorkOrderServiceClient(conn)
+	wo, err := orders.CreateWorkOrder(ctx, &pb.CreateWorkOrderRequest{AssetId: asset.GetId(), Title: "Vazamento de óleo"})
+	if err != nil {
+		t.Fatalf("CreateWorkOrder: %v", err)
+	}
+	if wo.GetStatus() != pb.WorkOrderStatus_WORK_ORDER_STATUS_OPEN || wo.GetType() != pb.WorkOrderType_WORK_ORDER_TYPE_CORRECTIVE {
+		t.Fatalf("created = %+v", wo)
+	}
+
+	// Transição inválida (aberta → aberta) é conflito: FailedPrecondition.
+	_, err = orders.TransitionWorkOrder(ctx, &pb.TransitionWorkOrderRequest{Id: wo.GetId(), Status: pb.WorkOrderStatus_WORK_ORDER_STATUS_OPEN})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("invalid transition: %v, want FailedPrecondition", err)
+	}
+
+	watchCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	stream, err := orders.WatchWorkOrders(watchCtx, &pb.WatchWorkOrdersRequest{})
+	if err != nil {
+		t.Fatalf("WatchWorkOrders: %v", err)
+	}
+	first, err := stream.Recv()
+	if err != nil || first.GetWorkOrder().GetId() != wo.GetId() || first.GetRevision() == 0 {
+		t.Fatalf("first event = %+v, %v", first, err)
+	}
+
+	if _, err := orders.TransitionWorkOrder(ctx, &pb.TransitionWorkOrderRequest{
+		Id: wo.GetId(), Status: pb.WorkOrderStatus_WORK_ORDER_STATUS_CANCELED,
+	}); err != nil {
+		t.Fatalf("TransitionWorkOrder: %v", err)
+	}
+	next, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if next.GetWorkOrder().GetStatus() != pb.WorkOrderStatus_WORK_ORDER_STATUS_CANCELED || next.GetRevision() <= first.GetRevision() {
+		t.Fatalf("next event = %+v", next)
+	}
+}
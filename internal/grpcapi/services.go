@@ -0,0 +1,239 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	pb "github.com/maxwellsouza/go-factory-maintenance/internal/grpcapi/maintenancev1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+// As validações de formato repetem as tags binding dos handlers REST; as regras
+// de negócio ficam nos serviços.
+
+type assetServer struct {
+	pb.UnimplementedAssetServiceServer
+	svc *service.AssetService
+}
+
+func (s *assetServer) CreateAsset(ctx context.Context, req *pb.CreateAssetRequest) (*pb.Asset, error) {
+	criticality, err := fromEnum(criticalities, req.GetCriticality())
+	if err != nil {
+		return nil, err
+	}
+	if utf8.RuneCountInString(req.GetName()) < 2 || len(req.GetExternalCode()) > 64 ||
+		len(req.GetClass()) > 63 || (req.IdealRatePerHour != nil && req.GetIdealRatePerHour() <= 0) {
+		return nil, domain.ErrInvalidInput
+	}
+	a := domain.Asset{
+		Name:             req.GetName(),
+		Location:         req.GetLocation(),
+		Criticality:      criticality,
+		ExternalCode:     req.GetExternalCode(),
+		IdealRatePerHour: req.IdealRatePerHour,
+		Manufacturer:     req.GetManufacturer(),
+		Model:            req.GetModel(),
+		SerialNumber:     req.GetSerialNumber(),
+		InstalledOn:      req.GetInstalledOn(),
+		WarrantyUntil:    req.GetWarrantyUntil(),
+		Class:            req.GetClass(),
+	}
+	if req.GetAttributes() != nil {
+		a.Attributes = req.GetAttributes().AsMap()
+	}
+	if err := s.svc.Create(ctx, &a); err != nil {
+		return nil, err
+	}
+	return toAsset(&a)
+}
+
+func (s *assetServer) GetAsset(ctx context.Context, req *pb.GetAssetRequest) (*pb.Asset, error) {
+	if req.GetId() <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	a, err := s.svc.Get(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	return toAsset(a)
+}
+
+func (s *assetServer) ListAssets(ctx context.Context, req *pb.ListAssetsRequest) (*pb.ListAssetsResponse, error) {
+	criticality, err := fromEnum(criticalities, req.GetCriticality())
+	if err != nil {
+		return nil, err
+	}
+	assets, err := s.svc.List(ctx, domain.AssetFilter{
+		Location:    req.GetLocation(),
+		Criticality: criticality,
+		Class:       req.GetClass(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := &pb.ListAssetsResponse{Assets: make([]*pb.Asset, 0, len(assets))}
+	for i := range assets {
+		a, err := toAsset(&assets[i])
+		if err != nil {
+			return nil, err
+		}
+		out.Assets = append(out.Assets, a)
+	}
+	return out, nil
+}
+
+type workOrderServer struct {
+	pb.UnimplementedWorkOrderServiceServer
+	svc *service.WorkOrderService
+}
+
+func (s *workOrderServer) CreateWorkOrder(ctx context.Context, req *pb.CreateWorkOrderRequest) (*pb.WorkOrder, error) {
+	typ, err := fromEnum(workOrderTypes, req.GetType())
+	if err != nil {
+		return nil, err
+	}
+	status, err := fromEnum(workOrderStatuses, req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	breakdownAt, err := timePtr(req.GetBreakdownAt())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetAssetId() <= 0 || utf8.RuneCountInString(req.GetTitle()) < 3 || len(req.GetTrade()) > 64 ||
+		(req.EstimatedMinutes != nil && req.GetEstimatedMinutes() <= 0) ||
+		(req.JobPlanId != nil && req.GetJobPlanId() <= 0) {
+		return nil, domain.ErrInvalidInput
+	}
+	o := domain.WorkOrder{
+		AssetID:          req.GetAssetId(),
+		Type:             typ,
+		Status:           status,
+		Title:            req.GetTitle(),
+		Description:      req.GetDescription(),
+		BreakdownAt:      breakdownAt,
+		Trade:            req.GetTrade(),
+		EstimatedMinutes: req.EstimatedMinutes,
+		JobPlanID:        req.JobPlanId,
+	}
+	if err := s.svc.Create(ctx, &o); err != nil {
+		return nil, err
+	}
+	return toWorkOrder(&o), nil
+}
+
+func (s *workOrderServer) ListWorkOrders(ctx context.Context, req *pb.ListWorkOrdersRequest) (*pb.ListWorkOrdersResponse, error) {
+	status, err := fromEnum(workOrderStatuses, req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	typ, err := fromEnum(workOrderTypes, req.GetType())
+	if err != nil {
+		return nil, err
+	}
+	f := domain.WorkOrderFilter{Status: status, Type: typ, AssetID: req.GetAssetId()}
+	if req.GetOverdue() {
+		now := time.Now()
+		f.OverdueAt = &now
+	}
+	orders, err := s.svc.List(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	out := &pb.ListWorkOrdersResponse{WorkOrders: make([]*pb.WorkOrder, 0, len(orders))}
+	for i := range orders {
+		out.WorkOrders = append(out.WorkOrders, toWorkOrder(&orders[i]))
+	}
+	return out, nil
+}
+
+func (s *workOrderServer) TransitionWorkOrder(ctx context.Context, req *pb.TransitionWorkOrderRequest) (*pb.WorkOrder, error) {
+	status, err := fromEnum(workOrderStatuses, req.GetStatus())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetId() <= 0 || status == "" ||
+		len(req.GetCause()) > 2000 || len(req.GetSolution()) > 2000 {
+		return nil, domain.ErrInvalidInput
+	}
+	o, err := s.svc.Transition(ctx, req.GetId(), service.TransitionRequest{
+		Status:          status,
+		FailureModeID:   req.FailureModeId,
+		FailureCauseID:  req.FailureCauseId,
+		FailureActionID: req.FailureActionId,
+		Cause:           req.GetCause(),
+		Solution:        req.GetSolution(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toWorkOrder(o), nil
+}
+
+// WatchWorkOrders segue o feed de revisões até o cliente cancelar; o fim da
+// chamada por cancelamento não é erro.
+func (s *workOrderServer) WatchWorkOrders(req *pb.WatchWorkOrdersRequest, stream pb.WorkOrderService_WatchWorkOrdersServer) error {
+	typ, err := fromEnum(workOrderTypes, req.GetType())
+	if err != nil {
+		return err
+	}
+	filter := domain.WorkOrderFilter{Type: typ, AssetID: req.GetAssetId()}
+	err = s.svc.Watch(stream.Context(), req.GetSinceRevision(), filter, func(o *domain.WorkOrder) error {
+		return stream.Send(&pb.WorkOrderEvent{Revision: o.Revision, WorkOrder: toWorkOrder(o)})
+	})
+	if stream.Context().Err() != nil {
+		return nil
+	}
+	return err
+}
+
+type maintenancePlanServer struct {
+	pb.UnimplementedMaintenancePlanServiceServer
+	svc *service.MaintenancePlanService
+}
+
+func (s *maintenancePlanServer) CreateMaintenancePlan(ctx context.Context, req *pb.CreateMaintenancePlanRequest) (*pb.MaintenancePlan, error) {
+	ruleType, err := fromEnum(planRuleTypes, req.GetRuleType())
+	if err != nil {
+		return nil, err
+	}
+	lastExecution, err := timePtr(req.GetLastExecution())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetAssetId() <= 0 || ruleType == "" || len(req.GetTrade()) > 64 ||
+		(req.FrequencyDays != nil && req.GetFrequencyDays() <= 0) ||
+		(req.MeterTarget != nil && req.GetMeterTarget() <= 0) ||
+		(req.JobPlanId != nil && req.GetJobPlanId() <= 0) ||
+		(req.EstimatedMinutes != nil && req.GetEstimatedMinutes() <= 0) {
+		return nil, domain.ErrInvalidInput
+	}
+	plan := domain.MaintenancePlan{
+		AssetID:          req.GetAssetId(),
+		RuleType:         ruleType,
+		FrequencyDays:    req.FrequencyDays,
+		MeterTarget:      req.MeterTarget,
+		LastExecution:    lastExecution,
+		JobPlanID:        req.JobPlanId,
+		Trade:            req.GetTrade(),
+		EstimatedMinutes: req.EstimatedMinutes,
+	}
+	if err := s.svc.Create(ctx, &plan); err != nil {
+		return nil, err
+	}
+	return toMaintenancePlan(&plan), nil
+}
+
+func (s *maintenancePlanServer) ListMaintenancePlans(ctx context.Context, _ *pb.ListMaintenancePlansRequest) (*pb.ListMaintenancePlansResponse, error) {
+	plans, err := s.svc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := &pb.ListMaintenancePlansResponse{MaintenancePlans: make([]*pb.MaintenancePlan, 0, len(plans))}
+	for i := range plans {
+		out.MaintenancePlans = append(out.MaintenancePlans, toMaintenancePlan(&plans[i]))
+	}
+	return out, nil
+}
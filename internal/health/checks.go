@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Pinger é satisfeito por *pgxpool.Pool e *postgres.DB.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// PingCheck verifica a conectividade com o banco.
+func PingCheck(p Pinger) CheckFunc {
+	return func(ctx context.Context) error {
+		return p.Ping(ctx)
+	}
+}
+
+// MigrationCheck compara a versão aplicada no banco com a esperada pelo binário.
+func MigrationCheck(current func(ctx context.Context) (int64, error), expected int64) CheckFunc {
+	return func(ctx context.Context) error {
+		v, err := current(ctx)
+		if err != nil {
+			return err
+		}
+		if v != expected {
+			return fmt.Errorf("schema version %d, expected %d", v, expected)
+		}
+		return nil
+	}
+}
+
+// BacklogCheck falha quando uma fila (ex: outbox) acumula mais que "max" itens.
+func BacklogCheck(count func(ctx context.Context) (int64, error), max int64) CheckFunc {
+	return func(ctx context.Context) error {
+		n, err := count(ctx)
+		if err != nil {
+			return err
+		}
+		if n > max {
+			return fmt.Errorf("backlog %d exceeds %d", n, max)
+		}
+		return nil
+	}
+}
+
+// MaxFailures é quantos ciclos seguidos com erro derrubam o Heartbeat.Check.
+const MaxFailures = 3
+
+// Heartbeat é atualizado por workers em background (ex: scheduler) a cada
+// ciclo: Beat no ciclo bem-sucedido, Fail no que terminou com erro.
+type Heartbeat struct {
+	last atomic.Int64 // unix nano do último ciclo bem-sucedido
+
+	mu       sync.Mutex
+	failures int   // ciclos seguidos com erro
+	lastErr  error // erro do último ciclo que falhou
+}
+
+// Beat registra um ciclo bem-sucedido e zera a contagem de falhas.
+func (h *Heartbeat) Beat() {
+	h.last.Store(time.Now().UnixNano())
+	h.mu.Lock()
+	h.failures, h.lastErr = 0, nil
+	h.mu.Unlock()
+}
+
+// Fail registra um ciclo que terminou com erro; o último sucesso não muda.
+func (h *Heartbeat) Fail(err error) {
+	h.mu.Lock()
+	h.failures++
+	h.lastErr = err
+	h.mu.Unlock()
+}
+
+// Last retorna o instante do último Beat (zero se nunca bateu).
+func (h *Heartbeat) Last() time.Time {
+	n := h.last.Load()
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n)
+}
+
+// Check falha se o último ciclo bem-sucedido for mais antigo que maxAge ou se
+// os últimos MaxFailures ciclos falharam: worker que erra em todo ciclo não
+// conta como vivo.
+func (h *Heartbeat) Check(maxAge time.Duration) CheckFunc {
+	return func(context.Context) error {
+		h.mu.Lock()
+		failures, lastErr := h.failures, h.lastErr
+		h.mu.Unlock()
+		if failures >= MaxFailures {
+			return fmt.Errorf("%d consecutive failures, last: %w", failures, lastErr)
+		}
+		last := h.Last()
+		if last.IsZero() {
+			if lastErr != nil {
+				return fmt.Errorf("no successful cycle yet, last: %w", lastErr)
+			}
+			return fmt.Errorf("no heartbeat yet")
+		}
+		if age := time.Since(last); age > maxAge {
+			if lastErr != nil {
+				return fmt.Errorf("last success %s ago, last: %w", age.Round(time.Second), lastErr)
+			}
+			return fmt.Errorf("last heartbeat %s ago", age.Round(time.Second))
+		}
+		return nil
+	}
+}
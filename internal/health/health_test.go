@@ -0,0 +1,103 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/health"
+)
+
+func TestRegistry_Run(t *testing.T) {
+	ok := func(context.Context) error { return nil }
+	failing := func(context.Context) error { return errors.New("boom") }
+	slow := func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }
+
+	tests := []struct {
+		name   string
+		checks map[string]health.CheckFunc
+		want   health.Status
+	}{
+		{name: "no checks", checks: nil, want: health.StatusOK},
+		{name: "all ok", checks: map[string]health.CheckFunc{"a": ok, "b": ok}, want: health.StatusOK},
+		{name: "one failing", checks: map[string]health.CheckFunc{"a": ok, "b": failing}, want: health.StatusFail},
+		{name: "timeout", checks: map[string]health.CheckFunc{"slow": slow}, want: health.StatusFail},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			reg := health.NewRegistry(50 * time.Millisecond)
+			for name, c := range tt.checks {
+				reg.Register(name, c)
+			}
+			report := reg.Run(context.Background())
+			if report.Status != tt.want {
+				t.Fatalf("expected %s, got %s (%+v)", tt.want, report.Status, report.Checks)
+			}
+			if len(report.Checks) != len(tt.checks) {
+				t.Fatalf("expected %d check results, got %d", len(tt.checks), len(report.Checks))
+			}
+		})
+	}
+}
+
+func TestRegistry_DrainFailsReadiness(t *testing.T) {
+	reg := health.NewRegistry(time.Second)
+	reg.Register("db", func(context.Context) error { return nil })
+
+	if got := reg.Run(context.Background()).Status; got != health.StatusOK {
+		t.Fatalf("expected ok before drain, got %s", got)
+	}
+	reg.Drain()
+	if got := reg.Run(context.Background()).Status; got != health.StatusFail {
+		t.Fatalf("expected fail after drain, got %s", got)
+	}
+}
+
+func TestChecks(t *testing.T) {
+	ctx := context.Background()
+
+	version := func(v int64) func(context.Context) (int64, error) {
+		return func(context.Context) (int64, error) { return v, nil }
+	}
+	if err := health.MigrationCheck(version(20251103), 20251103)(ctx); err != nil {
+		t.Fatalf("matching migration version should pass: %v", err)
+	}
+	if err := health.MigrationCheck(version(20251103), 20251110)(ctx); err == nil {
+		t.Fatalf("outdated schema should fail")
+	}
+
+	if err := health.BacklogCheck(version(10), 100)(ctx); err != nil {
+		t.Fatalf("small backlog should pass: %v", err)
+	}
+	if err := health.BacklogCheck(version(101), 100)(ctx); err == nil {
+		t.Fatalf("backlog above limit should fail")
+	}
+
+	var hb health.Heartbeat
+	if err := hb.Check(time.Minute)(ctx); err == nil {
+		t.Fatalf("heartbeat without beats should fail")
+	}
+	hb.Beat()
+	if err := hb.Check(time.Minute)(ctx); err != nil {
+		t.Fatalf("fresh heartbeat should pass: %v", err)
+	}
+	// Worker vivo, mas falhando em todo ciclo.
+	dbDown := errors.New("db down")
+	for i := 1; i < health.MaxFailures; i++ {
+		hb.Fail(dbDown)
+	}
+	if err := hb.Check(time.Minute)(ctx); err != nil {
+		t.Fatalf("heartbeat with %d failures should still pass: %v", health.MaxFailures-1, err)
+	}
+	hb.Fail(dbDown)
+	if err := hb.Check(time.Minute)(ctx); !errors.Is(err, dbDown) {
+		t.Fatalf("heartbeat after %d consecutive failures error = %v, want the last error", health.MaxFailures, err)
+	}
+	hb.Beat()
+	if err := hb.Check(time.Minute)(ctx); err != nil {
+		t.Fatalf("a successful cycle should reset the failures: %v", err)
+	}
+}
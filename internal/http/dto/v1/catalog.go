@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// Cadastros de apoio: sites, classes de ativo, códigos de falha, roteiros, peças e matriz de SLA.
+
+type Site struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewSite(s *domain.Site) Site {
+	return Site{ID: s.ID, Code: s.Code, Name: s.Name, CreatedAt: s.CreatedAt}
+}
+
+func NewSites(list []domain.Site) []Site {
+	return convert(list, NewSite)
+}
+
+type AttributeDef struct {
+	Key      string   `json:"key"`
+	Label    string   `json:"label,omitempty"`
+	Type     string   `json:"type"`
+	Unit     string   `json:"unit,omitempty"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options,omitempty"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+}
+
+type AssetClass struct {
+	ID         int64          `json:"id"`
+	Code       string         `json:"code"`
+	Name       string         `json:"name"`
+	Attributes []AttributeDef `json:"attributes"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+func NewAssetClass(c *domain.AssetClass) AssetClass {
+	return AssetClass{
+		ID:   c.ID,
+		Code: c.Code,
+		Name: c.Name,
+		Attributes: convert(c.Attributes, func(a *domain.AttributeDef) AttributeDef {
+			return AttributeDef{
+				Key:      a.Key,
+				Label:    a.Label,
+				Type:     string(a.Type),
+				Unit:     a.Unit,
+				Required: a.Required,
+				Options:  a.Options,
+				Min:      a.Min,
+				Max:      a.Max,
+			}
+		}),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func NewAssetClasses(list []domain.AssetClass) []AssetClass {
+	return convert(list, NewAssetClass)
+}
+
+type FailureCode struct {
+	ID        int64     `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Level     string    `json:"level"`
+	ParentID  *int64    `json:"parent_id,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewFailureCode(fc *domain.FailureCode) FailureCode {
+	return FailureCode{
+		ID:        fc.ID,
+		Code:      fc.Code,
+		Name:      fc.Name,
+		Level:     string(fc.Level),
+		ParentID:  fc.ParentID,
+		Active:    fc.Active,
+		CreatedAt: fc.CreatedAt,
+		UpdatedAt: fc.UpdatedAt,
+	}
+}
+
+func NewFailureCodes(list []domain.FailureCode) []FailureCode {
+	return convert(list, NewFailureCode)
+}
+
+// FailureCodeNode é um código da árvore modo > causa > ação, com os filhos aninhados.
+type FailureCodeNode struct {
+	FailureCode
+	Children []FailureCodeNode `json:"children,omitempty"`
+}
+
+func NewFailureCodeNode(n *domain.FailureCodeNode) FailureCodeNode {
+	node := FailureCodeNode{FailureCode: NewFailureCode(&n.FailureCode)}
+	if len(n.Children) > 0 {
+		node.Children = NewFailureCodeTree(n.Children)
+	}
+	return node
+}
+
+func NewFailureCodeTree(list []domain.FailureCodeNode) []FailureCodeNode {
+	return convert(list, NewFailureCodeNode)
+}
+
+type JobPlanStep struct {
+	Seq         int              `json:"seq"`
+	Description string           `json:"description"`
+	Measurement *MeasurementSpec `json:"measurement,omitempty"`
+}
+
+type JobPlan struct {
+	ID               int64         `json:"id"`
+	SiteID           int64         `json:"site_id"`
+	Name             string        `json:"name"`
+	Description      string        `json:"description,omitempty"`
+	Trade            string        `json:"trade,omitempty"`
+	EstimatedMinutes *int64        `json:"estimated_minutes,omitempty"`
+	Steps            []JobPlanStep `json:"steps"`
+	Parts            []JobPlanPart `json:"parts"`
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+}
+
+func NewJobPlan(jp *domain.JobPlan) JobPlan {
+	return JobPlan{
+		ID:               jp.ID,
+		SiteID:           jp.SiteID,
+		Name:             jp.Name,
+		Description:      jp.Description,
+		Trade:            jp.Trade,
+		EstimatedMinutes: jp.EstimatedMinutes,
+		Steps: convert(jp.Steps, func(s *domain.JobPlanStep) JobPlanStep {
+			return JobPlanStep{Seq: s.Seq, Description: s.Description, Measurement: newMeasurementSpec(s.Measurement)}
+		}),
+		Parts:     newJobPlanParts(jp.Parts),
+		CreatedAt: jp.CreatedAt,
+		UpdatedAt: jp.UpdatedAt,
+	}
+}
+
+func NewJobPlans(list []domain.JobPlan) []JobPlan {
+	return convert(list, NewJobPlan)
+}
+
+type SparePart struct {
+	ID          int64     `json:"id"`
+	SiteID      int64     `json:"site_id"`
+	Code        string    `json:"code"`
+	Name        string    `json:"name"`
+	Unit        string    `json:"unit"`
+	Quantity    float64   `json:"quantity"`
+	MinQuantity float64   `json:"min_quantity"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+func NewSparePart(p *domain.SparePart) SparePart {
+	return SparePart{
+		ID:          p.ID,
+		SiteID:      p.SiteID,
+		Code:        p.Code,
+		Name:        p.Name,
+		Unit:        p.Unit,
+		Quantity:    p.Quantity,
+		MinQuantity: p.MinQuantity,
+		CreatedAt:   p.CreatedAt,
+		UpdatedAt:   p.UpdatedAt,
+	}
+}
+
+func NewSpareParts(list []domain.SparePart) []SparePart {
+	return convert(list, NewSparePart)
+}
+
+type SLAPolicy struct {
+	Criticality       string `json:"criticality"`
+	Type              string `json:"type"`
+	Priority          string `json:"priority"`
+	ResponseMinutes   int64  `json:"response_minutes"`
+	ResolutionMinutes int64  `json:"resolution_minutes"`
+}
+
+func NewSLAPolicy(p *domain.SLAPolicy) SLAPolicy {
+	return SLAPolicy{
+		Criticality:       string(p.Criticality),
+		Type:              string(p.Type),
+		Priority:          string(p.Priority),
+		ResponseMinutes:   p.ResponseMinutes,
+		ResolutionMinutes: p.ResolutionMinutes,
+	}
+}
+
+func NewSLAPolicies(list []domain.SLAPolicy) []SLAPolicy {
+	return convert(list, NewSLAPolicy)
+}
@@ -0,0 +1,159 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// Paradas, solicitações do portal e importações.
+
+type DowntimeEvent struct {
+	ID          int64      `json:"id"`
+	AssetID     int64      `json:"asset_id"`
+	WorkOrderID *int64     `json:"work_order_id,omitempty"`
+	StartedAt   time.Time  `json:"started_at"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+	ReasonCode  string     `json:"reason_code"`
+	Planned     bool       `json:"planned"`
+	Notes       string     `json:"notes,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func NewDowntimeEvent(ev *domain.DowntimeEvent) DowntimeEvent {
+	return DowntimeEvent{
+		ID:          ev.ID,
+		AssetID:     ev.AssetID,
+		WorkOrderID: ev.WorkOrderID,
+		StartedAt:   ev.StartedAt,
+		EndedAt:     ev.EndedAt,
+		ReasonCode:  ev.ReasonCode,
+		Planned:     ev.Planned,
+		Notes:       ev.Notes,
+		CreatedAt:   ev.CreatedAt,
+		UpdatedAt:   ev.UpdatedAt,
+	}
+}
+
+func NewDowntimeEvents(list []domain.DowntimeEvent) []DowntimeEvent {
+	return convert(list, NewDowntimeEvent)
+}
+
+type MaintenanceRequest struct {
+	ID          int64      `json:"id"`
+	SiteID      int64      `json:"site_id"`
+	AssetID     int64      `json:"asset_id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description,omitempty"`
+	RequestedBy string     `json:"requested_by,omitempty"`
+	BreakdownAt *time.Time `json:"breakdown_at,omitempty"`
+	Status      string     `json:"status"`
+	TriageNote  string     `json:"triage_note,omitempty"`
+	Resolution  string     `json:"resolution,omitempty"`
+	WorkOrderID *int64     `json:"work_order_id,omitempty"`
+	TriagedAt   *time.Time `json:"triaged_at,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+func NewMaintenanceRequest(r *domain.MaintenanceRequest) MaintenanceRequest {
+	return MaintenanceRequest{
+		ID:          r.ID,
+		SiteID:      r.SiteID,
+		AssetID:     r.AssetID,
+		Title:       r.Title,
+		Description: r.Description,
+		RequestedBy: r.RequestedBy,
+		BreakdownAt: r.BreakdownAt,
+		Status:      string(r.Status),
+		TriageNote:  r.TriageNote,
+		Resolution:  r.Resolution,
+		WorkOrderID: r.WorkOrderID,
+		TriagedAt:   r.TriagedAt,
+		ClosedAt:    r.ClosedAt,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
+
+func NewMaintenanceRequests(list []domain.MaintenanceRequest) []MaintenanceRequest {
+	return convert(list, NewMaintenanceRequest)
+}
+
+// DuplicateCandidate é uma OS em aberto parecida com a solicitação.
+type DuplicateCandidate struct {
+	WorkOrderID int64     `json:"work_order_id"`
+	Title       string    `json:"title"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	Score       float64   `json:"score"`
+}
+
+func NewDuplicateCandidates(list []domain.DuplicateCandidate) []DuplicateCandidate {
+	return convert(list, func(d *domain.DuplicateCandidate) DuplicateCandidate {
+		return DuplicateCandidate{
+			WorkOrderID: d.WorkOrderID,
+			Title:       d.Title,
+			Status:      string(d.Status),
+			CreatedAt:   d.CreatedAt,
+			Score:       d.Score,
+		}
+	})
+}
+
+// RequestSubmission é a solicitação gravada e as possíveis OS duplicadas.
+type RequestSubmission struct {
+	Request    MaintenanceRequest   `json:"request"`
+	Duplicates []DuplicateCandidate `json:"duplicates"`
+}
+
+func NewRequestSubmission(s *domain.RequestSubmission) RequestSubmission {
+	return RequestSubmission{
+		Request:    NewMaintenanceRequest(&s.Request),
+		Duplicates: NewDuplicateCandidates(s.Duplicates),
+	}
+}
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type ImportJob struct {
+	ID         string           `json:"id"`
+	SiteID     int64            `json:"site_id"`
+	Kind       string           `json:"kind"`
+	DryRun     bool             `json:"dry_run"`
+	Status     string           `json:"status"`
+	TotalRows  int              `json:"total_rows"`
+	Processed  int              `json:"processed"`
+	Inserted   int64            `json:"inserted"`
+	ErrorCount int              `json:"error_count"`
+	Errors     []ImportRowError `json:"errors,omitempty"`
+	Failure    string           `json:"failure,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+func NewImportJob(j *domain.ImportJob) ImportJob {
+	return ImportJob{
+		ID:         j.ID,
+		SiteID:     j.SiteID,
+		Kind:       string(j.Kind),
+		DryRun:     j.DryRun,
+		Status:     string(j.Status),
+		TotalRows:  j.TotalRows,
+		Processed:  j.Processed,
+		Inserted:   j.Inserted,
+		ErrorCount: j.ErrorCount,
+		Errors: convert(j.Errors, func(e *domain.ImportRowError) ImportRowError {
+			return ImportRowError{Row: e.Row, Field: e.Field, Message: e.Message}
+		}),
+		Failure:   j.Failure,
+		CreatedAt: j.CreatedAt,
+		UpdatedAt: j.UpdatedAt,
+	}
+}
@@ -0,0 +1,161 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// Usuários, técnicos e turnos, regras de escalonamento e alertas.
+
+type User struct {
+	ID          int64               `json:"id"`
+	SiteID      int64               `json:"site_id"`
+	Name        string              `json:"name"`
+	Email       string              `json:"email,omitempty"`
+	Phone       string              `json:"phone,omitempty"`
+	ChatID      string              `json:"chat_id,omitempty"`
+	Active      bool                `json:"active"`
+	Corporate   bool                `json:"corporate"`
+	Preferences map[string][]string `json:"preferences"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+func NewUser(u *domain.User) User {
+	prefs := make(map[string][]string, len(u.Preferences))
+	for event, channels := range u.Preferences {
+		list := make([]string, 0, len(channels))
+		for _, ch := range channels {
+			list = append(list, string(ch))
+		}
+		prefs[string(event)] = list
+	}
+	return User{
+		ID:          u.ID,
+		SiteID:      u.SiteID,
+		Name:        u.Name,
+		Email:       u.Email,
+		Phone:       u.Phone,
+		ChatID:      u.ChatID,
+		Active:      u.Active,
+		Corporate:   u.Corporate,
+		Preferences: prefs,
+		CreatedAt:   u.CreatedAt,
+		UpdatedAt:   u.UpdatedAt,
+	}
+}
+
+func NewUsers(list []domain.User) []User {
+	return convert(list, NewUser)
+}
+
+type Technician struct {
+	ID        int64     `json:"id"`
+	SiteID    int64     `json:"site_id"`
+	Name      string    `json:"name"`
+	Skills    []string  `json:"skills"`
+	ShiftID   int64     `json:"shift_id"`
+	UserID    *int64    `json:"user_id,omitempty"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewTechnician(t *domain.Technician) Technician {
+	return Technician{
+		ID:        t.ID,
+		SiteID:    t.SiteID,
+		Name:      t.Name,
+		Skills:    t.Skills,
+		ShiftID:   t.ShiftID,
+		UserID:    t.UserID,
+		Active:    t.Active,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func NewTechnicians(list []domain.Technician) []Technician {
+	return convert(list, NewTechnician)
+}
+
+type Shift struct {
+	ID           int64     `json:"id"`
+	SiteID       int64     `json:"site_id"`
+	Name         string    `json:"name"`
+	Location     string    `json:"location,omitempty"`
+	StartTime    string    `json:"start_time"`
+	EndTime      string    `json:"end_time"`
+	Weekdays     []int     `json:"weekdays"`
+	BreakMinutes int       `json:"break_minutes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func NewShift(s *domain.Shift) Shift {
+	return Shift{
+		ID:           s.ID,
+		SiteID:       s.SiteID,
+		Name:         s.Name,
+		Location:     s.Location,
+		StartTime:    s.StartTime,
+		EndTime:      s.EndTime,
+		Weekdays:     s.Weekdays,
+		BreakMinutes: s.BreakMinutes,
+		CreatedAt:    s.CreatedAt,
+	}
+}
+
+func NewShifts(list []domain.Shift) []Shift {
+	return convert(list, NewShift)
+}
+
+type EscalationRule struct {
+	SiteID       int64   `json:"site_id"`
+	Event        string  `json:"event"`
+	Tier         int     `json:"tier"`
+	DelayMinutes int64   `json:"delay_minutes"`
+	UserIDs      []int64 `json:"user_ids"`
+}
+
+func NewEscalationRules(list []domain.EscalationRule) []EscalationRule {
+	return convert(list, func(r *domain.EscalationRule) EscalationRule {
+		return EscalationRule{SiteID: r.SiteID, Event: string(r.Event), Tier: r.Tier, DelayMinutes: r.DelayMinutes, UserIDs: r.UserIDs}
+	})
+}
+
+type Alert struct {
+	ID          int64      `json:"id"`
+	SiteID      int64      `json:"site_id"`
+	Event       string     `json:"event"`
+	RefType     string     `json:"ref_type"`
+	RefID       int64      `json:"ref_id"`
+	Message     string     `json:"message"`
+	TriggeredAt time.Time  `json:"triggered_at"`
+	Tier        int        `json:"tier"`
+	AckedAt     *time.Time `json:"acked_at,omitempty"`
+	AckedBy     *int64     `json:"acked_by,omitempty"`
+	ResolvedAt  *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func NewAlert(a *domain.Alert) Alert {
+	return Alert{
+		ID:          a.ID,
+		SiteID:      a.SiteID,
+		Event:       string(a.Event),
+		RefType:     string(a.RefType),
+		RefID:       a.RefID,
+		Message:     a.Message,
+		TriggeredAt: a.TriggeredAt,
+		Tier:        a.Tier,
+		AckedAt:     a.AckedAt,
+		AckedBy:     a.AckedBy,
+		ResolvedAt:  a.ResolvedAt,
+		CreatedAt:   a.CreatedAt,
+	}
+}
+
+func NewAlerts(list []domain.Alert) []Alert {
+	return convert(list, NewAlert)
+}
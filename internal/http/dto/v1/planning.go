@@ -0,0 +1,245 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// Planos de preventiva, calendário da planta e planejamento de capacidade.
+
+type MaintenancePlan struct {
+	ID                 int64      `json:"id"`
+	SiteID             int64      `json:"site_id"`
+	AssetID            int64      `json:"asset_id"`
+	RuleType           string     `json:"rule_type"`
+	FrequencyDays      *int64     `json:"frequency_days,omitempty"`
+	MeterTarget        *int64     `json:"meter_target,omitempty"`
+	LastExecution      *time.Time `json:"last_execution,omitempty"`
+	ConditionParameter string     `json:"condition_parameter,omitempty"`
+	ConditionMin       *float64   `json:"condition_min,omitempty"`
+	ConditionMax       *float64   `json:"condition_max,omitempty"`
+	JobPlanID          *int64     `json:"job_plan_id,omitempty"`
+	Trade              string     `json:"trade,omitempty"`
+	EstimatedMinutes   *int64     `json:"estimated_minutes,omitempty"`
+	Active             bool       `json:"active"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+func NewMaintenancePlan(p *domain.MaintenancePlan) MaintenancePlan {
+	return MaintenancePlan{
+		ID:                 p.ID,
+		SiteID:             p.SiteID,
+		AssetID:            p.AssetID,
+		RuleType:           string(p.RuleType),
+		FrequencyDays:      p.FrequencyDays,
+		MeterTarget:        p.MeterTarget,
+		LastExecution:      p.LastExecution,
+		ConditionParameter: p.ConditionParameter,
+		ConditionMin:       p.ConditionMin,
+		ConditionMax:       p.ConditionMax,
+		JobPlanID:          p.JobPlanID,
+		Trade:              p.Trade,
+		EstimatedMinutes:   p.EstimatedMinutes,
+		Active:             p.Active,
+		CreatedAt:          p.CreatedAt,
+		UpdatedAt:          p.UpdatedAt,
+	}
+}
+
+func NewMaintenancePlans(list []domain.MaintenancePlan) []MaintenancePlan {
+	return convert(list, NewMaintenancePlan)
+}
+
+// PreventiveOccurrence é uma preventiva programada (OS aberta ou execução projetada do plano).
+type PreventiveOccurrence struct {
+	PlanID       int64     `json:"plan_id"`
+	AssetID      int64     `json:"asset_id"`
+	WorkOrderID  *int64    `json:"work_order_id,omitempty"`
+	Title        string    `json:"title"`
+	ScheduledFor time.Time `json:"scheduled_for"`
+}
+
+func NewPreventiveOccurrences(list []domain.PreventiveOccurrence) []PreventiveOccurrence {
+	return convert(list, func(o *domain.PreventiveOccurrence) PreventiveOccurrence {
+		return PreventiveOccurrence{
+			PlanID:       o.PlanID,
+			AssetID:      o.AssetID,
+			WorkOrderID:  o.WorkOrderID,
+			Title:        o.Title,
+			ScheduledFor: o.ScheduledFor,
+		}
+	})
+}
+
+type CalendarSettings struct {
+	WorkingDays []int `json:"working_days"`
+}
+
+func NewCalendarSettings(s *domain.CalendarSettings) CalendarSettings {
+	return CalendarSettings{WorkingDays: s.WorkingDays}
+}
+
+type Holiday struct {
+	ID     int64  `json:"id"`
+	SiteID int64  `json:"site_id"`
+	Date   string `json:"date"`
+	Name   string `json:"name"`
+}
+
+func NewHoliday(h *domain.Holiday) Holiday {
+	return Holiday{ID: h.ID, SiteID: h.SiteID, Date: h.Date, Name: h.Name}
+}
+
+func NewHolidays(list []domain.Holiday) []Holiday {
+	return convert(list, NewHoliday)
+}
+
+type Shutdown struct {
+	ID        int64     `json:"id"`
+	SiteID    int64     `json:"site_id"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewShutdown(s *domain.Shutdown) Shutdown {
+	return Shutdown{ID: s.ID, SiteID: s.SiteID, StartsAt: s.StartsAt, EndsAt: s.EndsAt, Reason: s.Reason, CreatedAt: s.CreatedAt}
+}
+
+func NewShutdowns(list []domain.Shutdown) []Shutdown {
+	return convert(list, NewShutdown)
+}
+
+type WorkingWindow struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+type CalendarDay struct {
+	Date    string          `json:"date"`
+	Working bool            `json:"working"`
+	Holiday string          `json:"holiday,omitempty"`
+	Windows []WorkingWindow `json:"windows"`
+}
+
+func NewCalendarDays(list []domain.CalendarDay) []CalendarDay {
+	return convert(list, func(d *domain.CalendarDay) CalendarDay {
+		return CalendarDay{
+			Date:    d.Date,
+			Working: d.Working,
+			Holiday: d.Holiday,
+			Windows: convert(d.Windows, func(w *domain.WorkingWindow) WorkingWindow {
+				return WorkingWindow{Start: w.Start, End: w.End}
+			}),
+		}
+	})
+}
+
+type BacklogRow struct {
+	Week           string  `json:"week"`
+	Trade          string  `json:"trade"`
+	Criticality    string  `json:"criticality"`
+	Orders         int64   `json:"orders"`
+	EstimatedHours float64 `json:"estimated_hours"`
+}
+
+type CapacityRow struct {
+	Week           string  `json:"week"`
+	Trade          string  `json:"trade"`
+	Technicians    int64   `json:"technicians"`
+	AvailableHours float64 `json:"available_hours"`
+	DemandHours    float64 `json:"demand_hours"`
+	BalanceHours   float64 `json:"balance_hours"`
+}
+
+// BacklogReport é a carga de OS em aberto por semana e especialidade contra a capacidade dos técnicos.
+type BacklogReport struct {
+	From        time.Time     `json:"from"`
+	To          time.Time     `json:"to"`
+	Rows        []BacklogRow  `json:"rows"`
+	Capacity    []CapacityRow `json:"capacity"`
+	Unestimated int64         `json:"unestimated"`
+}
+
+func NewBacklogReport(r *domain.BacklogReport) BacklogReport {
+	return BacklogReport{
+		From: r.From,
+		To:   r.To,
+		Rows: convert(r.Rows, func(b *domain.BacklogRow) BacklogRow {
+			return BacklogRow{
+				Week:           b.Week,
+				Trade:          b.Trade,
+				Criticality:    string(b.Criticality),
+				Orders:         b.Orders,
+				EstimatedHours: b.EstimatedHours,
+			}
+		}),
+		Capacity: convert(r.Capacity, func(c *domain.CapacityRow) CapacityRow {
+			return CapacityRow{
+				Week:           c.Week,
+				Trade:          c.Trade,
+				Technicians:    c.Technicians,
+				AvailableHours: c.AvailableHours,
+				DemandHours:    c.DemandHours,
+				BalanceHours:   c.BalanceHours,
+			}
+		}),
+		Unestimated: r.Unestimated,
+	}
+}
+
+type Assignment struct {
+	WorkOrderID    int64     `json:"work_order_id"`
+	AssetID        int64     `json:"asset_id"`
+	Title          string    `json:"title"`
+	Trade          string    `json:"trade,omitempty"`
+	Priority       string    `json:"priority,omitempty"`
+	TechnicianID   int64     `json:"technician_id"`
+	TechnicianName string    `json:"technician_name"`
+	Start          time.Time `json:"start"`
+	End            time.Time `json:"end"`
+	EstimatedHours float64   `json:"estimated_hours"`
+	LateForSLA     bool      `json:"late_for_sla"`
+}
+
+type UnassignedOrder struct {
+	WorkOrderID int64  `json:"work_order_id"`
+	Trade       string `json:"trade,omitempty"`
+	Reason      string `json:"reason"`
+}
+
+// ScheduleProposal é a sugestão de alocação das OS em aberto nos turnos dos técnicos.
+type ScheduleProposal struct {
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	Assignments []Assignment      `json:"assignments"`
+	Unassigned  []UnassignedOrder `json:"unassigned"`
+}
+
+func NewScheduleProposal(p *domain.ScheduleProposal) ScheduleProposal {
+	return ScheduleProposal{
+		From: p.From,
+		To:   p.To,
+		Assignments: convert(p.Assignments, func(a *domain.Assignment) Assignment {
+			return Assignment{
+				WorkOrderID:    a.WorkOrderID,
+				AssetID:        a.AssetID,
+				Title:          a.Title,
+				Trade:          a.Trade,
+				Priority:       string(a.Priority),
+				TechnicianID:   a.TechnicianID,
+				TechnicianName: a.TechnicianName,
+				Start:          a.Start,
+				End:            a.End,
+				EstimatedHours: a.EstimatedHours,
+				LateForSLA:     a.LateForSLA,
+			}
+		}),
+		Unassigned: convert(p.Unassigned, func(u *domain.UnassignedOrder) UnassignedOrder {
+			return UnassignedOrder{WorkOrderID: u.WorkOrderID, Trade: u.Trade, Reason: string(u.Reason)}
+		}),
+	}
+}
@@ -0,0 +1,179 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// Relatórios: paradas por mês, OEE, Pareto de falhas, SLA e comparativo entre sites.
+
+type DowntimeReportRow struct {
+	Month           string `json:"month"`
+	AssetID         int64  `json:"asset_id"`
+	AssetName       string `json:"asset_name"`
+	Location        string `json:"location,omitempty"`
+	Breakdowns      int64  `json:"breakdowns"`
+	DowntimeMinutes int64  `json:"downtime_minutes"`
+	PlannedMinutes  int64  `json:"planned_minutes"`
+}
+
+func NewDowntimeReport(list []domain.DowntimeReportRow) []DowntimeReportRow {
+	return convert(list, func(r *domain.DowntimeReportRow) DowntimeReportRow {
+		return DowntimeReportRow{
+			Month:           r.Month,
+			AssetID:         r.AssetID,
+			AssetName:       r.AssetName,
+			Location:        r.Location,
+			Breakdowns:      r.Breakdowns,
+			DowntimeMinutes: r.DowntimeMinutes,
+			PlannedMinutes:  r.PlannedMinutes,
+		}
+	})
+}
+
+type OEERow struct {
+	Period          string    `json:"period"`
+	PeriodStart     time.Time `json:"period_start"`
+	Shift           string    `json:"shift,omitempty"`
+	AssetID         int64     `json:"asset_id,omitempty"`
+	AssetName       string    `json:"asset_name,omitempty"`
+	Location        string    `json:"location"`
+	PlannedMinutes  float64   `json:"planned_minutes"`
+	DowntimeMinutes float64   `json:"downtime_minutes"`
+	RunMinutes      float64   `json:"run_minutes"`
+	TotalCount      int64     `json:"total_count"`
+	GoodCount       int64     `json:"good_count"`
+	Availability    *float64  `json:"availability"`
+	Performance     *float64  `json:"performance"`
+	Quality         *float64  `json:"quality"`
+	OEE             *float64  `json:"oee"`
+}
+
+func newOEERow(r *domain.OEERow) OEERow {
+	return OEERow{
+		Period:          r.Period,
+		PeriodStart:     r.PeriodStart,
+		Shift:           r.Shift,
+		AssetID:         r.AssetID,
+		AssetName:       r.AssetName,
+		Location:        r.Location,
+		PlannedMinutes:  r.PlannedMinutes,
+		DowntimeMinutes: r.DowntimeMinutes,
+		RunMinutes:      r.RunMinutes,
+		TotalCount:      r.TotalCount,
+		GoodCount:       r.GoodCount,
+		Availability:    r.Availability,
+		Performance:     r.Performance,
+		Quality:         r.Quality,
+		OEE:             r.OEE,
+	}
+}
+
+// OEEReport traz o OEE por ativo e consolidado por linha.
+type OEEReport struct {
+	Granularity string    `json:"granularity"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	Assets      []OEERow  `json:"assets"`
+	Locations   []OEERow  `json:"locations"`
+}
+
+func NewOEEReport(r *domain.OEEReport) OEEReport {
+	return OEEReport{
+		Granularity: string(r.Granularity),
+		From:        r.From,
+		To:          r.To,
+		Assets:      convert(r.Assets, newOEERow),
+		Locations:   convert(r.Locations, newOEERow),
+	}
+}
+
+type ParetoRow struct {
+	FailureModeID   int64   `json:"failure_mode_id"`
+	Code            string  `json:"code"`
+	Name            string  `json:"name"`
+	Count           int64   `json:"count"`
+	DowntimeMinutes int64   `json:"downtime_minutes"`
+	Share           float64 `json:"share"`
+	CumulativeShare float64 `json:"cumulative_share"`
+}
+
+// ParetoReport é o ranking dos modos de falha; Unclassified conta as OS sem modo.
+type ParetoReport struct {
+	SortBy       string      `json:"sort_by"`
+	Rows         []ParetoRow `json:"rows"`
+	Unclassified int64       `json:"unclassified"`
+}
+
+func NewParetoReport(r *domain.ParetoReport) ParetoReport {
+	return ParetoReport{
+		SortBy: string(r.SortBy),
+		Rows: convert(r.Rows, func(p *domain.ParetoRow) ParetoRow {
+			return ParetoRow{
+				FailureModeID:   p.FailureModeID,
+				Code:            p.Code,
+				Name:            p.Name,
+				Count:           p.Count,
+				DowntimeMinutes: p.DowntimeMinutes,
+				Share:           p.Share,
+				CumulativeShare: p.CumulativeShare,
+			}
+		}),
+		Unclassified: r.Unclassified,
+	}
+}
+
+type SLAReportRow struct {
+	Month                string   `json:"month"`
+	Orders               int64    `json:"orders"`
+	ResponseEvaluated    int64    `json:"response_evaluated"`
+	ResponseMet          int64    `json:"response_met"`
+	ResponseCompliance   *float64 `json:"response_compliance"`
+	ResolutionEvaluated  int64    `json:"resolution_evaluated"`
+	ResolutionMet        int64    `json:"resolution_met"`
+	ResolutionCompliance *float64 `json:"resolution_compliance"`
+}
+
+func NewSLAReport(list []domain.SLAReportRow) []SLAReportRow {
+	return convert(list, func(r *domain.SLAReportRow) SLAReportRow {
+		return SLAReportRow{
+			Month:                r.Month,
+			Orders:               r.Orders,
+			ResponseEvaluated:    r.ResponseEvaluated,
+			ResponseMet:          r.ResponseMet,
+			ResponseCompliance:   r.ResponseCompliance,
+			ResolutionEvaluated:  r.ResolutionEvaluated,
+			ResolutionMet:        r.ResolutionMet,
+			ResolutionCompliance: r.ResolutionCompliance,
+		}
+	})
+}
+
+type SiteReportRow struct {
+	SiteID               int64    `json:"site_id"`
+	Code                 string   `json:"code"`
+	Name                 string   `json:"name"`
+	Breakdowns           int64    `json:"breakdowns"`
+	DowntimeMinutes      int64    `json:"downtime_minutes"`
+	PlannedMinutes       int64    `json:"planned_minutes"`
+	SLAOrders            int64    `json:"sla_orders"`
+	ResponseCompliance   *float64 `json:"response_compliance"`
+	ResolutionCompliance *float64 `json:"resolution_compliance"`
+}
+
+func NewSiteReport(list []domain.SiteReportRow) []SiteReportRow {
+	return convert(list, func(r *domain.SiteReportRow) SiteReportRow {
+		return SiteReportRow{
+			SiteID:               r.SiteID,
+			Code:                 r.Code,
+			Name:                 r.Name,
+			Breakdowns:           r.Breakdowns,
+			DowntimeMinutes:      r.DowntimeMinutes,
+			PlannedMinutes:       r.PlannedMinutes,
+			SLAOrders:            r.SLAOrders,
+			ResponseCompliance:   r.ResponseCompliance,
+			ResolutionCompliance: r.ResolutionCompliance,
+		}
+	})
+}
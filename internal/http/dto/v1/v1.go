@@ -0,0 +1,298 @@
+// Package v1 define o JSON da API /v1. Os handlers convertem os tipos de domínio
+// para estes DTOs, então mudar um struct de domain não muda o contrato publicado:
+// campo novo só aparece quando entra aqui, e uma quebra de formato vira um pacote
+// v2 servido em /v2 enquanto o /v1 continua igual.
+package v1
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type Asset struct {
+	ID               int64          `json:"id"`
+	SiteID           int64          `json:"site_id"`
+	Name             string         `json:"name"`
+	Location         string         `json:"location,omitempty"`
+	Criticality      string         `json:"criticality,omitempty"`
+	ExternalCode     string         `json:"external_code,omitempty"`
+	Manufacturer     string         `json:"manufacturer,omitempty"`
+	Model            string         `json:"model,omitempty"`
+	SerialNumber     string         `json:"serial_number,omitempty"`
+	InstalledOn      string         `json:"installed_on,omitempty"`
+	WarrantyUntil    string         `json:"warranty_until,omitempty"`
+	Class            string         `json:"class,omitempty"`
+	Attributes       map[string]any `json:"attributes,omitempty"`
+	IdealRatePerHour *float64       `json:"ideal_rate_per_hour,omitempty"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+}
+
+func NewAsset(a *domain.Asset) Asset {
+	return Asset{
+		ID:               a.ID,
+		SiteID:           a.SiteID,
+		Name:             a.Name,
+		Location:         a.Location,
+		Criticality:      string(a.Criticality),
+		ExternalCode:     a.ExternalCode,
+		Manufacturer:     a.Manufacturer,
+		Model:            a.Model,
+		SerialNumber:     a.SerialNumber,
+		InstalledOn:      a.InstalledOn,
+		WarrantyUntil:    a.WarrantyUntil,
+		Class:            a.Class,
+		Attributes:       a.Attributes,
+		IdealRatePerHour: a.IdealRatePerHour,
+		CreatedAt:        a.CreatedAt,
+		UpdatedAt:        a.UpdatedAt,
+	}
+}
+
+func NewAssets(list []domain.Asset) []Asset {
+	return convert(list, NewAsset)
+}
+
+type JobPlanPart struct {
+	SparePartID int64   `json:"spare_part_id"`
+	Quantity    float64 `json:"quantity"`
+}
+
+func newJobPlanParts(list []domain.JobPlanPart) []JobPlanPart {
+	return convert(list, func(p *domain.JobPlanPart) JobPlanPart {
+		return JobPlanPart{SparePartID: p.SparePartID, Quantity: p.Quantity}
+	})
+}
+
+type WorkOrder struct {
+	ID               int64         `json:"id"`
+	SiteID           int64         `json:"site_id"`
+	AssetID          int64         `json:"asset_id"`
+	Type             string        `json:"type"`
+	Status           string        `json:"status"`
+	Title            string        `json:"title"`
+	Description      string        `json:"description"`
+	BreakdownAt      *time.Time    `json:"breakdown_at,omitempty"`
+	ClosedAt         *time.Time    `json:"closed_at,omitempty"`
+	DowntimeMinutes  *int64        `json:"downtime_minutes,omitempty"`
+	Cause            string        `json:"cause,omitempty"`
+	Solution         string        `json:"solution,omitempty"`
+	FailureModeID    *int64        `json:"failure_mode_id,omitempty"`
+	FailureCauseID   *int64        `json:"failure_cause_id,omitempty"`
+	FailureActionID  *int64        `json:"failure_action_id,omitempty"`
+	Priority         string        `json:"priority,omitempty"`
+	ResponseDueAt    *time.Time    `json:"response_due_at,omitempty"`
+	ResolutionDueAt  *time.Time    `json:"resolution_due_at,omitempty"`
+	RespondedAt      *time.Time    `json:"responded_at,omitempty"`
+	SLABreachedAt    *time.Time    `json:"sla_breached_at,omitempty"`
+	Trade            string        `json:"trade,omitempty"`
+	EstimatedMinutes *int64        `json:"estimated_minutes,omitempty"`
+	JobPlanID        *int64        `json:"job_plan_id,omitempty"`
+	AssigneeID       *int64        `json:"assignee_id,omitempty"`
+	RequiredParts    []JobPlanPart `json:"required_parts,omitempty"`
+	PlanID           *int64        `json:"plan_id,omitempty"`
+	ScheduledFor     *time.Time    `json:"scheduled_for,omitempty"`
+	RequestID        *int64        `json:"request_id,omitempty"`
+	CreatedAt        time.Time     `json:"created_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+}
+
+func NewWorkOrder(o *domain.WorkOrder) WorkOrder {
+	return WorkOrder{
+		ID:               o.ID,
+		SiteID:           o.SiteID,
+		AssetID:          o.AssetID,
+		Type:             string(o.Type),
+		Status:           string(o.Status),
+		Title:            o.Title,
+		Description:      o.Description,
+		BreakdownAt:      o.BreakdownAt,
+		ClosedAt:         o.ClosedAt,
+		DowntimeMinutes:  o.DowntimeMinutes,
+		Cause:            o.Cause,
+		Solution:         o.Solution,
+		FailureModeID:    o.FailureModeID,
+		FailureCauseID:   o.FailureCauseID,
+		FailureActionID:  o.FailureActionID,
+		Priority:         string(o.Priority),
+		ResponseDueAt:    o.ResponseDueAt,
+		ResolutionDueAt:  o.ResolutionDueAt,
+		RespondedAt:      o.RespondedAt,
+		SLABreachedAt:    o.SLABreachedAt,
+		Trade:            o.Trade,
+		EstimatedMinutes: o.EstimatedMinutes,
+		JobPlanID:        o.JobPlanID,
+		AssigneeID:       o.AssigneeID,
+		RequiredParts:    newJobPlanParts(o.RequiredParts),
+		PlanID:           o.PlanID,
+		ScheduledFor:     o.ScheduledFor,
+		RequestID:        o.RequestID,
+		CreatedAt:        o.CreatedAt,
+		UpdatedAt:        o.UpdatedAt,
+	}
+}
+
+func NewWorkOrders(list []domain.WorkOrder) []WorkOrder {
+	return convert(list, NewWorkOrder)
+}
+
+type MeasurementSpec struct {
+	Unit     string   `json:"unit"`
+	Min      *float64 `json:"min,omitempty"`
+	Max      *float64 `json:"max,omitempty"`
+	FollowUp bool     `json:"follow_up"`
+}
+
+func newMeasurementSpec(m *domain.MeasurementSpec) *MeasurementSpec {
+	if m == nil {
+		return nil
+	}
+	return &MeasurementSpec{Unit: m.Unit, Min: m.Min, Max: m.Max, FollowUp: m.FollowUp}
+}
+
+type ChecklistItem struct {
+	Step           int              `json:"step"`
+	Description    string           `json:"description"`
+	Measurement    *MeasurementSpec `json:"measurement,omitempty"`
+	Done           bool             `json:"done"`
+	Value          *float64         `json:"value,omitempty"`
+	Note           string           `json:"note,omitempty"`
+	OutOfTolerance bool             `json:"out_of_tolerance"`
+	FollowUpID     *int64           `json:"follow_up_id,omitempty"`
+	CompletedAt    *time.Time       `json:"completed_at,omitempty"`
+}
+
+func NewChecklistItem(c *domain.ChecklistItem) ChecklistItem {
+	return ChecklistItem{
+		Step:           c.Step,
+		Description:    c.Description,
+		Measurement:    newMeasurementSpec(c.Measurement),
+		Done:           c.Done,
+		Value:          c.Value,
+		Note:           c.Note,
+		OutOfTolerance: c.OutOfTolerance,
+		FollowUpID:     c.FollowUpID,
+		CompletedAt:    c.CompletedAt,
+	}
+}
+
+func NewChecklist(list []domain.ChecklistItem) []ChecklistItem {
+	return convert(list, NewChecklistItem)
+}
+
+// ScanResult é a leitura da etiqueta.
+type ScanResult struct {
+	Tag              string           `json:"tag"`
+	Asset            Asset            `json:"asset"`
+	OpenWorkOrders   []WorkOrder      `json:"open_work_orders"`
+	Plans            []ScanPlan       `json:"plans"`
+	BreakdownRequest BreakdownRequest `json:"breakdown_request"`
+}
+
+// ScanPlan é um plano do ativo com o próximo vencimento.
+type ScanPlan struct {
+	MaintenancePlan
+	NextDue *time.Time `json:"next_due,omitempty"`
+	Overdue bool       `json:"overdue"`
+}
+
+// BreakdownRequest é o corpo sugerido para abrir a corretiva pelo app.
+type BreakdownRequest struct {
+	AssetID     int64     `json:"asset_id"`
+	Type        string    `json:"type"`
+	Title       string    `json:"title"`
+	BreakdownAt time.Time `json:"breakdown_at"`
+}
+
+func NewScanResult(r *domain.ScanResult) ScanResult {
+	return ScanResult{
+		Tag:            r.Tag,
+		Asset:          NewAsset(&r.Asset),
+		OpenWorkOrders: NewWorkOrders(r.OpenWorkOrders),
+		Plans: convert(r.Plans, func(p *domain.ScanPlan) ScanPlan {
+			return ScanPlan{MaintenancePlan: NewMaintenancePlan(&p.MaintenancePlan), NextDue: p.NextDue, Overdue: p.Overdue}
+		}),
+		BreakdownRequest: BreakdownRequest{
+			AssetID:     r.BreakdownRequest.AssetID,
+			Type:        string(r.BreakdownRequest.Type),
+			Title:       r.BreakdownRequest.Title,
+			BreakdownAt: r.BreakdownRequest.BreakdownAt,
+		},
+	}
+}
+
+// SyncChange é um registro alterado no delta da sincronização. ID é o ativo ou
+// a OS (nos passos de checklist, a OS do passo); Data traz o registro completo,
+// exceto nas remoções.
+type SyncChange struct {
+	Entity   string `json:"entity"`
+	ID       int64  `json:"id"`
+	Step     int    `json:"step,omitempty"`
+	Revision int64  `json:"revision"`
+	Deleted  bool   `json:"deleted,omitempty"`
+	Data     any    `json:"data,omitempty"`
+}
+
+func NewSyncChange(ch *domain.SyncChange) SyncChange {
+	out := SyncChange{Entity: string(ch.Entity), Revision: ch.Revision, Deleted: ch.Deleted}
+	switch {
+	case ch.Asset != nil:
+		out.ID = ch.Asset.ID
+		if !ch.Deleted {
+			out.Data = NewAsset(ch.Asset)
+		}
+	case ch.WorkOrder != nil:
+		out.ID = ch.WorkOrder.ID
+		if !ch.Deleted {
+			out.Data = NewWorkOrder(ch.WorkOrder)
+		}
+	case ch.ChecklistItem != nil:
+		out.ID, out.Step = ch.WorkOrderID, ch.ChecklistItem.Step
+		if !ch.Deleted {
+			out.Data = NewChecklistItem(ch.ChecklistItem)
+		}
+	}
+	return out
+}
+
+// SyncPage é uma página do delta; Next é o token a enviar como since na próxima chamada.
+type SyncPage struct {
+	Changes []SyncChange `json:"changes"`
+	Next    string       `json:"next"`
+	HasMore bool         `json:"has_more"`
+}
+
+func NewSyncPage(p *domain.SyncPage) SyncPage {
+	return SyncPage{
+		Changes: convert(p.Changes, NewSyncChange),
+		Next:    strconv.FormatInt(p.Next, 10),
+		HasMore: p.HasMore,
+	}
+}
+
+// SyncResult é o resultado de uma alteração do push; Current é a versão do
+// servidor (gravada ou vencedora do conflito) e Error o motivo da recusa.
+type SyncResult struct {
+	ClientID string      `json:"client_id"`
+	Status   string      `json:"status"`
+	ID       int64       `json:"id,omitempty"`
+	Current  *SyncChange `json:"current,omitempty"`
+	Error    *SyncError  `json:"error,omitempty"`
+}
+
+// SyncError é o erro de uma alteração recusada (mesmo formato das respostas de erro).
+type SyncError struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// convert mantém lista vazia como [] no JSON.
+func convert[D, T any](list []D, fn func(*D) T) []T {
+	out := make([]T, 0, len(list))
+	for i := range list {
+		out = append(out, fn(&list[i]))
+	}
+	return out
+}
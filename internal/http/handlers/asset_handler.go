@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/export"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
 )
@@ -15,17 +18,44 @@ type AssetHandler struct {
 
 func NewAssetHandler(s *service.AssetService) *AssetHandler { return &AssetHandler{service: s} }
 
-func (h *AssetHandler) RegisterRoutes(r *gin.Engine) {
+func (h *AssetHandler) RegisterRoutes(r gin.IRouter) {
 	g := r.Group("/assets")
 	g.POST("", h.create)
 	g.GET("", h.list)
+	g.PATCH("/:id", h.update)
 }
 
 // DTO de entrada com validação (não “suje” o domínio com tags binding)
 type createAssetRequest struct {
-	Name        string             `json:"name" binding:"required,min=2"`
-	Location    string             `json:"location"`
-	Criticality domain.Criticality `json:"criticality" binding:"omitempty,oneof=A B C"`
+	Name             string             `json:"name" binding:"required,min=2"`
+	Location         string             `json:"location"`
+	Criticality      domain.Criticality `json:"criticality" binding:"omitempty,oneof=A B C"`
+	ExternalCode     string             `json:"external_code" binding:"omitempty,max=64"`
+	IdealRatePerHour *float64           `json:"ideal_rate_per_hour" binding:"omitempty,gt=0"` // peças/hora (OEE)
+	Manufacturer     string             `json:"manufacturer" binding:"max=128"`
+	Model            string             `json:"model" binding:"max=128"`
+	SerialNumber     string             `json:"serial_number" binding:"max=128"`
+	InstalledOn      string             `json:"installed_on" binding:"omitempty,datetime=2006-01-02"`
+	WarrantyUntil    string             `json:"warranty_until" binding:"omitempty,datetime=2006-01-02"`
+	Class            string             `json:"class" binding:"max=63"`
+	Attributes       map[string]any     `json:"attributes"`
+}
+
+// updateAssetRequest: campos ausentes ficam como estão; ideal_rate_per_hour=0 remove a cadência.
+type updateAssetRequest struct {
+	Name             *string             `json:"name" binding:"omitempty,min=2"`
+	Location         *string             `json:"location"`
+	Criticality      *domain.Criticality `json:"criticality" binding:"omitempty,oneof=A B C"`
+	ExternalCode     *string             `json:"external_code" binding:"omitempty,max=64"`
+	IdealRatePerHour *float64            `json:"ideal_rate_per_hour" binding:"omitempty,gte=0"`
+	Manufacturer     *string             `json:"manufacturer" binding:"omitempty,max=128"`
+	Model            *string             `json:"model" binding:"omitempty,max=128"`
+	SerialNumber     *string             `json:"serial_number" binding:"omitempty,max=128"`
+	InstalledOn      *string             `json:"installed_on" binding:"omitempty,datetime=2006-01-02"` // "" remove
+	WarrantyUntil    *string             `json:"warranty_until" binding:"omitempty,datetime=2006-01-02"`
+	Class            *string             `json:"class" binding:"omitempty,max=63"`
+	// attributes é mesclado aos atuais; null remove o atributo.
+	Attributes map[string]any `json:"attributes"`
 }
 
 func (h *AssetHandler) create(c *gin.Context) {
@@ -36,23 +66,100 @@ func (h *AssetHandler) create(c *gin.Context) {
 	}
 
 	a := domain.Asset{
-		Name:        req.Name,
-		Location:    req.Location,
-		Criticality: req.Criticality,
+		Name:             req.Name,
+		Location:         req.Location,
+		Criticality:      req.Criticality,
+		ExternalCode:     req.ExternalCode,
+		IdealRatePerHour: req.IdealRatePerHour,
+		Manufacturer:     req.Manufacturer,
+		Model:            req.Model,
+		SerialNumber:     req.SerialNumber,
+		InstalledOn:      req.InstalledOn,
+		WarrantyUntil:    req.WarrantyUntil,
+		Class:            req.Class,
+		Attributes:       req.Attributes,
+	}
+
+	if err := h.service.Create(c.Request.Context(), &a); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewAsset(&a))
+}
+
+func (h *AssetHandler) update(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req updateAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
 	}
 
-	if err := h.service.Create(&a); err != nil {
+	a, err := h.service.Update(c.Request.Context(), id, service.AssetPatch{
+		Name:             req.Name,
+		Location:         req.Location,
+		Criticality:      req.Criticality,
+		ExternalCode:     req.ExternalCode,
+		IdealRatePerHour: req.IdealRatePerHour,
+		Manufacturer:     req.Manufacturer,
+		Model:            req.Model,
+		SerialNumber:     req.SerialNumber,
+		InstalledOn:      req.InstalledOn,
+		WarrantyUntil:    req.WarrantyUntil,
+		Class:            req.Class,
+		Attributes:       req.Attributes,
+	})
+	if err != nil {
 		response.HandleError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, a)
+	c.JSON(http.StatusOK, v1.NewAsset(a))
 }
 
 func (h *AssetHandler) list(c *gin.Context) {
-	assets, err := h.service.List()
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
+	filter := domain.AssetFilter{
+		Location:    c.Query("location"),
+		Criticality: domain.Criticality(c.Query("criticality")),
+		Class:       strings.ToLower(c.Query("class")),
+		Attributes:  attributeQuery(c),
+	}
+
+	if format != export.FormatJSON {
+		writeExport(c, format, "ativos", "Ativos", export.AssetHeader, func(write func([]string) error) error {
+			return h.service.Stream(c.Request.Context(), filter, func(a *domain.Asset) error {
+				return write(export.AssetRow(a))
+			})
+		})
+		return
+	}
+
+	assets, err := h.service.List(c.Request.Context(), filter)
 	if err != nil {
 		response.HandleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, assets)
+	c.JSON(http.StatusOK, v1.NewAssets(assets))
+}
+
+// attributeQuery lê os filtros de atributos customizados (?attr.voltage=380).
+func attributeQuery(c *gin.Context) map[string]string {
+	var attrs map[string]string
+	for key, values := range c.Request.URL.Query() {
+		name, ok := strings.CutPrefix(key, "attr.")
+		if !ok || name == "" || len(values) == 0 {
+			continue
+		}
+		if attrs == nil {
+			attrs = map[string]string{}
+		}
+		attrs[strings.ToLower(name)] = values[0]
+	}
+	return attrs
 }
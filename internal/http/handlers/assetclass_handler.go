@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type AssetClassHandler struct {
+	service *service.AssetClassService
+}
+
+func NewAssetClassHandler(s *service.AssetClassService) *AssetClassHandler {
+	return &AssetClassHandler{service: s}
+}
+
+func (h *AssetClassHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/asset-classes")
+	g.POST("", h.create)
+	g.GET("", h.list)
+	g.GET("/:code", h.get)
+	g.PUT("/:code", h.replace)
+}
+
+type attributeDefRequest struct {
+	Key      string   `json:"key" binding:"required,max=63"`
+	Label    string   `json:"label" binding:"max=128"`
+	Type     string   `json:"type" binding:"required,oneof=text number boolean date enum"`
+	Unit     string   `json:"unit" binding:"max=32"`
+	Required bool     `json:"required"`
+	Options  []string `json:"options" binding:"dive,required,max=128"`
+	Min      *float64 `json:"min"`
+	Max      *float64 `json:"max"`
+}
+
+// assetClassRequest: no PUT o código vem da URL e o do corpo é ignorado.
+type assetClassRequest struct {
+	Code       string                `json:"code" binding:"max=63"`
+	Name       string                `json:"name" binding:"required,max=128"`
+	Attributes []attributeDefRequest `json:"attributes" binding:"dive"`
+}
+
+func (req *assetClassRequest) assetClass() domain.AssetClass {
+	c := domain.AssetClass{
+		Code:       req.Code,
+		Name:       req.Name,
+		Attributes: make([]domain.AttributeDef, len(req.Attributes)),
+	}
+	for i, a := range req.Attributes {
+		c.Attributes[i] = domain.AttributeDef{
+			Key:      a.Key,
+			Label:    a.Label,
+			Type:     domain.AttributeType(a.Type),
+			Unit:     a.Unit,
+			Required: a.Required,
+			Options:  a.Options,
+			Min:      a.Min,
+			Max:      a.Max,
+		}
+	}
+	return c
+}
+
+func (h *AssetClassHandler) create(c *gin.Context) {
+	var req assetClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	class := req.assetClass()
+	if err := h.service.Create(c.Request.Context(), &class); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewAssetClass(&class))
+}
+
+func (h *AssetClassHandler) replace(c *gin.Context) {
+	var req assetClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	class := req.assetClass()
+	class.Code = c.Param("code")
+	if err := h.service.Replace(c.Request.Context(), &class); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewAssetClass(&class))
+}
+
+func (h *AssetClassHandler) get(c *gin.Context) {
+	class, err := h.service.Get(c.Request.Context(), strings.ToLower(c.Param("code")))
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewAssetClass(class))
+}
+
+func (h *AssetClassHandler) list(c *gin.Context) {
+	list, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewAssetClasses(list))
+}
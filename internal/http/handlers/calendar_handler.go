@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ical"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+// maxCalendarImportSize limita o upload de arquivos .ics de feriados.
+const maxCalendarImportSize = 1 << 20
+
+type CalendarHandler struct {
+	service   *service.CalendarService
+	scheduler *service.PreventiveScheduler
+}
+
+func NewCalendarHandler(s *service.CalendarService, scheduler *service.PreventiveScheduler) *CalendarHandler {
+	return &CalendarHandler{service: s, scheduler: scheduler}
+}
+
+func (h *CalendarHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/calendar")
+	g.GET("/settings", h.settings)
+	g.PUT("/settings", h.saveSettings)
+	g.GET("/holidays", h.listHolidays)
+	g.POST("/holidays", h.addHoliday)
+	g.POST("/holidays/import", h.importHolidays)
+	g.DELETE("/holidays/:id", h.deleteHoliday)
+	g.GET("/shutdowns", h.listShutdowns)
+	g.POST("/shutdowns", h.addShutdown)
+	g.DELETE("/shutdowns/:id", h.deleteShutdown)
+	g.GET("/days", h.days)
+	g.GET("/preventives", h.preventives)
+	g.GET("/assets/:id/preventives.ics", h.preventivesFeed)
+}
+
+type calendarSettingsRequest struct {
+	WorkingDays []int `json:"working_days" binding:"required,min=1,dive,min=0,max=6"`
+}
+
+func (h *CalendarHandler) settings(c *gin.Context) {
+	s, err := h.service.Settings(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewCalendarSettings(s))
+}
+
+func (h *CalendarHandler) saveSettings(c *gin.Context) {
+	var req calendarSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	s := domain.CalendarSettings{WorkingDays: req.WorkingDays}
+	if err := h.service.SaveSettings(c.Request.Context(), &s); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewCalendarSettings(&s))
+}
+
+type holidayRequest struct {
+	Date string `json:"date" binding:"required"` // AAAA-MM-DD
+	Name string `json:"name" binding:"required,max=128"`
+}
+
+func (h *CalendarHandler) listHolidays(c *gin.Context) {
+	list, err := h.service.ListHolidays(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewHolidays(list))
+}
+
+func (h *CalendarHandler) addHoliday(c *gin.Context) {
+	var req holidayRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	holiday := domain.Holiday{Date: req.Date, Name: req.Name}
+	if err := h.service.AddHoliday(c.Request.Context(), &holiday); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewHoliday(&holiday))
+}
+
+// importHolidays aceita o .ics em multipart (campo "file") ou direto no corpo.
+func (h *CalendarHandler) importHolidays(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxCalendarImportSize)
+
+	body, _, _, err := importFile(c)
+	if err != nil {
+		response.HandleError(c, fmt.Errorf("%w: %w", domain.ErrInvalidInput, err))
+		return
+	}
+	defer body.Close()
+
+	holidays, err := h.service.ImportHolidays(c.Request.Context(), body)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"imported": len(holidays), "holidays": v1.NewHolidays(holidays)})
+}
+
+func (h *CalendarHandler) deleteHoliday(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteHoliday(c.Request.Context(), id); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+type shutdownRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	Reason   string    `json:"reason" binding:"required,max=256"`
+}
+
+func (h *CalendarHandler) listShutdowns(c *gin.Context) {
+	list, err := h.service.ListShutdowns(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewShutdowns(list))
+}
+
+func (h *CalendarHandler) addShutdown(c *gin.Context) {
+	var req shutdownRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	s := domain.Shutdown{StartsAt: req.StartsAt, EndsAt: req.EndsAt, Reason: req.Reason}
+	if err := h.service.AddShutdown(c.Request.Context(), &s); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewShutdown(&s))
+}
+
+func (h *CalendarHandler) deleteShutdown(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteShutdown(c.Request.Context(), id); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// days: tempo útil dia a dia. Sem from/to, cobre os próximos 30 dias.
+func (h *CalendarHandler) days(c *gin.Context) {
+	from, to, err := planningPeriod(c, 30)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	days, err := h.service.Days(c.Request.Context(), from, to)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewCalendarDays(days))
+}
+
+// preventives: agenda de preventivas do ativo (asset_id obrigatório). Sem from/to, próximos 90 dias.
+func (h *CalendarHandler) preventives(c *gin.Context) {
+	assetID, ok := assetIDQuery(c)
+	if !ok {
+		return
+	}
+	if assetID == 0 {
+		response.HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+	from, to, err := planningPeriod(c, 90)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	list, err := h.scheduler.Forecast(c.Request.Context(), assetID, from, to)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewPreventiveOccurrences(list))
+}
+
+// preventivesFeed publica a agenda do ativo em iCalendar para assinatura em
+// clientes de calendário: um ano a partir de um mês atrás.
+func (h *CalendarHandler) preventivesFeed(c *gin.Context) {
+	assetID, ok := idParam(c)
+	if !ok {
+		return
+	}
+	now := time.Now().In(plant.Location())
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, -1, 0)
+	list, err := h.scheduler.Forecast(c.Request.Context(), assetID, from, from.AddDate(1, 0, 0))
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	events := make([]ical.Event, 0, len(list))
+	for _, o := range list {
+		e := ical.Event{
+			UID:     fmt.Sprintf("plan-%d-%s@factory-maintenance", o.PlanID, o.ScheduledFor.UTC().Format("20060102T150405Z")),
+			Summary: o.Title,
+			Start:   o.ScheduledFor,
+			End:     o.ScheduledFor.Add(time.Hour),
+		}
+		if o.WorkOrderID != nil {
+			e.UID = fmt.Sprintf("work-order-%d@factory-maintenance", *o.WorkOrderID)
+			e.Description = fmt.Sprintf("OS #%d", *o.WorkOrderID)
+		} else {
+			e.Description = "Previsão do plano (OS ainda não gerada)"
+		}
+		events = append(events, e)
+	}
+
+	c.Header("Content-Type", "text/calendar; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="preventivas-ativo-%d.ics"`, assetID))
+	c.Status(http.StatusOK)
+	if err := ical.Write(c.Writer, fmt.Sprintf("Preventivas do ativo #%d", assetID), events, now); err != nil {
+		_ = c.Error(err)
+	}
+}
+
+// planningPeriod lê from/to; o padrão é de hoje até days dias à frente.
+func planningPeriod(c *gin.Context, days int) (time.Time, time.Time, error) {
+	now := time.Now().In(plant.Location())
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	to := from.AddDate(0, 0, days)
+
+	f, err := dateParam(c, "from")
+	if err != nil {
+		return from, to, err
+	}
+	t, err := dateParam(c, "to")
+	if err != nil {
+		return from, to, err
+	}
+	if f != nil {
+		from = *f
+	}
+	if t != nil {
+		to = *t
+	}
+	if !from.Before(to) {
+		return from, to, domain.ErrInvalidInput
+	}
+	return from, to, nil
+}
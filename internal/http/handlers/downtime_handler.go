@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type DowntimeHandler struct {
+	service *service.DowntimeService
+}
+
+func NewDowntimeHandler(s *service.DowntimeService) *DowntimeHandler {
+	return &DowntimeHandler{service: s}
+}
+
+func (h *DowntimeHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/assets/:id/downtime")
+	g.GET("", h.list)
+	g.POST("", h.record)
+	g.POST("/start", h.start)
+	g.POST("/stop", h.stop)
+}
+
+type startDowntimeRequest struct {
+	ReasonCode  string     `json:"reason_code" binding:"required,max=32"`
+	Planned     bool       `json:"planned"`
+	WorkOrderID *int64     `json:"work_order_id" binding:"omitempty,gt=0"`
+	StartedAt   *time.Time `json:"started_at"` // padrão: agora
+	Notes       string     `json:"notes" binding:"max=500"`
+}
+
+// recordDowntimeRequest lança uma parada já encerrada (apontamento retroativo).
+type recordDowntimeRequest struct {
+	ReasonCode  string    `json:"reason_code" binding:"required,max=32"`
+	Planned     bool      `json:"planned"`
+	WorkOrderID *int64    `json:"work_order_id" binding:"omitempty,gt=0"`
+	StartedAt   time.Time `json:"started_at" binding:"required"`
+	EndedAt     time.Time `json:"ended_at" binding:"required"`
+	Notes       string    `json:"notes" binding:"max=500"`
+}
+
+type stopDowntimeRequest struct {
+	EndedAt     *time.Time `json:"ended_at"` // padrão: agora
+	WorkOrderID *int64     `json:"work_order_id" binding:"omitempty,gt=0"`
+}
+
+func (h *DowntimeHandler) start(c *gin.Context) {
+	assetID, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req startDowntimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	ev := domain.DowntimeEvent{
+		AssetID:     assetID,
+		WorkOrderID: req.WorkOrderID,
+		ReasonCode:  req.ReasonCode,
+		Planned:     req.Planned,
+		Notes:       req.Notes,
+	}
+	if req.StartedAt != nil {
+		ev.StartedAt = *req.StartedAt
+	}
+	if err := h.service.Start(c.Request.Context(), &ev); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewDowntimeEvent(&ev))
+}
+
+func (h *DowntimeHandler) record(c *gin.Context) {
+	assetID, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req recordDowntimeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	ev := domain.DowntimeEvent{
+		AssetID:     assetID,
+		WorkOrderID: req.WorkOrderID,
+		StartedAt:   req.StartedAt,
+		EndedAt:     &req.EndedAt,
+		ReasonCode:  req.ReasonCode,
+		Planned:     req.Planned,
+		Notes:       req.Notes,
+	}
+	if err := h.service.Start(c.Request.Context(), &ev); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewDowntimeEvent(&ev))
+}
+
+func (h *DowntimeHandler) stop(c *gin.Context) {
+	assetID, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req stopDowntimeRequest
+	// Corpo vazio é válido: encerra agora, sem vincular OS.
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+
+	ev, err := h.service.Stop(c.Request.Context(), assetID, req.EndedAt, req.WorkOrderID)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewDowntimeEvent(ev))
+}
+
+func (h *DowntimeHandler) list(c *gin.Context) {
+	assetID, ok := idParam(c)
+	if !ok {
+		return
+	}
+	from, err := dateParam(c, "from")
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	to, err := dateParam(c, "to")
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	events, err := h.service.List(c.Request.Context(), assetID, from, to)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewDowntimeEvents(events))
+}
+
+// idParam lê o :id da rota; responde 400 quando não é um inteiro positivo.
+func idParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		response.HandleError(c, domain.ErrInvalidInput)
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/export"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+)
+
+// exportFormat negocia ?format= / Accept; em caso de formato inválido já responde 400.
+func exportFormat(c *gin.Context) (export.Format, bool) {
+	f, err := export.Negotiate(c.Query("format"), c.GetHeader("Accept"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidInput)
+		return "", false
+	}
+	return f, true
+}
+
+// writeExport envia o arquivo linha a linha. Depois que a primeira linha sai,
+// o status já foi enviado: erros no meio do stream só podem ser logados.
+func writeExport(c *gin.Context, f export.Format, name, title string, header []string,
+	stream func(row func([]string) error) error) {
+	c.Header("Content-Type", f.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, f.Filename(name)))
+	c.Status(http.StatusOK)
+
+	w, err := export.NewWriter(f, c.Writer, title)
+	if err == nil {
+		err = w.Header(header)
+	}
+	if err == nil {
+		err = stream(w.Row)
+	}
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+// dateParam lê datas de filtro: AAAA-MM-DD (meia-noite no fuso da planta) ou RFC3339.
+func dateParam(c *gin.Context, key string) (*time.Time, error) {
+	v := c.Query(key)
+	if v == "" {
+		return nil, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", v, plant.Location()); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+	return &t, nil
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type FailureCodeHandler struct {
+	service *service.FailureCodeService
+}
+
+func NewFailureCodeHandler(s *service.FailureCodeService) *FailureCodeHandler {
+	return &FailureCodeHandler{service: s}
+}
+
+func (h *FailureCodeHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/failure-codes")
+	g.POST("", h.create)
+	g.GET("", h.list)
+	g.GET("/tree", h.tree)
+	g.PATCH("/:id", h.update)
+}
+
+type createFailureCodeRequest struct {
+	Code     string                  `json:"code" binding:"required,max=32"`
+	Name     string                  `json:"name" binding:"required,max=128"`
+	Level    domain.FailureCodeLevel `json:"level" binding:"required,oneof=mode cause action"`
+	ParentID *int64                  `json:"parent_id" binding:"omitempty,gt=0"`
+}
+
+type updateFailureCodeRequest struct {
+	Name   *string `json:"name" binding:"omitempty,max=128"`
+	Active *bool   `json:"active"`
+}
+
+func (h *FailureCodeHandler) create(c *gin.Context) {
+	var req createFailureCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	fc := domain.FailureCode{Code: req.Code, Name: req.Name, Level: req.Level, ParentID: req.ParentID}
+	if err := h.service.Create(c.Request.Context(), &fc); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewFailureCode(&fc))
+}
+
+func (h *FailureCodeHandler) update(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req updateFailureCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	fc, err := h.service.Update(c.Request.Context(), id, service.FailureCodePatch{Name: req.Name, Active: req.Active})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewFailureCode(fc))
+}
+
+// list: catálogo plano; ?include_inactive=true traz também os códigos desativados.
+func (h *FailureCodeHandler) list(c *gin.Context) {
+	activeOnly, ok := activeOnlyParam(c)
+	if !ok {
+		return
+	}
+	codes, err := h.service.List(c.Request.Context(), activeOnly)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewFailureCodes(codes))
+}
+
+func (h *FailureCodeHandler) tree(c *gin.Context) {
+	activeOnly, ok := activeOnlyParam(c)
+	if !ok {
+		return
+	}
+	tree, err := h.service.Tree(c.Request.Context(), activeOnly)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewFailureCodeTree(tree))
+}
+
+func activeOnlyParam(c *gin.Context) (bool, bool) {
+	v := c.Query("include_inactive")
+	if v == "" {
+		return true, true
+	}
+	include, err := strconv.ParseBool(v)
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidInput)
+		return false, false
+	}
+	return !include, true
+}
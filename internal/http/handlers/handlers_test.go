@@ -2,20 +2,46 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"image/png"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/health"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/http/handlers"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/notify"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	"github.com/xuri/excelize/v2"
 )
 
+// asSite faz o papel do AuthMiddleware: a requisição chega com o principal de
+// um usuário do site.
+func asSite(site int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request = c.Request.WithContext(onSite(site))
+		c.Next()
+	}
+}
+
+func onSite(site int64) context.Context {
+	return tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 1, SiteID: site})
+}
+
 func setupRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
+	r.Use(asSite(1))
 	r.Use(gin.Recovery())
 
 	assetRepo := memory.NewAssetMemoryRepo()
@@ -240,3 +266,822 @@ func TestWorkOrders_Create_Validation422(t *testing.T) {
 		})
 	}
 }
+
+func TestReadyz_VerboseAndDrain(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+
+	live := health.NewRegistry(time.Second)
+	ready := health.NewRegistry(time.Second)
+	ready.Register("postgres", func(context.Context) error { return nil })
+	handlers.NewHealthHandler(live, ready).RegisterRoutes(r)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "ok" {
+		t.Fatalf("GET /livez expected 200 ok, got %d %q", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz?verbose=1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /readyz expected 200, got %d; body=%s", w.Code, w.Body.String())
+	}
+	var report health.Report
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("unmarshal report: %v; body=%s", err, w.Body.String())
+	}
+	if len(report.Checks) != 1 || report.Checks[0].Name != "postgres" {
+		t.Fatalf("expected postgres check in report, got %+v", report.Checks)
+	}
+
+	// Durante o shutdown o readiness falha, mas o liveness continua ok.
+	ready.Drain()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("GET /readyz while draining expected 503, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /livez while draining expected 200, got %d", w.Code)
+	}
+}
+
+func TestReadyz_StaleHeartbeat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+
+	var scheduler health.Heartbeat
+	ready := health.NewRegistry(time.Second)
+	ready.Register("preventive_scheduler", scheduler.Check(20*time.Millisecond))
+	handlers.NewHealthHandler(health.NewRegistry(time.Second), ready).RegisterRoutes(r)
+
+	readyz := func() int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		return w.Code
+	}
+	if code := readyz(); code != http.StatusServiceUnavailable {
+		t.Fatalf("GET /readyz before the first beat expected 503, got %d", code)
+	}
+	scheduler.Beat()
+	if code := readyz(); code != http.StatusOK {
+		t.Fatalf("GET /readyz with a fresh heartbeat expected 200, got %d", code)
+	}
+	// Worker travado: o último sinal passa da idade máxima.
+	time.Sleep(40 * time.Millisecond)
+	if code := readyz(); code != http.StatusServiceUnavailable {
+		t.Fatalf("GET /readyz with a stale heartbeat expected 503, got %d", code)
+	}
+}
+
+func TestImports_XLSXUploadAndJobLookup(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	assetRepo := memory.NewAssetMemoryRepo()
+	importSvc := service.NewImportService(assetRepo, memory.NewWorkOrderMemoryRepo())
+	handlers.NewImportHandler(importSvc).RegisterRoutes(r)
+
+	xf := excelize.NewFile()
+	_ = xf.SetSheetRow("Sheet1", "A1", &[]any{"Equipamento", "Local"})
+	_ = xf.SetSheetRow("Sheet1", "A2", &[]any{"Cortadeira 1", "Corte"})
+	_ = xf.SetSheetRow("Sheet1", "A3", &[]any{"Cortadeira 2", "Corte"})
+	var xlsx bytes.Buffer
+	if err := xf.Write(&xlsx); err != nil {
+		t.Fatalf("write xlsx: %v", err)
+	}
+
+	upload := func(dryRun string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, _ := mw.CreateFormFile("file", "ativos.xlsx")
+		_, _ = fw.Write(xlsx.Bytes())
+		_ = mw.WriteField("mapping", `{"name":"Equipamento"}`)
+		_ = mw.WriteField("dry_run", dryRun)
+		_ = mw.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/imports/assets", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := upload("true")
+	if w.Code != http.StatusOK {
+		t.Fatalf("dry run expected 200, got %d; body=%s", w.Code, w.Body.String())
+	}
+	var job map[string]any
+	_ = json.Unmarshal(w.Body.Bytes(), &job)
+	if job["status"] != "validated" || job["total_rows"] != float64(2) {
+		t.Fatalf("unexpected dry run job: %v", job)
+	}
+
+	w = upload("false")
+	if w.Code != http.StatusOK {
+		t.Fatalf("commit expected 200, got %d; body=%s", w.Code, w.Body.String())
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &job)
+
+	wGet := httptest.NewRecorder()
+	r.ServeHTTP(wGet, httptest.NewRequest(http.MethodGet, "/imports/"+job["id"].(string), nil))
+	if wGet.Code != http.StatusOK {
+		t.Fatalf("GET /imports/:id expected 200, got %d", wGet.Code)
+	}
+	_ = json.Unmarshal(wGet.Body.Bytes(), &job)
+	if job["status"] != "done" || job["inserted"] != float64(2) {
+		t.Fatalf("unexpected committed job: %v", job)
+	}
+
+	// segunda importação dos mesmos nomes é recusada com 422
+	w = upload("false")
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("duplicate import expected 422, got %d; body=%s", w.Code, w.Body.String())
+	}
+}
+
+func TestExports_FormatsAndReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	assetRepo := memory.NewAssetMemoryRepo()
+	workOrderRepo := memory.NewWorkOrderMemoryRepo()
+	handlers.NewAssetHandler(service.NewAssetService(assetRepo)).RegisterRoutes(r)
+	handlers.NewWorkOrderHandler(service.NewWorkOrderService(workOrderRepo)).RegisterRoutes(r)
+	handlers.NewReportHandler(service.NewReportService(memory.NewReportMemoryRepo(assetRepo, workOrderRepo, memory.NewDowntimeMemoryRepo(assetRepo), memory.NewShiftMemoryRepo(), memory.NewProductionMemoryRepo(assetRepo), memory.NewFailureCodeMemoryRepo()))).RegisterRoutes(r)
+
+	ctx := onSite(1)
+	_ = assetRepo.Create(ctx, &domain.Asset{Name: "Cortadeira", Location: "Corte", Criticality: domain.CriticalityA})
+	breakdown := time.Now().Add(-2 * time.Hour)
+	minutes := int64(90)
+	_ = workOrderRepo.Create(ctx, &domain.WorkOrder{AssetID: 1, Type: domain.WOTypeCorrective, Status: domain.WOStatusDone,
+		Title: "Faca quebrada", BreakdownAt: &breakdown, DowntimeMinutes: &minutes})
+	_ = workOrderRepo.Create(ctx, &domain.WorkOrder{AssetID: 1, Type: domain.WOTypePreventive, Status: domain.WOStatusOpen, Title: "Lubrificação"})
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// CSV com cabeçalho em pt-BR e filtro aplicado
+	w := get("/work-orders?format=csv&type=corrective", "")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("CSV export expected 200 text/csv, got %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	lines := strings.Split(strings.TrimSpace(strings.TrimPrefix(w.Body.String(), "\xEF\xBB\xBF")), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "OS;Ativo;Tipo;Situação") || !strings.Contains(lines[1], "Corretiva;Concluída") {
+		t.Fatalf("unexpected CSV body: %q", w.Body.String())
+	}
+
+	// XLSX negociado pelo Accept
+	w = get("/assets", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	xf, err := excelize.OpenReader(w.Body)
+	if err != nil {
+		t.Fatalf("open exported xlsx: %v", err)
+	}
+	rows, _ := xf.GetRows(xf.GetSheetList()[0])
+	if len(rows) != 2 || rows[0][1] != "Nome" || rows[1][1] != "Cortadeira" {
+		t.Fatalf("unexpected xlsx rows: %v", rows)
+	}
+
+	// PDF
+	w = get("/work-orders?format=pdf", "")
+	if w.Code != http.StatusOK || !bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF")) {
+		t.Fatalf("PDF export expected a PDF document, got %d", w.Code)
+	}
+
+	// formato desconhecido
+	if w = get("/assets?format=doc", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("unknown format expected 400, got %d", w.Code)
+	}
+
+	// relatório mensal em JSON
+	w = get("/reports/downtime", "")
+	var report []domain.DowntimeReportRow
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("unmarshal report: %v; body=%s", err, w.Body.String())
+	}
+	if len(report) != 1 || report[0].DowntimeMinutes != 90 || report[0].AssetName != "Cortadeira" {
+		t.Fatalf("unexpected downtime report: %+v", report)
+	}
+}
+
+func TestOEE_ShiftsProductionAndReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	assetRepo := memory.NewAssetMemoryRepo()
+	shiftRepo := memory.NewShiftMemoryRepo()
+	productionRepo := memory.NewProductionMemoryRepo(assetRepo)
+	handlers.NewAssetHandler(service.NewAssetService(assetRepo)).RegisterRoutes(r)
+	handlers.NewShiftHandler(service.NewShiftService(shiftRepo)).RegisterRoutes(r)
+	handlers.NewProductionHandler(service.NewProductionService(productionRepo, assetRepo)).RegisterRoutes(r)
+	handlers.NewReportHandler(service.NewReportService(memory.NewReportMemoryRepo(assetRepo, memory.NewWorkOrderMemoryRepo(),
+		memory.NewDowntimeMemoryRepo(assetRepo), shiftRepo, productionRepo, memory.NewFailureCodeMemoryRepo()))).RegisterRoutes(r)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(http.MethodPost, "/assets", `{"name":"Envasadora","location":"Linha 2"}`); w.Code != http.StatusCreated {
+		t.Fatalf("create asset expected 201, got %d", w.Code)
+	}
+	if w := send(http.MethodPatch, "/assets/1", `{"ideal_rate_per_hour":100}`); w.Code != http.StatusOK {
+		t.Fatalf("patch asset expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/shifts", `{"name":"Turno A","start_time":"25:00","end_time":"14:00","weekdays":[1]}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid shift expected 400, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/shifts", `{"name":"Turno A","start_time":"06:00","end_time":"14:00","weekdays":[0,1,2,3,4,5,6]}`); w.Code != http.StatusCreated {
+		t.Fatalf("create shift expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// Lote atômico: um item inválido (boas > total) recusa o envio inteiro.
+	if w := send(http.MethodPost, "/production-counts", `[
+		{"asset_id":1,"period_start":"2025-03-10T09:00:00Z","period_end":"2025-03-10T10:00:00Z","total_count":100,"good_count":90},
+		{"asset_id":1,"period_start":"2025-03-10T10:00:00Z","period_end":"2025-03-10T11:00:00Z","total_count":10,"good_count":20}
+	]`); w.Code != http.StatusBadRequest {
+		t.Fatalf("invalid batch expected 400, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/production-counts", `[
+		{"asset_id":1,"period_start":"2025-03-10T09:00:00Z","period_end":"2025-03-10T10:00:00Z","total_count":100,"good_count":90}
+	]`); w.Code != http.StatusCreated {
+		t.Fatalf("production batch expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w := send(http.MethodGet, "/reports/oee?from=2025-03-10&to=2025-03-11&granularity=shift", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("oee expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report domain.OEEReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode oee report: %v", err)
+	}
+	if len(report.Assets) != 1 || report.Assets[0].Shift != "Turno A" || report.Assets[0].TotalCount != 100 || report.Assets[0].OEE == nil {
+		t.Fatalf("unexpected oee rows: %+v", report.Assets)
+	}
+	if len(report.Locations) != 1 || report.Locations[0].Location != "Linha 2" {
+		t.Fatalf("unexpected location rollup: %+v", report.Locations)
+	}
+
+	if w := send(http.MethodGet, "/reports/oee?granularity=hour", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("unknown granularity expected 400, got %d", w.Code)
+	}
+}
+
+func TestFailureCodes_CloseAndPareto(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	assetRepo := memory.NewAssetMemoryRepo()
+	workOrderRepo := memory.NewWorkOrderMemoryRepo()
+	codeRepo := memory.NewFailureCodeMemoryRepo()
+	handlers.NewFailureCodeHandler(service.NewFailureCodeService(codeRepo)).RegisterRoutes(r)
+	handlers.NewWorkOrderHandler(service.NewWorkOrderService(workOrderRepo,
+		service.WithAssets(assetRepo), service.WithFailureCodes(codeRepo))).RegisterRoutes(r)
+	handlers.NewReportHandler(service.NewReportService(memory.NewReportMemoryRepo(assetRepo, workOrderRepo,
+		memory.NewDowntimeMemoryRepo(assetRepo), memory.NewShiftMemoryRepo(), memory.NewProductionMemoryRepo(assetRepo), codeRepo))).RegisterRoutes(r)
+
+	ctx := onSite(1)
+	_ = assetRepo.Create(ctx, &domain.Asset{Name: "Extrusora", Location: "Linha 3", Criticality: domain.CriticalityA})
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, body := range []string{
+		`{"code":"VAZ","name":"Vazamento","level":"mode"}`,
+		`{"code":"RUI","name":"Ruído","level":"mode"}`,
+		`{"code":"VAZ-VED","name":"Vedação","level":"cause","parent_id":1}`,
+	} {
+		if w := send(http.MethodPost, "/failure-codes", body); w.Code != http.StatusCreated {
+			t.Fatalf("create failure code expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	if w := send(http.MethodPost, "/failure-codes", `{"code":"vaz","name":"Duplicado","level":"mode"}`); w.Code != http.StatusConflict {
+		t.Fatalf("duplicate code expected 409, got %d", w.Code)
+	}
+
+	w := send(http.MethodGet, "/failure-codes/tree", "")
+	var tree []domain.FailureCodeNode
+	if err := json.Unmarshal(w.Body.Bytes(), &tree); err != nil || len(tree) != 2 {
+		t.Fatalf("expected 2 root modes, got %s", w.Body.String())
+	}
+
+	// Três corretivas: duas fecham como vazamento, uma como ruído.
+	for i, mode := range []int{1, 1, 2} {
+		if w := send(http.MethodPost, "/work-orders", `{"asset_id":1,"title":"Falha na extrusora"}`); w.Code != http.StatusCreated {
+			t.Fatalf("create work order expected 201, got %d", w.Code)
+		}
+		path := "/work-orders/" + strconv.Itoa(i+1) + "/status"
+		if i == 0 {
+			if w := send(http.MethodPost, path, `{"status":"done"}`); w.Code != http.StatusPreconditionFailed {
+				t.Fatalf("done without code on criticality A expected 412, got %d", w.Code)
+			}
+		}
+		body := `{"status":"done","failure_mode_id":` + strconv.Itoa(mode) + `}`
+		if w := send(http.MethodPost, path, body); w.Code != http.StatusOK {
+			t.Fatalf("close work order expected 200, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	if w := send(http.MethodPost, "/work-orders/1/status", `{"status":"in_progress"}`); w.Code != http.StatusConflict {
+		t.Fatalf("reopen done order expected 409, got %d", w.Code)
+	}
+
+	w = send(http.MethodGet, "/reports/pareto?location=Linha%203", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("pareto expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var report domain.ParetoReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode pareto: %v", err)
+	}
+	if len(report.Rows) != 2 || report.Rows[0].Code != "VAZ" || report.Rows[0].Count != 2 || report.Rows[1].CumulativeShare != 1 {
+		t.Fatalf("unexpected pareto rows: %+v", report.Rows)
+	}
+}
+
+func TestNotifications_SparePartAlertAndAck(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	userRepo := memory.NewUserMemoryRepo()
+	notifications := service.NewNotificationService(userRepo, memory.NewEscalationMemoryRepo(), memory.NewAlertMemoryRepo(),
+		map[domain.NotificationChannel]notify.Channel{domain.ChannelLog: &notify.Log{}})
+	handlers.NewUserHandler(service.NewUserService(userRepo)).RegisterRoutes(r)
+	handlers.NewNotificationHandler(notifications).RegisterRoutes(r)
+	handlers.NewSparePartHandler(service.NewSparePartService(memory.NewSparePartMemoryRepo(), notifications)).RegisterRoutes(r)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(http.MethodPost, "/users", `{"name":"Almoxarife","phone":"11999"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("invalid phone expected 422, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/users", `{"name":"Almoxarife","preferences":{"low_stock":["fax"]}}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("unknown channel expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/users", `{"name":"Almoxarife","email":"almox@fabrica.local","preferences":{"low_stock":["log"]}}`); w.Code != http.StatusCreated {
+		t.Fatalf("create user expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPut, "/escalation-rules", `[{"event":"low_stock","tier":1,"delay_minutes":0,"user_ids":[1]}]`); w.Code != http.StatusOK {
+		t.Fatalf("replace rules expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if w := send(http.MethodPost, "/spare-parts", `{"code":"cor-a42","name":"Correia A42","quantity":3,"min_quantity":2}`); w.Code != http.StatusCreated {
+		t.Fatalf("create spare part expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/spare-parts/1/stock", `{"delta":-5}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("stock below zero expected 400, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/spare-parts/1/stock", `{"delta":-2}`); w.Code != http.StatusOK {
+		t.Fatalf("consume stock expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	// O aviso sai pelo escalonamento, fora da requisição.
+	if err := notifications.Escalate(tenant.System(context.Background())); err != nil {
+		t.Fatalf("Escalate() error = %v", err)
+	}
+
+	w := send(http.MethodGet, "/alerts?escalating=true", "")
+	var alerts []domain.Alert
+	if err := json.Unmarshal(w.Body.Bytes(), &alerts); err != nil || len(alerts) != 1 || alerts[0].Event != domain.EventLowStock || alerts[0].Tier != 1 {
+		t.Fatalf("expected one low stock alert at tier 1, got %s", w.Body.String())
+	}
+	if w := send(http.MethodPost, "/alerts/1/ack", `{"user_id":1}`); w.Code != http.StatusOK {
+		t.Fatalf("ack expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/alerts/1/ack", `{"user_id":1}`); w.Code != http.StatusConflict {
+		t.Fatalf("second ack expected 409, got %d", w.Code)
+	}
+}
+
+func TestCalendar_HolidayImportAndPreventiveFeed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	plans := memory.NewMaintenancePlanMemoryRepo()
+	calendar := service.NewCalendarService(memory.NewCalendarMemoryRepo(), memory.NewShiftMemoryRepo())
+	workOrders := service.NewWorkOrderService(orders, service.WithCalendar(calendar), service.WithPlans(plans))
+	scheduler := service.NewPreventiveScheduler(plans, orders, workOrders, calendar, time.Hour, 7*24*time.Hour)
+	handlers.NewAssetHandler(service.NewAssetService(assets)).RegisterRoutes(r)
+	handlers.NewMaintenancePlanHandler(service.NewMaintenancePlanService(plans, assets, memory.NewJobPlanMemoryRepo())).RegisterRoutes(r)
+	handlers.NewCalendarHandler(calendar, scheduler).RegisterRoutes(r)
+
+	send := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	ics := "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20251225\r\nSUMMARY:Natal\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20251231\r\nDTEND;VALUE=DATE:20260102\r\nSUMMARY:Recesso\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"
+	if w := send(http.MethodPost, "/calendar/holidays/import", "text/calendar", ics); w.Code != http.StatusOK {
+		t.Fatalf("import holidays expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var holidays []domain.Holiday
+	w := send(http.MethodGet, "/calendar/holidays", "", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &holidays); err != nil || len(holidays) != 3 || holidays[2].Date != "2026-01-01" {
+		t.Fatalf("expected 3 imported holidays, got %s", w.Body.String())
+	}
+	w = send(http.MethodGet, "/calendar/days?from=2025-12-24&to=2025-12-27", "", "")
+	var days []domain.CalendarDay
+	if err := json.Unmarshal(w.Body.Bytes(), &days); err != nil || len(days) != 3 || days[1].Working || days[1].Holiday != "Natal" {
+		t.Fatalf("unexpected calendar days: %s", w.Body.String())
+	}
+	if w := send(http.MethodPut, "/calendar/settings", "application/json", `{"working_days":[1,1]}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("duplicated working day expected 400, got %d", w.Code)
+	}
+
+	if w := send(http.MethodPost, "/assets", "application/json", `{"name":"Caldeira","criticality":"A"}`); w.Code != http.StatusCreated {
+		t.Fatalf("create asset expected 201, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/maintenance-plans", "application/json", `{"asset_id":1,"rule_type":"time"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("time plan without frequency expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/maintenance-plans", "application/json", `{"asset_id":1,"rule_type":"time","frequency_days":30}`); w.Code != http.StatusCreated {
+		t.Fatalf("create plan expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+
+	w = send(http.MethodGet, "/calendar/assets/1/preventives.ics", "", "")
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/calendar") {
+		t.Fatalf("feed expected 200 text/calendar, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "BEGIN:VEVENT\r\n") || !strings.Contains(body, "SUMMARY:Preventiva: plano #1\r\n") {
+		t.Fatalf("feed should list the projected preventives:\n%s", body)
+	}
+	if w := send(http.MethodGet, "/calendar/preventives", "", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("preventives without asset_id expected 400, got %d", w.Code)
+	}
+}
+
+func TestPlanning_TechniciansBacklogAndSchedule(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	shifts := memory.NewShiftMemoryRepo()
+	technicians := memory.NewTechnicianMemoryRepo()
+	calendar := service.NewCalendarService(memory.NewCalendarMemoryRepo(), shifts)
+	handlers.NewAssetHandler(service.NewAssetService(assets)).RegisterRoutes(r)
+	handlers.NewWorkOrderHandler(service.NewWorkOrderService(orders)).RegisterRoutes(r)
+	handlers.NewShiftHandler(service.NewShiftService(shifts)).RegisterRoutes(r)
+	handlers.NewTechnicianHandler(service.NewTechnicianService(technicians, shifts)).RegisterRoutes(r)
+	handlers.NewPlanningHandler(service.NewPlanningService(orders, assets, technicians, shifts, calendar)).RegisterRoutes(r)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, c := range []struct{ path, body string }{
+		{"/assets", `{"name":"Torno","criticality":"B"}`},
+		{"/shifts", `{"name":"Dia","start_time":"07:00","end_time":"16:00","weekdays":[1,2,3,4,5],"break_minutes":60}`},
+		{"/technicians", `{"name":"Caio","skills":["Eletrica","eletrica"],"shift_id":1}`},
+		{"/work-orders", `{"asset_id":1,"title":"Motor aquecendo","trade":"ELETRICA","estimated_minutes":180}`},
+	} {
+		if w := send(http.MethodPost, c.path, c.body); w.Code != http.StatusCreated {
+			t.Fatalf("POST %s expected 201, got %d: %s", c.path, w.Code, w.Body.String())
+		}
+	}
+	if w := send(http.MethodPost, "/technicians", `{"name":"Sem turno","shift_id":7}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("technician with unknown shift expected 400, got %d", w.Code)
+	}
+	w := send(http.MethodPatch, "/technicians/1", `{"skills":["eletrica","mecanica"]}`)
+	var tech domain.Technician
+	if err := json.Unmarshal(w.Body.Bytes(), &tech); err != nil || len(tech.Skills) != 2 {
+		t.Fatalf("update technician: %d %s", w.Code, w.Body.String())
+	}
+
+	w = send(http.MethodGet, "/planning/backlog?from=2025-11-17&weeks=1", "")
+	var report domain.BacklogReport
+	if err := json.Unmarshal(w.Body.Bytes(), &report); err != nil || len(report.Rows) != 1 || report.Rows[0].Trade != "eletrica" {
+		t.Fatalf("unexpected backlog: %d %s", w.Code, w.Body.String())
+	}
+	if c := report.Capacity[0]; c.Trade != "eletrica" || c.AvailableHours != 40 || c.DemandHours != 3 {
+		t.Fatalf("unexpected capacity: %+v", report.Capacity)
+	}
+	if w := send(http.MethodGet, "/planning/backlog?weeks=53", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("too many weeks expected 400, got %d", w.Code)
+	}
+
+	w = send(http.MethodPost, "/planning/schedule", `{"from":"2025-11-17T00:00:00-03:00","weeks":1}`)
+	var proposal domain.ScheduleProposal
+	if err := json.Unmarshal(w.Body.Bytes(), &proposal); err != nil || len(proposal.Assignments) != 1 {
+		t.Fatalf("unexpected proposal: %d %s", w.Code, w.Body.String())
+	}
+	if a := proposal.Assignments[0]; a.TechnicianName != "Caio" || a.Start.Hour() != 7 || a.End.Sub(a.Start) != 3*time.Hour {
+		t.Fatalf("unexpected assignment: %+v", a)
+	}
+}
+
+func TestJobPlans_ChecklistPatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	assets := memory.NewAssetMemoryRepo()
+	jobPlans := memory.NewJobPlanMemoryRepo()
+	handlers.NewAssetHandler(service.NewAssetService(assets)).RegisterRoutes(r)
+	handlers.NewJobPlanHandler(service.NewJobPlanService(jobPlans, memory.NewSparePartMemoryRepo())).RegisterRoutes(r)
+	orders := memory.NewWorkOrderMemoryRepo()
+	handlers.NewWorkOrderHandler(service.NewWorkOrderService(orders,
+		service.WithAssets(assets), service.WithChecklists(jobPlans, memory.NewChecklistMemoryRepo(orders)))).RegisterRoutes(r)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(http.MethodPost, "/assets", `{"name":"Bomba","criticality":"C"}`); w.Code != http.StatusCreated {
+		t.Fatalf("create asset expected 201, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/job-plans", `{"name":"Sem passos","steps":[]}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("job plan without steps expected 422, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/job-plans", `{"name":"Inspeção","steps":[{"description":"Medir vibração","measurement":{"unit":"mm/s","max":4.5,"follow_up":true}}]}`); w.Code != http.StatusCreated {
+		t.Fatalf("create job plan expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/work-orders", `{"asset_id":1,"type":"preventive","title":"Inspeção mensal","job_plan_id":1}`); w.Code != http.StatusCreated {
+		t.Fatalf("create work order expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/work-orders", `{"asset_id":1,"title":"Roteiro inexistente","job_plan_id":9}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("unknown job plan expected 400, got %d", w.Code)
+	}
+
+	if w := send(http.MethodPatch, "/work-orders/1/checklist/1", `{"done":true}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("measurement without value expected 400, got %d", w.Code)
+	}
+	w := send(http.MethodPatch, "/work-orders/1/checklist/1", `{"done":true,"value":7.1}`)
+	var item domain.ChecklistItem
+	if err := json.Unmarshal(w.Body.Bytes(), &item); err != nil || !item.OutOfTolerance || item.FollowUpID == nil || *item.FollowUpID != 2 {
+		t.Fatalf("expected follow-up work order #2: %d %s", w.Code, w.Body.String())
+	}
+	w = send(http.MethodGet, "/work-orders/1/checklist", "")
+	var items []domain.ChecklistItem
+	if err := json.Unmarshal(w.Body.Bytes(), &items); err != nil || len(items) != 1 || !items[0].Done {
+		t.Fatalf("unexpected checklist: %s", w.Body.String())
+	}
+	if w := send(http.MethodPost, "/work-orders/1/status", `{"status":"done"}`); w.Code != http.StatusOK {
+		t.Fatalf("close with complete checklist expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestAssets_ClassAttributesFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	classes := memory.NewAssetClassMemoryRepo()
+	handlers.NewAssetClassHandler(service.NewAssetClassService(classes)).RegisterRoutes(r)
+	handlers.NewAssetHandler(service.NewAssetService(memory.NewAssetMemoryRepo(), service.WithAssetClasses(classes))).RegisterRoutes(r)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(http.MethodPost, "/asset-classes", `{"code":"motor","name":"Motor","attributes":[{"key":"voltage","type":"number","unit":"V","required":true}]}`); w.Code != http.StatusCreated {
+		t.Fatalf("create class expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/asset-classes", `{"code":"motor","name":"Outro"}`); w.Code != http.StatusConflict {
+		t.Fatalf("duplicate class expected 409, got %d", w.Code)
+	}
+	for i, voltage := range []string{"380", "220"} {
+		body := fmt.Sprintf(`{"name":"Motor %s","external_code":"MT-%d","class":"motor","manufacturer":"WEG","installed_on":"2024-03-01","attributes":{"voltage":%s}}`, voltage, i, voltage)
+		if w := send(http.MethodPost, "/assets", body); w.Code != http.StatusCreated {
+			t.Fatalf("create asset expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	if w := send(http.MethodPost, "/assets", `{"name":"Sem tensão","class":"motor"}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("missing required attribute expected 400, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/assets", `{"name":"Repetido","external_code":"mt-0"}`); w.Code != http.StatusConflict {
+		t.Fatalf("duplicate tag expected 409, got %d", w.Code)
+	}
+
+	w := send(http.MethodGet, "/assets?class=motor&attr.voltage=380", "")
+	var list []domain.Asset
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list) != 1 || list[0].Name != "Motor 380" || list[0].Manufacturer != "WEG" {
+		t.Fatalf("unexpected filtered list: %s", w.Body.String())
+	}
+}
+
+func TestScan_TagImagesLabelsAndResolve(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	plans := memory.NewMaintenancePlanMemoryRepo()
+	assetSvc := service.NewAssetService(assets)
+	calendar := service.NewCalendarService(memory.NewCalendarMemoryRepo(), memory.NewShiftMemoryRepo())
+	workOrders := service.NewWorkOrderService(orders)
+	handlers.NewAssetHandler(assetSvc).RegisterRoutes(r)
+	handlers.NewWorkOrderHandler(workOrders).RegisterRoutes(r)
+	handlers.NewMaintenancePlanHandler(service.NewMaintenancePlanService(plans, assets, memory.NewJobPlanMemoryRepo())).RegisterRoutes(r)
+	handlers.NewCalendarHandler(calendar, service.NewPreventiveScheduler(plans, orders, workOrders, calendar, time.Hour, time.Hour)).RegisterRoutes(r)
+	handlers.NewScanHandler(service.NewScanService(assets, orders, plans), assetSvc, "https://cmms.example/").RegisterRoutes(r)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	send(http.MethodPost, "/assets", `{"name":"Cortadeira","location":"Corte","external_code":"CT-01"}`)
+	send(http.MethodPost, "/work-orders", `{"asset_id":1,"title":"Troca de lâmina"}`)
+	send(http.MethodPost, "/work-orders", `{"asset_id":1,"title":"Já feita","status":"done"}`)
+	send(http.MethodPost, "/maintenance-plans", `{"asset_id":1,"rule_type":"time","frequency_days":30}`)
+
+	w := send(http.MethodGet, "/assets/1/tag.png?size=200", "")
+	img, err := png.Decode(w.Body)
+	if w.Code != http.StatusOK || err != nil || img.Bounds().Dx() > 200 || img.Bounds().Dx() < 100 {
+		t.Fatalf("unexpected PNG: %d %v", w.Code, err)
+	}
+	w = send(http.MethodGet, "/assets/1/tag.svg?symbology=code128", "")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "image/svg+xml" || !strings.HasPrefix(w.Body.String(), "<svg") {
+		t.Fatalf("unexpected SVG: %d %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodGet, "/assets/1/tag.png?symbology=ean", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("unknown symbology expected 400, got %d", w.Code)
+	}
+	if w := send(http.MethodGet, "/assets/9/tag.png", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("unknown asset expected 404, got %d", w.Code)
+	}
+	w = send(http.MethodGet, "/assets/labels.pdf?location=Corte", "")
+	if w.Code != http.StatusOK || !bytes.HasPrefix(w.Body.Bytes(), []byte("%PDF")) {
+		t.Fatalf("unexpected labels PDF: %d", w.Code)
+	}
+
+	for _, tag := range []string{"AT-000001", "at-1", "ct-01"} {
+		w = send(http.MethodGet, "/scan/"+tag, "")
+		var res domain.ScanResult
+		if err := json.Unmarshal(w.Body.Bytes(), &res); err != nil || w.Code != http.StatusOK {
+			t.Fatalf("scan %s: %d %s", tag, w.Code, w.Body.String())
+		}
+		if res.Tag != "AT-000001" || len(res.OpenWorkOrders) != 1 || len(res.Plans) != 1 || res.Plans[0].NextDue == nil ||
+			res.BreakdownRequest.AssetID != 1 || res.BreakdownRequest.Type != domain.WOTypeCorrective {
+			t.Fatalf("scan %s: unexpected result %s", tag, w.Body.String())
+		}
+	}
+	if w := send(http.MethodGet, "/scan/XX-404", ""); w.Code != http.StatusNotFound {
+		t.Fatalf("unknown tag expected 404, got %d", w.Code)
+	}
+}
+
+func TestRequests_SubmitTriageAccept(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(asSite(1))
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	workOrders := service.NewWorkOrderService(orders)
+	handlers.NewAssetHandler(service.NewAssetService(assets)).RegisterRoutes(r)
+	handlers.NewWorkOrderHandler(workOrders).RegisterRoutes(r)
+	handlers.NewMaintenanceRequestHandler(service.NewMaintenanceRequestService(
+		memory.NewMaintenanceRequestMemoryRepo(), assets, orders, workOrders)).RegisterRoutes(r)
+
+	send := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	send(http.MethodPost, "/assets", `{"name":"Bobinadeira"}`)
+	send(http.MethodPost, "/work-orders", `{"asset_id":1,"title":"Rolamento com ruído"}`)
+
+	w := send(http.MethodPost, "/requests", `{"asset_id":1,"title":"Ruído no rolamento","requested_by":"turno B"}`)
+	var sub domain.RequestSubmission
+	if err := json.Unmarshal(w.Body.Bytes(), &sub); err != nil || w.Code != http.StatusCreated || len(sub.Duplicates) != 1 {
+		t.Fatalf("unexpected submit: %d %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/requests/1/accept", ""); w.Code != http.StatusConflict {
+		t.Fatalf("accept before triage expected 409, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/requests/1/triage", ""); w.Code != http.StatusOK {
+		t.Fatalf("triage expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(http.MethodPost, "/requests/1/reject", `{}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("reject without reason expected 422, got %d", w.Code)
+	}
+	w = send(http.MethodPost, "/requests/1/accept", `{"type":"improvement"}`)
+	var accepted struct {
+		Request   domain.MaintenanceRequest `json:"request"`
+		WorkOrder domain.WorkOrder          `json:"work_order"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &accepted); err != nil || w.Code != http.StatusCreated ||
+		accepted.WorkOrder.RequestID == nil || *accepted.WorkOrder.RequestID != 1 || *accepted.Request.WorkOrderID != accepted.WorkOrder.ID {
+		t.Fatalf("unexpected accept: %d %s", w.Code, w.Body.String())
+	}
+	w = send(http.MethodGet, "/requests?status=accepted&asset_id=1", "")
+	var list []domain.MaintenanceRequest
+	if err := json.Unmarshal(w.Body.Bytes(), &list); err != nil || len(list) != 1 {
+		t.Fatalf("unexpected list: %s", w.Body.String())
+	}
+}
+
+func TestSites_ScopeAndCorporateReport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	assets := memory.NewAssetMemoryRepo()
+	sites := memory.NewSiteMemoryRepo()
+	reports := service.NewReportService(memory.NewReportMemoryRepo(assets, memory.NewWorkOrderMemoryRepo(), memory.NewDowntimeMemoryRepo(assets),
+		memory.NewShiftMemoryRepo(), memory.NewProductionMemoryRepo(assets), memory.NewFailureCodeMemoryRepo()), service.WithSiteDirectory(sites))
+	router := func(p tenant.Principal) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			c.Request = c.Request.WithContext(tenant.WithPrincipal(c.Request.Context(), p))
+			c.Next()
+		})
+		handlers.NewAssetHandler(service.NewAssetService(assets)).RegisterRoutes(r)
+		handlers.NewSiteHandler(service.NewSiteService(sites)).RegisterRoutes(r)
+		handlers.NewReportHandler(reports).RegisterRoutes(r)
+		return r
+	}
+	site1 := router(tenant.Principal{UserID: 1, SiteID: 1})
+	site2 := router(tenant.Principal{UserID: 2, SiteID: 2})
+	corp := router(tenant.Principal{UserID: 3, SiteID: 1, Corporate: true})
+
+	send := func(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(site1, http.MethodPost, "/sites", `{"code":"sul","name":"Planta Sul"}`); w.Code != http.StatusForbidden {
+		t.Fatalf("site user creating site expected 403, got %d", w.Code)
+	}
+	for _, body := range []string{`{"code":"matriz","name":"Matriz"}`, `{"code":"sul","name":"Planta Sul"}`} {
+		if w := send(corp, http.MethodPost, "/sites", body); w.Code != http.StatusCreated {
+			t.Fatalf("create site expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+	}
+	send(site1, http.MethodPost, "/assets", `{"name":"Bobinadeira"}`)
+
+	if w := send(site2, http.MethodPatch, "/assets/1", `{"name":"Intrusa"}`); w.Code != http.StatusNotFound {
+		t.Fatalf("asset of another site expected 404, got %d", w.Code)
+	}
+	for _, q := range []string{"?site=all", "?site=1"} {
+		if w := send(site2, http.MethodGet, "/reports/downtime"+q, ""); w.Code != http.StatusForbidden {
+			t.Fatalf("site user %s expected 403, got %d", q, w.Code)
+		}
+	}
+	if w := send(site2, http.MethodGet, "/reports/downtime?site=2", ""); w.Code != http.StatusOK {
+		t.Fatalf("own site expected 200, got %d", w.Code)
+	}
+	if w := send(corp, http.MethodGet, "/reports/downtime?site=all", ""); w.Code != http.StatusOK {
+		t.Fatalf("corporate all sites expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(site1, http.MethodGet, "/reports/sites", ""); w.Code != http.StatusForbidden {
+		t.Fatalf("site comparison by site user expected 403, got %d", w.Code)
+	}
+	w := send(corp, http.MethodGet, "/reports/sites", "")
+	var rows []domain.SiteReportRow
+	if err := json.Unmarshal(w.Body.Bytes(), &rows); err != nil || w.Code != http.StatusOK || len(rows) != 2 || rows[1].Code != "SUL" {
+		t.Fatalf("unexpected site comparison: %d %s", w.Code, w.Body.String())
+	}
+}
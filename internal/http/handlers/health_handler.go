@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/health"
+)
+
+// HealthHandler expõe liveness (/livez) e readiness (/readyz) para o Kubernetes.
+type HealthHandler struct {
+	live  *health.Registry
+	ready *health.Registry
+}
+
+func NewHealthHandler(live, ready *health.Registry) *HealthHandler {
+	return &HealthHandler{live: live, ready: ready}
+}
+
+func (h *HealthHandler) RegisterRoutes(r gin.IRouter) {
+	r.GET("/livez", h.serve(h.live))
+	r.GET("/readyz", h.serve(h.ready))
+}
+
+// serve responde "ok"/"fail" em texto; com ?verbose=1 devolve o detalhe por check.
+func (h *HealthHandler) serve(reg *health.Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := reg.Run(c.Request.Context())
+
+		code := http.StatusOK
+		if report.Status != health.StatusOK {
+			code = http.StatusServiceUnavailable
+		}
+
+		if v := c.Query("verbose"); v != "" && v != "0" && v != "false" {
+			c.JSON(code, report)
+			return
+		}
+		c.String(code, string(report.Status))
+	}
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/importer"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+// MaxImportSize limita o upload de planilhas (≈ anos de histórico em CSV); o limite
+// geral de corpo da API precisa liberar as rotas de importação até ele.
+const MaxImportSize = 32 << 20
+
+type ImportHandler struct {
+	service *service.ImportService
+}
+
+func NewImportHandler(s *service.ImportService) *ImportHandler {
+	return &ImportHandler{service: s}
+}
+
+func (h *ImportHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/imports")
+	g.POST("/assets", h.create(domain.ImportAssets))
+	g.POST("/work-orders", h.create(domain.ImportWorkOrders))
+	g.GET("/:id", h.get)
+}
+
+// create aceita multipart (campo "file") ou o arquivo direto no corpo.
+// Parâmetros (query ou form): dry_run (padrão true), mapping (JSON campo→coluna), format (csv|xlsx).
+func (h *ImportHandler) create(kind domain.ImportKind) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxImportSize)
+
+		body, filename, contentType, err := importFile(c)
+		if err != nil {
+			response.HandleError(c, fmt.Errorf("%w: %w", domain.ErrInvalidInput, err))
+			return
+		}
+		defer body.Close()
+
+		format, err := importer.DetectFormat(param(c, "format"), filename, contentType)
+		if err != nil {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+
+		dryRun := true
+		if v := param(c, "dry_run"); v != "" {
+			if dryRun, err = strconv.ParseBool(v); err != nil {
+				response.HandleError(c, domain.ErrInvalidInput)
+				return
+			}
+		}
+
+		var mapping importer.Mapping
+		if v := param(c, "mapping"); v != "" {
+			if err := json.Unmarshal([]byte(v), &mapping); err != nil {
+				response.HandleError(c, domain.ErrInvalidInput)
+				return
+			}
+		}
+
+		table, err := importer.Read(body, format)
+		if err != nil {
+			response.HandleError(c, fmt.Errorf("%w: %w", domain.ErrInvalidInput, err))
+			return
+		}
+
+		job, err := h.service.Start(c.Request.Context(), service.ImportRequest{
+			Kind:    kind,
+			DryRun:  dryRun,
+			Table:   table,
+			Mapping: mapping,
+		})
+		if err != nil {
+			response.HandleError(c, err)
+			return
+		}
+		c.JSON(jobStatusCode(c, job), v1.NewImportJob(job))
+	}
+}
+
+func (h *ImportHandler) get(c *gin.Context) {
+	job, err := h.service.Get(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewImportJob(job))
+}
+
+// jobStatusCode: 202 enquanto roda em background, 422 se o commit foi recusado
+// por erros de validação e 200 quando terminou.
+func jobStatusCode(c *gin.Context, job *domain.ImportJob) int {
+	switch {
+	case !job.Finished():
+		c.Header("Location", "/imports/"+job.ID)
+		return http.StatusAccepted
+	case job.Status == domain.ImportStatusFailed && job.ErrorCount > 0:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusOK
+	}
+}
+
+func importFile(c *gin.Context) (io.ReadCloser, string, string, error) {
+	if fh, err := c.FormFile("file"); err == nil {
+		f, err := fh.Open()
+		if err != nil {
+			return nil, "", "", err
+		}
+		return f, fh.Filename, fh.Header.Get("Content-Type"), nil
+	} else if !errors.Is(err, http.ErrNotMultipart) {
+		return nil, "", "", err
+	}
+	return c.Request.Body, "", c.ContentType(), nil
+}
+
+// param lê primeiro da query string e depois do formulário multipart.
+func param(c *gin.Context, key string) string {
+	if v, ok := c.GetQuery(key); ok {
+		return v
+	}
+	return c.PostForm(key)
+}
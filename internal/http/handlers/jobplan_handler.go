@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type JobPlanHandler struct {
+	service *service.JobPlanService
+}
+
+func NewJobPlanHandler(s *service.JobPlanService) *JobPlanHandler {
+	return &JobPlanHandler{service: s}
+}
+
+func (h *JobPlanHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/job-plans")
+	g.POST("", h.create)
+	g.GET("", h.list)
+	g.GET("/:id", h.get)
+	g.PUT("/:id", h.replace)
+}
+
+type measurementRequest struct {
+	Unit     string   `json:"unit" binding:"max=32"`
+	Min      *float64 `json:"min"`
+	Max      *float64 `json:"max"`
+	FollowUp bool     `json:"follow_up"`
+}
+
+type jobPlanStepRequest struct {
+	Description string              `json:"description" binding:"required,max=512"`
+	Measurement *measurementRequest `json:"measurement"`
+}
+
+type jobPlanPartRequest struct {
+	SparePartID int64   `json:"spare_part_id" binding:"required,gt=0"`
+	Quantity    float64 `json:"quantity" binding:"required,gt=0"`
+}
+
+// jobPlanRequest: os passos seguem a ordem do array (seq é recalculado).
+type jobPlanRequest struct {
+	Name             string               `json:"name" binding:"required,max=128"`
+	Description      string               `json:"description" binding:"max=2000"`
+	Trade            string               `json:"trade" binding:"max=64"`
+	EstimatedMinutes *int64               `json:"estimated_minutes" binding:"omitempty,gt=0"`
+	Steps            []jobPlanStepRequest `json:"steps" binding:"required,min=1,dive"`
+	Parts            []jobPlanPartRequest `json:"parts" binding:"dive"`
+}
+
+func (req *jobPlanRequest) jobPlan() domain.JobPlan {
+	jp := domain.JobPlan{
+		Name:             req.Name,
+		Description:      req.Description,
+		Trade:            req.Trade,
+		EstimatedMinutes: req.EstimatedMinutes,
+		Steps:            make([]domain.JobPlanStep, len(req.Steps)),
+		Parts:            make([]domain.JobPlanPart, len(req.Parts)),
+	}
+	for i, s := range req.Steps {
+		jp.Steps[i] = domain.JobPlanStep{Description: s.Description}
+		if m := s.Measurement; m != nil {
+			jp.Steps[i].Measurement = &domain.MeasurementSpec{Unit: m.Unit, Min: m.Min, Max: m.Max, FollowUp: m.FollowUp}
+		}
+	}
+	for i, p := range req.Parts {
+		jp.Parts[i] = domain.JobPlanPart{SparePartID: p.SparePartID, Quantity: p.Quantity}
+	}
+	return jp
+}
+
+func (h *JobPlanHandler) create(c *gin.Context) {
+	var req jobPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	jp := req.jobPlan()
+	if err := h.service.Create(c.Request.Context(), &jp); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewJobPlan(&jp))
+}
+
+func (h *JobPlanHandler) replace(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req jobPlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	jp := req.jobPlan()
+	jp.ID = id
+	if err := h.service.Replace(c.Request.Context(), &jp); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewJobPlan(&jp))
+}
+
+func (h *JobPlanHandler) get(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	jp, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewJobPlan(jp))
+}
+
+func (h *JobPlanHandler) list(c *gin.Context) {
+	list, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewJobPlans(list))
+}
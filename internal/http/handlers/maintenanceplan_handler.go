@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type MaintenancePlanHandler struct {
+	service *service.MaintenancePlanService
+}
+
+func NewMaintenancePlanHandler(s *service.MaintenancePlanService) *MaintenancePlanHandler {
+	return &MaintenancePlanHandler{service: s}
+}
+
+func (h *MaintenancePlanHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/maintenance-plans")
+	g.POST("", h.create)
+	g.GET("", h.list)
+}
+
+type createMaintenancePlanRequest struct {
+	AssetID          int64      `json:"asset_id" binding:"required,gt=0"`
+	RuleType         string     `json:"rule_type" binding:"required,oneof=time meter condition"`
+	FrequencyDays    *int64     `json:"frequency_days" binding:"omitempty,gt=0"`
+	MeterTarget      *int64     `json:"meter_target" binding:"omitempty,gt=0"`
+	LastExecution    *time.Time `json:"last_execution"`
+	JobPlanID        *int64     `json:"job_plan_id" binding:"omitempty,gt=0"`
+	Trade            string     `json:"trade" binding:"max=64"`
+	EstimatedMinutes *int64     `json:"estimated_minutes" binding:"omitempty,gt=0"`
+	// Faixa aceitável da medição nos planos por condição.
+	ConditionParameter string   `json:"condition_parameter" binding:"max=64"`
+	ConditionMin       *float64 `json:"condition_min"`
+	ConditionMax       *float64 `json:"condition_max"`
+}
+
+func (h *MaintenancePlanHandler) create(c *gin.Context) {
+	var req createMaintenancePlanRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	plan := domain.MaintenancePlan{
+		AssetID:            req.AssetID,
+		RuleType:           domain.PlanRuleType(req.RuleType),
+		FrequencyDays:      req.FrequencyDays,
+		MeterTarget:        req.MeterTarget,
+		LastExecution:      req.LastExecution,
+		JobPlanID:          req.JobPlanID,
+		Trade:              req.Trade,
+		EstimatedMinutes:   req.EstimatedMinutes,
+		ConditionParameter: req.ConditionParameter,
+		ConditionMin:       req.ConditionMin,
+		ConditionMax:       req.ConditionMax,
+	}
+	if err := h.service.Create(c.Request.Context(), &plan); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewMaintenancePlan(&plan))
+}
+
+func (h *MaintenancePlanHandler) list(c *gin.Context) {
+	plans, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewMaintenancePlans(plans))
+}
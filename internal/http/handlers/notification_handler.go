@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+// NotificationHandler expõe as regras de escalonamento e os alertas.
+type NotificationHandler struct {
+	service *service.NotificationService
+}
+
+func NewNotificationHandler(s *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{service: s}
+}
+
+func (h *NotificationHandler) RegisterRoutes(r gin.IRouter) {
+	rules := r.Group("/escalation-rules")
+	rules.GET("", h.listRules)
+	rules.PUT("", h.replaceRules)
+
+	alerts := r.Group("/alerts")
+	alerts.GET("", h.listAlerts)
+	alerts.POST("/:id/ack", h.ack)
+}
+
+type escalationRuleRequest struct {
+	Event        domain.NotificationEvent `json:"event" binding:"required,oneof=critical_breakdown sla_at_risk preventive_overdue low_stock"`
+	Tier         int                      `json:"tier" binding:"required,gte=1"`
+	DelayMinutes int64                    `json:"delay_minutes" binding:"gte=0"`
+	UserIDs      []int64                  `json:"user_ids" binding:"required,min=1,dive,gt=0"`
+}
+
+type ackRequest struct {
+	UserID int64 `json:"user_id" binding:"required,gt=0"`
+}
+
+func (h *NotificationHandler) listRules(c *gin.Context) {
+	rules, err := h.service.ListRules(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewEscalationRules(rules))
+}
+
+// replaceRules recebe todas as regras (array); eventos ausentes não notificam ninguém.
+func (h *NotificationHandler) replaceRules(c *gin.Context) {
+	var req []escalationRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	rules := make([]domain.EscalationRule, 0, len(req))
+	for _, r := range req {
+		rules = append(rules, domain.EscalationRule{
+			Event: r.Event, Tier: r.Tier, DelayMinutes: r.DelayMinutes, UserIDs: r.UserIDs,
+		})
+	}
+	if err := h.service.ReplaceRules(c.Request.Context(), rules); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewEscalationRules(rules))
+}
+
+// listAlerts: ?escalating=true traz só os alertas ainda sem reconhecimento e sem resolução.
+func (h *NotificationHandler) listAlerts(c *gin.Context) {
+	escalating := false
+	if v := c.Query("escalating"); v != "" {
+		var err error
+		if escalating, err = strconv.ParseBool(v); err != nil {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+	}
+	alerts, err := h.service.ListAlerts(c.Request.Context(), escalating)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewAlerts(alerts))
+}
+
+func (h *NotificationHandler) ack(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req ackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	alert, err := h.service.Ack(c.Request.Context(), id, req.UserID)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewAlert(alert))
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type PlanningHandler struct {
+	service *service.PlanningService
+}
+
+func NewPlanningHandler(s *service.PlanningService) *PlanningHandler {
+	return &PlanningHandler{service: s}
+}
+
+func (h *PlanningHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/planning")
+	g.GET("/backlog", h.backlog)
+	g.POST("/schedule", h.schedule)
+}
+
+// backlog: carga × capacidade por semana. Parâmetros: from (padrão: semana atual), weeks (padrão 4).
+func (h *PlanningHandler) backlog(c *gin.Context) {
+	from, err := dateParam(c, "from")
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	start := time.Now()
+	if from != nil {
+		start = *from
+	}
+	weeks := 4
+	if v := c.Query("weeks"); v != "" {
+		if weeks, err = strconv.Atoi(v); err != nil {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	report, err := h.service.Backlog(c.Request.Context(), start, weeks)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewBacklogReport(report))
+}
+
+type scheduleRequest struct {
+	From  *time.Time `json:"from"`                           // padrão: agora
+	Weeks int        `json:"weeks" binding:"omitempty,gt=0"` // padrão 2
+}
+
+// schedule propõe técnico e datas para o backlog; a proposta não altera as OS.
+func (h *PlanningHandler) schedule(c *gin.Context) {
+	var req scheduleRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+	from := time.Now()
+	if req.From != nil {
+		from = *req.From
+	}
+	if req.Weeks == 0 {
+		req.Weeks = 2
+	}
+
+	proposal, err := h.service.Schedule(c.Request.Context(), from, req.Weeks)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewScheduleProposal(proposal))
+}
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type ProductionHandler struct {
+	service *service.ProductionService
+}
+
+func NewProductionHandler(s *service.ProductionService) *ProductionHandler {
+	return &ProductionHandler{service: s}
+}
+
+func (h *ProductionHandler) RegisterRoutes(r gin.IRouter) {
+	r.POST("/production-counts", h.record)
+}
+
+type productionCountRequest struct {
+	AssetID     int64     `json:"asset_id" binding:"required,gt=0"`
+	PeriodStart time.Time `json:"period_start" binding:"required"`
+	PeriodEnd   time.Time `json:"period_end" binding:"required"`
+	TotalCount  int64     `json:"total_count" binding:"gte=0"`
+	GoodCount   int64     `json:"good_count" binding:"gte=0"`
+}
+
+// record recebe um lote de apontamentos (array JSON). O lote é atômico.
+func (h *ProductionHandler) record(c *gin.Context) {
+	var req []productionCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	counts := make([]domain.ProductionCount, 0, len(req))
+	for _, p := range req {
+		counts = append(counts, domain.ProductionCount{
+			AssetID:     p.AssetID,
+			PeriodStart: p.PeriodStart,
+			PeriodEnd:   p.PeriodEnd,
+			TotalCount:  p.TotalCount,
+			GoodCount:   p.GoodCount,
+		})
+	}
+
+	n, err := h.service.Record(c.Request.Context(), counts)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"inserted": n})
+}
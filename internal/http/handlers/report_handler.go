@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/export"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type ReportHandler struct {
+	service *service.ReportService
+}
+
+func NewReportHandler(s *service.ReportService) *ReportHandler {
+	return &ReportHandler{service: s}
+}
+
+func (h *ReportHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/reports")
+	g.GET("/downtime", h.downtime)
+	g.GET("/oee", h.oee)
+	g.GET("/pareto", h.pareto)
+	g.GET("/sla", h.sla)
+	g.GET("/sites", h.sites)
+}
+
+// reportScope aplica ?site=: vazio é o site do usuário, "all" soma todos os sites
+// e um id consulta aquele site. Fora do próprio site, só usuários corporativos (403).
+func reportScope(c *gin.Context) (context.Context, bool) {
+	ctx := c.Request.Context()
+	var err error
+	switch v := c.Query("site"); v {
+	case "":
+		return ctx, true
+	case "all":
+		ctx, err = tenant.AllSites(ctx)
+	default:
+		site, perr := strconv.ParseInt(v, 10, 64)
+		if perr != nil {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return nil, false
+		}
+		ctx, err = tenant.OnSite(ctx, site)
+	}
+	if err != nil {
+		response.HandleError(c, err)
+		return nil, false
+	}
+	return ctx, true
+}
+
+// downtime: relatório mensal de paradas. Sem from/to, cobre os últimos 12 meses.
+func (h *ReportHandler) downtime(c *gin.Context) {
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
+	ctx, ok := reportScope(c)
+	if !ok {
+		return
+	}
+	from, to, err := reportPeriod(c)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	rows, err := h.service.MonthlyDowntime(ctx, from, to)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	if format == export.FormatJSON {
+		c.JSON(http.StatusOK, v1.NewDowntimeReport(rows))
+		return
+	}
+
+	writeExport(c, format, "paradas-mensais", "Paradas por mês", export.DowntimeHeader,
+		func(write func([]string) error) error {
+			for i := range rows {
+				if err := write(export.DowntimeRow(&rows[i])); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+}
+
+// oee: Disponibilidade × Performance × Qualidade por ativo e linha.
+// Parâmetros: asset_id, location, from/to (padrão: últimos 7 dias), granularity=shift|day|week (padrão day).
+func (h *ReportHandler) oee(c *gin.Context) {
+	assetID, ok := assetIDQuery(c)
+	if !ok {
+		return
+	}
+	filter := domain.OEEFilter{AssetID: assetID, Location: c.Query("location")}
+	ctx, ok := reportScope(c)
+	if !ok {
+		return
+	}
+
+	granularity := domain.OEEGranularity(c.DefaultQuery("granularity", string(domain.OEEByDay)))
+	if !granularity.Valid() {
+		response.HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+
+	now := time.Now().In(plant.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	from, to := today.AddDate(0, 0, -6), today.AddDate(0, 0, 1)
+	f, err := dateParam(c, "from")
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	t, err := dateParam(c, "to")
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	if f != nil {
+		from = *f
+	}
+	if t != nil {
+		to = *t
+	}
+
+	report, err := h.service.OEE(ctx, from, to, granularity, filter)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewOEEReport(report))
+}
+
+// pareto: modos de falha das OS concluídas, ranqueados por sort=count|downtime (padrão count).
+// Aceita asset_id, location e from/to (data de fechamento; padrão: últimos 12 meses).
+func (h *ReportHandler) pareto(c *gin.Context) {
+	assetID, ok := assetIDQuery(c)
+	if !ok {
+		return
+	}
+	ctx, ok := reportScope(c)
+	if !ok {
+		return
+	}
+	from, to, err := reportPeriod(c)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	sortBy := domain.ParetoSort(c.DefaultQuery("sort", string(domain.ParetoByCount)))
+	report, err := h.service.Pareto(ctx, from, to, sortBy,
+		domain.ParetoFilter{AssetID: assetID, Location: c.Query("location")})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewParetoReport(report))
+}
+
+// sla: cumprimento de SLA (atendimento e conclusão) por mês de abertura. Sem from/to, últimos 12 meses.
+func (h *ReportHandler) sla(c *gin.Context) {
+	ctx, ok := reportScope(c)
+	if !ok {
+		return
+	}
+	from, to, err := reportPeriod(c)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	rows, err := h.service.MonthlySLA(ctx, from, to)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	if rows == nil {
+		rows = []domain.SLAReportRow{}
+	}
+	c.JSON(http.StatusOK, v1.NewSLAReport(rows))
+}
+
+// assetIDQuery lê o asset_id opcional da query; responde 400 se não for um inteiro positivo.
+func assetIDQuery(c *gin.Context) (int64, bool) {
+	v := c.Query("asset_id")
+	if v == "" {
+		return 0, true
+	}
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id <= 0 {
+		response.HandleError(c, domain.ErrInvalidInput)
+		return 0, false
+	}
+	return id, true
+}
+
+// sites: comparativo corporativo entre plantas (paradas e SLA). Sem from/to, últimos 12 meses.
+func (h *ReportHandler) sites(c *gin.Context) {
+	from, to, err := reportPeriod(c)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	rows, err := h.service.Sites(c.Request.Context(), from, to)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewSiteReport(rows))
+}
+
+// reportPeriod lê from/to; o padrão é do 1º dia de 11 meses atrás até o próximo mês.
+func reportPeriod(c *gin.Context) (time.Time, time.Time, error) {
+	now := time.Now().In(plant.Location())
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	from, to := monthStart.AddDate(0, -11, 0), monthStart.AddDate(0, 1, 0)
+
+	f, err := dateParam(c, "from")
+	if err != nil {
+		return from, to, err
+	}
+	t, err := dateParam(c, "to")
+	if err != nil {
+		return from, to, err
+	}
+	if f != nil {
+		from = *f
+	}
+	if t != nil {
+		to = *t
+	}
+	if !from.Before(to) {
+		return from, to, domain.ErrInvalidInput
+	}
+	return from, to, nil
+}
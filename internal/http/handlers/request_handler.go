@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+// MaintenanceRequestHandler expõe o portal de solicitações: o operador envia,
+// a manutenção faz a triagem e decide (aceitar, rejeitar ou juntar a uma OS).
+type MaintenanceRequestHandler struct {
+	service *service.MaintenanceRequestService
+}
+
+func NewMaintenanceRequestHandler(s *service.MaintenanceRequestService) *MaintenanceRequestHandler {
+	return &MaintenanceRequestHandler{service: s}
+}
+
+func (h *MaintenanceRequestHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/requests")
+	g.POST("", h.submit)
+	g.GET("", h.list)
+	g.GET("/:id", h.get)
+	g.GET("/:id/duplicates", h.duplicates)
+	g.POST("/:id/triage", h.triage)
+	g.POST("/:id/accept", h.accept)
+	g.POST("/:id/reject", h.reject)
+	g.POST("/:id/merge", h.merge)
+}
+
+type submitRequestRequest struct {
+	AssetID     int64      `json:"asset_id" binding:"required,gt=0"`
+	Title       string     `json:"title" binding:"required,min=3,max=200"`
+	Description string     `json:"description" binding:"max=2000"`
+	RequestedBy string     `json:"requested_by" binding:"max=128"`
+	BreakdownAt *time.Time `json:"breakdown_at"`
+}
+
+type triageRequest struct {
+	Title       *string `json:"title" binding:"omitempty,min=3,max=200"`
+	Description *string `json:"description" binding:"omitempty,max=2000"`
+	Note        string  `json:"note" binding:"max=2000"`
+}
+
+type acceptRequest struct {
+	Type             domain.WorkOrderType `json:"type" binding:"omitempty,oneof=corrective preventive condition improvement"`
+	Trade            string               `json:"trade" binding:"max=64"`
+	EstimatedMinutes *int64               `json:"estimated_minutes" binding:"omitempty,gt=0"`
+}
+
+type rejectRequest struct {
+	Reason string `json:"reason" binding:"required,max=2000"`
+}
+
+type mergeRequest struct {
+	WorkOrderID int64  `json:"work_order_id" binding:"required,gt=0"`
+	Note        string `json:"note" binding:"max=2000"`
+}
+
+// submit responde 201 com a solicitação e as OS em aberto que parecem duplicadas.
+func (h *MaintenanceRequestHandler) submit(c *gin.Context) {
+	var req submitRequestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	res, err := h.service.Submit(c.Request.Context(), &domain.MaintenanceRequest{
+		AssetID:     req.AssetID,
+		Title:       req.Title,
+		Description: req.Description,
+		RequestedBy: req.RequestedBy,
+		BreakdownAt: req.BreakdownAt,
+	})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewRequestSubmission(res))
+}
+
+func (h *MaintenanceRequestHandler) list(c *gin.Context) {
+	assetID, ok := assetIDQuery(c)
+	if !ok {
+		return
+	}
+	filter := domain.RequestFilter{Status: domain.RequestStatus(c.Query("status")), AssetID: assetID}
+	list, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewMaintenanceRequests(list))
+}
+
+func (h *MaintenanceRequestHandler) get(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	req, err := h.service.Get(c.Request.Context(), id)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewMaintenanceRequest(req))
+}
+
+func (h *MaintenanceRequestHandler) duplicates(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	list, err := h.service.Duplicates(c.Request.Context(), id)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewDuplicateCandidates(list))
+}
+
+// triage, reject e merge respondem 409 quando a solicitação não está no estado esperado.
+func (h *MaintenanceRequestHandler) triage(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req triageRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+	res, err := h.service.Triage(c.Request.Context(), id, service.TriagePatch{
+		Title:       req.Title,
+		Description: req.Description,
+		Note:        req.Note,
+	})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewMaintenanceRequest(res))
+}
+
+// accept responde 201 com a solicitação e a OS aberta a partir dela; o corpo é opcional.
+func (h *MaintenanceRequestHandler) accept(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req acceptRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+	}
+	res, order, err := h.service.Accept(c.Request.Context(), id, service.AcceptRequest{
+		Type:             req.Type,
+		Trade:            req.Trade,
+		EstimatedMinutes: req.EstimatedMinutes,
+	})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"request": v1.NewMaintenanceRequest(res), "work_order": v1.NewWorkOrder(order)})
+}
+
+func (h *MaintenanceRequestHandler) reject(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req rejectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	res, err := h.service.Reject(c.Request.Context(), id, req.Reason)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewMaintenanceRequest(res))
+}
+
+func (h *MaintenanceRequestHandler) merge(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req mergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+	res, err := h.service.Merge(c.Request.Context(), id, req.WorkOrderID, req.Note)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewMaintenanceRequest(res))
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/label"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+// ScanHandler gera as etiquetas dos ativos e resolve a leitura delas.
+// Com baseURL configurada, o QR code leva à URL de leitura (a câmera do celular
+// abre direto); sem ela, e sempre no Code 128, o código traz só a tag.
+type ScanHandler struct {
+	scan    *service.ScanService
+	assets  *service.AssetService
+	baseURL string
+}
+
+func NewScanHandler(scan *service.ScanService, assets *service.AssetService, baseURL string) *ScanHandler {
+	return &ScanHandler{scan: scan, assets: assets, baseURL: strings.TrimRight(baseURL, "/")}
+}
+
+func (h *ScanHandler) RegisterRoutes(r gin.IRouter) {
+	r.GET("/scan/:tag", h.resolve)
+	r.GET("/assets/:id/tag.png", h.tagImage)
+	r.GET("/assets/:id/tag.svg", h.tagImage)
+	r.GET("/assets/labels.pdf", h.labels)
+}
+
+const (
+	defaultTagSize = 256
+	maxTagSize     = 2048
+)
+
+func (h *ScanHandler) resolve(c *gin.Context) {
+	res, err := h.scan.Resolve(c.Request.Context(), c.Param("tag"))
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewScanResult(res))
+}
+
+// payload é o conteúdo codificado na etiqueta do ativo.
+func (h *ScanHandler) payload(kind label.Symbology, a *domain.Asset) string {
+	if kind == label.SymbologyQR && h.baseURL != "" {
+		return h.baseURL + "/v1/scan/" + a.Tag()
+	}
+	return a.Tag()
+}
+
+// tagImage responde PNG ou SVG conforme a extensão; ?symbology=qr|code128 e ?size= em pixels.
+func (h *ScanHandler) tagImage(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	kind, err := label.ParseSymbology(c.Query("symbology"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+	size := defaultTagSize
+	if v := c.Query("size"); v != "" {
+		if size, err = strconv.Atoi(v); err != nil || size < 64 || size > maxTagSize {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	a, err := h.assets.Get(c.Request.Context(), id)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	sym, err := label.Encode(kind, h.payload(kind, a))
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	contentType := "image/png"
+	if strings.HasSuffix(c.Request.URL.Path, ".svg") {
+		contentType = "image/svg+xml"
+		err = sym.WriteSVG(&buf, size)
+	} else {
+		err = sym.WritePNG(&buf, size)
+	}
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// labels gera a folha de etiquetas (A4, 3 × 8) dos ativos filtrados como em
+// GET /assets ou dos informados em ?ids=1,2,3.
+func (h *ScanHandler) labels(c *gin.Context) {
+	kind, err := label.ParseSymbology(c.Query("symbology"))
+	if err != nil {
+		response.HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+	ids, ok := idsQuery(c)
+	if !ok {
+		return
+	}
+	filter := domain.AssetFilter{
+		Location:    c.Query("location"),
+		Criticality: domain.Criticality(c.Query("criticality")),
+		Class:       strings.ToLower(c.Query("class")),
+		Attributes:  attributeQuery(c),
+	}
+
+	var labels []label.Label
+	err = h.assets.Stream(c.Request.Context(), filter, func(a *domain.Asset) error {
+		if ids != nil && !ids[a.ID] {
+			return nil
+		}
+		labels = append(labels, label.Label{
+			Payload: h.payload(kind, a),
+			Tag:     a.Tag(),
+			Title:   a.Name,
+			Lines:   []string{a.Location, strings.TrimSpace(a.Manufacturer + " " + a.Model), a.ExternalCode},
+		})
+		return nil
+	})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := label.WriteSheet(&buf, kind, labels); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="etiquetas-%s.pdf"`, kind))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// idsQuery lê ?ids=1,2,3; ausente retorna nil (sem filtro).
+func idsQuery(c *gin.Context) (map[int64]bool, bool) {
+	v := c.Query("ids")
+	if v == "" {
+		return nil, true
+	}
+	ids := map[int64]bool{}
+	for _, part := range strings.Split(v, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+		if err != nil || id <= 0 {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return nil, false
+		}
+		ids[id] = true
+	}
+	return ids, true
+}
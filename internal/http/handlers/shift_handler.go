@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type ShiftHandler struct {
+	service *service.ShiftService
+}
+
+func NewShiftHandler(s *service.ShiftService) *ShiftHandler {
+	return &ShiftHandler{service: s}
+}
+
+func (h *ShiftHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/shifts")
+	g.POST("", h.create)
+	g.GET("", h.list)
+	g.DELETE("/:id", h.delete)
+}
+
+type createShiftRequest struct {
+	Name         string `json:"name" binding:"required,max=64"`
+	Location     string `json:"location" binding:"max=128"` // vazio = planta toda
+	StartTime    string `json:"start_time" binding:"required"`
+	EndTime      string `json:"end_time" binding:"required"`
+	Weekdays     []int  `json:"weekdays" binding:"required,min=1,dive,min=0,max=6"`
+	BreakMinutes int    `json:"break_minutes" binding:"gte=0"`
+}
+
+func (h *ShiftHandler) create(c *gin.Context) {
+	var req createShiftRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	s := domain.Shift{
+		Name:         req.Name,
+		Location:     req.Location,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		Weekdays:     req.Weekdays,
+		BreakMinutes: req.BreakMinutes,
+	}
+	if err := h.service.Create(c.Request.Context(), &s); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewShift(&s))
+}
+
+func (h *ShiftHandler) list(c *gin.Context) {
+	shifts, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewShifts(shifts))
+}
+
+func (h *ShiftHandler) delete(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	if err := h.service.Delete(c.Request.Context(), id); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
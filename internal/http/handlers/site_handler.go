@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type SiteHandler struct {
+	service *service.SiteService
+}
+
+func NewSiteHandler(s *service.SiteService) *SiteHandler {
+	return &SiteHandler{service: s}
+}
+
+func (h *SiteHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/sites")
+	g.POST("", h.create)
+	g.GET("", h.list)
+}
+
+type createSiteRequest struct {
+	Code string `json:"code" binding:"required,max=16"`
+	Name string `json:"name" binding:"required,max=128"`
+}
+
+func (h *SiteHandler) create(c *gin.Context) {
+	var req createSiteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	site := domain.Site{Code: req.Code, Name: req.Name}
+	if err := h.service.Create(c.Request.Context(), &site); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewSite(&site))
+}
+
+func (h *SiteHandler) list(c *gin.Context) {
+	list, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewSites(list))
+}
@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type SLAHandler struct {
+	service *service.SLAService
+}
+
+func NewSLAHandler(s *service.SLAService) *SLAHandler {
+	return &SLAHandler{service: s}
+}
+
+func (h *SLAHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/sla-policies")
+	g.GET("", h.list)
+	g.PUT("", h.replace)
+}
+
+type slaPolicyRequest struct {
+	Criticality       domain.Criticality   `json:"criticality" binding:"required,oneof=A B C"`
+	Type              domain.WorkOrderType `json:"type" binding:"required,oneof=corrective preventive condition improvement"`
+	Priority          domain.Priority      `json:"priority" binding:"required,oneof=urgent high normal low"`
+	ResponseMinutes   int64                `json:"response_minutes" binding:"required,gt=0"`
+	ResolutionMinutes int64                `json:"resolution_minutes" binding:"required,gt=0"`
+}
+
+func (h *SLAHandler) list(c *gin.Context) {
+	policies, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewSLAPolicies(policies))
+}
+
+// replace recebe a matriz inteira (array); células ausentes ficam sem SLA.
+func (h *SLAHandler) replace(c *gin.Context) {
+	var req []slaPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	policies := make([]domain.SLAPolicy, 0, len(req))
+	for _, p := range req {
+		policies = append(policies, domain.SLAPolicy{
+			Criticality:       p.Criticality,
+			Type:              p.Type,
+			Priority:          p.Priority,
+			ResponseMinutes:   p.ResponseMinutes,
+			ResolutionMinutes: p.ResolutionMinutes,
+		})
+	}
+	if err := h.service.Replace(c.Request.Context(), policies); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewSLAPolicies(policies))
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type SparePartHandler struct {
+	service *service.SparePartService
+}
+
+func NewSparePartHandler(s *service.SparePartService) *SparePartHandler {
+	return &SparePartHandler{service: s}
+}
+
+func (h *SparePartHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/spare-parts")
+	g.POST("", h.create)
+	g.GET("", h.list)
+	g.PATCH("/:id", h.update)
+	g.POST("/:id/stock", h.adjustStock)
+}
+
+type createSparePartRequest struct {
+	Code        string  `json:"code" binding:"required,max=32"`
+	Name        string  `json:"name" binding:"required,max=128"`
+	Unit        string  `json:"unit" binding:"omitempty,max=16"`
+	Quantity    float64 `json:"quantity" binding:"gte=0"`
+	MinQuantity float64 `json:"min_quantity" binding:"gte=0"`
+}
+
+type updateSparePartRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,max=128"`
+	Unit        *string  `json:"unit" binding:"omitempty,max=16"`
+	MinQuantity *float64 `json:"min_quantity" binding:"omitempty,gte=0"`
+}
+
+// stockRequest: delta positivo é entrada, negativo é saída (consumo em OS, perda...).
+type stockRequest struct {
+	Delta float64 `json:"delta" binding:"required"`
+}
+
+func (h *SparePartHandler) create(c *gin.Context) {
+	var req createSparePartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	p := domain.SparePart{Code: req.Code, Name: req.Name, Unit: req.Unit, Quantity: req.Quantity, MinQuantity: req.MinQuantity}
+	if err := h.service.Create(c.Request.Context(), &p); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewSparePart(&p))
+}
+
+func (h *SparePartHandler) update(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req updateSparePartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	p, err := h.service.Update(c.Request.Context(), id, service.SparePartPatch{Name: req.Name, Unit: req.Unit, MinQuantity: req.MinQuantity})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewSparePart(p))
+}
+
+func (h *SparePartHandler) adjustStock(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req stockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	p, err := h.service.AdjustStock(c.Request.Context(), id, req.Delta)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewSparePart(p))
+}
+
+func (h *SparePartHandler) list(c *gin.Context) {
+	parts, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewSpareParts(parts))
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type SyncHandler struct {
+	service *service.SyncService
+}
+
+func NewSyncHandler(s *service.SyncService) *SyncHandler {
+	return &SyncHandler{service: s}
+}
+
+func (h *SyncHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/sync")
+	g.GET("/changes", h.changes)
+	g.POST("/push", h.push)
+}
+
+// changes devolve o delta desde o token since (vazio na carga inicial); o app
+// pede de novo com next enquanto has_more for true.
+func (h *SyncHandler) changes(c *gin.Context) {
+	var since int64
+	if v := c.Query("since"); v != "" {
+		var err error
+		if since, err = strconv.ParseInt(v, 10, 64); err != nil || since < 0 {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+	}
+	limit := 0
+	if v := c.Query("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit <= 0 {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+	}
+
+	page, err := h.service.Changes(c.Request.Context(), since, limit)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewSyncPage(page))
+}
+
+type syncPushRequest struct {
+	Mutations []syncMutationRequest `json:"mutations" binding:"required,min=1,max=100,dive"`
+}
+
+// syncMutationRequest: data segue o corpo da rota equivalente (abertura de OS,
+// mudança de status ou apontamento do passo).
+type syncMutationRequest struct {
+	ClientID     string            `json:"client_id" binding:"required,max=64"`
+	Entity       domain.SyncEntity `json:"entity" binding:"required,oneof=work_order checklist_item"`
+	Op           service.SyncOp    `json:"op" binding:"required,oneof=create transition update"`
+	ID           int64             `json:"id" binding:"omitempty,gt=0"`
+	Step         int               `json:"step" binding:"omitempty,gt=0"`
+	BaseRevision int64             `json:"base_revision" binding:"gte=0"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+	Data         json.RawMessage   `json:"data" binding:"required"`
+}
+
+// push aplica o lote feito offline (até 100 alterações) e responde 200 com um
+// resultado por alteração, na mesma ordem: applied, conflict (com a versão do
+// servidor) ou rejected (com o erro que a rota equivalente teria devolvido).
+func (h *SyncHandler) push(c *gin.Context) {
+	var req syncPushRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	results := make([]v1.SyncResult, len(req.Mutations))
+	var valid []service.SyncMutation
+	var pos []int
+	for i, m := range req.Mutations {
+		mut, err := syncMutation(m)
+		if err != nil {
+			results[i] = syncResult(service.SyncResult{ClientID: m.ClientID, Status: service.SyncRejected, ID: m.ID, Err: err})
+			continue
+		}
+		valid = append(valid, mut)
+		pos = append(pos, i)
+	}
+	for i, res := range h.service.Push(c.Request.Context(), valid) {
+		results[pos[i]] = syncResult(res)
+	}
+	c.JSON(http.StatusOK, gin.H{"results": results})
+}
+
+func syncMutation(m syncMutationRequest) (service.SyncMutation, error) {
+	mut := service.SyncMutation{
+		ClientID: m.ClientID, Entity: m.Entity, Op: m.Op,
+		ID: m.ID, Step: m.Step, BaseRevision: m.BaseRevision, UpdatedAt: m.UpdatedAt,
+	}
+	invalid := func(err error) (service.SyncMutation, error) {
+		return mut, fmt.Errorf("%w: %w", domain.ErrInvalidInput, err)
+	}
+
+	switch {
+	case m.Entity == domain.SyncWorkOrder && m.Op == service.SyncCreate:
+		var req createWorkOrderRequest
+		if err := binding.JSON.BindBody(m.Data, &req); err != nil {
+			return invalid(err)
+		}
+		mut.WorkOrder = &domain.WorkOrder{
+			AssetID:          req.AssetID,
+			Type:             req.Type,
+			Status:           req.Status,
+			Title:            req.Title,
+			Description:      req.Description,
+			BreakdownAt:      req.BreakdownAt,
+			Trade:            req.Trade,
+			EstimatedMinutes: req.EstimatedMinutes,
+			JobPlanID:        req.JobPlanID,
+		}
+	case m.Entity == domain.SyncWorkOrder && m.Op == service.SyncTransition && m.ID > 0:
+		var req transitionRequest
+		if err := binding.JSON.BindBody(m.Data, &req); err != nil {
+			return invalid(err)
+		}
+		mut.Transition = &service.TransitionRequest{
+			Status:          req.Status,
+			FailureModeID:   req.FailureModeID,
+			FailureCauseID:  req.FailureCauseID,
+			FailureActionID: req.FailureActionID,
+			Cause:           req.Cause,
+			Solution:        req.Solution,
+		}
+	case m.Entity == domain.SyncChecklistItem && m.Op == service.SyncUpdate && m.ID > 0 && m.Step > 0:
+		var req checklistStepRequest
+		if err := binding.JSON.BindBody(m.Data, &req); err != nil {
+			return invalid(err)
+		}
+		mut.Checklist = &service.ChecklistUpdate{Done: *req.Done, Value: req.Value, Note: req.Note}
+	default:
+		return mut, domain.ErrInvalidInput
+	}
+	return mut, nil
+}
+
+func syncResult(r service.SyncResult) v1.SyncResult {
+	out := v1.SyncResult{ClientID: r.ClientID, Status: string(r.Status), ID: r.ID}
+	if r.Current != nil {
+		ch := v1.NewSyncChange(r.Current)
+		out.Current = &ch
+	}
+	if r.Err != nil {
+		code, msg := response.Describe(r.Err)
+		out.Error = &v1.SyncError{Error: msg, Code: code}
+	}
+	return out
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type TechnicianHandler struct {
+	service *service.TechnicianService
+}
+
+func NewTechnicianHandler(s *service.TechnicianService) *TechnicianHandler {
+	return &TechnicianHandler{service: s}
+}
+
+func (h *TechnicianHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/technicians")
+	g.POST("", h.create)
+	g.GET("", h.list)
+	g.PATCH("/:id", h.update)
+}
+
+// createTechnicianRequest: user_id vincula o técnico ao usuário que entra no
+// app offline (a sincronização dele fica nas OS atribuídas ao técnico).
+type createTechnicianRequest struct {
+	Name    string   `json:"name" binding:"required,max=128"`
+	Skills  []string `json:"skills" binding:"dive,max=64"`
+	ShiftID int64    `json:"shift_id" binding:"required,gt=0"`
+	UserID  *int64   `json:"user_id" binding:"omitempty,gt=0"`
+}
+
+// updateTechnicianRequest: user_id 0 desfaz o vínculo com o usuário.
+type updateTechnicianRequest struct {
+	Name    *string  `json:"name" binding:"omitempty,max=128"`
+	Skills  []string `json:"skills" binding:"omitempty,dive,max=64"`
+	ShiftID *int64   `json:"shift_id" binding:"omitempty,gt=0"`
+	Active  *bool    `json:"active"`
+	UserID  *int64   `json:"user_id" binding:"omitempty,gte=0"`
+}
+
+func (h *TechnicianHandler) create(c *gin.Context) {
+	var req createTechnicianRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	t := domain.Technician{Name: req.Name, Skills: req.Skills, ShiftID: req.ShiftID, UserID: req.UserID}
+	if err := h.service.Create(c.Request.Context(), &t); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewTechnician(&t))
+}
+
+func (h *TechnicianHandler) update(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req updateTechnicianRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	t, err := h.service.Update(c.Request.Context(), id, service.TechnicianPatch{
+		Name: req.Name, Skills: req.Skills, ShiftID: req.ShiftID, Active: req.Active, UserID: req.UserID,
+	})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewTechnician(t))
+}
+
+func (h *TechnicianHandler) list(c *gin.Context) {
+	list, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewTechnicians(list))
+}
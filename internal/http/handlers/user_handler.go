@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+type UserHandler struct {
+	service *service.UserService
+}
+
+func NewUserHandler(s *service.UserService) *UserHandler {
+	return &UserHandler{service: s}
+}
+
+func (h *UserHandler) RegisterRoutes(r gin.IRouter) {
+	g := r.Group("/users")
+	g.POST("", h.create)
+	g.GET("", h.list)
+	g.PATCH("/:id", h.update)
+}
+
+type createUserRequest struct {
+	Name        string                                                    `json:"name" binding:"required,max=128"`
+	Email       string                                                    `json:"email" binding:"omitempty,email"`
+	Phone       string                                                    `json:"phone" binding:"omitempty,e164"`
+	ChatID      string                                                    `json:"chat_id" binding:"omitempty,max=128"`
+	SiteID      int64                                                     `json:"site_id" binding:"omitempty,min=1"`
+	Corporate   bool                                                      `json:"corporate"`
+	Preferences map[domain.NotificationEvent][]domain.NotificationChannel `json:"preferences"`
+}
+
+type updateUserRequest struct {
+	Name        *string                                                   `json:"name" binding:"omitempty,max=128"`
+	Email       *string                                                   `json:"email" binding:"omitempty,email"`
+	Phone       *string                                                   `json:"phone" binding:"omitempty,e164"`
+	ChatID      *string                                                   `json:"chat_id" binding:"omitempty,max=128"`
+	Active      *bool                                                     `json:"active"`
+	Corporate   *bool                                                     `json:"corporate"`
+	Preferences map[domain.NotificationEvent][]domain.NotificationChannel `json:"preferences"`
+}
+
+func (h *UserHandler) create(c *gin.Context) {
+	var req createUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	u := domain.User{
+		Name: req.Name, Email: req.Email, Phone: req.Phone, ChatID: req.ChatID,
+		SiteID: req.SiteID, Corporate: req.Corporate, Preferences: req.Preferences,
+	}
+	if err := h.service.Create(c.Request.Context(), &u); err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, v1.NewUser(&u))
+}
+
+func (h *UserHandler) update(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req updateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	u, err := h.service.Update(c.Request.Context(), id, service.UserPatch{
+		Name: req.Name, Email: req.Email, Phone: req.Phone, ChatID: req.ChatID,
+		Active: req.Active, Corporate: req.Corporate, Preferences: req.Preferences,
+	})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewUser(u))
+}
+
+func (h *UserHandler) list(c *gin.Context) {
+	users, err := h.service.List(c.Request.Context())
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewUsers(users))
+}
@@ -2,9 +2,13 @@ package handlers
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/export"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
 )
@@ -17,18 +21,27 @@ func NewWorkOrderHandler(s *service.WorkOrderService) *WorkOrderHandler {
 	return &WorkOrderHandler{service: s}
 }
 
-func (h *WorkOrderHandler) RegisterRoutes(r *gin.Engine) {
+func (h *WorkOrderHandler) RegisterRoutes(r gin.IRouter) {
 	g := r.Group("/work-orders")
 	g.POST("", h.create)
 	g.GET("", h.list)
+	g.POST("/:id/status", h.transition)
+	g.PUT("/:id/assignee", h.assign)
+	g.GET("/:id/checklist", h.checklist)
+	g.PATCH("/:id/checklist/:step", h.updateChecklistStep)
 }
 
 type createWorkOrderRequest struct {
-	AssetID     int64                  `json:"asset_id" binding:"required,gt=0"`
-	Type        domain.WorkOrderType   `json:"type" binding:"omitempty,oneof=corrective preventive condition improvement"`
-	Status      domain.WorkOrderStatus `json:"status" binding:"omitempty,oneof=open in_progress done canceled"`
-	Title       string                 `json:"title" binding:"required,min=3"`
-	Description string                 `json:"description"`
+	AssetID          int64                  `json:"asset_id" binding:"required,gt=0"`
+	Type             domain.WorkOrderType   `json:"type" binding:"omitempty,oneof=corrective preventive condition improvement"`
+	Status           domain.WorkOrderStatus `json:"status" binding:"omitempty,oneof=open in_progress done canceled"`
+	Title            string                 `json:"title" binding:"required,min=3"`
+	Description      string                 `json:"description"`
+	BreakdownAt      *time.Time             `json:"breakdown_at"`
+	Trade            string                 `json:"trade" binding:"max=64"`
+	EstimatedMinutes *int64                 `json:"estimated_minutes" binding:"omitempty,gt=0"`
+	JobPlanID        *int64                 `json:"job_plan_id" binding:"omitempty,gt=0"`
+	AssigneeID       *int64                 `json:"assignee_id" binding:"omitempty,gt=0"`
 }
 
 func (h *WorkOrderHandler) create(c *gin.Context) {
@@ -39,26 +52,194 @@ func (h *WorkOrderHandler) create(c *gin.Context) {
 	}
 
 	o := domain.WorkOrder{
-		AssetID:     req.AssetID,
-		Type:        req.Type,
-		Status:      req.Status,
-		Title:       req.Title,
-		Description: req.Description,
+		AssetID:          req.AssetID,
+		Type:             req.Type,
+		Status:           req.Status,
+		Title:            req.Title,
+		Description:      req.Description,
+		BreakdownAt:      req.BreakdownAt,
+		Trade:            req.Trade,
+		EstimatedMinutes: req.EstimatedMinutes,
+		JobPlanID:        req.JobPlanID,
+		AssigneeID:       req.AssigneeID,
 	}
 
-	if err := h.service.Create(&o); err != nil {
+	if err := h.service.Create(c.Request.Context(), &o); err != nil {
 		response.HandleError(c, err)
 		return
 	}
-	c.JSON(http.StatusCreated, o)
+	c.JSON(http.StatusCreated, v1.NewWorkOrder(&o))
+}
+
+// transitionRequest: códigos de falha, causa e solução só valem no fechamento (done).
+type transitionRequest struct {
+	Status          domain.WorkOrderStatus `json:"status" binding:"required,oneof=open in_progress done canceled"`
+	FailureModeID   *int64                 `json:"failure_mode_id" binding:"omitempty,gt=0"`
+	FailureCauseID  *int64                 `json:"failure_cause_id" binding:"omitempty,gt=0"`
+	FailureActionID *int64                 `json:"failure_action_id" binding:"omitempty,gt=0"`
+	Cause           string                 `json:"cause" binding:"max=2000"`
+	Solution        string                 `json:"solution" binding:"max=2000"`
+}
+
+// transition muda o status: 409 para transição inválida e 412 ao concluir corretiva
+// de ativo criticidade A sem modo de falha.
+func (h *WorkOrderHandler) transition(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req transitionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	o, err := h.service.Transition(c.Request.Context(), id, service.TransitionRequest{
+		Status:          req.Status,
+		FailureModeID:   req.FailureModeID,
+		FailureCauseID:  req.FailureCauseID,
+		FailureActionID: req.FailureActionID,
+		Cause:           req.Cause,
+		Solution:        req.Solution,
+	})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewWorkOrder(o))
+}
+
+type assignRequest struct {
+	TechnicianID *int64 `json:"technician_id" binding:"omitempty,gt=0"`
+}
+
+// assign troca o técnico responsável (technician_id null retira): 409 com a OS
+// fechada e 400 para técnico inativo ou de outro site.
+func (h *WorkOrderHandler) assign(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	var req assignRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	o, err := h.service.Assign(c.Request.Context(), id, req.TechnicianID)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewWorkOrder(o))
+}
+
+func (h *WorkOrderHandler) checklist(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	items, err := h.service.Checklist(c.Request.Context(), id)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewChecklist(items))
+}
+
+type checklistStepRequest struct {
+	Done  *bool    `json:"done" binding:"required"`
+	Value *float64 `json:"value"`
+	Note  string   `json:"note" binding:"max=2000"`
+}
+
+// updateChecklistStep aponta um passo: 400 ao concluir medição sem valor, 409 com a OS fechada.
+// Medição fora da tolerância pode abrir uma corretiva (follow_up_id na resposta).
+func (h *WorkOrderHandler) updateChecklistStep(c *gin.Context) {
+	id, ok := idParam(c)
+	if !ok {
+		return
+	}
+	step, err := strconv.Atoi(c.Param("step"))
+	if err != nil || step <= 0 {
+		response.HandleError(c, domain.ErrInvalidInput)
+		return
+	}
+	var req checklistStepRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.ValidationError(c, err)
+		return
+	}
+
+	item, err := h.service.UpdateChecklistStep(c.Request.Context(), id, step, service.ChecklistUpdate{
+		Done: *req.Done, Value: req.Value, Note: req.Note,
+	})
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, v1.NewChecklistItem(item))
 }
 
 func (h *WorkOrderHandler) list(c *gin.Context) {
-	status := c.Query("status")
-	orders, err := h.service.List(status)
+	format, ok := exportFormat(c)
+	if !ok {
+		return
+	}
+	filter, err := workOrderFilter(c)
+	if err != nil {
+		response.HandleError(c, err)
+		return
+	}
+
+	if format != export.FormatJSON {
+		writeExport(c, format, "ordens-de-servico", "Ordens de serviço", export.WorkOrderHeader,
+			func(write func([]string) error) error {
+				return h.service.Stream(c.Request.Context(), filter, func(o *domain.WorkOrder) error {
+					return write(export.WorkOrderRow(o))
+				})
+			})
+		return
+	}
+
+	orders, err := h.service.List(c.Request.Context(), filter)
 	if err != nil {
 		response.HandleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, orders)
+	c.JSON(http.StatusOK, v1.NewWorkOrders(orders))
+}
+
+// workOrderFilter lê status, type, asset_id, overdue (SLA vencido agora) e o período
+// (from/to sobre a data de abertura).
+func workOrderFilter(c *gin.Context) (domain.WorkOrderFilter, error) {
+	f := domain.WorkOrderFilter{
+		Status: domain.WorkOrderStatus(c.Query("status")),
+		Type:   domain.WorkOrderType(c.Query("type")),
+	}
+	if v := c.Query("asset_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return f, domain.ErrInvalidInput
+		}
+		f.AssetID = id
+	}
+	if v := c.Query("overdue"); v != "" {
+		overdue, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, domain.ErrInvalidInput
+		}
+		if overdue {
+			now := time.Now()
+			f.OverdueAt = &now
+		}
+	}
+	var err error
+	if f.From, err = dateParam(c, "from"); err != nil {
+		return f, err
+	}
+	if f.To, err = dateParam(c, "to"); err != nil {
+		return f, err
+	}
+	return f, nil
 }
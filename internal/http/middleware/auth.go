@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/auth"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TokenVerifier confere o token Bearer (auth.Signer em produção).
+type TokenVerifier interface {
+	Verify(token string) (*auth.Claims, error)
+}
+
+// AuthMiddleware exige "Authorization: Bearer <token>" e coloca o principal no
+// contexto da requisição; daí em diante os repositórios só enxergam o site dele.
+// Sem token, ou com token inválido/expirado, responde 401.
+func AuthMiddleware(v TokenVerifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			response.HandleError(c, domain.ErrUnauthorized)
+			return
+		}
+		claims, err := v.Verify(strings.TrimSpace(token))
+		if err != nil {
+			response.HandleError(c, err)
+			return
+		}
+
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(
+			attribute.Int64("enduser.id", claims.UserID),
+			attribute.Int64("site.id", claims.SiteID),
+		)
+		c.Set("user_id", claims.UserID)
+		c.Request = c.Request.WithContext(tenant.WithPrincipal(c.Request.Context(), claims.Principal()))
+		c.Next()
+	}
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DeprecatedMiddleware marca as rotas antigas (sem versão) com os headers
+// Deprecation (RFC 9745), Sunset (RFC 8594) e Link para a mesma rota sob
+// successor (ex: /v1), e registra no span para medir quem ainda as usa.
+func DeprecatedMiddleware(since, sunset time.Time, successor string) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", since.Unix())
+	sunsetAt := sunset.UTC().Format(http.TimeFormat)
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		h.Set("Deprecation", deprecation)
+		h.Set("Sunset", sunsetAt)
+		h.Add("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successor, c.Request.URL.Path))
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.Bool("http.route.deprecated", true))
+		c.Next()
+	}
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	log "github.com/sirupsen/logrus"
+)
+
+// IdempotencyStore reserva e grava as respostas por chave (service.IdempotencyService em produção).
+type IdempotencyStore interface {
+	Begin(ctx context.Context, userID int64, key, hash string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, rec *domain.IdempotencyRecord) error
+	Release(ctx context.Context, userID int64, key string) error
+}
+
+const maxIdempotencyKeyLen = 255
+
+// IdempotencyMiddleware atende o header Idempotency-Key nos POST: a primeira
+// requisição executa e tem a resposta gravada; repetir a chave com o mesmo
+// método, caminho (sem a versão) e corpo devolve essa resposta (com Idempotent-Replayed: true)
+// sem executar de novo, e com outra requisição responde 422. As chaves são por
+// usuário, então precisa rodar depois do AuthMiddleware. Respostas 5xx não são
+// gravadas: a chave é liberada para o cliente tentar outra vez.
+func IdempotencyMiddleware(s IdempotencyStore) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("Idempotency-Key")
+		p, ok := tenant.PrincipalFrom(c.Request.Context())
+		if c.Request.Method != http.MethodPost || key == "" || !ok {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			response.HandleError(c, fmt.Errorf("%w: %w", domain.ErrInvalidInput, err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.New()
+		// Sem a versão no caminho: a repetição pelo alias antigo (ou por /v1) é a mesma requisição.
+		sum.Write([]byte(c.Request.Method + " " + apiVersion.ReplaceAllString(c.Request.URL.RequestURI(), "/") + "\n"))
+		sum.Write(body)
+		hash := hex.EncodeToString(sum.Sum(nil))
+
+		ctx := c.Request.Context()
+		saved, err := s.Begin(ctx, p.UserID, key, hash)
+		if err != nil {
+			response.HandleError(c, err)
+			return
+		}
+		if saved != nil {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(saved.Status, saved.ContentType, saved.Body)
+			c.Abort()
+			return
+		}
+
+		w := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		// A gravação não depende do cliente continuar conectado.
+		bg := context.WithoutCancel(ctx)
+		completed := false
+		defer func() {
+			if completed {
+				return
+			}
+			if err := s.Release(bg, p.UserID, key); err != nil {
+				log.WithError(err).Warn("idempotency key release failed")
+			}
+		}()
+
+		c.Next()
+
+		if w.Status() >= http.StatusInternalServerError {
+			return
+		}
+		rec := &domain.IdempotencyRecord{
+			UserID:      p.UserID,
+			Key:         key,
+			RequestHash: hash,
+			Status:      w.Status(),
+			ContentType: w.Header().Get("Content-Type"),
+			Body:        w.body.Bytes(),
+		}
+		if err := s.Complete(bg, rec); err != nil {
+			log.WithError(err).Warn("idempotency response not saved")
+			return
+		}
+		completed = true
+	}
+}
+
+// recordingWriter repassa a resposta ao cliente e guarda uma cópia do corpo.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
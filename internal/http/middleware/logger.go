@@ -6,28 +6,51 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// RequestIDHeader é o header usado para correlacionar requisições entre serviços.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLen limita IDs vindos de fora para não poluir logs.
+const maxRequestIDLen = 128
+
 // LoggerMiddleware cria logs estruturados em JSON com ID de requisição.
+//
+// Um X-Request-ID recebido (ex: do load balancer) é reaproveitado; caso
+// contrário, um UUID novo é gerado. O ID sempre volta no header da resposta.
 func LoggerMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		start := time.Now()
-		requestID := uuid.New().String()
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.New().String()
+		}
 
 		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		span := trace.SpanFromContext(c.Request.Context())
+		span.SetAttributes(attribute.String("http.request_id", requestID))
 
 		c.Next() // processa a request
 
 		latency := time.Since(start)
 
-		entry := log.WithFields(log.Fields{
+		fields := log.Fields{
 			"request_id": requestID,
 			"status":     c.Writer.Status(),
 			"method":     c.Request.Method,
 			"path":       c.Request.URL.Path,
 			"ip":         c.ClientIP(),
 			"latency":    latency,
-		})
+		}
+		if sc := span.SpanContext(); sc.IsValid() {
+			fields["trace_id"] = sc.TraceID().String()
+			fields["span_id"] = sc.SpanID().String()
+		}
+		entry := log.WithFields(fields)
 
 		if len(c.Errors) > 0 {
 			entry.WithField("errors", c.Errors.String()).Error("request failed")
@@ -36,3 +59,16 @@ func LoggerMiddleware() gin.HandlerFunc {
 		}
 	}
 }
+
+// validRequestID aceita apenas IDs curtos com caracteres ASCII visíveis.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,387 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/auth"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/middleware"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func setupRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	r := gin.New()
+	r.Use(middleware.TracingMiddleware(), middleware.LoggerMiddleware())
+	r.GET("/ping", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+	return r
+}
+
+func TestLogger_RequestIDIsEchoed(t *testing.T) {
+	r := setupRouter()
+
+	tests := []struct {
+		name     string
+		incoming string
+		keep     bool
+	}{
+		{name: "honours incoming id", incoming: "lb-1234", keep: true},
+		{name: "generates when missing", incoming: "", keep: false},
+		{name: "replaces invalid id", incoming: "com espaço", keep: false},
+		{name: "replaces oversized id", incoming: strings.Repeat("x", 200), keep: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+			if tt.incoming != "" {
+				req.Header.Set(middleware.RequestIDHeader, tt.incoming)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			got := w.Header().Get(middleware.RequestIDHeader)
+			if got == "" {
+				t.Fatalf("expected %s header in response", middleware.RequestIDHeader)
+			}
+			if tt.keep && got != tt.incoming {
+				t.Fatalf("expected request id %q, got %q", tt.incoming, got)
+			}
+			if !tt.keep && got == tt.incoming {
+				t.Fatalf("expected a generated request id, got %q", got)
+			}
+		})
+	}
+}
+
+func TestTracing_TraceparentIsPropagated(t *testing.T) {
+	r := setupRouter()
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	tp := w.Header().Get("traceparent")
+	if !strings.Contains(tp, traceID) {
+		t.Fatalf("expected traceparent with trace id %s, got %q", traceID, tp)
+	}
+}
+
+func TestAuth_RequiresValidToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	signer, err := auth.NewSigner([]byte(strings.Repeat("s", 32)))
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	r := gin.New()
+	r.Use(middleware.AuthMiddleware(signer))
+	r.GET("/me", func(c *gin.Context) {
+		p, _ := tenant.PrincipalFrom(c.Request.Context())
+		site, _ := tenant.Site(c.Request.Context())
+		c.JSON(http.StatusOK, gin.H{"user": p.UserID, "site": site})
+	})
+
+	valid, err := signer.Issue(&domain.User{ID: 7, SiteID: 2}, time.Hour)
+	if err != nil {
+		t.Fatalf("issue: %v", err)
+	}
+	expired, _ := signer.Issue(&domain.User{ID: 7, SiteID: 2}, time.Nanosecond)
+	time.Sleep(time.Second)
+	other, _ := auth.NewSigner([]byte(strings.Repeat("x", 32)))
+	forged, _ := other.Issue(&domain.User{ID: 7, SiteID: 1, Corporate: true}, time.Hour)
+
+	tests := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "missing", header: "", want: http.StatusUnauthorized},
+		{name: "not bearer", header: "Basic abc", want: http.StatusUnauthorized},
+		{name: "forged", header: "Bearer " + forged, want: http.StatusUnauthorized},
+		{name: "expired", header: "Bearer " + expired, want: http.StatusUnauthorized},
+		{name: "valid", header: "Bearer " + valid, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d; body=%s", w.Code, tt.want, w.Body.String())
+			}
+			if tt.want == http.StatusOK && w.Body.String() != `{"site":2,"user":7}` {
+				t.Fatalf("principal = %s, want user 7 on site 2", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestDeprecated_LegacyRoutesCarryHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	since := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC)
+	register := func(r gin.IRouter) {
+		r.GET("/assets/:id", func(c *gin.Context) { c.String(http.StatusOK, c.Param("id")) })
+	}
+	r := gin.New()
+	register(r.Group("/v1"))
+	register(r.Group("", middleware.DeprecatedMiddleware(since, sunset, "/v1")))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/assets/7", nil))
+	if w.Code != http.StatusOK || w.Body.String() != "7" {
+		t.Fatalf("legacy route: %d %s", w.Code, w.Body.String())
+	}
+	for header, want := range map[string]string{
+		"Deprecation": "@1792368000",
+		"Sunset":      "Fri, 30 Apr 2027 00:00:00 GMT",
+		"Link":        `</v1/assets/7>; rel="successor-version"`,
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s = %q, want %q", header, got, want)
+		}
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/assets/7", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Deprecation") != "" {
+		t.Fatalf("versioned route should not be deprecated: %d %v", w.Code, w.Header())
+	}
+}
+
+func TestIdempotency_ReplaysAndSerializes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	svc := service.NewIdempotencyService(memory.NewIdempotencyMemoryRepo(),
+		service.WithIdempotencyWait(2*time.Second, 5*time.Millisecond))
+	var created, failures atomic.Int64
+	failures.Store(1)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenant.WithPrincipal(c.Request.Context(), tenant.Principal{UserID: 1, SiteID: 1}))
+	}, middleware.IdempotencyMiddleware(svc))
+	r.POST("/work-orders", func(c *gin.Context) {
+		time.Sleep(20 * time.Millisecond)
+		c.JSON(http.StatusCreated, gin.H{"id": created.Add(1)})
+	})
+	r.POST("/v1/work-orders", func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{"id": created.Add(1)})
+	})
+	r.POST("/flaky", func(c *gin.Context) {
+		if failures.Add(-1) >= 0 {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "falhou"})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{"ok": true})
+	})
+
+	post := func(path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Duplicatas simultâneas: só uma executa, as outras esperam e recebem a mesma resposta.
+	var wg sync.WaitGroup
+	results := make([]*httptest.ResponseRecorder, 5)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = post("/work-orders", "k1", `{"title":"Troca"}`)
+		}()
+	}
+	wg.Wait()
+	replayed := 0
+	for _, w := range results {
+		if w.Code != http.StatusCreated || w.Body.String() != `{"id":1}` {
+			t.Fatalf("duplicate got %d %s", w.Code, w.Body.String())
+		}
+		if w.Header().Get("Idempotent-Replayed") == "true" {
+			replayed++
+		}
+	}
+	if created.Load() != 1 || replayed != len(results)-1 {
+		t.Fatalf("created %d orders with %d replays, want 1 and %d", created.Load(), replayed, len(results)-1)
+	}
+
+	if w := post("/work-orders", "k1", `{"title":"Outra"}`); w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("same key, other body: got %d, want 422", w.Code)
+	}
+	if w := post("/work-orders", "k2", `{"title":"Outra"}`); w.Body.String() != `{"id":2}` {
+		t.Fatalf("new key should execute, got %s", w.Body.String())
+	}
+	// O alias sem versão e o /v1 são a mesma rota: a repetição é respondida da gravação.
+	if w := post("/v1/work-orders", "k2", `{"title":"Outra"}`); w.Body.String() != `{"id":2}` || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("retry through /v1 should replay, got %d %s", w.Code, w.Body.String())
+	}
+	if w := post("/work-orders", strings.Repeat("k", 256), `{}`); w.Code != http.StatusBadRequest {
+		t.Fatalf("long key: got %d, want 400", w.Code)
+	}
+
+	// 5xx não é gravado: a mesma chave executa de novo.
+	if w := post("/flaky", "k3", `{}`); w.Code != http.StatusInternalServerError {
+		t.Fatalf("flaky first call: got %d", w.Code)
+	}
+	if w := post("/flaky", "k3", `{}`); w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "" {
+		t.Fatalf("retry after 5xx should execute, got %d %v", w.Code, w.Header())
+	}
+}
+
+func TestRateLimit_PerClientAndRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	limiter := service.NewRateLimiter(memory.NewRateLimitMemoryRepo(), domain.RateLimit{Requests: 5, Per: time.Minute},
+		service.WithRouteLimit("POST /work-orders", domain.RateLimit{Requests: 2, Per: time.Minute}))
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		if user, err := strconv.ParseInt(c.GetHeader("X-User"), 10, 64); err == nil {
+			c.Request = c.Request.WithContext(tenant.WithPrincipal(c.Request.Context(), tenant.Principal{UserID: user, SiteID: 1}))
+		}
+	}, middleware.RateLimitMiddleware(limiter))
+	ok := func(c *gin.Context) { c.Status(http.StatusNoContent) }
+	r.POST("/v1/work-orders", ok)
+	r.POST("/work-orders", ok)
+	r.GET("/v1/assets", ok)
+
+	call := func(method, path, user string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("X-User", user)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// A rota antiga e a /v1 dividem a cota de 2 por minuto.
+	if w := call(http.MethodPost, "/v1/work-orders", "1"); w.Code != http.StatusNoContent ||
+		w.Header().Get("RateLimit-Limit") != "2" || w.Header().Get("RateLimit-Remaining") != "1" ||
+		w.Header().Get("RateLimit-Policy") != "2;w=60" {
+		t.Fatalf("first call: %d %v", w.Code, w.Header())
+	}
+	call(http.MethodPost, "/work-orders", "1")
+	w := call(http.MethodPost, "/v1/work-orders", "1")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("third call: got %d, want 429", w.Code)
+	}
+	if ra, _ := strconv.Atoi(w.Header().Get("Retry-After")); ra < 29 || ra > 30 {
+		t.Fatalf("Retry-After = %q, want ~30s", w.Header().Get("Retry-After"))
+	}
+
+	// Outras rotas usam a cota padrão; outros usuários, e o IP sem usuário, têm a própria.
+	if w := call(http.MethodGet, "/v1/assets", "1"); w.Code != http.StatusNoContent || w.Header().Get("RateLimit-Limit") != "5" {
+		t.Fatalf("default bucket: %d %v", w.Code, w.Header())
+	}
+	if w := call(http.MethodPost, "/v1/work-orders", "2"); w.Code != http.StatusNoContent {
+		t.Fatalf("other user: got %d", w.Code)
+	}
+	if w := call(http.MethodPost, "/v1/work-orders", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("anonymous client: got %d", w.Code)
+	}
+}
+
+func TestRateLimit_IPBeforeAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	signer, err := auth.NewSigner([]byte(strings.Repeat("s", 32)))
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	buckets := memory.NewRateLimitMemoryRepo()
+	r := gin.New()
+	r.Use(
+		middleware.IPRateLimitMiddleware(service.NewRateLimiter(buckets, domain.RateLimit{Requests: 3, Per: time.Minute})),
+		middleware.AuthMiddleware(signer),
+		middleware.RateLimitMiddleware(service.NewRateLimiter(buckets, domain.RateLimit{Requests: 10, Per: time.Minute})),
+	)
+	r.GET("/v1/assets", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	call := func(ip, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/assets", nil)
+		req.RemoteAddr = ip + ":40000"
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// Sem token: 401 até a cota do IP acabar, depois 429.
+	for i := 0; i < 2; i++ {
+		if w := call("10.0.0.1", "guess"); w.Code != http.StatusUnauthorized {
+			t.Fatalf("call %d without a valid token: got %d, want 401", i+1, w.Code)
+		}
+	}
+	valid, _ := signer.Issue(&domain.User{ID: 7, SiteID: 1}, time.Hour)
+	if w := call("10.0.0.1", valid); w.Code != http.StatusNoContent || w.Header().Get("RateLimit-Limit") != "10" {
+		t.Fatalf("authenticated call: %d %v, want 204 with the user quota headers", w.Code, w.Header())
+	}
+	w := call("10.0.0.1", "")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Fatalf("call above the IP quota: got %d %v, want 429 with Retry-After", w.Code, w.Header())
+	}
+	if w := call("10.0.0.2", ""); w.Code != http.StatusUnauthorized {
+		t.Fatalf("other IP: got %d, want 401", w.Code)
+	}
+}
+
+func TestBodyLimit_Returns413(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(middleware.BodyLimitMiddleware(16, map[string]int64{"POST /imports/assets": 64}))
+	bind := func(c *gin.Context) {
+		var body map[string]any
+		if err := c.ShouldBindJSON(&body); err != nil {
+			response.ValidationError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+	r.POST("/v1/work-orders", bind)
+	r.POST("/v1/imports/assets", bind)
+
+	big := `{"description":"` + strings.Repeat("x", 32) + `"}`
+	tests := []struct {
+		name, path, body string
+		chunked          bool
+		want             int
+	}{
+		{name: "small", path: "/v1/work-orders", body: `{"a":1}`, want: http.StatusNoContent},
+		{name: "content-length", path: "/v1/work-orders", body: big, want: http.StatusRequestEntityTooLarge},
+		{name: "chunked", path: "/v1/work-orders", body: big, chunked: true, want: http.StatusRequestEntityTooLarge},
+		{name: "route override", path: "/v1/imports/assets", body: big, want: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d; body=%s", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	log "github.com/sirupsen/logrus"
+)
+
+// RateLimitStore consome a cota do cliente na rota (service.RateLimiter em produção).
+type RateLimitStore interface {
+	Allow(ctx context.Context, client, route string) (domain.RateLimit, domain.RateLimitStatus, error)
+}
+
+// RateLimitMiddleware limita as requisições por cliente: o usuário do token
+// (cada integração tem o seu) ou, sem principal, o IP. Toda resposta leva
+// RateLimit-Limit/Remaining/Reset/Policy; acima da cota responde 429 com
+// Retry-After. Se a cota não puder ser consultada, a requisição passa.
+func RateLimitMiddleware(s RateLimitStore) gin.HandlerFunc {
+	return rateLimit(s, func(c *gin.Context) string {
+		if p, ok := tenant.PrincipalFrom(c.Request.Context()); ok {
+			return fmt.Sprintf("user:%d", p.UserID)
+		}
+		return "ip:" + c.ClientIP()
+	})
+}
+
+// IPRateLimitMiddleware limita as requisições por IP e vai antes do
+// AuthMiddleware: requisições sem token ou com token inválido também gastam a
+// cota e recebem 429, em vez de um 401 atrás do outro. As respostas seguem o
+// formato do RateLimitMiddleware; o limite por usuário, registrado depois da
+// autenticação, sobrescreve os cabeçalhos.
+func IPRateLimitMiddleware(s RateLimitStore) gin.HandlerFunc {
+	return rateLimit(s, func(c *gin.Context) string { return "ip:" + c.ClientIP() })
+}
+
+func rateLimit(s RateLimitStore, client func(*gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, st, err := s.Allow(c.Request.Context(), client(c), route(c))
+		if err != nil {
+			log.WithError(err).Warn("rate limit unavailable")
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		h.Set("RateLimit-Remaining", strconv.Itoa(st.Remaining))
+		h.Set("RateLimit-Reset", ceilSeconds(st.Reset))
+		h.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", limit.Requests, ceilSeconds(limit.Per)))
+		if !st.Allowed {
+			h.Set("Retry-After", ceilSeconds(st.RetryAfter))
+			response.HandleError(c, domain.ErrRateLimited)
+			return
+		}
+		c.Next()
+	}
+}
+
+// BodyLimitMiddleware recusa com 413 corpos acima de maxBytes; routes dá a
+// algumas rotas ("POST /imports/assets", caminho sem a versão) um limite maior.
+// Content-Length acima do limite é recusado na hora; sem ele, a leitura do
+// corpo falha ao passar do limite e o handler responde 413.
+func BodyLimitMiddleware(maxBytes int64, routes map[string]int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit := maxBytes
+		if n, ok := routes[route(c)]; ok {
+			limit = n
+		}
+		if c.Request.ContentLength > limit {
+			response.HandleError(c, &http.MaxBytesError{Limit: limit})
+			return
+		}
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
+
+var apiVersion = regexp.MustCompile(`^/v\d+/`)
+
+// route identifica a rota pelo método e pelo caminho registrado, sem a versão,
+// para /v1/... e o caminho antigo dividirem a mesma cota.
+func route(c *gin.Context) string {
+	return c.Request.Method + " " + apiVersion.ReplaceAllString(c.FullPath(), "/")
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
@@ -0,0 +1,146 @@
+// Package openapi publica o contrato OpenAPI 3.1 da API (/openapi.json e a
+// documentação em /docs) e valida as requisições contra ele.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.json
+var specJSON []byte
+
+//go:embed docs.html
+var docsHTML []byte
+
+// Document é o recorte do OpenAPI usado na validação: operações, parâmetros,
+// corpo JSON e schemas. O resto do documento só é servido.
+type Document struct {
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components struct {
+		Schemas    map[string]*Schema    `json:"schemas"`
+		Parameters map[string]*Parameter `json:"parameters"`
+	} `json:"components"`
+}
+
+type Operation struct {
+	OperationID string       `json:"operationId"`
+	Parameters  []*Parameter `json:"parameters"`
+	RequestBody *RequestBody `json:"requestBody"`
+}
+
+type Parameter struct {
+	Ref      string  `json:"$ref"`
+	Name     string  `json:"name"`
+	In       string  `json:"in"` // path|query|header
+	Required bool    `json:"required"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema  *Schema         `json:"schema"`
+	Example json.RawMessage `json:"example"`
+}
+
+// Schema cobre as palavras-chave do JSON Schema usadas no contrato.
+type Schema struct {
+	Ref              string             `json:"$ref"`
+	Type             Types              `json:"type"`
+	Format           string             `json:"format"`
+	Enum             []any              `json:"enum"`
+	Required         []string           `json:"required"`
+	Properties       map[string]*Schema `json:"properties"`
+	Items            *Schema            `json:"items"`
+	MinLength        *int               `json:"minLength"`
+	MaxLength        *int               `json:"maxLength"`
+	Minimum          *float64           `json:"minimum"`
+	Maximum          *float64           `json:"maximum"`
+	ExclusiveMinimum *float64           `json:"exclusiveMinimum"`
+}
+
+// Types aceita "type" como texto ou lista (ex: ["string", "null"] no 3.1).
+type Types []string
+
+func (t *Types) UnmarshalJSON(b []byte) error {
+	var one string
+	if err := json.Unmarshal(b, &one); err == nil {
+		*t = Types{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(b, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+func (t Types) allows(kind string) bool {
+	if len(t) == 0 {
+		return true
+	}
+	for _, v := range t {
+		if v == kind || (v == "number" && kind == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// Load lê o contrato embutido no binário.
+func Load() (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(specJSON, &doc); err != nil {
+		return nil, fmt.Errorf("parse openapi.json: %w", err)
+	}
+	return &doc, nil
+}
+
+// Operation devolve a operação do método no caminho do contrato (/assets/{id}).
+// O prefixo do servidor (/v1) é opcional, para valer também nas rotas sem versão.
+func (d *Document) Operation(method, path string) (*Operation, bool) {
+	if len(d.Servers) > 0 && d.Servers[0].URL != "/" {
+		path = strings.TrimPrefix(path, d.Servers[0].URL)
+	}
+	op, ok := d.Paths[path][strings.ToLower(method)]
+	return op, ok
+}
+
+// schema resolve $ref (#/components/schemas/...).
+func (d *Document) schema(s *Schema) *Schema {
+	for s != nil && s.Ref != "" {
+		s = d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]
+	}
+	return s
+}
+
+// parameter resolve $ref (#/components/parameters/...).
+func (d *Document) parameter(p *Parameter) *Parameter {
+	if p.Ref != "" {
+		return d.Components.Parameters[strings.TrimPrefix(p.Ref, "#/components/parameters/")]
+	}
+	return p
+}
+
+// Handler serve o contrato e a documentação navegável (Redoc).
+type Handler struct{}
+
+func NewHandler() *Handler { return &Handler{} }
+
+func (h *Handler) RegisterRoutes(r gin.IRouter) {
+	r.GET("/openapi.json", func(c *gin.Context) { c.Data(http.StatusOK, "application/json", specJSON) })
+	r.GET("/docs", func(c *gin.Context) { c.Data(http.StatusOK, "text/html; charset=utf-8", docsHTML) })
+}
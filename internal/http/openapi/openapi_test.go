@@ -0,0 +1,233 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/dto/v1"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/handlers"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/openapi"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// setupAPI monta as rotas descritas no contrato com o validador na frente.
+func setupAPI(t *testing.T) (*gin.Engine, *openapi.Document) {
+	t.Helper()
+	doc, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenant.WithPrincipal(c.Request.Context(), tenant.Principal{UserID: 1, SiteID: 1}))
+		c.Next()
+	}, openapi.RequestValidator(doc))
+
+	assets := memory.NewAssetMemoryRepo()
+	jobPlans := memory.NewJobPlanMemoryRepo()
+	ctx := tenant.WithPrincipal(t.Context(), tenant.Principal{UserID: 1, SiteID: 1})
+	if err := jobPlans.Create(ctx, &domain.JobPlan{Name: "Inspeção", Steps: []domain.JobPlanStep{{Seq: 1, Description: "Folga do cilindro"}}}); err != nil {
+		t.Fatalf("create job plan: %v", err)
+	}
+	api := r.Group("/v1")
+	handlers.NewAssetHandler(service.NewAssetService(assets)).RegisterRoutes(api)
+	orders := memory.NewWorkOrderMemoryRepo()
+	checklists := memory.NewChecklistMemoryRepo(orders)
+	workOrders := service.NewWorkOrderService(orders, service.WithAssets(assets), service.WithChecklists(jobPlans, checklists))
+	handlers.NewWorkOrderHandler(workOrders).RegisterRoutes(api)
+	handlers.NewSyncHandler(service.NewSyncService(memory.NewSyncMemoryRepo(assets, orders, checklists),
+		orders, checklists, workOrders)).RegisterRoutes(api)
+	return r, doc
+}
+
+var ginParam = regexp.MustCompile(`:([^/]+)`)
+
+func TestContract_RoutesMatchHandlers(t *testing.T) {
+	r, doc := setupAPI(t)
+
+	var registered, documented []string
+	for _, route := range r.Routes() {
+		path := strings.TrimPrefix(route.Path, doc.Servers[0].URL)
+		registered = append(registered, route.Method+" "+ginParam.ReplaceAllString(path, "{$1}"))
+	}
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(registered)
+	sort.Strings(documented)
+	if !reflect.DeepEqual(registered, documented) {
+		t.Fatalf("handlers and openapi.json drifted apart:\nregistered: %v\ndocumented: %v", registered, documented)
+	}
+}
+
+func TestContract_SchemasMatchTypes(t *testing.T) {
+	_, doc := setupAPI(t)
+
+	for name, typ := range map[string]any{
+		"ErrorResponse":           response.ErrorResponse{},
+		"ValidationErrorResponse": response.ValidationErrorResponse{},
+		"ValidationDetail":        response.ValidationDetail{},
+		"Asset":                   v1.Asset{},
+		"WorkOrder":               v1.WorkOrder{},
+		"JobPlanPart":             v1.JobPlanPart{},
+		"ChecklistItem":           v1.ChecklistItem{},
+		"MeasurementSpec":         v1.MeasurementSpec{},
+		"SyncChange":              v1.SyncChange{},
+		"SyncPage":                v1.SyncPage{},
+		"SyncResult":              v1.SyncResult{},
+		"SyncError":               v1.SyncError{},
+	} {
+		schema, ok := doc.Components.Schemas[name]
+		if !ok {
+			t.Errorf("schema %s missing", name)
+			continue
+		}
+		var documented []string
+		for prop := range schema.Properties {
+			documented = append(documented, prop)
+		}
+		sort.Strings(documented)
+		if fields := jsonFields(reflect.TypeOf(typ)); !reflect.DeepEqual(fields, documented) {
+			t.Errorf("schema %s drifted:\nstruct: %v\nschema: %v", name, fields, documented)
+		}
+	}
+}
+
+func jsonFields(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Os exemplos do contrato precisam passar no validador e no handler; nos
+// cadastros, o recurso devolvido traz de volta cada campo enviado.
+func TestContract_ExamplesAreAccepted(t *testing.T) {
+	r, doc := setupAPI(t)
+
+	for _, tc := range []struct {
+		method, path, spec string
+		want               int
+		echo               bool
+	}{
+		{http.MethodPost, "/v1/assets", "/assets", http.StatusCreated, true},
+		{http.MethodPatch, "/v1/assets/1", "/assets/{id}", http.StatusOK, false},
+		{http.MethodPost, "/v1/work-orders", "/work-orders", http.StatusCreated, true},
+		{http.MethodPost, "/v1/work-orders/1/status", "/work-orders/{id}/status", http.StatusOK, false},
+		{http.MethodPut, "/v1/work-orders/1/assignee", "/work-orders/{id}/assignee", http.StatusOK, false},
+		{http.MethodPatch, "/v1/work-orders/1/checklist/1", "/work-orders/{id}/checklist/{step}", http.StatusOK, false},
+		{http.MethodPost, "/v1/sync/push", "/sync/push", http.StatusOK, false},
+	} {
+		op, ok := doc.Operation(tc.method, tc.spec)
+		if !ok || op.RequestBody == nil {
+			t.Fatalf("%s %s: no request body in contract", tc.method, tc.spec)
+		}
+		example := op.RequestBody.Content["application/json"].Example
+		req := httptest.NewRequest(tc.method, tc.path, bytes.NewReader(example))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Fatalf("%s %s example: expected %d, got %d; body=%s", tc.method, tc.path, tc.want, w.Code, w.Body.String())
+		}
+		if !tc.echo {
+			continue
+		}
+		var sent, got map[string]any
+		_ = json.Unmarshal(example, &sent)
+		_ = json.Unmarshal(w.Body.Bytes(), &got)
+		for field, v := range sent {
+			if !reflect.DeepEqual(got[field], v) {
+				t.Errorf("%s %s: field %q sent %v, got %v", tc.method, tc.path, field, v, got[field])
+			}
+		}
+	}
+}
+
+func TestRequestValidator(t *testing.T) {
+	r, _ := setupAPI(t)
+
+	tests := []struct {
+		name, method, path, body string
+		key                      string
+		want                     int
+		details                  []response.ValidationDetail
+	}{
+		{name: "missing required", method: http.MethodPost, path: "/v1/work-orders", body: `{"title":"Troca"}`,
+			want: http.StatusUnprocessableEntity, details: []response.ValidationDetail{{Field: "asset_id", Rule: "required"}}},
+		{name: "wrong type", method: http.MethodPost, path: "/v1/work-orders", body: `{"asset_id":"1","title":"Troca"}`,
+			want: http.StatusUnprocessableEntity, details: []response.ValidationDetail{{Field: "asset_id", Rule: "type"}}},
+		{name: "enum", method: http.MethodPost, path: "/v1/assets", body: `{"name":"Torno","criticality":"Z"}`,
+			want: http.StatusUnprocessableEntity, details: []response.ValidationDetail{{Field: "criticality", Rule: "enum"}}},
+		{name: "format", method: http.MethodPost, path: "/v1/assets", body: `{"name":"Torno","installed_on":"01/03/2019"}`,
+			want: http.StatusUnprocessableEntity, details: []response.ValidationDetail{{Field: "installed_on", Rule: "format"}}},
+		{name: "nullable", method: http.MethodPatch, path: "/v1/assets/1", body: `{"name":null}`, want: http.StatusNotFound},
+		{name: "invalid json", method: http.MethodPost, path: "/v1/assets", body: `{"name":`,
+			want: http.StatusUnprocessableEntity, details: []response.ValidationDetail{{Field: "body", Rule: "json"}}},
+		{name: "path param", method: http.MethodPatch, path: "/v1/assets/abc", body: `{}`, want: http.StatusBadRequest},
+		{name: "query enum", method: http.MethodGet, path: "/v1/work-orders?status=closed", want: http.StatusBadRequest},
+		{name: "query ok", method: http.MethodGet, path: "/v1/work-orders?status=open&overdue=true", want: http.StatusOK},
+		{name: "header", method: http.MethodPost, path: "/v1/assets", body: `{"name":"Torno"}`,
+			key: strings.Repeat("k", 256), want: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.key != "" {
+				req.Header.Set("Idempotency-Key", tt.key)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d; body=%s", tt.want, w.Code, w.Body.String())
+			}
+			if tt.details == nil {
+				return
+			}
+			var body response.ValidationErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || !reflect.DeepEqual(body.Details, tt.details) {
+				t.Fatalf("details = %+v, want %+v", body.Details, tt.details)
+			}
+		})
+	}
+}
+
+func TestHandler_ServesSpecAndDocs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	openapi.NewHandler().RegisterRoutes(r)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil || w.Code != http.StatusOK || spec["openapi"] != "3.1.0" {
+		t.Fatalf("unexpected spec: %d %.80s", w.Code, w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `spec-url="/openapi.json"`) {
+		t.Fatalf("unexpected docs: %d", w.Code)
+	}
+}
@@ -0,0 +1,264 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/response"
+)
+
+var ginParam = regexp.MustCompile(`:([^/]+)`)
+
+// RequestValidator confere as rotas descritas no contrato antes do handler:
+// parâmetros de caminho, query e header fora do schema respondem 400 e corpo JSON
+// fora do schema responde 422 com os campos e regras violados (como os
+// handlers já fazem). Rotas fora do contrato passam direto.
+func RequestValidator(doc *Document) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op, ok := doc.Operation(c.Request.Method, ginParam.ReplaceAllString(c.FullPath(), "{$1}"))
+		if !ok {
+			c.Next()
+			return
+		}
+		if !doc.validParams(c, op) {
+			response.HandleError(c, domain.ErrInvalidInput)
+			return
+		}
+		details, err := doc.validBody(c, op)
+		if err != nil {
+			response.HandleError(c, err)
+			return
+		}
+		if len(details) > 0 {
+			response.ValidationFailed(c, details)
+			return
+		}
+		c.Next()
+	}
+}
+
+func (d *Document) validParams(c *gin.Context, op *Operation) bool {
+	for _, p := range op.Parameters {
+		p = d.parameter(p)
+		if p == nil {
+			continue
+		}
+		var raw string
+		switch p.In {
+		case "path":
+			raw = c.Param(p.Name)
+		case "query":
+			raw = c.Query(p.Name)
+		case "header":
+			raw = c.GetHeader(p.Name)
+		default:
+			continue
+		}
+		if raw == "" {
+			if p.Required {
+				return false
+			}
+			continue
+		}
+		s := d.schema(p.Schema)
+		v, ok := paramValue(s, raw)
+		if !ok {
+			return false
+		}
+		var details []response.ValidationDetail
+		d.validate(s, v, p.Name, &details)
+		if len(details) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// paramValue converte o texto do parâmetro no tipo do schema.
+func paramValue(s *Schema, raw string) (any, bool) {
+	switch {
+	case s == nil:
+		return raw, true
+	case s.Type.allows("string"):
+		return raw, true
+	case s.Type.allows("integer"):
+		if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(raw), true
+	case s.Type.allows("number"):
+		if _, err := strconv.ParseFloat(raw, 64); err != nil {
+			return nil, false
+		}
+		return json.Number(raw), true
+	case s.Type.allows("boolean"):
+		b, err := strconv.ParseBool(raw)
+		return b, err == nil
+	}
+	return raw, true
+}
+
+// validBody lê o corpo JSON, devolve as violações e repõe o corpo para o handler.
+// Falha na leitura (ex: corpo acima do limite) volta como erro.
+func (d *Document) validBody(c *gin.Context, op *Operation) ([]response.ValidationDetail, error) {
+	if op.RequestBody == nil {
+		return nil, nil
+	}
+	media, ok := op.RequestBody.Content["application/json"]
+	if !ok {
+		return nil, nil
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrInvalidInput, err)
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if len(bytes.TrimSpace(body)) == 0 {
+		if op.RequestBody.Required {
+			return []response.ValidationDetail{{Field: "body", Rule: "required"}}, nil
+		}
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return []response.ValidationDetail{{Field: "body", Rule: "json"}}, nil
+	}
+	var details []response.ValidationDetail
+	d.validate(media.Schema, v, "", &details)
+	return details, nil
+}
+
+// validate confere v (decodificado com UseNumber) contra o schema; field é o
+// caminho do valor no corpo (ex: "attributes.voltage", "items[0]").
+func (d *Document) validate(s *Schema, v any, field string, out *[]response.ValidationDetail) {
+	s = d.schema(s)
+	if s == nil {
+		return
+	}
+	fail := func(rule string) { *out = append(*out, response.ValidationDetail{Field: fieldName(field), Rule: rule}) }
+
+	kind := kindOf(v)
+	if kind == "null" {
+		if !s.Type.allows("null") && len(s.Type) > 0 {
+			fail("type")
+		}
+		return
+	}
+	if !s.Type.allows(kind) {
+		fail("type")
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, v) {
+		fail("enum")
+		return
+	}
+
+	switch val := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(val)
+		if s.MinLength != nil && n < *s.MinLength {
+			fail("minLength")
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			fail("maxLength")
+		}
+		if !validFormat(s.Format, val) {
+			fail("format")
+		}
+	case json.Number:
+		f, _ := val.Float64()
+		if s.Minimum != nil && f < *s.Minimum {
+			fail("minimum")
+		}
+		if s.ExclusiveMinimum != nil && f <= *s.ExclusiveMinimum {
+			fail("exclusiveMinimum")
+		}
+		if s.Maximum != nil && f > *s.Maximum {
+			fail("maximum")
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := val[name]; !ok {
+				*out = append(*out, response.ValidationDetail{Field: join(field, name), Rule: "required"})
+			}
+		}
+		for _, name := range slices.Sorted(maps.Keys(s.Properties)) {
+			if pv, ok := val[name]; ok {
+				d.validate(s.Properties[name], pv, join(field, name), out)
+			}
+		}
+	case []any:
+		for i, item := range val {
+			d.validate(s.Items, item, fmt.Sprintf("%s[%d]", field, i), out)
+		}
+	}
+}
+
+func kindOf(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if _, err := val.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	}
+	return "unknown"
+}
+
+func inEnum(enum []any, v any) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func validFormat(format, v string) bool {
+	switch format {
+	case "date":
+		_, err := time.Parse("2006-01-02", v)
+		return err == nil
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, v)
+		return err == nil
+	}
+	return true
+}
+
+func join(field, name string) string {
+	if field == "" {
+		return name
+	}
+	return field + "." + name
+}
+
+func fieldName(field string) string {
+	if field == "" {
+		return "body"
+	}
+	return field
+}
@@ -32,45 +32,69 @@ type ValidationErrorResponse struct {
 
 // HandleError transforma erros de domínio em HTTP.
 func HandleError(c *gin.Context, err error) {
-	code := http.StatusInternalServerError
-	msg := err.Error()
+	code, msg := Describe(err)
 
-	switch err {
-	case domain.ErrNotFound:
+	reqID, _ := c.Get("request_id")
+	rid, _ := reqID.(string)
+
+	c.JSON(code, ErrorResponse{
+		RequestID: rid,
+		Error:     msg,
+		Code:      code,
+	})
+	c.Abort()
+}
+
+// Describe devolve o status HTTP e a mensagem de um erro de domínio (também
+// usado nos resultados por item de operações em lote).
+func Describe(err error) (code int, msg string) {
+	code = http.StatusInternalServerError
+	msg = err.Error()
+
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		code = http.StatusRequestEntityTooLarge
+		msg = "corpo da requisição muito grande"
+	case errors.Is(err, domain.ErrNotFound):
 		code = http.StatusNotFound
 		msg = "registro não encontrado"
-	case domain.ErrInvalidInput:
+	case errors.Is(err, domain.ErrInvalidInput):
 		code = http.StatusBadRequest
 		msg = "entrada inválida"
-	case domain.ErrAlreadyExists:
+	case errors.Is(err, domain.ErrAlreadyExists):
 		code = http.StatusConflict
 		msg = "registro já existente"
-	case domain.ErrPrecondition:
+	case errors.Is(err, domain.ErrConflict):
+		code = http.StatusConflict
+		msg = "conflito com o estado atual"
+	case errors.Is(err, domain.ErrPrecondition):
 		code = http.StatusPreconditionFailed
 		msg = "pré-condição não atendida"
-	case domain.ErrUnauthorized:
+	case errors.Is(err, domain.ErrUnauthorized):
 		code = http.StatusUnauthorized
 		msg = "não autorizado"
-	case domain.ErrForbidden:
+	case errors.Is(err, domain.ErrForbidden):
 		code = http.StatusForbidden
 		msg = "acesso negado"
+	case errors.Is(err, domain.ErrKeyReused):
+		code = http.StatusUnprocessableEntity
+		msg = "chave de idempotência já usada com outra requisição"
+	case errors.Is(err, domain.ErrRateLimited):
+		code = http.StatusTooManyRequests
+		msg = "limite de requisições excedido"
 	}
-
-	reqID, _ := c.Get("request_id")
-	rid, _ := reqID.(string)
-
-	c.JSON(code, ErrorResponse{
-		RequestID: rid,
-		Error:     msg,
-		Code:      code,
-	})
-	c.Abort()
+	return code, msg
 }
 
 // ValidationError retorna 422 e, quando possível, detalhes por campo/regra.
+// Corpo acima do limite responde 413.
 func ValidationError(c *gin.Context, err error) {
-	reqID, _ := c.Get("request_id")
-	rid, _ := reqID.(string)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		HandleError(c, err)
+		return
+	}
 
 	details := make([]ValidationDetail, 0, 4)
 
@@ -85,6 +109,14 @@ func ValidationError(c *gin.Context, err error) {
 		}
 	}
 
+	ValidationFailed(c, details)
+}
+
+// ValidationFailed retorna 422 com detalhes já montados (ex: validação pelo contrato OpenAPI).
+func ValidationFailed(c *gin.Context, details []ValidationDetail) {
+	reqID, _ := c.Get("request_id")
+	rid, _ := reqID.(string)
+
 	c.JSON(http.StatusUnprocessableEntity, ValidationErrorResponse{
 		RequestID: rid,
 		Error:     "erro de validação",
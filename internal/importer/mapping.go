@@ -0,0 +1,50 @@
+package importer
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Mapping associa um campo do sistema (ex: "name") ao cabeçalho da planilha (ex: "Máquina").
+type Mapping map[string]string
+
+// Columns resolve o índice de cada campo no cabeçalho. Campos sem mapeamento
+// explícito são procurados pelo próprio nome ou pelos aliases informados,
+// ignorando caixa e acentos. Campos não encontrados ficam com -1.
+func (t *Table) Columns(fields map[string][]string, mapping Mapping) map[string]int {
+	index := make(map[string]int, len(t.Headers))
+	for i, h := range t.Headers {
+		index[Fold(h)] = i
+	}
+
+	cols := make(map[string]int, len(fields))
+	for field, aliases := range fields {
+		cols[field] = -1
+		candidates := append([]string{field}, aliases...)
+		if h, ok := mapping[field]; ok {
+			candidates = []string{h}
+		}
+		for _, c := range candidates {
+			if i, ok := index[Fold(c)]; ok {
+				cols[field] = i
+				break
+			}
+		}
+	}
+	return cols
+}
+
+// Fold normaliza textos para comparação (cabeçalhos, títulos): minúsculas, sem acentos
+// e sem espaços nas pontas.
+func Fold(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	out, _, err := transform.String(t, strings.ToLower(strings.TrimSpace(s)))
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(s))
+	}
+	return out
+}
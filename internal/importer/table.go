@@ -0,0 +1,169 @@
+// Package importer lê planilhas (CSV/XLSX) em uma tabela de strings com cabeçalho.
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format é o formato do arquivo enviado.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+// ErrUnsupportedFormat é retornado para arquivos que não são CSV nem XLSX.
+var ErrUnsupportedFormat = errors.New("unsupported file format")
+
+// DetectFormat escolhe o formato pelo parâmetro explícito, extensão ou Content-Type.
+func DetectFormat(explicit, filename, contentType string) (Format, error) {
+	switch strings.ToLower(explicit) {
+	case "csv":
+		return FormatCSV, nil
+	case "xlsx":
+		return FormatXLSX, nil
+	case "":
+	default:
+		return "", ErrUnsupportedFormat
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv", ".txt":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+
+	ct := strings.ToLower(contentType)
+	switch {
+	case strings.Contains(ct, "spreadsheetml"):
+		return FormatXLSX, nil
+	case strings.Contains(ct, "csv"), strings.HasPrefix(ct, "text/plain"):
+		return FormatCSV, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Table é a planilha já lida: cabeçalho + linhas de dados (sem linhas vazias).
+type Table struct {
+	Headers []string
+	Rows    []Row
+}
+
+// Row guarda o número da linha no arquivo original (cabeçalho = 1) para os erros.
+type Row struct {
+	Line   int
+	Values []string
+}
+
+// Read lê o arquivo inteiro no formato indicado.
+func Read(r io.Reader, format Format) (*Table, error) {
+	var records [][]string
+	var err error
+
+	switch format {
+	case FormatCSV:
+		records, err = readCSV(r)
+	case FormatXLSX:
+		records, err = readXLSX(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("empty file: header row is required")
+	}
+
+	t := &Table{Headers: trimAll(records[0])}
+	for i, rec := range records[1:] {
+		values := trimAll(rec)
+		if isBlank(values) {
+			continue
+		}
+		t.Rows = append(t.Rows, Row{Line: i + 2, Values: values})
+	}
+	return t, nil
+}
+
+// Value retorna a célula da coluna "col" (vazio se a linha for mais curta ou col < 0).
+func (r Row) Value(col int) string {
+	if col < 0 || col >= len(r.Values) {
+		return ""
+	}
+	return r.Values[col]
+}
+
+// readCSV aceita ";" (padrão do Excel em pt-BR) ou "," e remove BOM do UTF-8.
+func readCSV(r io.Reader) ([][]string, error) {
+	br := bufio.NewReader(r)
+	if b, err := br.Peek(3); err == nil && bytes.Equal(b, []byte{0xEF, 0xBB, 0xBF}) {
+		_, _ = br.Discard(3)
+	}
+
+	firstLine, err := br.Peek(4096)
+	if err != nil && err != io.EOF && !errors.Is(err, bufio.ErrBufferFull) {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	header, _, _ := bytes.Cut(firstLine, []byte("\n"))
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		cr.Comma = ';'
+	}
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	return records, nil
+}
+
+// readXLSX lê a primeira planilha do arquivo.
+func readXLSX(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("open xlsx: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("xlsx has no sheets")
+	}
+	rows, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("read xlsx rows: %w", err)
+	}
+	return rows, nil
+}
+
+func trimAll(values []string) []string {
+	out := make([]string, len(values))
+	for i, v := range values {
+		out[i] = strings.TrimSpace(v)
+	}
+	return out
+}
+
+func isBlank(values []string) bool {
+	for _, v := range values {
+		if v != "" {
+			return false
+		}
+	}
+	return true
+}
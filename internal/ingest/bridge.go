@@ -0,0 +1,272 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ingest/mqtt"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultRetry = time.Second
+	maxRetry     = time.Minute
+)
+
+// Sink recebe os sinais já convertidos (service.SignalService).
+type Sink interface {
+	RecordMeter(ctx context.Context, m *domain.MeterReading) ([]domain.WorkOrder, error)
+	RecordCondition(ctx context.Context, c *domain.ConditionReading) ([]domain.WorkOrder, error)
+	SetRunning(ctx context.Context, assetID int64, running bool, at time.Time, reasonCode string) (*domain.DowntimeEvent, error)
+}
+
+// errBadValue marca o valor do sinal que não pôde ser interpretado.
+var errBadValue = errors.New("bad signal value")
+
+// Bridge assina os tópicos do mapeamento e repassa cada sinal ao Sink. As
+// mensagens são tratadas uma por vez, na ordem de chegada, e só são
+// confirmadas ao broker depois de gravadas.
+type Bridge struct {
+	cfg     mqtt.Config
+	mapping *Mapping
+	assets  repository.AssetRepository
+	sink    Sink
+	retry   time.Duration
+	now     func() time.Time
+
+	assetIDs []int64        // ativo de cada sinal, resolvido no Run
+	running  map[int64]bool // último estado aplicado por ativo
+	last     []lastReading  // última leitura repassada por sinal
+}
+
+type lastReading struct {
+	value float64
+	at    time.Time // horário de chegada
+}
+
+// BridgeOption ajusta a ponte (ex: espera de reconexão).
+type BridgeOption func(*Bridge)
+
+// WithRetry define a espera antes da primeira reconexão; ela dobra a cada
+// falha seguida, até um minuto.
+func WithRetry(d time.Duration) BridgeOption {
+	return func(b *Bridge) { b.retry = d }
+}
+
+func NewBridge(cfg mqtt.Config, m *Mapping, assets repository.AssetRepository, sink Sink, opts ...BridgeOption) *Bridge {
+	b := &Bridge{
+		cfg:     cfg,
+		mapping: m,
+		assets:  assets,
+		sink:    sink,
+		retry:   defaultRetry,
+		now:     time.Now,
+		running: map[int64]bool{},
+		last:    make([]lastReading, len(m.Signals)),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Run resolve os ativos do mapeamento (ativo inexistente é erro) e mantém a
+// assinatura até ctx ser cancelado, reconectando quando o broker cai.
+func (b *Bridge) Run(ctx context.Context) error {
+	ctx, err := tenant.OnSite(tenant.System(ctx), b.mapping.SiteID)
+	if err != nil {
+		return err
+	}
+	if err := b.resolve(ctx); err != nil {
+		return err
+	}
+
+	wait := b.retry
+	for {
+		healthy, err := b.session(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if healthy {
+			wait = b.retry
+		}
+		log.WithError(err).WithField("retry_in", wait.String()).Warn("mqtt session ended")
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+		wait = min(wait*2, maxRetry)
+	}
+}
+
+// session conecta, assina e trata as mensagens até a conexão cair ou uma
+// gravação falhar. Só a sessão que assinou e não parou por falha de gravação
+// zera a espera de reconexão: com o banco fora, a espera continua dobrando.
+func (b *Bridge) session(ctx context.Context) (bool, error) {
+	c, err := mqtt.Dial(ctx, b.cfg)
+	if err != nil {
+		return false, err
+	}
+	defer c.Close()
+	topics := b.mapping.Topics()
+	if err := c.Subscribe(topics...); err != nil {
+		return false, err
+	}
+	log.WithFields(log.Fields{"topics": topics, "session_present": c.SessionPresent()}).Info("mqtt subscribed")
+	var failed bool
+	err = c.Serve(ctx, func(msg mqtt.Message) error {
+		err := b.handle(ctx, msg)
+		failed = err != nil
+		return err
+	})
+	return !failed, err
+}
+
+func (b *Bridge) resolve(ctx context.Context) error {
+	b.assetIDs = make([]int64, len(b.mapping.Signals))
+	byCode := map[string]int64{}
+	for i, s := range b.mapping.Signals {
+		if s.AssetID != 0 {
+			if _, err := b.assets.FindByID(ctx, s.AssetID); err != nil {
+				return fmt.Errorf("mapping: signal %d: asset %d: %w", i, s.AssetID, err)
+			}
+			b.assetIDs[i] = s.AssetID
+			continue
+		}
+		if id, ok := byCode[s.Asset]; ok {
+			b.assetIDs[i] = id
+			continue
+		}
+		a, err := b.assets.FindByCode(ctx, s.Asset)
+		if err != nil {
+			return fmt.Errorf("mapping: signal %d: asset %q: %w", i, s.Asset, err)
+		}
+		byCode[s.Asset] = a.ID
+		b.assetIDs[i] = a.ID
+	}
+	return nil
+}
+
+// handle repassa a mensagem a todos os sinais cujo tópico casa com ela. Payload
+// ou valor inválido e recusa do serviço (erro de domínio) vão para o log e a
+// mensagem é confirmada, porque se repetiriam a cada reenvio. As demais falhas
+// ao gravar (banco fora, timeout) voltam como erro: a mensagem fica sem PUBACK
+// e é reenviada na reconexão. Os sinais da mensagem gravados antes da falha
+// voltam no reenvio: estado e contador repetidos são descartados, mas uma
+// medição pode ser gravada duas vezes.
+func (b *Bridge) handle(ctx context.Context, msg mqtt.Message) error {
+	for i := range b.mapping.Signals {
+		s := &b.mapping.Signals[i]
+		if !mqtt.Match(s.Topic, msg.Topic) {
+			continue
+		}
+		fields := log.Fields{"topic": msg.Topic, "signal": i, "asset_id": b.assetIDs[i], "kind": s.Kind}
+		readings, err := s.extract(msg.Payload)
+		if err != nil {
+			log.WithError(err).WithFields(fields).Warn("machine signal ignored")
+			continue
+		}
+		for _, r := range readings {
+			err := b.apply(ctx, i, r)
+			switch {
+			case err == nil:
+			case permanent(err):
+				log.WithError(err).WithFields(fields).Error("machine signal failed")
+			default:
+				log.WithError(err).WithFields(fields).Warn("machine signal not recorded, waiting redelivery")
+				return fmt.Errorf("signal %d: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// permanent diz se a falha se repetiria a cada reenvio da mensagem.
+func permanent(err error) bool {
+	for _, target := range []error{errBadValue, domain.ErrInvalidInput, domain.ErrNotFound, domain.ErrConflict,
+		domain.ErrAlreadyExists, domain.ErrPrecondition, domain.ErrUnauthorized, domain.ErrForbidden} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Bridge) apply(ctx context.Context, i int, r reading) error {
+	s := &b.mapping.Signals[i]
+	assetID := b.assetIDs[i]
+	switch s.Kind {
+	case KindState:
+		running, err := s.running(r.value)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errBadValue, err)
+		}
+		// Os CLPs repetem o estado a cada ciclo; só a mudança vai para o serviço.
+		if prev, ok := b.running[assetID]; ok && prev == running {
+			return nil
+		}
+		ev, err := b.sink.SetRunning(ctx, assetID, running, r.at, s.ReasonCode)
+		if err != nil {
+			delete(b.running, assetID)
+			return err
+		}
+		b.running[assetID] = running
+		if ev != nil {
+			log.WithFields(log.Fields{"asset_id": assetID, "downtime_id": ev.ID, "running": running}).
+				Info("downtime updated by machine signal")
+		}
+		return nil
+
+	case KindMeter:
+		v, err := s.number(r.value)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errBadValue, err)
+		}
+		if v < 0 {
+			return fmt.Errorf("%w: meter value %v is negative", errBadValue, v)
+		}
+		if !b.due(i, v, true) {
+			return nil
+		}
+		if _, err := b.sink.RecordMeter(ctx, &domain.MeterReading{AssetID: assetID, Value: int64(v), ReadAt: r.at}); err != nil {
+			return err
+		}
+		b.last[i] = lastReading{value: v, at: b.now()}
+		return nil
+
+	case KindCondition:
+		v, err := s.number(r.value)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errBadValue, err)
+		}
+		if !b.due(i, v, false) {
+			return nil
+		}
+		c := &domain.ConditionReading{AssetID: assetID, Parameter: s.Parameter, Value: v, ReadAt: r.at}
+		if _, err := b.sink.RecordCondition(ctx, c); err != nil {
+			return err
+		}
+		b.last[i] = lastReading{value: v, at: b.now()}
+		return nil
+	}
+	return nil
+}
+
+// due aplica o intervalo mínimo (Every) do sinal; com skipSame, valor igual ao
+// último repassado também é descartado.
+func (b *Bridge) due(i int, v float64, skipSame bool) bool {
+	last := b.last[i]
+	if last.at.IsZero() {
+		return true
+	}
+	if skipSame && last.value == v {
+		return false
+	}
+	return b.now().Sub(last.at) >= b.mapping.Signals[i].every
+}
@@ -0,0 +1,251 @@
+package ingest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ingest"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ingest/mqtt"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ingest/mqtt/mqtttest"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+const mapping = `{
+	"site_id": 1,
+	"signals": [
+		{"topic": "plant/slitter/SLT-01/status", "asset": "SLT-01", "kind": "state",
+		 "field": "state", "timestamp_field": "ts", "running": ["RUN"], "reason_code": "MEC"},
+		{"topic": "plant/slitter/SLT-01/status", "asset": "SLT-01", "kind": "meter", "field": "counters.meters"},
+		{"topic": "plant/slitter/+/vibration", "asset": "SLT-01", "kind": "condition", "parameter": "vibration"},
+		{"topic": "opcua/json/data/line2", "format": "opcua", "node": "Running", "writer_id": 7,
+		 "asset": "WND-02", "kind": "state"}
+	]
+}`
+
+func TestBridge_MachineSignals(t *testing.T) {
+	ctx := tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 1, SiteID: 1})
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	plans := memory.NewMaintenancePlanMemoryRepo()
+	events := memory.NewDowntimeMemoryRepo(assets)
+	workOrders := service.NewWorkOrderService(orders, service.WithAssets(assets), service.WithPlans(plans))
+	signals := service.NewSignalService(memory.NewSignalMemoryRepo(assets), assets, plans, orders, workOrders,
+		service.NewDowntimeService(events, assets, orders))
+
+	slitter := domain.Asset{Name: "Slitter 01", ExternalCode: "SLT-01"}
+	winder := domain.Asset{Name: "Bobinadeira 02", ExternalCode: "WND-02"}
+	for _, a := range []*domain.Asset{&slitter, &winder} {
+		if err := assets.Create(ctx, a); err != nil {
+			t.Fatalf("create asset: %v", err)
+		}
+	}
+	target, limit := int64(100), 7.1
+	for _, p := range []domain.MaintenancePlan{
+		{AssetID: slitter.ID, RuleType: domain.PlanRuleMeter, MeterTarget: &target, Active: true},
+		{AssetID: slitter.ID, RuleType: domain.PlanRuleCondition, ConditionParameter: "vibration", ConditionMax: &limit, Active: true},
+	} {
+		if err := plans.Create(ctx, &p); err != nil {
+			t.Fatalf("create plan: %v", err)
+		}
+	}
+
+	m, err := ingest.ParseMapping([]byte(mapping))
+	if err != nil {
+		t.Fatalf("ParseMapping: %v", err)
+	}
+	broker, err := mqtttest.NewBroker()
+	if err != nil {
+		t.Fatalf("broker: %v", err)
+	}
+	defer broker.Close()
+
+	runCtx, stop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	bridge := ingest.NewBridge(mqtt.Config{Broker: broker.Addr(), ClientID: "ingest-test"}, m, assets, signals,
+		ingest.WithRetry(10*time.Millisecond))
+	go func() { done <- bridge.Run(runCtx) }()
+	defer func() {
+		stop()
+		if err := <-done; err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	}()
+
+	// publish repete até um assinante confirmar (o PUBACK sai depois do tratamento).
+	publish := func(topic, payload string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			pctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			n, err := broker.Publish(pctx, topic, []byte(payload))
+			cancel()
+			if err == nil && n == 1 {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("publish %s: delivered to %d (%v)", topic, n, err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	downtime := func(assetID int64) []domain.DowntimeEvent {
+		t.Helper()
+		list, err := events.FindByAsset(ctx, assetID, nil, nil)
+		if err != nil {
+			t.Fatalf("FindByAsset: %v", err)
+		}
+		return list
+	}
+	status := func(state string, meters int, at time.Time) string {
+		return fmt.Sprintf(`{"state": %q, "counters": {"meters": %d}, "ts": %q}`, state, meters, at.Format(time.RFC3339))
+	}
+
+	stoppedAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+	publish("plant/slitter/SLT-01/status", status("RUN", 1000, stoppedAt.Add(-time.Minute)))
+	publish("plant/slitter/SLT-01/status", status("FAULT", 1000, stoppedAt))
+	publish("plant/slitter/SLT-01/status", status("FAULT", 1000, stoppedAt.Add(time.Second)))
+	list := downtime(slitter.ID)
+	if len(list) != 1 || !list[0].IsOpen() || !list[0].StartedAt.Equal(stoppedAt) || list[0].ReasonCode != "MEC" {
+		t.Fatalf("downtime after stop = %+v", list)
+	}
+
+	// Queda do broker: a ponte reconecta, assina de novo e segue do mesmo estado.
+	broker.DropClients()
+	publish("plant/slitter/SLT-01/status", status("RUN", 1150, stoppedAt.Add(15*time.Minute)))
+	list = downtime(slitter.ID)
+	if len(list) != 1 || list[0].IsOpen() || list[0].Minutes(time.Now()) != 15 {
+		t.Fatalf("downtime after run = %+v", list)
+	}
+
+	publish("plant/slitter/SLT-01/vibration", "4.8")
+	publish("plant/slitter/SLT-01/vibration", "9.4")
+	publish("plant/slitter/SLT-01/vibration", "not-a-number")
+	var types []domain.WorkOrderType
+	err = orders.Stream(ctx, domain.WorkOrderFilter{AssetID: slitter.ID, Open: true}, func(o *domain.WorkOrder) error {
+		types = append(types, o.Type)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	slices.Sort(types)
+	if fmt.Sprint(types) != "[condition preventive]" {
+		t.Fatalf("open work orders = %v, want the meter and the condition plan orders", types)
+	}
+
+	// OPC UA PubSub: só o DataSetMessage do writer mapeado conta, com o SourceTimestamp.
+	winderStop := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+	publish("opcua/json/data/line2", fmt.Sprintf(`{
+		"MessageId": "32235546", "MessageType": "ua-data", "PublisherId": "line2-gw",
+		"Messages": [
+			{"DataSetWriterId": 3, "Payload": {"Running": true}},
+			{"DataSetWriterId": 7, "Timestamp": %q,
+			 "Payload": {"Running": {"Value": {"Type": 1, "Body": false}, "SourceTimestamp": %q}}}
+		]
+	}`, time.Now().Format(time.RFC3339), winderStop.Format(time.RFC3339)))
+	list = downtime(winder.ID)
+	if len(list) != 1 || !list[0].IsOpen() || !list[0].StartedAt.Equal(winderStop) || list[0].ReasonCode != ingest.DefaultStopReason {
+		t.Fatalf("winder downtime = %+v", list)
+	}
+}
+
+// flakySink falha a primeira gravação de contador como um banco fora do ar.
+type flakySink struct {
+	mu       sync.Mutex
+	calls    int
+	recorded []int64
+}
+
+func (s *flakySink) RecordMeter(_ context.Context, m *domain.MeterReading) ([]domain.WorkOrder, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.calls == 1 {
+		return nil, errors.New("connection refused")
+	}
+	s.recorded = append(s.recorded, m.Value)
+	return nil, nil
+}
+
+func (s *flakySink) RecordCondition(context.Context, *domain.ConditionReading) ([]domain.WorkOrder, error) {
+	return nil, nil
+}
+
+func (s *flakySink) SetRunning(context.Context, int64, bool, time.Time, string) (*domain.DowntimeEvent, error) {
+	return nil, nil
+}
+
+func TestBridge_RedeliversAfterSinkFailure(t *testing.T) {
+	ctx := tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 1, SiteID: 1})
+	assets := memory.NewAssetMemoryRepo()
+	if err := assets.Create(ctx, &domain.Asset{Name: "Slitter 01", ExternalCode: "SLT-01"}); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	m, err := ingest.ParseMapping([]byte(`{"site_id": 1, "signals": [
+		{"topic": "plant/slitter/SLT-01/meters", "asset": "SLT-01", "kind": "meter"}]}`))
+	if err != nil {
+		t.Fatalf("ParseMapping: %v", err)
+	}
+	broker, err := mqtttest.NewBroker()
+	if err != nil {
+		t.Fatalf("broker: %v", err)
+	}
+	defer broker.Close()
+
+	sink := &flakySink{}
+	runCtx, stop := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	bridge := ingest.NewBridge(mqtt.Config{Broker: broker.Addr(), ClientID: "ingest-redelivery"}, m, assets, sink,
+		ingest.WithRetry(10*time.Millisecond))
+	go func() { done <- bridge.Run(runCtx) }()
+	defer func() {
+		stop()
+		if err := <-done; err != nil {
+			t.Errorf("Run() error = %v", err)
+		}
+	}()
+
+	pctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := broker.WaitSubscribed(pctx, "plant/slitter/SLT-01/meters"); err != nil {
+		t.Fatal(err)
+	}
+	// A primeira gravação falha: sem PUBACK, a ponte reconecta na mesma sessão
+	// e o broker reenvia. O Publish só volta com a confirmação do reenvio.
+	if n, err := broker.Publish(pctx, "plant/slitter/SLT-01/meters", []byte("1234")); err != nil || n != 1 {
+		t.Fatalf("Publish() = %d, %v", n, err)
+	}
+	// Valor inválido não melhora com reenvio: é confirmado sem chegar ao Sink.
+	if n, err := broker.Publish(pctx, "plant/slitter/SLT-01/meters", []byte("n/a")); err != nil || n != 1 {
+		t.Fatalf("Publish(invalid) = %d, %v", n, err)
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if sink.calls != 2 || fmt.Sprint(sink.recorded) != "[1234]" {
+		t.Fatalf("sink calls = %d, recorded = %v, want the failed write retried once", sink.calls, sink.recorded)
+	}
+}
+
+func TestParseMapping_Errors(t *testing.T) {
+	for _, tc := range []struct{ name, json string }{
+		{"missing site", `{"signals": [{"topic": "a", "asset": "X", "kind": "meter"}]}`},
+		{"bad wildcard", `{"site_id": 1, "signals": [{"topic": "a/b#", "asset": "X", "kind": "meter"}]}`},
+		{"no asset", `{"site_id": 1, "signals": [{"topic": "a", "kind": "meter"}]}`},
+		{"condition without parameter", `{"site_id": 1, "signals": [{"topic": "a", "asset": "X", "kind": "condition"}]}`},
+		{"opcua without node", `{"site_id": 1, "signals": [{"topic": "a", "asset": "X", "kind": "state", "format": "opcua"}]}`},
+		{"unknown field", `{"site_id": 1, "signals": [{"topic": "a", "asset": "X", "kind": "state", "tag": "x"}]}`},
+	} {
+		if _, err := ingest.ParseMapping([]byte(tc.json)); err == nil {
+			t.Errorf("%s: ParseMapping() error = nil", tc.name)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Package ingest liga os sinais das máquinas ao sistema: assina os tópicos MQTT
+// do arquivo de mapeamento, extrai o valor de cada sinal (JSON do CLP/gateway ou
+// OPC UA PubSub em JSON) e grava como leitura de contador, medição de condição
+// ou estado de marcha/parada do ativo.
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ingest/mqtt"
+)
+
+// DefaultStopReason é o motivo das paradas abertas pelo sinal quando o
+// mapeamento não informa outro; a operação reclassifica depois.
+const DefaultStopReason = "AUTO"
+
+// SignalKind é o que o sinal alimenta no sistema.
+type SignalKind string
+
+const (
+	KindState     SignalKind = "state"     // marcha/parada → paradas do ativo
+	KindMeter     SignalKind = "meter"     // contador de uso → planos por uso
+	KindCondition SignalKind = "condition" // medição → planos por condição
+)
+
+// Formatos de payload aceitos.
+const (
+	// FormatJSON é um objeto JSON (valor em Field) ou um valor solto (número,
+	// true/false, texto) quando Field está vazio.
+	FormatJSON = "json"
+	// FormatOPCUA é a mensagem JSON do OPC UA PubSub (Part 14): o valor é o campo
+	// Node do Payload dos DataSetMessages, com ou sem o envelope de NetworkMessage.
+	FormatOPCUA = "opcua"
+)
+
+// Mapping é o arquivo de mapeamento do cmd/ingest: de onde vem cada sinal e a
+// qual ativo ele pertence.
+type Mapping struct {
+	// SiteID é a planta dos ativos mapeados (os códigos são únicos por site).
+	SiteID  int64    `json:"site_id"`
+	Signals []Signal `json:"signals"`
+}
+
+type Signal struct {
+	// Topic é o filtro MQTT assinado (aceita + e #).
+	Topic  string `json:"topic"`
+	Format string `json:"format,omitempty"` // json (padrão) | opcua
+	// Field é o caminho do valor no objeto JSON, com pontos (ex: data.counter).
+	Field string `json:"field,omitempty"`
+	// TimestampField é o caminho do horário da leitura (RFC 3339 ou epoch em
+	// milissegundos); sem ele vale o horário de chegada. No OPC UA vem do
+	// SourceTimestamp ou do Timestamp do DataSetMessage.
+	TimestampField string `json:"timestamp_field,omitempty"`
+	// Node é o campo do DataSet no OPC UA (nome do campo ou NodeId publicado).
+	Node string `json:"node,omitempty"`
+	// WriterID restringe aos DataSetMessages desse DataSetWriterId (0 = todos).
+	WriterID int64 `json:"writer_id,omitempty"`
+
+	// O ativo vem pelo código (external_code) ou pelo id.
+	Asset   string `json:"asset,omitempty"`
+	AssetID int64  `json:"asset_id,omitempty"`
+
+	Kind SignalKind `json:"kind"`
+	// Parameter é o nome da medição nos sinais de condição (ex: vibration).
+	Parameter string `json:"parameter,omitempty"`
+	// Running lista os valores que significam "em marcha"; sem lista, valem
+	// true, número diferente de zero e os textos run/running/on/true.
+	Running []string `json:"running,omitempty"`
+	// ReasonCode é o motivo das paradas abertas pelo sinal (padrão AUTO).
+	ReasonCode string `json:"reason_code,omitempty"`
+	// Scale multiplica os valores numéricos (ex: 0.001 para mm → m); 0 = 1.
+	Scale float64 `json:"scale,omitempty"`
+	// Every é o intervalo mínimo entre leituras gravadas de contador e condição
+	// (ex: "5m"); vazio grava todas. Contador parado nunca é regravado.
+	Every string `json:"every,omitempty"`
+
+	every time.Duration
+}
+
+// LoadMapping lê e valida o arquivo de mapeamento.
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read mapping: %w", err)
+	}
+	return ParseMapping(data)
+}
+
+// ParseMapping valida o mapeamento; o erro aponta o sinal problemático.
+func ParseMapping(data []byte) (*Mapping, error) {
+	var m Mapping
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&m); err != nil {
+		return nil, fmt.Errorf("parse mapping: %w", err)
+	}
+	if m.SiteID <= 0 {
+		return nil, errors.New("mapping: site_id is required")
+	}
+	if len(m.Signals) == 0 {
+		return nil, errors.New("mapping: no signals")
+	}
+	for i := range m.Signals {
+		if err := m.Signals[i].normalize(); err != nil {
+			return nil, fmt.Errorf("mapping: signal %d (%s): %w", i, m.Signals[i].Topic, err)
+		}
+	}
+	return &m, nil
+}
+
+func (s *Signal) normalize() error {
+	if !mqtt.ValidFilter(s.Topic) {
+		return errors.New("invalid topic filter")
+	}
+	switch s.Format {
+	case "":
+		s.Format = FormatJSON
+	case FormatJSON:
+	case FormatOPCUA:
+		if s.Node == "" {
+			return errors.New("node is required for opcua payloads")
+		}
+	default:
+		return fmt.Errorf("unsupported format %q", s.Format)
+	}
+	if (s.Asset == "") == (s.AssetID == 0) {
+		return errors.New("set either asset or asset_id")
+	}
+	s.Parameter = domain.NormalizeParameter(s.Parameter)
+	switch s.Kind {
+	case KindState:
+		if s.ReasonCode == "" {
+			s.ReasonCode = DefaultStopReason
+		}
+	case KindMeter:
+	case KindCondition:
+		if s.Parameter == "" {
+			return errors.New("parameter is required for condition signals")
+		}
+	default:
+		return fmt.Errorf("unsupported kind %q", s.Kind)
+	}
+	if s.Scale == 0 {
+		s.Scale = 1
+	}
+	if s.Every != "" {
+		d, err := time.ParseDuration(s.Every)
+		if err != nil || d < 0 {
+			return fmt.Errorf("invalid every %q", s.Every)
+		}
+		s.every = d
+	}
+	return nil
+}
+
+// Topics devolve os filtros distintos a assinar.
+func (m *Mapping) Topics() []string {
+	var topics []string
+	seen := map[string]bool{}
+	for _, s := range m.Signals {
+		if !seen[s.Topic] {
+			seen[s.Topic] = true
+			topics = append(topics, s.Topic)
+		}
+	}
+	return topics
+}
@@ -0,0 +1,256 @@
+// Package mqtt adapta o cliente Eclipse Paho (paho.mqtt.golang) ao cmd/ingest:
+// conecta, assina os tópicos com QoS 1 e entrega as publicações uma por vez,
+// na ordem de chegada. A confirmação (PUBACK) só sai depois que a mensagem foi
+// tratada sem erro. Com a sessão persistente (o padrão), a mensagem sem
+// confirmação, por queda ou por falha no tratamento, é reenviada pelo broker na
+// reconexão (entrega pelo menos uma vez). A reconexão fica com quem chama (a
+// ponte espera e reconecta), não com o Paho.
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	paho "github.com/eclipse/paho.mqtt.golang"
+)
+
+const (
+	defaultKeepAlive = 30 * time.Second
+	// queueSize é a fila entre o Paho e o Serve. Como o PUBACK só sai depois do
+	// tratamento, a janela de mensagens em voo do broker limita o que chega
+	// antes; com a fila cheia, o Paho espera.
+	queueSize = 1000
+	// disconnectWait é quanto o Close espera o envio dos PUBACK pendentes.
+	disconnectWait = 250 // ms
+)
+
+// Config descreve a conexão com o broker.
+type Config struct {
+	// Broker é tcp://host:1883 ou ssl://host:8883 (sem esquema, vale tcp).
+	Broker string
+	// ClientID identifica a sessão no broker; obrigatório sem CleanSession.
+	ClientID  string
+	Username  string
+	Password  string
+	KeepAlive time.Duration // 0 = 30s
+	// TLSConfig é usado nos brokers ssl://; nil usa as CAs do sistema.
+	TLSConfig *tls.Config
+	// CleanSession descarta a sessão a cada conexão. Sem ela, o broker guarda
+	// as assinaturas e as mensagens QoS 1 não confirmadas do ClientID, inclusive
+	// as que chegam enquanto o cliente está fora, e as entrega na reconexão.
+	CleanSession bool
+}
+
+// Message é uma publicação recebida.
+type Message struct {
+	Topic   string
+	Payload []byte
+	// Retained indica a última mensagem guardada pelo broker, entregue na assinatura.
+	Retained bool
+}
+
+type Client struct {
+	c         paho.Client
+	keepAlive time.Duration
+	// msgs recebe todas as publicações, inclusive as que chegam antes do
+	// SUBACK (retidas ou reenviadas da sessão), pelo handler padrão do Paho.
+	msgs  chan paho.Message
+	lost  chan error
+	done  chan struct{}
+	close sync.Once
+	// sessionPresent indica que o broker retomou a sessão anterior.
+	sessionPresent bool
+}
+
+// Dial conecta e autentica no broker, retomando a sessão do ClientID (ou numa
+// sessão limpa, com CleanSession).
+func Dial(ctx context.Context, cfg Config) (*Client, error) {
+	broker, err := brokerURL(cfg.Broker)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ClientID == "" && !cfg.CleanSession {
+		return nil, errors.New("mqtt: client id is required for a persistent session")
+	}
+	keepAlive := cfg.KeepAlive
+	if keepAlive <= 0 {
+		keepAlive = defaultKeepAlive
+	}
+
+	c := &Client{
+		keepAlive: keepAlive,
+		msgs:      make(chan paho.Message, queueSize),
+		lost:      make(chan error, 1),
+		done:      make(chan struct{}),
+	}
+	opts := paho.NewClientOptions().
+		AddBroker(broker).
+		SetClientID(cfg.ClientID).
+		SetUsername(cfg.Username).
+		SetPassword(cfg.Password).
+		SetCleanSession(cfg.CleanSession).
+		SetKeepAlive(keepAlive).
+		SetTLSConfig(cfg.TLSConfig).
+		SetProtocolVersion(4).
+		SetAutoReconnect(false).
+		SetAutoAckDisabled(true).
+		SetOrderMatters(true).
+		SetDefaultPublishHandler(func(_ paho.Client, m paho.Message) {
+			select {
+			case c.msgs <- m:
+			case <-c.done:
+			}
+		}).
+		SetConnectionLostHandler(func(_ paho.Client, err error) {
+			select {
+			case c.lost <- err:
+			default:
+			}
+		})
+	if deadline, ok := ctx.Deadline(); ok {
+		opts.SetConnectTimeout(time.Until(deadline))
+	}
+	c.c = paho.NewClient(opts)
+
+	tok := c.c.Connect()
+	select {
+	case <-tok.Done():
+	case <-ctx.Done():
+		c.Close()
+		return nil, ctx.Err()
+	}
+	if err := tok.Error(); err != nil {
+		return nil, fmt.Errorf("mqtt connect %s: %w", broker, err)
+	}
+	c.sessionPresent = tok.(*paho.ConnectToken).SessionPresent()
+	return c, nil
+}
+
+// brokerURL completa o esquema e a porta padrão do endereço do broker.
+func brokerURL(broker string) (string, error) {
+	if !strings.Contains(broker, "://") {
+		broker = "tcp://" + broker
+	}
+	u, err := url.Parse(broker)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("mqtt broker %q: invalid address", broker)
+	}
+	port := "1883"
+	switch u.Scheme {
+	case "tcp", "mqtt":
+	case "ssl", "tls", "mqtts":
+		port = "8883"
+	default:
+		return "", fmt.Errorf("mqtt broker %q: unsupported scheme %q", broker, u.Scheme)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), port)
+	}
+	return u.String(), nil
+}
+
+// SessionPresent diz se o broker retomou a sessão anterior do ClientID.
+func (c *Client) SessionPresent() bool {
+	return c.sessionPresent
+}
+
+// Subscribe assina os filtros (com curingas + e #) com QoS 1 e espera a confirmação.
+func (c *Client) Subscribe(filters ...string) error {
+	if len(filters) == 0 {
+		return nil
+	}
+	subs := make(map[string]byte, len(filters))
+	for _, f := range filters {
+		subs[f] = 1
+	}
+	// Sem callback por filtro: tudo passa pelo handler padrão, uma vez por
+	// mensagem, mesmo quando ela casa com mais de um filtro.
+	tok := c.c.SubscribeMultiple(subs, nil)
+	if !tok.WaitTimeout(c.keepAlive) {
+		return errors.New("mqtt suback: timeout")
+	}
+	if err := tok.Error(); err != nil {
+		return fmt.Errorf("mqtt subscribe: %w", err)
+	}
+	for f, code := range tok.(*paho.SubscribeToken).Result() {
+		if code == 0x80 {
+			return fmt.Errorf("mqtt subscribe %q: refused by broker", f)
+		}
+	}
+	return nil
+}
+
+// Serve entrega as publicações a handle, uma por vez, até ctx ser cancelado
+// (retorna nil) ou a conexão cair (retorna o erro). Se handle falhar, a
+// mensagem fica sem PUBACK e Serve retorna o erro: feche o cliente e reconecte
+// para o broker reenviá-la.
+func (c *Client) Serve(ctx context.Context, handle func(Message) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-c.lost:
+			return fmt.Errorf("mqtt connection lost: %w", err)
+		case m := <-c.msgs:
+			msg := Message{Topic: m.Topic(), Payload: m.Payload(), Retained: m.Retained()}
+			if err := handle(msg); err != nil {
+				return fmt.Errorf("mqtt handle %s: %w", msg.Topic, err)
+			}
+			m.Ack()
+		}
+	}
+}
+
+// Close desconecta do broker, enviando antes os PUBACK já liberados. As
+// mensagens ainda na fila ficam sem confirmação e voltam na próxima sessão.
+func (c *Client) Close() error {
+	c.close.Do(func() {
+		close(c.done)
+		c.c.Disconnect(disconnectWait)
+	})
+	return nil
+}
+
+// Match diz se o tópico casa com o filtro de assinatura: + vale um nível e #
+// (no fim) o nível atual e todos abaixo. Tópicos de sistema ($SYS/...) não
+// casam com curinga no primeiro nível.
+func Match(filter, topic string) bool {
+	if strings.HasPrefix(topic, "$") && (strings.HasPrefix(filter, "+") || strings.HasPrefix(filter, "#")) {
+		return false
+	}
+	fs, ts := strings.Split(filter, "/"), strings.Split(topic, "/")
+	for i, f := range fs {
+		if f == "#" {
+			return i == len(fs)-1
+		}
+		if i >= len(ts) || (f != "+" && f != ts[i]) {
+			return false
+		}
+	}
+	return len(fs) == len(ts)
+}
+
+// ValidFilter confere a sintaxe do filtro: curingas ocupam o nível inteiro e #
+// só aparece no fim.
+func ValidFilter(filter string) bool {
+	if filter == "" {
+		return false
+	}
+	levels := strings.Split(filter, "/")
+	for i, l := range levels {
+		if strings.ContainsAny(l, "+#") && len(l) > 1 {
+			return false
+		}
+		if l == "#" && i != len(levels)-1 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,404 @@
+// Package mqtttest sobe um broker MQTT 3.1.1 em processo para os testes do
+// cmd/ingest: aceita conexões, assinaturas (com curingas) e PINGREQ, e publica
+// para os assinantes esperando a confirmação de cada um. Sessões persistentes
+// (sem clean session) guardam as assinaturas e as publicações sem PUBACK, que
+// são reenviadas na reconexão do mesmo ClientID.
+package mqtttest
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"sync"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ingest/mqtt"
+)
+
+// Broker é o stand-in do broker. Publish com QoS 1 só retorna depois do PUBACK
+// dos assinantes, ou seja, depois que o cliente tratou a mensagem.
+type Broker struct {
+	ln       net.Listener
+	mu       sync.Mutex // protege também o estado das sessões
+	clients  map[*client]bool
+	sessions map[string]*session // sessões persistentes por ClientID
+	changed  chan struct{}       // fechado e trocado a cada nova assinatura
+	wg       sync.WaitGroup
+}
+
+// session é o que sobrevive à conexão quando o cliente não pede sessão limpa.
+type session struct {
+	filters  []string
+	nextID   uint16
+	inflight map[uint16]*message // publicações QoS 1 sem PUBACK
+	conn     *client             // nil com o cliente desconectado
+}
+
+type message struct {
+	topic   string
+	payload []byte
+	acked   chan struct{}
+}
+
+type client struct {
+	conn net.Conn
+	wmu  sync.Mutex
+	sess *session
+}
+
+// NewBroker escuta numa porta livre de 127.0.0.1.
+func NewBroker() (*Broker, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	b := &Broker{ln: ln, clients: map[*client]bool{}, sessions: map[string]*session{}, changed: make(chan struct{})}
+	b.wg.Add(1)
+	go b.accept()
+	return b, nil
+}
+
+// Addr é o endereço para mqtt.Config.Broker (tcp://127.0.0.1:porta).
+func (b *Broker) Addr() string {
+	return "tcp://" + b.ln.Addr().String()
+}
+
+// Close para de aceitar conexões e derruba os clientes.
+func (b *Broker) Close() {
+	b.ln.Close()
+	b.DropClients()
+	b.wg.Wait()
+}
+
+// DropClients derruba as conexões abertas (simula queda de rede ou do broker).
+// As sessões persistentes continuam guardadas.
+func (b *Broker) DropClients() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for c := range b.clients {
+		c.conn.Close()
+	}
+}
+
+// WaitSubscribed espera até algum cliente conectado assinar um filtro que case com topic.
+func (b *Broker) WaitSubscribed(ctx context.Context, topic string) error {
+	for {
+		b.mu.Lock()
+		for c := range b.clients {
+			for _, f := range c.sess.filters {
+				if mqtt.Match(f, topic) {
+					b.mu.Unlock()
+					return nil
+				}
+			}
+		}
+		changed := b.changed
+		b.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("no subscriber for %q: %w", topic, ctx.Err())
+		case <-changed:
+		}
+	}
+}
+
+// Publish entrega payload com QoS 1 às sessões que assinam topic e espera o
+// PUBACK de cada uma. Sessão persistente com o cliente fora recebe na
+// reconexão, e mensagem sem PUBACK é reenviada a cada reconexão. Retorna
+// quantas sessões receberam.
+func (b *Broker) Publish(ctx context.Context, topic string, payload []byte) (int, error) {
+	type delivery struct {
+		c  *client
+		id uint16
+		m  *message
+	}
+	var (
+		pending []*message
+		now     []delivery
+	)
+	b.mu.Lock()
+	targets := map[*session]bool{}
+	for c := range b.clients {
+		targets[c.sess] = true
+	}
+	for _, s := range b.sessions {
+		targets[s] = true
+	}
+	for s := range targets {
+		if !slices.ContainsFunc(s.filters, func(f string) bool { return mqtt.Match(f, topic) }) {
+			continue
+		}
+		m := &message{topic: topic, payload: payload, acked: make(chan struct{})}
+		id := s.add(m)
+		pending = append(pending, m)
+		if s.conn != nil {
+			now = append(now, delivery{s.conn, id, m})
+		}
+	}
+	b.mu.Unlock()
+
+	// Falha de escrita é conexão caindo: a sessão persistente reenvia depois.
+	for _, d := range now {
+		_ = d.c.publish(d.id, d.m, false)
+	}
+	for _, m := range pending {
+		select {
+		case <-m.acked:
+		case <-ctx.Done():
+			return 0, fmt.Errorf("waiting puback on %q: %w", topic, ctx.Err())
+		}
+	}
+	return len(pending), nil
+}
+
+func (b *Broker) accept() {
+	defer b.wg.Done()
+	for {
+		conn, err := b.ln.Accept()
+		if err != nil {
+			return
+		}
+		c := &client{conn: conn}
+		b.wg.Add(1)
+		go func() {
+			defer b.wg.Done()
+			b.serve(c)
+		}()
+	}
+}
+
+func (b *Broker) serve(c *client) {
+	defer c.conn.Close()
+	r := bufio.NewReader(c.conn)
+	typ, body, err := readPacket(r)
+	if err != nil || typ != 1 {
+		return
+	}
+	clientID, clean, err := parseConnect(body)
+	if err != nil {
+		return
+	}
+	if clientID == "" && !clean {
+		_ = c.write(2<<4, []byte{0, 2}) // CONNACK: identificador recusado
+		return
+	}
+
+	b.mu.Lock()
+	s, present := b.sessions[clientID]
+	switch {
+	case clean:
+		delete(b.sessions, clientID)
+		s, present = newSession(), false
+	case !present:
+		s = newSession()
+		b.sessions[clientID] = s
+	}
+	if s.conn != nil {
+		s.conn.conn.Close() // o mesmo ClientID conectou de novo: derruba a conexão antiga
+	}
+	s.conn = c
+	c.sess = s
+	b.clients[c] = true
+	ids := make([]uint16, 0, len(s.inflight))
+	for id := range s.inflight {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	resend := make([]*message, len(ids))
+	for i, id := range ids {
+		resend[i] = s.inflight[id]
+	}
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		delete(b.clients, c)
+		if s.conn == c {
+			s.conn = nil
+		}
+		b.mu.Unlock()
+	}()
+
+	connack := []byte{0, 0}
+	if present {
+		connack[0] = 1 // sessão retomada
+	}
+	if err := c.write(2<<4, connack); err != nil {
+		return
+	}
+	for i, m := range resend {
+		if c.publish(ids[i], m, true) != nil {
+			return
+		}
+	}
+
+	for {
+		typ, body, err := readPacket(r)
+		if err != nil {
+			return
+		}
+		switch typ {
+		case 4: // PUBACK
+			if len(body) == 2 {
+				b.acked(s, binary.BigEndian.Uint16(body))
+			}
+		case 8: // SUBSCRIBE
+			if err := b.subscribe(c, body); err != nil {
+				return
+			}
+		case 12: // PINGREQ
+			if c.write(13<<4, nil) != nil {
+				return
+			}
+		case 14: // DISCONNECT
+			return
+		default:
+			return
+		}
+	}
+}
+
+// parseConnect lê o ClientID e a flag de sessão limpa do CONNECT.
+func parseConnect(body []byte) (clientID string, clean bool, err error) {
+	_, rest, ok := cutString(body) // nome do protocolo
+	if !ok || len(rest) < 4 {
+		return "", false, errors.New("connect: truncated header")
+	}
+	clean = rest[1]&0x02 != 0 // depois do nível do protocolo; o keepalive vem em seguida
+	clientID, _, ok = cutString(rest[4:])
+	if !ok {
+		return "", false, errors.New("connect: truncated client id")
+	}
+	return clientID, clean, nil
+}
+
+func cutString(b []byte) (string, []byte, bool) {
+	if len(b) < 2 {
+		return "", nil, false
+	}
+	n := int(binary.BigEndian.Uint16(b))
+	if len(b) < 2+n {
+		return "", nil, false
+	}
+	return string(b[2 : 2+n]), b[2+n:], true
+}
+
+func (b *Broker) subscribe(c *client, body []byte) error {
+	if len(body) < 2 {
+		return errors.New("subscribe without packet id")
+	}
+	resp := append([]byte(nil), body[:2]...)
+	var filters []string
+	for rest := body[2:]; len(rest) > 0; {
+		if len(rest) < 2 {
+			return errors.New("subscribe: truncated filter")
+		}
+		n := int(binary.BigEndian.Uint16(rest))
+		if len(rest) < 3+n {
+			return errors.New("subscribe: truncated filter")
+		}
+		f := string(rest[2 : 2+n])
+		rest = rest[3+n:]
+		if !mqtt.ValidFilter(f) {
+			resp = append(resp, 0x80)
+			continue
+		}
+		filters = append(filters, f)
+		resp = append(resp, 1)
+	}
+
+	b.mu.Lock()
+	for _, f := range filters {
+		if !slices.Contains(c.sess.filters, f) {
+			c.sess.filters = append(c.sess.filters, f)
+		}
+	}
+	close(b.changed)
+	b.changed = make(chan struct{})
+	b.mu.Unlock()
+	return c.write(9<<4, resp)
+}
+
+func (b *Broker) acked(s *session, id uint16) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if m, ok := s.inflight[id]; ok {
+		close(m.acked)
+		delete(s.inflight, id)
+	}
+}
+
+func newSession() *session {
+	return &session{inflight: map[uint16]*message{}}
+}
+
+// add guarda a mensagem como pendente e devolve o packet id (com b.mu travado).
+func (s *session) add(m *message) uint16 {
+	for {
+		s.nextID++
+		if s.nextID == 0 {
+			s.nextID = 1
+		}
+		if _, busy := s.inflight[s.nextID]; !busy {
+			s.inflight[s.nextID] = m
+			return s.nextID
+		}
+	}
+}
+
+// publish envia o PUBLISH QoS 1; dup marca o reenvio.
+func (c *client) publish(id uint16, m *message, dup bool) error {
+	header := byte(3<<4 | 0x02)
+	if dup {
+		header |= 0x08
+	}
+	body := binary.BigEndian.AppendUint16(nil, uint16(len(m.topic)))
+	body = append(body, m.topic...)
+	body = binary.BigEndian.AppendUint16(body, id)
+	body = append(body, m.payload...)
+	return c.write(header, body)
+}
+
+func (c *client) write(header byte, body []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	buf := []byte{header}
+	for n := len(body); ; {
+		d := byte(n % 128)
+		n /= 128
+		if n > 0 {
+			d |= 0x80
+		}
+		buf = append(buf, d)
+		if n == 0 {
+			break
+		}
+	}
+	_, err := c.conn.Write(append(buf, body...))
+	return err
+}
+
+func readPacket(r *bufio.Reader) (byte, []byte, error) {
+	header, err := r.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	n, mult := 0, 1
+	for i := 0; i < 4; i++ {
+		d, err := r.ReadByte()
+		if err != nil {
+			return 0, nil, err
+		}
+		n += int(d&0x7f) * mult
+		if d&0x80 == 0 {
+			body := make([]byte, n)
+			_, err := io.ReadFull(r, body)
+			return header >> 4, body, err
+		}
+		mult *= 128
+	}
+	return 0, nil, errors.New("invalid remaining length")
+}
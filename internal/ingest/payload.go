@@ -0,0 +1,265 @@
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// reading é um valor do sinal extraído de uma mensagem.
+type reading struct {
+	value any       // como decodificado do JSON (números em json.Number)
+	at    time.Time // zero = horário de chegada
+}
+
+// extract devolve as leituras do sinal na mensagem. Mensagem OPC UA sem o campo
+// do sinal (ex: outro DataSetWriter no mesmo tópico) não tem leitura nem erro.
+func (s *Signal) extract(payload []byte) ([]reading, error) {
+	if s.Format == FormatOPCUA {
+		doc, err := decode(payload)
+		if err != nil {
+			return nil, err
+		}
+		return s.extractOPCUA(doc)
+	}
+
+	doc, err := decode(payload)
+	if err != nil {
+		if s.Field != "" {
+			return nil, err
+		}
+		// Valor solto sem aspas (ex: RUN), como muitos CLPs publicam.
+		return []reading{{value: strings.TrimSpace(string(payload))}}, nil
+	}
+	r := reading{value: doc}
+	if s.Field != "" {
+		v, ok := lookup(doc, s.Field)
+		if !ok {
+			return nil, fmt.Errorf("field %q not found", s.Field)
+		}
+		r.value = v
+	}
+	if s.TimestampField != "" {
+		v, ok := lookup(doc, s.TimestampField)
+		if !ok {
+			return nil, fmt.Errorf("field %q not found", s.TimestampField)
+		}
+		if r.at, err = parseTime(v); err != nil {
+			return nil, err
+		}
+	}
+	return []reading{r}, nil
+}
+
+// extractOPCUA lê a mensagem JSON do OPC UA PubSub (Part 14, 7.2.5): um
+// NetworkMessage com a lista Messages, um DataSetMessage avulso ou só o Payload.
+// Os campos podem vir como valor simples, Variant ({"Type", "Body"} ou
+// {"UaType", "Value"}) ou DataValue ({"Value", "StatusCode", "SourceTimestamp"});
+// valores com StatusCode Bad são descartados.
+func (s *Signal) extractOPCUA(doc any) ([]reading, error) {
+	root, ok := doc.(map[string]any)
+	if !ok {
+		return nil, errors.New("opcua: payload is not a JSON object")
+	}
+	if t, _ := root["MessageType"].(string); t != "" && t != "ua-data" {
+		return nil, nil // ex: ua-metadata
+	}
+	messages := []any{root}
+	if list, ok := root["Messages"].([]any); ok {
+		messages = list
+	}
+
+	var out []reading
+	for _, m := range messages {
+		dsm, ok := m.(map[string]any)
+		if !ok {
+			continue
+		}
+		if s.WriterID != 0 {
+			id, ok := dsm["DataSetWriterId"].(json.Number)
+			if !ok || id.String() != strconv.FormatInt(s.WriterID, 10) {
+				continue
+			}
+		}
+		fields := dsm
+		if p, ok := dsm["Payload"].(map[string]any); ok {
+			fields = p
+		}
+		v, ok := fields[s.Node]
+		if !ok {
+			continue
+		}
+		r, good := dataValue(v)
+		if !good {
+			continue
+		}
+		if r.at.IsZero() {
+			if ts, ok := dsm["Timestamp"]; ok {
+				r.at, _ = parseTime(ts)
+			}
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+func dataValue(v any) (reading, bool) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return reading{value: v}, true
+	}
+	if _, variant := m["UaType"]; variant {
+		return reading{value: variantValue(m)}, true
+	}
+	if _, variant := m["Body"]; variant {
+		return reading{value: variantValue(m)}, true
+	}
+	if !hasAny(m, "Value", "StatusCode", "SourceTimestamp") {
+		return reading{value: v}, true
+	}
+	if bad(m["StatusCode"]) {
+		return reading{}, false
+	}
+	r := reading{value: m["Value"]}
+	if inner, ok := r.value.(map[string]any); ok {
+		r.value = variantValue(inner)
+	}
+	for _, key := range []string{"SourceTimestamp", "ServerTimestamp"} {
+		if ts, ok := m[key]; ok {
+			if at, err := parseTime(ts); err == nil {
+				r.at = at
+				break
+			}
+		}
+	}
+	return r, true
+}
+
+func variantValue(m map[string]any) any {
+	if _, ok := m["UaType"]; ok {
+		return m["Value"]
+	}
+	if body, ok := m["Body"]; ok {
+		return body
+	}
+	return m
+}
+
+// bad diz se o StatusCode (número ou {"Code": n}) é Bad: bit mais alto ligado.
+func bad(status any) bool {
+	if m, ok := status.(map[string]any); ok {
+		status = m["Code"]
+	}
+	n, ok := status.(json.Number)
+	if !ok {
+		return false
+	}
+	code, err := strconv.ParseUint(n.String(), 10, 32)
+	return err == nil && code&0x80000000 != 0
+}
+
+func hasAny(m map[string]any, keys ...string) bool {
+	return slices.ContainsFunc(keys, func(k string) bool {
+		_, ok := m[k]
+		return ok
+	})
+}
+
+func decode(payload []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode payload: %w", err)
+	}
+	return doc, nil
+}
+
+// lookup segue o caminho com pontos (ex: data.counter) nos objetos aninhados.
+func lookup(doc any, path string) (any, bool) {
+	v := doc
+	for key := range strings.SplitSeq(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[key]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+// parseTime aceita RFC 3339 ou epoch em milissegundos.
+func parseTime(v any) (time.Time, error) {
+	switch t := v.(type) {
+	case string:
+		return time.Parse(time.RFC3339Nano, t)
+	case json.Number:
+		ms, err := t.Int64()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("timestamp %s: %w", t, err)
+		}
+		return time.UnixMilli(ms), nil
+	}
+	return time.Time{}, fmt.Errorf("timestamp %v: unsupported type", v)
+}
+
+// number converte o valor (número, texto numérico ou booleano) aplicando a escala.
+func (s *Signal) number(v any) (float64, error) {
+	var f float64
+	var err error
+	switch t := v.(type) {
+	case json.Number:
+		f, err = t.Float64()
+	case string:
+		f, err = strconv.ParseFloat(strings.TrimSpace(t), 64)
+	case bool:
+		if t {
+			f = 1
+		}
+	default:
+		err = fmt.Errorf("value %v is not a number", v)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return f * s.Scale, nil
+}
+
+// running interpreta o estado de marcha: pela lista Running do sinal, se houver,
+// ou pelos valores usuais de CLP.
+func (s *Signal) running(v any) (bool, error) {
+	text := valueText(v)
+	if len(s.Running) > 0 {
+		return slices.ContainsFunc(s.Running, func(r string) bool { return strings.EqualFold(r, text) }), nil
+	}
+	if n, ok := v.(json.Number); ok {
+		f, err := n.Float64()
+		return f != 0, err
+	}
+	switch strings.ToLower(text) {
+	case "true", "1", "on", "run", "running":
+		return true, nil
+	case "false", "0", "off", "stop", "stopped":
+		return false, nil
+	}
+	return false, fmt.Errorf("state %q not mapped (set running in the mapping)", text)
+}
+
+func valueText(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case json.Number:
+		return t.String()
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	return fmt.Sprint(v)
+}
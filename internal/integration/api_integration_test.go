@@ -11,14 +11,17 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/http/handlers"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/http/middleware"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/postgres"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
 )
 
-func setupAPI(t *testing.T) *gin.Engine {
+func connectDB(t *testing.T) *postgres.DB {
 	t.Helper()
 
 	os.Setenv("DB_HOST", "localhost")
@@ -27,28 +30,42 @@ func setupAPI(t *testing.T) *gin.Engine {
 	os.Setenv("DB_PASS", "dev")
 	os.Setenv("DB_NAME", "maintenance")
 
-	ctx := context.Background()
-
-	db, err := postgres.New(ctx)
+	db, err := postgres.New(context.Background())
 	if err != nil {
 		t.Fatalf("failed to connect to DB: %v", err)
 	}
+	return db
+}
+
+func setupAPI(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	db := connectDB(t)
 
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
 	r.Use(gin.Recovery())
+	// Usuário do site 1 (MATRIZ), criado pela migração de sites.
+	r.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(tenant.WithPrincipal(c.Request.Context(), tenant.Principal{UserID: 1, SiteID: 1}))
+	}, middleware.IdempotencyMiddleware(service.NewIdempotencyService(postgres.NewIdempotencyRepo(db))))
 
 	assetRepo := postgres.NewAssetRepo(db)
 	workOrderRepo := postgres.NewWorkOrderRepo(db)
 
+	checklistRepo := postgres.NewChecklistRepo(db)
+
 	assetSvc := service.NewAssetService(assetRepo)
 	workOrderSvc := service.NewWorkOrderService(workOrderRepo)
+	syncSvc := service.NewSyncService(postgres.NewSyncRepo(db), workOrderRepo, checklistRepo, workOrderSvc)
 
 	assetHandler := handlers.NewAssetHandler(assetSvc)
 	workOrderHandler := handlers.NewWorkOrderHandler(workOrderSvc)
+	syncHandler := handlers.NewSyncHandler(syncSvc)
 
 	assetHandler.RegisterRoutes(r)
 	workOrderHandler.RegisterRoutes(r)
+	syncHandler.RegisterRoutes(r)
 
 	return r
 }
@@ -56,15 +73,25 @@ func setupAPI(t *testing.T) *gin.Engine {
 func TestIntegration_AssetsAndWorkOrders(t *testing.T) {
 	r := setupAPI(t)
 
-	// Criar um ativo
+	// Criar um ativo, repetindo a requisição com a mesma Idempotency-Key
 	assetPayload := []byte(`{"name":"IntegrTest Cortadeira","location":"Galpão A","criticality":"A"}`)
-	req := httptest.NewRequest(http.MethodPost, "/assets", bytes.NewReader(assetPayload))
-	req.Header.Set("Content-Type", "application/json")
-	w := httptest.NewRecorder()
-	r.ServeHTTP(w, req)
-
-	if w.Code != http.StatusCreated {
-		t.Fatalf("expected 201, got %d; body=%s", w.Code, w.Body.String())
+	key := fmt.Sprintf("integration-%d", time.Now().UnixNano())
+	var first string
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/assets", bytes.NewReader(assetPayload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Idempotency-Key", key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d; body=%s", w.Code, w.Body.String())
+		}
+		if i == 0 {
+			first = w.Body.String()
+		} else if w.Body.String() != first || w.Header().Get("Idempotent-Replayed") != "true" {
+			t.Fatalf("expected replay of %s, got %s", first, w.Body.String())
+		}
 	}
 
 	// Listar ativos
@@ -86,6 +113,16 @@ func TestIntegration_AssetsAndWorkOrders(t *testing.T) {
 
 	id := int(assets[len(assets)-1]["id"].(float64))
 
+	// Token de sincronização atual, para conferir o delta depois de abrir a OS
+	since := ""
+	for {
+		page := syncChanges(t, r, since)
+		since = page.Next
+		if !page.HasMore {
+			break
+		}
+	}
+
 	// Criar OS vinculada ao ativo
 	payloadWO := []byte(fmt.Sprintf(`{"asset_id":%d,"type":"corrective","title":"Lubrificar rolamento","description":"rolamento ruidoso"}`, id))
 	reqWO := httptest.NewRequest(http.MethodPost, "/work-orders", bytes.NewReader(payloadWO))
@@ -112,4 +149,35 @@ func TestIntegration_AssetsAndWorkOrders(t *testing.T) {
 	if len(workOrders) == 0 {
 		t.Fatalf("expected at least 1 work order, got 0")
 	}
+
+	// A OS nova vem no delta da sincronização
+	var created map[string]any
+	_ = json.Unmarshal(wWO.Body.Bytes(), &created)
+	delta := syncChanges(t, r, since)
+	if len(delta.Changes) == 0 || delta.Changes[0].Entity != "work_order" || delta.Changes[0].ID != int64(created["id"].(float64)) {
+		t.Fatalf("expected the new work order in the sync delta, got %+v", delta.Changes)
+	}
+}
+
+type syncPage struct {
+	Changes []struct {
+		Entity string `json:"entity"`
+		ID     int64  `json:"id"`
+	} `json:"changes"`
+	Next    string `json:"next"`
+	HasMore bool   `json:"has_more"`
+}
+
+func syncChanges(t *testing.T, r *gin.Engine, since string) syncPage {
+	t.Helper()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/sync/changes?limit=1000&since="+since, nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("sync changes: expected 200, got %d; body=%s", w.Code, w.Body.String())
+	}
+	var page syncPage
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil {
+		t.Fatalf("failed to unmarshal sync page: %v", err)
+	}
+	return page
 }
@@ -0,0 +1,273 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/postgres"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// siteOne é o usuário do site 1 (MATRIZ), criado pela migração de sites.
+func siteOne() context.Context {
+	return tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 1, SiteID: 1})
+}
+
+func TestIntegration_WorkOrderWritesRollBackWithTx(t *testing.T) {
+	db := connectDB(t)
+	defer db.Pool.Close()
+	ctx := siteOne()
+	assets := postgres.NewAssetRepo(db)
+	orders := postgres.NewWorkOrderRepo(db)
+
+	asset := domain.Asset{Name: "Prensa rollback", Criticality: domain.CriticalityB}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	wo := domain.WorkOrder{AssetID: asset.ID, Type: domain.WOTypeCorrective, Status: domain.WOStatusOpen, Title: "Antes"}
+	if err := orders.Create(ctx, &wo); err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	rollback := errors.New("rollback")
+	err := db.InTx(ctx, func(ctx context.Context) error {
+		changed := wo
+		changed.Title = "Dentro da transação"
+		if err := orders.Update(ctx, &changed); err != nil {
+			return err
+		}
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatalf("InTx() error = %v, want the rollback error", err)
+	}
+	got, err := orders.FindByID(ctx, wo.ID)
+	if err != nil || got.Title != "Antes" {
+		t.Fatalf("FindByID() = %+v, %v; the update should roll back with the transaction", got, err)
+	}
+}
+
+// Os repositórios sem site_id próprio filtram pelo site do ativo ou da OS; um
+// usuário de outro site (que nem precisa existir) não lê nem altera nada.
+func TestIntegration_AssetRecordsFollowTheAssetSite(t *testing.T) {
+	db := connectDB(t)
+	defer db.Pool.Close()
+	ctx := siteOne()
+	other := tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 1, SiteID: 2})
+	assets := postgres.NewAssetRepo(db)
+	orders := postgres.NewWorkOrderRepo(db)
+	events := postgres.NewDowntimeRepo(db)
+	signals := postgres.NewSignalRepo(db)
+	counts := postgres.NewProductionRepo(db)
+	checklists := postgres.NewChecklistRepo(db)
+	jobPlans := postgres.NewJobPlanRepo(db)
+
+	asset := domain.Asset{Name: "Prensa escopo", Criticality: domain.CriticalityB}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	wo := domain.WorkOrder{AssetID: asset.ID, Type: domain.WOTypeCorrective, Status: domain.WOStatusOpen, Title: "Escopo"}
+	if err := orders.Create(ctx, &wo); err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+	start := time.Now().UTC().Truncate(time.Second)
+	event := domain.DowntimeEvent{AssetID: asset.ID, ReasonCode: "MEC", StartedAt: start}
+	if err := events.Create(ctx, &event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	if err := signals.CreateMeterReading(ctx, &domain.MeterReading{AssetID: asset.ID, Value: 10, ReadAt: start}); err != nil {
+		t.Fatalf("create reading: %v", err)
+	}
+	if err := checklists.Create(ctx, wo.ID, []domain.ChecklistItem{{Step: 1, Description: "Apertar"}}); err != nil {
+		t.Fatalf("create checklist: %v", err)
+	}
+	plan := domain.JobPlan{Name: "Roteiro escopo"}
+	if err := jobPlans.Create(ctx, &plan); err != nil {
+		t.Fatalf("create job plan: %v", err)
+	}
+
+	if _, err := events.FindOpenByAsset(other, asset.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("other site open downtime: err = %v, want ErrNotFound", err)
+	}
+	end := start.Add(time.Minute)
+	event.EndedAt = &end
+	if err := events.Update(other, &event); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("other site closing downtime: err = %v, want ErrNotFound", err)
+	}
+	if err := events.Create(other, &domain.DowntimeEvent{AssetID: asset.ID, ReasonCode: "MEC", StartedAt: end}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("other site downtime: err = %v, want ErrNotFound", err)
+	}
+	if _, err := signals.MeterReadingAt(other, asset.ID, start); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("other site meter reading: err = %v, want ErrNotFound", err)
+	}
+	if _, err := counts.CreateBatch(other, []domain.ProductionCount{{AssetID: asset.ID, PeriodStart: start, PeriodEnd: end, TotalCount: 1}}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("other site production: err = %v, want ErrInvalidInput", err)
+	}
+	if items, err := checklists.FindByWorkOrder(other, wo.ID); err != nil || len(items) != 0 {
+		t.Errorf("other site checklist = %v, %v; want none", items, err)
+	}
+	if err := checklists.UpdateItem(other, wo.ID, &domain.ChecklistItem{Step: 1, Done: true}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("other site checklist update: err = %v, want ErrNotFound", err)
+	}
+	if _, err := jobPlans.FindByID(other, plan.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("other site job plan: err = %v, want ErrNotFound", err)
+	}
+}
+
+// Escritas concorrentes não disputam contador: a OS B grava e confirma enquanto
+// a transação da OS A segue aberta. O delta segura B até A terminar, porque A
+// tem xid menor e sua revisão ficaria atrás do token entregue.
+func TestIntegration_SyncRevisionsWithoutCounterLock(t *testing.T) {
+	db := connectDB(t)
+	defer db.Pool.Close()
+	ctx := siteOne()
+	assets := postgres.NewAssetRepo(db)
+	orders := postgres.NewWorkOrderRepo(db)
+	changes := postgres.NewSyncRepo(db)
+
+	asset := domain.Asset{Name: "Prensa revisões", Criticality: domain.CriticalityB}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	a := domain.WorkOrder{AssetID: asset.ID, Type: domain.WOTypeCorrective, Status: domain.WOStatusOpen, Title: "A"}
+	b := domain.WorkOrder{AssetID: asset.ID, Type: domain.WOTypeCorrective, Status: domain.WOStatusOpen, Title: "B"}
+	for _, o := range []*domain.WorkOrder{&a, &b} {
+		if err := orders.Create(ctx, o); err != nil {
+			t.Fatalf("create work order: %v", err)
+		}
+	}
+	since := max(a.Revision, b.Revision)
+
+	txA, err := db.Pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer txA.Rollback(context.Background())
+	if _, err := txA.Exec(ctx, `UPDATE work_orders SET title = 'A2' WHERE id = $1;`, a.ID); err != nil {
+		t.Fatalf("update A: %v", err)
+	}
+
+	// Com a antiga linha de contador, este update esperaria o commit de A.
+	quick, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	b.Title = "B2"
+	if err := orders.Update(quick, &b); err != nil {
+		t.Fatalf("update B while A is open: %v", err)
+	}
+	if b.Revision <= since {
+		t.Fatalf("B revision %d did not advance past %d", b.Revision, since)
+	}
+
+	seen := func() map[int64]int64 {
+		list, err := changes.WorkOrderChanges(ctx, since, 1000)
+		if err != nil {
+			t.Fatalf("work order changes: %v", err)
+		}
+		revs := map[int64]int64{}
+		for _, o := range list {
+			revs[o.ID] = o.Revision
+		}
+		return revs
+	}
+	if revs := seen(); len(revs) != 0 {
+		t.Fatalf("delta while A is open = %v, want nothing past the watermark", revs)
+	}
+
+	if err := txA.Commit(ctx); err != nil {
+		t.Fatalf("commit A: %v", err)
+	}
+	revs := seen()
+	if revs[a.ID] == 0 || revs[b.ID] != b.Revision {
+		t.Fatalf("delta after commit = %v, want A and B (revision %d)", revs, b.Revision)
+	}
+	if revs[a.ID] >= revs[b.ID] {
+		t.Errorf("A revision %d should sort before B %d (older transaction)", revs[a.ID], revs[b.ID])
+	}
+}
+
+func TestIntegration_SyncFollowsTheAssignee(t *testing.T) {
+	db := connectDB(t)
+	defer db.Pool.Close()
+	ctx := siteOne()
+	assets := postgres.NewAssetRepo(db)
+	orders := postgres.NewWorkOrderRepo(db)
+	technicians := postgres.NewTechnicianRepo(db)
+	changes := postgres.NewSyncRepo(db)
+
+	shift := domain.Shift{Name: "Turno sync", StartTime: "06:00", EndTime: "14:00", Weekdays: []int{1, 2, 3, 4, 5}}
+	if err := postgres.NewShiftRepo(db).Create(ctx, &shift); err != nil {
+		t.Fatalf("create shift: %v", err)
+	}
+	var techs [2]domain.Technician
+	for i := range techs {
+		u := domain.User{Name: "Técnico sync", Active: true}
+		if err := postgres.NewUserRepo(db).Create(ctx, &u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		techs[i] = domain.Technician{Name: "Técnico sync", ShiftID: shift.ID, Active: true, UserID: &u.ID}
+		if err := technicians.Create(ctx, &techs[i]); err != nil {
+			t.Fatalf("create technician: %v", err)
+		}
+		if got, err := technicians.FindByUser(ctx, u.ID); err != nil || got.ID != techs[i].ID {
+			t.Fatalf("FindByUser = %+v, %v", got, err)
+		}
+	}
+	a, b := techs[0].ID, techs[1].ID
+
+	asset := domain.Asset{Name: "Prensa responsável", Criticality: domain.CriticalityB}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	wo := domain.WorkOrder{AssetID: asset.ID, Type: domain.WOTypeCorrective, Status: domain.WOStatusOpen, Title: "Vazamento", AssigneeID: &a}
+	if err := orders.Create(ctx, &wo); err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+	if err := postgres.NewChecklistRepo(db).Create(ctx, wo.ID, []domain.ChecklistItem{{Step: 1, Description: "Reaperto"}}); err != nil {
+		t.Fatalf("create checklist: %v", err)
+	}
+
+	delta := func(technicianID, since int64) []domain.SyncChange {
+		t.Helper()
+		list, err := changes.Changes(ctx, since, technicianID, 1000)
+		if err != nil {
+			t.Fatalf("changes: %v", err)
+		}
+		var mine []domain.SyncChange
+		for _, ch := range list {
+			if ch.WorkOrderID == wo.ID || (ch.WorkOrder != nil && ch.WorkOrder.ID == wo.ID) {
+				mine = append(mine, ch)
+			}
+		}
+		return mine
+	}
+	if got := delta(a, 0); len(got) != 2 {
+		t.Fatalf("assignee initial load = %+v, want the order and its step", got)
+	}
+	if got := delta(b, 0); len(got) != 0 {
+		t.Fatalf("other technician got %+v", got)
+	}
+	since := wo.Revision
+
+	wo.AssigneeID = &b
+	if err := orders.Update(ctx, &wo); err != nil {
+		t.Fatalf("reassign: %v", err)
+	}
+	if got := delta(a, since); len(got) != 1 || !got[0].Deleted || got[0].WorkOrder.ID != wo.ID {
+		t.Fatalf("previous assignee delta = %+v, want a deletion", got)
+	}
+	if got := delta(b, since); len(got) != 2 || got[0].Deleted {
+		t.Fatalf("new assignee delta = %+v, want the order and its step", got)
+	}
+
+	// O responsável precisa ser um técnico do site da OS.
+	missing := b + 1_000_000
+	other := domain.WorkOrder{AssetID: asset.ID, Type: domain.WOTypeCorrective, Status: domain.WOStatusOpen, Title: "Ruído", AssigneeID: &missing}
+	if err := orders.Create(ctx, &other); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("unknown assignee error = %v, want ErrInvalidInput", err)
+	}
+}
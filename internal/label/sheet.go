@@ -0,0 +1,100 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Label é uma etiqueta da folha: o código (Payload) e o texto ao lado dele.
+type Label struct {
+	Payload string   // conteúdo codificado (tag ou URL de leitura)
+	Tag     string   // tag impressa em destaque, para digitação quando o código não lê
+	Title   string   // nome do ativo
+	Lines   []string // informações extras (localização, fabricante/modelo...)
+}
+
+// Layout da folha A4 no padrão 3 × 8 (etiquetas de 70 × 37 mm, sem margem entre elas).
+const (
+	sheetCols    = 3
+	sheetRows    = 8
+	labelWidth   = 70.0
+	labelHeight  = 37.0
+	sheetTop     = 0.5
+	labelPadding = 2.5
+	// maxTitleRunes limita o nome a ~2 linhas para não invadir a etiqueta de baixo.
+	maxTitleRunes = 48
+)
+
+// WriteSheet gera o PDF com uma etiqueta por item, quebrando página a cada 24.
+// No Code 128 o código ocupa a largura da etiqueta e o texto vai embaixo.
+func WriteSheet(w io.Writer, kind Symbology, labels []Label) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	for i, l := range labels {
+		slot := i % (sheetCols * sheetRows)
+		if slot == 0 {
+			pdf.AddPage()
+		}
+		x := float64(slot%sheetCols) * labelWidth
+		y := sheetTop + float64(slot/sheetCols)*labelHeight
+
+		sym, err := Encode(kind, l.Payload)
+		if err != nil {
+			return err
+		}
+		var img bytes.Buffer
+		if err := sym.WritePNG(&img, 600); err != nil {
+			return err
+		}
+		name := fmt.Sprintf("label-%d", i)
+		pdf.RegisterImageOptionsReader(name, fpdf.ImageOptions{ImageType: "PNG"}, &img)
+
+		textX, textY, textW := x+labelPadding, y+labelPadding, labelWidth-2*labelPadding
+		if kind == SymbologyCode128 {
+			gw, gh := sym.grid()
+			imgW := labelWidth - 2*labelPadding
+			pdf.ImageOptions(name, x+labelPadding, y+labelPadding, imgW, 0, false, fpdf.ImageOptions{}, 0, "")
+			textY += imgW*float64(gh)/float64(gw) + 1
+		} else {
+			side := labelHeight - 2*labelPadding
+			pdf.ImageOptions(name, x+labelPadding, y+labelPadding, side, side, false, fpdf.ImageOptions{}, 0, "")
+			textX += side + labelPadding
+			textW -= side + labelPadding
+		}
+
+		pdf.SetXY(textX, textY)
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(textW, 5, tr(l.Tag), "", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 8)
+		pdf.MultiCell(textW, 3.5, tr(truncate(l.Title, maxTitleRunes)), "", "L", false)
+		pdf.SetFont("Helvetica", "", 7)
+		for _, line := range l.Lines {
+			if line == "" {
+				continue
+			}
+			pdf.SetX(textX)
+			pdf.CellFormat(textW, 3.5, tr(line), "", 2, "L", false, 0, "")
+		}
+	}
+	if len(labels) == 0 {
+		pdf.AddPage()
+	}
+	if err := pdf.Error(); err != nil {
+		return fmt.Errorf("labels pdf: %w", err)
+	}
+	return pdf.Output(w)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,156 @@
+// Package label gera as etiquetas dos ativos: QR code ou código de barras
+// (Code 128) em PNG/SVG e folhas de etiquetas em PDF para impressão.
+package label
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+// Symbology é o tipo de código impresso na etiqueta.
+type Symbology string
+
+const (
+	SymbologyQR      Symbology = "qr"
+	SymbologyCode128 Symbology = "code128"
+)
+
+// ErrUnsupportedSymbology indica um tipo de código desconhecido.
+var ErrUnsupportedSymbology = errors.New("unsupported symbology")
+
+// ParseSymbology aceita "" (QR) e os nomes de Symbology sem diferenciar maiúsculas.
+func ParseSymbology(s string) (Symbology, error) {
+	switch Symbology(strings.ToLower(s)) {
+	case "", SymbologyQR:
+		return SymbologyQR, nil
+	case SymbologyCode128:
+		return SymbologyCode128, nil
+	}
+	return "", ErrUnsupportedSymbology
+}
+
+// Symbol é o código já codificado, em módulos (1 módulo = 1 ponto do QR ou 1 barra estreita).
+type Symbol struct {
+	kind    Symbology
+	modules [][]bool // [linha][coluna]; códigos de barras têm uma única linha
+}
+
+// Encode codifica o conteúdo; QR usa correção de erro M (etiqueta suja ainda lê).
+func Encode(kind Symbology, content string) (*Symbol, error) {
+	var (
+		bc  barcode.Barcode
+		err error
+	)
+	switch kind {
+	case SymbologyQR:
+		bc, err = qr.Encode(content, qr.M, qr.Auto)
+	case SymbologyCode128:
+		bc, err = code128.Encode(content)
+	default:
+		return nil, ErrUnsupportedSymbology
+	}
+	if err != nil {
+		return nil, fmt.Errorf("encode %s: %w", kind, err)
+	}
+
+	b := bc.Bounds()
+	rows := b.Dy()
+	if kind == SymbologyCode128 {
+		rows = 1
+	}
+	s := &Symbol{kind: kind, modules: make([][]bool, rows)}
+	for y := range rows {
+		s.modules[y] = make([]bool, b.Dx())
+		for x := range b.Dx() {
+			r, _, _, _ := bc.At(b.Min.X+x, b.Min.Y+y).RGBA()
+			s.modules[y][x] = r == 0
+		}
+	}
+	return s, nil
+}
+
+// quiet é a margem branca exigida pelos leitores, em módulos.
+func (s *Symbol) quiet() int {
+	if s.kind == SymbologyCode128 {
+		return 10
+	}
+	return 4
+}
+
+// grid devolve largura e altura em módulos, margem incluída. O Code 128 tem
+// altura de 1/3 da largura para continuar legível quando reduzido.
+func (s *Symbol) grid() (w, h int) {
+	q := s.quiet()
+	w = len(s.modules[0]) + 2*q
+	if s.kind == SymbologyCode128 {
+		return w, max(w/3, 2*q)
+	}
+	return w, len(s.modules) + 2*q
+}
+
+// dark diz se o módulo (x, y) da grade com margem é preto.
+func (s *Symbol) dark(x, y int) bool {
+	q := s.quiet()
+	x -= q
+	if x < 0 || x >= len(s.modules[0]) {
+		return false
+	}
+	if s.kind == SymbologyCode128 {
+		_, h := s.grid()
+		return y >= q/2 && y < h-q/2 && s.modules[0][x]
+	}
+	y -= q
+	return y >= 0 && y < len(s.modules) && s.modules[y][x]
+}
+
+// WritePNG desenha o código com largura de até size pixels (arredondada para
+// um número inteiro de pixels por módulo, o que mantém as bordas nítidas).
+func (s *Symbol) WritePNG(w io.Writer, size int) error {
+	gw, gh := s.grid()
+	scale := max(size/gw, 1)
+	img := image.NewGray(image.Rect(0, 0, gw*scale, gh*scale))
+	for py := range gh * scale {
+		for px := range gw * scale {
+			c := color.Gray{Y: 255}
+			if s.dark(px/scale, py/scale) {
+				c.Y = 0
+			}
+			img.SetGray(px, py, c)
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// WriteSVG gera um SVG vetorial com size pixels de largura; módulos pretos
+// vizinhos na mesma linha viram um único retângulo.
+func (s *Symbol) WriteSVG(w io.Writer, size int) error {
+	gw, gh := s.grid()
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`,
+		size, size*gh/gw, gw, gh)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="#fff"/><path fill="#000" d="`, gw, gh)
+	for y := range gh {
+		for x := 0; x < gw; x++ {
+			if !s.dark(x, y) {
+				continue
+			}
+			start := x
+			for x < gw && s.dark(x, y) {
+				x++
+			}
+			fmt.Fprintf(&b, "M%d %dh%dv1h-%dz", start, y, x-start, x-start)
+		}
+	}
+	b.WriteString(`"/></svg>`)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
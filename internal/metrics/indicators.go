@@ -0,0 +1,71 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// IndicatorSource fornece os indicadores de domínio (implementado por service.IndicatorService).
+type IndicatorSource interface {
+	Current(ctx context.Context) (*domain.Indicators, error)
+}
+
+// IndicatorCollector calcula os gauges de domínio no momento do scrape.
+type IndicatorCollector struct {
+	source  IndicatorSource
+	timeout time.Duration
+
+	openOrders   *prometheus.Desc
+	overduePlans *prometheus.Desc
+	assetsDown   *prometheus.Desc
+	overdueWOs   *prometheus.Desc
+}
+
+func NewIndicatorCollector(source IndicatorSource) *IndicatorCollector {
+	return &IndicatorCollector{
+		source:  source,
+		timeout: 5 * time.Second,
+
+		openOrders: prometheus.NewDesc("maintenance_work_orders_open",
+			"OS em aberto por status, tipo e criticidade do ativo.",
+			[]string{"status", "type", "criticality"}, nil),
+		overduePlans: prometheus.NewDesc("maintenance_plans_overdue",
+			"Planos preventivos por tempo com vencimento ultrapassado.", nil, nil),
+		assetsDown: prometheus.NewDesc("maintenance_assets_down",
+			"Ativos atualmente parados (parada em andamento).", nil, nil),
+		overdueWOs: prometheus.NewDesc("maintenance_work_orders_sla_overdue",
+			"OS em aberto com prazo de SLA (atendimento ou conclusão) vencido.", nil, nil),
+	}
+}
+
+func (c *IndicatorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.openOrders
+	ch <- c.overduePlans
+	ch <- c.assetsDown
+	ch <- c.overdueWOs
+}
+
+func (c *IndicatorCollector) Collect(ch chan<- prometheus.Metric) {
+	// Os gauges da instância somam todos os sites.
+	ctx, cancel := context.WithTimeout(tenant.System(context.Background()), c.timeout)
+	defer cancel()
+
+	ind, err := c.source.Current(ctx)
+	if err != nil {
+		// Falha vira erro no scrape (e não gauges zerados que disparariam alertas falsos).
+		ch <- prometheus.NewInvalidMetric(c.openOrders, err)
+		return
+	}
+
+	for _, wc := range ind.OpenWorkOrders {
+		ch <- prometheus.MustNewConstMetric(c.openOrders, prometheus.GaugeValue, float64(wc.Count),
+			string(wc.Status), string(wc.Type), string(wc.Criticality))
+	}
+	ch <- prometheus.MustNewConstMetric(c.overduePlans, prometheus.GaugeValue, float64(ind.OverduePlans))
+	ch <- prometheus.MustNewConstMetric(c.assetsDown, prometheus.GaugeValue, float64(ind.AssetsDown))
+	ch <- prometheus.MustNewConstMetric(c.overdueWOs, prometheus.GaugeValue, float64(ind.OverdueOrders))
+}
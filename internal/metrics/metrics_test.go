@@ -0,0 +1,70 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/metrics"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+func TestMetricsEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 1, SiteID: 1})
+
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	plans := memory.NewMaintenancePlanMemoryRepo()
+
+	crit := domain.Asset{Name: "Cortadeira", Criticality: domain.CriticalityA}
+	if err := assets.Create(ctx, &crit); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	breakdown := time.Now().Add(-time.Hour)
+	_ = orders.Create(ctx, &domain.WorkOrder{AssetID: crit.ID, Type: domain.WOTypeCorrective, Status: domain.WOStatusOpen, Title: "Quebra faca", BreakdownAt: &breakdown})
+	_ = orders.Create(ctx, &domain.WorkOrder{AssetID: crit.ID, Type: domain.WOTypeCorrective, Status: domain.WOStatusDone, Title: "Antiga"})
+
+	freq := int64(7)
+	last := time.Now().AddDate(0, 0, -30)
+	_ = plans.Create(ctx, &domain.MaintenancePlan{AssetID: crit.ID, RuleType: domain.PlanRuleTime, FrequencyDays: &freq, LastExecution: &last, Active: true})
+
+	reg := metrics.NewRegistry()
+	httpMetrics := metrics.NewHTTPMetrics(reg)
+	indicators := service.NewIndicatorService(memory.NewIndicatorMemoryRepo(assets, orders, plans, memory.NewDowntimeMemoryRepo(assets)))
+	reg.MustRegister(metrics.NewIndicatorCollector(indicators))
+
+	r := gin.New()
+	r.Use(httpMetrics.Middleware())
+	r.GET("/assets/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	r.GET("/metrics", metrics.Handler(reg))
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/assets/42", nil))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET /metrics expected 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+
+	want := []string{
+		`http_requests_total{method="GET",route="/assets/:id",status="204"} 1`,
+		`http_request_duration_seconds_count{method="GET",route="/assets/:id",status="204"} 1`,
+		`maintenance_work_orders_open{criticality="A",status="open",type="corrective"} 1`,
+		`maintenance_plans_overdue 1`,
+		`maintenance_assets_down 1`,
+	}
+	for _, line := range want {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected metrics output to contain %q", line)
+		}
+	}
+}
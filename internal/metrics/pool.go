@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolCollector expõe as estatísticas do pgxpool a cada scrape.
+type PoolCollector struct {
+	stat func() *pgxpool.Stat
+
+	acquired     *prometheus.Desc
+	idle         *prometheus.Desc
+	total        *prometheus.Desc
+	max          *prometheus.Desc
+	acquires     *prometheus.Desc
+	acquireWait  *prometheus.Desc
+	emptyAcquire *prometheus.Desc
+}
+
+func NewPoolCollector(pool *pgxpool.Pool) *PoolCollector {
+	return &PoolCollector{
+		stat: pool.Stat,
+
+		acquired:     prometheus.NewDesc("pgxpool_acquired_connections", "Conexões em uso.", nil, nil),
+		idle:         prometheus.NewDesc("pgxpool_idle_connections", "Conexões ociosas.", nil, nil),
+		total:        prometheus.NewDesc("pgxpool_total_connections", "Total de conexões abertas no pool.", nil, nil),
+		max:          prometheus.NewDesc("pgxpool_max_connections", "Tamanho máximo do pool.", nil, nil),
+		acquires:     prometheus.NewDesc("pgxpool_acquire_total", "Total de aquisições de conexão.", nil, nil),
+		acquireWait:  prometheus.NewDesc("pgxpool_acquire_wait_seconds_total", "Tempo acumulado aguardando conexão.", nil, nil),
+		emptyAcquire: prometheus.NewDesc("pgxpool_empty_acquire_total", "Aquisições que precisaram esperar por pool vazio.", nil, nil),
+	}
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquired
+	ch <- c.idle
+	ch <- c.total
+	ch <- c.max
+	ch <- c.acquires
+	ch <- c.acquireWait
+	ch <- c.emptyAcquire
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stat()
+	ch <- prometheus.MustNewConstMetric(c.acquired, prometheus.GaugeValue, float64(s.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(s.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(s.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(c.max, prometheus.GaugeValue, float64(s.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(c.acquires, prometheus.CounterValue, float64(s.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(c.acquireWait, prometheus.CounterValue, s.AcquireDuration().Seconds())
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquire, prometheus.CounterValue, float64(s.EmptyAcquireCount()))
+}
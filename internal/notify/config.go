@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// ChannelsFromEnv monta os canais configurados por variáveis de ambiente:
+//   - SMTP_ADDR (host:porta), SMTP_FROM, SMTP_USER/SMTP_PASSWORD: e-mail
+//   - SMS_GATEWAY_URL: SMS via gateway HTTP
+//   - CHAT_WEBHOOK_URL, CHAT_WEBHOOK_FORMAT (slack|teams|telegram, padrão slack): chat
+//
+// O canal de log está sempre disponível; canais sem configuração ficam de fora.
+func ChannelsFromEnv() (map[domain.NotificationChannel]Channel, error) {
+	channels := map[domain.NotificationChannel]Channel{domain.ChannelLog: &Log{}}
+
+	if addr := os.Getenv("SMTP_ADDR"); addr != "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, fmt.Errorf("SMTP_ADDR: %w", err)
+		}
+		c := &SMTP{Addr: addr, From: getenv("SMTP_FROM", "cmms@localhost")}
+		if user := os.Getenv("SMTP_USER"); user != "" {
+			c.Auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+		}
+		channels[domain.ChannelEmail] = c
+	}
+	if url := os.Getenv("SMS_GATEWAY_URL"); url != "" {
+		channels[domain.ChannelSMS] = &Webhook{URL: url, Format: FormatSMS}
+	}
+	if url := os.Getenv("CHAT_WEBHOOK_URL"); url != "" {
+		format := Format(strings.ToLower(getenv("CHAT_WEBHOOK_FORMAT", string(FormatSlack))))
+		if !format.Valid() || format == FormatSMS {
+			return nil, fmt.Errorf("unknown CHAT_WEBHOOK_FORMAT %q", format)
+		}
+		channels[domain.ChannelChat] = &Webhook{URL: url, Format: format}
+	}
+	return channels, nil
+}
+
+func getenv(k, def string) string {
+	if v := os.Getenv(k); v != "" {
+		return v
+	}
+	return def
+}
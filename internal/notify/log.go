@@ -0,0 +1,34 @@
+package notify
+
+import (
+	"context"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Log só registra a mensagem no log e guarda uma cópia; serve de canal
+// em testes e em ambientes sem SMTP/webhook configurado.
+type Log struct {
+	mu   sync.Mutex
+	sent []Message
+}
+
+func (l *Log) Send(_ context.Context, msg Message) error {
+	log.WithFields(log.Fields{
+		"to":      msg.To.Name,
+		"subject": msg.Subject,
+	}).Info(msg.Text)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sent = append(l.sent, msg)
+	return nil
+}
+
+// Sent retorna as mensagens registradas até agora.
+func (l *Log) Sent() []Message {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]Message(nil), l.sent...)
+}
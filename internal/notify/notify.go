@@ -0,0 +1,30 @@
+// Package notify entrega mensagens de alerta pelos canais externos
+// (SMTP, webhooks HTTP de chat/SMS) ou apenas no log.
+package notify
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoAddress indica que o destinatário não tem contato para o canal.
+var ErrNoAddress = errors.New("recipient has no address for channel")
+
+// Recipient traz os contatos do destinatário; cada canal usa o seu.
+type Recipient struct {
+	Name   string
+	Email  string
+	Phone  string
+	ChatID string
+}
+
+type Message struct {
+	To      Recipient
+	Subject string
+	Text    string
+}
+
+// Channel é um adaptador de entrega.
+type Channel interface {
+	Send(ctx context.Context, msg Message) error
+}
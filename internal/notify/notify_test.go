@@ -0,0 +1,158 @@
+package notify_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/notify"
+)
+
+type smtpCapture struct {
+	from, to, data string
+}
+
+// fakeSMTP aceita uma conexão, responde o mínimo do protocolo e devolve
+// remetente, destinatário e corpo recebidos.
+func fakeSMTP(t *testing.T) (string, <-chan smtpCapture) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	out := make(chan smtpCapture, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		reply := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+
+		var got smtpCapture
+		reply("220 fake ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			cmd := strings.ToUpper(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250-fake")
+				reply("250 8BITMIME")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				got.from = line[len("MAIL FROM:"):]
+				reply("250 ok")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				got.to = line[len("RCPT TO:"):]
+				reply("250 ok")
+			case cmd == "DATA":
+				reply("354 go ahead")
+				var b strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					b.WriteString(l)
+				}
+				got.data = b.String()
+				reply("250 queued")
+			case cmd == "QUIT":
+				reply("221 bye")
+				out <- got
+				return
+			default:
+				reply("502 not implemented")
+			}
+		}
+	}()
+	return ln.Addr().String(), out
+}
+
+func TestSMTP_SendsPlainTextMail(t *testing.T) {
+	addr, got := fakeSMTP(t)
+	ch := &notify.SMTP{Addr: addr, From: "cmms@fabrica.local"}
+
+	err := ch.Send(context.Background(), notify.Message{
+		To:      notify.Recipient{Name: "Ana", Email: "ana@fabrica.local"},
+		Subject: "Quebra em ativo crítico",
+		Text:    "OS #7 aberta",
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	mail := <-got
+	if !strings.HasPrefix(mail.from, "<cmms@fabrica.local>") || mail.to != "<ana@fabrica.local>" {
+		t.Fatalf("unexpected envelope: %+v", mail)
+	}
+	if !strings.Contains(mail.data, "Subject: =?utf-8?q?Quebra_em_ativo_cr=C3=ADtico?=") || !strings.Contains(mail.data, "OS #7 aberta") {
+		t.Fatalf("unexpected mail data:\n%s", mail.data)
+	}
+
+	if err := ch.Send(context.Background(), notify.Message{To: notify.Recipient{Name: "Sem e-mail"}}); !errors.Is(err, notify.ErrNoAddress) {
+		t.Fatalf("expected ErrNoAddress, got %v", err)
+	}
+}
+
+func TestWebhook_PayloadPerFormat(t *testing.T) {
+	var last map[string]string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type %q", r.Header.Get("Content-Type"))
+		}
+		last = nil
+		_ = json.NewDecoder(r.Body).Decode(&last)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	msg := notify.Message{
+		To:      notify.Recipient{Name: "Ana", Phone: "+5511999990000", ChatID: "42"},
+		Subject: "Estoque baixo",
+		Text:    "ROL-6205 abaixo do mínimo",
+	}
+	cases := []struct {
+		format notify.Format
+		key    string
+		want   string
+	}{
+		{notify.FormatSlack, "text", "42 Estoque baixo\nROL-6205 abaixo do mínimo"},
+		{notify.FormatTeams, "title", "Estoque baixo"},
+		{notify.FormatTelegram, "chat_id", "42"},
+		{notify.FormatSMS, "to", "+5511999990000"},
+	}
+	for _, tc := range cases {
+		ch := &notify.Webhook{URL: srv.URL, Format: tc.format}
+		if err := ch.Send(context.Background(), msg); err != nil {
+			t.Fatalf("%s: Send() error = %v", tc.format, err)
+		}
+		if last[tc.key] != tc.want {
+			t.Fatalf("%s: %s = %q, want %q (payload %v)", tc.format, tc.key, last[tc.key], tc.want, last)
+		}
+	}
+
+	sms := &notify.Webhook{URL: srv.URL, Format: notify.FormatSMS}
+	if err := sms.Send(context.Background(), notify.Message{To: notify.Recipient{Name: "Sem telefone"}}); !errors.Is(err, notify.ErrNoAddress) {
+		t.Fatalf("expected ErrNoAddress, got %v", err)
+	}
+	status = http.StatusBadGateway
+	if err := sms.Send(context.Background(), msg); err == nil {
+		t.Fatalf("expected error on non-2xx response")
+	}
+}
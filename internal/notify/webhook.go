@@ -0,0 +1,98 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Format define o corpo JSON enviado ao webhook.
+type Format string
+
+const (
+	FormatSlack    Format = "slack"    // {"text": ...} (também aceito por Mattermost/Rocket.Chat)
+	FormatTeams    Format = "teams"    // MessageCard do conector de entrada do Teams
+	FormatTelegram Format = "telegram" // sendMessage da Bot API; usa Recipient.ChatID
+	FormatSMS      Format = "sms"      // gateway genérico: {"to": telefone, "text": ...}
+)
+
+func (f Format) Valid() bool {
+	switch f {
+	case FormatSlack, FormatTeams, FormatTelegram, FormatSMS:
+		return true
+	}
+	return false
+}
+
+// Webhook faz POST JSON em URL; respostas fora de 2xx são erro.
+type Webhook struct {
+	URL    string
+	Format Format
+	Client *http.Client // nil = cliente com timeout de 10s
+}
+
+func (c *Webhook) Send(ctx context.Context, msg Message) error {
+	payload, err := c.payload(msg)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := c.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook post: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s: status %d", c.Format, resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *Webhook) payload(msg Message) (any, error) {
+	text := msg.Subject + "\n" + msg.Text
+	switch c.Format {
+	case FormatSlack:
+		if msg.To.ChatID != "" {
+			text = msg.To.ChatID + " " + text // menção (ex: <@U123>)
+		}
+		return map[string]string{"text": text}, nil
+	case FormatTeams:
+		return map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  msg.Subject,
+			"title":    msg.Subject,
+			"text":     msg.Text,
+		}, nil
+	case FormatTelegram:
+		if msg.To.ChatID == "" {
+			return nil, ErrNoAddress
+		}
+		return map[string]string{"chat_id": msg.To.ChatID, "text": text}, nil
+	case FormatSMS:
+		if msg.To.Phone == "" {
+			return nil, ErrNoAddress
+		}
+		return map[string]string{"to": msg.To.Phone, "text": text}, nil
+	}
+	return nil, fmt.Errorf("unknown webhook format %q", c.Format)
+}
@@ -0,0 +1,34 @@
+// Package plant concentra configurações da planta (fuso horário local).
+package plant
+
+import (
+	"os"
+	"sync"
+	"time"
+	_ "time/tzdata" // garante o fuso mesmo em imagens sem zoneinfo
+)
+
+// DefaultTimeZone é usado quando PLANT_TZ não está definido.
+const DefaultTimeZone = "America/Sao_Paulo"
+
+var (
+	locOnce sync.Once
+	loc     *time.Location
+)
+
+// Location retorna o fuso da planta (PLANT_TZ), usado para interpretar datas
+// de planilhas e formatar exportações. Um valor inválido cai para o padrão.
+func Location() *time.Location {
+	locOnce.Do(func() {
+		name := os.Getenv("PLANT_TZ")
+		if name == "" {
+			name = DefaultTimeZone
+		}
+		l, err := time.LoadLocation(name)
+		if err != nil {
+			l, _ = time.LoadLocation(DefaultTimeZone)
+		}
+		loc = l
+	})
+	return loc
+}
@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type AlertMemoryRepo struct {
+	data map[int64]*domain.Alert
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewAlertMemoryRepo() *AlertMemoryRepo {
+	return &AlertMemoryRepo{
+		data: make(map[int64]*domain.Alert),
+		next: 1,
+	}
+}
+
+func (r *AlertMemoryRepo) Create(ctx context.Context, a *domain.Alert) error {
+	if err := tenant.Assign(ctx, &a.SiteID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, other := range r.data {
+		if other.ResolvedAt == nil && other.Event == a.Event && other.RefType == a.RefType && other.RefID == a.RefID {
+			return domain.ErrAlreadyExists
+		}
+	}
+	a.ID = r.next
+	r.next++
+	a.CreatedAt = time.Now()
+	cp := *a
+	r.data[a.ID] = &cp
+	return nil
+}
+
+func (r *AlertMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.Alert, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, ok := r.data[id]
+	if !ok || !tenant.Visible(site, a.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (r *AlertMemoryRepo) FindAll(ctx context.Context, escalatingOnly bool) ([]domain.Alert, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.Alert, 0, len(r.data))
+	for _, a := range r.data {
+		if !tenant.Visible(site, a.SiteID) || escalatingOnly && !a.Escalating() {
+			continue
+		}
+		result = append(result, *a)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	return result, nil
+}
+
+func (r *AlertMemoryRepo) SetTier(ctx context.Context, id int64, tier int) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.data[id]
+	if !ok || !tenant.Visible(site, a.SiteID) {
+		return domain.ErrNotFound
+	}
+	a.Tier = tier
+	return nil
+}
+
+func (r *AlertMemoryRepo) Ack(ctx context.Context, id, userID int64, at time.Time) (*domain.Alert, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	a, ok := r.data[id]
+	if !ok || !tenant.Visible(site, a.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	if !a.Escalating() {
+		return nil, domain.ErrConflict
+	}
+	a.AckedAt, a.AckedBy = &at, &userID
+	cp := *a
+	return &cp, nil
+}
+
+func (r *AlertMemoryRepo) Resolve(ctx context.Context, refType domain.AlertRefType, refID int64, at time.Time) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, a := range r.data {
+		if tenant.Visible(site, a.SiteID) && a.ResolvedAt == nil && a.RefType == refType && a.RefID == refID {
+			resolved := at
+			a.ResolvedAt = &resolved
+		}
+	}
+	return nil
+}
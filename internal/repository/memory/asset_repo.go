@@ -1,11 +1,15 @@
 package memory
 
 import (
+	"context"
+	"maps"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
 )
 
 type AssetMemoryRepo struct {
@@ -21,40 +25,188 @@ func NewAssetMemoryRepo() *AssetMemoryRepo {
 	}
 }
 
-func (r *AssetMemoryRepo) Create(asset *domain.Asset) error {
+// copyAsset evita compartilhar o mapa de atributos com quem chamou.
+func copyAsset(a *domain.Asset) domain.Asset {
+	cp := *a
+	cp.Attributes = maps.Clone(a.Attributes)
+	return cp
+}
+
+// codeTaken reproduz o índice único de external_code por site (sem diferenciar maiúsculas).
+func (r *AssetMemoryRepo) codeTaken(site int64, code string, exceptID int64) bool {
+	if code == "" {
+		return false
+	}
+	for id, a := range r.data {
+		if id != exceptID && a.SiteID == site && strings.EqualFold(a.ExternalCode, code) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *AssetMemoryRepo) Create(ctx context.Context, asset *domain.Asset) error {
+	if err := tenant.Assign(ctx, &asset.SiteID); err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if r.codeTaken(asset.SiteID, asset.ExternalCode, 0) {
+		return domain.ErrAlreadyExists
+	}
 	asset.ID = r.next
 	r.next++
 	asset.CreatedAt = time.Now()
 	asset.UpdatedAt = asset.CreatedAt
-	r.data[asset.ID] = asset
+	asset.Revision = nextRevision()
+	cp := copyAsset(asset)
+	r.data[asset.ID] = &cp
 	return nil
 }
 
-func (r *AssetMemoryRepo) FindAll() ([]domain.Asset, error) {
+// CreateBatch grava em lote; datas de criação já preenchidas são preservadas.
+func (r *AssetMemoryRepo) CreateBatch(ctx context.Context, assets []domain.Asset) (int64, error) {
+	for i := range assets {
+		if err := tenant.Assign(ctx, &assets[i].SiteID); err != nil {
+			return 0, err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	type siteCode struct {
+		site int64
+		code string
+	}
+	seen := map[siteCode]bool{}
+	for _, a := range assets {
+		k := siteCode{a.SiteID, strings.ToLower(a.ExternalCode)}
+		if k.code != "" && (seen[k] || r.codeTaken(k.site, k.code, 0)) {
+			return 0, domain.ErrAlreadyExists
+		}
+		seen[k] = true
+	}
+	now := time.Now()
+	for i := range assets {
+		item := copyAsset(&assets[i])
+		item.ID = r.next
+		r.next++
+		if item.CreatedAt.IsZero() {
+			item.CreatedAt = now
+		}
+		item.UpdatedAt = now
+		item.Revision = nextRevision()
+		r.data[item.ID] = &item
+	}
+	return int64(len(assets)), nil
+}
+
+func (r *AssetMemoryRepo) FindAll(ctx context.Context) ([]domain.Asset, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	ids := make([]int64, 0, len(r.data))
-	for id := range r.data {
-		ids = append(ids, id)
+	for id, a := range r.data {
+		if tenant.Visible(site, a.SiteID) {
+			ids = append(ids, id)
+		}
 	}
 	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
 
-	result := make([]domain.Asset, 0, len(r.data))
+	result := make([]domain.Asset, 0, len(ids))
 	for _, id := range ids {
-		a := r.data[id]
-		result = append(result, *a)
+		result = append(result, copyAsset(r.data[id]))
 	}
 	return result, nil
 }
 
-func (r *AssetMemoryRepo) FindByID(id int64) (*domain.Asset, error) {
+func (r *AssetMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.Asset, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if a, ok := r.data[id]; ok && tenant.Visible(site, a.SiteID) {
+		cp := copyAsset(a)
+		return &cp, nil
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *AssetMemoryRepo) FindByCode(ctx context.Context, code string) (*domain.Asset, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	if a, ok := r.data[id]; ok {
-		return a, nil
+	for _, a := range r.data {
+		if code != "" && tenant.Visible(site, a.SiteID) && strings.EqualFold(a.ExternalCode, code) {
+			cp := copyAsset(a)
+			return &cp, nil
+		}
 	}
 	return nil, domain.ErrNotFound
 }
+
+// Update não muda o site do ativo.
+func (r *AssetMemoryRepo) Update(ctx context.Context, asset *domain.Asset) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[asset.ID]
+	if !ok || !tenant.Visible(site, cur.SiteID) {
+		return domain.ErrNotFound
+	}
+	asset.SiteID = cur.SiteID
+	if r.codeTaken(asset.SiteID, asset.ExternalCode, asset.ID) {
+		return domain.ErrAlreadyExists
+	}
+	asset.UpdatedAt = time.Now()
+	asset.Revision = nextRevision()
+	cp := copyAsset(asset)
+	r.data[asset.ID] = &cp
+	return nil
+}
+
+func (r *AssetMemoryRepo) Stream(ctx context.Context, filter domain.AssetFilter, fn func(*domain.Asset) error) error {
+	all, err := r.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range all {
+		if !filter.Match(&all[i]) {
+			continue
+		}
+		if err := fn(&all[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// inScope diz quais ativos o escopo do contexto enxerga. Paradas, leituras e
+// produção não têm site próprio e filtram por ele, resolvido antes de tomar o
+// próprio lock para manter a ordem de locks do SyncMemoryRepo.
+func (r *AssetMemoryRepo) inScope(ctx context.Context) (func(assetID int64) bool, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	visible := make(map[int64]bool, len(r.data))
+	for id, a := range r.data {
+		if tenant.Visible(site, a.SiteID) {
+			visible[id] = true
+		}
+	}
+	return func(assetID int64) bool { return visible[assetID] }, nil
+}
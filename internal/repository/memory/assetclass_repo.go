@@ -0,0 +1,85 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type AssetClassMemoryRepo struct {
+	data map[string]*domain.AssetClass
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewAssetClassMemoryRepo() *AssetClassMemoryRepo {
+	return &AssetClassMemoryRepo{
+		data: make(map[string]*domain.AssetClass),
+		next: 1,
+	}
+}
+
+// copyAssetClass evita compartilhar as definições de atributos com quem chamou.
+func copyAssetClass(c *domain.AssetClass) domain.AssetClass {
+	cp := *c
+	cp.Attributes = make([]domain.AttributeDef, len(c.Attributes))
+	for i, d := range c.Attributes {
+		d.Options = append([]string(nil), d.Options...)
+		cp.Attributes[i] = d
+	}
+	return cp
+}
+
+func (r *AssetClassMemoryRepo) Create(_ context.Context, c *domain.AssetClass) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.data[c.Code]; ok {
+		return domain.ErrAlreadyExists
+	}
+	c.ID = r.next
+	r.next++
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = c.CreatedAt
+	cp := copyAssetClass(c)
+	r.data[c.Code] = &cp
+	return nil
+}
+
+func (r *AssetClassMemoryRepo) Update(_ context.Context, c *domain.AssetClass) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[c.Code]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	c.ID, c.CreatedAt = cur.ID, cur.CreatedAt
+	c.UpdatedAt = time.Now()
+	cp := copyAssetClass(c)
+	r.data[c.Code] = &cp
+	return nil
+}
+
+func (r *AssetClassMemoryRepo) FindAll(_ context.Context) ([]domain.AssetClass, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.AssetClass, 0, len(r.data))
+	for _, c := range r.data {
+		result = append(result, copyAssetClass(c))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+	return result, nil
+}
+
+func (r *AssetClassMemoryRepo) FindByCode(_ context.Context, code string) (*domain.AssetClass, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.data[code]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	cp := copyAssetClass(c)
+	return &cp, nil
+}
@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// CalendarMemoryRepo começa com domain.DefaultCalendarSettings em todo site, como o banco após a migração.
+type CalendarMemoryRepo struct {
+	settings     map[int64]domain.CalendarSettings // por site
+	holidays     map[int64]*domain.Holiday
+	shutdowns    map[int64]*domain.Shutdown
+	mu           sync.RWMutex
+	nextHoliday  int64
+	nextShutdown int64
+}
+
+func NewCalendarMemoryRepo() *CalendarMemoryRepo {
+	return &CalendarMemoryRepo{
+		settings:     make(map[int64]domain.CalendarSettings),
+		holidays:     make(map[int64]*domain.Holiday),
+		shutdowns:    make(map[int64]*domain.Shutdown),
+		nextHoliday:  1,
+		nextShutdown: 1,
+	}
+}
+
+func (r *CalendarMemoryRepo) Settings(ctx context.Context) (*domain.CalendarSettings, error) {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.settings[site]
+	if !ok {
+		s = domain.DefaultCalendarSettings()
+	}
+	return &domain.CalendarSettings{WorkingDays: append([]int{}, s.WorkingDays...)}, nil
+}
+
+func (r *CalendarMemoryRepo) SaveSettings(ctx context.Context, s *domain.CalendarSettings) error {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settings[site] = domain.CalendarSettings{WorkingDays: append([]int{}, s.WorkingDays...)}
+	return nil
+}
+
+func (r *CalendarMemoryRepo) UpsertHolidays(ctx context.Context, holidays []domain.Holiday) error {
+	for i := range holidays {
+		if err := tenant.Assign(ctx, &holidays[i].SiteID); err != nil {
+			return err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range holidays {
+		h := &holidays[i]
+		found := false
+		for _, cur := range r.holidays {
+			if cur.SiteID == h.SiteID && cur.Date == h.Date {
+				cur.Name = h.Name
+				h.ID = cur.ID
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		h.ID = r.nextHoliday
+		r.nextHoliday++
+		cp := *h
+		r.holidays[h.ID] = &cp
+	}
+	return nil
+}
+
+func (r *CalendarMemoryRepo) FindHolidays(ctx context.Context) ([]domain.Holiday, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.Holiday, 0, len(r.holidays))
+	for _, h := range r.holidays {
+		if tenant.Visible(site, h.SiteID) {
+			result = append(result, *h)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Date != result[j].Date {
+			return result[i].Date < result[j].Date
+		}
+		return result[i].SiteID < result[j].SiteID
+	})
+	return result, nil
+}
+
+func (r *CalendarMemoryRepo) DeleteHoliday(ctx context.Context, id int64) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.holidays[id]
+	if !ok || !tenant.Visible(site, h.SiteID) {
+		return domain.ErrNotFound
+	}
+	delete(r.holidays, id)
+	return nil
+}
+
+func (r *CalendarMemoryRepo) CreateShutdown(ctx context.Context, s *domain.Shutdown) error {
+	if err := tenant.Assign(ctx, &s.SiteID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s.ID = r.nextShutdown
+	r.nextShutdown++
+	s.CreatedAt = time.Now()
+	cp := *s
+	r.shutdowns[s.ID] = &cp
+	return nil
+}
+
+func (r *CalendarMemoryRepo) FindShutdowns(ctx context.Context) ([]domain.Shutdown, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.Shutdown, 0, len(r.shutdowns))
+	for _, s := range r.shutdowns {
+		if tenant.Visible(site, s.SiteID) {
+			result = append(result, *s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StartsAt.Before(result[j].StartsAt) })
+	return result, nil
+}
+
+func (r *CalendarMemoryRepo) DeleteShutdown(ctx context.Context, id int64) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s, ok := r.shutdowns[id]
+	if !ok || !tenant.Visible(site, s.SiteID) {
+		return domain.ErrNotFound
+	}
+	delete(r.shutdowns, id)
+	return nil
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// ChecklistMemoryRepo guarda os checklists por OS, na ordem dos passos; o site
+// é o da OS.
+type ChecklistMemoryRepo struct {
+	orders *WorkOrderMemoryRepo
+	data   map[int64][]domain.ChecklistItem
+	mu     sync.RWMutex
+}
+
+func NewChecklistMemoryRepo(orders *WorkOrderMemoryRepo) *ChecklistMemoryRepo {
+	r := &ChecklistMemoryRepo{orders: orders, data: make(map[int64][]domain.ChecklistItem)}
+	orders.mu.Lock()
+	orders.reassigned = r.touch
+	orders.mu.Unlock()
+	return r
+}
+
+// touch dá nova revisão aos passos da OS, para que o novo responsável receba o
+// checklist inteiro. Chamado pelo WorkOrderMemoryRepo com o lock dele (ordem de
+// locks: OS antes de checklist, como no SyncMemoryRepo).
+func (r *ChecklistMemoryRepo) touch(workOrderID int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.data[workOrderID] {
+		r.data[workOrderID][i].Revision = nextRevision()
+	}
+}
+
+// reachable devolve ErrNotFound se a OS não existir ou estiver fora do escopo.
+func (r *ChecklistMemoryRepo) reachable(ctx context.Context, workOrderID int64) error {
+	ok, err := r.orders.visible(ctx, workOrderID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func copyChecklistItem(it domain.ChecklistItem) domain.ChecklistItem {
+	if it.Measurement != nil {
+		m := *it.Measurement
+		it.Measurement = &m
+	}
+	return it
+}
+
+func (r *ChecklistMemoryRepo) Create(ctx context.Context, workOrderID int64, items []domain.ChecklistItem) error {
+	if err := r.reachable(ctx, workOrderID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.data[workOrderID]; exists {
+		return domain.ErrAlreadyExists
+	}
+	now := time.Now()
+	list := make([]domain.ChecklistItem, len(items))
+	for i, it := range items {
+		it.UpdatedAt, it.Revision = now, nextRevision()
+		list[i] = copyChecklistItem(it)
+	}
+	r.data[workOrderID] = list
+	return nil
+}
+
+func (r *ChecklistMemoryRepo) FindByWorkOrder(ctx context.Context, workOrderID int64) ([]domain.ChecklistItem, error) {
+	ok, err := r.orders.visible(ctx, workOrderID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		// Como no Postgres, OS de outro site tem checklist vazio.
+		return []domain.ChecklistItem{}, nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.ChecklistItem, 0, len(r.data[workOrderID]))
+	for _, it := range r.data[workOrderID] {
+		result = append(result, copyChecklistItem(it))
+	}
+	return result, nil
+}
+
+func (r *ChecklistMemoryRepo) UpdateItem(ctx context.Context, workOrderID int64, item *domain.ChecklistItem) error {
+	if err := r.reachable(ctx, workOrderID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, it := range r.data[workOrderID] {
+		if it.Step == item.Step {
+			if it.FollowUpID != nil && (item.FollowUpID == nil || *item.FollowUpID != *it.FollowUpID) {
+				return domain.ErrConflict
+			}
+			item.UpdatedAt, item.Revision = time.Now(), nextRevision()
+			r.data[workOrderID][i] = copyChecklistItem(*item)
+			return nil
+		}
+	}
+	return domain.ErrNotFound
+}
@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// DowntimeMemoryRepo herda o site do ativo de cada parada.
+type DowntimeMemoryRepo struct {
+	assets *AssetMemoryRepo
+	data   map[int64]*domain.DowntimeEvent
+	mu     sync.RWMutex
+	next   int64
+}
+
+func NewDowntimeMemoryRepo(assets *AssetMemoryRepo) *DowntimeMemoryRepo {
+	return &DowntimeMemoryRepo{
+		assets: assets,
+		data:   make(map[int64]*domain.DowntimeEvent),
+		next:   1,
+	}
+}
+
+func (r *DowntimeMemoryRepo) Create(ctx context.Context, e *domain.DowntimeEvent) error {
+	visible, err := r.assets.inScope(ctx)
+	if err != nil {
+		return err
+	}
+	if !visible(e.AssetID) {
+		return domain.ErrNotFound
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Mesma garantia da exclusion constraint do Postgres.
+	for _, other := range r.data {
+		if other.AssetID == e.AssetID && other.Overlaps(e.StartedAt, e.EndedAt) {
+			return domain.ErrConflict
+		}
+	}
+	e.ID = r.next
+	r.next++
+	e.CreatedAt = time.Now()
+	e.UpdatedAt = e.CreatedAt
+	cp := *e
+	r.data[e.ID] = &cp
+	return nil
+}
+
+func (r *DowntimeMemoryRepo) Update(ctx context.Context, e *domain.DowntimeEvent) error {
+	visible, err := r.assets.inScope(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cur, ok := r.data[e.ID]; !ok || !visible(cur.AssetID) || !visible(e.AssetID) {
+		return domain.ErrNotFound
+	}
+	for id, other := range r.data {
+		if id != e.ID && other.AssetID == e.AssetID && other.Overlaps(e.StartedAt, e.EndedAt) {
+			return domain.ErrConflict
+		}
+	}
+	e.UpdatedAt = time.Now()
+	cp := *e
+	r.data[e.ID] = &cp
+	return nil
+}
+
+func (r *DowntimeMemoryRepo) FindOpenByAsset(ctx context.Context, assetID int64) (*domain.DowntimeEvent, error) {
+	open, err := r.filter(ctx, func(e *domain.DowntimeEvent) bool {
+		return e.AssetID == assetID && e.IsOpen()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(open) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return &open[0], nil
+}
+
+func (r *DowntimeMemoryRepo) FindOverlapping(ctx context.Context, assetID int64, start time.Time, end *time.Time, excludeID int64) ([]domain.DowntimeEvent, error) {
+	return r.filter(ctx, func(e *domain.DowntimeEvent) bool {
+		return e.ID != excludeID && e.AssetID == assetID && e.Overlaps(start, end)
+	})
+}
+
+func (r *DowntimeMemoryRepo) FindByAsset(ctx context.Context, assetID int64, from, to *time.Time) ([]domain.DowntimeEvent, error) {
+	return r.filter(ctx, func(e *domain.DowntimeEvent) bool {
+		if e.AssetID != assetID {
+			return false
+		}
+		if from != nil && e.StartedAt.Before(*from) {
+			return false
+		}
+		return to == nil || e.StartedAt.Before(*to)
+	})
+}
+
+func (r *DowntimeMemoryRepo) FindByAssets(ctx context.Context, assetIDs []int64, from, to *time.Time) ([]domain.DowntimeEvent, error) {
+	return r.filter(ctx, func(e *domain.DowntimeEvent) bool {
+		if !slices.Contains(assetIDs, e.AssetID) {
+			return false
+		}
+		if from != nil && e.StartedAt.Before(*from) {
+			return false
+		}
+		return to == nil || e.StartedAt.Before(*to)
+	})
+}
+
+func (r *DowntimeMemoryRepo) FindByWorkOrder(ctx context.Context, workOrderID int64) ([]domain.DowntimeEvent, error) {
+	return r.filter(ctx, func(e *domain.DowntimeEvent) bool {
+		return e.WorkOrderID != nil && *e.WorkOrderID == workOrderID
+	})
+}
+
+// FindAll é usado pelos relatórios e indicadores em memória.
+func (r *DowntimeMemoryRepo) FindAll(ctx context.Context) ([]domain.DowntimeEvent, error) {
+	return r.filter(ctx, func(*domain.DowntimeEvent) bool { return true })
+}
+
+// filter devolve cópias ordenadas por início, só dos ativos do escopo.
+func (r *DowntimeMemoryRepo) filter(ctx context.Context, keep func(*domain.DowntimeEvent) bool) ([]domain.DowntimeEvent, error) {
+	visible, err := r.assets.inScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := []domain.DowntimeEvent{}
+	for _, e := range r.data {
+		if visible(e.AssetID) && keep(e) {
+			result = append(result, *e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].StartedAt.Before(result[j].StartedAt) })
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type escalationKey struct {
+	event domain.NotificationEvent
+	tier  int
+}
+
+type EscalationMemoryRepo struct {
+	data map[int64][]domain.EscalationRule // por site
+	mu   sync.RWMutex
+}
+
+func NewEscalationMemoryRepo() *EscalationMemoryRepo {
+	return &EscalationMemoryRepo{data: make(map[int64][]domain.EscalationRule)}
+}
+
+func copyRule(rule domain.EscalationRule) domain.EscalationRule {
+	rule.UserIDs = append([]int64{}, rule.UserIDs...)
+	return rule
+}
+
+func (r *EscalationMemoryRepo) FindAll(ctx context.Context) ([]domain.EscalationRule, error) {
+	return r.find(ctx, func(domain.EscalationRule) bool { return true })
+}
+
+func (r *EscalationMemoryRepo) FindByEvent(ctx context.Context, event domain.NotificationEvent) ([]domain.EscalationRule, error) {
+	return r.find(ctx, func(rule domain.EscalationRule) bool { return rule.Event == event })
+}
+
+func (r *EscalationMemoryRepo) find(ctx context.Context, match func(domain.EscalationRule) bool) ([]domain.EscalationRule, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	sites := make([]int64, 0, len(r.data))
+	for owner := range r.data {
+		if tenant.Visible(site, owner) {
+			sites = append(sites, owner)
+		}
+	}
+	sort.Slice(sites, func(i, j int) bool { return sites[i] < sites[j] })
+	result := []domain.EscalationRule{}
+	for _, owner := range sites {
+		for _, rule := range r.data[owner] {
+			if match(rule) {
+				result = append(result, copyRule(rule))
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *EscalationMemoryRepo) ReplaceAll(ctx context.Context, rules []domain.EscalationRule) error {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return err
+	}
+	seen := map[escalationKey]bool{}
+	data := make([]domain.EscalationRule, 0, len(rules))
+	for i := range rules {
+		if err := tenant.Assign(ctx, &rules[i].SiteID); err != nil {
+			return err
+		}
+		k := escalationKey{rules[i].Event, rules[i].Tier}
+		if seen[k] {
+			return domain.ErrAlreadyExists
+		}
+		seen[k] = true
+		data = append(data, copyRule(rules[i]))
+	}
+	sort.Slice(data, func(i, j int) bool {
+		if data[i].Event != data[j].Event {
+			return data[i].Event < data[j].Event
+		}
+		return data[i].Tier < data[j].Tier
+	})
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data[site] = data
+	return nil
+}
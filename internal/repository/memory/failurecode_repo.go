@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type FailureCodeMemoryRepo struct {
+	data map[int64]*domain.FailureCode
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewFailureCodeMemoryRepo() *FailureCodeMemoryRepo {
+	return &FailureCodeMemoryRepo{
+		data: make(map[int64]*domain.FailureCode),
+		next: 1,
+	}
+}
+
+func (r *FailureCodeMemoryRepo) Create(_ context.Context, fc *domain.FailureCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, other := range r.data {
+		if other.Code == fc.Code {
+			return domain.ErrAlreadyExists
+		}
+	}
+	fc.ID = r.next
+	r.next++
+	fc.CreatedAt = time.Now()
+	fc.UpdatedAt = fc.CreatedAt
+	cp := *fc
+	r.data[fc.ID] = &cp
+	return nil
+}
+
+func (r *FailureCodeMemoryRepo) Update(_ context.Context, fc *domain.FailureCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[fc.ID]
+	if !ok {
+		return domain.ErrNotFound
+	}
+	cur.Name, cur.Active = fc.Name, fc.Active
+	cur.UpdatedAt = time.Now()
+	fc.UpdatedAt = cur.UpdatedAt
+	return nil
+}
+
+func (r *FailureCodeMemoryRepo) FindAll(_ context.Context) ([]domain.FailureCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.FailureCode, 0, len(r.data))
+	for _, fc := range r.data {
+		result = append(result, *fc)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+	return result, nil
+}
+
+func (r *FailureCodeMemoryRepo) FindByID(_ context.Context, id int64) (*domain.FailureCode, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	fc, ok := r.data[id]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	cp := *fc
+	return &cp, nil
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type idempotencyKey struct {
+	userID int64
+	key    string
+}
+
+type IdempotencyMemoryRepo struct {
+	data map[idempotencyKey]*domain.IdempotencyRecord
+	mu   sync.Mutex
+}
+
+func NewIdempotencyMemoryRepo() *IdempotencyMemoryRepo {
+	return &IdempotencyMemoryRepo{data: make(map[idempotencyKey]*domain.IdempotencyRecord)}
+}
+
+func (r *IdempotencyMemoryRepo) Reserve(_ context.Context, rec *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := idempotencyKey{rec.UserID, rec.Key}
+	now := time.Now()
+	if cur, ok := r.data[k]; ok && cur.ExpiresAt.After(now) {
+		cp := *cur
+		return &cp, false, nil
+	}
+	cp := *rec
+	cp.Status, cp.ContentType, cp.Body = 0, "", nil
+	cp.CreatedAt = now
+	r.data[k] = &cp
+	rec.CreatedAt = now
+	return nil, true, nil
+}
+
+func (r *IdempotencyMemoryRepo) Complete(_ context.Context, rec *domain.IdempotencyRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[idempotencyKey{rec.UserID, rec.Key}]
+	if !ok || cur.Done() || cur.RequestHash != rec.RequestHash {
+		return domain.ErrNotFound
+	}
+	cur.Status, cur.ContentType, cur.ExpiresAt = rec.Status, rec.ContentType, rec.ExpiresAt
+	cur.Body = append([]byte(nil), rec.Body...)
+	return nil
+}
+
+func (r *IdempotencyMemoryRepo) Release(_ context.Context, userID int64, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	k := idempotencyKey{userID, key}
+	if cur, ok := r.data[k]; ok && !cur.Done() {
+		delete(r.data, k)
+	}
+	return nil
+}
+
+func (r *IdempotencyMemoryRepo) DeleteExpired(_ context.Context, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for k, rec := range r.data {
+		if !rec.ExpiresAt.After(now) {
+			delete(r.data, k)
+			n++
+		}
+	}
+	return n, nil
+}
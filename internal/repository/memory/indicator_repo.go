@@ -0,0 +1,98 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// IndicatorMemoryRepo calcula os indicadores varrendo os demais repositórios em memória.
+type IndicatorMemoryRepo struct {
+	assets *AssetMemoryRepo
+	orders *WorkOrderMemoryRepo
+	plans  *MaintenancePlanMemoryRepo
+	events *DowntimeMemoryRepo
+}
+
+func NewIndicatorMemoryRepo(assets *AssetMemoryRepo, orders *WorkOrderMemoryRepo, plans *MaintenancePlanMemoryRepo, events *DowntimeMemoryRepo) *IndicatorMemoryRepo {
+	return &IndicatorMemoryRepo{assets: assets, orders: orders, plans: plans, events: events}
+}
+
+func (r *IndicatorMemoryRepo) Indicators(ctx context.Context, now time.Time) (*domain.Indicators, error) {
+	orders, err := r.orders.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plans, err := r.plans.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.events.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		status      domain.WorkOrderStatus
+		woType      domain.WorkOrderType
+		criticality domain.Criticality
+	}
+	counts := map[key]int64{}
+	down := map[int64]bool{}
+	linked := map[int64]bool{}
+	var overdue int64
+	for _, e := range events {
+		if e.WorkOrderID != nil {
+			linked[*e.WorkOrderID] = true
+		}
+		if e.IsOpen() {
+			if _, err := r.assets.FindByID(ctx, e.AssetID); err == nil {
+				down[e.AssetID] = true
+			}
+		}
+	}
+
+	for _, o := range orders {
+		if !o.IsOpen() {
+			continue
+		}
+		var crit domain.Criticality
+		if a, err := r.assets.FindByID(ctx, o.AssetID); err == nil {
+			crit = a.Criticality
+		}
+		counts[key{o.Status, o.Type, crit}]++
+		if o.IsOverdue(now) {
+			overdue++
+		}
+		// OS sem paradas apontadas: a quebra em aberto indica ativo parado.
+		if o.Type == domain.WOTypeCorrective && o.BreakdownAt != nil && !linked[o.ID] {
+			down[o.AssetID] = true
+		}
+	}
+
+	ind := &domain.Indicators{AssetsDown: int64(len(down)), OverdueOrders: overdue}
+	for k, n := range counts {
+		ind.OpenWorkOrders = append(ind.OpenWorkOrders, domain.WorkOrderCount{
+			Status: k.status, Type: k.woType, Criticality: k.criticality, Count: n,
+		})
+	}
+	sort.Slice(ind.OpenWorkOrders, func(i, j int) bool {
+		a, b := ind.OpenWorkOrders[i], ind.OpenWorkOrders[j]
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.Criticality < b.Criticality
+	})
+
+	for _, p := range plans {
+		if p.IsOverdue(now) {
+			ind.OverduePlans++
+		}
+	}
+	return ind, nil
+}
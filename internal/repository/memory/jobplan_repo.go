@@ -0,0 +1,105 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type JobPlanMemoryRepo struct {
+	data map[int64]*domain.JobPlan
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewJobPlanMemoryRepo() *JobPlanMemoryRepo {
+	return &JobPlanMemoryRepo{
+		data: make(map[int64]*domain.JobPlan),
+		next: 1,
+	}
+}
+
+// copyJobPlan evita compartilhar passos e peças com quem chamou.
+func copyJobPlan(j *domain.JobPlan) domain.JobPlan {
+	cp := *j
+	cp.Steps = make([]domain.JobPlanStep, len(j.Steps))
+	for i, s := range j.Steps {
+		if s.Measurement != nil {
+			m := *s.Measurement
+			s.Measurement = &m
+		}
+		cp.Steps[i] = s
+	}
+	cp.Parts = append([]domain.JobPlanPart{}, j.Parts...)
+	return cp
+}
+
+func (r *JobPlanMemoryRepo) Create(ctx context.Context, j *domain.JobPlan) error {
+	if err := tenant.Assign(ctx, &j.SiteID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	j.ID = r.next
+	r.next++
+	j.CreatedAt = time.Now()
+	j.UpdatedAt = j.CreatedAt
+	cp := copyJobPlan(j)
+	r.data[j.ID] = &cp
+	return nil
+}
+
+func (r *JobPlanMemoryRepo) Update(ctx context.Context, j *domain.JobPlan) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[j.ID]
+	if !ok || !tenant.Visible(site, cur.SiteID) {
+		return domain.ErrNotFound
+	}
+	j.SiteID = cur.SiteID
+	j.CreatedAt = cur.CreatedAt
+	j.UpdatedAt = time.Now()
+	cp := copyJobPlan(j)
+	r.data[j.ID] = &cp
+	return nil
+}
+
+func (r *JobPlanMemoryRepo) FindAll(ctx context.Context) ([]domain.JobPlan, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.JobPlan, 0, len(r.data))
+	for _, j := range r.data {
+		if tenant.Visible(site, j.SiteID) {
+			result = append(result, copyJobPlan(j))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *JobPlanMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.JobPlan, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	j, ok := r.data[id]
+	if !ok || !tenant.Visible(site, j.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	cp := copyJobPlan(j)
+	return &cp, nil
+}
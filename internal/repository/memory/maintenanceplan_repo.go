@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type MaintenancePlanMemoryRepo struct {
+	data map[int64]*domain.MaintenancePlan
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewMaintenancePlanMemoryRepo() *MaintenancePlanMemoryRepo {
+	return &MaintenancePlanMemoryRepo{
+		data: make(map[int64]*domain.MaintenancePlan),
+		next: 1,
+	}
+}
+
+func (r *MaintenancePlanMemoryRepo) Create(ctx context.Context, plan *domain.MaintenancePlan) error {
+	if err := tenant.Assign(ctx, &plan.SiteID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	plan.ID = r.next
+	r.next++
+	plan.CreatedAt = time.Now()
+	plan.UpdatedAt = plan.CreatedAt
+	r.data[plan.ID] = plan
+	return nil
+}
+
+func (r *MaintenancePlanMemoryRepo) FindAll(ctx context.Context) ([]domain.MaintenancePlan, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]int64, 0, len(r.data))
+	for id, p := range r.data {
+		if tenant.Visible(site, p.SiteID) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	result := make([]domain.MaintenancePlan, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, *r.data[id])
+	}
+	return result, nil
+}
+
+func (r *MaintenancePlanMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.MaintenancePlan, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.data[id]
+	if !ok || !tenant.Visible(site, p.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (r *MaintenancePlanMemoryRepo) SetLastExecution(ctx context.Context, id int64, at time.Time) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.data[id]
+	if !ok || !tenant.Visible(site, p.SiteID) {
+		return domain.ErrNotFound
+	}
+	p.LastExecution = &at
+	p.UpdatedAt = time.Now()
+	return nil
+}
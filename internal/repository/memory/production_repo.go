@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// ProductionMemoryRepo herda o site do ativo de cada apontamento.
+type ProductionMemoryRepo struct {
+	assets *AssetMemoryRepo
+	data   []domain.ProductionCount
+	mu     sync.RWMutex
+	next   int64
+}
+
+func NewProductionMemoryRepo(assets *AssetMemoryRepo) *ProductionMemoryRepo {
+	return &ProductionMemoryRepo{assets: assets, next: 1}
+}
+
+// CreateBatch recusa o lote inteiro se algum ativo estiver fora do site do
+// escopo, como o Postgres (ativo inexistente também é entrada inválida).
+func (r *ProductionMemoryRepo) CreateBatch(ctx context.Context, counts []domain.ProductionCount) (int64, error) {
+	visible, err := r.assets.inScope(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, p := range counts {
+		if !visible(p.AssetID) {
+			return 0, domain.ErrInvalidInput
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for _, p := range counts {
+		p.ID = r.next
+		r.next++
+		p.CreatedAt = now
+		r.data = append(r.data, p)
+	}
+	return int64(len(counts)), nil
+}
+
+// FindAll é usado pelos relatórios em memória.
+func (r *ProductionMemoryRepo) FindAll(ctx context.Context) ([]domain.ProductionCount, error) {
+	visible, err := r.assets.inScope(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := []domain.ProductionCount{}
+	for _, p := range r.data {
+		if visible(p.AssetID) {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
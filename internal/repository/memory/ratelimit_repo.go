@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type rateBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+type RateLimitMemoryRepo struct {
+	data map[string]*rateBucket
+	mu   sync.Mutex
+}
+
+func NewRateLimitMemoryRepo() *RateLimitMemoryRepo {
+	return &RateLimitMemoryRepo{data: make(map[string]*rateBucket)}
+}
+
+func (r *RateLimitMemoryRepo) Take(_ context.Context, key string, limit domain.RateLimit) (domain.RateLimitStatus, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	b, ok := r.data[key]
+	if !ok {
+		b = &rateBucket{tokens: float64(limit.Requests), updated: now}
+		r.data[key] = b
+	}
+	b.tokens = math.Min(float64(limit.Requests), b.tokens+now.Sub(b.updated).Seconds()*limit.Rate())
+	b.updated = now
+	if b.tokens < 1 {
+		return limit.Status(b.tokens, false), nil
+	}
+	b.tokens--
+	return limit.Status(b.tokens, true), nil
+}
+
+func (r *RateLimitMemoryRepo) DeleteIdle(_ context.Context, before time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for k, b := range r.data {
+		if b.updated.Before(before) {
+			delete(r.data, k)
+			n++
+		}
+	}
+	return n, nil
+}
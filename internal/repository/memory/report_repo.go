@@ -0,0 +1,239 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// ReportMemoryRepo calcula os relatórios varrendo os repositórios em memória.
+type ReportMemoryRepo struct {
+	assets *AssetMemoryRepo
+	orders *WorkOrderMemoryRepo
+	events *DowntimeMemoryRepo
+	shifts *ShiftMemoryRepo
+	counts *ProductionMemoryRepo
+	codes  *FailureCodeMemoryRepo
+}
+
+func NewReportMemoryRepo(assets *AssetMemoryRepo, orders *WorkOrderMemoryRepo, events *DowntimeMemoryRepo,
+	shifts *ShiftMemoryRepo, counts *ProductionMemoryRepo, codes *FailureCodeMemoryRepo) *ReportMemoryRepo {
+	return &ReportMemoryRepo{assets: assets, orders: orders, events: events, shifts: shifts, counts: counts, codes: codes}
+}
+
+func (r *ReportMemoryRepo) MonthlyDowntime(ctx context.Context, from, to time.Time, loc *time.Location) ([]domain.DowntimeReportRow, error) {
+	orders, err := r.orders.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.events.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct {
+		month   string
+		assetID int64
+	}
+	rows := map[key]*domain.DowntimeReportRow{}
+	rowFor := func(assetID int64, at time.Time) *domain.DowntimeReportRow {
+		if at.Before(from) || !at.Before(to) {
+			return nil
+		}
+		k := key{month: at.In(loc).Format("2006-01"), assetID: assetID}
+		row, ok := rows[k]
+		if !ok {
+			// Ativo fora do escopo (outro site) não entra no relatório, como no JOIN do banco.
+			a, err := r.assets.FindByID(ctx, assetID)
+			if err != nil {
+				return nil
+			}
+			row = &domain.DowntimeReportRow{Month: k.month, AssetID: assetID, AssetName: a.Name, Location: a.Location}
+			rows[k] = row
+		}
+		return row
+	}
+
+	linked := map[int64]bool{}
+	for i := range events {
+		e := &events[i]
+		if e.WorkOrderID != nil {
+			linked[*e.WorkOrderID] = true
+		}
+		if e.IsOpen() {
+			continue
+		}
+		row := rowFor(e.AssetID, e.StartedAt)
+		if row == nil {
+			continue
+		}
+		if e.Planned {
+			row.PlannedMinutes += e.Minutes(time.Now())
+		} else {
+			row.Breakdowns++
+			row.DowntimeMinutes += e.Minutes(time.Now())
+		}
+	}
+
+	// Histórico sem paradas apontadas: usa os dados digitados na própria OS.
+	for _, o := range orders {
+		if linked[o.ID] || o.Status == domain.WOStatusCanceled || (o.BreakdownAt == nil && o.DowntimeMinutes == nil) {
+			continue
+		}
+		at := o.CreatedAt
+		if o.BreakdownAt != nil {
+			at = *o.BreakdownAt
+		}
+		row := rowFor(o.AssetID, at)
+		if row == nil {
+			continue
+		}
+		row.Breakdowns++
+		if o.DowntimeMinutes != nil {
+			row.DowntimeMinutes += *o.DowntimeMinutes
+		}
+	}
+
+	list := make([]domain.DowntimeReportRow, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, *row)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Month != list[j].Month {
+			return list[i].Month < list[j].Month
+		}
+		return list[i].AssetID < list[j].AssetID
+	})
+	return list, nil
+}
+
+func (r *ReportMemoryRepo) OEEInputs(ctx context.Context, from, to time.Time, filter domain.OEEFilter) (*domain.OEEInputs, error) {
+	assets, err := r.assets.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := r.shifts.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events, err := r.events.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := r.counts.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	in := &domain.OEEInputs{Shifts: shifts}
+	scope := map[int64]bool{}
+	for i := range assets {
+		if filter.Match(&assets[i]) {
+			scope[assets[i].ID] = true
+			in.Assets = append(in.Assets, assets[i])
+		}
+	}
+	for i := range events {
+		if scope[events[i].AssetID] && events[i].Overlaps(from, &to) {
+			in.Downtime = append(in.Downtime, events[i])
+		}
+	}
+	for _, p := range counts {
+		if scope[p.AssetID] && p.PeriodStart.Before(to) && p.PeriodEnd.After(from) {
+			in.Counts = append(in.Counts, p)
+		}
+	}
+	return in, nil
+}
+
+func (r *ReportMemoryRepo) Pareto(ctx context.Context, from, to time.Time, filter domain.ParetoFilter) (*domain.ParetoReport, error) {
+	orders, err := r.orders.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ParetoReport{}
+	rows := map[int64]*domain.ParetoRow{}
+	for _, o := range orders {
+		closed := o.UpdatedAt
+		if o.ClosedAt != nil {
+			closed = *o.ClosedAt
+		}
+		if o.Status != domain.WOStatusDone || closed.Before(from) || !closed.Before(to) {
+			continue
+		}
+		if filter.AssetID != 0 && o.AssetID != filter.AssetID {
+			continue
+		}
+		if filter.Location != "" {
+			if a, err := r.assets.FindByID(ctx, o.AssetID); err != nil || a.Location != filter.Location {
+				continue
+			}
+		}
+		if o.FailureModeID == nil {
+			report.Unclassified++
+			continue
+		}
+
+		row, ok := rows[*o.FailureModeID]
+		if !ok {
+			row = &domain.ParetoRow{FailureModeID: *o.FailureModeID}
+			if fc, err := r.codes.FindByID(ctx, *o.FailureModeID); err == nil {
+				row.Code, row.Name = fc.Code, fc.Name
+			}
+			rows[*o.FailureModeID] = row
+		}
+		row.Count++
+		if o.DowntimeMinutes != nil {
+			row.DowntimeMinutes += *o.DowntimeMinutes
+		}
+	}
+	for _, row := range rows {
+		report.Rows = append(report.Rows, *row)
+	}
+	return report, nil
+}
+
+func (r *ReportMemoryRepo) MonthlySLA(ctx context.Context, from, to time.Time, loc *time.Location, now time.Time) ([]domain.SLAReportRow, error) {
+	orders, err := r.orders.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := map[string]*domain.SLAReportRow{}
+	for _, o := range orders {
+		if o.ResolutionDueAt == nil || o.Status == domain.WOStatusCanceled || o.CreatedAt.Before(from) || !o.CreatedAt.Before(to) {
+			continue
+		}
+		month := o.CreatedAt.In(loc).Format("2006-01")
+		row, ok := rows[month]
+		if !ok {
+			row = &domain.SLAReportRow{Month: month}
+			rows[month] = row
+		}
+		row.Orders++
+		if o.RespondedAt != nil || (o.ResponseDueAt != nil && o.ResponseDueAt.Before(now)) {
+			row.ResponseEvaluated++
+		}
+		if o.RespondedAt != nil && o.ResponseDueAt != nil && !o.RespondedAt.After(*o.ResponseDueAt) {
+			row.ResponseMet++
+		}
+		done := o.Status == domain.WOStatusDone
+		if done || o.ResolutionDueAt.Before(now) {
+			row.ResolutionEvaluated++
+		}
+		if done && o.ClosedAt != nil && !o.ClosedAt.After(*o.ResolutionDueAt) {
+			row.ResolutionMet++
+		}
+	}
+
+	list := make([]domain.SLAReportRow, 0, len(rows))
+	for _, row := range rows {
+		row.FillCompliance()
+		list = append(list, *row)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Month < list[j].Month })
+	return list, nil
+}
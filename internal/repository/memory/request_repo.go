@@ -0,0 +1,92 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type MaintenanceRequestMemoryRepo struct {
+	data map[int64]*domain.MaintenanceRequest
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewMaintenanceRequestMemoryRepo() *MaintenanceRequestMemoryRepo {
+	return &MaintenanceRequestMemoryRepo{
+		data: make(map[int64]*domain.MaintenanceRequest),
+		next: 1,
+	}
+}
+
+func (r *MaintenanceRequestMemoryRepo) Create(ctx context.Context, req *domain.MaintenanceRequest) error {
+	if err := tenant.Assign(ctx, &req.SiteID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	req.ID = r.next
+	r.next++
+	req.CreatedAt = time.Now()
+	req.UpdatedAt = req.CreatedAt
+	cp := *req
+	r.data[req.ID] = &cp
+	return nil
+}
+
+func (r *MaintenanceRequestMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.MaintenanceRequest, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	req, ok := r.data[id]
+	if !ok || !tenant.Visible(site, req.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	cp := *req
+	return &cp, nil
+}
+
+func (r *MaintenanceRequestMemoryRepo) FindAll(ctx context.Context, filter domain.RequestFilter) ([]domain.MaintenanceRequest, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := []domain.MaintenanceRequest{}
+	for _, req := range r.data {
+		if tenant.Visible(site, req.SiteID) && filter.Match(req) {
+			result = append(result, *req)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID > result[j].ID })
+	return result, nil
+}
+
+func (r *MaintenanceRequestMemoryRepo) Update(ctx context.Context, req *domain.MaintenanceRequest, from domain.RequestStatus) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[req.ID]
+	if !ok || !tenant.Visible(site, cur.SiteID) {
+		return domain.ErrNotFound
+	}
+	if cur.Status != from {
+		return domain.ErrConflict
+	}
+	req.SiteID, req.AssetID, req.CreatedAt = cur.SiteID, cur.AssetID, cur.CreatedAt
+	req.UpdatedAt = time.Now()
+	cp := *req
+	r.data[req.ID] = &cp
+	return nil
+}
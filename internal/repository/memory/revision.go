@@ -0,0 +1,12 @@
+package memory
+
+import "sync/atomic"
+
+// revisions é o contador de sincronização dos ativos, OS e checklists. A
+// revisão é tomada dentro do lock de escrita, então já segue a ordem em que as
+// escritas ficam visíveis; o Postgres precisa da marca d'água para isso.
+var revisions atomic.Int64
+
+func nextRevision() int64 {
+	return revisions.Add(1)
+}
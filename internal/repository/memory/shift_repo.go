@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type ShiftMemoryRepo struct {
+	data map[int64]*domain.Shift
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewShiftMemoryRepo() *ShiftMemoryRepo {
+	return &ShiftMemoryRepo{
+		data: make(map[int64]*domain.Shift),
+		next: 1,
+	}
+}
+
+func (r *ShiftMemoryRepo) Create(ctx context.Context, s *domain.Shift) error {
+	if err := tenant.Assign(ctx, &s.SiteID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s.ID = r.next
+	r.next++
+	s.CreatedAt = time.Now()
+	cp := *s
+	r.data[s.ID] = &cp
+	return nil
+}
+
+func (r *ShiftMemoryRepo) FindAll(ctx context.Context) ([]domain.Shift, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.Shift, 0, len(r.data))
+	for _, s := range r.data {
+		if tenant.Visible(site, s.SiteID) {
+			result = append(result, *s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Location != result[j].Location {
+			return result[i].Location < result[j].Location
+		}
+		if result[i].StartTime != result[j].StartTime {
+			return result[i].StartTime < result[j].StartTime
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (r *ShiftMemoryRepo) Delete(ctx context.Context, id int64) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if s, ok := r.data[id]; !ok || !tenant.Visible(site, s.SiteID) {
+		return domain.ErrNotFound
+	}
+	delete(r.data, id)
+	return nil
+}
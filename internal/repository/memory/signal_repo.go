@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// SignalMemoryRepo herda o site do ativo de cada leitura.
+type SignalMemoryRepo struct {
+	assets     *AssetMemoryRepo
+	meters     []domain.MeterReading
+	conditions []domain.ConditionReading
+	mu         sync.RWMutex
+	next       int64
+}
+
+func NewSignalMemoryRepo(assets *AssetMemoryRepo) *SignalMemoryRepo {
+	return &SignalMemoryRepo{assets: assets, next: 1}
+}
+
+// reachable devolve ErrNotFound se o ativo estiver fora do escopo.
+func (r *SignalMemoryRepo) reachable(ctx context.Context, assetID int64) error {
+	visible, err := r.assets.inScope(ctx)
+	if err != nil {
+		return err
+	}
+	if !visible(assetID) {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *SignalMemoryRepo) CreateMeterReading(ctx context.Context, m *domain.MeterReading) error {
+	if err := r.reachable(ctx, m.AssetID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m.ID = r.next
+	r.next++
+	m.CreatedAt = time.Now()
+	r.meters = append(r.meters, *m)
+	return nil
+}
+
+func (r *SignalMemoryRepo) MeterReadingAt(ctx context.Context, assetID int64, at time.Time) (*domain.MeterReading, error) {
+	if err := r.reachable(ctx, assetID); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var before, after *domain.MeterReading
+	for i := range r.meters {
+		m := &r.meters[i]
+		if m.AssetID != assetID {
+			continue
+		}
+		if !m.ReadAt.After(at) {
+			if before == nil || !m.ReadAt.Before(before.ReadAt) {
+				before = m
+			}
+		} else if after == nil || m.ReadAt.Before(after.ReadAt) {
+			after = m
+		}
+	}
+	if before == nil {
+		before = after
+	}
+	if before == nil {
+		return nil, domain.ErrNotFound
+	}
+	cp := *before
+	return &cp, nil
+}
+
+func (r *SignalMemoryRepo) CreateConditionReading(ctx context.Context, c *domain.ConditionReading) error {
+	if err := r.reachable(ctx, c.AssetID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c.ID = r.next
+	r.next++
+	c.CreatedAt = time.Now()
+	r.conditions = append(r.conditions, *c)
+	return nil
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type SiteMemoryRepo struct {
+	data map[int64]*domain.Site
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewSiteMemoryRepo() *SiteMemoryRepo {
+	return &SiteMemoryRepo{
+		data: make(map[int64]*domain.Site),
+		next: 1,
+	}
+}
+
+func (r *SiteMemoryRepo) Create(_ context.Context, s *domain.Site) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, cur := range r.data {
+		if strings.EqualFold(cur.Code, s.Code) {
+			return domain.ErrAlreadyExists
+		}
+	}
+	s.ID = r.next
+	r.next++
+	s.CreatedAt = time.Now()
+	cp := *s
+	r.data[s.ID] = &cp
+	return nil
+}
+
+func (r *SiteMemoryRepo) FindAll(ctx context.Context) ([]domain.Site, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := []domain.Site{}
+	for _, s := range r.data {
+		if tenant.Visible(site, s.ID) {
+			result = append(result, *s)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *SiteMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.Site, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	s, ok := r.data[id]
+	if !ok || !tenant.Visible(site, s.ID) {
+		return nil, domain.ErrNotFound
+	}
+	cp := *s
+	return &cp, nil
+}
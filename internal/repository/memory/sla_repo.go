@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type slaKey struct {
+	criticality domain.Criticality
+	woType      domain.WorkOrderType
+}
+
+// SLAMemoryRepo começa com domain.DefaultSLAMatrix, como o banco após a migração.
+type SLAMemoryRepo struct {
+	data []domain.SLAPolicy
+	mu   sync.RWMutex
+}
+
+func NewSLAMemoryRepo() *SLAMemoryRepo {
+	return &SLAMemoryRepo{data: domain.DefaultSLAMatrix()}
+}
+
+func (r *SLAMemoryRepo) FindAll(_ context.Context) ([]domain.SLAPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]domain.SLAPolicy(nil), r.data...), nil
+}
+
+func (r *SLAMemoryRepo) Find(_ context.Context, criticality domain.Criticality, woType domain.WorkOrderType) (*domain.SLAPolicy, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, p := range r.data {
+		if p.Criticality == criticality && p.Type == woType {
+			cp := p
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
+
+func (r *SLAMemoryRepo) ReplaceAll(_ context.Context, policies []domain.SLAPolicy) error {
+	seen := map[slaKey]bool{}
+	for _, p := range policies {
+		k := slaKey{p.Criticality, p.Type}
+		if seen[k] {
+			return domain.ErrAlreadyExists
+		}
+		seen[k] = true
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data = append([]domain.SLAPolicy(nil), policies...)
+	return nil
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type SparePartMemoryRepo struct {
+	data map[int64]*domain.SparePart
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewSparePartMemoryRepo() *SparePartMemoryRepo {
+	return &SparePartMemoryRepo{
+		data: make(map[int64]*domain.SparePart),
+		next: 1,
+	}
+}
+
+func (r *SparePartMemoryRepo) Create(ctx context.Context, p *domain.SparePart) error {
+	if err := tenant.Assign(ctx, &p.SiteID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, other := range r.data {
+		if other.SiteID == p.SiteID && other.Code == p.Code {
+			return domain.ErrAlreadyExists
+		}
+	}
+	p.ID = r.next
+	r.next++
+	p.CreatedAt = time.Now()
+	p.UpdatedAt = p.CreatedAt
+	cp := *p
+	r.data[p.ID] = &cp
+	return nil
+}
+
+func (r *SparePartMemoryRepo) Update(ctx context.Context, p *domain.SparePart) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[p.ID]
+	if !ok || !tenant.Visible(site, cur.SiteID) {
+		return domain.ErrNotFound
+	}
+	cur.Name, cur.Unit, cur.MinQuantity = p.Name, p.Unit, p.MinQuantity
+	cur.UpdatedAt = time.Now()
+	*p = *cur
+	return nil
+}
+
+func (r *SparePartMemoryRepo) FindAll(ctx context.Context) ([]domain.SparePart, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.SparePart, 0, len(r.data))
+	for _, p := range r.data {
+		if tenant.Visible(site, p.SiteID) {
+			result = append(result, *p)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Code < result[j].Code })
+	return result, nil
+}
+
+func (r *SparePartMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.SparePart, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.data[id]
+	if !ok || !tenant.Visible(site, p.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	cp := *p
+	return &cp, nil
+}
+
+func (r *SparePartMemoryRepo) AdjustStock(ctx context.Context, id int64, delta float64) (*domain.SparePart, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.data[id]
+	if !ok || !tenant.Visible(site, p.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	if p.Quantity+delta < 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	p.Quantity += delta
+	p.UpdatedAt = time.Now()
+	cp := *p
+	return &cp, nil
+}
@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// SyncMemoryRepo lê o delta direto dos repositórios de ativos, OS e checklists.
+type SyncMemoryRepo struct {
+	assets     *AssetMemoryRepo
+	orders     *WorkOrderMemoryRepo
+	checklists *ChecklistMemoryRepo
+}
+
+func NewSyncMemoryRepo(assets *AssetMemoryRepo, orders *WorkOrderMemoryRepo, checklists *ChecklistMemoryRepo) *SyncMemoryRepo {
+	return &SyncMemoryRepo{assets: assets, orders: orders, checklists: checklists}
+}
+
+func (r *SyncMemoryRepo) Changes(ctx context.Context, since, technicianID int64, limit int) ([]domain.SyncChange, error) {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Os três locks juntos: nenhuma escrita com revisão já tomada fica de fora da leitura.
+	r.assets.mu.RLock()
+	defer r.assets.mu.RUnlock()
+	r.orders.mu.RLock()
+	defer r.orders.mu.RUnlock()
+	r.checklists.mu.RLock()
+	defer r.checklists.mu.RUnlock()
+
+	var changes []domain.SyncChange
+	for _, a := range r.assets.data {
+		if tenant.Visible(site, a.SiteID) && a.Revision > since {
+			cp := copyAsset(a)
+			changes = append(changes, domain.SyncChange{Entity: domain.SyncAsset, Revision: a.Revision, Asset: &cp})
+		}
+	}
+	for _, o := range r.orders.data {
+		if !tenant.Visible(site, o.SiteID) || !assignedTo(o, technicianID) {
+			continue
+		}
+		if o.Revision > since && (since > 0 || o.IsOpen()) {
+			cp := *o
+			changes = append(changes, domain.SyncChange{Entity: domain.SyncWorkOrder, Revision: o.Revision, WorkOrder: &cp})
+		}
+		if !o.IsOpen() {
+			continue
+		}
+		for _, it := range r.checklists.data[o.ID] {
+			if it.Revision > since {
+				cp := copyChecklistItem(it)
+				changes = append(changes, domain.SyncChange{
+					Entity: domain.SyncChecklistItem, Revision: it.Revision, WorkOrderID: o.ID, ChecklistItem: &cp,
+				})
+			}
+		}
+	}
+	if technicianID != 0 && since > 0 {
+		for _, h := range r.orders.handoffs {
+			o := r.orders.data[h.workOrderID]
+			if h.technicianID == technicianID && h.revision > since && tenant.Visible(site, o.SiteID) && !assignedTo(o, technicianID) {
+				changes = append(changes, domain.SyncChange{
+					Entity: domain.SyncWorkOrder, Revision: h.revision, Deleted: true, WorkOrder: &domain.WorkOrder{ID: o.ID},
+				})
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Revision < changes[j].Revision })
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+// assignedTo diz se a OS entra no delta do técnico (technicianID = 0: todas).
+func assignedTo(o *domain.WorkOrder, technicianID int64) bool {
+	return technicianID == 0 || (o.AssigneeID != nil && *o.AssigneeID == technicianID)
+}
+
+func (r *SyncMemoryRepo) WorkOrderChanges(ctx context.Context, since int64, limit int) ([]domain.WorkOrder, error) {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.orders.mu.RLock()
+	defer r.orders.mu.RUnlock()
+
+	var list []domain.WorkOrder
+	for _, o := range r.orders.data {
+		if tenant.Visible(site, o.SiteID) && o.Revision > since && (since > 0 || o.IsOpen()) {
+			list = append(list, *o)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Revision < list[j].Revision })
+	if len(list) > limit {
+		list = list[:limit]
+	}
+	return list, nil
+}
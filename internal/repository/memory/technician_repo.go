@@ -0,0 +1,135 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type TechnicianMemoryRepo struct {
+	data map[int64]*domain.Technician
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewTechnicianMemoryRepo() *TechnicianMemoryRepo {
+	return &TechnicianMemoryRepo{
+		data: make(map[int64]*domain.Technician),
+		next: 1,
+	}
+}
+
+// copyTechnician evita compartilhar a lista de especialidades com quem chamou.
+func copyTechnician(t *domain.Technician) domain.Technician {
+	cp := *t
+	cp.Skills = append([]string{}, t.Skills...)
+	if t.UserID != nil {
+		u := *t.UserID
+		cp.UserID = &u
+	}
+	return cp
+}
+
+// userTaken reproduz o índice único de technicians.user_id.
+func (r *TechnicianMemoryRepo) userTaken(userID *int64, exceptID int64) bool {
+	if userID == nil {
+		return false
+	}
+	for id, t := range r.data {
+		if id != exceptID && t.UserID != nil && *t.UserID == *userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *TechnicianMemoryRepo) Create(ctx context.Context, t *domain.Technician) error {
+	if err := tenant.Assign(ctx, &t.SiteID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.userTaken(t.UserID, 0) {
+		return domain.ErrAlreadyExists
+	}
+	t.ID = r.next
+	r.next++
+	t.CreatedAt = time.Now()
+	t.UpdatedAt = t.CreatedAt
+	cp := copyTechnician(t)
+	r.data[t.ID] = &cp
+	return nil
+}
+
+func (r *TechnicianMemoryRepo) Update(ctx context.Context, t *domain.Technician) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[t.ID]
+	if !ok || !tenant.Visible(site, cur.SiteID) {
+		return domain.ErrNotFound
+	}
+	if r.userTaken(t.UserID, t.ID) {
+		return domain.ErrAlreadyExists
+	}
+	t.SiteID, t.CreatedAt = cur.SiteID, cur.CreatedAt
+	t.UpdatedAt = time.Now()
+	cp := copyTechnician(t)
+	r.data[t.ID] = &cp
+	return nil
+}
+
+func (r *TechnicianMemoryRepo) FindAll(ctx context.Context) ([]domain.Technician, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.Technician, 0, len(r.data))
+	for _, t := range r.data {
+		if tenant.Visible(site, t.SiteID) {
+			result = append(result, copyTechnician(t))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *TechnicianMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.Technician, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.data[id]
+	if !ok || !tenant.Visible(site, t.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	cp := copyTechnician(t)
+	return &cp, nil
+}
+
+func (r *TechnicianMemoryRepo) FindByUser(ctx context.Context, userID int64) (*domain.Technician, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, t := range r.data {
+		if t.UserID != nil && *t.UserID == userID && tenant.Visible(site, t.SiteID) {
+			cp := copyTechnician(t)
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrNotFound
+}
@@ -0,0 +1,28 @@
+package memory
+
+import (
+	"context"
+	"sync"
+)
+
+type txKey struct{}
+
+// Transactor serializa os blocos de InTx (não desfaz gravações: os repositórios
+// em memória não têm rollback). Basta para os testes de concorrência.
+type Transactor struct {
+	mu sync.Mutex
+}
+
+func NewTransactor() *Transactor {
+	return &Transactor{}
+}
+
+// InTx executa fn com exclusão mútua; dentro de outra InTx, só executa fn.
+func (t *Transactor) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return fn(context.WithValue(ctx, txKey{}, t))
+}
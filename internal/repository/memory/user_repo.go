@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type UserMemoryRepo struct {
+	data map[int64]*domain.User
+	mu   sync.RWMutex
+	next int64
+}
+
+func NewUserMemoryRepo() *UserMemoryRepo {
+	return &UserMemoryRepo{
+		data: make(map[int64]*domain.User),
+		next: 1,
+	}
+}
+
+// copyUser evita compartilhar o mapa de preferências com quem chamou.
+func copyUser(u *domain.User) domain.User {
+	cp := *u
+	cp.Preferences = make(map[domain.NotificationEvent][]domain.NotificationChannel, len(u.Preferences))
+	for event, channels := range u.Preferences {
+		cp.Preferences[event] = append([]domain.NotificationChannel{}, channels...)
+	}
+	return cp
+}
+
+func (r *UserMemoryRepo) Create(ctx context.Context, u *domain.User) error {
+	if err := tenant.Assign(ctx, &u.SiteID); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	u.ID = r.next
+	r.next++
+	u.CreatedAt = time.Now()
+	u.UpdatedAt = u.CreatedAt
+	cp := copyUser(u)
+	r.data[u.ID] = &cp
+	return nil
+}
+
+// Update não muda o site do usuário.
+func (r *UserMemoryRepo) Update(ctx context.Context, u *domain.User) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[u.ID]
+	if !ok || !tenant.Visible(site, cur.SiteID) {
+		return domain.ErrNotFound
+	}
+	u.SiteID = cur.SiteID
+	u.CreatedAt = cur.CreatedAt
+	u.UpdatedAt = time.Now()
+	cp := copyUser(u)
+	r.data[u.ID] = &cp
+	return nil
+}
+
+func (r *UserMemoryRepo) FindAll(ctx context.Context) ([]domain.User, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]domain.User, 0, len(r.data))
+	for _, u := range r.data {
+		if tenant.Visible(site, u.SiteID) {
+			result = append(result, copyUser(u))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *UserMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.User, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	u, ok := r.data[id]
+	if !ok || !tenant.Visible(site, u.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	cp := copyUser(u)
+	return &cp, nil
+}
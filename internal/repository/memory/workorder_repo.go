@@ -1,17 +1,28 @@
 package memory
 
 import (
+	"context"
 	"sort"
 	"sync"
 	"time"
 
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
 )
 
 type WorkOrderMemoryRepo struct {
 	data map[int64]*domain.WorkOrder
 	mu   sync.RWMutex
 	next int64
+	// handoffs são as saídas de responsável, lidas pelo SyncMemoryRepo; reassigned
+	// reenvia o checklist ao novo responsável (ligado pelo ChecklistMemoryRepo).
+	handoffs   []handoff
+	reassigned func(workOrderID int64)
+}
+
+// handoff é a OS que saiu do técnico (a work_order_handoffs do Postgres).
+type handoff struct {
+	workOrderID, technicianID, revision int64
 }
 
 func NewWorkOrderMemoryRepo() *WorkOrderMemoryRepo {
@@ -21,29 +32,73 @@ func NewWorkOrderMemoryRepo() *WorkOrderMemoryRepo {
 	}
 }
 
-func (r *WorkOrderMemoryRepo) Create(order *domain.WorkOrder) error {
+// Create grava a OS no site do escopo; em escopo de todos os sites, SiteID
+// precisa vir preenchido (o serviço copia o do ativo).
+func (r *WorkOrderMemoryRepo) Create(ctx context.Context, order *domain.WorkOrder) error {
+	if err := tenant.Assign(ctx, &order.SiteID); err != nil {
+		return err
+	}
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	if order.PlanID != nil && order.IsOpen() {
+		// Mesma regra do índice único do banco: uma OS em aberto por plano.
+		for _, o := range r.data {
+			if o.PlanID != nil && *o.PlanID == *order.PlanID && o.IsOpen() {
+				return domain.ErrAlreadyExists
+			}
+		}
+	}
 	order.ID = r.next
 	r.next++
 	order.CreatedAt = time.Now()
 	order.UpdatedAt = order.CreatedAt
+	order.Revision = nextRevision()
 	r.data[order.ID] = order
 	return nil
 }
 
-func (r *WorkOrderMemoryRepo) FindAll() ([]domain.WorkOrder, error) {
+// CreateBatch grava em lote; datas de criação já preenchidas são preservadas.
+func (r *WorkOrderMemoryRepo) CreateBatch(ctx context.Context, orders []domain.WorkOrder) (int64, error) {
+	for i := range orders {
+		if err := tenant.Assign(ctx, &orders[i].SiteID); err != nil {
+			return 0, err
+		}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for i := range orders {
+		item := orders[i]
+		item.ID = r.next
+		r.next++
+		if item.CreatedAt.IsZero() {
+			item.CreatedAt = now
+		}
+		item.UpdatedAt = now
+		item.Revision = nextRevision()
+		r.data[item.ID] = &item
+	}
+	return int64(len(orders)), nil
+}
+
+func (r *WorkOrderMemoryRepo) FindAll(ctx context.Context) ([]domain.WorkOrder, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	// colete e ordene os IDs
 	ids := make([]int64, 0, len(r.data))
-	for id := range r.data {
-		ids = append(ids, id)
+	for id, o := range r.data {
+		if tenant.Visible(site, o.SiteID) {
+			ids = append(ids, id)
+		}
 	}
 	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
 
-	result := make([]domain.WorkOrder, 0, len(r.data))
+	result := make([]domain.WorkOrder, 0, len(ids))
 	for _, id := range ids {
 		o := r.data[id]
 		result = append(result, *o)
@@ -51,14 +106,183 @@ func (r *WorkOrderMemoryRepo) FindAll() ([]domain.WorkOrder, error) {
 	return result, nil
 }
 
-func (r *WorkOrderMemoryRepo) FindByStatus(status domain.WorkOrderStatus) ([]domain.WorkOrder, error) {
+func (r *WorkOrderMemoryRepo) FindByStatus(ctx context.Context, status domain.WorkOrderStatus) ([]domain.WorkOrder, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 	result := []domain.WorkOrder{}
 	for _, o := range r.data {
-		if o.Status == status {
+		if o.Status == status && tenant.Visible(site, o.SiteID) {
 			result = append(result, *o)
 		}
 	}
 	return result, nil
 }
+
+func (r *WorkOrderMemoryRepo) Stream(ctx context.Context, filter domain.WorkOrderFilter, fn func(*domain.WorkOrder) error) error {
+	all, err := r.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	// Com PerAsset, só as mais recentes de cada ativo: conta da mais nova para a
+	// mais antiga (created_at e id decrescentes, como o ROW_NUMBER do postgres).
+	recent := make([]int, len(all))
+	for i := range recent {
+		recent[i] = i
+	}
+	sort.SliceStable(recent, func(a, b int) bool {
+		x, y := &all[recent[a]], &all[recent[b]]
+		if !x.CreatedAt.Equal(y.CreatedAt) {
+			return x.CreatedAt.After(y.CreatedAt)
+		}
+		return x.ID > y.ID
+	})
+	keep := make([]bool, len(all))
+	perAsset := map[int64]int{}
+	for _, i := range recent {
+		if !filter.Match(&all[i]) {
+			continue
+		}
+		if filter.PerAsset > 0 {
+			if perAsset[all[i].AssetID] >= filter.PerAsset {
+				continue
+			}
+			perAsset[all[i].AssetID]++
+		}
+		keep[i] = true
+	}
+	for i := range all {
+		if !keep[i] {
+			continue
+		}
+		if err := fn(&all[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *WorkOrderMemoryRepo) FindByID(ctx context.Context, id int64) (*domain.WorkOrder, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	o, ok := r.data[id]
+	if !ok || !tenant.Visible(site, o.SiteID) {
+		return nil, domain.ErrNotFound
+	}
+	cp := *o
+	return &cp, nil
+}
+
+// Update mantém os campos fixados na abertura (site, ativo, tipo, SLA, plano e roteiro) e o DowntimeMinutes derivado.
+func (r *WorkOrderMemoryRepo) Update(ctx context.Context, order *domain.WorkOrder) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur, ok := r.data[order.ID]
+	if !ok || !tenant.Visible(site, cur.SiteID) {
+		return domain.ErrNotFound
+	}
+	order.SiteID = cur.SiteID
+	order.AssetID, order.Type, order.DowntimeMinutes = cur.AssetID, cur.Type, cur.DowntimeMinutes
+	order.Priority, order.ResponseDueAt, order.ResolutionDueAt = cur.Priority, cur.ResponseDueAt, cur.ResolutionDueAt
+	order.SLABreachedAt = cur.SLABreachedAt
+	order.PlanID, order.ScheduledFor = cur.PlanID, cur.ScheduledFor
+	order.JobPlanID, order.RequiredParts = cur.JobPlanID, cur.RequiredParts
+	order.RequestID = cur.RequestID
+	order.CreatedAt = cur.CreatedAt
+	order.UpdatedAt = time.Now()
+	order.Revision = nextRevision()
+	cp := *order
+	r.data[order.ID] = &cp
+	if !sameID(cur.AssigneeID, order.AssigneeID) {
+		r.reassign(order.ID, cur.AssigneeID, order.AssigneeID)
+	}
+	return nil
+}
+
+// reassign reproduz o trigger de troca de responsável: registra a saída do
+// anterior e reenvia o checklist ao novo. Chamado com o lock de escrita.
+func (r *WorkOrderMemoryRepo) reassign(id int64, from, to *int64) {
+	if from != nil {
+		h := handoff{workOrderID: id, technicianID: *from, revision: nextRevision()}
+		replaced := false
+		for i := range r.handoffs {
+			if r.handoffs[i].workOrderID == id && r.handoffs[i].technicianID == *from {
+				r.handoffs[i], replaced = h, true
+			}
+		}
+		if !replaced {
+			r.handoffs = append(r.handoffs, h)
+		}
+	}
+	if to != nil && r.reassigned != nil {
+		r.reassigned(id)
+	}
+}
+
+func sameID(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (r *WorkOrderMemoryRepo) MarkSLABreached(ctx context.Context, now time.Time) ([]domain.WorkOrder, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var marked []domain.WorkOrder
+	for _, o := range r.data {
+		if tenant.Visible(site, o.SiteID) && o.SLABreachedAt == nil && o.IsOverdue(now) {
+			at := now
+			o.SLABreachedAt = &at
+			o.Revision = nextRevision()
+			marked = append(marked, *o)
+		}
+	}
+	sort.Slice(marked, func(i, j int) bool { return marked[i].ID < marked[j].ID })
+	return marked, nil
+}
+
+func (r *WorkOrderMemoryRepo) SetDowntimeMinutes(ctx context.Context, id int64, minutes *int64) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o, ok := r.data[id]
+	if !ok || !tenant.Visible(site, o.SiteID) {
+		return domain.ErrNotFound
+	}
+	o.DowntimeMinutes = minutes
+	o.UpdatedAt = time.Now()
+	o.Revision = nextRevision()
+	return nil
+}
+
+// visible diz se a OS existe e pertence ao escopo do contexto; os checklists
+// herdam o site dela.
+func (r *WorkOrderMemoryRepo) visible(ctx context.Context, id int64) (bool, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	o, ok := r.data[id]
+	return ok && tenant.Visible(site, o.SiteID), nil
+}
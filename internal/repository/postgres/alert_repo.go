@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type AlertRepo struct {
+	db *DB
+}
+
+func NewAlertRepo(db *DB) *AlertRepo {
+	return &AlertRepo{db: db}
+}
+
+const alertColumns = `id, site_id, event, ref_type, ref_id, message, triggered_at, tier, acked_at, acked_by, resolved_at, created_at`
+
+func scanAlert(row pgx.Row) (domain.Alert, error) {
+	var a domain.Alert
+	err := row.Scan(&a.ID, &a.SiteID, &a.Event, &a.RefType, &a.RefID, &a.Message, &a.TriggeredAt, &a.Tier,
+		&a.AckedAt, &a.AckedBy, &a.ResolvedAt, &a.CreatedAt)
+	return a, err
+}
+
+func (r *AlertRepo) Create(ctx context.Context, a *domain.Alert) error {
+	if err := tenant.Assign(ctx, &a.SiteID); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO alerts (site_id, event, ref_type, ref_id, message, triggered_at, tier, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		RETURNING id, created_at;
+	`
+	err := r.db.Pool.QueryRow(ctx, query, a.SiteID, a.Event, a.RefType, a.RefID, a.Message, a.TriggeredAt, a.Tier).
+		Scan(&a.ID, &a.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert alert: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *AlertRepo) FindByID(ctx context.Context, id int64) (*domain.Alert, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, err := scanAlert(r.db.Pool.QueryRow(ctx, `SELECT `+alertColumns+` FROM alerts
+		WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find alert: %w", err)
+	}
+	return &a, nil
+}
+
+func (r *AlertRepo) FindAll(ctx context.Context, escalatingOnly bool) ([]domain.Alert, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `
+		SELECT `+alertColumns+`
+		FROM alerts
+		WHERE ($2::bigint = 0 OR site_id = $2) AND (NOT $1 OR (acked_at IS NULL AND resolved_at IS NULL))
+		ORDER BY id DESC;`, escalatingOnly, site)
+	if err != nil {
+		return nil, fmt.Errorf("query alerts: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Alert, error) { return scanAlert(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan alert: %w", err)
+	}
+	return list, nil
+}
+
+func (r *AlertRepo) SetTier(ctx context.Context, id int64, tier int) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tag, err := r.db.Pool.Exec(ctx, `UPDATE alerts SET tier=$2 WHERE id=$1 AND ($3::bigint = 0 OR site_id = $3);`,
+		id, tier, site)
+	if err != nil {
+		return fmt.Errorf("update alert tier: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *AlertRepo) Ack(ctx context.Context, id, userID int64, at time.Time) (*domain.Alert, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, err := scanAlert(r.db.Pool.QueryRow(ctx, `
+		UPDATE alerts SET acked_at=$3, acked_by=$2
+		WHERE id=$1 AND acked_at IS NULL AND resolved_at IS NULL AND ($4::bigint = 0 OR site_id = $4)
+		RETURNING `+alertColumns+`;`, id, userID, at, site))
+	if err == pgx.ErrNoRows {
+		// Distingue alerta inexistente de alerta já encerrado.
+		if _, findErr := r.FindByID(ctx, id); findErr != nil {
+			return nil, findErr
+		}
+		return nil, domain.ErrConflict
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ack alert: %w", mapError(err))
+	}
+	return &a, nil
+}
+
+func (r *AlertRepo) Resolve(ctx context.Context, refType domain.AlertRefType, refID int64, at time.Time) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	_, err = r.db.Pool.Exec(ctx, `
+		UPDATE alerts SET resolved_at=$3
+		WHERE ref_type=$1 AND ref_id=$2 AND resolved_at IS NULL AND ($4::bigint = 0 OR site_id = $4);`,
+		refType, refID, at, site)
+	if err != nil {
+		return fmt.Errorf("resolve alerts: %w", err)
+	}
+	return nil
+}
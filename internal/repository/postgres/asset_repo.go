@@ -3,10 +3,12 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
 )
 
 type AssetRepo struct {
@@ -17,32 +19,85 @@ func NewAssetRepo(db *DB) *AssetRepo {
 	return &AssetRepo{db: db}
 }
 
-func (r *AssetRepo) Create(asset *domain.Asset) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+const assetColumns = `id, site_id, name, COALESCE(location,''), criticality, COALESCE(external_code,''),
+					ideal_rate_per_hour::float8, COALESCE(manufacturer,''), COALESCE(model,''), COALESCE(serial_number,''),
+					COALESCE(to_char(installed_on,'YYYY-MM-DD'),''), COALESCE(to_char(warranty_until,'YYYY-MM-DD'),''),
+					COALESCE(class,''), attributes, created_at, updated_at, revision`
+
+func scanAsset(row pgx.Row) (domain.Asset, error) {
+	var a domain.Asset
+	err := row.Scan(&a.ID, &a.SiteID, &a.Name, &a.Location, &a.Criticality, &a.ExternalCode,
+		&a.IdealRatePerHour, &a.Manufacturer, &a.Model, &a.SerialNumber, &a.InstalledOn, &a.WarrantyUntil,
+		&a.Class, &a.Attributes, &a.CreatedAt, &a.UpdatedAt, &a.Revision)
+	if len(a.Attributes) == 0 {
+		a.Attributes = nil
+	}
+	return a, err
+}
+
+func (r *AssetRepo) Create(ctx context.Context, asset *domain.Asset) error {
+	if err := tenant.Assign(ctx, &asset.SiteID); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	query := `
-		INSERT INTO assets (name, location, criticality, created_at, updated_at)
-		VALUES ($1, $2, $3, NOW(), NOW())
-		RETURNING id, created_at, updated_at;
+		INSERT INTO assets (site_id, name, location, criticality, external_code, ideal_rate_per_hour,
+		                    manufacturer, model, serial_number, installed_on, warranty_until, class, attributes,
+		                    created_at, updated_at)
+		VALUES ($13, $1, $2, $3, NULLIF($4,''), $5, NULLIF($6,''), NULLIF($7,''), NULLIF($8,''),
+		        NULLIF($9,'')::date, NULLIF($10,'')::date, NULLIF($11,''), COALESCE($12,'{}'::jsonb), NOW(), NOW())
+		RETURNING id, created_at, updated_at, revision;
 	`
 
-	err := r.db.Pool.QueryRow(ctx, query, asset.Name, asset.Location, asset.Criticality).
-		Scan(&asset.ID, &asset.CreatedAt, &asset.UpdatedAt)
+	err := r.db.Pool.QueryRow(ctx, query, asset.Name, asset.Location, asset.Criticality, asset.ExternalCode, asset.IdealRatePerHour,
+		asset.Manufacturer, asset.Model, asset.SerialNumber, asset.InstalledOn, asset.WarrantyUntil, asset.Class, asset.Attributes,
+		asset.SiteID).
+		Scan(&asset.ID, &asset.CreatedAt, &asset.UpdatedAt, &asset.Revision)
 	if err != nil {
-		return fmt.Errorf("insert asset: %w", err)
+		return fmt.Errorf("insert asset: %w", mapError(err))
 	}
 	return nil
 }
 
-func (r *AssetRepo) FindAll() ([]domain.Asset, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+// CreateBatch grava os ativos via COPY (importações em lote); não preenche os IDs.
+func (r *AssetRepo) CreateBatch(ctx context.Context, assets []domain.Asset) (int64, error) {
+	for i := range assets {
+		if err := tenant.Assign(ctx, &assets[i].SiteID); err != nil {
+			return 0, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	n, err := r.db.Pool.CopyFrom(ctx,
+		pgx.Identifier{"assets"},
+		[]string{"site_id", "name", "location", "criticality", "external_code", "created_at", "updated_at"},
+		pgx.CopyFromSlice(len(assets), func(i int) ([]any, error) {
+			a := assets[i]
+			return []any{a.SiteID, a.Name, a.Location, string(a.Criticality), nullIfEmpty(a.ExternalCode), now, now}, nil
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("copy assets: %w", mapError(err))
+	}
+	return n, nil
+}
+
+func (r *AssetRepo) FindAll(ctx context.Context) ([]domain.Asset, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	query := `SELECT id, name, COALESCE(location,''), criticality, created_at, updated_at
-          FROM assets ORDER BY id;`
+	query := `SELECT ` + assetColumns + `
+          FROM assets WHERE ($1::bigint = 0 OR site_id = $1) ORDER BY id;`
 
-	rows, err := r.db.Pool.Query(ctx, query)
+	rows, err := r.db.Pool.Query(ctx, query, site)
 	if err != nil {
 		return nil, fmt.Errorf("query assets: %w", err)
 	}
@@ -50,8 +105,8 @@ func (r *AssetRepo) FindAll() ([]domain.Asset, error) {
 
 	var assets []domain.Asset
 	for rows.Next() {
-		var a domain.Asset
-		if err := rows.Scan(&a.ID, &a.Name, &a.Location, &a.Criticality, &a.CreatedAt, &a.UpdatedAt); err != nil {
+		a, err := scanAsset(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan asset: %w", err)
 		}
 		assets = append(assets, a)
@@ -59,15 +114,18 @@ func (r *AssetRepo) FindAll() ([]domain.Asset, error) {
 	return assets, nil
 }
 
-func (r *AssetRepo) FindByID(id int64) (*domain.Asset, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (r *AssetRepo) FindByID(ctx context.Context, id int64) (*domain.Asset, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	query := `SELECT id, name, COALESCE(location,''), criticality, created_at, updated_at
-          FROM assets WHERE id=$1;`
+	query := `SELECT ` + assetColumns + `
+          FROM assets WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`
 
-	var a domain.Asset
-	err := r.db.Pool.QueryRow(ctx, query, id).Scan(&a.ID, &a.Name, &a.Location, &a.Criticality, &a.CreatedAt, &a.UpdatedAt)
+	a, err := scanAsset(r.db.Pool.QueryRow(ctx, query, id, site))
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, domain.ErrNotFound
@@ -76,3 +134,128 @@ func (r *AssetRepo) FindByID(id int64) (*domain.Asset, error) {
 	}
 	return &a, nil
 }
+
+func (r *AssetRepo) FindByCode(ctx context.Context, code string) (*domain.Asset, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + assetColumns + `
+          FROM assets WHERE LOWER(external_code)=LOWER($1) AND ($2::bigint = 0 OR site_id = $2)
+          ORDER BY id LIMIT 1;`
+
+	a, err := scanAsset(r.db.Pool.QueryRow(ctx, query, code, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find asset by code: %w", err)
+	}
+	return &a, nil
+}
+
+// Update não muda o site do ativo.
+func (r *AssetRepo) Update(ctx context.Context, asset *domain.Asset) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE assets
+		SET name=$2, location=$3, criticality=$4, external_code=NULLIF($5,''), ideal_rate_per_hour=$6,
+		    manufacturer=NULLIF($7,''), model=NULLIF($8,''), serial_number=NULLIF($9,''),
+		    installed_on=NULLIF($10,'')::date, warranty_until=NULLIF($11,'')::date,
+		    class=NULLIF($12,''), attributes=COALESCE($13,'{}'::jsonb), updated_at=NOW()
+		WHERE id=$1 AND ($14::bigint = 0 OR site_id = $14)
+		RETURNING site_id, updated_at, revision;
+	`
+	err = r.db.Pool.QueryRow(ctx, query,
+		asset.ID, asset.Name, asset.Location, asset.Criticality, asset.ExternalCode, asset.IdealRatePerHour,
+		asset.Manufacturer, asset.Model, asset.SerialNumber, asset.InstalledOn, asset.WarrantyUntil,
+		asset.Class, asset.Attributes, site,
+	).Scan(&asset.SiteID, &asset.UpdatedAt, &asset.Revision)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("update asset: %w", mapError(err))
+	}
+	return nil
+}
+
+// nullIfEmpty converte "" em NULL para colunas de texto opcionais no COPY.
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (r *AssetRepo) Stream(ctx context.Context, filter domain.AssetFilter, fn func(*domain.Asset) error) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, streamTimeout)
+	defer cancel()
+
+	var (
+		where []string
+		args  []any
+	)
+	if site != 0 {
+		args = append(args, site)
+		where = append(where, fmt.Sprintf("site_id = $%d", len(args)))
+	}
+	if len(filter.IDs) > 0 {
+		args = append(args, filter.IDs)
+		where = append(where, fmt.Sprintf("id = ANY($%d)", len(args)))
+	}
+	if filter.Location != "" {
+		args = append(args, filter.Location)
+		where = append(where, fmt.Sprintf("location = $%d", len(args)))
+	}
+	if filter.Criticality != "" {
+		args = append(args, filter.Criticality)
+		where = append(where, fmt.Sprintf("criticality = $%d", len(args)))
+	}
+	if filter.Class != "" {
+		args = append(args, filter.Class)
+		where = append(where, fmt.Sprintf("class = $%d", len(args)))
+	}
+	keys := make([]string, 0, len(filter.Attributes))
+	for k := range filter.Attributes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, k, filter.Attributes[k])
+		where = append(where, fmt.Sprintf("attributes->>$%d = $%d", len(args)-1, len(args)))
+	}
+
+	query := `SELECT ` + assetColumns + `
+          FROM assets` + whereClause(where) + ` ORDER BY id;`
+
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("stream assets: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		a, err := scanAsset(rows)
+		if err != nil {
+			return fmt.Errorf("scan asset: %w", err)
+		}
+		if err := fn(&a); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type AssetClassRepo struct {
+	db *DB
+}
+
+func NewAssetClassRepo(db *DB) *AssetClassRepo {
+	return &AssetClassRepo{db: db}
+}
+
+const assetClassColumns = `id, code, name, attributes, created_at, updated_at`
+
+func scanAssetClass(row pgx.Row) (domain.AssetClass, error) {
+	var c domain.AssetClass
+	err := row.Scan(&c.ID, &c.Code, &c.Name, &c.Attributes, &c.CreatedAt, &c.UpdatedAt)
+	return c, err
+}
+
+func (r *AssetClassRepo) Create(ctx context.Context, c *domain.AssetClass) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO asset_classes (code, name, attributes, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		RETURNING id, created_at, updated_at;
+	`
+	err := r.db.Pool.QueryRow(ctx, query, c.Code, c.Name, c.Attributes).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert asset class: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *AssetClassRepo) Update(ctx context.Context, c *domain.AssetClass) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := r.db.Pool.QueryRow(ctx, `
+		UPDATE asset_classes SET name=$2, attributes=$3, updated_at=NOW()
+		WHERE code=$1
+		RETURNING id, created_at, updated_at;`,
+		c.Code, c.Name, c.Attributes,
+	).Scan(&c.ID, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("update asset class: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *AssetClassRepo) FindAll(ctx context.Context) ([]domain.AssetClass, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+assetClassColumns+` FROM asset_classes ORDER BY code;`)
+	if err != nil {
+		return nil, fmt.Errorf("query asset classes: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.AssetClass, error) { return scanAssetClass(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan asset class: %w", err)
+	}
+	return list, nil
+}
+
+func (r *AssetClassRepo) FindByCode(ctx context.Context, code string) (*domain.AssetClass, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := scanAssetClass(r.db.Pool.QueryRow(ctx, `SELECT `+assetClassColumns+` FROM asset_classes WHERE code=$1;`, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find asset class: %w", err)
+	}
+	return &c, nil
+}
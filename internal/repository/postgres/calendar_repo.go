@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type CalendarRepo struct {
+	db *DB
+}
+
+func NewCalendarRepo(db *DB) *CalendarRepo {
+	return &CalendarRepo{db: db}
+}
+
+func (r *CalendarRepo) Settings(ctx context.Context) (*domain.CalendarSettings, error) {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var s domain.CalendarSettings
+	err = r.db.Pool.QueryRow(ctx, `SELECT working_days FROM plant_calendar WHERE site_id=$1;`, site).Scan(&s.WorkingDays)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			def := domain.DefaultCalendarSettings()
+			return &def, nil
+		}
+		return nil, fmt.Errorf("find plant calendar: %w", err)
+	}
+	return &s, nil
+}
+
+func (r *CalendarRepo) SaveSettings(ctx context.Context, s *domain.CalendarSettings) error {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	_, err = r.db.Pool.Exec(ctx, `
+		INSERT INTO plant_calendar (site_id, working_days, updated_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (site_id) DO UPDATE SET working_days = EXCLUDED.working_days, updated_at = NOW();`,
+		site, s.WorkingDays)
+	if err != nil {
+		return fmt.Errorf("save plant calendar: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *CalendarRepo) UpsertHolidays(ctx context.Context, holidays []domain.Holiday) error {
+	for i := range holidays {
+		if err := tenant.Assign(ctx, &holidays[i].SiteID); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	return pgx.BeginFunc(ctx, r.db.Pool, func(tx pgx.Tx) error {
+		for i := range holidays {
+			h := &holidays[i]
+			err := tx.QueryRow(ctx, `
+				INSERT INTO holidays (site_id, date, name, created_at) VALUES ($1, $2::date, $3, NOW())
+				ON CONFLICT (site_id, date) DO UPDATE SET name = EXCLUDED.name
+				RETURNING id;`, h.SiteID, h.Date, h.Name).Scan(&h.ID)
+			if err != nil {
+				return fmt.Errorf("upsert holiday: %w", mapError(err))
+			}
+		}
+		return nil
+	})
+}
+
+func (r *CalendarRepo) FindHolidays(ctx context.Context) ([]domain.Holiday, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT id, site_id, to_char(date,'YYYY-MM-DD'), name FROM holidays
+		WHERE ($1::bigint = 0 OR site_id = $1) ORDER BY date, site_id;`, site)
+	if err != nil {
+		return nil, fmt.Errorf("query holidays: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Holiday, error) {
+		var h domain.Holiday
+		err := row.Scan(&h.ID, &h.SiteID, &h.Date, &h.Name)
+		return h, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan holiday: %w", err)
+	}
+	return list, nil
+}
+
+func (r *CalendarRepo) DeleteHoliday(ctx context.Context, id int64) error {
+	return r.delete(ctx, `DELETE FROM holidays WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id)
+}
+
+func (r *CalendarRepo) CreateShutdown(ctx context.Context, s *domain.Shutdown) error {
+	if err := tenant.Assign(ctx, &s.SiteID); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := r.db.Pool.QueryRow(ctx, `
+		INSERT INTO shutdowns (site_id, starts_at, ends_at, reason, created_at) VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, created_at;`, s.SiteID, s.StartsAt, s.EndsAt, s.Reason).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert shutdown: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *CalendarRepo) FindShutdowns(ctx context.Context) ([]domain.Shutdown, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT id, site_id, starts_at, ends_at, reason, created_at FROM shutdowns
+		WHERE ($1::bigint = 0 OR site_id = $1) ORDER BY starts_at;`, site)
+	if err != nil {
+		return nil, fmt.Errorf("query shutdowns: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Shutdown, error) {
+		var s domain.Shutdown
+		err := row.Scan(&s.ID, &s.SiteID, &s.StartsAt, &s.EndsAt, &s.Reason, &s.CreatedAt)
+		return s, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan shutdown: %w", err)
+	}
+	return list, nil
+}
+
+func (r *CalendarRepo) DeleteShutdown(ctx context.Context, id int64) error {
+	return r.delete(ctx, `DELETE FROM shutdowns WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id)
+}
+
+func (r *CalendarRepo) delete(ctx context.Context, query string, id int64) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tag, err := r.db.Pool.Exec(ctx, query, id, site)
+	if err != nil {
+		return fmt.Errorf("delete calendar entry: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// ChecklistRepo guarda os checklists no escopo da OS (orderInSite).
+type ChecklistRepo struct {
+	db *DB
+}
+
+func NewChecklistRepo(db *DB) *ChecklistRepo {
+	return &ChecklistRepo{db: db}
+}
+
+func (r *ChecklistRepo) Create(ctx context.Context, workOrderID int64, items []domain.ChecklistItem) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var visible bool
+	err = r.db.conn(ctx).QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM work_orders WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2));`,
+		workOrderID, site).Scan(&visible)
+	if err != nil {
+		return fmt.Errorf("check checklist work order: %w", err)
+	}
+	if !visible {
+		return domain.ErrNotFound
+	}
+	batch := &pgx.Batch{}
+	for _, it := range items {
+		batch.Queue(`
+			INSERT INTO work_order_checklist (work_order_id, step, description, measurement)
+			VALUES ($1, $2, $3, $4);`, workOrderID, it.Step, it.Description, it.Measurement)
+	}
+	if err := r.db.conn(ctx).SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("insert checklist: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *ChecklistRepo) FindByWorkOrder(ctx context.Context, workOrderID int64) ([]domain.ChecklistItem, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.conn(ctx).Query(ctx, `
+			SELECT step, description, measurement, done, value, COALESCE(note,''),
+					out_of_tolerance, follow_up_id, completed_at, updated_at, revision
+			FROM work_order_checklist
+			WHERE work_order_id=$1 AND `+orderInSite("work_order_id", 2)+`
+			ORDER BY step;`, workOrderID, site)
+	if err != nil {
+		return nil, fmt.Errorf("query checklist: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.ChecklistItem, error) {
+		var it domain.ChecklistItem
+		err := row.Scan(&it.Step, &it.Description, &it.Measurement, &it.Done, &it.Value, &it.Note,
+			&it.OutOfTolerance, &it.FollowUpID, &it.CompletedAt, &it.UpdatedAt, &it.Revision)
+		return it, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan checklist item: %w", err)
+	}
+	return list, nil
+}
+
+func (r *ChecklistRepo) UpdateItem(ctx context.Context, workOrderID int64, it *domain.ChecklistItem) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	// A condição em follow_up_id é reavaliada depois do lock da linha: de dois
+	// apontamentos concorrentes que abrem corretiva, o segundo não grava.
+	q := r.db.conn(ctx)
+	err = q.QueryRow(ctx, `
+		UPDATE work_order_checklist
+		SET done=$3, value=$4, note=NULLIF($5,''), out_of_tolerance=$6, follow_up_id=$7, completed_at=$8,
+			updated_at=NOW()
+		WHERE work_order_id=$1 AND step=$2 AND (follow_up_id IS NULL OR follow_up_id = $7)
+		  AND `+orderInSite("work_order_id", 9)+`
+		RETURNING updated_at, revision;`,
+		workOrderID, it.Step, it.Done, it.Value, it.Note, it.OutOfTolerance, it.FollowUpID, it.CompletedAt, site).
+		Scan(&it.UpdatedAt, &it.Revision)
+	if err == pgx.ErrNoRows {
+		var exists bool
+		err = q.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM work_order_checklist
+			WHERE work_order_id=$1 AND step=$2 AND `+orderInSite("work_order_id", 3)+`);`,
+			workOrderID, it.Step, site).Scan(&exists)
+		if err == nil && !exists {
+			return domain.ErrNotFound
+		}
+		if err == nil {
+			return domain.ErrConflict
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("update checklist item: %w", mapError(err))
+	}
+	return nil
+}
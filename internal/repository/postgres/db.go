@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -30,6 +33,7 @@ func New(ctx context.Context) (*DB, error) {
 	cfg.MaxConns = 10
 	cfg.MinConns = 0
 	cfg.MaxConnLifetime = time.Hour
+	cfg.ConnConfig.Tracer = newQueryTracer()
 
 	pool, err := pgxpool.NewWithConfig(ctx, cfg)
 	if err != nil {
@@ -59,3 +63,74 @@ func getenv(k, def string) string {
 	}
 	return def
 }
+
+// Ping verifica a conexão respeitando o deadline do contexto recebido.
+func (db *DB) Ping(ctx context.Context) error {
+	if err := db.Pool.Ping(ctx); err != nil {
+		return fmt.Errorf("db ping: %w", err)
+	}
+	return nil
+}
+
+// SchemaVersion retorna a última migração aplicada pelo goose.
+func (db *DB) SchemaVersion(ctx context.Context) (int64, error) {
+	var v int64
+	err := db.Pool.QueryRow(ctx,
+		`SELECT COALESCE(MAX(version_id), 0) FROM goose_db_version WHERE is_applied;`).Scan(&v)
+	if err != nil {
+		return 0, fmt.Errorf("schema version: %w", err)
+	}
+	return v, nil
+}
+
+type txKey struct{}
+
+// querier é atendido pelo pool e por pgx.Tx.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+	CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, src pgx.CopyFromSource) (int64, error)
+}
+
+// conn devolve a transação aberta por InTx no contexto ou, fora dela, o pool.
+func (db *DB) conn(ctx context.Context) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db.Pool
+}
+
+// InTx executa fn numa transação (READ COMMITTED) levada no contexto; os
+// repositórios que consultam via conn gravam nela. Dentro de outra InTx, só executa fn.
+func (db *DB) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+	return pgx.BeginFunc(ctx, db.Pool, func(tx pgx.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// streamTimeout é o limite das consultas que alimentam exportações longas.
+const streamTimeout = 5 * time.Minute
+
+// whereClause junta condições com AND (vazio quando não há filtros).
+func whereClause(conds []string) string {
+	if len(conds) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conds, " AND ")
+}
+
+// assetInSite restringe as tabelas sem site próprio, ligadas ao ativo pela
+// coluna col, ao site do parâmetro $p (0 = todos os sites).
+func assetInSite(col string, p int) string {
+	return fmt.Sprintf("($%[2]d::bigint = 0 OR %[1]s IN (SELECT id FROM assets WHERE site_id = $%[2]d))", col, p)
+}
+
+// orderInSite é o assetInSite dos registros ligados a uma OS.
+func orderInSite(col string, p int) string {
+	return fmt.Sprintf("($%[2]d::bigint = 0 OR %[1]s IN (SELECT id FROM work_orders WHERE site_id = $%[2]d))", col, p)
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// DowntimeRepo grava as paradas; elas não têm site próprio e ficam no escopo
+// do ativo (assetInSite).
+type DowntimeRepo struct {
+	db *DB
+}
+
+func NewDowntimeRepo(db *DB) *DowntimeRepo {
+	return &DowntimeRepo{db: db}
+}
+
+const downtimeColumns = `id, asset_id, work_order_id, started_at, ended_at, reason_code, planned,
+					COALESCE(notes,'') AS notes, created_at, updated_at`
+
+func scanDowntime(row pgx.Row) (domain.DowntimeEvent, error) {
+	var e domain.DowntimeEvent
+	err := row.Scan(&e.ID, &e.AssetID, &e.WorkOrderID, &e.StartedAt, &e.EndedAt,
+		&e.ReasonCode, &e.Planned, &e.Notes, &e.CreatedAt, &e.UpdatedAt)
+	return e, err
+}
+
+func (r *DowntimeRepo) Create(ctx context.Context, e *domain.DowntimeEvent) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO downtime_events (asset_id, work_order_id, started_at, ended_at, reason_code, planned, notes, created_at, updated_at)
+		SELECT $1, $2, $3, $4, $5, $6, NULLIF($7,''), NOW(), NOW()
+		WHERE ` + assetInSite("$1::bigint", 8) + `
+		RETURNING id, created_at, updated_at;
+	`
+	err = r.db.Pool.QueryRow(ctx, query,
+		e.AssetID, e.WorkOrderID, e.StartedAt, e.EndedAt, e.ReasonCode, e.Planned, e.Notes, site,
+	).Scan(&e.ID, &e.CreatedAt, &e.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("insert downtime event: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *DowntimeRepo) Update(ctx context.Context, e *domain.DowntimeEvent) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE downtime_events
+		SET work_order_id=$2, started_at=$3, ended_at=$4, reason_code=$5, planned=$6, notes=NULLIF($7,''), updated_at=NOW()
+		WHERE id=$1 AND ` + assetInSite("asset_id", 8) + `
+		RETURNING updated_at;
+	`
+	err = r.db.Pool.QueryRow(ctx, query,
+		e.ID, e.WorkOrderID, e.StartedAt, e.EndedAt, e.ReasonCode, e.Planned, e.Notes, site,
+	).Scan(&e.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("update downtime event: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *DowntimeRepo) FindOpenByAsset(ctx context.Context, assetID int64) (*domain.DowntimeEvent, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `SELECT ` + downtimeColumns + `
+			FROM downtime_events
+			WHERE asset_id=$1 AND ended_at IS NULL AND ` + assetInSite("asset_id", 2) + `;`
+
+	e, err := scanDowntime(r.db.Pool.QueryRow(ctx, query, assetID, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find open downtime: %w", err)
+	}
+	return &e, nil
+}
+
+func (r *DowntimeRepo) FindOverlapping(ctx context.Context, assetID int64, start time.Time, end *time.Time, excludeID int64) ([]domain.DowntimeEvent, error) {
+	query := `SELECT ` + downtimeColumns + `
+			FROM downtime_events
+			WHERE asset_id=$1 AND id<>$4 AND ` + assetInSite("asset_id", 5) + `
+			  AND tstzrange(started_at, COALESCE(ended_at, 'infinity'::timestamptz))
+			      && tstzrange($2, COALESCE($3::timestamptz, 'infinity'::timestamptz))
+			ORDER BY started_at;`
+	return r.query(ctx, query, assetID, start, end, excludeID)
+}
+
+func (r *DowntimeRepo) FindByAsset(ctx context.Context, assetID int64, from, to *time.Time) ([]domain.DowntimeEvent, error) {
+	query := `SELECT ` + downtimeColumns + `
+			FROM downtime_events
+			WHERE asset_id=$1 AND ` + assetInSite("asset_id", 4) + `
+			  AND ($2::timestamptz IS NULL OR started_at >= $2)
+			  AND ($3::timestamptz IS NULL OR started_at < $3)
+			ORDER BY started_at;`
+	return r.query(ctx, query, assetID, from, to)
+}
+
+func (r *DowntimeRepo) FindByAssets(ctx context.Context, assetIDs []int64, from, to *time.Time) ([]domain.DowntimeEvent, error) {
+	query := `SELECT ` + downtimeColumns + `
+			FROM downtime_events
+			WHERE asset_id = ANY($1) AND ` + assetInSite("asset_id", 4) + `
+			  AND ($2::timestamptz IS NULL OR started_at >= $2)
+			  AND ($3::timestamptz IS NULL OR started_at < $3)
+			ORDER BY started_at;`
+	return r.query(ctx, query, assetIDs, from, to)
+}
+
+func (r *DowntimeRepo) FindByWorkOrder(ctx context.Context, workOrderID int64) ([]domain.DowntimeEvent, error) {
+	query := `SELECT ` + downtimeColumns + `
+			FROM downtime_events
+			WHERE work_order_id=$1 AND ` + assetInSite("asset_id", 2) + `
+			ORDER BY started_at;`
+	return r.query(ctx, query, workOrderID)
+}
+
+// query completa os argumentos com o site da chamada, sempre o último parâmetro.
+func (r *DowntimeRepo) query(ctx context.Context, query string, args ...any) ([]domain.DowntimeEvent, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, query, append(args, site)...)
+	if err != nil {
+		return nil, fmt.Errorf("query downtime_events: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.DowntimeEvent
+	for rows.Next() {
+		e, err := scanDowntime(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan downtime_event: %w", err)
+		}
+		list = append(list, e)
+	}
+	return list, rows.Err()
+}
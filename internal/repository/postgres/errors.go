@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// Códigos SQLSTATE tratados como erros de domínio.
+const (
+	pgNotNullViolation    = "23502"
+	pgUniqueViolation     = "23505"
+	pgExclusionViolation  = "23P01"
+	pgForeignKeyViolation = "23503"
+	pgCheckViolation      = "23514"
+)
+
+// mapError traduz violações de constraint em erros de domínio; demais erros passam intactos.
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch pgErr.Code {
+	case pgUniqueViolation:
+		return domain.ErrAlreadyExists
+	case pgExclusionViolation:
+		return domain.ErrConflict
+	case pgNotNullViolation, pgForeignKeyViolation, pgCheckViolation:
+		return domain.ErrInvalidInput
+	}
+	return err
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type EscalationRepo struct {
+	db *DB
+}
+
+func NewEscalationRepo(db *DB) *EscalationRepo {
+	return &EscalationRepo{db: db}
+}
+
+const escalationColumns = `site_id, event, tier, delay_minutes, user_ids`
+
+func scanEscalationRule(row pgx.Row) (domain.EscalationRule, error) {
+	var rule domain.EscalationRule
+	err := row.Scan(&rule.SiteID, &rule.Event, &rule.Tier, &rule.DelayMinutes, &rule.UserIDs)
+	return rule, err
+}
+
+func (r *EscalationRepo) FindAll(ctx context.Context) ([]domain.EscalationRule, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	return r.query(ctx, `SELECT `+escalationColumns+` FROM escalation_rules
+		WHERE ($1::bigint = 0 OR site_id = $1) ORDER BY site_id, event, tier;`, site)
+}
+
+func (r *EscalationRepo) FindByEvent(ctx context.Context, event domain.NotificationEvent) ([]domain.EscalationRule, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	return r.query(ctx, `SELECT `+escalationColumns+` FROM escalation_rules
+		WHERE event=$1 AND ($2::bigint = 0 OR site_id = $2) ORDER BY site_id, tier;`, event, site)
+}
+
+func (r *EscalationRepo) query(ctx context.Context, query string, args ...any) ([]domain.EscalationRule, error) {
+	rows, err := r.db.Pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query escalation_rules: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.EscalationRule, error) { return scanEscalationRule(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan escalation_rule: %w", err)
+	}
+	return list, nil
+}
+
+func (r *EscalationRepo) ReplaceAll(ctx context.Context, rules []domain.EscalationRule) error {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range rules {
+		if err := tenant.Assign(ctx, &rules[i].SiteID); err != nil {
+			return err
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return pgx.BeginFunc(ctx, r.db.Pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM escalation_rules WHERE site_id=$1;`, site); err != nil {
+			return fmt.Errorf("clear escalation_rules: %w", err)
+		}
+		for _, rule := range rules {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO escalation_rules (site_id, event, tier, delay_minutes, user_ids, updated_at)
+				VALUES ($1, $2, $3, $4, $5, NOW());`,
+				rule.SiteID, rule.Event, rule.Tier, rule.DelayMinutes, rule.UserIDs)
+			if err != nil {
+				return fmt.Errorf("insert escalation_rule: %w", mapError(err))
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type FailureCodeRepo struct {
+	db *DB
+}
+
+func NewFailureCodeRepo(db *DB) *FailureCodeRepo {
+	return &FailureCodeRepo{db: db}
+}
+
+const failureCodeColumns = `id, code, name, level, parent_id, active, created_at, updated_at`
+
+func scanFailureCode(row pgx.Row) (domain.FailureCode, error) {
+	var fc domain.FailureCode
+	err := row.Scan(&fc.ID, &fc.Code, &fc.Name, &fc.Level, &fc.ParentID, &fc.Active, &fc.CreatedAt, &fc.UpdatedAt)
+	return fc, err
+}
+
+func (r *FailureCodeRepo) Create(ctx context.Context, fc *domain.FailureCode) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO failure_codes (code, name, level, parent_id, active, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, created_at, updated_at;
+	`
+	err := r.db.Pool.QueryRow(ctx, query, fc.Code, fc.Name, fc.Level, fc.ParentID, fc.Active).
+		Scan(&fc.ID, &fc.CreatedAt, &fc.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert failure code: %w", mapError(err))
+	}
+	return nil
+}
+
+// Update altera nome e situação; código, nível e pai são fixos (o histórico das OS depende deles).
+func (r *FailureCodeRepo) Update(ctx context.Context, fc *domain.FailureCode) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := r.db.Pool.QueryRow(ctx,
+		`UPDATE failure_codes SET name=$2, active=$3, updated_at=NOW() WHERE id=$1 RETURNING updated_at;`,
+		fc.ID, fc.Name, fc.Active,
+	).Scan(&fc.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("update failure code: %w", err)
+	}
+	return nil
+}
+
+func (r *FailureCodeRepo) FindAll(ctx context.Context) ([]domain.FailureCode, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+failureCodeColumns+` FROM failure_codes ORDER BY code;`)
+	if err != nil {
+		return nil, fmt.Errorf("query failure codes: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.FailureCode, error) { return scanFailureCode(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan failure code: %w", err)
+	}
+	return list, nil
+}
+
+func (r *FailureCodeRepo) FindByID(ctx context.Context, id int64) (*domain.FailureCode, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	fc, err := scanFailureCode(r.db.Pool.QueryRow(ctx, `SELECT `+failureCodeColumns+` FROM failure_codes WHERE id=$1;`, id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find failure code: %w", err)
+	}
+	return &fc, nil
+}
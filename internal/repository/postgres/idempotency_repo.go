@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type IdempotencyRepo struct {
+	db *DB
+}
+
+func NewIdempotencyRepo(db *DB) *IdempotencyRepo {
+	return &IdempotencyRepo{db: db}
+}
+
+// Reserve insere a chave ou assume uma expirada (reserva abandonada ou retenção
+// vencida) num único comando, então dois pedidos simultâneos nunca reservam juntos.
+func (r *IdempotencyRepo) Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := r.db.Pool.QueryRow(ctx, `
+		INSERT INTO idempotency_keys (user_id, key, request_hash, created_at, expires_at)
+		VALUES ($1, $2, $3, NOW(), $4)
+		ON CONFLICT (user_id, key) DO UPDATE
+		   SET request_hash = EXCLUDED.request_hash, status = NULL, content_type = NULL, body = NULL,
+		       created_at = NOW(), expires_at = EXCLUDED.expires_at
+		 WHERE idempotency_keys.expires_at <= NOW()
+		RETURNING created_at;`,
+		rec.UserID, rec.Key, rec.RequestHash, rec.ExpiresAt).Scan(&rec.CreatedAt)
+	if err == nil {
+		return nil, true, nil
+	}
+	if err != pgx.ErrNoRows {
+		return nil, false, fmt.Errorf("reserve idempotency key: %w", mapError(err))
+	}
+
+	var cur domain.IdempotencyRecord
+	var status *int
+	var contentType *string
+	err = r.db.Pool.QueryRow(ctx, `
+		SELECT user_id, key, request_hash, status, content_type, body, created_at, expires_at
+		  FROM idempotency_keys WHERE user_id=$1 AND key=$2;`, rec.UserID, rec.Key).
+		Scan(&cur.UserID, &cur.Key, &cur.RequestHash, &status, &contentType, &cur.Body, &cur.CreatedAt, &cur.ExpiresAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			// Apagada entre os dois comandos (expurgo): o cliente pode tentar de novo.
+			return nil, false, domain.ErrConflict
+		}
+		return nil, false, fmt.Errorf("find idempotency key: %w", err)
+	}
+	if status != nil {
+		cur.Status = *status
+	}
+	if contentType != nil {
+		cur.ContentType = *contentType
+	}
+	return &cur, false, nil
+}
+
+func (r *IdempotencyRepo) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tag, err := r.db.Pool.Exec(ctx, `
+		UPDATE idempotency_keys SET status=$4, content_type=$5, body=$6, expires_at=$7
+		 WHERE user_id=$1 AND key=$2 AND request_hash=$3 AND status IS NULL;`,
+		rec.UserID, rec.Key, rec.RequestHash, rec.Status, rec.ContentType, rec.Body, rec.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *IdempotencyRepo) Release(ctx context.Context, userID int64, key string) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if _, err := r.db.Pool.Exec(ctx,
+		`DELETE FROM idempotency_keys WHERE user_id=$1 AND key=$2 AND status IS NULL;`, userID, key); err != nil {
+		return fmt.Errorf("release idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepo) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE expires_at <= $1;`, now)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
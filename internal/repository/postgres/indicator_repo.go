@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type IndicatorRepo struct {
+	db *DB
+}
+
+func NewIndicatorRepo(db *DB) *IndicatorRepo {
+	return &IndicatorRepo{db: db}
+}
+
+func (r *IndicatorRepo) Indicators(ctx context.Context, now time.Time) (*domain.Indicators, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	ind := &domain.Indicators{}
+
+	rows, err := r.db.Pool.Query(ctx, `
+			SELECT wo.status, wo.type, COALESCE(a.criticality,'') AS criticality, COUNT(*)
+			FROM work_orders wo
+			JOIN assets a ON a.id = wo.asset_id
+			WHERE wo.status IN ('open','in_progress')
+			  AND ($1::bigint = 0 OR wo.site_id = $1)
+			GROUP BY wo.status, wo.type, a.criticality
+			ORDER BY wo.status, wo.type, a.criticality;
+			`, site)
+	if err != nil {
+		return nil, fmt.Errorf("query open work order counts: %w", err)
+	}
+	for rows.Next() {
+		var c domain.WorkOrderCount
+		if err := rows.Scan(&c.Status, &c.Type, &c.Criticality, &c.Count); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan work order count: %w", err)
+		}
+		ind.OpenWorkOrders = append(ind.OpenWorkOrders, c)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("query open work order counts: %w", err)
+	}
+
+	err = r.db.Pool.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM maintenance_plans
+			WHERE active
+			  AND rule_type = 'time'
+			  AND frequency_days IS NOT NULL
+			  AND COALESCE(last_execution, created_at) + frequency_days * INTERVAL '1 day' < $1
+			  AND ($2::bigint = 0 OR site_id = $2);
+			`, now, site).Scan(&ind.OverduePlans)
+	if err != nil {
+		return nil, fmt.Errorf("count overdue plans: %w", err)
+	}
+
+	err = r.db.Pool.QueryRow(ctx, `
+			SELECT COUNT(DISTINCT asset_id) FROM (
+				SELECT de.asset_id
+				FROM downtime_events de
+				JOIN assets a ON a.id = de.asset_id
+				WHERE de.ended_at IS NULL
+				  AND ($1::bigint = 0 OR a.site_id = $1)
+				UNION
+				-- OS sem paradas apontadas: a quebra em aberto indica ativo parado.
+				SELECT wo.asset_id
+				FROM work_orders wo
+				WHERE wo.type = 'corrective'
+				  AND wo.status IN ('open','in_progress')
+				  AND wo.breakdown_at IS NOT NULL
+				  AND NOT EXISTS (SELECT 1 FROM downtime_events de WHERE de.work_order_id = wo.id)
+				  AND ($1::bigint = 0 OR wo.site_id = $1)
+			) down;
+			`, site).Scan(&ind.AssetsDown)
+	if err != nil {
+		return nil, fmt.Errorf("count assets down: %w", err)
+	}
+
+	err = r.db.Pool.QueryRow(ctx, `
+			SELECT COUNT(*)
+			FROM work_orders
+			WHERE status IN ('open','in_progress')
+			  AND (resolution_due_at < $1 OR (responded_at IS NULL AND response_due_at < $1))
+			  AND ($2::bigint = 0 OR site_id = $2);
+			`, now, site).Scan(&ind.OverdueOrders)
+	if err != nil {
+		return nil, fmt.Errorf("count overdue work orders: %w", err)
+	}
+
+	return ind, nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type JobPlanRepo struct {
+	db *DB
+}
+
+func NewJobPlanRepo(db *DB) *JobPlanRepo {
+	return &JobPlanRepo{db: db}
+}
+
+const jobPlanColumns = `id, site_id, name, COALESCE(description,''), trade, estimated_minutes, steps, parts, created_at, updated_at`
+
+func scanJobPlan(row pgx.Row) (domain.JobPlan, error) {
+	var j domain.JobPlan
+	err := row.Scan(&j.ID, &j.SiteID, &j.Name, &j.Description, &j.Trade, &j.EstimatedMinutes, &j.Steps, &j.Parts, &j.CreatedAt, &j.UpdatedAt)
+	return j, err
+}
+
+func (r *JobPlanRepo) Create(ctx context.Context, j *domain.JobPlan) error {
+	if err := tenant.Assign(ctx, &j.SiteID); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO job_plans (site_id, name, description, trade, estimated_minutes, steps, parts, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3,''), $4, $5, $6, $7, NOW(), NOW())
+		RETURNING id, created_at, updated_at;
+	`
+	err := r.db.Pool.QueryRow(ctx, query, j.SiteID, j.Name, j.Description, j.Trade, j.EstimatedMinutes, j.Steps, j.Parts).
+		Scan(&j.ID, &j.CreatedAt, &j.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert job plan: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *JobPlanRepo) Update(ctx context.Context, j *domain.JobPlan) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err = r.db.Pool.QueryRow(ctx, `
+		UPDATE job_plans
+		SET name=$2, description=NULLIF($3,''), trade=$4, estimated_minutes=$5, steps=$6, parts=$7, updated_at=NOW()
+		WHERE id=$1 AND ($8::bigint = 0 OR site_id = $8)
+		RETURNING site_id, created_at, updated_at;`,
+		j.ID, j.Name, j.Description, j.Trade, j.EstimatedMinutes, j.Steps, j.Parts, site,
+	).Scan(&j.SiteID, &j.CreatedAt, &j.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("update job plan: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *JobPlanRepo) FindAll(ctx context.Context) ([]domain.JobPlan, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+jobPlanColumns+` FROM job_plans
+		WHERE ($1::bigint = 0 OR site_id = $1) ORDER BY id;`, site)
+	if err != nil {
+		return nil, fmt.Errorf("query job plans: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.JobPlan, error) { return scanJobPlan(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan job plan: %w", err)
+	}
+	return list, nil
+}
+
+func (r *JobPlanRepo) FindByID(ctx context.Context, id int64) (*domain.JobPlan, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	j, err := scanJobPlan(r.db.Pool.QueryRow(ctx, `SELECT `+jobPlanColumns+` FROM job_plans
+		WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find job plan: %w", err)
+	}
+	return &j, nil
+}
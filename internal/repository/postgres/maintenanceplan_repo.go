@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type MaintenancePlanRepo struct {
+	db *DB
+}
+
+func NewMaintenancePlanRepo(db *DB) *MaintenancePlanRepo {
+	return &MaintenancePlanRepo{db: db}
+}
+
+// Create grava o plano no site do ativo; ativo fora do escopo é ErrInvalidInput.
+func (r *MaintenancePlanRepo) Create(ctx context.Context, plan *domain.MaintenancePlan) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO maintenance_plans (site_id, asset_id, rule_type, frequency_days, meter_target, last_execution,
+			job_plan_id, trade, estimated_minutes, active, condition_parameter, condition_min, condition_max,
+			created_at, updated_at)
+		VALUES ((SELECT site_id FROM assets WHERE id = $1 AND ($10::bigint = 0 OR site_id = $10)),
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($11,''), $12, $13, NOW(), NOW())
+		RETURNING id, site_id, created_at, updated_at;
+	`
+
+	err = r.db.Pool.QueryRow(ctx, query,
+		plan.AssetID,
+		plan.RuleType,
+		plan.FrequencyDays,
+		plan.MeterTarget,
+		plan.LastExecution,
+		plan.JobPlanID,
+		plan.Trade,
+		plan.EstimatedMinutes,
+		plan.Active,
+		site,
+		plan.ConditionParameter,
+		plan.ConditionMin,
+		plan.ConditionMax,
+	).Scan(&plan.ID, &plan.SiteID, &plan.CreatedAt, &plan.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert maintenance plan: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *MaintenancePlanRepo) FindAll(ctx context.Context) ([]domain.MaintenancePlan, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+			SELECT id, site_id, asset_id, rule_type, frequency_days, meter_target,
+					last_execution, job_plan_id, trade, estimated_minutes, active,
+					COALESCE(condition_parameter,''), condition_min, condition_max, created_at, updated_at
+			FROM maintenance_plans
+			WHERE ($1::bigint = 0 OR site_id = $1)
+			ORDER BY id;
+			`
+
+	rows, err := r.db.Pool.Query(ctx, query, site)
+	if err != nil {
+		return nil, fmt.Errorf("query maintenance_plans: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.MaintenancePlan
+	for rows.Next() {
+		var p domain.MaintenancePlan
+		if err := rows.Scan(
+			&p.ID, &p.SiteID, &p.AssetID, &p.RuleType, &p.FrequencyDays, &p.MeterTarget,
+			&p.LastExecution, &p.JobPlanID, &p.Trade, &p.EstimatedMinutes, &p.Active,
+			&p.ConditionParameter, &p.ConditionMin, &p.ConditionMax, &p.CreatedAt, &p.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan maintenance_plan: %w", err)
+		}
+		list = append(list, p)
+	}
+	return list, nil
+}
+
+func (r *MaintenancePlanRepo) FindByID(ctx context.Context, id int64) (*domain.MaintenancePlan, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var p domain.MaintenancePlan
+	err = r.db.Pool.QueryRow(ctx, `
+			SELECT id, site_id, asset_id, rule_type, frequency_days, meter_target,
+					last_execution, job_plan_id, trade, estimated_minutes, active,
+					COALESCE(condition_parameter,''), condition_min, condition_max, created_at, updated_at
+			FROM maintenance_plans
+			WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id, site).Scan(
+		&p.ID, &p.SiteID, &p.AssetID, &p.RuleType, &p.FrequencyDays, &p.MeterTarget,
+		&p.LastExecution, &p.JobPlanID, &p.Trade, &p.EstimatedMinutes, &p.Active,
+		&p.ConditionParameter, &p.ConditionMin, &p.ConditionMax, &p.CreatedAt, &p.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find maintenance plan: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *MaintenancePlanRepo) SetLastExecution(ctx context.Context, id int64, at time.Time) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tag, err := r.db.Pool.Exec(ctx,
+		`UPDATE maintenance_plans SET last_execution=$2, updated_at=NOW()
+		WHERE id=$1 AND ($3::bigint = 0 OR site_id = $3);`, id, at, site)
+	if err != nil {
+		return fmt.Errorf("update maintenance plan execution: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
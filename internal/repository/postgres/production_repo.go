@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// ProductionRepo grava os apontamentos de produção; como as paradas, eles não
+// têm site próprio e ficam no escopo do ativo.
+type ProductionRepo struct {
+	db *DB
+}
+
+func NewProductionRepo(db *DB) *ProductionRepo {
+	return &ProductionRepo{db: db}
+}
+
+// CreateBatch grava os apontamentos via COPY; não preenche os IDs. Ativo fora
+// do site da chamada recusa o lote inteiro com ErrInvalidInput.
+func (r *ProductionRepo) CreateBatch(ctx context.Context, counts []domain.ProductionCount) (int64, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	if site != 0 {
+		ids := make([]int64, 0, len(counts))
+		seen := map[int64]bool{}
+		for _, p := range counts {
+			if !seen[p.AssetID] {
+				seen[p.AssetID] = true
+				ids = append(ids, p.AssetID)
+			}
+		}
+		var visible int
+		err := r.db.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM assets WHERE id = ANY($1) AND site_id = $2;`, ids, site).Scan(&visible)
+		if err != nil {
+			return 0, fmt.Errorf("check production assets: %w", err)
+		}
+		if visible != len(ids) {
+			return 0, domain.ErrInvalidInput
+		}
+	}
+
+	now := time.Now()
+	n, err := r.db.Pool.CopyFrom(ctx,
+		pgx.Identifier{"production_counts"},
+		[]string{"asset_id", "period_start", "period_end", "total_count", "good_count", "created_at"},
+		pgx.CopyFromSlice(len(counts), func(i int) ([]any, error) {
+			p := counts[i]
+			return []any{p.AssetID, p.PeriodStart, p.PeriodEnd, p.TotalCount, p.GoodCount, now}, nil
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("copy production counts: %w", mapError(err))
+	}
+	return n, nil
+}
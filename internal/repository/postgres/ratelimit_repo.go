@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type RateLimitRepo struct {
+	db *DB
+}
+
+func NewRateLimitRepo(db *DB) *RateLimitRepo {
+	return &RateLimitRepo{db: db}
+}
+
+// refilled é o saldo do balde agora, com a reposição desde updated_at. O relógio
+// é o do banco, o mesmo para todas as réplicas.
+const refilled = `LEAST($2::float8, b.tokens + GREATEST(EXTRACT(EPOCH FROM NOW() - b.updated_at)::float8, 0) * $3::float8)`
+
+// Take consome a ficha num único comando: o UPDATE só acontece com saldo, então
+// réplicas concorrentes não gastam a mesma ficha. Sem linha devolvida, a cota acabou.
+func (r *RateLimitRepo) Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var tokens float64
+	err := r.db.Pool.QueryRow(ctx, `
+		INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at)
+		VALUES ($1, $2::float8 - 1, NOW())
+		ON CONFLICT (key) DO UPDATE SET tokens = `+refilled+` - 1, updated_at = NOW()
+		 WHERE `+refilled+` >= 1
+		RETURNING tokens;`, key, limit.Requests, limit.Rate()).Scan(&tokens)
+	if err == nil {
+		return limit.Status(tokens, true), nil
+	}
+	if err != pgx.ErrNoRows {
+		return domain.RateLimitStatus{}, fmt.Errorf("take rate limit token: %w", err)
+	}
+
+	err = r.db.Pool.QueryRow(ctx, `SELECT `+refilled+` FROM rate_limit_buckets b WHERE key=$1;`,
+		key, limit.Requests, limit.Rate()).Scan(&tokens)
+	if err != nil && err != pgx.ErrNoRows {
+		return domain.RateLimitStatus{}, fmt.Errorf("read rate limit bucket: %w", err)
+	}
+	return limit.Status(tokens, false), nil
+}
+
+func (r *RateLimitRepo) DeleteIdle(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM rate_limit_buckets WHERE updated_at < $1;`, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete idle rate limit buckets: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
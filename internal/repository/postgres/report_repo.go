@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type ReportRepo struct {
+	db *DB
+}
+
+func NewReportRepo(db *DB) *ReportRepo {
+	return &ReportRepo{db: db}
+}
+
+func (r *ReportRepo) MonthlyDowntime(ctx context.Context, from, to time.Time, loc *time.Location) ([]domain.DowntimeReportRow, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Paradas apontadas + OS históricas (sem paradas vinculadas) com quebra ou minutos parados.
+	query := `
+			WITH stops AS (
+				SELECT de.asset_id, de.started_at AS at, NOT de.planned AS is_breakdown,
+						CASE WHEN de.planned THEN 0
+							ELSE FLOOR(EXTRACT(EPOCH FROM de.ended_at - de.started_at) / 60)::bigint END AS unplanned_min,
+						CASE WHEN de.planned
+							THEN FLOOR(EXTRACT(EPOCH FROM de.ended_at - de.started_at) / 60)::bigint ELSE 0 END AS planned_min
+				FROM downtime_events de
+				WHERE de.ended_at IS NOT NULL
+				UNION ALL
+				SELECT wo.asset_id, COALESCE(wo.breakdown_at, wo.created_at), TRUE, COALESCE(wo.downtime_minutes, 0), 0
+				FROM work_orders wo
+				WHERE wo.status <> 'canceled'
+				  AND (wo.breakdown_at IS NOT NULL OR wo.downtime_minutes IS NOT NULL)
+				  AND NOT EXISTS (SELECT 1 FROM downtime_events de WHERE de.work_order_id = wo.id)
+			)
+			SELECT to_char(s.at AT TIME ZONE $3, 'YYYY-MM') AS month,
+					a.id, a.name, COALESCE(a.location,''),
+					COUNT(*) FILTER (WHERE s.is_breakdown) AS breakdowns,
+					COALESCE(SUM(s.unplanned_min), 0) AS downtime_minutes,
+					COALESCE(SUM(s.planned_min), 0) AS planned_minutes
+			FROM stops s
+			JOIN assets a ON a.id = s.asset_id
+			WHERE s.at >= $1 AND s.at < $2
+			  AND ($4::bigint = 0 OR a.site_id = $4)
+			GROUP BY 1, a.id, a.name, a.location
+			ORDER BY 1, a.id;
+			`
+
+	rows, err := r.db.Pool.Query(ctx, query, from, to, loc.String(), site)
+	if err != nil {
+		return nil, fmt.Errorf("query monthly downtime: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.DowntimeReportRow
+	for rows.Next() {
+		var row domain.DowntimeReportRow
+		if err := rows.Scan(&row.Month, &row.AssetID, &row.AssetName, &row.Location, &row.Breakdowns, &row.DowntimeMinutes, &row.PlannedMinutes); err != nil {
+			return nil, fmt.Errorf("scan downtime row: %w", err)
+		}
+		list = append(list, row)
+	}
+	return list, rows.Err()
+}
+
+func (r *ReportRepo) OEEInputs(ctx context.Context, from, to time.Time, filter domain.OEEFilter) (*domain.OEEInputs, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Mesmo filtro de ativos (e de site) para as três consultas dependentes.
+	assetScope := `SELECT id FROM assets
+			WHERE ($1::bigint = 0 OR id = $1) AND ($2::text = '' OR COALESCE(location,'') = $2)
+			  AND ($3::bigint = 0 OR site_id = $3)`
+
+	in := &domain.OEEInputs{}
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+assetColumns+` FROM assets WHERE id IN (`+assetScope+`) ORDER BY id;`,
+		filter.AssetID, filter.Location, site)
+	if err != nil {
+		return nil, fmt.Errorf("query oee assets: %w", err)
+	}
+	in.Assets, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Asset, error) { return scanAsset(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan oee assets: %w", err)
+	}
+
+	rows, err = r.db.Pool.Query(ctx, `SELECT `+shiftColumns+` FROM shifts ORDER BY id;`)
+	if err != nil {
+		return nil, fmt.Errorf("query oee shifts: %w", err)
+	}
+	in.Shifts, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Shift, error) { return scanShift(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan oee shifts: %w", err)
+	}
+
+	rows, err = r.db.Pool.Query(ctx, `SELECT `+downtimeColumns+`
+			FROM downtime_events
+			WHERE asset_id IN (`+assetScope+`)
+			  AND started_at < $5 AND (ended_at IS NULL OR ended_at > $4)
+			ORDER BY started_at;`, filter.AssetID, filter.Location, site, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query oee downtime: %w", err)
+	}
+	in.Downtime, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.DowntimeEvent, error) { return scanDowntime(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan oee downtime: %w", err)
+	}
+
+	rows, err = r.db.Pool.Query(ctx, `
+			SELECT id, asset_id, period_start, period_end, total_count, good_count, created_at
+			FROM production_counts
+			WHERE asset_id IN (`+assetScope+`)
+			  AND period_start < $5 AND period_end > $4
+			ORDER BY period_start;`, filter.AssetID, filter.Location, site, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("query oee production: %w", err)
+	}
+	in.Counts, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.ProductionCount, error) {
+		var p domain.ProductionCount
+		err := row.Scan(&p.ID, &p.AssetID, &p.PeriodStart, &p.PeriodEnd, &p.TotalCount, &p.GoodCount, &p.CreatedAt)
+		return p, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan oee production: %w", err)
+	}
+	return in, nil
+}
+
+func (r *ReportRepo) Pareto(ctx context.Context, from, to time.Time, filter domain.ParetoFilter) (*domain.ParetoReport, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// OS sem closed_at (histórico antigo) usam a última atualização como data de fechamento.
+	query := `
+			SELECT wo.failure_mode_id, COALESCE(fc.code,''), COALESCE(fc.name,''),
+					COUNT(*), COALESCE(SUM(wo.downtime_minutes), 0)
+			FROM work_orders wo
+			JOIN assets a ON a.id = wo.asset_id
+			LEFT JOIN failure_codes fc ON fc.id = wo.failure_mode_id
+			WHERE wo.status = 'done'
+			  AND COALESCE(wo.closed_at, wo.updated_at) >= $1
+			  AND COALESCE(wo.closed_at, wo.updated_at) < $2
+			  AND ($3::bigint = 0 OR wo.asset_id = $3)
+			  AND ($4::text = '' OR COALESCE(a.location,'') = $4)
+			  AND ($5::bigint = 0 OR wo.site_id = $5)
+			GROUP BY 1, 2, 3;
+			`
+
+	rows, err := r.db.Pool.Query(ctx, query, from, to, filter.AssetID, filter.Location, site)
+	if err != nil {
+		return nil, fmt.Errorf("query pareto: %w", err)
+	}
+	defer rows.Close()
+
+	report := &domain.ParetoReport{}
+	for rows.Next() {
+		var (
+			modeID *int64
+			row    domain.ParetoRow
+		)
+		if err := rows.Scan(&modeID, &row.Code, &row.Name, &row.Count, &row.DowntimeMinutes); err != nil {
+			return nil, fmt.Errorf("scan pareto row: %w", err)
+		}
+		if modeID == nil {
+			report.Unclassified = row.Count
+			continue
+		}
+		row.FailureModeID = *modeID
+		report.Rows = append(report.Rows, row)
+	}
+	return report, rows.Err()
+}
+
+func (r *ReportRepo) MonthlySLA(ctx context.Context, from, to time.Time, loc *time.Location, now time.Time) ([]domain.SLAReportRow, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	// Mesmas regras de avaliação de domain.SLAReportRow: prazo decidido ou vencido.
+	query := `
+			SELECT to_char(created_at AT TIME ZONE $3, 'YYYY-MM') AS month,
+					COUNT(*),
+					COUNT(*) FILTER (WHERE responded_at IS NOT NULL OR response_due_at < $4),
+					COUNT(*) FILTER (WHERE responded_at <= response_due_at),
+					COUNT(*) FILTER (WHERE status = 'done' OR resolution_due_at < $4),
+					COUNT(*) FILTER (WHERE status = 'done' AND closed_at <= resolution_due_at)
+			FROM work_orders
+			WHERE resolution_due_at IS NOT NULL
+			  AND status <> 'canceled'
+			  AND created_at >= $1 AND created_at < $2
+			  AND ($5::bigint = 0 OR site_id = $5)
+			GROUP BY 1
+			ORDER BY 1;
+			`
+
+	rows, err := r.db.Pool.Query(ctx, query, from, to, loc.String(), now, site)
+	if err != nil {
+		return nil, fmt.Errorf("query monthly sla: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.SLAReportRow
+	for rows.Next() {
+		var row domain.SLAReportRow
+		if err := rows.Scan(&row.Month, &row.Orders, &row.ResponseEvaluated, &row.ResponseMet,
+			&row.ResolutionEvaluated, &row.ResolutionMet); err != nil {
+			return nil, fmt.Errorf("scan sla row: %w", err)
+		}
+		row.FillCompliance()
+		list = append(list, row)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type MaintenanceRequestRepo struct {
+	db *DB
+}
+
+func NewMaintenanceRequestRepo(db *DB) *MaintenanceRequestRepo {
+	return &MaintenanceRequestRepo{db: db}
+}
+
+const requestColumns = `id, site_id, asset_id, title, COALESCE(description,''), COALESCE(requested_by,''), breakdown_at, status,
+					COALESCE(triage_note,''), COALESCE(resolution,''), work_order_id, triaged_at, closed_at,
+					created_at, updated_at`
+
+func scanRequest(row pgx.Row) (domain.MaintenanceRequest, error) {
+	var r domain.MaintenanceRequest
+	err := row.Scan(&r.ID, &r.SiteID, &r.AssetID, &r.Title, &r.Description, &r.RequestedBy, &r.BreakdownAt, &r.Status,
+		&r.TriageNote, &r.Resolution, &r.WorkOrderID, &r.TriagedAt, &r.ClosedAt, &r.CreatedAt, &r.UpdatedAt)
+	return r, err
+}
+
+// Create grava a solicitação no site do ativo; ativo fora do escopo é ErrInvalidInput.
+func (r *MaintenanceRequestRepo) Create(ctx context.Context, req *domain.MaintenanceRequest) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO maintenance_requests (site_id, asset_id, title, description, requested_by, breakdown_at, status,
+			created_at, updated_at)
+		VALUES ((SELECT site_id FROM assets WHERE id = $1 AND ($7::bigint = 0 OR site_id = $7)),
+			$1, $2, NULLIF($3,''), NULLIF($4,''), $5, $6, NOW(), NOW())
+		RETURNING id, site_id, created_at, updated_at;
+	`
+	err = r.db.Pool.QueryRow(ctx, query,
+		req.AssetID, req.Title, req.Description, req.RequestedBy, req.BreakdownAt, req.Status, site,
+	).Scan(&req.ID, &req.SiteID, &req.CreatedAt, &req.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert maintenance request: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *MaintenanceRequestRepo) FindByID(ctx context.Context, id int64) (*domain.MaintenanceRequest, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	req, err := scanRequest(r.db.Pool.QueryRow(ctx, `SELECT `+requestColumns+` FROM maintenance_requests
+		WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find maintenance request: %w", err)
+	}
+	return &req, nil
+}
+
+func (r *MaintenanceRequestRepo) FindAll(ctx context.Context, filter domain.RequestFilter) ([]domain.MaintenanceRequest, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	args := []any{site}
+	where := []string{"($1::bigint = 0 OR site_id = $1)"}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where = append(where, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.AssetID != 0 {
+		args = append(args, filter.AssetID)
+		where = append(where, fmt.Sprintf("asset_id = $%d", len(args)))
+	}
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+requestColumns+` FROM maintenance_requests`+whereClause(where)+` ORDER BY id DESC;`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query maintenance requests: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.MaintenanceRequest, error) { return scanRequest(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan maintenance request: %w", err)
+	}
+	return list, nil
+}
+
+func (r *MaintenanceRequestRepo) Update(ctx context.Context, req *domain.MaintenanceRequest, from domain.RequestStatus) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE maintenance_requests
+		SET title=$3, description=NULLIF($4,''), status=$5, triage_note=NULLIF($6,''), resolution=NULLIF($7,''),
+		    work_order_id=$8, triaged_at=$9, closed_at=$10, updated_at=NOW()
+		WHERE id=$1 AND status=$2 AND ($11::bigint = 0 OR site_id = $11)
+		RETURNING site_id, created_at, updated_at;
+	`
+	err = r.db.Pool.QueryRow(ctx, query,
+		req.ID, from, req.Title, req.Description, req.Status, req.TriageNote, req.Resolution,
+		req.WorkOrderID, req.TriagedAt, req.ClosedAt, site,
+	).Scan(&req.SiteID, &req.CreatedAt, &req.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		// Distingue solicitação inexistente de status alterado por outra triagem.
+		if _, findErr := r.FindByID(ctx, req.ID); findErr != nil {
+			return findErr
+		}
+		return domain.ErrConflict
+	}
+	if err != nil {
+		return fmt.Errorf("update maintenance request: %w", mapError(err))
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type ShiftRepo struct {
+	db *DB
+}
+
+func NewShiftRepo(db *DB) *ShiftRepo {
+	return &ShiftRepo{db: db}
+}
+
+const shiftColumns = `id, site_id, name, COALESCE(location,''), to_char(start_time,'HH24:MI'), to_char(end_time,'HH24:MI'),
+					weekdays, break_minutes, created_at`
+
+func scanShift(row pgx.Row) (domain.Shift, error) {
+	var s domain.Shift
+	err := row.Scan(&s.ID, &s.SiteID, &s.Name, &s.Location, &s.StartTime, &s.EndTime, &s.Weekdays, &s.BreakMinutes, &s.CreatedAt)
+	return s, err
+}
+
+func (r *ShiftRepo) Create(ctx context.Context, s *domain.Shift) error {
+	if err := tenant.Assign(ctx, &s.SiteID); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO shifts (site_id, name, location, start_time, end_time, weekdays, break_minutes, created_at)
+		VALUES ($1, $2, NULLIF($3,''), $4::time, $5::time, $6, $7, NOW())
+		RETURNING id, created_at;
+	`
+	err := r.db.Pool.QueryRow(ctx, query,
+		s.SiteID, s.Name, s.Location, s.StartTime, s.EndTime, s.Weekdays, s.BreakMinutes,
+	).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert shift: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *ShiftRepo) FindAll(ctx context.Context) ([]domain.Shift, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+shiftColumns+` FROM shifts
+		WHERE ($1::bigint = 0 OR site_id = $1)
+		ORDER BY location NULLS FIRST, start_time, id;`, site)
+	if err != nil {
+		return nil, fmt.Errorf("query shifts: %w", err)
+	}
+	defer rows.Close()
+
+	var list []domain.Shift
+	for rows.Next() {
+		s, err := scanShift(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan shift: %w", err)
+		}
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
+
+func (r *ShiftRepo) Delete(ctx context.Context, id int64) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tag, err := r.db.Pool.Exec(ctx, `DELETE FROM shifts WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id, site)
+	if err != nil {
+		return fmt.Errorf("delete shift: %w", mapError(err)) // turno ainda usado por técnicos
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
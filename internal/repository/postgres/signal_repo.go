@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// SignalRepo grava as leituras de máquina, no escopo do ativo (sem site próprio).
+type SignalRepo struct {
+	db *DB
+}
+
+func NewSignalRepo(db *DB) *SignalRepo {
+	return &SignalRepo{db: db}
+}
+
+func (r *SignalRepo) CreateMeterReading(ctx context.Context, m *domain.MeterReading) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err = r.db.Pool.QueryRow(ctx, `
+		INSERT INTO meter_readings (asset_id, value, read_at, created_at)
+		SELECT $1, $2, $3, NOW()
+		WHERE `+assetInSite("$1::bigint", 4)+`
+		RETURNING id, created_at;`,
+		m.AssetID, m.Value, m.ReadAt, site,
+	).Scan(&m.ID, &m.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("insert meter reading: %w", mapError(err))
+	}
+	return nil
+}
+
+// MeterReadingAt busca a última leitura até at e, se não houver, a primeira
+// depois dele: duas consultas pelo índice (asset_id, read_at), sem ordenar as
+// leituras do ativo.
+func (r *SignalRepo) MeterReadingAt(ctx context.Context, assetID int64, at time.Time) (*domain.MeterReading, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	m, err := r.meterReading(ctx, `
+		SELECT id, asset_id, value, read_at, created_at
+		FROM meter_readings
+		WHERE asset_id = $1 AND read_at <= $2 AND `+assetInSite("asset_id", 3)+`
+		ORDER BY read_at DESC
+		LIMIT 1;`, assetID, at, site)
+	if err != domain.ErrNotFound {
+		return m, err
+	}
+	return r.meterReading(ctx, `
+		SELECT id, asset_id, value, read_at, created_at
+		FROM meter_readings
+		WHERE asset_id = $1 AND read_at > $2 AND `+assetInSite("asset_id", 3)+`
+		ORDER BY read_at
+		LIMIT 1;`, assetID, at, site)
+}
+
+func (r *SignalRepo) meterReading(ctx context.Context, query string, args ...any) (*domain.MeterReading, error) {
+	var m domain.MeterReading
+	err := r.db.Pool.QueryRow(ctx, query, args...).Scan(&m.ID, &m.AssetID, &m.Value, &m.ReadAt, &m.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find meter reading: %w", err)
+	}
+	return &m, nil
+}
+
+func (r *SignalRepo) CreateConditionReading(ctx context.Context, c *domain.ConditionReading) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err = r.db.Pool.QueryRow(ctx, `
+		INSERT INTO condition_readings (asset_id, parameter, value, read_at, created_at)
+		SELECT $1, $2, $3, $4, NOW()
+		WHERE `+assetInSite("$1::bigint", 5)+`
+		RETURNING id, created_at;`,
+		c.AssetID, c.Parameter, c.Value, c.ReadAt, site,
+	).Scan(&c.ID, &c.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("insert condition reading: %w", mapError(err))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type SiteRepo struct {
+	db *DB
+}
+
+func NewSiteRepo(db *DB) *SiteRepo {
+	return &SiteRepo{db: db}
+}
+
+const siteColumns = `id, code, name, created_at`
+
+func scanSite(row pgx.Row) (domain.Site, error) {
+	var s domain.Site
+	err := row.Scan(&s.ID, &s.Code, &s.Name, &s.CreatedAt)
+	return s, err
+}
+
+func (r *SiteRepo) Create(ctx context.Context, s *domain.Site) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err := r.db.Pool.QueryRow(ctx, `
+		INSERT INTO sites (code, name, created_at) VALUES ($1, $2, NOW())
+		RETURNING id, created_at;`, s.Code, s.Name).Scan(&s.ID, &s.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert site: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *SiteRepo) FindAll(ctx context.Context) ([]domain.Site, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx,
+		`SELECT `+siteColumns+` FROM sites WHERE ($1::bigint = 0 OR id = $1) ORDER BY id;`, site)
+	if err != nil {
+		return nil, fmt.Errorf("query sites: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Site, error) { return scanSite(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan site: %w", err)
+	}
+	return list, nil
+}
+
+func (r *SiteRepo) FindByID(ctx context.Context, id int64) (*domain.Site, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	s, err := scanSite(r.db.Pool.QueryRow(ctx,
+		`SELECT `+siteColumns+` FROM sites WHERE id=$1 AND ($2::bigint = 0 OR id = $2);`, id, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find site: %w", err)
+	}
+	return &s, nil
+}
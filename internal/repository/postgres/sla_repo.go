@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+type SLARepo struct {
+	db *DB
+}
+
+func NewSLARepo(db *DB) *SLARepo {
+	return &SLARepo{db: db}
+}
+
+const slaColumns = `criticality, type, priority, response_minutes, resolution_minutes`
+
+func scanSLAPolicy(row pgx.Row) (domain.SLAPolicy, error) {
+	var p domain.SLAPolicy
+	err := row.Scan(&p.Criticality, &p.Type, &p.Priority, &p.ResponseMinutes, &p.ResolutionMinutes)
+	return p, err
+}
+
+func (r *SLARepo) FindAll(ctx context.Context) ([]domain.SLAPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+slaColumns+` FROM sla_policies ORDER BY criticality, type;`)
+	if err != nil {
+		return nil, fmt.Errorf("query sla_policies: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.SLAPolicy, error) { return scanSLAPolicy(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan sla_policy: %w", err)
+	}
+	return list, nil
+}
+
+func (r *SLARepo) Find(ctx context.Context, criticality domain.Criticality, woType domain.WorkOrderType) (*domain.SLAPolicy, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	p, err := scanSLAPolicy(r.db.Pool.QueryRow(ctx,
+		`SELECT `+slaColumns+` FROM sla_policies WHERE criticality=$1 AND type=$2;`, criticality, woType))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find sla_policy: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *SLARepo) ReplaceAll(ctx context.Context, policies []domain.SLAPolicy) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	return pgx.BeginFunc(ctx, r.db.Pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM sla_policies;`); err != nil {
+			return fmt.Errorf("clear sla_policies: %w", err)
+		}
+		for _, p := range policies {
+			_, err := tx.Exec(ctx, `
+				INSERT INTO sla_policies (criticality, type, priority, response_minutes, resolution_minutes, updated_at)
+				VALUES ($1, $2, $3, $4, $5, NOW());`,
+				p.Criticality, p.Type, p.Priority, p.ResponseMinutes, p.ResolutionMinutes)
+			if err != nil {
+				return fmt.Errorf("insert sla_policy: %w", mapError(err))
+			}
+		}
+		return nil
+	})
+}
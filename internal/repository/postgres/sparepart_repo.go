@@ -0,0 +1,131 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type SparePartRepo struct {
+	db *DB
+}
+
+func NewSparePartRepo(db *DB) *SparePartRepo {
+	return &SparePartRepo{db: db}
+}
+
+const sparePartColumns = `id, site_id, code, name, unit, quantity, min_quantity, created_at, updated_at`
+
+func scanSparePart(row pgx.Row) (domain.SparePart, error) {
+	var p domain.SparePart
+	err := row.Scan(&p.ID, &p.SiteID, &p.Code, &p.Name, &p.Unit, &p.Quantity, &p.MinQuantity, &p.CreatedAt, &p.UpdatedAt)
+	return p, err
+}
+
+func (r *SparePartRepo) Create(ctx context.Context, p *domain.SparePart) error {
+	if err := tenant.Assign(ctx, &p.SiteID); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO spare_parts (site_id, code, name, unit, quantity, min_quantity, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING id, created_at, updated_at;
+	`
+	err := r.db.Pool.QueryRow(ctx, query, p.SiteID, p.Code, p.Name, p.Unit, p.Quantity, p.MinQuantity).
+		Scan(&p.ID, &p.CreatedAt, &p.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert spare part: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *SparePartRepo) Update(ctx context.Context, p *domain.SparePart) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	cur, err := scanSparePart(r.db.Pool.QueryRow(ctx, `
+		UPDATE spare_parts SET name=$2, unit=$3, min_quantity=$4, updated_at=NOW()
+		WHERE id=$1 AND ($5::bigint = 0 OR site_id = $5)
+		RETURNING `+sparePartColumns+`;`,
+		p.ID, p.Name, p.Unit, p.MinQuantity, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("update spare part: %w", mapError(err))
+	}
+	*p = cur
+	return nil
+}
+
+func (r *SparePartRepo) FindAll(ctx context.Context) ([]domain.SparePart, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+sparePartColumns+` FROM spare_parts
+		WHERE ($1::bigint = 0 OR site_id = $1) ORDER BY code, site_id;`, site)
+	if err != nil {
+		return nil, fmt.Errorf("query spare parts: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.SparePart, error) { return scanSparePart(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan spare part: %w", err)
+	}
+	return list, nil
+}
+
+func (r *SparePartRepo) FindByID(ctx context.Context, id int64) (*domain.SparePart, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	p, err := scanSparePart(r.db.Pool.QueryRow(ctx, `SELECT `+sparePartColumns+` FROM spare_parts
+		WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find spare part: %w", err)
+	}
+	return &p, nil
+}
+
+func (r *SparePartRepo) AdjustStock(ctx context.Context, id int64, delta float64) (*domain.SparePart, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	// O CHECK (quantity >= 0) da tabela barra saídas maiores que o saldo.
+	p, err := scanSparePart(r.db.Pool.QueryRow(ctx, `
+		UPDATE spare_parts SET quantity = quantity + $2, updated_at=NOW()
+		WHERE id=$1 AND ($3::bigint = 0 OR site_id = $3)
+		RETURNING `+sparePartColumns+`;`, id, delta, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("adjust spare part stock: %w", mapError(err))
+	}
+	return &p, nil
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// syncWatermark é a marca d'água das revisões: abaixo dela todas as transações
+// já terminaram, então nenhuma escrita ainda invisível pode ganhar revisão menor
+// (ver a migração de revisões de sincronização).
+const syncWatermark = `(pg_snapshot_xmin(pg_current_snapshot())::text::bigint << 20)`
+
+type SyncRepo struct {
+	db *DB
+}
+
+func NewSyncRepo(db *DB) *SyncRepo {
+	return &SyncRepo{db: db}
+}
+
+// Changes lê as três tabelas num snapshot (REPEATABLE READ), até a marca
+// d'água desse snapshot, e junta os resultados pela revisão.
+func (r *SyncRepo) Changes(ctx context.Context, since, technicianID int64, limit int) ([]domain.SyncChange, error) {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := r.db.Pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("begin sync snapshot: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var changes []domain.SyncChange
+
+	rows, err := tx.Query(ctx, `
+			SELECT `+assetColumns+`
+			FROM assets
+			WHERE revision > $1 AND revision < `+syncWatermark+` AND ($2::bigint = 0 OR site_id = $2)
+			ORDER BY revision LIMIT $3;`, since, site, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query asset changes: %w", err)
+	}
+	assets, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Asset, error) { return scanAsset(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan asset: %w", err)
+	}
+	for i := range assets {
+		changes = append(changes, domain.SyncChange{Entity: domain.SyncAsset, Revision: assets[i].Revision, Asset: &assets[i]})
+	}
+
+	orders, err := workOrderChanges(ctx, tx, since, site, technicianID, limit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range orders {
+		changes = append(changes, domain.SyncChange{Entity: domain.SyncWorkOrder, Revision: orders[i].Revision, WorkOrder: &orders[i]})
+	}
+
+	rows, err = tx.Query(ctx, `
+			SELECT c.work_order_id, c.step, c.description, c.measurement, c.done, c.value, COALESCE(c.note,''),
+					c.out_of_tolerance, c.follow_up_id, c.completed_at, c.updated_at, c.revision
+			FROM work_order_checklist c
+			JOIN work_orders wo ON wo.id = c.work_order_id
+			WHERE c.revision > $1 AND c.revision < `+syncWatermark+` AND ($2::bigint = 0 OR wo.site_id = $2)
+			  AND wo.status IN ('open','in_progress') AND ($4::bigint = 0 OR wo.assignee_id = $4)
+			ORDER BY c.revision LIMIT $3;`, since, site, limit, technicianID)
+	if err != nil {
+		return nil, fmt.Errorf("query checklist changes: %w", err)
+	}
+	items, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.SyncChange, error) {
+		ch := domain.SyncChange{Entity: domain.SyncChecklistItem, ChecklistItem: &domain.ChecklistItem{}}
+		it := ch.ChecklistItem
+		err := row.Scan(&ch.WorkOrderID, &it.Step, &it.Description, &it.Measurement, &it.Done, &it.Value, &it.Note,
+			&it.OutOfTolerance, &it.FollowUpID, &it.CompletedAt, &it.UpdatedAt, &it.Revision)
+		ch.Revision = it.Revision
+		return ch, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan checklist item: %w", err)
+	}
+	changes = append(changes, items...)
+
+	if technicianID != 0 && since > 0 {
+		// OS que saíram do técnico: só o ID, para o aparelho apagar.
+		rows, err = tx.Query(ctx, `
+			SELECT h.work_order_id, h.revision
+			FROM work_order_handoffs h
+			JOIN work_orders wo ON wo.id = h.work_order_id
+			WHERE h.technician_id = $4 AND h.revision > $1 AND h.revision < `+syncWatermark+`
+			  AND ($2::bigint = 0 OR wo.site_id = $2) AND wo.assignee_id IS DISTINCT FROM $4
+			ORDER BY h.revision LIMIT $3;`, since, site, limit, technicianID)
+		if err != nil {
+			return nil, fmt.Errorf("query work order handoffs: %w", err)
+		}
+		gone, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.SyncChange, error) {
+			ch := domain.SyncChange{Entity: domain.SyncWorkOrder, Deleted: true, WorkOrder: &domain.WorkOrder{}}
+			err := row.Scan(&ch.WorkOrder.ID, &ch.Revision)
+			return ch, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scan work order handoff: %w", err)
+		}
+		changes = append(changes, gone...)
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Revision < changes[j].Revision })
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+func (r *SyncRepo) WorkOrderChanges(ctx context.Context, since int64, limit int) ([]domain.WorkOrder, error) {
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	return workOrderChanges(ctx, r.db.Pool, since, site, 0, limit)
+}
+
+// workOrderChanges roda no pool ou dentro do snapshot de Changes; technicianID
+// = 0 não filtra por responsável.
+func workOrderChanges(ctx context.Context, q interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}, since, site, technicianID int64, limit int) ([]domain.WorkOrder, error) {
+	rows, err := q.Query(ctx, `
+			SELECT `+workOrderColumns+`
+			FROM work_orders
+			WHERE revision > $1 AND revision < `+syncWatermark+` AND ($2::bigint = 0 OR site_id = $2)
+			  AND ($1 > 0 OR status IN ('open','in_progress')) AND ($4::bigint = 0 OR assignee_id = $4)
+			ORDER BY revision LIMIT $3;`, since, site, limit, technicianID)
+	if err != nil {
+		return nil, fmt.Errorf("query work order changes: %w", err)
+	}
+	orders, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.WorkOrder, error) { return scanWorkOrder(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan work order: %w", err)
+	}
+	return orders, nil
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type TechnicianRepo struct {
+	db *DB
+}
+
+func NewTechnicianRepo(db *DB) *TechnicianRepo {
+	return &TechnicianRepo{db: db}
+}
+
+const technicianColumns = `id, site_id, name, skills, shift_id, user_id, active, created_at, updated_at`
+
+func scanTechnician(row pgx.Row) (domain.Technician, error) {
+	var t domain.Technician
+	err := row.Scan(&t.ID, &t.SiteID, &t.Name, &t.Skills, &t.ShiftID, &t.UserID, &t.Active, &t.CreatedAt, &t.UpdatedAt)
+	return t, err
+}
+
+// Create grava o técnico no site do turno; turno fora do escopo é ErrInvalidInput.
+func (r *TechnicianRepo) Create(ctx context.Context, t *domain.Technician) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO technicians (site_id, name, skills, shift_id, active, user_id, created_at, updated_at)
+		VALUES ((SELECT site_id FROM shifts WHERE id = $3 AND ($5::bigint = 0 OR site_id = $5)),
+			$1, $2, $3, $4, $6, NOW(), NOW())
+		RETURNING id, site_id, created_at, updated_at;
+	`
+	err = r.db.Pool.QueryRow(ctx, query, t.Name, t.Skills, t.ShiftID, t.Active, site, t.UserID).
+		Scan(&t.ID, &t.SiteID, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert technician: %w", mapError(err))
+	}
+	return nil
+}
+
+// Update não muda o site: o novo turno precisa ser do mesmo site (FK composta).
+func (r *TechnicianRepo) Update(ctx context.Context, t *domain.Technician) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err = r.db.Pool.QueryRow(ctx, `
+		UPDATE technicians
+		SET name=$2, skills=$3, shift_id=$4, active=$5, user_id=$7, updated_at=NOW()
+		WHERE id=$1 AND ($6::bigint = 0 OR site_id = $6)
+		RETURNING site_id, created_at, updated_at;`,
+		t.ID, t.Name, t.Skills, t.ShiftID, t.Active, site, t.UserID,
+	).Scan(&t.SiteID, &t.CreatedAt, &t.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("update technician: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *TechnicianRepo) FindAll(ctx context.Context) ([]domain.Technician, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx, `SELECT `+technicianColumns+` FROM technicians
+		WHERE ($1::bigint = 0 OR site_id = $1) ORDER BY id;`, site)
+	if err != nil {
+		return nil, fmt.Errorf("query technicians: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Technician, error) { return scanTechnician(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan technician: %w", err)
+	}
+	return list, nil
+}
+
+func (r *TechnicianRepo) FindByID(ctx context.Context, id int64) (*domain.Technician, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	t, err := scanTechnician(r.db.Pool.QueryRow(ctx, `SELECT `+technicianColumns+` FROM technicians
+		WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find technician: %w", err)
+	}
+	return &t, nil
+}
+
+func (r *TechnicianRepo) FindByUser(ctx context.Context, userID int64) (*domain.Technician, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	t, err := scanTechnician(r.db.Pool.QueryRow(ctx, `SELECT `+technicianColumns+` FROM technicians
+		WHERE user_id=$1 AND ($2::bigint = 0 OR site_id = $2);`, userID, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find technician by user: %w", err)
+	}
+	return &t, nil
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+type UserRepo struct {
+	db *DB
+}
+
+func NewUserRepo(db *DB) *UserRepo {
+	return &UserRepo{db: db}
+}
+
+const userColumns = `id, site_id, name, COALESCE(email,''), COALESCE(phone,''), COALESCE(chat_id,''),
+		active, corporate, preferences, created_at, updated_at`
+
+func scanUser(row pgx.Row) (domain.User, error) {
+	var u domain.User
+	err := row.Scan(&u.ID, &u.SiteID, &u.Name, &u.Email, &u.Phone, &u.ChatID, &u.Active, &u.Corporate,
+		&u.Preferences, &u.CreatedAt, &u.UpdatedAt)
+	return u, err
+}
+
+func (r *UserRepo) Create(ctx context.Context, u *domain.User) error {
+	if err := tenant.Assign(ctx, &u.SiteID); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		INSERT INTO users (site_id, name, email, phone, chat_id, active, corporate, preferences, created_at, updated_at)
+		VALUES ($1, $2, NULLIF($3,''), NULLIF($4,''), NULLIF($5,''), $6, $7, $8, NOW(), NOW())
+		RETURNING id, created_at, updated_at;
+	`
+	err := r.db.Pool.QueryRow(ctx, query, u.SiteID, u.Name, u.Email, u.Phone, u.ChatID, u.Active, u.Corporate, u.Preferences).
+		Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("insert user: %w", mapError(err))
+	}
+	return nil
+}
+
+// Update não muda o site do usuário.
+func (r *UserRepo) Update(ctx context.Context, u *domain.User) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	err = r.db.Pool.QueryRow(ctx, `
+		UPDATE users
+		SET name=$2, email=NULLIF($3,''), phone=NULLIF($4,''), chat_id=NULLIF($5,''),
+		    active=$6, corporate=$7, preferences=$8, updated_at=NOW()
+		WHERE id=$1 AND ($9::bigint = 0 OR site_id = $9)
+		RETURNING site_id, created_at, updated_at;`,
+		u.ID, u.Name, u.Email, u.Phone, u.ChatID, u.Active, u.Corporate, u.Preferences, site,
+	).Scan(&u.SiteID, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("update user: %w", mapError(err))
+	}
+	return nil
+}
+
+func (r *UserRepo) FindAll(ctx context.Context) ([]domain.User, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := r.db.Pool.Query(ctx,
+		`SELECT `+userColumns+` FROM users WHERE ($1::bigint = 0 OR site_id = $1) ORDER BY id;`, site)
+	if err != nil {
+		return nil, fmt.Errorf("query users: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.User, error) { return scanUser(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan user: %w", err)
+	}
+	return list, nil
+}
+
+func (r *UserRepo) FindByID(ctx context.Context, id int64) (*domain.User, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	u, err := scanUser(r.db.Pool.QueryRow(ctx,
+		`SELECT `+userColumns+` FROM users WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);`, id, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+	return &u, nil
+}
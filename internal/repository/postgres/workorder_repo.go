@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
 )
 
 type WorkOrderRepo struct {
@@ -16,46 +18,137 @@ func NewWorkOrderRepo(db *DB) *WorkOrderRepo {
 	return &WorkOrderRepo{db: db}
 }
 
-func (r *WorkOrderRepo) Create(order *domain.WorkOrder) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+const workOrderColumns = `id, site_id, asset_id, type, status, title,
+					COALESCE(description,'') AS description,
+					breakdown_at, closed_at,
+					downtime_minutes,
+					COALESCE(cause,'')    AS cause,
+					COALESCE(solution,'') AS solution,
+					failure_mode_id, failure_cause_id, failure_action_id,
+					COALESCE(priority,'') AS priority, response_due_at, resolution_due_at,
+					responded_at, sla_breached_at,
+					plan_id, scheduled_for,
+					trade, estimated_minutes, assignee_id,
+					job_plan_id, required_parts, request_id,
+					created_at, updated_at, revision`
+
+func scanWorkOrder(row pgx.Row) (domain.WorkOrder, error) {
+	var o domain.WorkOrder
+	err := row.Scan(
+		&o.ID, &o.SiteID, &o.AssetID, &o.Type, &o.Status, &o.Title, &o.Description,
+		&o.BreakdownAt, &o.ClosedAt, &o.DowntimeMinutes,
+		&o.Cause, &o.Solution,
+		&o.FailureModeID, &o.FailureCauseID, &o.FailureActionID,
+		&o.Priority, &o.ResponseDueAt, &o.ResolutionDueAt,
+		&o.RespondedAt, &o.SLABreachedAt,
+		&o.PlanID, &o.ScheduledFor,
+		&o.Trade, &o.EstimatedMinutes, &o.AssigneeID,
+		&o.JobPlanID, &o.RequiredParts, &o.RequestID,
+		&o.CreatedAt, &o.UpdatedAt, &o.Revision,
+	)
+	return o, err
+}
+
+// Create grava a OS no site do ativo; ativo fora do escopo é ErrInvalidInput.
+func (r *WorkOrderRepo) Create(ctx context.Context, order *domain.WorkOrder) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	query := `
-		INSERT INTO work_orders (asset_id, type, status, title, description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
-		RETURNING id, created_at, updated_at;
+		INSERT INTO work_orders (site_id, asset_id, type, status, title, description, breakdown_at, closed_at,
+			priority, response_due_at, resolution_due_at, responded_at, plan_id, scheduled_for,
+			trade, estimated_minutes, job_plan_id, required_parts, request_id, assignee_id, created_at, updated_at)
+		VALUES ((SELECT site_id FROM assets WHERE id = $1 AND ($19::bigint = 0 OR site_id = $19)),
+			$1, $2, $3, $4, $5, $6, $7, NULLIF($8,''), $9, $10, $11, $12, $13, $14, $15, $16, COALESCE($17, '[]'::jsonb), $18, $20, NOW(), NOW())
+		RETURNING id, site_id, created_at, updated_at, revision;
 	`
 
-	err := r.db.Pool.QueryRow(ctx, query,
+	err = r.db.conn(ctx).QueryRow(ctx, query,
 		order.AssetID,
 		order.Type,
 		order.Status,
 		order.Title,
 		order.Description,
-	).Scan(&order.ID, &order.CreatedAt, &order.UpdatedAt)
+		order.BreakdownAt,
+		order.ClosedAt,
+		order.Priority,
+		order.ResponseDueAt,
+		order.ResolutionDueAt,
+		order.RespondedAt,
+		order.PlanID,
+		order.ScheduledFor,
+		order.Trade,
+		order.EstimatedMinutes,
+		order.JobPlanID,
+		order.RequiredParts,
+		order.RequestID,
+		site,
+		order.AssigneeID,
+	).Scan(&order.ID, &order.SiteID, &order.CreatedAt, &order.UpdatedAt, &order.Revision)
 	if err != nil {
-		return fmt.Errorf("insert work order: %w", err)
+		return fmt.Errorf("insert work order: %w", mapError(err))
 	}
 	return nil
 }
 
-func (r *WorkOrderRepo) FindAll() ([]domain.WorkOrder, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+// CreateBatch grava OS históricas via COPY, preservando datas de abertura e fechamento.
+// SiteID deve ser o do ativo (a FK composta recusa outro site).
+func (r *WorkOrderRepo) CreateBatch(ctx context.Context, orders []domain.WorkOrder) (int64, error) {
+	for i := range orders {
+		if err := tenant.Assign(ctx, &orders[i].SiteID); err != nil {
+			return 0, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	n, err := r.db.conn(ctx).CopyFrom(ctx,
+		pgx.Identifier{"work_orders"},
+		[]string{
+			"site_id", "asset_id", "type", "status", "title", "description",
+			"breakdown_at", "closed_at", "downtime_minutes", "cause", "solution",
+			"created_at", "updated_at",
+		},
+		pgx.CopyFromSlice(len(orders), func(i int) ([]any, error) {
+			o := orders[i]
+			created := o.CreatedAt
+			if created.IsZero() {
+				created = now
+			}
+			return []any{
+				o.SiteID, o.AssetID, string(o.Type), string(o.Status), o.Title, o.Description,
+				o.BreakdownAt, o.ClosedAt, o.DowntimeMinutes, nullIfEmpty(o.Cause), nullIfEmpty(o.Solution),
+				created, now,
+			}, nil
+		}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("copy work_orders: %w", mapError(err))
+	}
+	return n, nil
+}
+
+func (r *WorkOrderRepo) FindAll(ctx context.Context) ([]domain.WorkOrder, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	query := `
-			SELECT id, asset_id, type, status, title,
-					COALESCE(description,'') AS description,
-					breakdown_at, closed_at,
-					downtime_minutes,
-					COALESCE(cause,'')    AS cause,
-					COALESCE(solution,'') AS solution,
-					created_at, updated_at
+			SELECT ` + workOrderColumns + `
 			FROM work_orders
+			WHERE ($1::bigint = 0 OR site_id = $1)
 			ORDER BY id;
 			`
 
-	rows, err := r.db.Pool.Query(ctx, query)
+	rows, err := r.db.conn(ctx).Query(ctx, query, site)
 	if err != nil {
 		return nil, fmt.Errorf("query work_orders: %w", err)
 	}
@@ -63,12 +156,8 @@ func (r *WorkOrderRepo) FindAll() ([]domain.WorkOrder, error) {
 
 	var list []domain.WorkOrder
 	for rows.Next() {
-		var o domain.WorkOrder
-		if err := rows.Scan(
-			&o.ID, &o.AssetID, &o.Type, &o.Status, &o.Title, &o.Description,
-			&o.BreakdownAt, &o.ClosedAt, &o.DowntimeMinutes,
-			&o.Cause, &o.Solution, &o.CreatedAt, &o.UpdatedAt,
-		); err != nil {
+		o, err := scanWorkOrder(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan work_order: %w", err)
 		}
 		list = append(list, o)
@@ -76,24 +165,129 @@ func (r *WorkOrderRepo) FindAll() ([]domain.WorkOrder, error) {
 	return list, nil
 }
 
-func (r *WorkOrderRepo) FindByStatus(status domain.WorkOrderStatus) ([]domain.WorkOrder, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+func (r *WorkOrderRepo) FindByID(ctx context.Context, id int64) (*domain.WorkOrder, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	query := `
-			SELECT id, asset_id, type, status, title,
-					COALESCE(description,'') AS description,
-					breakdown_at, closed_at,
-					downtime_minutes,
-					COALESCE(cause,'')    AS cause,
-					COALESCE(solution,'') AS solution,
-					created_at, updated_at
+			SELECT ` + workOrderColumns + `
 			FROM work_orders
-			WHERE status=$1
+			WHERE id=$1 AND ($2::bigint = 0 OR site_id = $2);
+			`
+
+	o, err := scanWorkOrder(r.db.conn(ctx).QueryRow(ctx, query, id, site))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, fmt.Errorf("find work order: %w", err)
+	}
+	return &o, nil
+}
+
+// Update grava o ciclo de vida, o técnico responsável e o fechamento da OS (status, datas,
+// causa/solução e códigos de falha). Prioridade e prazos de SLA são fixados na abertura.
+// Trocar o responsável registra a saída do anterior e reenvia o checklist (trigger).
+func (r *WorkOrderRepo) Update(ctx context.Context, o *domain.WorkOrder) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+		UPDATE work_orders
+		SET status=$2, title=$3, description=$4, breakdown_at=$5, closed_at=$6,
+			cause=NULLIF($7,''), solution=NULLIF($8,''),
+			failure_mode_id=$9, failure_cause_id=$10, failure_action_id=$11,
+			responded_at=$12, assignee_id=$14, updated_at=NOW()
+		WHERE id=$1 AND ($13::bigint = 0 OR site_id = $13)
+		RETURNING site_id, updated_at, revision;
+	`
+	err = r.db.conn(ctx).QueryRow(ctx, query,
+		o.ID, o.Status, o.Title, o.Description, o.BreakdownAt, o.ClosedAt,
+		o.Cause, o.Solution, o.FailureModeID, o.FailureCauseID, o.FailureActionID,
+		o.RespondedAt, site, o.AssigneeID,
+	).Scan(&o.SiteID, &o.UpdatedAt, &o.Revision)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return fmt.Errorf("update work order: %w", mapError(err))
+	}
+	return nil
+}
+
+// MarkSLABreached marca as OS em atraso ainda não sinalizadas e as retorna.
+func (r *WorkOrderRepo) MarkSLABreached(ctx context.Context, now time.Time) ([]domain.WorkOrder, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	query := `
+			UPDATE work_orders
+			SET sla_breached_at = $1
+			WHERE sla_breached_at IS NULL
+			  AND status IN ('open','in_progress')
+			  AND (resolution_due_at < $1 OR (responded_at IS NULL AND response_due_at < $1))
+			  AND ($2::bigint = 0 OR site_id = $2)
+			RETURNING ` + workOrderColumns + `;`
+
+	rows, err := r.db.conn(ctx).Query(ctx, query, now, site)
+	if err != nil {
+		return nil, fmt.Errorf("mark sla breached: %w", err)
+	}
+	list, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.WorkOrder, error) { return scanWorkOrder(row) })
+	if err != nil {
+		return nil, fmt.Errorf("scan breached work_order: %w", err)
+	}
+	return list, nil
+}
+
+func (r *WorkOrderRepo) SetDowntimeMinutes(ctx context.Context, id int64, minutes *int64) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tag, err := r.db.conn(ctx).Exec(ctx,
+		`UPDATE work_orders SET downtime_minutes=$2, updated_at=NOW()
+		WHERE id=$1 AND ($3::bigint = 0 OR site_id = $3);`, id, minutes, site)
+	if err != nil {
+		return fmt.Errorf("update work order downtime: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WorkOrderRepo) FindByStatus(ctx context.Context, status domain.WorkOrderStatus) ([]domain.WorkOrder, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	query := `
+			SELECT ` + workOrderColumns + `
+			FROM work_orders
+			WHERE status=$1 AND ($2::bigint = 0 OR site_id = $2)
 			ORDER BY id;
 			`
 
-	rows, err := r.db.Pool.Query(ctx, query, status)
+	rows, err := r.db.conn(ctx).Query(ctx, query, status, site)
 	if err != nil {
 		return nil, fmt.Errorf("query by status: %w", err)
 	}
@@ -101,15 +295,98 @@ func (r *WorkOrderRepo) FindByStatus(status domain.WorkOrderStatus) ([]domain.Wo
 
 	var list []domain.WorkOrder
 	for rows.Next() {
-		var o domain.WorkOrder
-		if err := rows.Scan(
-			&o.ID, &o.AssetID, &o.Type, &o.Status, &o.Title, &o.Description,
-			&o.BreakdownAt, &o.ClosedAt, &o.DowntimeMinutes,
-			&o.Cause, &o.Solution, &o.CreatedAt, &o.UpdatedAt,
-		); err != nil {
+		o, err := scanWorkOrder(rows)
+		if err != nil {
 			return nil, fmt.Errorf("scan work_order: %w", err)
 		}
 		list = append(list, o)
 	}
 	return list, nil
 }
+
+func (r *WorkOrderRepo) Stream(ctx context.Context, filter domain.WorkOrderFilter, fn func(*domain.WorkOrder) error) error {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, streamTimeout)
+	defer cancel()
+
+	var (
+		where []string
+		args  []any
+	)
+	add := func(cond string, v any) {
+		args = append(args, v)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+	if site != 0 {
+		add("site_id = $%d", site)
+	}
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.Type != "" {
+		add("type = $%d", filter.Type)
+	}
+	if filter.AssetID != 0 {
+		add("asset_id = $%d", filter.AssetID)
+	}
+	if len(filter.AssetIDs) > 0 {
+		add("asset_id = ANY($%d)", filter.AssetIDs)
+	}
+	if filter.PlanID != 0 {
+		add("plan_id = $%d", filter.PlanID)
+	}
+	if filter.Open {
+		where = append(where, "status IN ('open','in_progress')")
+	}
+	if filter.From != nil {
+		add("created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		add("created_at < $%d", *filter.To)
+	}
+	if filter.OverdueAt != nil {
+		// Mesma regra de domain.WorkOrder.IsOverdue.
+		add(`status IN ('open','in_progress') AND (resolution_due_at < $%[1]d
+			OR (responded_at IS NULL AND response_due_at < $%[1]d))`, *filter.OverdueAt)
+	}
+	if filter.DueBefore != nil {
+		add("status IN ('open','in_progress') AND resolution_due_at < $%d", *filter.DueBefore)
+	}
+
+	from := "work_orders" + whereClause(where)
+	if filter.PerAsset > 0 {
+		// O corte por ativo fica no banco (idx_work_orders_asset_created).
+		args = append(args, filter.PerAsset)
+		from = fmt.Sprintf(`(
+				SELECT *, ROW_NUMBER() OVER (PARTITION BY asset_id ORDER BY created_at DESC, id DESC) AS asset_rank
+				FROM %s
+			) work_orders
+			WHERE asset_rank <= $%d`, from, len(args))
+	}
+
+	query := `
+			SELECT ` + workOrderColumns + `
+			FROM ` + from + `
+			ORDER BY id;
+			`
+
+	rows, err := r.db.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("stream work_orders: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		o, err := scanWorkOrder(rows)
+		if err != nil {
+			return fmt.Errorf("scan work_order: %w", err)
+		}
+		if err := fn(&o); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -1,15 +1,270 @@
 package repository
 
-import "github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+import (
+	"context"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// SiteRepository guarda as plantas. Listagem e busca respeitam o escopo do
+// contexto: fora do escopo de todos os sites, só o próprio site aparece.
+type SiteRepository interface {
+	Create(ctx context.Context, site *domain.Site) error
+	FindAll(ctx context.Context) ([]domain.Site, error)
+	FindByID(ctx context.Context, id int64) (*domain.Site, error)
+}
+
+// Transactor agrupa gravações de repositórios numa transação: os repositórios
+// chamados com o contexto recebido por fn gravam nela, e um erro de fn desfaz
+// tudo. Chamadas aninhadas reaproveitam a transação aberta. Hoje participam as
+// OS e os checklists.
+type Transactor interface {
+	InTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Os repositórios de ativos, OS, planos, usuários, relatórios e indicadores só
+// leem e gravam registros do site do contexto (tenant.Site); sem escopo, a
+// chamada falha com ErrUnauthorized.
 
 type AssetRepository interface {
-	Create(asset *domain.Asset) error
-	FindAll() ([]domain.Asset, error)
-	FindByID(id int64) (*domain.Asset, error)
+	Create(ctx context.Context, asset *domain.Asset) error
+	CreateBatch(ctx context.Context, assets []domain.Asset) (int64, error)
+	FindAll(ctx context.Context) ([]domain.Asset, error)
+	FindByID(ctx context.Context, id int64) (*domain.Asset, error)
+	// FindByCode busca pelo external_code sem diferenciar maiúsculas (ErrNotFound se não houver).
+	FindByCode(ctx context.Context, code string) (*domain.Asset, error)
+	Update(ctx context.Context, asset *domain.Asset) error
+	// Stream percorre os ativos filtrados sem carregar tudo em memória.
+	Stream(ctx context.Context, filter domain.AssetFilter, fn func(*domain.Asset) error) error
+}
+
+// AssetClassRepository guarda as classes de ativo e as definições dos atributos customizados.
+type AssetClassRepository interface {
+	Create(ctx context.Context, class *domain.AssetClass) error
+	// Update troca nome e atributos da classe; o código não muda.
+	Update(ctx context.Context, class *domain.AssetClass) error
+	FindAll(ctx context.Context) ([]domain.AssetClass, error)
+	FindByCode(ctx context.Context, code string) (*domain.AssetClass, error)
 }
 
 type WorkOrderRepository interface {
-	Create(order *domain.WorkOrder) error
-	FindAll() ([]domain.WorkOrder, error)
-	FindByStatus(status domain.WorkOrderStatus) ([]domain.WorkOrder, error)
+	Create(ctx context.Context, order *domain.WorkOrder) error
+	CreateBatch(ctx context.Context, orders []domain.WorkOrder) (int64, error)
+	FindAll(ctx context.Context) ([]domain.WorkOrder, error)
+	FindByID(ctx context.Context, id int64) (*domain.WorkOrder, error)
+	FindByStatus(ctx context.Context, status domain.WorkOrderStatus) ([]domain.WorkOrder, error)
+	// Update grava status, responsável, fechamento e códigos de falha; ativo, tipo e
+	// DowntimeMinutes não mudam. Trocar o responsável deixa uma remoção no delta do
+	// técnico anterior e reenvia o checklist ao novo (ver SyncRepository).
+	Update(ctx context.Context, order *domain.WorkOrder) error
+	// MarkSLABreached sinaliza (uma única vez) as OS em aberto com SLA vencido em now e as retorna.
+	MarkSLABreached(ctx context.Context, now time.Time) ([]domain.WorkOrder, error)
+	// SetDowntimeMinutes grava o total derivado das paradas vinculadas à OS.
+	SetDowntimeMinutes(ctx context.Context, id int64, minutes *int64) error
+	// Stream percorre as OS filtradas sem carregar tudo em memória.
+	Stream(ctx context.Context, filter domain.WorkOrderFilter, fn func(*domain.WorkOrder) error) error
+}
+
+// MaintenanceRequestRepository guarda as solicitações do portal de manutenção.
+type MaintenanceRequestRepository interface {
+	Create(ctx context.Context, req *domain.MaintenanceRequest) error
+	FindByID(ctx context.Context, id int64) (*domain.MaintenanceRequest, error)
+	// FindAll lista as solicitações filtradas, mais recentes primeiro.
+	FindAll(ctx context.Context, filter domain.RequestFilter) ([]domain.MaintenanceRequest, error)
+	// Update grava a triagem/decisão só se o status atual ainda for from (ErrConflict
+	// caso outra triagem tenha chegado antes).
+	Update(ctx context.Context, req *domain.MaintenanceRequest, from domain.RequestStatus) error
+}
+
+type MaintenancePlanRepository interface {
+	Create(ctx context.Context, plan *domain.MaintenancePlan) error
+	FindAll(ctx context.Context) ([]domain.MaintenancePlan, error)
+	FindByID(ctx context.Context, id int64) (*domain.MaintenancePlan, error)
+	// SetLastExecution registra a execução do plano (fechamento da preventiva).
+	SetLastExecution(ctx context.Context, id int64, at time.Time) error
+}
+
+// IndicatorRepository calcula agregados para métricas (ex: gauges do Prometheus).
+type IndicatorRepository interface {
+	Indicators(ctx context.Context, now time.Time) (*domain.Indicators, error)
+}
+
+type DowntimeRepository interface {
+	Create(ctx context.Context, event *domain.DowntimeEvent) error
+	Update(ctx context.Context, event *domain.DowntimeEvent) error
+	// FindOpenByAsset retorna a parada em andamento do ativo (ErrNotFound se não houver).
+	FindOpenByAsset(ctx context.Context, assetID int64) (*domain.DowntimeEvent, error)
+	// FindOverlapping lista paradas do ativo que interceptam [start, end) (end nil = em aberto).
+	FindOverlapping(ctx context.Context, assetID int64, start time.Time, end *time.Time, excludeID int64) ([]domain.DowntimeEvent, error)
+	// FindByAsset lista as paradas iniciadas em [from, to); limites nil não filtram.
+	FindByAsset(ctx context.Context, assetID int64, from, to *time.Time) ([]domain.DowntimeEvent, error)
+	// FindByAssets é o FindByAsset de vários ativos numa consulta só.
+	FindByAssets(ctx context.Context, assetIDs []int64, from, to *time.Time) ([]domain.DowntimeEvent, error)
+	FindByWorkOrder(ctx context.Context, workOrderID int64) ([]domain.DowntimeEvent, error)
+}
+
+type FailureCodeRepository interface {
+	Create(ctx context.Context, code *domain.FailureCode) error
+	Update(ctx context.Context, code *domain.FailureCode) error
+	FindAll(ctx context.Context) ([]domain.FailureCode, error)
+	FindByID(ctx context.Context, id int64) (*domain.FailureCode, error)
+}
+
+// SLARepository guarda a matriz de SLA (criticidade × tipo de OS).
+type SLARepository interface {
+	FindAll(ctx context.Context) ([]domain.SLAPolicy, error)
+	// Find retorna a política da célula (ErrNotFound se a matriz não a tiver).
+	Find(ctx context.Context, criticality domain.Criticality, woType domain.WorkOrderType) (*domain.SLAPolicy, error)
+	// ReplaceAll troca a matriz inteira de forma atômica.
+	ReplaceAll(ctx context.Context, policies []domain.SLAPolicy) error
+}
+
+type UserRepository interface {
+	Create(ctx context.Context, user *domain.User) error
+	Update(ctx context.Context, user *domain.User) error
+	FindAll(ctx context.Context) ([]domain.User, error)
+	FindByID(ctx context.Context, id int64) (*domain.User, error)
+}
+
+// EscalationRepository guarda os níveis de escalonamento por site e evento.
+type EscalationRepository interface {
+	FindAll(ctx context.Context) ([]domain.EscalationRule, error)
+	// FindByEvent retorna os níveis do evento em ordem de tier.
+	FindByEvent(ctx context.Context, event domain.NotificationEvent) ([]domain.EscalationRule, error)
+	// ReplaceAll troca de forma atômica as regras do site da chamada (escopo de
+	// um só site); as dos demais sites ficam intactas.
+	ReplaceAll(ctx context.Context, rules []domain.EscalationRule) error
+}
+
+type AlertRepository interface {
+	// Create grava o alerta; ErrAlreadyExists se já houver alerta não resolvido do mesmo evento e referência.
+	Create(ctx context.Context, alert *domain.Alert) error
+	FindByID(ctx context.Context, id int64) (*domain.Alert, error)
+	// FindAll lista os alertas mais recentes primeiro; escalatingOnly traz só os sem ack e sem resolução.
+	FindAll(ctx context.Context, escalatingOnly bool) ([]domain.Alert, error)
+	SetTier(ctx context.Context, id int64, tier int) error
+	// Ack registra o reconhecimento; ErrConflict se o alerta já foi reconhecido ou resolvido.
+	Ack(ctx context.Context, id, userID int64, at time.Time) (*domain.Alert, error)
+	// Resolve encerra os alertas em aberto da referência (qualquer evento).
+	Resolve(ctx context.Context, refType domain.AlertRefType, refID int64, at time.Time) error
+}
+
+type SparePartRepository interface {
+	Create(ctx context.Context, part *domain.SparePart) error
+	// Update altera nome, unidade e estoque mínimo; a quantidade só muda via AdjustStock.
+	Update(ctx context.Context, part *domain.SparePart) error
+	FindAll(ctx context.Context) ([]domain.SparePart, error)
+	FindByID(ctx context.Context, id int64) (*domain.SparePart, error)
+	// AdjustStock soma delta à quantidade de forma atômica; ErrInvalidInput se ficaria negativa.
+	AdjustStock(ctx context.Context, id int64, delta float64) (*domain.SparePart, error)
+}
+
+// CalendarRepository guarda o calendário de cada site: dias úteis, feriados e
+// paradas programadas. Dias úteis e gravações pedem escopo de um só site.
+type CalendarRepository interface {
+	// Settings devolve os dias úteis do site (o padrão se ainda não foram salvos).
+	Settings(ctx context.Context) (*domain.CalendarSettings, error)
+	SaveSettings(ctx context.Context, settings *domain.CalendarSettings) error
+	// UpsertHolidays grava os feriados; data já cadastrada no site tem o nome atualizado.
+	UpsertHolidays(ctx context.Context, holidays []domain.Holiday) error
+	FindHolidays(ctx context.Context) ([]domain.Holiday, error)
+	DeleteHoliday(ctx context.Context, id int64) error
+	CreateShutdown(ctx context.Context, shutdown *domain.Shutdown) error
+	FindShutdowns(ctx context.Context) ([]domain.Shutdown, error)
+	DeleteShutdown(ctx context.Context, id int64) error
+}
+
+type ShiftRepository interface {
+	Create(ctx context.Context, shift *domain.Shift) error
+	FindAll(ctx context.Context) ([]domain.Shift, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+// JobPlanRepository guarda os roteiros padrão (passos, medições e peças) das preventivas.
+type JobPlanRepository interface {
+	Create(ctx context.Context, jp *domain.JobPlan) error
+	Update(ctx context.Context, jp *domain.JobPlan) error
+	FindAll(ctx context.Context) ([]domain.JobPlan, error)
+	FindByID(ctx context.Context, id int64) (*domain.JobPlan, error)
+}
+
+// ChecklistRepository guarda o checklist de cada OS (cópia dos passos do roteiro).
+type ChecklistRepository interface {
+	Create(ctx context.Context, workOrderID int64, items []domain.ChecklistItem) error
+	FindByWorkOrder(ctx context.Context, workOrderID int64) ([]domain.ChecklistItem, error)
+	// UpdateItem grava o apontamento de um passo (ErrNotFound se o passo não existir).
+	// A corretiva vinculada ao passo não é trocada nem removida: ErrConflict se o
+	// passo já tiver outra follow_up_id.
+	UpdateItem(ctx context.Context, workOrderID int64, item *domain.ChecklistItem) error
+}
+
+// TechnicianRepository guarda os técnicos usados no planejamento de capacidade.
+type TechnicianRepository interface {
+	Create(ctx context.Context, t *domain.Technician) error
+	Update(ctx context.Context, t *domain.Technician) error
+	FindAll(ctx context.Context) ([]domain.Technician, error)
+	FindByID(ctx context.Context, id int64) (*domain.Technician, error)
+	// FindByUser devolve o técnico vinculado ao usuário (ErrNotFound se não houver).
+	FindByUser(ctx context.Context, userID int64) (*domain.Technician, error)
+}
+
+type ProductionRepository interface {
+	CreateBatch(ctx context.Context, counts []domain.ProductionCount) (int64, error)
+}
+
+// SignalRepository guarda os sinais de máquina recebidos pelo cmd/ingest.
+type SignalRepository interface {
+	CreateMeterReading(ctx context.Context, m *domain.MeterReading) error
+	// MeterReadingAt retorna a última leitura do ativo até at; sem leitura até lá,
+	// a primeira depois (ErrNotFound se o ativo não tiver leituras).
+	MeterReadingAt(ctx context.Context, assetID int64, at time.Time) (*domain.MeterReading, error)
+	CreateConditionReading(ctx context.Context, c *domain.ConditionReading) error
+}
+
+// ReportRepository concentra as consultas agregadas dos relatórios gerenciais.
+type ReportRepository interface {
+	// MonthlyDowntime agrupa quebras por ativo e mês (no fuso loc) em [from, to).
+	MonthlyDowntime(ctx context.Context, from, to time.Time, loc *time.Location) ([]domain.DowntimeReportRow, error)
+	// OEEInputs carrega ativos, turnos, paradas e apontamentos de [from, to); o cálculo fica no serviço.
+	OEEInputs(ctx context.Context, from, to time.Time, filter domain.OEEFilter) (*domain.OEEInputs, error)
+	// MonthlySLA consolida o cumprimento de SLA por mês de abertura (no fuso loc) em [from, to).
+	MonthlySLA(ctx context.Context, from, to time.Time, loc *time.Location, now time.Time) ([]domain.SLAReportRow, error)
+	// Pareto conta OS concluídas em [from, to) e soma suas paradas por modo de falha (sem ordenar).
+	Pareto(ctx context.Context, from, to time.Time, filter domain.ParetoFilter) (*domain.ParetoReport, error)
+}
+
+// IdempotencyRepository guarda as respostas dos POST com Idempotency-Key, por usuário.
+type IdempotencyRepository interface {
+	// Reserve grava rec como em andamento e devolve true; se a chave já existir
+	// e ainda não tiver expirado, devolve o registro atual e false.
+	Reserve(ctx context.Context, rec *domain.IdempotencyRecord) (*domain.IdempotencyRecord, bool, error)
+	// Complete grava a resposta e a retenção (rec.ExpiresAt) da chave reservada.
+	Complete(ctx context.Context, rec *domain.IdempotencyRecord) error
+	// Release apaga a reserva em andamento, liberando a chave para nova tentativa.
+	Release(ctx context.Context, userID int64, key string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// RateLimitRepository guarda os baldes do limitador de requisições. A versão em
+// memória vale por processo; a do Postgres divide a cota entre as réplicas.
+type RateLimitRepository interface {
+	// Take repõe as fichas do balde key pelo tempo decorrido e consome uma, se houver.
+	Take(ctx context.Context, key string, limit domain.RateLimit) (domain.RateLimitStatus, error)
+	// DeleteIdle apaga os baldes sem uso desde before (a essa altura, já cheios).
+	DeleteIdle(ctx context.Context, before time.Time) (int64, error)
+}
+
+// SyncRepository lê o delta da sincronização offline no escopo de um site (escopo
+// de todos os sites é ErrInvalidInput).
+type SyncRepository interface {
+	// Changes devolve até limit ativos, OS e passos de checklist com revisão maior
+	// que since, em ordem de revisão, lidos num mesmo instante. Passos só vêm de OS
+	// em aberto; com since = 0 (carga inicial) as OS fechadas também ficam de fora.
+	// Com technicianID, só vêm as OS atribuídas ao técnico (e seus passos), mais
+	// uma remoção (Deleted, só com o ID) para cada OS que saiu dele depois de since.
+	Changes(ctx context.Context, since, technicianID int64, limit int) ([]domain.SyncChange, error)
+	// WorkOrderChanges devolve até limit OS com revisão maior que since, em ordem de
+	// revisão, inclusive as fechadas (com since = 0, só as em aberto).
+	WorkOrderChanges(ctx context.Context, since int64, limit int) ([]domain.WorkOrder, error)
 }
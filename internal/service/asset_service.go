@@ -1,23 +1,177 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"maps"
+
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
 )
 
 type AssetService struct {
-	repo repository.AssetRepository
+	repo    repository.AssetRepository
+	classes repository.AssetClassRepository
 }
 
-func NewAssetService(r repository.AssetRepository) *AssetService {
-	return &AssetService{repo: r}
+// AssetOption injeta dependências opcionais do serviço de ativos.
+type AssetOption func(*AssetService)
+
+// WithAssetClasses valida a classe e os atributos customizados dos ativos.
+func WithAssetClasses(r repository.AssetClassRepository) AssetOption {
+	return func(s *AssetService) { s.classes = r }
 }
 
-func (s *AssetService) Create(asset *domain.Asset) error {
+func NewAssetService(r repository.AssetRepository, opts ...AssetOption) *AssetService {
+	s := &AssetService{repo: r}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *AssetService) Create(ctx context.Context, asset *domain.Asset) error {
+	ctx, span := tracer.Start(ctx, "AssetService.Create")
+	defer span.End()
+
 	asset.Normalize()
-	return s.repo.Create(asset)
+	if err := s.validate(ctx, asset); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, asset)
 }
 
-func (s *AssetService) List() ([]domain.Asset, error) {
-	return s.repo.FindAll()
+// validate confere as datas de placa e os atributos contra a definição da classe.
+func (s *AssetService) validate(ctx context.Context, a *domain.Asset) error {
+	if err := a.Validate(); err != nil {
+		return err
+	}
+	if a.Class == "" {
+		return nil
+	}
+	if s.classes == nil {
+		return errNotConfigured
+	}
+	class, err := s.classes.FindByCode(ctx, a.Class)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidInput
+	}
+	if err != nil {
+		return err
+	}
+	return class.CheckAttributes(a.Attributes)
+}
+
+// AssetPatch traz apenas os campos a alterar; nil mantém o valor atual.
+type AssetPatch struct {
+	Name             *string
+	Location         *string
+	Criticality      *domain.Criticality
+	ExternalCode     *string
+	IdealRatePerHour *float64 // 0 remove a cadência cadastrada
+	Manufacturer     *string
+	Model            *string
+	SerialNumber     *string
+	InstalledOn      *string // "" remove a data
+	WarrantyUntil    *string // "" remove a data
+	Class            *string
+	// Attributes é mesclado aos atuais; valor nil remove o atributo.
+	Attributes map[string]any
+}
+
+func (s *AssetService) Update(ctx context.Context, id int64, patch AssetPatch) (*domain.Asset, error) {
+	ctx, span := tracer.Start(ctx, "AssetService.Update")
+	defer span.End()
+
+	current, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	a := *current
+	if patch.Name != nil {
+		a.Name = *patch.Name
+	}
+	if patch.Location != nil {
+		a.Location = *patch.Location
+	}
+	if patch.Criticality != nil {
+		a.Criticality = *patch.Criticality
+	}
+	if patch.ExternalCode != nil {
+		a.ExternalCode = *patch.ExternalCode
+	}
+	if patch.IdealRatePerHour != nil {
+		a.IdealRatePerHour = nil
+		if *patch.IdealRatePerHour > 0 {
+			rate := *patch.IdealRatePerHour
+			a.IdealRatePerHour = &rate
+		}
+	}
+	if patch.Manufacturer != nil {
+		a.Manufacturer = *patch.Manufacturer
+	}
+	if patch.Model != nil {
+		a.Model = *patch.Model
+	}
+	if patch.SerialNumber != nil {
+		a.SerialNumber = *patch.SerialNumber
+	}
+	if patch.InstalledOn != nil {
+		a.InstalledOn = *patch.InstalledOn
+	}
+	if patch.WarrantyUntil != nil {
+		a.WarrantyUntil = *patch.WarrantyUntil
+	}
+	if patch.Class != nil {
+		a.Class = *patch.Class
+	}
+	if patch.Attributes != nil {
+		attrs := maps.Clone(a.Attributes)
+		if attrs == nil {
+			attrs = map[string]any{}
+		}
+		for k, v := range patch.Attributes {
+			if v == nil {
+				delete(attrs, k)
+				continue
+			}
+			attrs[k] = v
+		}
+		a.Attributes = attrs
+	}
+	a.Normalize()
+	if err := s.validate(ctx, &a); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *AssetService) Get(ctx context.Context, id int64) (*domain.Asset, error) {
+	ctx, span := tracer.Start(ctx, "AssetService.Get")
+	defer span.End()
+
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *AssetService) List(ctx context.Context, filter domain.AssetFilter) ([]domain.Asset, error) {
+	ctx, span := tracer.Start(ctx, "AssetService.List")
+	defer span.End()
+
+	var list []domain.Asset
+	err := s.repo.Stream(ctx, filter, func(a *domain.Asset) error {
+		list = append(list, *a)
+		return nil
+	})
+	return list, err
+}
+
+// Stream entrega os ativos um a um (exportações grandes sem acumular em memória).
+func (s *AssetService) Stream(ctx context.Context, filter domain.AssetFilter, fn func(*domain.Asset) error) error {
+	ctx, span := tracer.Start(ctx, "AssetService.Stream")
+	defer span.End()
+
+	return s.repo.Stream(ctx, filter, fn)
 }
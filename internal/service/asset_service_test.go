@@ -1,6 +1,7 @@
 package service_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
@@ -30,7 +31,7 @@ func TestAssetService_CreateAndList(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			a := tt.input
-			if err := svc.Create(&a); err != nil {
+			if err := svc.Create(onSite(1), &a); err != nil {
 				t.Fatalf("Create() error = %v", err)
 			}
 			if a.ID == 0 {
@@ -42,7 +43,7 @@ func TestAssetService_CreateAndList(t *testing.T) {
 		})
 	}
 
-	list, err := svc.List()
+	list, err := svc.List(onSite(1), domain.AssetFilter{})
 	if err != nil {
 		t.Fatalf("List() error = %v", err)
 	}
@@ -55,3 +56,60 @@ func TestAssetService_CreateAndList(t *testing.T) {
 		t.Fatalf("expected default criticality B, got %s", list[1].Criticality)
 	}
 }
+
+func TestAssetService_ClassAttributesAndFilter(t *testing.T) {
+	ctx := onSite(1)
+	classes := memory.NewAssetClassMemoryRepo()
+	svc := service.NewAssetService(memory.NewAssetMemoryRepo(), service.WithAssetClasses(classes))
+
+	maxVoltage := 690.0
+	motor := domain.AssetClass{Code: "Motor", Name: "Motor elétrico", Attributes: []domain.AttributeDef{
+		{Key: "voltage", Type: domain.AttrNumber, Unit: "V", Required: true, Max: &maxVoltage},
+		{Key: "enclosure", Type: domain.AttrEnum, Options: []string{"IP55", "IP65"}},
+	}}
+	if err := service.NewAssetClassService(classes).Create(ctx, &motor); err != nil {
+		t.Fatalf("create class: %v", err)
+	}
+
+	invalid := []domain.Asset{
+		{Name: "Sem tensão", Class: "motor", Attributes: map[string]any{"enclosure": "IP55"}},
+		{Name: "Tensão alta", Class: "motor", Attributes: map[string]any{"voltage": 1000.0}},
+		{Name: "Tensão texto", Class: "motor", Attributes: map[string]any{"voltage": "380"}},
+		{Name: "Chave extra", Class: "motor", Attributes: map[string]any{"voltage": 380.0, "rpm": 1750.0}},
+		{Name: "Classe inexistente", Class: "bomba"},
+		{Name: "Sem classe", Attributes: map[string]any{"voltage": 380.0}},
+		{Name: "Data ruim", InstalledOn: "2024-13-01"},
+	}
+	for _, a := range invalid {
+		if err := svc.Create(ctx, &a); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Fatalf("%s: expected ErrInvalidInput, got %v", a.Name, err)
+		}
+	}
+
+	m380 := domain.Asset{Name: "Motor 380", ExternalCode: "MT-01", Class: "motor", Manufacturer: "WEG",
+		InstalledOn: "2024-03-01", Attributes: map[string]any{"voltage": 380.0, "enclosure": "IP55"}}
+	m220 := domain.Asset{Name: "Motor 220", ExternalCode: "MT-02", Class: "motor", Attributes: map[string]any{"voltage": 220.0}}
+	for _, a := range []*domain.Asset{&m380, &m220} {
+		if err := svc.Create(ctx, a); err != nil {
+			t.Fatalf("create %s: %v", a.Name, err)
+		}
+	}
+	dup := domain.Asset{Name: "Duplicado", ExternalCode: "mt-01"}
+	if err := svc.Create(ctx, &dup); !errors.Is(err, domain.ErrAlreadyExists) {
+		t.Fatalf("duplicate tag: expected ErrAlreadyExists, got %v", err)
+	}
+
+	list, err := svc.List(ctx, domain.AssetFilter{Attributes: map[string]string{"voltage": "380"}})
+	if err != nil || len(list) != 1 || list[0].ID != m380.ID {
+		t.Fatalf("attr filter: %+v %v", list, err)
+	}
+
+	// null remove o atributo opcional; a tensão obrigatória continua exigida.
+	updated, err := svc.Update(ctx, m380.ID, service.AssetPatch{Attributes: map[string]any{"enclosure": nil}})
+	if err != nil || len(updated.Attributes) != 1 {
+		t.Fatalf("remove attribute: %+v %v", updated, err)
+	}
+	if _, err := svc.Update(ctx, m380.ID, service.AssetPatch{Attributes: map[string]any{"voltage": nil}}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("remove required attribute: expected ErrInvalidInput, got %v", err)
+	}
+}
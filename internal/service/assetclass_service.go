@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// AssetClassService mantém as classes de ativo e as definições de atributos.
+// Alterar a definição não revalida os ativos já cadastrados; a nova regra vale
+// na próxima gravação de cada ativo.
+type AssetClassService struct {
+	repo repository.AssetClassRepository
+}
+
+func NewAssetClassService(r repository.AssetClassRepository) *AssetClassService {
+	return &AssetClassService{repo: r}
+}
+
+func (s *AssetClassService) Create(ctx context.Context, c *domain.AssetClass) error {
+	ctx, span := tracer.Start(ctx, "AssetClassService.Create")
+	defer span.End()
+
+	c.Normalize()
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, c)
+}
+
+// Replace troca nome e atributos da classe identificada por c.Code.
+func (s *AssetClassService) Replace(ctx context.Context, c *domain.AssetClass) error {
+	ctx, span := tracer.Start(ctx, "AssetClassService.Replace")
+	defer span.End()
+
+	c.Normalize()
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, c)
+}
+
+func (s *AssetClassService) Get(ctx context.Context, code string) (*domain.AssetClass, error) {
+	ctx, span := tracer.Start(ctx, "AssetClassService.Get")
+	defer span.End()
+
+	return s.repo.FindByCode(ctx, code)
+}
+
+func (s *AssetClassService) List(ctx context.Context) ([]domain.AssetClass, error) {
+	ctx, span := tracer.Start(ctx, "AssetClassService.List")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
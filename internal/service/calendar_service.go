@@ -0,0 +1,229 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/ical"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// CalendarSource fornece o calendário da planta para quem calcula prazos em tempo útil.
+type CalendarSource interface {
+	// Calendar devolve o calendário do site da chamada (escopo de um só site).
+	Calendar(ctx context.Context) (*domain.Calendar, error)
+}
+
+// siteCalendar carrega o calendário do site do registro, que pode não ser o da
+// chamada (processos do sistema enxergam todos os sites).
+func siteCalendar(ctx context.Context, source CalendarSource, site int64) (*domain.Calendar, error) {
+	ctx, err := tenant.OnSite(ctx, site)
+	if err != nil {
+		return nil, err
+	}
+	return source.Calendar(ctx)
+}
+
+// calendarCache guarda os calendários já carregados numa varredura que passa
+// por vários sites.
+type calendarCache struct {
+	source CalendarSource
+	sites  map[int64]*domain.Calendar
+}
+
+func newCalendarCache(source CalendarSource) *calendarCache {
+	return &calendarCache{source: source, sites: map[int64]*domain.Calendar{}}
+}
+
+func (c *calendarCache) site(ctx context.Context, site int64) (*domain.Calendar, error) {
+	if cal, ok := c.sites[site]; ok {
+		return cal, nil
+	}
+	cal, err := siteCalendar(ctx, c.source, site)
+	if err != nil {
+		return nil, err
+	}
+	c.sites[site] = cal
+	return cal, nil
+}
+
+// CalendarService mantém o calendário de cada planta (dias úteis, feriados,
+// paradas programadas) e monta o domain.Calendar junto com os turnos da planta.
+type CalendarService struct {
+	repo   repository.CalendarRepository
+	shifts repository.ShiftRepository
+}
+
+func NewCalendarService(r repository.CalendarRepository, shifts repository.ShiftRepository) *CalendarService {
+	return &CalendarService{repo: r, shifts: shifts}
+}
+
+// Calendar carrega o calendário inteiro do site da chamada; turnos de linha (com
+// Location) ficam de fora.
+func (s *CalendarService) Calendar(ctx context.Context) (*domain.Calendar, error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.Calendar")
+	defer span.End()
+
+	settings, err := s.repo.Settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := s.repo.FindHolidays(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shutdowns, err := s.repo.FindShutdowns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	shifts, err := s.shifts.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cal := &domain.Calendar{
+		Location:    plant.Location(),
+		WorkingDays: settings.WorkingDays,
+		Holidays:    holidays,
+		Shutdowns:   shutdowns,
+	}
+	for _, sh := range shifts {
+		if sh.Location == "" {
+			cal.Shifts = append(cal.Shifts, sh)
+		}
+	}
+	return cal, nil
+}
+
+// Days detalha dia a dia o tempo útil de [from, to).
+func (s *CalendarService) Days(ctx context.Context, from, to time.Time) ([]domain.CalendarDay, error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.Days")
+	defer span.End()
+
+	if !from.Before(to) || to.Sub(from) > maxCalendarPeriod {
+		return nil, domain.ErrInvalidInput
+	}
+	cal, err := s.Calendar(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cal.Days(from, to), nil
+}
+
+// maxCalendarPeriod limita consultas dia a dia (um ano e pouco).
+const maxCalendarPeriod = 370 * 24 * time.Hour
+
+func (s *CalendarService) Settings(ctx context.Context) (*domain.CalendarSettings, error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.Settings")
+	defer span.End()
+
+	return s.repo.Settings(ctx)
+}
+
+func (s *CalendarService) SaveSettings(ctx context.Context, settings *domain.CalendarSettings) error {
+	ctx, span := tracer.Start(ctx, "CalendarService.SaveSettings")
+	defer span.End()
+
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	return s.repo.SaveSettings(ctx, settings)
+}
+
+func (s *CalendarService) ListHolidays(ctx context.Context) ([]domain.Holiday, error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.ListHolidays")
+	defer span.End()
+
+	return s.repo.FindHolidays(ctx)
+}
+
+// AddHoliday cadastra o feriado; se a data já existir, só o nome muda.
+func (s *CalendarService) AddHoliday(ctx context.Context, h *domain.Holiday) error {
+	ctx, span := tracer.Start(ctx, "CalendarService.AddHoliday")
+	defer span.End()
+
+	if err := h.Validate(); err != nil {
+		return err
+	}
+	holidays := []domain.Holiday{*h}
+	if err := s.repo.UpsertHolidays(ctx, holidays); err != nil {
+		return err
+	}
+	*h = holidays[0]
+	return nil
+}
+
+// ImportHolidays lê um arquivo iCalendar (.ics) e grava cada dia dos eventos como feriado.
+// Eventos de vários dias viram um feriado por dia; datas já cadastradas têm o nome atualizado.
+func (s *CalendarService) ImportHolidays(ctx context.Context, r io.Reader) ([]domain.Holiday, error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.ImportHolidays")
+	defer span.End()
+
+	loc := plant.Location()
+	events, err := ical.Parse(r, loc)
+	if err != nil {
+		return nil, domain.ErrInvalidInput
+	}
+
+	byDate := map[string]int{}
+	var holidays []domain.Holiday
+	for _, e := range events {
+		name := strings.TrimSpace(e.Summary)
+		if name == "" {
+			continue
+		}
+		start := e.Start.In(loc)
+		day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+		for first := true; first || day.Before(e.End); day, first = day.AddDate(0, 0, 1), false {
+			h := domain.Holiday{Date: day.Format(time.DateOnly), Name: name}
+			if i, dup := byDate[h.Date]; dup {
+				holidays[i] = h
+				continue
+			}
+			byDate[h.Date] = len(holidays)
+			holidays = append(holidays, h)
+		}
+	}
+	if len(holidays) == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	if err := s.repo.UpsertHolidays(ctx, holidays); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+func (s *CalendarService) DeleteHoliday(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "CalendarService.DeleteHoliday")
+	defer span.End()
+
+	return s.repo.DeleteHoliday(ctx, id)
+}
+
+func (s *CalendarService) ListShutdowns(ctx context.Context) ([]domain.Shutdown, error) {
+	ctx, span := tracer.Start(ctx, "CalendarService.ListShutdowns")
+	defer span.End()
+
+	return s.repo.FindShutdowns(ctx)
+}
+
+func (s *CalendarService) AddShutdown(ctx context.Context, sd *domain.Shutdown) error {
+	ctx, span := tracer.Start(ctx, "CalendarService.AddShutdown")
+	defer span.End()
+
+	if err := sd.Validate(); err != nil {
+		return err
+	}
+	return s.repo.CreateShutdown(ctx, sd)
+}
+
+func (s *CalendarService) DeleteShutdown(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "CalendarService.DeleteShutdown")
+	defer span.End()
+
+	return s.repo.DeleteShutdown(ctx, id)
+}
@@ -0,0 +1,232 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+const holidaysICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:proclamacao@feriados\r\n" +
+	"DTSTART;VALUE=DATE:20251117\r\n" +
+	"DTEND;VALUE=DATE:20251118\r\n" +
+	"SUMMARY:Feriado municipal\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestCalendarService_WorkingTimeSkipsWeekendHolidayAndShutdown(t *testing.T) {
+	ctx := onSite(1)
+	loc := plant.Location()
+	shifts := memory.NewShiftMemoryRepo()
+	svc := service.NewCalendarService(memory.NewCalendarMemoryRepo(), shifts)
+
+	// Turno administrativo de segunda a sábado; o sábado fica fora dos dias úteis.
+	if err := shifts.Create(ctx, &domain.Shift{Name: "ADM", StartTime: "08:00", EndTime: "17:00", Weekdays: []int{1, 2, 3, 4, 5, 6}}); err != nil {
+		t.Fatalf("create shift: %v", err)
+	}
+	holidays, err := svc.ImportHolidays(ctx, strings.NewReader(holidaysICS))
+	if err != nil || len(holidays) != 1 || holidays[0].Date != "2025-11-17" {
+		t.Fatalf("ImportHolidays() = %+v, %v", holidays, err)
+	}
+	shutdown := domain.Shutdown{
+		StartsAt: time.Date(2025, 11, 18, 8, 0, 0, 0, loc),
+		EndsAt:   time.Date(2025, 11, 18, 12, 0, 0, 0, loc),
+		Reason:   "Troca do transformador",
+	}
+	if err := svc.AddShutdown(ctx, &shutdown); err != nil {
+		t.Fatalf("AddShutdown() error = %v", err)
+	}
+
+	cal, err := svc.Calendar(ctx)
+	if err != nil {
+		t.Fatalf("Calendar() error = %v", err)
+	}
+	// Sexta 16h + 2h úteis: 1h na sexta, segunda é feriado, terça só depois da parada.
+	friday := time.Date(2025, 11, 14, 16, 0, 0, 0, loc)
+	if got, want := cal.AddWorkingTime(friday, 2*time.Hour), time.Date(2025, 11, 18, 13, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("AddWorkingTime() = %v, want %v", got, want)
+	}
+	saturday := time.Date(2025, 11, 15, 9, 0, 0, 0, loc)
+	if got, want := cal.NextWorkingTime(saturday), time.Date(2025, 11, 18, 12, 0, 0, 0, loc); !got.Equal(want) {
+		t.Fatalf("NextWorkingTime() = %v, want %v", got, want)
+	}
+
+	days, err := svc.Days(ctx, friday, time.Date(2025, 11, 19, 0, 0, 0, 0, loc))
+	if err != nil || len(days) != 5 {
+		t.Fatalf("Days() = %+v, %v", days, err)
+	}
+	if !days[0].Working || days[1].Working || days[2].Working || days[3].Working || days[3].Holiday != "Feriado municipal" {
+		t.Fatalf("unexpected days: %+v", days)
+	}
+	if len(days[4].Windows) != 1 || days[4].Windows[0].Start.In(loc).Hour() != 12 {
+		t.Fatalf("shutdown should cut the morning of %s: %+v", days[4].Date, days[4].Windows)
+	}
+
+	if _, err := svc.ImportHolidays(ctx, strings.NewReader("not a calendar")); err != domain.ErrInvalidInput {
+		t.Fatalf("invalid ics expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestPreventiveScheduler_GeneratesOrdersInWorkingTime(t *testing.T) {
+	ctx := onSite(1)
+	loc := plant.Location()
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	plans := memory.NewMaintenancePlanMemoryRepo()
+	calendar := service.NewCalendarService(memory.NewCalendarMemoryRepo(), memory.NewShiftMemoryRepo())
+	workOrders := service.NewWorkOrderService(orders,
+		service.WithAssets(assets),
+		service.WithSLA(memory.NewSLAMemoryRepo()),
+		service.WithCalendar(calendar),
+		service.WithPlans(plans),
+	)
+
+	asset := domain.Asset{Name: "Bomba de recalque", Criticality: domain.CriticalityC}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+
+	// Vencimento no próximo sábado: a OS deve ser programada para a segunda seguinte.
+	now := time.Now().In(loc)
+	saturday := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, loc)
+	for saturday.Weekday() != time.Saturday {
+		saturday = saturday.AddDate(0, 0, 1)
+	}
+	monday := time.Date(saturday.Year(), saturday.Month(), saturday.Day()+2, 0, 0, 0, 0, loc)
+	freq := int64(30)
+	last := saturday.AddDate(0, 0, -30)
+	plan := domain.MaintenancePlan{AssetID: asset.ID, RuleType: domain.PlanRuleTime, FrequencyDays: &freq, LastExecution: &last, Active: true}
+	if err := plans.Create(ctx, &plan); err != nil {
+		t.Fatalf("create plan: %v", err)
+	}
+
+	scheduler := service.NewPreventiveScheduler(plans, orders, workOrders, calendar, time.Hour, 10*24*time.Hour)
+	created, err := scheduler.Check(ctx)
+	if err != nil || len(created) != 1 {
+		t.Fatalf("Check() = %+v, %v", created, err)
+	}
+	wo := created[0]
+	if wo.PlanID == nil || *wo.PlanID != plan.ID || wo.ScheduledFor == nil || !wo.ScheduledFor.Equal(monday) {
+		t.Fatalf("expected order for plan %d scheduled on %v, got %+v", plan.ID, monday, wo)
+	}
+	// Conclusão em 14 dias úteis (dias inteiros, sem turnos) contados da data programada.
+	if want := monday.AddDate(0, 0, 18); wo.ResolutionDueAt == nil || !wo.ResolutionDueAt.Equal(want) {
+		t.Fatalf("resolution due = %v, want %v", wo.ResolutionDueAt, want)
+	}
+	if again, err := scheduler.Check(ctx); err != nil || len(again) != 0 {
+		t.Fatalf("second Check() should not duplicate the order: %+v, %v", again, err)
+	}
+
+	forecast, err := scheduler.Forecast(ctx, asset.ID, now, now.AddDate(0, 0, 70))
+	if err != nil || len(forecast) < 2 {
+		t.Fatalf("Forecast() = %+v, %v", forecast, err)
+	}
+	if forecast[0].WorkOrderID == nil || *forecast[0].WorkOrderID != wo.ID || forecast[1].WorkOrderID != nil {
+		t.Fatalf("forecast should start with the generated order: %+v", forecast)
+	}
+	if wd := forecast[1].ScheduledFor.In(loc).Weekday(); wd == time.Saturday || wd == time.Sunday {
+		t.Fatalf("projected occurrence on a weekend: %v", forecast[1].ScheduledFor)
+	}
+
+	if _, err := workOrders.Transition(ctx, wo.ID, service.TransitionRequest{Status: domain.WOStatusDone}); err != nil {
+		t.Fatalf("close preventive: %v", err)
+	}
+	updated, err := plans.FindByID(ctx, plan.ID)
+	if err != nil || updated.LastExecution == nil || !updated.LastExecution.After(last) {
+		t.Fatalf("closing the order should register the plan execution: %+v, %v", updated, err)
+	}
+}
+
+func TestCalendarService_PerSite(t *testing.T) {
+	site1, site2 := onSite(1), onSite(2)
+	loc := plant.Location()
+	calendar := service.NewCalendarService(memory.NewCalendarMemoryRepo(), memory.NewShiftMemoryRepo())
+
+	// Site 2 trabalha aos sábados; o site 1 fica no padrão (segunda a sexta).
+	if err := calendar.SaveSettings(site2, &domain.CalendarSettings{WorkingDays: []int{1, 2, 3, 4, 5, 6}}); err != nil {
+		t.Fatalf("SaveSettings(site 2) error = %v", err)
+	}
+	if s, _ := calendar.Settings(site1); len(s.WorkingDays) != 5 {
+		t.Errorf("site 1 working days = %v, want the default", s.WorkingDays)
+	}
+	holiday := domain.Holiday{Date: "2025-11-17", Name: "Feriado municipal"}
+	if err := calendar.AddHoliday(site1, &holiday); err != nil {
+		t.Fatalf("AddHoliday() error = %v", err)
+	}
+	shutdown := domain.Shutdown{StartsAt: time.Date(2025, 11, 18, 8, 0, 0, 0, loc), EndsAt: time.Date(2025, 11, 18, 12, 0, 0, 0, loc), Reason: "Transformador"}
+	if err := calendar.AddShutdown(site1, &shutdown); err != nil {
+		t.Fatalf("AddShutdown() error = %v", err)
+	}
+
+	if list, _ := calendar.ListHolidays(site2); len(list) != 0 {
+		t.Errorf("site 2 sees %d holidays, want 0", len(list))
+	}
+	if list, _ := calendar.ListShutdowns(site2); len(list) != 0 {
+		t.Errorf("site 2 sees %d shutdowns, want 0", len(list))
+	}
+	if err := calendar.DeleteHoliday(site2, holiday.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("delete holiday from site 2: err = %v, want ErrNotFound", err)
+	}
+	if err := calendar.DeleteShutdown(site2, shutdown.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("delete shutdown from site 2: err = %v, want ErrNotFound", err)
+	}
+	// A mesma data no site 2 é outro feriado e não renomeia o do site 1.
+	other := domain.Holiday{Date: holiday.Date, Name: "Aniversário da cidade"}
+	if err := calendar.AddHoliday(site2, &other); err != nil || other.ID == holiday.ID {
+		t.Fatalf("AddHoliday(site 2) = %+v, %v; want a new holiday", other, err)
+	}
+	if list, _ := calendar.ListHolidays(site1); len(list) != 1 || list[0].Name != holiday.Name {
+		t.Errorf("site 1 holidays = %+v, want the original name", list)
+	}
+	if _, err := calendar.Calendar(tenant.System(context.Background())); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("Calendar() without a single site: err = %v, want ErrInvalidInput", err)
+	}
+
+	// O agendador (todos os sites) usa o calendário do site de cada plano: vencimento
+	// no sábado vira segunda no site 1 e fica no sábado no site 2.
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	plans := memory.NewMaintenancePlanMemoryRepo()
+	workOrders := service.NewWorkOrderService(orders, service.WithAssets(assets), service.WithCalendar(calendar), service.WithPlans(plans))
+	now := time.Now().In(loc)
+	saturday := time.Date(now.Year(), now.Month(), now.Day()+1, 10, 0, 0, 0, loc)
+	for saturday.Weekday() != time.Saturday {
+		saturday = saturday.AddDate(0, 0, 1)
+	}
+	freq := int64(30)
+	last := saturday.AddDate(0, 0, -30)
+	for _, ctx := range []context.Context{site1, site2} {
+		asset := domain.Asset{Name: "Bomba"}
+		if err := assets.Create(ctx, &asset); err != nil {
+			t.Fatalf("create asset: %v", err)
+		}
+		plan := domain.MaintenancePlan{AssetID: asset.ID, RuleType: domain.PlanRuleTime, FrequencyDays: &freq, LastExecution: &last, Active: true}
+		if err := plans.Create(ctx, &plan); err != nil {
+			t.Fatalf("create plan: %v", err)
+		}
+	}
+	scheduler := service.NewPreventiveScheduler(plans, orders, workOrders, calendar, time.Hour, 10*24*time.Hour)
+	created, err := scheduler.Check(tenant.System(context.Background()))
+	if err != nil || len(created) != 2 {
+		t.Fatalf("Check() = %+v, %v", created, err)
+	}
+	for _, wo := range created {
+		want := time.Saturday
+		if wo.SiteID == 1 {
+			want = time.Monday
+		}
+		if got := wo.ScheduledFor.In(loc).Weekday(); got != want {
+			t.Errorf("site %d order scheduled on %v, want %v", wo.SiteID, got, want)
+		}
+	}
+}
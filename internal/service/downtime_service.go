@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// DowntimeService registra paradas de ativos e mantém o DowntimeMinutes das OS
+// vinculadas como soma das paradas encerradas.
+type DowntimeService struct {
+	events repository.DowntimeRepository
+	assets repository.AssetRepository
+	orders repository.WorkOrderRepository
+	now    func() time.Time
+}
+
+func NewDowntimeService(events repository.DowntimeRepository, assets repository.AssetRepository, orders repository.WorkOrderRepository) *DowntimeService {
+	return &DowntimeService{events: events, assets: assets, orders: orders, now: time.Now}
+}
+
+// Start abre uma parada (ou registra uma já encerrada, se EndedAt vier preenchido).
+// Rejeita paradas sobrepostas no mesmo ativo com ErrConflict.
+func (s *DowntimeService) Start(ctx context.Context, ev *domain.DowntimeEvent) error {
+	ctx, span := tracer.Start(ctx, "DowntimeService.Start")
+	defer span.End()
+
+	if _, err := s.assets.FindByID(ctx, ev.AssetID); err != nil {
+		return err
+	}
+	now := s.now()
+	if ev.StartedAt.IsZero() {
+		ev.StartedAt = now
+	}
+	if ev.StartedAt.After(now) || ev.ReasonCode == "" {
+		return domain.ErrInvalidInput
+	}
+	if ev.EndedAt != nil && (!ev.EndedAt.After(ev.StartedAt) || ev.EndedAt.After(now)) {
+		return domain.ErrInvalidInput
+	}
+	if err := s.checkWorkOrder(ctx, ev); err != nil {
+		return err
+	}
+	if err := s.checkOverlap(ctx, ev); err != nil {
+		return err
+	}
+
+	if err := s.events.Create(ctx, ev); err != nil {
+		return err
+	}
+	return s.syncWorkOrder(ctx, ev.WorkOrderID)
+}
+
+// Stop encerra a parada em andamento do ativo. workOrderID (opcional) vincula
+// a parada a uma OS aberta durante o atendimento.
+func (s *DowntimeService) Stop(ctx context.Context, assetID int64, endedAt *time.Time, workOrderID *int64) (*domain.DowntimeEvent, error) {
+	ctx, span := tracer.Start(ctx, "DowntimeService.Stop")
+	defer span.End()
+
+	// As paradas não têm site próprio: o escopo vem do ativo.
+	if _, err := s.assets.FindByID(ctx, assetID); err != nil {
+		return nil, err
+	}
+	ev, err := s.events.FindOpenByAsset(ctx, assetID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrPrecondition // nenhuma parada em andamento
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	end := s.now()
+	if endedAt != nil {
+		end = *endedAt
+	}
+	if !end.After(ev.StartedAt) || end.After(s.now()) {
+		return nil, domain.ErrInvalidInput
+	}
+	ev.EndedAt = &end
+
+	previousWO := ev.WorkOrderID
+	if workOrderID != nil {
+		ev.WorkOrderID = workOrderID
+		if err := s.checkWorkOrder(ctx, ev); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkOverlap(ctx, ev); err != nil {
+		return nil, err
+	}
+
+	if err := s.events.Update(ctx, ev); err != nil {
+		return nil, err
+	}
+	if previousWO != nil && (ev.WorkOrderID == nil || *previousWO != *ev.WorkOrderID) {
+		if err := s.syncWorkOrder(ctx, previousWO); err != nil {
+			return nil, err
+		}
+	}
+	return ev, s.syncWorkOrder(ctx, ev.WorkOrderID)
+}
+
+// List retorna as paradas do ativo iniciadas em [from, to).
+func (s *DowntimeService) List(ctx context.Context, assetID int64, from, to *time.Time) ([]domain.DowntimeEvent, error) {
+	ctx, span := tracer.Start(ctx, "DowntimeService.List")
+	defer span.End()
+
+	if _, err := s.assets.FindByID(ctx, assetID); err != nil {
+		return nil, err
+	}
+	return s.events.FindByAsset(ctx, assetID, from, to)
+}
+
+// ListByAssets é o List de vários ativos: ativos fora do escopo do contexto
+// são ignorados, e a resposta traz as paradas agrupadas por ativo.
+func (s *DowntimeService) ListByAssets(ctx context.Context, assetIDs []int64, from, to *time.Time) (map[int64][]domain.DowntimeEvent, error) {
+	ctx, span := tracer.Start(ctx, "DowntimeService.ListByAssets")
+	defer span.End()
+
+	if len(assetIDs) == 0 {
+		return nil, nil
+	}
+	var visible []int64
+	err := s.assets.Stream(ctx, domain.AssetFilter{IDs: assetIDs}, func(a *domain.Asset) error {
+		visible = append(visible, a.ID)
+		return nil
+	})
+	if err != nil || len(visible) == 0 {
+		return nil, err
+	}
+	events, err := s.events.FindByAssets(ctx, visible, from, to)
+	if err != nil {
+		return nil, err
+	}
+	byAsset := make(map[int64][]domain.DowntimeEvent, len(visible))
+	for _, e := range events {
+		byAsset[e.AssetID] = append(byAsset[e.AssetID], e)
+	}
+	return byAsset, nil
+}
+
+// checkWorkOrder garante que a OS vinculada existe e é do mesmo ativo.
+func (s *DowntimeService) checkWorkOrder(ctx context.Context, ev *domain.DowntimeEvent) error {
+	if ev.WorkOrderID == nil {
+		return nil
+	}
+	wo, err := s.orders.FindByID(ctx, *ev.WorkOrderID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidInput
+	}
+	if err != nil {
+		return err
+	}
+	if wo.AssetID != ev.AssetID {
+		return domain.ErrInvalidInput
+	}
+	return nil
+}
+
+func (s *DowntimeService) checkOverlap(ctx context.Context, ev *domain.DowntimeEvent) error {
+	others, err := s.events.FindOverlapping(ctx, ev.AssetID, ev.StartedAt, ev.EndedAt, ev.ID)
+	if err != nil {
+		return err
+	}
+	if len(others) > 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+// syncWorkOrder recalcula o DowntimeMinutes da OS a partir das paradas encerradas.
+func (s *DowntimeService) syncWorkOrder(ctx context.Context, workOrderID *int64) error {
+	if workOrderID == nil {
+		return nil
+	}
+	events, err := s.events.FindByWorkOrder(ctx, *workOrderID)
+	if err != nil {
+		return err
+	}
+
+	var total *int64
+	for i := range events {
+		if events[i].IsOpen() {
+			continue
+		}
+		m := events[i].Minutes(s.now())
+		if total == nil {
+			total = new(int64)
+		}
+		*total += m
+	}
+	return s.orders.SetDowntimeMinutes(ctx, *workOrderID, total)
+}
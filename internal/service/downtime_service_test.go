@@ -0,0 +1,62 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+func TestDowntimeService_OverlapAndDerivedMinutes(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	svc := service.NewDowntimeService(memory.NewDowntimeMemoryRepo(assets), assets, orders)
+
+	asset := domain.Asset{Name: "Prensa 01", Location: "Linha 1", Criticality: "high"}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	wo := domain.WorkOrder{AssetID: asset.ID, Type: domain.WOTypeCorrective, Status: domain.WOStatusOpen, Title: "Quebra"}
+	if err := orders.Create(ctx, &wo); err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	start := time.Now().Add(-3 * time.Hour).Truncate(time.Minute)
+	end := start.Add(45 * time.Minute)
+	first := domain.DowntimeEvent{AssetID: asset.ID, WorkOrderID: &wo.ID, ReasonCode: "MEC", StartedAt: start, EndedAt: &end}
+	if err := svc.Start(ctx, &first); err != nil {
+		t.Fatalf("Start(first) error = %v", err)
+	}
+
+	// Sobreposta à primeira → conflito.
+	overlapEnd := end.Add(10 * time.Minute)
+	overlap := domain.DowntimeEvent{AssetID: asset.ID, ReasonCode: "ELE", StartedAt: start.Add(30 * time.Minute), EndedAt: &overlapEnd}
+	if err := svc.Start(ctx, &overlap); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("Start(overlap) error = %v, want ErrConflict", err)
+	}
+
+	// Parada em andamento encerrada com vínculo à mesma OS.
+	second := domain.DowntimeEvent{AssetID: asset.ID, ReasonCode: "MEC", StartedAt: start.Add(time.Hour)}
+	if err := svc.Start(ctx, &second); err != nil {
+		t.Fatalf("Start(second) error = %v", err)
+	}
+	stopAt := second.StartedAt.Add(30 * time.Minute)
+	if _, err := svc.Stop(ctx, asset.ID, &stopAt, &wo.ID); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if _, err := svc.Stop(ctx, asset.ID, nil, nil); !errors.Is(err, domain.ErrPrecondition) {
+		t.Fatalf("Stop() without open event error = %v, want ErrPrecondition", err)
+	}
+
+	got, err := orders.FindByID(ctx, wo.ID)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	if got.DowntimeMinutes == nil || *got.DowntimeMinutes != 75 {
+		t.Fatalf("expected derived downtime 75 min, got %v", got.DowntimeMinutes)
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// FailureCodeService mantém o catálogo de falhas (modo → causa → ação).
+type FailureCodeService struct {
+	repo repository.FailureCodeRepository
+}
+
+func NewFailureCodeService(r repository.FailureCodeRepository) *FailureCodeService {
+	return &FailureCodeService{repo: r}
+}
+
+// Create valida o nível e o pai: causa sob modo, ação sob causa, modo na raiz.
+func (s *FailureCodeService) Create(ctx context.Context, fc *domain.FailureCode) error {
+	ctx, span := tracer.Start(ctx, "FailureCodeService.Create")
+	defer span.End()
+
+	fc.Code = strings.ToUpper(strings.TrimSpace(fc.Code))
+	fc.Name = strings.TrimSpace(fc.Name)
+	if fc.Code == "" || fc.Name == "" || !fc.Level.Valid() {
+		return domain.ErrInvalidInput
+	}
+
+	parentLevel, needsParent := fc.Level.ParentLevel()
+	switch {
+	case !needsParent && fc.ParentID != nil, needsParent && fc.ParentID == nil:
+		return domain.ErrInvalidInput
+	case needsParent:
+		parent, err := s.repo.FindByID(ctx, *fc.ParentID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidInput
+		}
+		if err != nil {
+			return err
+		}
+		if parent.Level != parentLevel {
+			return domain.ErrInvalidInput
+		}
+	}
+
+	fc.Active = true
+	return s.repo.Create(ctx, fc)
+}
+
+// FailureCodePatch traz apenas os campos a alterar; nil mantém o valor atual.
+type FailureCodePatch struct {
+	Name   *string
+	Active *bool
+}
+
+func (s *FailureCodeService) Update(ctx context.Context, id int64, patch FailureCodePatch) (*domain.FailureCode, error) {
+	ctx, span := tracer.Start(ctx, "FailureCodeService.Update")
+	defer span.End()
+
+	fc, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if patch.Name != nil {
+		if fc.Name = strings.TrimSpace(*patch.Name); fc.Name == "" {
+			return nil, domain.ErrInvalidInput
+		}
+	}
+	if patch.Active != nil {
+		fc.Active = *patch.Active
+	}
+	if err := s.repo.Update(ctx, fc); err != nil {
+		return nil, err
+	}
+	return fc, nil
+}
+
+// List retorna o catálogo plano; activeOnly esconde os códigos desativados.
+func (s *FailureCodeService) List(ctx context.Context, activeOnly bool) ([]domain.FailureCode, error) {
+	ctx, span := tracer.Start(ctx, "FailureCodeService.List")
+	defer span.End()
+
+	all, err := s.repo.FindAll(ctx)
+	if err != nil || !activeOnly {
+		return all, err
+	}
+	list := make([]domain.FailureCode, 0, len(all))
+	for _, fc := range all {
+		if fc.Active {
+			list = append(list, fc)
+		}
+	}
+	return list, nil
+}
+
+// Tree retorna o catálogo em árvore. Com activeOnly, um filho só aparece se toda a cadeia estiver ativa.
+func (s *FailureCodeService) Tree(ctx context.Context, activeOnly bool) ([]domain.FailureCodeNode, error) {
+	list, err := s.List(ctx, activeOnly)
+	if err != nil {
+		return nil, err
+	}
+	return domain.BuildFailureTree(list), nil
+}
@@ -0,0 +1,23 @@
+package service
+
+import "context"
+
+// Beater recebe o resultado de cada ciclo dos workers em background (ex:
+// health.Heartbeat), para o readiness acusar worker travado, parado ou
+// falhando em todo ciclo.
+type Beater interface {
+	Beat()
+	Fail(err error)
+}
+
+// beat registra o ciclo: Beat sem erro, Fail com erro. Cancelamento do
+// contexto (desligamento) não conta como falha.
+func beat(ctx context.Context, hb Beater, err error) {
+	switch {
+	case hb == nil || ctx.Err() != nil:
+	case err != nil:
+		hb.Fail(err)
+	default:
+		hb.Beat()
+	}
+}
@@ -0,0 +1,51 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/health"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+var errDBDown = errors.New("db down")
+
+// brokenPurge falha toda limpeza, como um banco fora do ar.
+type brokenPurge struct {
+	*memory.IdempotencyMemoryRepo
+}
+
+func (brokenPurge) DeleteExpired(context.Context, time.Time) (int64, error) {
+	return 0, errDBDown
+}
+
+func TestWorker_FailingCyclesFailHeartbeat(t *testing.T) {
+	var hb health.Heartbeat
+	svc := service.NewIdempotencyService(brokenPurge{memory.NewIdempotencyMemoryRepo()})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		svc.RunPurge(ctx, time.Millisecond, &hb)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	// O loop continua rodando, mas nenhum ciclo dá certo: o check não pode passar.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		err := hb.Check(time.Hour)(context.Background())
+		if errors.Is(err, errDBDown) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Check() = %v, want the purge error after %d failed cycles", err, health.MaxFailures)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
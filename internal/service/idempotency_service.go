@@ -0,0 +1,125 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// IdempotencyService controla as Idempotency-Key dos POST: o primeiro pedido
+// reserva a chave e executa; as repetições recebem a resposta gravada. Uma
+// repetição que chega enquanto o primeiro ainda roda espera por ele.
+type IdempotencyService struct {
+	repo  repository.IdempotencyRepository
+	ttl   time.Duration // retenção da resposta
+	lease time.Duration // prazo da reserva; depois disso a chave é considerada abandonada
+	wait  time.Duration // quanto uma repetição espera o pedido em andamento
+	poll  time.Duration
+	now   func() time.Time
+}
+
+// IdempotencyOption ajusta os prazos do serviço.
+type IdempotencyOption func(*IdempotencyService)
+
+// WithIdempotencyTTL define por quanto tempo a resposta fica disponível para repetição.
+func WithIdempotencyTTL(ttl time.Duration) IdempotencyOption {
+	return func(s *IdempotencyService) { s.ttl = ttl }
+}
+
+// WithIdempotencyWait define quanto uma repetição espera o pedido em andamento
+// antes de desistir com 409.
+func WithIdempotencyWait(wait, poll time.Duration) IdempotencyOption {
+	return func(s *IdempotencyService) { s.wait, s.poll = wait, poll }
+}
+
+func NewIdempotencyService(repo repository.IdempotencyRepository, opts ...IdempotencyOption) *IdempotencyService {
+	s := &IdempotencyService{
+		repo:  repo,
+		ttl:   24 * time.Hour,
+		lease: time.Minute,
+		wait:  30 * time.Second,
+		poll:  100 * time.Millisecond,
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Begin reserva a chave do usuário para a requisição identificada por hash.
+// Retorna nil quando o chamador deve executá-la (e depois chamar Complete ou
+// Release) e o registro gravado quando é uma repetição. Mesma chave com outra
+// requisição dá ErrKeyReused; se o pedido original não terminar a tempo, ErrConflict.
+func (s *IdempotencyService) Begin(ctx context.Context, userID int64, key, hash string) (*domain.IdempotencyRecord, error) {
+	ctx, span := tracer.Start(ctx, "IdempotencyService.Begin")
+	defer span.End()
+
+	deadline := s.now().Add(s.wait)
+	for {
+		rec := &domain.IdempotencyRecord{UserID: userID, Key: key, RequestHash: hash, ExpiresAt: s.now().Add(s.lease)}
+		cur, reserved, err := s.repo.Reserve(ctx, rec)
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return nil, nil
+		}
+		if cur.RequestHash != hash {
+			return nil, domain.ErrKeyReused
+		}
+		if cur.Done() {
+			return cur, nil
+		}
+		if !s.now().Before(deadline) {
+			return nil, domain.ErrConflict
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(s.poll):
+		}
+	}
+}
+
+// Complete grava a resposta da chave reservada por Begin.
+func (s *IdempotencyService) Complete(ctx context.Context, rec *domain.IdempotencyRecord) error {
+	ctx, span := tracer.Start(ctx, "IdempotencyService.Complete")
+	defer span.End()
+
+	rec.ExpiresAt = s.now().Add(s.ttl)
+	return s.repo.Complete(ctx, rec)
+}
+
+// Release libera a chave sem gravar resposta (falha do servidor), para o
+// cliente poder repetir a requisição.
+func (s *IdempotencyService) Release(ctx context.Context, userID int64, key string) error {
+	ctx, span := tracer.Start(ctx, "IdempotencyService.Release")
+	defer span.End()
+
+	return s.repo.Release(ctx, userID, key)
+}
+
+// RunPurge apaga as chaves vencidas a cada intervalo até o contexto ser cancelado,
+// sinalizando hb (pode ser nil) com o resultado de cada ciclo.
+func (s *IdempotencyService) RunPurge(ctx context.Context, interval time.Duration, hb Beater) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		n, err := s.repo.DeleteExpired(ctx, s.now())
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("idempotency purge failed")
+		} else if n > 0 {
+			log.WithField("deleted", n).Debug("idempotency keys purged")
+		}
+		beat(ctx, hb, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,487 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/importer"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// importBatchSize é o tamanho de cada COPY durante o commit.
+	importBatchSize = 500
+	// importAsyncThreshold: acima disso o job roda em background.
+	importAsyncThreshold = 500
+	// maxReportedImportErrors limita o payload de erros por job.
+	maxReportedImportErrors = 500
+	// defaultImportJobTTL é quanto um job concluído fica disponível para consulta.
+	defaultImportJobTTL = time.Hour
+)
+
+// Campos aceitos em cada importação e seus aliases em pt-BR.
+var (
+	assetImportFields = map[string][]string{
+		"name":          {"nome", "maquina", "ativo"},
+		"location":      {"localizacao", "local", "setor", "linha"},
+		"criticality":   {"criticidade"},
+		"external_code": {"codigo", "code", "tag"},
+	}
+	workOrderImportFields = map[string][]string{
+		"asset":            {"ativo", "maquina", "equipamento"},
+		"type":             {"tipo"},
+		"status":           {"situacao"},
+		"title":            {"titulo", "resumo"},
+		"description":      {"descricao", "detalhes"},
+		"opened_at":        {"abertura", "data abertura", "created_at"},
+		"breakdown_at":     {"quebra", "data quebra", "parada"},
+		"closed_at":        {"fechamento", "data fechamento", "encerramento"},
+		"downtime_minutes": {"minutos parado", "tempo parado", "downtime"},
+		"cause":            {"causa"},
+		"solution":         {"solucao", "acao"},
+	}
+)
+
+// ImportRequest descreve uma planilha recebida para importação.
+type ImportRequest struct {
+	Kind    domain.ImportKind
+	DryRun  bool
+	Table   *importer.Table
+	Mapping importer.Mapping
+}
+
+// ImportService valida e grava planilhas de ativos e histórico de OS.
+// Os jobs ficam em memória no processo que recebeu o upload, visíveis só no
+// site de quem enviou, e são descartados ttl depois de concluídos.
+type ImportService struct {
+	assets repository.AssetRepository
+	orders repository.WorkOrderRepository
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu   sync.RWMutex
+	jobs map[string]*domain.ImportJob
+}
+
+// ImportOption ajusta o serviço de importação.
+type ImportOption func(*ImportService)
+
+// WithImportJobTTL define por quanto tempo um job concluído continua consultável.
+func WithImportJobTTL(ttl time.Duration) ImportOption {
+	return func(s *ImportService) { s.ttl = ttl }
+}
+
+func NewImportService(assets repository.AssetRepository, orders repository.WorkOrderRepository, opts ...ImportOption) *ImportService {
+	s := &ImportService{
+		assets: assets,
+		orders: orders,
+		ttl:    defaultImportJobTTL,
+		now:    time.Now,
+		jobs:   make(map[string]*domain.ImportJob),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Start cria o job e o executa: de forma síncrona para planilhas pequenas,
+// em background (acompanhar via Get) para as grandes.
+func (s *ImportService) Start(ctx context.Context, req ImportRequest) (*domain.ImportJob, error) {
+	ctx, span := tracer.Start(ctx, "ImportService.Start")
+	defer span.End()
+
+	if req.Table == nil {
+		return nil, domain.ErrInvalidInput
+	}
+	if req.Kind != domain.ImportAssets && req.Kind != domain.ImportWorkOrders {
+		return nil, domain.ErrInvalidInput
+	}
+	site, err := tenant.SingleSite(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	job := &domain.ImportJob{
+		ID:        uuid.New().String(),
+		SiteID:    site,
+		Kind:      req.Kind,
+		DryRun:    req.DryRun,
+		Status:    domain.ImportStatusPending,
+		TotalRows: len(req.Table.Rows),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.mu.Lock()
+	s.prune(now)
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	if job.TotalRows > importAsyncThreshold {
+		// O job sobrevive à requisição; mantém só os valores do contexto (trace).
+		go s.run(context.WithoutCancel(ctx), job.ID, req)
+		return s.snapshot(job), nil
+	}
+	s.run(ctx, job.ID, req)
+	return s.snapshot(job), nil
+}
+
+// Get retorna uma cópia do estado atual do job; job de outro site ou já
+// expirado é ErrNotFound.
+func (s *ImportService) Get(ctx context.Context, id string) (*domain.ImportJob, error) {
+	site, err := tenant.Site(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	job, ok := s.jobs[id]
+	if !ok || !tenant.Visible(site, job.SiteID) || s.expired(job, s.now()) {
+		return nil, domain.ErrNotFound
+	}
+	return copyImportJob(job), nil
+}
+
+// snapshot copia o job sob a trava (o run em background pode estar mexendo nele).
+func (s *ImportService) snapshot(job *domain.ImportJob) *domain.ImportJob {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return copyImportJob(job)
+}
+
+func copyImportJob(job *domain.ImportJob) *domain.ImportJob {
+	cp := *job
+	cp.Errors = append([]domain.ImportRowError(nil), job.Errors...)
+	return &cp
+}
+
+func (s *ImportService) expired(job *domain.ImportJob, now time.Time) bool {
+	return job.Finished() && now.Sub(job.UpdatedAt) > s.ttl
+}
+
+// prune descarta os jobs expirados; chamado com s.mu travado.
+func (s *ImportService) prune(now time.Time) {
+	for id, job := range s.jobs {
+		if s.expired(job, now) {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+func (s *ImportService) update(id string, fn func(j *domain.ImportJob)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job, ok := s.jobs[id]; ok {
+		fn(job)
+		job.UpdatedAt = s.now()
+	}
+}
+
+func (s *ImportService) fail(id string, err error) {
+	log.WithField("import_id", id).WithError(err).Error("import failed")
+	s.update(id, func(j *domain.ImportJob) {
+		j.Status = domain.ImportStatusFailed
+		j.Failure = err.Error()
+	})
+}
+
+func (s *ImportService) run(ctx context.Context, id string, req ImportRequest) {
+	s.update(id, func(j *domain.ImportJob) { j.Status = domain.ImportStatusRunning })
+
+	switch req.Kind {
+	case domain.ImportAssets:
+		s.runAssets(ctx, id, req)
+	case domain.ImportWorkOrders:
+		s.runWorkOrders(ctx, id, req)
+	}
+}
+
+// rowErrors acumula os erros de validação respeitando o limite de payload.
+type rowErrors struct {
+	list  []domain.ImportRowError
+	count int
+}
+
+func (e *rowErrors) add(line int, field, msg string) {
+	e.count++
+	if len(e.list) < maxReportedImportErrors {
+		e.list = append(e.list, domain.ImportRowError{Row: line, Field: field, Message: msg})
+	}
+}
+
+// finishValidation publica o resultado da validação e diz se o commit pode seguir.
+func (s *ImportService) finishValidation(id string, req ImportRequest, errs *rowErrors) bool {
+	s.update(id, func(j *domain.ImportJob) {
+		j.Processed = j.TotalRows
+		j.Errors = errs.list
+		j.ErrorCount = errs.count
+		switch {
+		case req.DryRun:
+			j.Status = domain.ImportStatusValidated
+		case errs.count > 0:
+			j.Status = domain.ImportStatusFailed
+			j.Failure = fmt.Sprintf("%d linha(s) com erro; nada foi gravado", errs.count)
+		}
+	})
+	return !req.DryRun && errs.count == 0
+}
+
+func (s *ImportService) runAssets(ctx context.Context, id string, req ImportRequest) {
+	existing, err := s.assets.FindAll(ctx)
+	if err != nil {
+		s.fail(id, err)
+		return
+	}
+	names := make(map[string]bool, len(existing))
+	codes := make(map[string]bool, len(existing))
+	for _, a := range existing {
+		names[strings.ToLower(a.Name)] = true
+		if a.ExternalCode != "" {
+			codes[strings.ToLower(a.ExternalCode)] = true
+		}
+	}
+
+	cols := req.Table.Columns(assetImportFields, req.Mapping)
+	errs := &rowErrors{}
+	if cols["name"] < 0 {
+		errs.add(1, "name", "coluna obrigatória não encontrada")
+	}
+
+	var batch []domain.Asset
+	for i, row := range req.Table.Rows {
+		a := domain.Asset{
+			Name:         row.Value(cols["name"]),
+			Location:     row.Value(cols["location"]),
+			Criticality:  domain.Criticality(strings.ToUpper(row.Value(cols["criticality"]))),
+			ExternalCode: row.Value(cols["external_code"]),
+		}
+		a.Normalize()
+
+		ok := true
+		switch {
+		case len(a.Name) < 2:
+			errs.add(row.Line, "name", "obrigatório (mínimo 2 caracteres)")
+			ok = false
+		case names[strings.ToLower(a.Name)]:
+			errs.add(row.Line, "name", "ativo já existente")
+			ok = false
+		}
+		if a.Criticality != domain.CriticalityA && a.Criticality != domain.CriticalityB && a.Criticality != domain.CriticalityC {
+			errs.add(row.Line, "criticality", "deve ser A, B ou C")
+			ok = false
+		}
+		if a.ExternalCode != "" && codes[strings.ToLower(a.ExternalCode)] {
+			errs.add(row.Line, "external_code", "código já existente")
+			ok = false
+		}
+
+		if ok {
+			names[strings.ToLower(a.Name)] = true
+			if a.ExternalCode != "" {
+				codes[strings.ToLower(a.ExternalCode)] = true
+			}
+			batch = append(batch, a)
+		}
+		s.update(id, func(j *domain.ImportJob) { j.Processed = i + 1 })
+	}
+
+	if !s.finishValidation(id, req, errs) {
+		return
+	}
+	for start := 0; start < len(batch); start += importBatchSize {
+		end := min(start+importBatchSize, len(batch))
+		n, err := s.assets.CreateBatch(ctx, batch[start:end])
+		if err != nil {
+			s.fail(id, err)
+			return
+		}
+		s.update(id, func(j *domain.ImportJob) { j.Inserted += n })
+	}
+	s.update(id, func(j *domain.ImportJob) { j.Status = domain.ImportStatusDone })
+}
+
+func (s *ImportService) runWorkOrders(ctx context.Context, id string, req ImportRequest) {
+	assets, err := s.assets.FindAll(ctx)
+	if err != nil {
+		s.fail(id, err)
+		return
+	}
+	resolver := newAssetResolver(assets)
+
+	cols := req.Table.Columns(workOrderImportFields, req.Mapping)
+	errs := &rowErrors{}
+	for _, required := range []string{"asset", "title"} {
+		if cols[required] < 0 {
+			errs.add(1, required, "coluna obrigatória não encontrada")
+		}
+	}
+
+	var batch []domain.WorkOrder
+	for i, row := range req.Table.Rows {
+		o, rowOK := parseWorkOrderRow(row, cols, resolver, errs)
+		if rowOK {
+			batch = append(batch, o)
+		}
+		s.update(id, func(j *domain.ImportJob) { j.Processed = i + 1 })
+	}
+
+	if !s.finishValidation(id, req, errs) {
+		return
+	}
+	for start := 0; start < len(batch); start += importBatchSize {
+		end := min(start+importBatchSize, len(batch))
+		n, err := s.orders.CreateBatch(ctx, batch[start:end])
+		if err != nil {
+			s.fail(id, err)
+			return
+		}
+		s.update(id, func(j *domain.ImportJob) { j.Inserted += n })
+	}
+	s.update(id, func(j *domain.ImportJob) { j.Status = domain.ImportStatusDone })
+}
+
+func parseWorkOrderRow(row importer.Row, cols map[string]int, resolver *assetResolver, errs *rowErrors) (domain.WorkOrder, bool) {
+	ok := true
+	o := domain.WorkOrder{
+		Type:        domain.WorkOrderType(strings.ToLower(row.Value(cols["type"]))),
+		Status:      domain.WorkOrderStatus(strings.ToLower(row.Value(cols["status"]))),
+		Title:       row.Value(cols["title"]),
+		Description: row.Value(cols["description"]),
+		Cause:       row.Value(cols["cause"]),
+		Solution:    row.Value(cols["solution"]),
+	}
+
+	ref := row.Value(cols["asset"])
+	assetID, msg := resolver.resolve(ref)
+	if msg != "" {
+		errs.add(row.Line, "asset", msg)
+		ok = false
+	}
+	o.AssetID = assetID
+
+	if len(o.Title) < 3 {
+		errs.add(row.Line, "title", "obrigatório (mínimo 3 caracteres)")
+		ok = false
+	}
+
+	dates := map[string]**time.Time{"breakdown_at": &o.BreakdownAt, "closed_at": &o.ClosedAt}
+	for field, dst := range dates {
+		t, err := parseImportTime(row.Value(cols[field]))
+		if err != nil {
+			errs.add(row.Line, field, err.Error())
+			ok = false
+			continue
+		}
+		*dst = t
+	}
+	opened, err := parseImportTime(row.Value(cols["opened_at"]))
+	if err != nil {
+		errs.add(row.Line, "opened_at", err.Error())
+		ok = false
+	} else if opened != nil {
+		o.CreatedAt = *opened
+	}
+
+	if v := row.Value(cols["downtime_minutes"]); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			errs.add(row.Line, "downtime_minutes", "deve ser um inteiro >= 0")
+			ok = false
+		} else {
+			o.DowntimeMinutes = &n
+		}
+	}
+
+	// Histórico: OS com data de fechamento entra como concluída.
+	if o.Status == "" && o.ClosedAt != nil {
+		o.Status = domain.WOStatusDone
+	}
+	o.Normalize()
+	switch o.Type {
+	case domain.WOTypeCorrective, domain.WOTypePreventive, domain.WOTypeCondition, domain.WOTypeImprovement:
+	default:
+		errs.add(row.Line, "type", "deve ser corrective, preventive, condition ou improvement")
+		ok = false
+	}
+	switch o.Status {
+	case domain.WOStatusOpen, domain.WOStatusInProgress, domain.WOStatusDone, domain.WOStatusCanceled:
+	default:
+		errs.add(row.Line, "status", "deve ser open, in_progress, done ou canceled")
+		ok = false
+	}
+	if o.ClosedAt != nil && o.BreakdownAt != nil && o.ClosedAt.Before(*o.BreakdownAt) {
+		errs.add(row.Line, "closed_at", "anterior à data da quebra")
+		ok = false
+	}
+	return o, ok
+}
+
+// assetResolver encontra o ativo pelo código externo ou, na falta, pelo nome.
+type assetResolver struct {
+	byCode map[string]int64
+	byName map[string][]int64
+}
+
+func newAssetResolver(assets []domain.Asset) *assetResolver {
+	r := &assetResolver{byCode: map[string]int64{}, byName: map[string][]int64{}}
+	for _, a := range assets {
+		if a.ExternalCode != "" {
+			r.byCode[strings.ToLower(a.ExternalCode)] = a.ID
+		}
+		key := strings.ToLower(a.Name)
+		r.byName[key] = append(r.byName[key], a.ID)
+	}
+	return r
+}
+
+// resolve retorna o ID ou uma mensagem de erro para a linha.
+func (r *assetResolver) resolve(ref string) (int64, string) {
+	key := strings.ToLower(strings.TrimSpace(ref))
+	if key == "" {
+		return 0, "obrigatório"
+	}
+	if id, ok := r.byCode[key]; ok {
+		return id, ""
+	}
+	switch ids := r.byName[key]; len(ids) {
+	case 0:
+		return 0, fmt.Sprintf("ativo %q não encontrado", ref)
+	case 1:
+		return ids[0], ""
+	default:
+		return 0, fmt.Sprintf("nome %q ambíguo (%d ativos); use o código", ref, len(ids))
+	}
+}
+
+// Formatos de data aceitos nas planilhas, interpretados no fuso da planta.
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02/01/2006",
+}
+
+func parseImportTime(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, v, plant.Location()); err == nil {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("data inválida %q (use AAAA-MM-DD ou DD/MM/AAAA [HH:MM])", v)
+}
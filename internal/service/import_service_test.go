@@ -0,0 +1,156 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/importer"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+func readCSV(t *testing.T, csv string) *importer.Table {
+	t.Helper()
+	table, err := importer.Read(strings.NewReader(csv), importer.FormatCSV)
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	return table
+}
+
+func TestImportService_AssetsDryRunThenCommit(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	svc := service.NewImportService(assets, memory.NewWorkOrderMemoryRepo())
+
+	bad := readCSV(t, "Máquina;Setor;Criticidade;Código\nCortadeira 1;Corte;A;CT-01\nX;Corte;Z;CT-02\nCortadeira 1;Corte;B;\n")
+	job, err := svc.Start(ctx, service.ImportRequest{Kind: domain.ImportAssets, DryRun: true, Table: bad})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if job.Status != domain.ImportStatusValidated {
+		t.Fatalf("expected validated, got %s", job.Status)
+	}
+	// linha 3: nome curto + criticidade inválida; linha 4: nome duplicado no arquivo
+	if job.ErrorCount != 3 {
+		t.Fatalf("expected 3 row errors, got %d: %+v", job.ErrorCount, job.Errors)
+	}
+	if list, _ := assets.FindAll(ctx); len(list) != 0 {
+		t.Fatalf("dry run must not persist, got %d assets", len(list))
+	}
+
+	// commit com erros é recusado por inteiro
+	job, _ = svc.Start(ctx, service.ImportRequest{Kind: domain.ImportAssets, Table: bad})
+	if job.Status != domain.ImportStatusFailed || job.Inserted != 0 {
+		t.Fatalf("expected failed commit without inserts, got %s/%d", job.Status, job.Inserted)
+	}
+
+	good := readCSV(t, "Máquina,Setor,Criticidade,Código\nCortadeira 1,Corte,A,CT-01\nRebobinadeira,Acabamento,,RB-01\n")
+	job, _ = svc.Start(ctx, service.ImportRequest{Kind: domain.ImportAssets, Table: good})
+	if job.Status != domain.ImportStatusDone || job.Inserted != 2 {
+		t.Fatalf("expected done with 2 inserts, got %s/%d (%+v)", job.Status, job.Inserted, job.Errors)
+	}
+	list, _ := assets.FindAll(ctx)
+	if len(list) != 2 || list[1].Criticality != domain.CriticalityB || list[0].ExternalCode != "CT-01" {
+		t.Fatalf("unexpected imported assets: %+v", list)
+	}
+}
+
+func TestImportService_WorkOrderHistory(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	_ = assets.Create(ctx, &domain.Asset{Name: "Cortadeira 1", ExternalCode: "CT-01"})
+	_ = assets.Create(ctx, &domain.Asset{Name: "Rebobinadeira"})
+	svc := service.NewImportService(assets, orders)
+
+	csv := "equipamento;titulo;abertura;quebra;fechamento;minutos parado;causa\n" +
+		"CT-01;Troca de faca;2021-03-01;01/03/2021 08:00;01/03/2021 10:30;150;desgaste\n" +
+		"rebobinadeira;Correia rompida;2022-05-10;;;;\n" +
+		"Desconhecida;Motor queimado;;;;;\n"
+
+	job, _ := svc.Start(ctx, service.ImportRequest{
+		Kind:    domain.ImportWorkOrders,
+		Table:   readCSV(t, csv),
+		Mapping: importer.Mapping{"description": "inexistente"},
+		DryRun:  true,
+	})
+	if job.ErrorCount != 1 || job.Errors[0].Row != 4 || job.Errors[0].Field != "asset" {
+		t.Fatalf("expected asset error on row 4, got %+v", job.Errors)
+	}
+
+	csv = strings.Join(strings.Split(csv, "\n")[:3], "\n")
+	job, _ = svc.Start(ctx, service.ImportRequest{Kind: domain.ImportWorkOrders, Table: readCSV(t, csv)})
+	if job.Status != domain.ImportStatusDone || job.Inserted != 2 {
+		t.Fatalf("expected 2 work orders imported, got %s/%d (%+v)", job.Status, job.Inserted, job.Errors)
+	}
+
+	list, _ := orders.FindAll(ctx)
+	first := list[0]
+	if first.AssetID != 1 || first.Status != domain.WOStatusDone || first.DowntimeMinutes == nil || *first.DowntimeMinutes != 150 {
+		t.Fatalf("unexpected first order: %+v", first)
+	}
+	if first.CreatedAt.Year() != 2021 {
+		t.Fatalf("expected historical created_at to be preserved, got %s", first.CreatedAt)
+	}
+	if list[1].AssetID != 2 || list[1].Status != domain.WOStatusOpen {
+		t.Fatalf("expected asset resolved by name and status open, got %+v", list[1])
+	}
+}
+
+func TestImportService_LargeImportRunsInBackground(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	svc := service.NewImportService(assets, memory.NewWorkOrderMemoryRepo())
+
+	var b strings.Builder
+	b.WriteString("name,location\n")
+	for i := 0; i < 1200; i++ {
+		fmt.Fprintf(&b, "Máquina %04d,Linha %d\n", i, i%4)
+	}
+
+	job, err := svc.Start(ctx, service.ImportRequest{Kind: domain.ImportAssets, Table: readCSV(t, b.String())})
+	if err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for !job.Finished() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+		job, _ = svc.Get(ctx, job.ID)
+	}
+	if job.Status != domain.ImportStatusDone || job.Inserted != 1200 {
+		t.Fatalf("expected background import of 1200 rows, got %s/%d", job.Status, job.Inserted)
+	}
+}
+
+func TestImportService_JobsPerSiteExpire(t *testing.T) {
+	site1 := onSite(1)
+	svc := service.NewImportService(memory.NewAssetMemoryRepo(), memory.NewWorkOrderMemoryRepo(),
+		service.WithImportJobTTL(20*time.Millisecond))
+	table := readCSV(t, "name,location\nCortadeira 1,Corte\n")
+
+	job, err := svc.Start(site1, service.ImportRequest{Kind: domain.ImportAssets, DryRun: true, Table: table})
+	if err != nil || !job.Finished() || job.SiteID != 1 {
+		t.Fatalf("Start() = %+v, %v", job, err)
+	}
+	if _, err := svc.Get(site1, job.ID); err != nil {
+		t.Fatalf("Get() from the same site error = %v", err)
+	}
+	if _, err := svc.Get(onSite(2), job.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("Get() from site 2: err = %v, want ErrNotFound", err)
+	}
+	if _, err := svc.Start(tenant.System(context.Background()), service.ImportRequest{Kind: domain.ImportAssets, Table: table}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("Start() without a single site: err = %v, want ErrInvalidInput", err)
+	}
+
+	time.Sleep(40 * time.Millisecond)
+	if _, err := svc.Get(site1, job.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("Get() after the TTL: err = %v, want ErrNotFound", err)
+	}
+}
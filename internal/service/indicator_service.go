@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+type IndicatorService struct {
+	repo repository.IndicatorRepository
+	now  func() time.Time
+}
+
+func NewIndicatorService(r repository.IndicatorRepository) *IndicatorService {
+	return &IndicatorService{repo: r, now: time.Now}
+}
+
+// Current calcula os indicadores de manutenção no instante atual.
+func (s *IndicatorService) Current(ctx context.Context) (*domain.Indicators, error) {
+	ctx, span := tracer.Start(ctx, "IndicatorService.Current")
+	defer span.End()
+
+	return s.repo.Indicators(ctx, s.now())
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// JobPlanService mantém os roteiros padrão copiados para as OS preventivas.
+// Alterar um roteiro não muda os checklists de OS já abertas.
+type JobPlanService struct {
+	repo  repository.JobPlanRepository
+	parts repository.SparePartRepository
+}
+
+func NewJobPlanService(r repository.JobPlanRepository, parts repository.SparePartRepository) *JobPlanService {
+	return &JobPlanService{repo: r, parts: parts}
+}
+
+func (s *JobPlanService) Create(ctx context.Context, jp *domain.JobPlan) error {
+	ctx, span := tracer.Start(ctx, "JobPlanService.Create")
+	defer span.End()
+
+	if err := s.validate(ctx, jp); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, jp)
+}
+
+// Replace substitui o roteiro inteiro (passos e peças).
+func (s *JobPlanService) Replace(ctx context.Context, jp *domain.JobPlan) error {
+	ctx, span := tracer.Start(ctx, "JobPlanService.Replace")
+	defer span.End()
+
+	if err := s.validate(ctx, jp); err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, jp)
+}
+
+// validate confere o roteiro e a existência das peças previstas.
+func (s *JobPlanService) validate(ctx context.Context, jp *domain.JobPlan) error {
+	jp.Normalize()
+	if err := jp.Validate(); err != nil {
+		return err
+	}
+	for _, p := range jp.Parts {
+		if _, err := s.parts.FindByID(ctx, p.SparePartID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidInput
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *JobPlanService) Get(ctx context.Context, id int64) (*domain.JobPlan, error) {
+	ctx, span := tracer.Start(ctx, "JobPlanService.Get")
+	defer span.End()
+
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *JobPlanService) List(ctx context.Context) ([]domain.JobPlan, error) {
+	ctx, span := tracer.Start(ctx, "JobPlanService.List")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
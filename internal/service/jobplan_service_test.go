@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+func TestJobPlan_ChecklistCopiedAndFollowUpOnOutOfTolerance(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	plans := memory.NewMaintenancePlanMemoryRepo()
+	jobPlans := memory.NewJobPlanMemoryRepo()
+	parts := memory.NewSparePartMemoryRepo()
+	calendar := service.NewCalendarService(memory.NewCalendarMemoryRepo(), memory.NewShiftMemoryRepo())
+	workOrders := service.NewWorkOrderService(orders,
+		service.WithAssets(assets),
+		service.WithPlans(plans),
+		service.WithChecklists(jobPlans, memory.NewChecklistMemoryRepo(orders)),
+	)
+	jobPlanSvc := service.NewJobPlanService(jobPlans, parts)
+	planSvc := service.NewMaintenancePlanService(plans, assets, jobPlans)
+
+	asset := domain.Asset{Name: "Compressor", Criticality: domain.CriticalityB}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	filter := domain.SparePart{Code: "FLT-01", Name: "Filtro de ar", Quantity: 4}
+	if err := parts.Create(ctx, &filter); err != nil {
+		t.Fatalf("create spare part: %v", err)
+	}
+
+	minP, maxP := 6.0, 8.0
+	est := int64(90)
+	jp := domain.JobPlan{
+		Name:             "Preventiva mensal do compressor",
+		Trade:            "Mecanica",
+		EstimatedMinutes: &est,
+		Steps: []domain.JobPlanStep{
+			{Description: "Trocar filtro de ar"},
+			{Description: "Medir pressão de descarga", Measurement: &domain.MeasurementSpec{Unit: "bar", Min: &minP, Max: &maxP, FollowUp: true}},
+		},
+		Parts: []domain.JobPlanPart{{SparePartID: filter.ID, Quantity: 1}},
+	}
+	if err := jobPlanSvc.Create(ctx, &domain.JobPlan{Name: "Sem peça", Steps: jp.Steps, Parts: []domain.JobPlanPart{{SparePartID: 99, Quantity: 1}}}); err != domain.ErrInvalidInput {
+		t.Fatalf("unknown spare part expected ErrInvalidInput, got %v", err)
+	}
+	if err := jobPlanSvc.Create(ctx, &jp); err != nil {
+		t.Fatalf("create job plan: %v", err)
+	}
+	if jp.Steps[1].Seq != 2 || jp.Trade != "mecanica" {
+		t.Fatalf("job plan should be normalized: %+v", jp)
+	}
+
+	freq := int64(30)
+	last := time.Now().AddDate(0, 0, -31)
+	plan := domain.MaintenancePlan{AssetID: asset.ID, RuleType: domain.PlanRuleTime, FrequencyDays: &freq, LastExecution: &last, JobPlanID: &jp.ID}
+	if err := planSvc.Create(ctx, &plan); err != nil {
+		t.Fatalf("create plan: %v", err)
+	}
+
+	scheduler := service.NewPreventiveScheduler(plans, orders, workOrders, calendar, time.Hour, 0)
+	created, err := scheduler.Check(ctx)
+	if err != nil || len(created) != 1 {
+		t.Fatalf("Check() = %+v, %v", created, err)
+	}
+	wo := created[0]
+	if wo.JobPlanID == nil || len(wo.RequiredParts) != 1 || wo.Trade != "mecanica" || wo.EstimatedMinutes == nil || *wo.EstimatedMinutes != 90 {
+		t.Fatalf("work order should inherit the job plan: %+v", wo)
+	}
+	items, err := workOrders.Checklist(ctx, wo.ID)
+	if err != nil || len(items) != 2 || items[1].Measurement == nil {
+		t.Fatalf("Checklist() = %+v, %v", items, err)
+	}
+
+	if _, err := workOrders.Transition(ctx, wo.ID, service.TransitionRequest{Status: domain.WOStatusDone}); err != domain.ErrPrecondition {
+		t.Fatalf("closing with pending steps expected ErrPrecondition, got %v", err)
+	}
+	if _, err := workOrders.UpdateChecklistStep(ctx, wo.ID, 2, service.ChecklistUpdate{Done: true}); err != domain.ErrInvalidInput {
+		t.Fatalf("measurement step without value expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := workOrders.UpdateChecklistStep(ctx, wo.ID, 3, service.ChecklistUpdate{Done: true}); err != domain.ErrNotFound {
+		t.Fatalf("unknown step expected ErrNotFound, got %v", err)
+	}
+	if _, err := workOrders.UpdateChecklistStep(ctx, wo.ID, 1, service.ChecklistUpdate{Done: true}); err != nil {
+		t.Fatalf("complete step 1: %v", err)
+	}
+
+	high := 9.2
+	item, err := workOrders.UpdateChecklistStep(ctx, wo.ID, 2, service.ChecklistUpdate{Done: true, Value: &high})
+	if err != nil || !item.OutOfTolerance || item.FollowUpID == nil {
+		t.Fatalf("out of tolerance should open a follow-up: %+v, %v", item, err)
+	}
+	followUp, err := orders.FindByID(ctx, *item.FollowUpID)
+	if err != nil || followUp.Type != domain.WOTypeCorrective || followUp.AssetID != asset.ID {
+		t.Fatalf("unexpected follow-up: %+v, %v", followUp, err)
+	}
+	if followUp.Description != "Passo 2: medido 9.2 bar, faixa 6 a 8 bar." {
+		t.Fatalf("unexpected follow-up description: %q", followUp.Description)
+	}
+	// Novo apontamento fora da faixa não duplica a corretiva.
+	again, err := workOrders.UpdateChecklistStep(ctx, wo.ID, 2, service.ChecklistUpdate{Done: true, Value: &high, Note: "confirmado"})
+	if err != nil || again.FollowUpID == nil || *again.FollowUpID != followUp.ID {
+		t.Fatalf("follow-up should be created once: %+v, %v", again, err)
+	}
+
+	if _, err := workOrders.Transition(ctx, wo.ID, service.TransitionRequest{Status: domain.WOStatusDone}); err != nil {
+		t.Fatalf("close completed preventive: %v", err)
+	}
+	if _, err := workOrders.UpdateChecklistStep(ctx, wo.ID, 1, service.ChecklistUpdate{Done: false}); err != domain.ErrConflict {
+		t.Fatalf("closed order expected ErrConflict, got %v", err)
+	}
+}
+
+// slowChecklists alarga a janela entre ler o passo e gravá-lo.
+type slowChecklists struct {
+	*memory.ChecklistMemoryRepo
+}
+
+func (r slowChecklists) FindByWorkOrder(ctx context.Context, workOrderID int64) ([]domain.ChecklistItem, error) {
+	items, err := r.ChecklistMemoryRepo.FindByWorkOrder(ctx, workOrderID)
+	time.Sleep(5 * time.Millisecond)
+	return items, err
+}
+
+func TestJobPlan_ConcurrentOutOfToleranceOpensOneFollowUp(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	jobPlans := memory.NewJobPlanMemoryRepo()
+	checklists := memory.NewChecklistMemoryRepo(orders)
+	workOrders := service.NewWorkOrderService(orders,
+		service.WithAssets(assets),
+		service.WithChecklists(jobPlans, slowChecklists{checklists}),
+		service.WithTransactions(memory.NewTransactor()),
+	)
+
+	asset := domain.Asset{Name: "Compressor", Criticality: domain.CriticalityB}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	maxP := 8.0
+	jp := domain.JobPlan{Name: "Inspeção", Steps: []domain.JobPlanStep{
+		{Seq: 1, Description: "Medir pressão", Measurement: &domain.MeasurementSpec{Unit: "bar", Max: &maxP, FollowUp: true}},
+	}}
+	if err := jobPlans.Create(ctx, &jp); err != nil {
+		t.Fatalf("create job plan: %v", err)
+	}
+	wo := domain.WorkOrder{AssetID: asset.ID, Type: domain.WOTypePreventive, Title: "Inspeção", JobPlanID: &jp.ID}
+	if err := workOrders.Create(ctx, &wo); err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	// Dois técnicos (ou o app offline e o web) apontam a mesma medição ao mesmo tempo.
+	high := 9.5
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := workOrders.UpdateChecklistStep(ctx, wo.ID, 1, service.ChecklistUpdate{Done: true, Value: &high}); err != nil {
+				t.Errorf("UpdateChecklistStep() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	all, err := orders.FindAll(ctx)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	var corrective int
+	for _, o := range all {
+		if o.Type == domain.WOTypeCorrective {
+			corrective++
+		}
+	}
+	if corrective != 1 {
+		t.Fatalf("expected exactly one follow-up, got %d", corrective)
+	}
+
+	// Apontamento lido antes da corretiva não apaga o vínculo.
+	items, _ := checklists.FindByWorkOrder(ctx, wo.ID)
+	stale := items[0]
+	stale.FollowUpID = nil
+	if err := checklists.UpdateItem(ctx, wo.ID, &stale); err != domain.ErrConflict {
+		t.Fatalf("stale item overwriting the follow-up expected ErrConflict, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// MaintenancePlanService cadastra os planos de preventiva usados pelo agendador.
+type MaintenancePlanService struct {
+	repo     repository.MaintenancePlanRepository
+	assets   repository.AssetRepository
+	jobPlans repository.JobPlanRepository
+}
+
+func NewMaintenancePlanService(r repository.MaintenancePlanRepository, assets repository.AssetRepository,
+	jobPlans repository.JobPlanRepository) *MaintenancePlanService {
+	return &MaintenancePlanService{repo: r, assets: assets, jobPlans: jobPlans}
+}
+
+func (s *MaintenancePlanService) Create(ctx context.Context, plan *domain.MaintenancePlan) error {
+	ctx, span := tracer.Start(ctx, "MaintenancePlanService.Create")
+	defer span.End()
+
+	plan.Normalize()
+	if err := plan.Validate(); err != nil {
+		return err
+	}
+	asset, err := s.assets.FindByID(ctx, plan.AssetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidInput
+		}
+		return err
+	}
+	plan.SiteID = asset.SiteID
+	if plan.JobPlanID != nil {
+		if _, err := s.jobPlans.FindByID(ctx, *plan.JobPlanID); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return domain.ErrInvalidInput
+			}
+			return err
+		}
+	}
+	return s.repo.Create(ctx, plan)
+}
+
+func (s *MaintenancePlanService) List(ctx context.Context) ([]domain.MaintenancePlan, error) {
+	ctx, span := tracer.Start(ctx, "MaintenancePlanService.List")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
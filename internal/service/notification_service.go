@@ -0,0 +1,310 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/notify"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Notifier recebe os gatilhos de alerta dos demais serviços.
+type Notifier interface {
+	// Raise grava o alerta (os avisos saem pelo escalonamento); alerta repetido é ignorado.
+	Raise(ctx context.Context, alert *domain.Alert) error
+	// Resolve encerra os alertas da referência (a condição deixou de existir).
+	Resolve(ctx context.Context, refType domain.AlertRefType, refID int64) error
+}
+
+// NotificationService abre alertas, escalona por nível enquanto ninguém
+// reconhece e entrega as mensagens pelos canais preferidos de cada usuário.
+// A tabela de alertas é a fila de envio: Raise só grava o alerta e o
+// escalonamento (RunEscalation) faz as entregas fora da requisição. Um nível
+// só é dado como avisado quando ao menos uma entrega funcionou; se todas
+// falharem, ele é repetido no ciclo seguinte.
+type NotificationService struct {
+	users    repository.UserRepository
+	rules    repository.EscalationRepository
+	alerts   repository.AlertRepository
+	channels map[domain.NotificationChannel]notify.Channel
+	wake     chan struct{}
+	now      func() time.Time
+}
+
+func NewNotificationService(users repository.UserRepository, rules repository.EscalationRepository,
+	alerts repository.AlertRepository, channels map[domain.NotificationChannel]notify.Channel) *NotificationService {
+	return &NotificationService{users: users, rules: rules, alerts: alerts, channels: channels,
+		wake: make(chan struct{}, 1), now: time.Now}
+}
+
+func (s *NotificationService) Raise(ctx context.Context, alert *domain.Alert) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.Raise")
+	defer span.End()
+	span.SetAttributes(attribute.String("alert.event", string(alert.Event)))
+
+	if !alert.Event.Valid() || alert.RefType == "" || alert.RefID == 0 {
+		return domain.ErrInvalidInput
+	}
+	now := s.now()
+	if alert.TriggeredAt.IsZero() {
+		alert.TriggeredAt = now
+	}
+	alert.Tier = 0
+	err := s.alerts.Create(ctx, alert)
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		return nil // já há alerta em andamento para o mesmo evento
+	}
+	if err != nil {
+		return err
+	}
+	// Acorda o escalonamento para o primeiro nível não esperar o próximo ciclo.
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (s *NotificationService) Resolve(ctx context.Context, refType domain.AlertRefType, refID int64) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.Resolve")
+	defer span.End()
+
+	return s.alerts.Resolve(ctx, refType, refID, s.now())
+}
+
+// Escalate avisa os próximos níveis dos alertas sem reconhecimento cujo atraso venceu.
+func (s *NotificationService) Escalate(ctx context.Context) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.Escalate")
+	defer span.End()
+
+	alerts, err := s.alerts.FindAll(ctx, true)
+	if err != nil {
+		return err
+	}
+	now := s.now()
+	var errs []error
+	for i := range alerts {
+		if err := s.escalate(ctx, &alerts[i], now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunEscalation executa Escalate na partida, a cada intervalo e logo após cada
+// Raise, até o contexto ser cancelado, sinalizando hb (pode ser nil) com o
+// resultado de cada ciclo.
+func (s *NotificationService) RunEscalation(ctx context.Context, interval time.Duration, hb Beater) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := s.Escalate(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("alert escalation failed")
+		}
+		beat(ctx, hb, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// PendingDeliveries conta os alertas sem reconhecimento com algum nível vencido
+// há mais de grace e ainda não avisado: a fila de entregas que o escalonamento
+// não está conseguindo esvaziar.
+func (s *NotificationService) PendingDeliveries(ctx context.Context, grace time.Duration) (int64, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.PendingDeliveries")
+	defer span.End()
+
+	alerts, err := s.alerts.FindAll(ctx, true)
+	if err != nil {
+		return 0, err
+	}
+	type siteEvent struct {
+		site  int64
+		event domain.NotificationEvent
+	}
+	rules := map[siteEvent][]domain.EscalationRule{}
+	cutoff := s.now().Add(-grace)
+	var pending int64
+	for i := range alerts {
+		alert := &alerts[i]
+		key := siteEvent{alert.SiteID, alert.Event}
+		eventRules, ok := rules[key]
+		if !ok {
+			siteCtx, err := tenant.OnSite(ctx, alert.SiteID)
+			if err != nil {
+				return 0, err
+			}
+			if eventRules, err = s.rules.FindByEvent(siteCtx, alert.Event); err != nil {
+				return 0, err
+			}
+			rules[key] = eventRules
+		}
+		for _, rule := range eventRules {
+			if rule.Tier > alert.Tier && !alert.TriggeredAt.Add(time.Duration(rule.DelayMinutes)*time.Minute).After(cutoff) {
+				pending++
+				break
+			}
+		}
+	}
+	return pending, nil
+}
+
+// escalate avisa, em ordem, os níveis acima do último avisado cujo atraso já
+// passou. Para no primeiro nível sem nenhuma entrega: ele (e os seguintes) fica
+// para o próximo ciclo. Regras e destinatários são só os do site do alerta.
+func (s *NotificationService) escalate(ctx context.Context, alert *domain.Alert, now time.Time) error {
+	siteCtx, err := tenant.OnSite(ctx, alert.SiteID)
+	if err != nil {
+		return err
+	}
+	rules, err := s.rules.FindByEvent(siteCtx, alert.Event)
+	if err != nil {
+		return err
+	}
+	tier := alert.Tier
+	var failed error
+	for _, rule := range rules {
+		if rule.Tier <= tier || alert.TriggeredAt.Add(time.Duration(rule.DelayMinutes)*time.Minute).After(now) {
+			continue
+		}
+		if err := s.dispatch(siteCtx, alert, rule); err != nil {
+			failed = fmt.Errorf("alert %d tier %d: %w", alert.ID, rule.Tier, err)
+			break
+		}
+		tier = rule.Tier
+	}
+	if tier != alert.Tier {
+		if err := s.alerts.SetTier(ctx, alert.ID, tier); err != nil {
+			return errors.Join(failed, err)
+		}
+		alert.Tier = tier
+	}
+	return failed
+}
+
+// dispatch entrega a mensagem do nível aos usuários da regra. Só devolve erro
+// quando houve tentativas e todas falharam; destinatário inativo ou canal não
+// configurado não contam como tentativa (repetir não adiantaria).
+func (s *NotificationService) dispatch(ctx context.Context, alert *domain.Alert, rule domain.EscalationRule) error {
+	msg := notify.Message{
+		Subject: fmt.Sprintf("[Manutenção] %s (nível %d)", eventLabels[alert.Event], rule.Tier),
+		Text:    fmt.Sprintf("%s\nAlerta #%d. Reconheça em POST /alerts/%d/ack para encerrar o escalonamento.", alert.Message, alert.ID, alert.ID),
+	}
+	var (
+		delivered int
+		failures  []error
+	)
+	for _, userID := range rule.UserIDs {
+		user, err := s.users.FindByID(ctx, userID)
+		if errors.Is(err, domain.ErrNotFound) {
+			log.WithError(err).WithField("user_id", userID).Warn("alert recipient not found")
+			continue
+		}
+		if err != nil {
+			failures = append(failures, err)
+			continue
+		}
+		if !user.Active {
+			continue
+		}
+		msg.To = notify.Recipient{Name: user.Name, Email: user.Email, Phone: user.Phone, ChatID: user.ChatID}
+		for _, ch := range user.ChannelsFor(alert.Event) {
+			fields := log.Fields{"alert_id": alert.ID, "user_id": user.ID, "channel": ch}
+			adapter, ok := s.channels[ch]
+			if !ok {
+				log.WithFields(fields).Warn("notification channel not configured")
+				continue
+			}
+			if err := adapter.Send(ctx, msg); err != nil {
+				log.WithFields(fields).WithError(err).Error("notification delivery failed")
+				failures = append(failures, err)
+				continue
+			}
+			delivered++
+		}
+	}
+	if delivered == 0 && len(failures) > 0 {
+		return errors.Join(failures...)
+	}
+	return nil
+}
+
+var eventLabels = map[domain.NotificationEvent]string{
+	domain.EventCriticalBreakdown: "Quebra em ativo crítico",
+	domain.EventSLAAtRisk:         "SLA perto de vencer",
+	domain.EventPreventiveOverdue: "Preventiva atrasada",
+	domain.EventLowStock:          "Estoque baixo",
+}
+
+// Ack reconhece o alerta em nome do usuário e encerra o escalonamento.
+func (s *NotificationService) Ack(ctx context.Context, id, userID int64) (*domain.Alert, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.Ack")
+	defer span.End()
+
+	if _, err := s.users.FindByID(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidInput
+		}
+		return nil, err
+	}
+	return s.alerts.Ack(ctx, id, userID, s.now())
+}
+
+// ListAlerts lista os alertas; escalatingOnly traz só os ainda sem ack e sem resolução.
+func (s *NotificationService) ListAlerts(ctx context.Context, escalatingOnly bool) ([]domain.Alert, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.ListAlerts")
+	defer span.End()
+
+	return s.alerts.FindAll(ctx, escalatingOnly)
+}
+
+func (s *NotificationService) ListRules(ctx context.Context) ([]domain.EscalationRule, error) {
+	ctx, span := tracer.Start(ctx, "NotificationService.ListRules")
+	defer span.End()
+
+	return s.rules.FindAll(ctx)
+}
+
+// ReplaceRules troca as regras de escalonamento do site da chamada; os usuários
+// citados precisam existir nesse site.
+func (s *NotificationService) ReplaceRules(ctx context.Context, rules []domain.EscalationRule) error {
+	ctx, span := tracer.Start(ctx, "NotificationService.ReplaceRules")
+	defer span.End()
+
+	type level struct {
+		event domain.NotificationEvent
+		tier  int
+	}
+	seen := map[level]bool{}
+	for i := range rules {
+		r := &rules[i]
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		l := level{r.Event, r.Tier}
+		if seen[l] {
+			return domain.ErrInvalidInput
+		}
+		seen[l] = true
+		for _, id := range r.UserIDs {
+			if _, err := s.users.FindByID(ctx, id); err != nil {
+				if errors.Is(err, domain.ErrNotFound) {
+					return domain.ErrInvalidInput
+				}
+				return err
+			}
+		}
+	}
+	return s.rules.ReplaceAll(ctx, rules)
+}
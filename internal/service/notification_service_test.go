@@ -0,0 +1,348 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/notify"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+func TestNotificationService_EscalatesUntilAcknowledged(t *testing.T) {
+	ctx := onSite(1)
+	users := memory.NewUserMemoryRepo()
+	alerts := memory.NewAlertMemoryRepo()
+	logCh := &notify.Log{}
+	notifications := service.NewNotificationService(users, memory.NewEscalationMemoryRepo(), alerts,
+		map[domain.NotificationChannel]notify.Channel{domain.ChannelLog: logCh})
+	userSvc := service.NewUserService(users)
+
+	onCall := domain.User{Name: "Plantonista", Preferences: map[domain.NotificationEvent][]domain.NotificationChannel{
+		domain.EventCriticalBreakdown: {domain.ChannelLog, domain.ChannelSMS}, // SMS sem gateway: ignorado
+	}}
+	supervisor := domain.User{Name: "Supervisor", Preferences: map[domain.NotificationEvent][]domain.NotificationChannel{
+		domain.EventCriticalBreakdown: {domain.ChannelLog},
+	}}
+	manager := domain.User{Name: "Gerente", Preferences: map[domain.NotificationEvent][]domain.NotificationChannel{
+		domain.EventCriticalBreakdown: {domain.ChannelLog},
+	}}
+	for _, u := range []*domain.User{&onCall, &supervisor, &manager} {
+		if err := userSvc.Create(ctx, u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	err := notifications.ReplaceRules(ctx, []domain.EscalationRule{
+		{Event: domain.EventCriticalBreakdown, Tier: 1, DelayMinutes: 0, UserIDs: []int64{onCall.ID}},
+		{Event: domain.EventCriticalBreakdown, Tier: 2, DelayMinutes: 30, UserIDs: []int64{supervisor.ID}},
+		{Event: domain.EventCriticalBreakdown, Tier: 3, DelayMinutes: 120, UserIDs: []int64{manager.ID}},
+	})
+	if err != nil {
+		t.Fatalf("ReplaceRules() error = %v", err)
+	}
+	if err := notifications.ReplaceRules(ctx, []domain.EscalationRule{{Event: domain.EventLowStock, Tier: 1, UserIDs: []int64{99}}}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("rule with unknown user: expected ErrInvalidInput, got %v", err)
+	}
+
+	// Quebra há 45 min: primeiro e segundo níveis já venceram, o terceiro ainda não.
+	alert := domain.Alert{Event: domain.EventCriticalBreakdown, RefType: domain.AlertRefWorkOrder, RefID: 7,
+		Message: "Extrusora parada", TriggeredAt: time.Now().Add(-45 * time.Minute)}
+	if err := notifications.Raise(ctx, &alert); err != nil {
+		t.Fatalf("Raise() error = %v", err)
+	}
+	if n := len(logCh.Sent()); n != 0 {
+		t.Fatalf("Raise() sent %d messages, want them queued for the escalation", n)
+	}
+	if err := notifications.Escalate(ctx); err != nil {
+		t.Fatalf("Escalate() error = %v", err)
+	}
+	sent := logCh.Sent()
+	if len(sent) != 2 || sent[0].To.Name != "Plantonista" || sent[1].To.Name != "Supervisor" {
+		t.Fatalf("expected on-call and supervisor notified, got %+v", sent)
+	}
+	if open, _ := notifications.ListAlerts(ctx, true); len(open) != 1 || open[0].Tier != 2 {
+		t.Fatalf("expected alert at tier 2, got %+v", open)
+	}
+
+	// Gatilho repetido não duplica alerta nem mensagens.
+	again := domain.Alert{Event: domain.EventCriticalBreakdown, RefType: domain.AlertRefWorkOrder, RefID: 7, Message: "Extrusora parada"}
+	if err := notifications.Raise(ctx, &again); err != nil {
+		t.Fatalf("Raise() duplicate error = %v", err)
+	}
+	if err := notifications.Escalate(ctx); err != nil {
+		t.Fatalf("Escalate() error = %v", err)
+	}
+	if n := len(logCh.Sent()); n != 2 {
+		t.Fatalf("expected no new messages before tier 3 delay, got %d", n)
+	}
+
+	acked, err := notifications.Ack(ctx, alert.ID, supervisor.ID)
+	if err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+	if acked.AckedBy == nil || *acked.AckedBy != supervisor.ID {
+		t.Fatalf("unexpected acked alert: %+v", acked)
+	}
+	if _, err := notifications.Ack(ctx, alert.ID, onCall.ID); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second ack: expected ErrConflict, got %v", err)
+	}
+	if open, _ := notifications.ListAlerts(ctx, true); len(open) != 0 {
+		t.Fatalf("expected no escalating alerts after ack, got %+v", open)
+	}
+}
+
+// flakyChannel falha os primeiros envios (SMTP fora do ar de madrugada).
+type flakyChannel struct {
+	notify.Log
+	failures int
+}
+
+func (c *flakyChannel) Send(ctx context.Context, msg notify.Message) error {
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("smtp: connection refused")
+	}
+	return c.Log.Send(ctx, msg)
+}
+
+func TestNotificationService_RetriesFailedDelivery(t *testing.T) {
+	ctx := onSite(1)
+	users := memory.NewUserMemoryRepo()
+	email := &flakyChannel{failures: 2}
+	notifications := service.NewNotificationService(users, memory.NewEscalationMemoryRepo(), memory.NewAlertMemoryRepo(),
+		map[domain.NotificationChannel]notify.Channel{domain.ChannelEmail: email})
+
+	onCall := domain.User{Name: "Plantonista", Email: "plantao@example.com", Preferences: map[domain.NotificationEvent][]domain.NotificationChannel{
+		domain.EventCriticalBreakdown: {domain.ChannelEmail},
+	}}
+	manager := domain.User{Name: "Gerente", Email: "gerente@example.com", Preferences: map[domain.NotificationEvent][]domain.NotificationChannel{
+		domain.EventCriticalBreakdown: {domain.ChannelEmail},
+	}}
+	for _, u := range []*domain.User{&onCall, &manager} {
+		if err := service.NewUserService(users).Create(ctx, u); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	if err := notifications.ReplaceRules(ctx, []domain.EscalationRule{
+		{Event: domain.EventCriticalBreakdown, Tier: 1, UserIDs: []int64{onCall.ID}},
+		{Event: domain.EventCriticalBreakdown, Tier: 2, DelayMinutes: 30, UserIDs: []int64{manager.ID}},
+	}); err != nil {
+		t.Fatalf("ReplaceRules() error = %v", err)
+	}
+	alert := domain.Alert{Event: domain.EventCriticalBreakdown, RefType: domain.AlertRefWorkOrder, RefID: 3,
+		Message: "Extrusora parada", TriggeredAt: time.Now().Add(-time.Hour)}
+	if err := notifications.Raise(ctx, &alert); err != nil {
+		t.Fatalf("Raise() error = %v", err)
+	}
+
+	// Os dois primeiros ciclos falham no nível 1: nada avança e o nível 2 espera.
+	for i := 0; i < 2; i++ {
+		if err := notifications.Escalate(ctx); err == nil {
+			t.Fatalf("cycle %d: Escalate() error = nil, want the delivery failure", i)
+		}
+		if open, _ := notifications.ListAlerts(ctx, true); len(open) != 1 || open[0].Tier != 0 {
+			t.Fatalf("cycle %d: alert = %+v, want tier 0", i, open)
+		}
+	}
+	// O nível 1 venceu há 1h e o 2 há 30 min: o alerta conta no backlog do readiness.
+	if n, err := notifications.PendingDeliveries(ctx, 15*time.Minute); err != nil || n != 1 {
+		t.Fatalf("PendingDeliveries() = %d, %v; want 1", n, err)
+	}
+	if n, err := notifications.PendingDeliveries(ctx, 2*time.Hour); err != nil || n != 0 {
+		t.Fatalf("PendingDeliveries(2h) = %d, %v; want 0 within the grace period", n, err)
+	}
+	if err := notifications.Escalate(ctx); err != nil {
+		t.Fatalf("Escalate() after recovery error = %v", err)
+	}
+	if n, err := notifications.PendingDeliveries(ctx, 0); err != nil || n != 0 {
+		t.Fatalf("PendingDeliveries() after recovery = %d, %v; want 0", n, err)
+	}
+	sent := email.Sent()
+	if len(sent) != 2 || sent[0].To.Name != "Plantonista" || sent[1].To.Name != "Gerente" {
+		t.Fatalf("sent = %+v, want the on-call then the manager", sent)
+	}
+	if open, _ := notifications.ListAlerts(ctx, true); len(open) != 1 || open[0].Tier != 2 {
+		t.Fatalf("alert = %+v, want tier 2", open)
+	}
+}
+
+func TestNotificationTriggers_CriticalBreakdownAndLowStock(t *testing.T) {
+	ctx := onSite(1)
+	users := memory.NewUserMemoryRepo()
+	alerts := memory.NewAlertMemoryRepo()
+	logCh := &notify.Log{}
+	notifications := service.NewNotificationService(users, memory.NewEscalationMemoryRepo(), alerts,
+		map[domain.NotificationChannel]notify.Channel{domain.ChannelLog: logCh})
+
+	tech := domain.User{Name: "Técnico", Preferences: map[domain.NotificationEvent][]domain.NotificationChannel{
+		domain.EventCriticalBreakdown: {domain.ChannelLog},
+		domain.EventLowStock:          {domain.ChannelLog},
+	}}
+	if err := service.NewUserService(users).Create(ctx, &tech); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if err := notifications.ReplaceRules(ctx, []domain.EscalationRule{
+		{Event: domain.EventCriticalBreakdown, Tier: 1, UserIDs: []int64{tech.ID}},
+		{Event: domain.EventLowStock, Tier: 1, UserIDs: []int64{tech.ID}},
+	}); err != nil {
+		t.Fatalf("ReplaceRules() error = %v", err)
+	}
+
+	assets := memory.NewAssetMemoryRepo()
+	critical := domain.Asset{Name: "Rebobinadeira", Criticality: domain.CriticalityA}
+	minor := domain.Asset{Name: "Esteira", Criticality: domain.CriticalityC}
+	for _, a := range []*domain.Asset{&critical, &minor} {
+		if err := assets.Create(ctx, a); err != nil {
+			t.Fatalf("create asset: %v", err)
+		}
+	}
+	orders := service.NewWorkOrderService(memory.NewWorkOrderMemoryRepo(), service.WithAssets(assets), service.WithNotifier(notifications))
+	minorWO := domain.WorkOrder{AssetID: minor.ID, Title: "Correia gasta"}
+	criticalWO := domain.WorkOrder{AssetID: critical.ID, Title: "Rebobinadeira travada"}
+	for _, wo := range []*domain.WorkOrder{&minorWO, &criticalWO} {
+		if err := orders.Create(ctx, wo); err != nil {
+			t.Fatalf("create work order: %v", err)
+		}
+	}
+	if err := notifications.Escalate(ctx); err != nil {
+		t.Fatalf("Escalate() error = %v", err)
+	}
+	sent := logCh.Sent()
+	if len(sent) != 1 || sent[0].To.Name != "Técnico" {
+		t.Fatalf("expected one critical breakdown message, got %+v", sent)
+	}
+
+	// Fechar a OS resolve o alerta.
+	if _, err := orders.Transition(ctx, criticalWO.ID, service.TransitionRequest{Status: domain.WOStatusCanceled}); err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+	list, _ := notifications.ListAlerts(ctx, false)
+	if len(list) != 1 || list[0].ResolvedAt == nil {
+		t.Fatalf("expected resolved alert after closing the order, got %+v", list)
+	}
+
+	parts := service.NewSparePartService(memory.NewSparePartMemoryRepo(), notifications)
+	bearing := domain.SparePart{Code: "rol-6205", Name: "Rolamento 6205", Quantity: 5, MinQuantity: 4}
+	if err := parts.Create(ctx, &bearing); err != nil {
+		t.Fatalf("create spare part: %v", err)
+	}
+	if _, err := parts.AdjustStock(ctx, bearing.ID, -6); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("negative stock: expected ErrInvalidInput, got %v", err)
+	}
+	for _, delta := range []float64{-2, -1} { // dois consumos abaixo do mínimo: um alerta só
+		if _, err := parts.AdjustStock(ctx, bearing.ID, delta); err != nil {
+			t.Fatalf("AdjustStock(%v) error = %v", delta, err)
+		}
+		if err := notifications.Escalate(ctx); err != nil {
+			t.Fatalf("Escalate() error = %v", err)
+		}
+	}
+	if n := len(logCh.Sent()); n != 2 {
+		t.Fatalf("expected one low stock message, got %d messages", n)
+	}
+	if _, err := parts.AdjustStock(ctx, bearing.ID, 10); err != nil {
+		t.Fatalf("AdjustStock(+10) error = %v", err)
+	}
+	if open, _ := notifications.ListAlerts(ctx, true); len(open) != 0 {
+		t.Fatalf("expected low stock alert resolved after restock, got %+v", open)
+	}
+}
+
+func TestSLAMonitor_RaisesPreventiveOverdueAndAtRiskAlerts(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	repo := memory.NewWorkOrderMemoryRepo()
+	alerts := memory.NewAlertMemoryRepo()
+	notifications := service.NewNotificationService(memory.NewUserMemoryRepo(), memory.NewEscalationMemoryRepo(), alerts,
+		map[domain.NotificationChannel]notify.Channel{})
+	orders := service.NewWorkOrderService(repo, service.WithAssets(assets), service.WithSLA(memory.NewSLAMemoryRepo()))
+
+	asset := domain.Asset{Name: "Bobinadeira", Criticality: domain.CriticalityA}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	// Preventiva A: 3 dias para concluir; quebra corretiva A: 4 h, aberta há 3h30.
+	longAgo := time.Now().Add(-4 * 24 * time.Hour)
+	recent := time.Now().Add(-210 * time.Minute)
+	preventive := domain.WorkOrder{AssetID: asset.ID, Title: "Lubrificação", Type: domain.WOTypePreventive, BreakdownAt: &longAgo}
+	corrective := domain.WorkOrder{AssetID: asset.ID, Title: "Motor aquecendo", BreakdownAt: &recent}
+	for _, wo := range []*domain.WorkOrder{&preventive, &corrective} {
+		if err := orders.Create(ctx, wo); err != nil {
+			t.Fatalf("create work order: %v", err)
+		}
+	}
+	if _, err := orders.Transition(ctx, corrective.ID, service.TransitionRequest{Status: domain.WOStatusInProgress}); err != nil {
+		t.Fatalf("Transition() error = %v", err)
+	}
+
+	monitor := service.NewSLAMonitor(repo, time.Minute, service.WithSLAAlerts(notifications, time.Hour))
+	if _, err := monitor.Check(ctx); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	list, _ := notifications.ListAlerts(ctx, true)
+	events := map[domain.NotificationEvent]int64{}
+	for _, a := range list {
+		events[a.Event] = a.RefID
+	}
+	if len(list) != 2 || events[domain.EventPreventiveOverdue] != preventive.ID || events[domain.EventSLAAtRisk] != corrective.ID {
+		t.Fatalf("unexpected alerts: %+v", list)
+	}
+}
+
+func TestNotificationService_RulesPerSite(t *testing.T) {
+	site1, site2 := onSite(1), onSite(2)
+	users := memory.NewUserMemoryRepo()
+	logCh := &notify.Log{}
+	notifications := service.NewNotificationService(users, memory.NewEscalationMemoryRepo(), memory.NewAlertMemoryRepo(),
+		map[domain.NotificationChannel]notify.Channel{domain.ChannelLog: logCh})
+
+	prefs := map[domain.NotificationEvent][]domain.NotificationChannel{domain.EventCriticalBreakdown: {domain.ChannelLog}}
+	north := domain.User{Name: "Plantão matriz", Preferences: prefs}
+	south := domain.User{Name: "Plantão sul", Preferences: prefs}
+	userSvc := service.NewUserService(users)
+	if err := userSvc.Create(site1, &north); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if err := userSvc.Create(site2, &south); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if err := notifications.ReplaceRules(site1, []domain.EscalationRule{
+		{Event: domain.EventCriticalBreakdown, Tier: 1, UserIDs: []int64{north.ID}},
+	}); err != nil {
+		t.Fatalf("ReplaceRules(site 1) error = %v", err)
+	}
+	if err := notifications.ReplaceRules(site2, []domain.EscalationRule{
+		{Event: domain.EventCriticalBreakdown, Tier: 1, UserIDs: []int64{north.ID}},
+	}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("site 2 rule with a site 1 user: err = %v, want ErrInvalidInput", err)
+	}
+	if err := notifications.ReplaceRules(site2, []domain.EscalationRule{
+		{Event: domain.EventCriticalBreakdown, Tier: 1, UserIDs: []int64{south.ID}},
+	}); err != nil {
+		t.Fatalf("ReplaceRules(site 2) error = %v", err)
+	}
+
+	// Trocar as regras de um site não apaga as do outro.
+	for site, ctx := range map[int64]context.Context{1: site1, 2: site2} {
+		rules, err := notifications.ListRules(ctx)
+		if err != nil || len(rules) != 1 || rules[0].SiteID != site {
+			t.Fatalf("site %d rules = %+v, %v; want only its own", site, rules, err)
+		}
+	}
+
+	// Quebra no site 2: o escalonamento (sem principal) avisa só o plantão do site 2.
+	alert := domain.Alert{Event: domain.EventCriticalBreakdown, RefType: domain.AlertRefWorkOrder, RefID: 5, Message: "Prensa parada"}
+	if err := notifications.Raise(site2, &alert); err != nil {
+		t.Fatalf("Raise() error = %v", err)
+	}
+	if err := notifications.Escalate(tenant.System(context.Background())); err != nil {
+		t.Fatalf("Escalate() error = %v", err)
+	}
+	if sent := logCh.Sent(); len(sent) != 1 || sent[0].To.Name != south.Name {
+		t.Fatalf("sent = %+v, want only the site 2 on-call", sent)
+	}
+}
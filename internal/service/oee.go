@@ -0,0 +1,273 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// oeeAcc acumula os tempos e contagens de um ativo/linha em um período.
+type oeeAcc struct {
+	planned  time.Duration
+	downtime time.Duration
+	run      time.Duration
+	rateRun  time.Duration // run apenas dos ativos com cadência ideal cadastrada
+	ideal    time.Duration // tempo ideal para produzir o total apontado
+	total    int64
+	good     int64
+}
+
+func (a *oeeAcc) add(b *oeeAcc) {
+	a.planned += b.planned
+	a.downtime += b.downtime
+	a.run += b.run
+	a.rateRun += b.rateRun
+	a.ideal += b.ideal
+	a.total += b.total
+	a.good += b.good
+}
+
+// fill calcula Disponibilidade × Performance × Qualidade a partir das somas,
+// o que pondera corretamente o consolidado por linha.
+func (a *oeeAcc) fill(row *domain.OEERow) {
+	row.PlannedMinutes = roundTo(a.planned.Minutes(), 1)
+	row.DowntimeMinutes = roundTo(a.downtime.Minutes(), 1)
+	row.RunMinutes = roundTo(a.run.Minutes(), 1)
+	row.TotalCount, row.GoodCount = a.total, a.good
+
+	if a.planned > 0 {
+		row.Availability = ratio(float64(a.run), float64(a.planned))
+	}
+	if a.rateRun > 0 {
+		row.Performance = ratio(float64(a.ideal), float64(a.rateRun))
+	}
+	if a.total > 0 {
+		row.Quality = ratio(float64(a.good), float64(a.total))
+	}
+	if row.Availability != nil && row.Performance != nil && row.Quality != nil {
+		v := roundTo(*row.Availability**row.Performance**row.Quality, 4)
+		row.OEE = &v
+	}
+}
+
+type oeeBucket struct {
+	start time.Time
+	shift string
+}
+
+type oeeRowKey struct {
+	bucket   oeeBucket
+	assetID  int64
+	location string
+}
+
+// computeOEE distribui turnos, paradas e apontamentos de cada ativo nos períodos
+// da granularidade pedida. Regras:
+//   - tempo planejado = turno recortado em [from, to) - pausas (proporcionais) - paradas programadas;
+//   - paradas abertas contam até now; só o trecho dentro do turno entra;
+//   - o apontamento de produção vai para o turno que contém o seu ponto médio
+//     (produção fora do calendário não entra no OEE);
+//   - o período (dia/semana) de um turno é o do seu início, no fuso loc.
+func computeOEE(in *domain.OEEInputs, from, to time.Time, g domain.OEEGranularity, loc *time.Location, now time.Time) *domain.OEEReport {
+	events := map[int64][]domain.DowntimeEvent{}
+	for _, e := range in.Downtime {
+		events[e.AssetID] = append(events[e.AssetID], e)
+	}
+	counts := map[int64][]domain.ProductionCount{}
+	for _, p := range in.Counts {
+		counts[p.AssetID] = append(counts[p.AssetID], p)
+	}
+
+	windowsByLocation := map[string][]domain.ShiftWindow{}
+	windowsFor := func(location string) []domain.ShiftWindow {
+		if w, ok := windowsByLocation[location]; ok {
+			return w
+		}
+		var w []domain.ShiftWindow
+		for _, s := range shiftsFor(in.Shifts, location) {
+			w = append(w, s.Windows(from, to, loc)...)
+		}
+		windowsByLocation[location] = w
+		return w
+	}
+
+	assetAcc := map[oeeRowKey]*oeeAcc{}
+	locationAcc := map[oeeRowKey]*oeeAcc{}
+	assetInfo := map[int64]*domain.Asset{}
+
+	for i := range in.Assets {
+		asset := &in.Assets[i]
+		assetInfo[asset.ID] = asset
+
+		for _, w := range windowsFor(asset.Location) {
+			start, end := maxTime(w.Start, from), minTime(w.End, to)
+			if !start.Before(end) {
+				continue
+			}
+			span := end.Sub(start)
+			breaks := time.Duration(float64(time.Duration(w.Shift.BreakMinutes)*time.Minute) * float64(span) / float64(w.End.Sub(w.Start)))
+
+			var plannedStop, unplannedStop time.Duration
+			for _, e := range events[asset.ID] {
+				d := overlap(e.StartedAt, eventEnd(&e, now), start, end)
+				if e.Planned {
+					plannedStop += d
+				} else {
+					unplannedStop += d
+				}
+			}
+
+			acc := &oeeAcc{planned: max(span-breaks-plannedStop, 0)}
+			acc.downtime = min(unplannedStop, acc.planned)
+			acc.run = acc.planned - acc.downtime
+			for _, p := range counts[asset.ID] {
+				if mid := p.Midpoint(); !mid.Before(start) && mid.Before(end) {
+					acc.total += p.TotalCount
+					acc.good += p.GoodCount
+				}
+			}
+			if asset.IdealRatePerHour != nil && *asset.IdealRatePerHour > 0 {
+				acc.rateRun = acc.run
+				acc.ideal = time.Duration(float64(acc.total) / *asset.IdealRatePerHour * float64(time.Hour))
+			}
+
+			b := bucketOf(w, g, loc)
+			accumulate(assetAcc, oeeRowKey{bucket: b, assetID: asset.ID, location: asset.Location}, acc)
+			accumulate(locationAcc, oeeRowKey{bucket: b, location: asset.Location}, acc)
+		}
+	}
+
+	report := &domain.OEEReport{Granularity: g, From: from, To: to}
+	report.Assets = oeeRows(assetAcc, g, loc, func(row *domain.OEERow, k oeeRowKey) {
+		row.AssetID = k.assetID
+		row.AssetName = assetInfo[k.assetID].Name
+	})
+	report.Locations = oeeRows(locationAcc, g, loc, nil)
+	return report
+}
+
+// shiftsFor retorna os turnos da linha ou, se ela não tiver calendário próprio, os da planta.
+func shiftsFor(shifts []domain.Shift, location string) []*domain.Shift {
+	var own, plant []*domain.Shift
+	for i := range shifts {
+		switch shifts[i].Location {
+		case location:
+			own = append(own, &shifts[i])
+		case "":
+			plant = append(plant, &shifts[i])
+		}
+	}
+	if len(own) > 0 {
+		return own
+	}
+	return plant
+}
+
+func bucketOf(w domain.ShiftWindow, g domain.OEEGranularity, loc *time.Location) oeeBucket {
+	t := w.Start.In(loc)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	switch g {
+	case domain.OEEByShift:
+		return oeeBucket{start: w.Start, shift: w.Shift.Name}
+	case domain.OEEByWeek:
+		return oeeBucket{start: weekStart(day, loc)}
+	default:
+		return oeeBucket{start: day}
+	}
+}
+
+func periodLabel(b oeeBucket, g domain.OEEGranularity, loc *time.Location) string {
+	t := b.start.In(loc)
+	switch g {
+	case domain.OEEByShift:
+		return t.Format("2006-01-02") + " " + b.shift
+	case domain.OEEByWeek:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+func accumulate(m map[oeeRowKey]*oeeAcc, k oeeRowKey, acc *oeeAcc) {
+	cur, ok := m[k]
+	if !ok {
+		cur = &oeeAcc{}
+		m[k] = cur
+	}
+	cur.add(acc)
+}
+
+func oeeRows(m map[oeeRowKey]*oeeAcc, g domain.OEEGranularity, loc *time.Location, decorate func(*domain.OEERow, oeeRowKey)) []domain.OEERow {
+	rows := make([]domain.OEERow, 0, len(m))
+	for k, acc := range m {
+		row := domain.OEERow{
+			Period:      periodLabel(k.bucket, g, loc),
+			PeriodStart: k.bucket.start,
+			Shift:       k.bucket.shift,
+			Location:    k.location,
+		}
+		if decorate != nil {
+			decorate(&row, k)
+		}
+		acc.fill(&row)
+		rows = append(rows, row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if !a.PeriodStart.Equal(b.PeriodStart) {
+			return a.PeriodStart.Before(b.PeriodStart)
+		}
+		if a.Shift != b.Shift {
+			return a.Shift < b.Shift
+		}
+		if a.Location != b.Location {
+			return a.Location < b.Location
+		}
+		return a.AssetID < b.AssetID
+	})
+	return rows
+}
+
+func eventEnd(e *domain.DowntimeEvent, now time.Time) time.Time {
+	if e.EndedAt != nil {
+		return *e.EndedAt
+	}
+	return now
+}
+
+// overlap retorna a duração de [a1, a2) ∩ [b1, b2).
+func overlap(a1, a2, b1, b2 time.Time) time.Duration {
+	d := minTime(a2, b2).Sub(maxTime(a1, b1))
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func ratio(num, den float64) *float64 {
+	v := roundTo(num/den, 4)
+	return &v
+}
+
+func roundTo(v float64, decimals int) float64 {
+	p := math.Pow10(decimals)
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// maxPlanningWeeks limita o horizonte do backlog e do sequenciamento.
+const maxPlanningWeeks = 12
+
+// PlanningService compara o backlog de OS em aberto com a capacidade dos técnicos
+// (turnos no calendário da planta) e propõe datas de execução.
+type PlanningService struct {
+	orders      repository.WorkOrderRepository
+	assets      repository.AssetRepository
+	technicians repository.TechnicianRepository
+	shifts      repository.ShiftRepository
+	calendar    CalendarSource
+}
+
+func NewPlanningService(orders repository.WorkOrderRepository, assets repository.AssetRepository,
+	technicians repository.TechnicianRepository, shifts repository.ShiftRepository, calendar CalendarSource) *PlanningService {
+	return &PlanningService{orders: orders, assets: assets, technicians: technicians, shifts: shifts, calendar: calendar}
+}
+
+// planningInputs é o retrato usado por backlog e sequenciamento.
+type planningInputs struct {
+	cal         *domain.Calendar
+	orders      []domain.WorkOrder
+	criticality map[int64]domain.Criticality
+	technicians []domain.Technician // só ativos
+	shifts      map[int64]*domain.Shift
+}
+
+func (s *PlanningService) load(ctx context.Context) (*planningInputs, error) {
+	cal, err := s.calendar.Calendar(ctx)
+	if err != nil {
+		return nil, err
+	}
+	in := &planningInputs{cal: cal, criticality: map[int64]domain.Criticality{}, shifts: map[int64]*domain.Shift{}}
+
+	err = s.orders.Stream(ctx, domain.WorkOrderFilter{}, func(o *domain.WorkOrder) error {
+		if o.IsOpen() {
+			in.orders = append(in.orders, *o)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	assets, err := s.assets.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assets {
+		in.criticality[a.ID] = a.Criticality
+	}
+	shifts, err := s.shifts.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range shifts {
+		in.shifts[shifts[i].ID] = &shifts[i]
+	}
+	technicians, err := s.technicians.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range technicians {
+		if t.Active && in.shifts[t.ShiftID] != nil {
+			in.technicians = append(in.technicians, t)
+		}
+	}
+	return in, nil
+}
+
+// availability é o tempo de trabalho do técnico em [from, to).
+func (in *planningInputs) availability(t *domain.Technician, from, to time.Time) []domain.WorkingWindow {
+	return in.cal.ShiftWindows(in.shifts[t.ShiftID], from, to)
+}
+
+// Backlog agrupa as OS em aberto por semana (a partir da semana de from), especialidade e
+// criticidade do ativo, e compara a carga de cada especialidade com a capacidade dos técnicos.
+func (s *PlanningService) Backlog(ctx context.Context, from time.Time, weeks int) (*domain.BacklogReport, error) {
+	ctx, span := tracer.Start(ctx, "PlanningService.Backlog")
+	defer span.End()
+
+	if weeks < 1 || weeks > maxPlanningWeeks {
+		return nil, domain.ErrInvalidInput
+	}
+	in, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	loc := in.cal.Location
+	start := weekStart(from, loc)
+	end := start.AddDate(0, 0, 7*weeks)
+	report := &domain.BacklogReport{From: start, To: end, Rows: []domain.BacklogRow{}, Capacity: []domain.CapacityRow{}}
+
+	type rowKey struct {
+		week        string
+		trade       string
+		criticality domain.Criticality
+	}
+	type capKey struct{ week, trade string }
+	rows := map[rowKey]*domain.BacklogRow{}
+	demand := map[capKey]time.Duration{}
+	trades := map[string]bool{}
+	for i := range in.orders {
+		o := &in.orders[i]
+		at := start
+		if o.ScheduledFor != nil && o.ScheduledFor.After(start) {
+			at = *o.ScheduledFor
+		}
+		if !at.Before(end) {
+			continue
+		}
+		week := weekStart(at, loc).Format(time.DateOnly)
+		k := rowKey{week, o.Trade, in.criticality[o.AssetID]}
+		row := rows[k]
+		if row == nil {
+			row = &domain.BacklogRow{Week: k.week, Trade: k.trade, Criticality: k.criticality}
+			rows[k] = row
+		}
+		row.Orders++
+		row.EstimatedHours += o.EstimatedWork().Hours()
+		demand[capKey{week, o.Trade}] += o.EstimatedWork()
+		trades[o.Trade] = true
+		if o.EstimatedMinutes == nil {
+			report.Unestimated++
+		}
+	}
+	for _, row := range rows {
+		row.EstimatedHours = roundTo(row.EstimatedHours, 2)
+		report.Rows = append(report.Rows, *row)
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if a.Week != b.Week {
+			return a.Week < b.Week
+		}
+		if a.Trade != b.Trade {
+			return a.Trade < b.Trade
+		}
+		return a.Criticality < b.Criticality
+	})
+
+	for _, t := range in.technicians {
+		for _, skill := range t.Skills {
+			trades[skill] = true
+		}
+	}
+	tradeList := make([]string, 0, len(trades))
+	for t := range trades {
+		tradeList = append(tradeList, t)
+	}
+	sort.Strings(tradeList)
+
+	for ws := start; ws.Before(end); ws = ws.AddDate(0, 0, 7) {
+		we := ws.AddDate(0, 0, 7)
+		week := ws.Format(time.DateOnly)
+		hours := make([]time.Duration, len(in.technicians))
+		for i := range in.technicians {
+			for _, w := range in.availability(&in.technicians[i], ws, we) {
+				hours[i] += w.End.Sub(w.Start)
+			}
+		}
+		for _, trade := range tradeList {
+			row := domain.CapacityRow{Week: week, Trade: trade}
+			var available time.Duration
+			for i := range in.technicians {
+				if in.technicians[i].HasSkill(trade) {
+					row.Technicians++
+					available += hours[i]
+				}
+			}
+			need := demand[capKey{week, trade}]
+			row.AvailableHours = roundTo(available.Hours(), 2)
+			row.DemandHours = roundTo(need.Hours(), 2)
+			row.BalanceHours = roundTo((available - need).Hours(), 2)
+			report.Capacity = append(report.Capacity, row)
+		}
+	}
+	return report, nil
+}
+
+// priorityRank ordena o sequenciamento: urgente primeiro, OS sem prioridade por último.
+var priorityRank = map[domain.Priority]int{
+	domain.PriorityUrgent: 0,
+	domain.PriorityHigh:   1,
+	domain.PriorityNormal: 2,
+	domain.PriorityLow:    3,
+}
+
+func rankOf(p domain.Priority) int {
+	if r, ok := priorityRank[p]; ok {
+		return r
+	}
+	return len(priorityRank)
+}
+
+// Schedule propõe técnico e datas para as OS em aberto em [from, from+weeks) com um
+// sequenciamento guloso: por prioridade e prazo de conclusão, cada OS vai para o técnico
+// da especialidade que termina primeiro, ocupando o tempo livre dos turnos (fora de
+// feriados e paradas programadas) a partir da data programada. Nada é gravado.
+func (s *PlanningService) Schedule(ctx context.Context, from time.Time, weeks int) (*domain.ScheduleProposal, error) {
+	ctx, span := tracer.Start(ctx, "PlanningService.Schedule")
+	defer span.End()
+
+	if weeks < 1 || weeks > maxPlanningWeeks {
+		return nil, domain.ErrInvalidInput
+	}
+	in, err := s.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	to := from.AddDate(0, 0, 7*weeks)
+	proposal := &domain.ScheduleProposal{From: from, To: to, Assignments: []domain.Assignment{}, Unassigned: []domain.UnassignedOrder{}}
+
+	free := make([][]domain.WorkingWindow, len(in.technicians))
+	for i := range in.technicians {
+		free[i] = in.availability(&in.technicians[i], from, to)
+	}
+
+	orders := in.orders
+	sort.SliceStable(orders, func(i, j int) bool {
+		a, b := &orders[i], &orders[j]
+		if ra, rb := rankOf(a.Priority), rankOf(b.Priority); ra != rb {
+			return ra < rb
+		}
+		if da, db := a.ResolutionDueAt, b.ResolutionDueAt; (da == nil) != (db == nil) {
+			return da != nil
+		} else if da != nil && !da.Equal(*db) {
+			return da.Before(*db)
+		}
+		return a.ID < b.ID
+	})
+
+	for i := range orders {
+		o := &orders[i]
+		earliest := from
+		if o.ScheduledFor != nil && o.ScheduledFor.After(from) {
+			earliest = *o.ScheduledFor
+		}
+		if !earliest.Before(to) {
+			continue // programada para depois do horizonte
+		}
+
+		best, skilled := -1, false
+		var bestStart, bestEnd time.Time
+		var bestRest []domain.WorkingWindow
+		for t := range in.technicians {
+			if !in.technicians[t].HasSkill(o.Trade) {
+				continue
+			}
+			skilled = true
+			start, end, rest, ok := allocate(free[t], earliest, o.EstimatedWork())
+			if ok && (best < 0 || end.Before(bestEnd)) {
+				best, bestStart, bestEnd, bestRest = t, start, end, rest
+			}
+		}
+		if best < 0 {
+			reason := domain.UnassignedNoCapacity
+			if !skilled {
+				reason = domain.UnassignedNoSkill
+			}
+			proposal.Unassigned = append(proposal.Unassigned, domain.UnassignedOrder{WorkOrderID: o.ID, Trade: o.Trade, Reason: reason})
+			continue
+		}
+
+		free[best] = bestRest
+		tech := &in.technicians[best]
+		proposal.Assignments = append(proposal.Assignments, domain.Assignment{
+			WorkOrderID:    o.ID,
+			AssetID:        o.AssetID,
+			Title:          o.Title,
+			Trade:          o.Trade,
+			Priority:       o.Priority,
+			TechnicianID:   tech.ID,
+			TechnicianName: tech.Name,
+			Start:          bestStart,
+			End:            bestEnd,
+			EstimatedHours: roundTo(o.EstimatedWork().Hours(), 2),
+			LateForSLA:     o.ResolutionDueAt != nil && bestEnd.After(*o.ResolutionDueAt),
+		})
+	}
+	return proposal, nil
+}
+
+// allocate reserva need de tempo livre a partir de earliest, podendo dividir o trabalho
+// entre janelas; retorna o início e o fim do trabalho e as janelas que sobram livres.
+func allocate(windows []domain.WorkingWindow, earliest time.Time, need time.Duration) (start, end time.Time, rest []domain.WorkingWindow, ok bool) {
+	rest = make([]domain.WorkingWindow, 0, len(windows)+1)
+	for _, w := range windows {
+		if need <= 0 || !w.End.After(earliest) {
+			rest = append(rest, w)
+			continue
+		}
+		from := w.Start
+		if from.Before(earliest) {
+			rest = append(rest, domain.WorkingWindow{Start: w.Start, End: earliest})
+			from = earliest
+		}
+		if start.IsZero() {
+			start = from
+		}
+		take := w.End.Sub(from)
+		if take > need {
+			take = need
+		}
+		need -= take
+		end = from.Add(take)
+		if end.Before(w.End) {
+			rest = append(rest, domain.WorkingWindow{Start: end, End: w.End})
+		}
+	}
+	return start, end, rest, need <= 0
+}
+
+// weekStart retorna a segunda-feira 00:00 (no fuso loc) da semana de t (semana ISO).
+func weekStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+func TestPlanningService_BacklogCapacityAndSchedule(t *testing.T) {
+	ctx := onSite(1)
+	loc := plant.Location()
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	shifts := memory.NewShiftMemoryRepo()
+	technicians := memory.NewTechnicianMemoryRepo()
+	calendar := service.NewCalendarService(memory.NewCalendarMemoryRepo(), shifts)
+	techSvc := service.NewTechnicianService(technicians, shifts)
+	svc := service.NewPlanningService(orders, assets, technicians, shifts, calendar)
+
+	critical := domain.Asset{Name: "Prensa", Criticality: domain.CriticalityA}
+	minor := domain.Asset{Name: "Exaustor", Criticality: domain.CriticalityC}
+	for _, a := range []*domain.Asset{&critical, &minor} {
+		if err := assets.Create(ctx, a); err != nil {
+			t.Fatalf("create asset: %v", err)
+		}
+	}
+
+	// Turno de 9h com 1h de pausa: 8h úteis por dia; a quarta é parada programada.
+	shift := domain.Shift{Name: "Manutenção", StartTime: "08:00", EndTime: "17:00", Weekdays: []int{1, 2, 3, 4, 5}, BreakMinutes: 60}
+	if err := shifts.Create(ctx, &shift); err != nil {
+		t.Fatalf("create shift: %v", err)
+	}
+	if err := calendar.AddShutdown(ctx, &domain.Shutdown{
+		StartsAt: time.Date(2025, 11, 19, 0, 0, 0, 0, loc),
+		EndsAt:   time.Date(2025, 11, 20, 0, 0, 0, 0, loc),
+		Reason:   "Inventário",
+	}); err != nil {
+		t.Fatalf("add shutdown: %v", err)
+	}
+
+	if err := techSvc.Create(ctx, &domain.Technician{Name: "Ana", Skills: []string{" Mecanica "}, ShiftID: 99}); err != domain.ErrInvalidInput {
+		t.Fatalf("unknown shift expected ErrInvalidInput, got %v", err)
+	}
+	ana := domain.Technician{Name: "Ana", Skills: []string{" Mecanica "}, ShiftID: shift.ID}
+	bia := domain.Technician{Name: "Bia", Skills: []string{"eletrica"}, ShiftID: shift.ID}
+	for _, tech := range []*domain.Technician{&ana, &bia} {
+		if err := techSvc.Create(ctx, tech); err != nil {
+			t.Fatalf("create technician: %v", err)
+		}
+	}
+	if ana.Skills[0] != "mecanica" {
+		t.Fatalf("skills should be normalized, got %v", ana.Skills)
+	}
+
+	minutes := func(m int64) *int64 { return &m }
+	monday := time.Date(2025, 11, 17, 0, 0, 0, 0, loc)
+	dueSoon := monday.Add(9 * time.Hour)
+	nextWeek := monday.AddDate(0, 0, 7).Add(9 * time.Hour)
+	seed := []*domain.WorkOrder{
+		{AssetID: critical.ID, Title: "Vazamento no cilindro", Trade: "mecanica", EstimatedMinutes: minutes(600), Priority: domain.PriorityNormal},
+		{AssetID: minor.ID, Title: "Correia rompida", Trade: "mecanica", EstimatedMinutes: minutes(120), Priority: domain.PriorityUrgent, ResolutionDueAt: &dueSoon},
+		{AssetID: critical.ID, Title: "Sensor intermitente", Trade: "eletrica", Priority: domain.PriorityHigh},
+		{AssetID: minor.ID, Title: "Mangueira ressecada", Trade: "hidraulica", EstimatedMinutes: minutes(60), Priority: domain.PriorityNormal},
+		{AssetID: minor.ID, Title: "Reforma do exaustor", Trade: "mecanica", EstimatedMinutes: minutes(2400), Priority: domain.PriorityLow},
+		{AssetID: critical.ID, Title: "Preventiva: plano #1", Type: domain.WOTypePreventive, Trade: "mecanica", EstimatedMinutes: minutes(60), ScheduledFor: &nextWeek},
+		{AssetID: critical.ID, Title: "Já concluída", Trade: "mecanica", Status: domain.WOStatusDone},
+	}
+	for _, o := range seed {
+		o.Normalize()
+		if err := orders.Create(ctx, o); err != nil {
+			t.Fatalf("create work order: %v", err)
+		}
+	}
+
+	report, err := svc.Backlog(ctx, monday.AddDate(0, 0, 2), 2)
+	if err != nil {
+		t.Fatalf("Backlog() error = %v", err)
+	}
+	if !report.From.Equal(monday) || report.Unestimated != 1 || len(report.Rows) != 5 {
+		t.Fatalf("unexpected backlog: %+v", report)
+	}
+	if r := report.Rows[3]; r.Week != "2025-11-17" || r.Trade != "mecanica" || r.Criticality != domain.CriticalityC || r.Orders != 2 || r.EstimatedHours != 42 {
+		t.Fatalf("unexpected mechanical C row: %+v", r)
+	}
+	if r := report.Rows[4]; r.Week != "2025-11-24" || r.EstimatedHours != 1 {
+		t.Fatalf("scheduled preventive should fall in its own week: %+v", r)
+	}
+	want := []domain.CapacityRow{
+		{Week: "2025-11-17", Trade: "eletrica", Technicians: 1, AvailableHours: 32, DemandHours: 2, BalanceHours: 30},
+		{Week: "2025-11-17", Trade: "hidraulica", Technicians: 0, AvailableHours: 0, DemandHours: 1, BalanceHours: -1},
+		{Week: "2025-11-17", Trade: "mecanica", Technicians: 1, AvailableHours: 32, DemandHours: 52, BalanceHours: -20},
+	}
+	for i, w := range want {
+		if report.Capacity[i] != w {
+			t.Fatalf("capacity[%d] = %+v, want %+v", i, report.Capacity[i], w)
+		}
+	}
+	if c := report.Capacity[5]; c.Week != "2025-11-24" || c.AvailableHours != 40 || c.DemandHours != 1 {
+		t.Fatalf("unexpected next week capacity: %+v", c)
+	}
+
+	proposal, err := svc.Schedule(ctx, monday, 1)
+	if err != nil {
+		t.Fatalf("Schedule() error = %v", err)
+	}
+	if len(proposal.Assignments) != 3 || len(proposal.Unassigned) != 2 {
+		t.Fatalf("unexpected proposal: %+v", proposal)
+	}
+	at := func(day, hour int) time.Time { return time.Date(2025, 11, day, hour, 0, 0, 0, loc) }
+	first, second, third := proposal.Assignments[0], proposal.Assignments[1], proposal.Assignments[2]
+	if first.WorkOrderID != seed[1].ID || first.TechnicianID != ana.ID || !first.Start.Equal(at(17, 8)) || !first.End.Equal(at(17, 10)) || !first.LateForSLA {
+		t.Fatalf("urgent order should go first to Ana: %+v", first)
+	}
+	if second.WorkOrderID != seed[2].ID || second.TechnicianID != bia.ID || !second.End.Equal(at(17, 10)) {
+		t.Fatalf("electrical order should go to Bia: %+v", second)
+	}
+	// 10h: 6h na segunda (até a pausa descontada) e 4h na terça.
+	if third.WorkOrderID != seed[0].ID || !third.Start.Equal(at(17, 10)) || !third.End.Equal(at(18, 12)) {
+		t.Fatalf("unexpected split assignment: %+v", third)
+	}
+	if u := proposal.Unassigned; u[0].WorkOrderID != seed[3].ID || u[0].Reason != domain.UnassignedNoSkill ||
+		u[1].WorkOrderID != seed[4].ID || u[1].Reason != domain.UnassignedNoCapacity {
+		t.Fatalf("unexpected unassigned orders: %+v", u)
+	}
+
+	if _, err := svc.Backlog(ctx, monday, 0); err != domain.ErrInvalidInput {
+		t.Fatalf("zero weeks expected ErrInvalidInput, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// PreventiveScheduler gera as OS preventivas dos planos por tempo. O vencimento
+// do plano (última execução + frequência) é empurrado para o próximo tempo útil
+// do calendário do site do plano, e a OS é aberta com lead de antecedência.
+type PreventiveScheduler struct {
+	plans      repository.MaintenancePlanRepository
+	orders     repository.WorkOrderRepository
+	workOrders *WorkOrderService
+	calendar   CalendarSource
+	interval   time.Duration
+	lead       time.Duration
+	now        func() time.Time
+}
+
+func NewPreventiveScheduler(plans repository.MaintenancePlanRepository, orders repository.WorkOrderRepository,
+	workOrders *WorkOrderService, calendar CalendarSource, interval, lead time.Duration) *PreventiveScheduler {
+	return &PreventiveScheduler{
+		plans:      plans,
+		orders:     orders,
+		workOrders: workOrders,
+		calendar:   calendar,
+		interval:   interval,
+		lead:       lead,
+		now:        time.Now,
+	}
+}
+
+// Check roda uma varredura e retorna as OS geradas nela. Planos que já têm
+// OS em aberto não geram outra.
+func (p *PreventiveScheduler) Check(ctx context.Context) ([]domain.WorkOrder, error) {
+	ctx, span := tracer.Start(ctx, "PreventiveScheduler.Check")
+	defer span.End()
+
+	plans, err := p.plans.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := p.openByPlan(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	calendars := newCalendarCache(p.calendar)
+	limit := p.now().Add(p.lead)
+	var created []domain.WorkOrder
+	for _, plan := range plans {
+		due, ok := plan.NextDue()
+		if !plan.Active || !ok || *plan.FrequencyDays <= 0 || pending[plan.ID] != nil {
+			continue
+		}
+		cal, err := calendars.site(ctx, plan.SiteID)
+		if err != nil {
+			return created, err
+		}
+		scheduled := cal.NextWorkingTime(due)
+		if scheduled.After(limit) {
+			continue
+		}
+		order := planOrder(&plan, domain.WOTypePreventive, planTitle(&plan),
+			fmt.Sprintf("Gerada pelo agendador: vencimento do plano em %s.", due.In(cal.Location).Format(time.DateOnly)))
+		order.ScheduledFor = &scheduled
+		err = p.workOrders.Create(ctx, order)
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			continue // outra instância gerou a mesma OS
+		}
+		if err != nil {
+			return created, err
+		}
+		log.WithFields(log.Fields{
+			"work_order_id": order.ID,
+			"plan_id":       plan.ID,
+			"asset_id":      plan.AssetID,
+			"scheduled_for": scheduled,
+		}).Info("preventive work order scheduled")
+		created = append(created, *order)
+	}
+	return created, nil
+}
+
+// Run executa Check a cada intervalo até ctx ser cancelado, sinalizando hb (pode
+// ser nil) com o resultado de cada ciclo.
+func (p *PreventiveScheduler) Run(ctx context.Context, hb Beater) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		_, err := p.Check(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("preventive scheduler check failed")
+		}
+		beat(ctx, hb, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Forecast lista as preventivas do ativo programadas em [from, to): as OS em aberto
+// geradas pelo agendador e as próximas execuções projetadas dos planos ativos.
+func (p *PreventiveScheduler) Forecast(ctx context.Context, assetID int64, from, to time.Time) ([]domain.PreventiveOccurrence, error) {
+	ctx, span := tracer.Start(ctx, "PreventiveScheduler.Forecast")
+	defer span.End()
+
+	if !from.Before(to) || to.Sub(from) > maxCalendarPeriod {
+		return nil, domain.ErrInvalidInput
+	}
+	plans, err := p.plans.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := p.openByPlan(ctx, assetID)
+	if err != nil {
+		return nil, err
+	}
+
+	calendars := newCalendarCache(p.calendar)
+	list := []domain.PreventiveOccurrence{}
+	inRange := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+	for _, plan := range plans {
+		due, ok := plan.NextDue()
+		if plan.AssetID != assetID || !plan.Active || !ok || *plan.FrequencyDays <= 0 {
+			continue
+		}
+		cal, err := calendars.site(ctx, plan.SiteID)
+		if err != nil {
+			return nil, err
+		}
+		step := 0
+		if o := pending[plan.ID]; o != nil {
+			// A OS em aberto cobre o vencimento atual; projeta a partir do seguinte.
+			if o.ScheduledFor != nil && inRange(*o.ScheduledFor) {
+				id := o.ID
+				list = append(list, domain.PreventiveOccurrence{
+					PlanID: plan.ID, AssetID: assetID, WorkOrderID: &id, Title: o.Title, ScheduledFor: *o.ScheduledFor,
+				})
+			}
+			step = 1
+		}
+		freq := int(*plan.FrequencyDays)
+		for ; ; step++ {
+			at := cal.NextWorkingTime(due.AddDate(0, 0, step*freq))
+			if !at.Before(to) {
+				break
+			}
+			if inRange(at) {
+				list = append(list, domain.PreventiveOccurrence{
+					PlanID: plan.ID, AssetID: assetID, Title: planTitle(&plan), ScheduledFor: at,
+				})
+			}
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].ScheduledFor.Before(list[j].ScheduledFor) })
+	return list, nil
+}
+
+// openByPlan indexa por plano as OS em aberto geradas pelo agendador (assetID 0 = todos).
+func (p *PreventiveScheduler) openByPlan(ctx context.Context, assetID int64) (map[int64]*domain.WorkOrder, error) {
+	open := map[int64]*domain.WorkOrder{}
+	filter := domain.WorkOrderFilter{AssetID: assetID, Type: domain.WOTypePreventive}
+	err := p.orders.Stream(ctx, filter, func(o *domain.WorkOrder) error {
+		if o.PlanID != nil && o.IsOpen() {
+			cp := *o
+			open[*o.PlanID] = &cp
+		}
+		return nil
+	})
+	return open, err
+}
+
+func planTitle(p *domain.MaintenancePlan) string {
+	return fmt.Sprintf("Preventiva: plano #%d", p.ID)
+}
+
+// planOrder monta a OS em aberto gerada pelo plano, com o roteiro e o
+// planejamento (ofício, estimativa) copiados dele.
+func planOrder(plan *domain.MaintenancePlan, typ domain.WorkOrderType, title, description string) *domain.WorkOrder {
+	planID := plan.ID
+	order := &domain.WorkOrder{
+		SiteID:      plan.SiteID,
+		AssetID:     plan.AssetID,
+		Type:        typ,
+		Status:      domain.WOStatusOpen,
+		Title:       title,
+		Description: description,
+		PlanID:      &planID,
+		Trade:       plan.Trade,
+		JobPlanID:   plan.JobPlanID,
+	}
+	if plan.EstimatedMinutes != nil {
+		est := *plan.EstimatedMinutes
+		order.EstimatedMinutes = &est
+	}
+	return order
+}
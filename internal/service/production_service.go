@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// maxProductionBatch limita quantos apontamentos um envio pode trazer.
+const maxProductionBatch = 10000
+
+// ProductionService recebe os apontamentos de peças boas/totais (MES, CLP, manual).
+type ProductionService struct {
+	repo   repository.ProductionRepository
+	assets repository.AssetRepository
+}
+
+func NewProductionService(r repository.ProductionRepository, assets repository.AssetRepository) *ProductionService {
+	return &ProductionService{repo: r, assets: assets}
+}
+
+// Record valida o lote inteiro antes de gravar: ou entram todos, ou nenhum.
+func (s *ProductionService) Record(ctx context.Context, counts []domain.ProductionCount) (int64, error) {
+	ctx, span := tracer.Start(ctx, "ProductionService.Record")
+	defer span.End()
+
+	if len(counts) == 0 || len(counts) > maxProductionBatch {
+		return 0, domain.ErrInvalidInput
+	}
+	known := map[int64]bool{}
+	for i := range counts {
+		if err := counts[i].Validate(); err != nil {
+			return 0, err
+		}
+		id := counts[i].AssetID
+		if known[id] {
+			continue
+		}
+		if _, err := s.assets.FindByID(ctx, id); err != nil {
+			if errors.Is(err, domain.ErrNotFound) {
+				return 0, domain.ErrInvalidInput
+			}
+			return 0, err
+		}
+		known[id] = true
+	}
+	return s.repo.CreateBatch(ctx, counts)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// RateLimiter aplica as cotas de requisições por cliente: um balde para as
+// rotas sem limite próprio e um balde separado para cada rota configurada.
+type RateLimiter struct {
+	buckets repository.RateLimitRepository
+	def     domain.RateLimit
+	routes  map[string]domain.RateLimit
+	now     func() time.Time
+}
+
+// RateLimiterOption configura limites por rota.
+type RateLimiterOption func(*RateLimiter)
+
+// WithRouteLimit dá à rota ("POST /work-orders", caminho sem a versão) uma cota própria.
+func WithRouteLimit(route string, limit domain.RateLimit) RateLimiterOption {
+	return func(l *RateLimiter) { l.routes[route] = limit }
+}
+
+func NewRateLimiter(buckets repository.RateLimitRepository, def domain.RateLimit, opts ...RateLimiterOption) *RateLimiter {
+	l := &RateLimiter{buckets: buckets, def: def, routes: make(map[string]domain.RateLimit), now: time.Now}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Allow consome uma requisição da cota do cliente na rota e devolve a cota aplicada.
+func (l *RateLimiter) Allow(ctx context.Context, client, route string) (domain.RateLimit, domain.RateLimitStatus, error) {
+	ctx, span := tracer.Start(ctx, "RateLimiter.Allow")
+	defer span.End()
+
+	limit, bucket := l.def, client+" *"
+	if rl, ok := l.routes[route]; ok {
+		limit, bucket = rl, client+" "+route
+	}
+	st, err := l.buckets.Take(ctx, bucket, limit)
+	return limit, st, err
+}
+
+// RunPurge apaga a cada intervalo os baldes parados há mais tempo que a maior
+// janela (já estão cheios, equivalem a balde novo). hb (pode ser nil) é
+// sinalizado com o resultado de cada ciclo.
+func (l *RateLimiter) RunPurge(ctx context.Context, interval time.Duration, hb Beater) {
+	idle := l.def.Per
+	for _, rl := range l.routes {
+		idle = max(idle, rl.Per)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		_, err := l.buckets.DeleteIdle(ctx, l.now().Add(-idle))
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("rate limit purge failed")
+		}
+		beat(ctx, hb, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// ReportService monta os relatórios gerenciais no escopo de site do contexto;
+// o handler amplia o escopo (?site=) para usuários corporativos.
+type ReportService struct {
+	repo  repository.ReportRepository
+	sites repository.SiteRepository
+}
+
+type ReportOption func(*ReportService)
+
+// WithSiteDirectory habilita o comparativo entre plantas (Sites).
+func WithSiteDirectory(r repository.SiteRepository) ReportOption {
+	return func(s *ReportService) { s.sites = r }
+}
+
+func NewReportService(r repository.ReportRepository, opts ...ReportOption) *ReportService {
+	s := &ReportService{repo: r}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// MonthlyDowntime consolida paradas por ativo e mês em [from, to).
+func (s *ReportService) MonthlyDowntime(ctx context.Context, from, to time.Time) ([]domain.DowntimeReportRow, error) {
+	ctx, span := tracer.Start(ctx, "ReportService.MonthlyDowntime")
+	defer span.End()
+
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidInput
+	}
+	return s.repo.MonthlyDowntime(ctx, from, to, plant.Location())
+}
+
+// maxOEEPeriod limita o período do OEE (o cálculo percorre cada turno de cada ativo).
+const maxOEEPeriod = 370 * 24 * time.Hour
+
+// OEE calcula Disponibilidade × Performance × Qualidade por ativo e por linha em [from, to).
+func (s *ReportService) OEE(ctx context.Context, from, to time.Time, g domain.OEEGranularity, filter domain.OEEFilter) (*domain.OEEReport, error) {
+	ctx, span := tracer.Start(ctx, "ReportService.OEE")
+	defer span.End()
+
+	if !from.Before(to) || to.Sub(from) > maxOEEPeriod || !g.Valid() {
+		return nil, domain.ErrInvalidInput
+	}
+	in, err := s.repo.OEEInputs(ctx, from, to, filter)
+	if err != nil {
+		return nil, err
+	}
+	return computeOEE(in, from, to, g, plant.Location(), time.Now()), nil
+}
+
+// Pareto ranqueia os modos de falha das OS concluídas em [from, to) por contagem ou minutos parados.
+func (s *ReportService) Pareto(ctx context.Context, from, to time.Time, sortBy domain.ParetoSort, filter domain.ParetoFilter) (*domain.ParetoReport, error) {
+	ctx, span := tracer.Start(ctx, "ReportService.Pareto")
+	defer span.End()
+
+	if !from.Before(to) || (sortBy != domain.ParetoByCount && sortBy != domain.ParetoByDowntime) {
+		return nil, domain.ErrInvalidInput
+	}
+	report, err := s.repo.Pareto(ctx, from, to, filter)
+	if err != nil {
+		return nil, err
+	}
+	report.SortBy = sortBy
+	if report.Rows == nil {
+		report.Rows = []domain.ParetoRow{}
+	}
+
+	value := func(r *domain.ParetoRow) int64 {
+		if sortBy == domain.ParetoByDowntime {
+			return r.DowntimeMinutes
+		}
+		return r.Count
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := &report.Rows[i], &report.Rows[j]
+		if value(a) != value(b) {
+			return value(a) > value(b)
+		}
+		return a.Code < b.Code
+	})
+
+	var total, cumulative int64
+	for i := range report.Rows {
+		total += value(&report.Rows[i])
+	}
+	if total == 0 {
+		return report, nil
+	}
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		cumulative += value(row)
+		row.Share = roundTo(float64(value(row))/float64(total), 4)
+		row.CumulativeShare = roundTo(float64(cumulative)/float64(total), 4)
+	}
+	return report, nil
+}
+
+// MonthlySLA consolida o cumprimento de SLA por mês de abertura em [from, to).
+func (s *ReportService) MonthlySLA(ctx context.Context, from, to time.Time) ([]domain.SLAReportRow, error) {
+	ctx, span := tracer.Start(ctx, "ReportService.MonthlySLA")
+	defer span.End()
+
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidInput
+	}
+	return s.repo.MonthlySLA(ctx, from, to, plant.Location(), time.Now())
+}
+
+// Sites compara as plantas em [from, to): paradas e cumprimento de SLA de cada
+// site. Só usuários corporativos (ErrForbidden para os demais).
+func (s *ReportService) Sites(ctx context.Context, from, to time.Time) ([]domain.SiteReportRow, error) {
+	ctx, span := tracer.Start(ctx, "ReportService.Sites")
+	defer span.End()
+
+	if s.sites == nil {
+		return nil, errNotConfigured
+	}
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidInput
+	}
+	all, err := tenant.AllSites(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sites, err := s.sites.FindAll(all)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, now := plant.Location(), time.Now()
+	list := make([]domain.SiteReportRow, 0, len(sites))
+	for _, site := range sites {
+		siteCtx, err := tenant.OnSite(ctx, site.ID)
+		if err != nil {
+			return nil, err
+		}
+		row := domain.SiteReportRow{SiteID: site.ID, Code: site.Code, Name: site.Name}
+		downtime, err := s.repo.MonthlyDowntime(siteCtx, from, to, loc)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range downtime {
+			row.Breakdowns += d.Breakdowns
+			row.DowntimeMinutes += d.DowntimeMinutes
+			row.PlannedMinutes += d.PlannedMinutes
+		}
+		months, err := s.repo.MonthlySLA(siteCtx, from, to, loc, now)
+		if err != nil {
+			return nil, err
+		}
+		var sla domain.SLAReportRow
+		for _, m := range months {
+			sla.Orders += m.Orders
+			sla.ResponseEvaluated += m.ResponseEvaluated
+			sla.ResponseMet += m.ResponseMet
+			sla.ResolutionEvaluated += m.ResolutionEvaluated
+			sla.ResolutionMet += m.ResolutionMet
+		}
+		sla.FillCompliance()
+		row.SLAOrders, row.ResponseCompliance, row.ResolutionCompliance = sla.Orders, sla.ResponseCompliance, sla.ResolutionCompliance
+		list = append(list, row)
+	}
+	return list, nil
+}
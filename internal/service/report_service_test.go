@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/plant"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+func TestReportService_OEE(t *testing.T) {
+	ctx := onSite(1)
+	loc := plant.Location()
+	at := func(hour, min int) time.Time { return time.Date(2025, 1, 6, hour, min, 0, 0, loc) } // segunda-feira
+
+	assets := memory.NewAssetMemoryRepo()
+	events := memory.NewDowntimeMemoryRepo(assets)
+	shifts := memory.NewShiftMemoryRepo()
+	counts := memory.NewProductionMemoryRepo(assets)
+	svc := service.NewReportService(memory.NewReportMemoryRepo(assets, memory.NewWorkOrderMemoryRepo(), events, shifts, counts, memory.NewFailureCodeMemoryRepo()))
+
+	rateA, rateB := 60.0, 120.0
+	a := domain.Asset{Name: "Prensa 01", Location: "Linha 1", IdealRatePerHour: &rateA}
+	b := domain.Asset{Name: "Prensa 02", Location: "Linha 1", IdealRatePerHour: &rateB}
+	for _, asset := range []*domain.Asset{&a, &b} {
+		if err := assets.Create(ctx, asset); err != nil {
+			t.Fatalf("create asset: %v", err)
+		}
+	}
+	if err := shifts.Create(ctx, &domain.Shift{Name: "Turno A", StartTime: "06:00", EndTime: "14:00", Weekdays: []int{1, 2, 3, 4, 5}, BreakMinutes: 60}); err != nil {
+		t.Fatalf("create shift: %v", err)
+	}
+
+	// A: 42 min de quebra no turno; a parada das 20h fica fora do calendário.
+	for _, ev := range []domain.DowntimeEvent{
+		{AssetID: a.ID, ReasonCode: "MEC", StartedAt: at(8, 0), EndedAt: ptr(at(8, 42))},
+		{AssetID: a.ID, ReasonCode: "ELE", StartedAt: at(20, 0), EndedAt: ptr(at(21, 0))},
+	} {
+		if err := events.Create(ctx, &ev); err != nil {
+			t.Fatalf("create downtime: %v", err)
+		}
+	}
+	if _, err := counts.CreateBatch(ctx, []domain.ProductionCount{
+		{AssetID: a.ID, PeriodStart: at(10, 0), PeriodEnd: at(11, 0), TotalCount: 300, GoodCount: 285},
+		{AssetID: b.ID, PeriodStart: at(6, 0), PeriodEnd: at(14, 0), TotalCount: 700, GoodCount: 700},
+		{AssetID: b.ID, PeriodStart: at(15, 0), PeriodEnd: at(16, 0), TotalCount: 50, GoodCount: 50}, // fora do turno
+	}); err != nil {
+		t.Fatalf("create counts: %v", err)
+	}
+
+	report, err := svc.OEE(ctx, at(0, 0), at(24, 0), domain.OEEByDay, domain.OEEFilter{})
+	if err != nil {
+		t.Fatalf("OEE() error = %v", err)
+	}
+	if len(report.Assets) != 2 || len(report.Locations) != 1 {
+		t.Fatalf("expected 2 asset rows and 1 location row, got %d/%d", len(report.Assets), len(report.Locations))
+	}
+
+	rowA := report.Assets[0]
+	if rowA.Period != "2025-01-06" || rowA.PlannedMinutes != 420 || rowA.RunMinutes != 378 {
+		t.Fatalf("unexpected row for A: %+v", rowA)
+	}
+	assertRatio(t, "A availability", rowA.Availability, 0.9)
+	assertRatio(t, "A performance", rowA.Performance, 300.0/378)
+	assertRatio(t, "A quality", rowA.Quality, 0.95)
+	assertRatio(t, "A oee", rowA.OEE, 0.9*(300.0/378)*0.95)
+
+	line := report.Locations[0]
+	if line.Location != "Linha 1" || line.TotalCount != 1000 {
+		t.Fatalf("unexpected location row: %+v", line)
+	}
+	assertRatio(t, "line availability", line.Availability, 798.0/840)
+	assertRatio(t, "line performance", line.Performance, 650.0/798)
+	assertRatio(t, "line quality", line.Quality, 0.985)
+
+	weekly, err := svc.OEE(ctx, at(0, 0), at(24, 0), domain.OEEByWeek, domain.OEEFilter{AssetID: b.ID})
+	if err != nil {
+		t.Fatalf("OEE(week) error = %v", err)
+	}
+	if len(weekly.Assets) != 1 || weekly.Assets[0].Period != "2025-W02" {
+		t.Fatalf("unexpected weekly rows: %+v", weekly.Assets)
+	}
+
+	if _, err := svc.OEE(ctx, at(0, 0), at(24, 0), "month", domain.OEEFilter{}); err != domain.ErrInvalidInput {
+		t.Fatalf("expected ErrInvalidInput for unknown granularity, got %v", err)
+	}
+}
+
+func assertRatio(t *testing.T, name string, got *float64, want float64) {
+	t.Helper()
+	if got == nil {
+		t.Fatalf("%s: expected %.4f, got nil", name, want)
+	}
+	if math.Abs(*got-want) > 0.0001 {
+		t.Fatalf("%s: expected %.4f, got %.4f", name, want, *got)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/importer"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	// minDuplicateScore é a semelhança mínima de título para sugerir a OS como duplicada.
+	minDuplicateScore = 0.5
+	maxDuplicates     = 5
+)
+
+// MaintenanceRequestService conduz a solicitação do operador pela triagem até
+// virar OS (aceita), ser descartada (rejeitada) ou juntada a uma OS em aberto.
+type MaintenanceRequestService struct {
+	repo       repository.MaintenanceRequestRepository
+	assets     repository.AssetRepository
+	orders     repository.WorkOrderRepository
+	workOrders *WorkOrderService
+	now        func() time.Time
+}
+
+func NewMaintenanceRequestService(r repository.MaintenanceRequestRepository, assets repository.AssetRepository,
+	orders repository.WorkOrderRepository, workOrders *WorkOrderService) *MaintenanceRequestService {
+	return &MaintenanceRequestService{repo: r, assets: assets, orders: orders, workOrders: workOrders, now: time.Now}
+}
+
+// Submit registra a solicitação e já devolve as OS em aberto que parecem a mesma falha.
+func (s *MaintenanceRequestService) Submit(ctx context.Context, req *domain.MaintenanceRequest) (*domain.RequestSubmission, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceRequestService.Submit")
+	defer span.End()
+
+	req.Normalize()
+	req.Status = domain.RequestSubmitted
+	if req.Title == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	asset, err := s.assets.FindByID(ctx, req.AssetID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, domain.ErrInvalidInput
+		}
+		return nil, err
+	}
+	req.SiteID = asset.SiteID
+	if err := s.repo.Create(ctx, req); err != nil {
+		return nil, err
+	}
+	dups, err := s.duplicates(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.RequestSubmission{Request: *req, Duplicates: dups}, nil
+}
+
+func (s *MaintenanceRequestService) Get(ctx context.Context, id int64) (*domain.MaintenanceRequest, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceRequestService.Get")
+	defer span.End()
+
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *MaintenanceRequestService) List(ctx context.Context, filter domain.RequestFilter) ([]domain.MaintenanceRequest, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceRequestService.List")
+	defer span.End()
+
+	return s.repo.FindAll(ctx, filter)
+}
+
+// Duplicates sugere OS em aberto no mesmo ativo com título parecido, mais semelhantes primeiro.
+func (s *MaintenanceRequestService) Duplicates(ctx context.Context, id int64) ([]domain.DuplicateCandidate, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceRequestService.Duplicates")
+	defer span.End()
+
+	req, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.duplicates(ctx, req)
+}
+
+func (s *MaintenanceRequestService) duplicates(ctx context.Context, req *domain.MaintenanceRequest) ([]domain.DuplicateCandidate, error) {
+	words := titleWords(req.Title)
+	list := []domain.DuplicateCandidate{}
+	err := s.orders.Stream(ctx, domain.WorkOrderFilter{AssetID: req.AssetID}, func(o *domain.WorkOrder) error {
+		if !o.IsOpen() {
+			return nil
+		}
+		if score := similarity(words, titleWords(o.Title)); score >= minDuplicateScore {
+			list = append(list, domain.DuplicateCandidate{
+				WorkOrderID: o.ID, Title: o.Title, Status: o.Status, CreatedAt: o.CreatedAt, Score: roundTo(score, 2),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Score != list[j].Score {
+			return list[i].Score > list[j].Score
+		}
+		return list[i].WorkOrderID > list[j].WorkOrderID
+	})
+	if len(list) > maxDuplicates {
+		list = list[:maxDuplicates]
+	}
+	return list, nil
+}
+
+// TriagePatch registra a triagem; título e descrição podem ser reescritos para
+// que a OS resultante não herde textos vagos.
+type TriagePatch struct {
+	Title       *string
+	Description *string
+	Note        string
+}
+
+func (s *MaintenanceRequestService) Triage(ctx context.Context, id int64, patch TriagePatch) (*domain.MaintenanceRequest, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceRequestService.Triage")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("request.id", id))
+
+	return s.decide(ctx, id, domain.RequestTriaged, func(r *domain.MaintenanceRequest, now time.Time) error {
+		if patch.Title != nil {
+			r.Title = *patch.Title
+		}
+		if patch.Description != nil {
+			r.Description = *patch.Description
+		}
+		r.Normalize()
+		if r.Title == "" {
+			return domain.ErrInvalidInput
+		}
+		r.TriageNote = strings.TrimSpace(patch.Note)
+		r.TriagedAt = &now
+		return nil
+	})
+}
+
+// Reject descarta a solicitação; o motivo é obrigatório para retorno ao operador.
+func (s *MaintenanceRequestService) Reject(ctx context.Context, id int64, reason string) (*domain.MaintenanceRequest, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceRequestService.Reject")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("request.id", id))
+
+	return s.decide(ctx, id, domain.RequestRejected, func(r *domain.MaintenanceRequest, now time.Time) error {
+		r.Resolution = strings.TrimSpace(reason)
+		if r.Resolution == "" {
+			return domain.ErrInvalidInput
+		}
+		r.ClosedAt = &now
+		return nil
+	})
+}
+
+// Merge junta a solicitação a uma OS em aberto do mesmo ativo (a duplicada confirmada).
+func (s *MaintenanceRequestService) Merge(ctx context.Context, id, workOrderID int64, note string) (*domain.MaintenanceRequest, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceRequestService.Merge")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("request.id", id), attribute.Int64("work_order.id", workOrderID))
+
+	return s.decide(ctx, id, domain.RequestMerged, func(r *domain.MaintenanceRequest, now time.Time) error {
+		o, err := s.orders.FindByID(ctx, workOrderID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidInput
+		}
+		if err != nil {
+			return err
+		}
+		if o.AssetID != r.AssetID {
+			return domain.ErrInvalidInput
+		}
+		if !o.IsOpen() {
+			return domain.ErrConflict
+		}
+		r.WorkOrderID = &o.ID
+		r.Resolution = strings.TrimSpace(note)
+		r.ClosedAt = &now
+		return nil
+	})
+}
+
+// AcceptRequest completa o que a triagem decide sobre a OS; vazios usam os
+// padrões da OS (corretiva, sem especialidade, sem estimativa).
+type AcceptRequest struct {
+	Type             domain.WorkOrderType
+	Trade            string
+	EstimatedMinutes *int64
+}
+
+// Accept converte a solicitação em OS. A solicitação é reservada (accepted) antes
+// de abrir a OS, o que impede duas OS para a mesma solicitação; se a abertura
+// falhar, a solicitação volta para triaged.
+func (s *MaintenanceRequestService) Accept(ctx context.Context, id int64, in AcceptRequest) (*domain.MaintenanceRequest, *domain.WorkOrder, error) {
+	ctx, span := tracer.Start(ctx, "MaintenanceRequestService.Accept")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("request.id", id))
+
+	req, err := s.decide(ctx, id, domain.RequestAccepted, func(r *domain.MaintenanceRequest, now time.Time) error {
+		r.ClosedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	order := &domain.WorkOrder{
+		AssetID:          req.AssetID,
+		Type:             in.Type,
+		Title:            req.Title,
+		Description:      req.Description,
+		BreakdownAt:      req.BreakdownAt,
+		Trade:            in.Trade,
+		EstimatedMinutes: in.EstimatedMinutes,
+		RequestID:        &req.ID,
+	}
+	if err := s.workOrders.Create(ctx, order); err != nil {
+		s.release(ctx, req)
+		return nil, nil, err
+	}
+
+	req.WorkOrderID = &order.ID
+	if err := s.repo.Update(ctx, req, domain.RequestAccepted); err != nil {
+		return nil, nil, err
+	}
+	return req, order, nil
+}
+
+// release devolve a solicitação reservada para triaged quando a OS não pôde ser aberta.
+func (s *MaintenanceRequestService) release(ctx context.Context, req *domain.MaintenanceRequest) {
+	req.Status, req.ClosedAt = domain.RequestTriaged, nil
+	if err := s.repo.Update(ctx, req, domain.RequestAccepted); err != nil {
+		log.WithError(err).WithField("request_id", req.ID).Error("release maintenance request")
+	}
+}
+
+// decide aplica a transição para to; apply preenche os campos da decisão.
+// Transição inválida, ou outra decisão gravada no meio do caminho, é ErrConflict.
+func (s *MaintenanceRequestService) decide(ctx context.Context, id int64, to domain.RequestStatus,
+	apply func(*domain.MaintenanceRequest, time.Time) error) (*domain.MaintenanceRequest, error) {
+	r, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !r.CanTransition(to) {
+		return nil, domain.ErrConflict
+	}
+	from := r.Status
+	if err := apply(r, s.now()); err != nil {
+		return nil, err
+	}
+	r.Status = to
+	if err := s.repo.Update(ctx, r, from); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// stopWords são palavras que não ajudam a distinguir uma falha da outra.
+var stopWords = map[string]bool{
+	"a": true, "o": true, "e": true, "de": true, "da": true, "do": true, "das": true, "dos": true,
+	"em": true, "na": true, "no": true, "nas": true, "nos": true, "com": true, "sem": true,
+	"para": true, "pra": true, "por": true, "um": true, "uma": true, "ao": true, "the": true,
+}
+
+// titleWords reduz o título a palavras comparáveis: sem acento, sem stopwords e
+// sem o plural simples (rolamentos → rolamento).
+func titleWords(title string) map[string]bool {
+	words := map[string]bool{}
+	for _, w := range strings.FieldsFunc(importer.Fold(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if stopWords[w] {
+			continue
+		}
+		if len(w) > 3 && strings.HasSuffix(w, "s") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		words[w] = true
+	}
+	return words
+}
+
+// similarity é o coeficiente de Dice entre os conjuntos de palavras (0 a 1).
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	common := 0
+	for w := range a {
+		if b[w] {
+			common++
+		}
+	}
+	return 2 * float64(common) / float64(len(a)+len(b))
+}
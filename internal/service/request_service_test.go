@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+func TestMaintenanceRequest_DuplicatesAndLifecycle(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	workOrders := service.NewWorkOrderService(orders, service.WithAssets(assets))
+	svc := service.NewMaintenanceRequestService(memory.NewMaintenanceRequestMemoryRepo(), assets, orders, workOrders)
+
+	for _, name := range []string{"Redutor", "Prensa"} {
+		if err := assets.Create(ctx, &domain.Asset{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	existing := []domain.WorkOrder{
+		{AssetID: 1, Title: "Vazamento de óleo no redutor"},
+		{AssetID: 1, Title: "Troca de correia"},
+		{AssetID: 2, Title: "Vazamento de óleo"},                           // outro ativo
+		{AssetID: 1, Title: "Vazamento óleo", Status: domain.WOStatusDone}, // já fechada
+	}
+	for i := range existing {
+		if err := workOrders.Create(ctx, &existing[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := svc.Submit(ctx, &domain.MaintenanceRequest{AssetID: 9, Title: "Barulho"}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("unknown asset: expected ErrInvalidInput, got %v", err)
+	}
+	sub, err := svc.Submit(ctx, &domain.MaintenanceRequest{AssetID: 1, Title: "VAZAMENTOS de oleo", RequestedBy: "Operador 3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.Request.Status != domain.RequestSubmitted || len(sub.Duplicates) != 1 || sub.Duplicates[0].WorkOrderID != existing[0].ID {
+		t.Fatalf("unexpected submission: %+v", sub)
+	}
+
+	id := sub.Request.ID
+	if _, _, err := svc.Accept(ctx, id, service.AcceptRequest{}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("accept before triage: expected ErrConflict, got %v", err)
+	}
+	title := "Vazamento de óleo na vedação do eixo"
+	if _, err := svc.Triage(ctx, id, service.TriagePatch{Title: &title, Note: "confirmado no local"}); err != nil {
+		t.Fatal(err)
+	}
+	req, wo, err := svc.Accept(ctx, id, service.AcceptRequest{Trade: "mecanica"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Status != domain.RequestAccepted || req.WorkOrderID == nil || *req.WorkOrderID != wo.ID {
+		t.Fatalf("unexpected accepted request: %+v", req)
+	}
+	if wo.RequestID == nil || *wo.RequestID != id || wo.Title != title || wo.Type != domain.WOTypeCorrective || wo.Trade != "mecanica" {
+		t.Fatalf("unexpected work order: %+v", wo)
+	}
+	if _, err := svc.Reject(ctx, id, "tarde demais"); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("reject accepted: expected ErrConflict, got %v", err)
+	}
+
+	// Juntar exige OS em aberto do mesmo ativo.
+	sub, _ = svc.Submit(ctx, &domain.MaintenanceRequest{AssetID: 1, Title: "Correia patinando"})
+	if _, err := svc.Triage(ctx, sub.Request.ID, service.TriagePatch{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Merge(ctx, sub.Request.ID, existing[2].ID, ""); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("merge into other asset: expected ErrInvalidInput, got %v", err)
+	}
+	if _, err := svc.Merge(ctx, sub.Request.ID, existing[3].ID, ""); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("merge into closed order: expected ErrConflict, got %v", err)
+	}
+	merged, err := svc.Merge(ctx, sub.Request.ID, existing[1].ID, "mesma correia")
+	if err != nil || merged.Status != domain.RequestMerged || *merged.WorkOrderID != existing[1].ID || merged.ClosedAt == nil {
+		t.Fatalf("unexpected merge: %+v %v", merged, err)
+	}
+
+	sub, _ = svc.Submit(ctx, &domain.MaintenanceRequest{AssetID: 2, Title: "Máquina estranha"})
+	svc.Triage(ctx, sub.Request.ID, service.TriagePatch{})
+	if _, err := svc.Reject(ctx, sub.Request.ID, " "); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("reject without reason: expected ErrInvalidInput, got %v", err)
+	}
+	pending, _ := svc.List(ctx, domain.RequestFilter{Status: domain.RequestTriaged})
+	if len(pending) != 1 || pending[0].ID != sub.Request.ID {
+		t.Fatalf("unexpected triaged list: %+v", pending)
+	}
+}
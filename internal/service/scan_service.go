@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// ScanService resolve a etiqueta lida no chão de fábrica para o ativo e o que
+// está pendente nele.
+type ScanService struct {
+	assets repository.AssetRepository
+	orders repository.WorkOrderRepository
+	plans  repository.MaintenancePlanRepository
+	now    func() time.Time
+}
+
+func NewScanService(assets repository.AssetRepository, orders repository.WorkOrderRepository,
+	plans repository.MaintenancePlanRepository) *ScanService {
+	return &ScanService{assets: assets, orders: orders, plans: plans, now: time.Now}
+}
+
+// Resolve aceita a tag gerada (AT-000042) ou o external_code do ativo, para
+// etiquetas antigas impressas com o código legado.
+func (s *ScanService) Resolve(ctx context.Context, tag string) (*domain.ScanResult, error) {
+	ctx, span := tracer.Start(ctx, "ScanService.Resolve")
+	defer span.End()
+	span.SetAttributes(attribute.String("asset.tag", tag))
+
+	asset, err := s.findAsset(ctx, strings.TrimSpace(tag))
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	res := &domain.ScanResult{
+		Tag:            asset.Tag(),
+		Asset:          *asset,
+		OpenWorkOrders: []domain.WorkOrder{},
+		Plans:          []domain.ScanPlan{},
+		BreakdownRequest: domain.BreakdownRequest{
+			AssetID:     asset.ID,
+			Type:        domain.WOTypeCorrective,
+			Title:       fmt.Sprintf("Quebra: %s", asset.Name),
+			BreakdownAt: now,
+		},
+	}
+
+	err = s.orders.Stream(ctx, domain.WorkOrderFilter{AssetID: asset.ID}, func(o *domain.WorkOrder) error {
+		if o.IsOpen() {
+			res.OpenWorkOrders = append(res.OpenWorkOrders, *o)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := s.plans.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range plans {
+		if p.AssetID != asset.ID || !p.Active {
+			continue
+		}
+		sp := domain.ScanPlan{MaintenancePlan: p, Overdue: p.IsOverdue(now)}
+		if due, ok := p.NextDue(); ok {
+			sp.NextDue = &due
+		}
+		res.Plans = append(res.Plans, sp)
+	}
+	return res, nil
+}
+
+func (s *ScanService) findAsset(ctx context.Context, tag string) (*domain.Asset, error) {
+	if tag == "" {
+		return nil, domain.ErrNotFound
+	}
+	if id, ok := domain.ParseAssetTag(tag); ok {
+		asset, err := s.assets.FindByID(ctx, id)
+		if !errors.Is(err, domain.ErrNotFound) {
+			return asset, err
+		}
+	}
+	return s.assets.FindByCode(ctx, tag)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// ShiftService mantém o calendário de turnos usado no tempo planejado do OEE.
+type ShiftService struct {
+	repo repository.ShiftRepository
+}
+
+func NewShiftService(r repository.ShiftRepository) *ShiftService {
+	return &ShiftService{repo: r}
+}
+
+func (s *ShiftService) Create(ctx context.Context, shift *domain.Shift) error {
+	ctx, span := tracer.Start(ctx, "ShiftService.Create")
+	defer span.End()
+
+	shift.Name = strings.TrimSpace(shift.Name)
+	if shift.Name == "" {
+		return domain.ErrInvalidInput
+	}
+	if err := shift.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, shift)
+}
+
+func (s *ShiftService) List(ctx context.Context) ([]domain.Shift, error) {
+	ctx, span := tracer.Start(ctx, "ShiftService.List")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
+
+func (s *ShiftService) Delete(ctx context.Context, id int64) error {
+	ctx, span := tracer.Start(ctx, "ShiftService.Delete")
+	defer span.End()
+
+	return s.repo.Delete(ctx, id)
+}
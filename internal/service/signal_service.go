@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// SignalService recebe os sinais das máquinas (cmd/ingest): o contador de uso
+// dispara os planos por uso, as medições disparam os planos por condição e o
+// estado de marcha/parada abre e encerra as paradas do ativo.
+type SignalService struct {
+	signals    repository.SignalRepository
+	assets     repository.AssetRepository
+	plans      repository.MaintenancePlanRepository
+	orders     repository.WorkOrderRepository
+	workOrders *WorkOrderService
+	downtime   *DowntimeService
+	now        func() time.Time
+}
+
+func NewSignalService(signals repository.SignalRepository, assets repository.AssetRepository,
+	plans repository.MaintenancePlanRepository, orders repository.WorkOrderRepository,
+	workOrders *WorkOrderService, downtime *DowntimeService) *SignalService {
+	return &SignalService{
+		signals:    signals,
+		assets:     assets,
+		plans:      plans,
+		orders:     orders,
+		workOrders: workOrders,
+		downtime:   downtime,
+		now:        time.Now,
+	}
+}
+
+// RecordMeter grava a leitura do contador e abre a preventiva dos planos por uso
+// cujo avanço desde a última execução (ou desde a criação do plano) atingiu a
+// meta. Retorna as OS abertas pela leitura.
+func (s *SignalService) RecordMeter(ctx context.Context, m *domain.MeterReading) ([]domain.WorkOrder, error) {
+	ctx, span := tracer.Start(ctx, "SignalService.RecordMeter")
+	defer span.End()
+
+	m.ReadAt = s.readAt(m.ReadAt)
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkAsset(ctx, m.AssetID); err != nil {
+		return nil, err
+	}
+	if err := s.signals.CreateMeterReading(ctx, m); err != nil {
+		return nil, err
+	}
+
+	plans, err := s.assetPlans(ctx, m.AssetID, domain.PlanRuleMeter)
+	if err != nil || len(plans) == 0 {
+		return nil, err
+	}
+	var orders []*domain.WorkOrder
+	for i := range plans {
+		p := &plans[i]
+		base := p.CreatedAt
+		if p.LastExecution != nil {
+			base = *p.LastExecution
+		}
+		start, err := s.signals.MeterReadingAt(ctx, m.AssetID, base)
+		if err != nil {
+			return nil, err
+		}
+		// Contador zerado (troca de CLP) fica negativo e não dispara.
+		used := m.Value - start.Value
+		if used < *p.MeterTarget {
+			continue
+		}
+		orders = append(orders, planOrder(p, domain.WOTypePreventive, planTitle(p),
+			fmt.Sprintf("Gerada pelo sinal da máquina: %d de uso desde a última execução (meta %d).", used, *p.MeterTarget)))
+	}
+	return s.openPlanOrders(ctx, m.AssetID, orders)
+}
+
+// RecordCondition grava a medição e abre a OS de condição dos planos cuja faixa
+// aceitável do parâmetro foi ultrapassada. Retorna as OS abertas pela medição.
+func (s *SignalService) RecordCondition(ctx context.Context, c *domain.ConditionReading) ([]domain.WorkOrder, error) {
+	ctx, span := tracer.Start(ctx, "SignalService.RecordCondition")
+	defer span.End()
+
+	c.Normalize()
+	c.ReadAt = s.readAt(c.ReadAt)
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkAsset(ctx, c.AssetID); err != nil {
+		return nil, err
+	}
+	if err := s.signals.CreateConditionReading(ctx, c); err != nil {
+		return nil, err
+	}
+
+	plans, err := s.assetPlans(ctx, c.AssetID, domain.PlanRuleCondition)
+	if err != nil || len(plans) == 0 {
+		return nil, err
+	}
+	var orders []*domain.WorkOrder
+	for i := range plans {
+		p := &plans[i]
+		if !p.OutOfLimits(c.Parameter, c.Value) {
+			continue
+		}
+		orders = append(orders, planOrder(p, domain.WOTypeCondition,
+			fmt.Sprintf("Condição: %s fora da faixa", c.Parameter),
+			fmt.Sprintf("Gerada pelo sinal da máquina: %s = %s em %s, faixa aceitável %s (plano #%d).",
+				c.Parameter, formatFloat(c.Value), c.ReadAt.Format(time.RFC3339), limitsText(p), p.ID)))
+	}
+	return s.openPlanOrders(ctx, c.AssetID, orders)
+}
+
+// SetRunning aplica o estado de marcha do ativo: a parada abre uma parada não
+// programada com reasonCode e a volta à marcha encerra a parada em andamento
+// (inclusive uma aberta manualmente). Sinal repetido não muda nada e retorna nil.
+func (s *SignalService) SetRunning(ctx context.Context, assetID int64, running bool, at time.Time, reasonCode string) (*domain.DowntimeEvent, error) {
+	ctx, span := tracer.Start(ctx, "SignalService.SetRunning")
+	defer span.End()
+
+	at = s.readAt(at)
+	if running {
+		ev, err := s.downtime.Stop(ctx, assetID, &at, nil)
+		if errors.Is(err, domain.ErrPrecondition) {
+			return nil, nil // já estava em marcha
+		}
+		return ev, err
+	}
+
+	ev := &domain.DowntimeEvent{
+		AssetID:    assetID,
+		StartedAt:  at,
+		ReasonCode: reasonCode,
+		Notes:      "Aberta pelo sinal da máquina.",
+	}
+	err := s.downtime.Start(ctx, ev)
+	if errors.Is(err, domain.ErrConflict) {
+		return nil, nil // parada já em andamento
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// readAt usa o horário da leitura informado pela máquina; sem ele, ou com o
+// relógio do CLP adiantado, vale o horário de chegada.
+func (s *SignalService) readAt(t time.Time) time.Time {
+	now := s.now()
+	if t.IsZero() || t.After(now) {
+		return now
+	}
+	return t
+}
+
+func (s *SignalService) checkAsset(ctx context.Context, assetID int64) error {
+	if _, err := s.assets.FindByID(ctx, assetID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidInput
+		}
+		return err
+	}
+	return nil
+}
+
+// assetPlans lista os planos ativos do ativo com a regra informada.
+func (s *SignalService) assetPlans(ctx context.Context, assetID int64, rule domain.PlanRuleType) ([]domain.MaintenancePlan, error) {
+	all, err := s.plans.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var list []domain.MaintenancePlan
+	for _, p := range all {
+		if p.Active && p.AssetID == assetID && p.RuleType == rule {
+			list = append(list, p)
+		}
+	}
+	return list, nil
+}
+
+// openPlanOrders abre as OS dos planos disparados, exceto dos que já têm OS em
+// aberto: enquanto a OS não é concluída, novas leituras não geram outra.
+func (s *SignalService) openPlanOrders(ctx context.Context, assetID int64, orders []*domain.WorkOrder) ([]domain.WorkOrder, error) {
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	pending := map[int64]bool{}
+	err := s.orders.Stream(ctx, domain.WorkOrderFilter{AssetID: assetID, Open: true}, func(o *domain.WorkOrder) error {
+		if o.PlanID != nil {
+			pending[*o.PlanID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var created []domain.WorkOrder
+	for _, order := range orders {
+		if pending[*order.PlanID] {
+			continue
+		}
+		err := s.workOrders.Create(ctx, order)
+		if errors.Is(err, domain.ErrAlreadyExists) {
+			continue // leitura concorrente abriu a mesma OS
+		}
+		if err != nil {
+			return created, err
+		}
+		log.WithFields(log.Fields{
+			"work_order_id": order.ID,
+			"plan_id":       *order.PlanID,
+			"asset_id":      order.AssetID,
+			"type":          order.Type,
+		}).Info("work order opened by machine signal")
+		created = append(created, *order)
+	}
+	return created, nil
+}
+
+func limitsText(p *domain.MaintenancePlan) string {
+	switch {
+	case p.ConditionMin != nil && p.ConditionMax != nil:
+		return fmt.Sprintf("%s a %s", formatFloat(*p.ConditionMin), formatFloat(*p.ConditionMax))
+	case p.ConditionMin != nil:
+		return "acima de " + formatFloat(*p.ConditionMin)
+	default:
+		return "até " + formatFloat(*p.ConditionMax)
+	}
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
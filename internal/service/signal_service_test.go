@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+)
+
+func TestSignalService_PlansAndDowntime(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	plans := memory.NewMaintenancePlanMemoryRepo()
+	events := memory.NewDowntimeMemoryRepo(assets)
+	workOrders := service.NewWorkOrderService(orders, service.WithAssets(assets), service.WithPlans(plans))
+	svc := service.NewSignalService(memory.NewSignalMemoryRepo(assets), assets, plans, orders, workOrders,
+		service.NewDowntimeService(events, assets, orders))
+
+	asset := domain.Asset{Name: "Slitter 01", Criticality: domain.CriticalityA}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	planSvc := service.NewMaintenancePlanService(plans, assets, memory.NewJobPlanMemoryRepo())
+	target, limit := int64(5000), 7.1
+	meterPlan := domain.MaintenancePlan{AssetID: asset.ID, RuleType: domain.PlanRuleMeter, MeterTarget: &target}
+	conditionPlan := domain.MaintenancePlan{AssetID: asset.ID, RuleType: domain.PlanRuleCondition,
+		ConditionParameter: " Vibration ", ConditionMax: &limit}
+	for _, p := range []*domain.MaintenancePlan{&meterPlan, &conditionPlan} {
+		if err := planSvc.Create(ctx, p); err != nil {
+			t.Fatalf("create plan: %v", err)
+		}
+	}
+
+	// O uso conta a partir da primeira leitura depois da criação do plano.
+	for _, tc := range []struct {
+		value int64
+		want  int
+	}{{1000, 0}, {5999, 0}, {6000, 1}, {9000, 0}} {
+		created, err := svc.RecordMeter(ctx, &domain.MeterReading{AssetID: asset.ID, Value: tc.value})
+		if err != nil {
+			t.Fatalf("RecordMeter(%d) error = %v", tc.value, err)
+		}
+		if len(created) != tc.want {
+			t.Fatalf("RecordMeter(%d) opened %d work orders, want %d", tc.value, len(created), tc.want)
+		}
+		if len(created) == 1 && (created[0].Type != domain.WOTypePreventive || *created[0].PlanID != meterPlan.ID) {
+			t.Fatalf("meter work order = %+v", created[0])
+		}
+	}
+
+	if created, err := svc.RecordCondition(ctx, &domain.ConditionReading{AssetID: asset.ID, Parameter: "vibration", Value: 4.2}); err != nil || len(created) != 0 {
+		t.Fatalf("RecordCondition(4.2) = %v, %v; want nothing", created, err)
+	}
+	created, err := svc.RecordCondition(ctx, &domain.ConditionReading{AssetID: asset.ID, Parameter: "VIBRATION", Value: 9.3})
+	if err != nil || len(created) != 1 {
+		t.Fatalf("RecordCondition(9.3) = %v, %v; want one work order", created, err)
+	}
+	if wo := created[0]; wo.Type != domain.WOTypeCondition || *wo.PlanID != conditionPlan.ID || wo.Title != "Condição: vibration fora da faixa" {
+		t.Fatalf("condition work order = %+v", wo)
+	}
+
+	// Parada e volta à marcha; sinais repetidos não mudam nada.
+	stoppedAt := time.Now().Add(-20 * time.Minute)
+	ev, err := svc.SetRunning(ctx, asset.ID, false, stoppedAt, "AUTO")
+	if err != nil || ev == nil || !ev.IsOpen() {
+		t.Fatalf("SetRunning(false) = %+v, %v", ev, err)
+	}
+	if again, err := svc.SetRunning(ctx, asset.ID, false, time.Now(), "AUTO"); err != nil || again != nil {
+		t.Fatalf("repeated stop = %+v, %v; want no change", again, err)
+	}
+	ev, err = svc.SetRunning(ctx, asset.ID, true, time.Time{}, "")
+	if err != nil || ev == nil || ev.IsOpen() || ev.Minutes(time.Now()) != 20 {
+		t.Fatalf("SetRunning(true) = %+v, %v; want 20 min closed downtime", ev, err)
+	}
+	if again, err := svc.SetRunning(ctx, asset.ID, true, time.Time{}, ""); err != nil || again != nil {
+		t.Fatalf("repeated run = %+v, %v; want no change", again, err)
+	}
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// SiteService mantém o cadastro de plantas. Só usuários corporativos criam
+// sites; os demais enxergam apenas o próprio.
+type SiteService struct {
+	repo repository.SiteRepository
+}
+
+func NewSiteService(r repository.SiteRepository) *SiteService {
+	return &SiteService{repo: r}
+}
+
+func (s *SiteService) Create(ctx context.Context, site *domain.Site) error {
+	ctx, span := tracer.Start(ctx, "SiteService.Create")
+	defer span.End()
+
+	if _, err := tenant.AllSites(ctx); err != nil {
+		return err
+	}
+	site.Normalize()
+	if err := site.Validate(); err != nil {
+		return err
+	}
+	return s.repo.Create(ctx, site)
+}
+
+// List traz todos os sites para usuários corporativos e só o próprio para os demais.
+func (s *SiteService) List(ctx context.Context) ([]domain.Site, error) {
+	ctx, span := tracer.Start(ctx, "SiteService.List")
+	defer span.End()
+
+	if all, err := tenant.AllSites(ctx); err == nil {
+		ctx = all
+	}
+	return s.repo.FindAll(ctx)
+}
@@ -0,0 +1,390 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// onSite simula uma requisição autenticada de um usuário do site.
+func onSite(site int64) context.Context {
+	return tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 1, SiteID: site})
+}
+
+func corporate(site int64) context.Context {
+	return tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 99, SiteID: site, Corporate: true})
+}
+
+func TestSiteIsolation(t *testing.T) {
+	site1, site2 := onSite(1), onSite(2)
+	assetRepo := memory.NewAssetMemoryRepo()
+	orderRepo := memory.NewWorkOrderMemoryRepo()
+	planRepo := memory.NewMaintenancePlanMemoryRepo()
+	assets := service.NewAssetService(assetRepo)
+	orders := service.NewWorkOrderService(orderRepo, service.WithAssets(assetRepo))
+	plans := service.NewMaintenancePlanService(planRepo, assetRepo, memory.NewJobPlanMemoryRepo())
+	users := service.NewUserService(memory.NewUserMemoryRepo())
+
+	press := domain.Asset{Name: "Prensa 01", Location: "Linha 1", ExternalCode: "TAG-1"}
+	if err := assets.Create(site1, &press); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	if press.SiteID != 1 {
+		t.Fatalf("asset site = %d, want 1", press.SiteID)
+	}
+	// Mesmo código externo em outro site é permitido.
+	if err := assets.Create(site2, &domain.Asset{Name: "Prensa 01", Location: "Linha 1", ExternalCode: "TAG-1"}); err != nil {
+		t.Fatalf("same code on another site: %v", err)
+	}
+	order := domain.WorkOrder{AssetID: press.ID, Title: "Vazamento"}
+	if err := orders.Create(site1, &order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	if order.SiteID != 1 {
+		t.Fatalf("order site = %d, want 1", order.SiteID)
+	}
+	freq := int64(30)
+	plan := domain.MaintenancePlan{AssetID: press.ID, RuleType: domain.PlanRuleTime, FrequencyDays: &freq, Active: true}
+	if err := plans.Create(site1, &plan); err != nil {
+		t.Fatalf("create plan: %v", err)
+	}
+	if err := users.Create(site1, &domain.User{Name: "Ana", Email: "ana@example.com"}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	if _, err := assets.Get(site2, press.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("asset from site 2: err = %v, want ErrNotFound", err)
+	}
+	if list, _ := assets.List(site2, domain.AssetFilter{}); len(list) != 1 || list[0].SiteID != 2 {
+		t.Errorf("site 2 assets = %+v, want only its own", list)
+	}
+	if list, _ := orders.List(site2, domain.WorkOrderFilter{}); len(list) != 0 {
+		t.Errorf("site 2 sees %d orders, want 0", len(list))
+	}
+	if _, err := orderRepo.FindByID(site2, order.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("order from site 2: err = %v, want ErrNotFound", err)
+	}
+	if _, err := orders.Transition(site2, order.ID, service.TransitionRequest{Status: domain.WOStatusInProgress}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("transition from site 2: err = %v, want ErrNotFound", err)
+	}
+	if err := orders.Create(site2, &domain.WorkOrder{AssetID: press.ID, Title: "Intrusa"}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("order on another site's asset: err = %v, want ErrInvalidInput", err)
+	}
+	if err := plans.Create(site2, &domain.MaintenancePlan{AssetID: press.ID, RuleType: domain.PlanRuleTime, FrequencyDays: &freq}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("plan on another site's asset: err = %v, want ErrInvalidInput", err)
+	}
+	if list, _ := plans.List(site2); len(list) != 0 {
+		t.Errorf("site 2 sees %d plans, want 0", len(list))
+	}
+	if list, _ := users.List(site2); len(list) != 0 {
+		t.Errorf("site 2 sees %d users, want 0", len(list))
+	}
+
+	// Sem principal nem System a chamada é recusada.
+	if _, err := assets.List(context.Background(), domain.AssetFilter{}); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Errorf("unscoped list: err = %v, want ErrUnauthorized", err)
+	}
+	// O sistema (agendador, monitor de SLA) enxerga todos os sites.
+	if list, _ := assets.List(tenant.System(context.Background()), domain.AssetFilter{}); len(list) != 2 {
+		t.Errorf("system sees %d assets, want 2", len(list))
+	}
+}
+
+// Solicitações, turnos, técnicos, peças e alertas também são da planta: outro
+// site não lista, não lê e não altera.
+func TestSiteIsolation_PlantData(t *testing.T) {
+	site1, site2 := onSite(1), onSite(2)
+	assetRepo := memory.NewAssetMemoryRepo()
+	orderRepo := memory.NewWorkOrderMemoryRepo()
+	shiftRepo := memory.NewShiftMemoryRepo()
+	userRepo := memory.NewUserMemoryRepo()
+	alerts := service.NewNotificationService(userRepo, memory.NewEscalationMemoryRepo(), memory.NewAlertMemoryRepo(), nil)
+	orders := service.NewWorkOrderService(orderRepo, service.WithAssets(assetRepo))
+	requests := service.NewMaintenanceRequestService(memory.NewMaintenanceRequestMemoryRepo(), assetRepo, orderRepo, orders)
+	shifts := service.NewShiftService(shiftRepo)
+	technicians := service.NewTechnicianService(memory.NewTechnicianMemoryRepo(), shiftRepo)
+	parts := service.NewSparePartService(memory.NewSparePartMemoryRepo(), alerts)
+
+	press := domain.Asset{Name: "Prensa 01", Location: "Linha 1"}
+	if err := assetRepo.Create(site1, &press); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	sub, err := requests.Submit(site1, &domain.MaintenanceRequest{AssetID: press.ID, Title: "Barulho no redutor"})
+	if err != nil {
+		t.Fatalf("submit request: %v", err)
+	}
+	req := sub.Request
+	if req.SiteID != 1 {
+		t.Fatalf("request site = %d, want 1", req.SiteID)
+	}
+	shift := domain.Shift{Name: "A", StartTime: "06:00", EndTime: "14:00", Weekdays: []int{1, 2, 3, 4, 5}}
+	if err := shifts.Create(site1, &shift); err != nil {
+		t.Fatalf("create shift: %v", err)
+	}
+	tech := domain.Technician{Name: "Rui", Skills: []string{"mecanica"}, ShiftID: shift.ID}
+	if err := technicians.Create(site1, &tech); err != nil {
+		t.Fatalf("create technician: %v", err)
+	}
+	if tech.SiteID != 1 {
+		t.Fatalf("technician site = %d, want 1", tech.SiteID)
+	}
+	// A peça nasce abaixo do mínimo: abre o alerta de estoque baixo no site 1.
+	part := domain.SparePart{Code: "ROL-6205", Name: "Rolamento", MinQuantity: 2}
+	if err := parts.Create(site1, &part); err != nil {
+		t.Fatalf("create part: %v", err)
+	}
+	if _, err := parts.AdjustStock(site1, part.ID, 1); err != nil {
+		t.Fatalf("adjust stock: %v", err)
+	}
+	list, err := alerts.ListAlerts(site1, true)
+	if err != nil || len(list) != 1 || list[0].SiteID != 1 {
+		t.Fatalf("site 1 alerts = %+v, %v; want the low stock alert", list, err)
+	}
+	alert := list[0]
+	outsider := domain.User{Name: "Bia", Email: "bia@example.com"}
+	if err := userRepo.Create(site2, &outsider); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	// Solicitações: nem leitura, nem triagem, nem decisão.
+	if _, err := requests.Get(site2, req.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("request from site 2: err = %v, want ErrNotFound", err)
+	}
+	if list, _ := requests.List(site2, domain.RequestFilter{}); len(list) != 0 {
+		t.Errorf("site 2 sees %d requests, want 0", len(list))
+	}
+	if _, err := requests.Reject(site2, req.ID, "não é nosso"); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("reject from site 2: err = %v, want ErrNotFound", err)
+	}
+	if _, _, err := requests.Accept(site2, req.ID, service.AcceptRequest{}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("accept from site 2: err = %v, want ErrNotFound", err)
+	}
+	if _, err := requests.Submit(site2, &domain.MaintenanceRequest{AssetID: press.ID, Title: "Intrusa"}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("request on another site's asset: err = %v, want ErrInvalidInput", err)
+	}
+
+	// Turnos e técnicos.
+	if list, _ := shifts.List(site2); len(list) != 0 {
+		t.Errorf("site 2 sees %d shifts, want 0", len(list))
+	}
+	if err := shifts.Delete(site2, shift.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("delete shift from site 2: err = %v, want ErrNotFound", err)
+	}
+	if list, _ := technicians.List(site2); len(list) != 0 {
+		t.Errorf("site 2 sees %d technicians, want 0", len(list))
+	}
+	name := "Intruso"
+	if _, err := technicians.Update(site2, tech.ID, service.TechnicianPatch{Name: &name}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("update technician from site 2: err = %v, want ErrNotFound", err)
+	}
+	if err := technicians.Create(site2, &domain.Technician{Name: "Zé", ShiftID: shift.ID}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("technician on another site's shift: err = %v, want ErrInvalidInput", err)
+	}
+
+	// Estoque: o mesmo código pode existir em cada planta, com saldo próprio.
+	if list, _ := parts.List(site2); len(list) != 0 {
+		t.Errorf("site 2 sees %d parts, want 0", len(list))
+	}
+	if _, err := parts.AdjustStock(site2, part.ID, -1); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("stock movement from site 2: err = %v, want ErrNotFound", err)
+	}
+	if err := parts.Create(site2, &domain.SparePart{Code: "ROL-6205", Name: "Rolamento"}); err != nil {
+		t.Errorf("same part code on another site: %v", err)
+	}
+
+	// Alertas.
+	if list, _ := alerts.ListAlerts(site2, false); len(list) != 0 {
+		t.Errorf("site 2 sees %d alerts, want 0", len(list))
+	}
+	if _, err := alerts.Ack(site2, alert.ID, outsider.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("ack from site 2: err = %v, want ErrNotFound", err)
+	}
+	if list, _ := alerts.ListAlerts(tenant.System(context.Background()), true); len(list) != 1 {
+		t.Errorf("system sees %d escalating alerts, want 1", len(list))
+	}
+}
+
+// Paradas, leituras, produção e checklists não têm site próprio: herdam o do
+// ativo ou da OS. Roteiros têm site_id.
+func TestSiteIsolation_AssetRecords(t *testing.T) {
+	site1, site2 := onSite(1), onSite(2)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	events := memory.NewDowntimeMemoryRepo(assets)
+	signals := memory.NewSignalMemoryRepo(assets)
+	counts := memory.NewProductionMemoryRepo(assets)
+	checklists := memory.NewChecklistMemoryRepo(orders)
+	jobPlans := memory.NewJobPlanMemoryRepo()
+
+	press := domain.Asset{Name: "Prensa 01", Location: "Linha 1"}
+	if err := assets.Create(site1, &press); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	order := domain.WorkOrder{AssetID: press.ID, Title: "Vazamento"}
+	if err := orders.Create(site1, &order); err != nil {
+		t.Fatalf("create order: %v", err)
+	}
+	start := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	event := domain.DowntimeEvent{AssetID: press.ID, ReasonCode: "MEC", StartedAt: start}
+	if err := events.Create(site1, &event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	if err := signals.CreateMeterReading(site1, &domain.MeterReading{AssetID: press.ID, Value: 100, ReadAt: start}); err != nil {
+		t.Fatalf("create reading: %v", err)
+	}
+	if _, err := counts.CreateBatch(site1, []domain.ProductionCount{{AssetID: press.ID, PeriodStart: start, PeriodEnd: start.Add(time.Hour), TotalCount: 10, GoodCount: 9}}); err != nil {
+		t.Fatalf("create counts: %v", err)
+	}
+	if err := checklists.Create(site1, order.ID, []domain.ChecklistItem{{Step: 1, Description: "Apertar"}}); err != nil {
+		t.Fatalf("create checklist: %v", err)
+	}
+	plan := domain.JobPlan{Name: "Lubrificação"}
+	if err := jobPlans.Create(site1, &plan); err != nil {
+		t.Fatalf("create job plan: %v", err)
+	}
+	if plan.SiteID != 1 {
+		t.Fatalf("job plan site = %d, want 1", plan.SiteID)
+	}
+
+	// O site 2 não lê nem altera nada disso.
+	if list, err := events.FindByAsset(site2, press.ID, nil, nil); err != nil || len(list) != 0 {
+		t.Errorf("site 2 downtime = %v, %v; want none", list, err)
+	}
+	if _, err := events.FindOpenByAsset(site2, press.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("site 2 open downtime: err = %v, want ErrNotFound", err)
+	}
+	end := start.Add(time.Hour)
+	event.EndedAt = &end
+	if err := events.Update(site2, &event); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("site 2 closing downtime: err = %v, want ErrNotFound", err)
+	}
+	if err := events.Create(site2, &domain.DowntimeEvent{AssetID: press.ID, ReasonCode: "MEC", StartedAt: end}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("site 2 downtime on foreign asset: err = %v, want ErrNotFound", err)
+	}
+	if _, err := signals.MeterReadingAt(site2, press.ID, start); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("site 2 meter reading: err = %v, want ErrNotFound", err)
+	}
+	if err := signals.CreateConditionReading(site2, &domain.ConditionReading{AssetID: press.ID, Parameter: "vibration", Value: 3, ReadAt: start}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("site 2 condition reading: err = %v, want ErrNotFound", err)
+	}
+	if list, err := counts.FindAll(site2); err != nil || len(list) != 0 {
+		t.Errorf("site 2 production = %v, %v; want none", list, err)
+	}
+	if _, err := counts.CreateBatch(site2, []domain.ProductionCount{{AssetID: press.ID, PeriodStart: end, PeriodEnd: end.Add(time.Hour), TotalCount: 1}}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Errorf("site 2 production on foreign asset: err = %v, want ErrInvalidInput", err)
+	}
+	if items, err := checklists.FindByWorkOrder(site2, order.ID); err != nil || len(items) != 0 {
+		t.Errorf("site 2 checklist = %v, %v; want none", items, err)
+	}
+	if err := checklists.UpdateItem(site2, order.ID, &domain.ChecklistItem{Step: 1, Done: true}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("site 2 checklist update: err = %v, want ErrNotFound", err)
+	}
+	if _, err := jobPlans.FindByID(site2, plan.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("site 2 job plan: err = %v, want ErrNotFound", err)
+	}
+	if err := jobPlans.Update(site2, &domain.JobPlan{ID: plan.ID, Name: "Outro"}); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("site 2 job plan update: err = %v, want ErrNotFound", err)
+	}
+	if list, err := jobPlans.FindAll(site2); err != nil || len(list) != 0 {
+		t.Errorf("site 2 job plans = %v, %v; want none", list, err)
+	}
+
+	// O corporativo enxerga tudo.
+	all, err := tenant.AllSites(corporate(2))
+	if err != nil {
+		t.Fatalf("all sites: %v", err)
+	}
+	if list, err := events.FindByAsset(all, press.ID, nil, nil); err != nil || len(list) != 1 {
+		t.Errorf("corporate downtime = %v, %v; want 1", list, err)
+	}
+	if items, err := checklists.FindByWorkOrder(all, order.ID); err != nil || len(items) != 1 {
+		t.Errorf("corporate checklist = %v, %v; want 1", items, err)
+	}
+}
+
+func TestUserService_SiteRules(t *testing.T) {
+	users := service.NewUserService(memory.NewUserMemoryRepo())
+
+	if err := users.Create(onSite(1), &domain.User{Name: "Bia", Email: "bia@example.com", SiteID: 2}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("user on another site: err = %v, want ErrForbidden", err)
+	}
+	if err := users.Create(onSite(1), &domain.User{Name: "Caio", Email: "caio@example.com", Corporate: true}); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("corporate user by site user: err = %v, want ErrForbidden", err)
+	}
+	u := domain.User{Name: "Duda", Email: "duda@example.com", SiteID: 2, Corporate: true}
+	if err := users.Create(corporate(1), &u); err != nil {
+		t.Fatalf("create by corporate: %v", err)
+	}
+	if u.SiteID != 2 || !u.Corporate {
+		t.Errorf("user = %+v, want site 2 and corporate", u)
+	}
+}
+
+func TestReportService_Sites(t *testing.T) {
+	assetRepo := memory.NewAssetMemoryRepo()
+	events := memory.NewDowntimeMemoryRepo(assetRepo)
+	siteRepo := memory.NewSiteMemoryRepo()
+	reports := service.NewReportService(
+		memory.NewReportMemoryRepo(assetRepo, memory.NewWorkOrderMemoryRepo(), events, memory.NewShiftMemoryRepo(),
+			memory.NewProductionMemoryRepo(assetRepo), memory.NewFailureCodeMemoryRepo()),
+		service.WithSiteDirectory(siteRepo),
+	)
+	sites := service.NewSiteService(siteRepo)
+
+	if err := sites.Create(onSite(1), &domain.Site{Code: "sul", Name: "Planta Sul"}); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("site by site user: err = %v, want ErrForbidden", err)
+	}
+	for _, s := range []*domain.Site{{Code: "matriz", Name: "Matriz"}, {Code: "sul", Name: "Planta Sul"}} {
+		if err := sites.Create(corporate(1), s); err != nil {
+			t.Fatalf("create site: %v", err)
+		}
+	}
+
+	start := time.Date(2025, 3, 10, 8, 0, 0, 0, time.UTC)
+	for site, minutes := range map[int64]int{1: 30, 2: 90} {
+		ctx := onSite(site)
+		a := domain.Asset{Name: "Torno", Location: "Usinagem"}
+		if err := assetRepo.Create(ctx, &a); err != nil {
+			t.Fatalf("create asset: %v", err)
+		}
+		end := start.Add(time.Duration(minutes) * time.Minute)
+		if err := events.Create(ctx, &domain.DowntimeEvent{AssetID: a.ID, ReasonCode: "MEC", StartedAt: start, EndedAt: &end}); err != nil {
+			t.Fatalf("create event: %v", err)
+		}
+	}
+
+	from, to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := reports.Sites(onSite(1), from, to); !errors.Is(err, domain.ErrForbidden) {
+		t.Errorf("sites report by site user: err = %v, want ErrForbidden", err)
+	}
+	rows, err := reports.Sites(corporate(1), from, to)
+	if err != nil {
+		t.Fatalf("sites report: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("rows = %d, want 2", len(rows))
+	}
+	for i, want := range []struct {
+		code    string
+		minutes int64
+	}{{"MATRIZ", 30}, {"SUL", 90}} {
+		if rows[i].Code != want.code || rows[i].Breakdowns != 1 || rows[i].DowntimeMinutes != want.minutes {
+			t.Errorf("row %d = %+v, want %s with 1 breakdown and %d min", i, rows[i], want.code, want.minutes)
+		}
+	}
+
+	// O relatório mensal do usuário do site só traz a própria planta.
+	own, err := reports.MonthlyDowntime(onSite(2), from, to)
+	if err != nil {
+		t.Fatalf("downtime: %v", err)
+	}
+	if len(own) != 1 || own[0].DowntimeMinutes != 90 {
+		t.Errorf("site 2 downtime = %+v, want only its 90 min", own)
+	}
+}
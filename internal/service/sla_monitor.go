@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// SLAMonitor é o detector de atrasos: periodicamente marca (sla_breached_at)
+// as OS em aberto que estouraram o prazo de atendimento ou de conclusão.
+type SLAMonitor struct {
+	orders     repository.WorkOrderRepository
+	interval   time.Duration
+	notifier   Notifier
+	warnBefore time.Duration
+	now        func() time.Time
+}
+
+// SLAMonitorOption liga recursos opcionais do detector.
+type SLAMonitorOption func(*SLAMonitor)
+
+// WithSLAAlerts abre alertas de preventiva atrasada e de OS cuja conclusão
+// vence dentro de warnBefore.
+func WithSLAAlerts(n Notifier, warnBefore time.Duration) SLAMonitorOption {
+	return func(m *SLAMonitor) { m.notifier, m.warnBefore = n, warnBefore }
+}
+
+func NewSLAMonitor(orders repository.WorkOrderRepository, interval time.Duration, opts ...SLAMonitorOption) *SLAMonitor {
+	m := &SLAMonitor{orders: orders, interval: interval, now: time.Now}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Check roda uma varredura e retorna as OS marcadas nela.
+func (m *SLAMonitor) Check(ctx context.Context) ([]domain.WorkOrder, error) {
+	ctx, span := tracer.Start(ctx, "SLAMonitor.Check")
+	defer span.End()
+
+	now := m.now()
+	breached, err := m.orders.MarkSLABreached(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range breached {
+		log.WithFields(log.Fields{
+			"work_order_id": o.ID,
+			"asset_id":      o.AssetID,
+			"priority":      o.Priority,
+		}).Warn("work order SLA breached")
+		if o.Type == domain.WOTypePreventive {
+			m.raise(ctx, domain.EventPreventiveOverdue, &o,
+				fmt.Sprintf("Preventiva OS #%d (%s) passou do prazo de conclusão.", o.ID, o.Title))
+		}
+	}
+	if err := m.warnAtRisk(ctx, now); err != nil {
+		return breached, err
+	}
+	return breached, nil
+}
+
+// warnAtRisk alerta as OS que ainda estão no prazo, mas vencem em até warnBefore.
+// Alertas repetidos são ignorados pelo Notifier, então a varredura pode rodar sempre.
+func (m *SLAMonitor) warnAtRisk(ctx context.Context, now time.Time) error {
+	if m.notifier == nil || m.warnBefore <= 0 {
+		return nil
+	}
+	limit := now.Add(m.warnBefore)
+	return m.orders.Stream(ctx, domain.WorkOrderFilter{DueBefore: &limit}, func(o *domain.WorkOrder) error {
+		if o.IsOverdue(now) {
+			return nil
+		}
+		left := o.ResolutionDueAt.Sub(now).Round(time.Minute)
+		m.raise(ctx, domain.EventSLAAtRisk, o,
+			fmt.Sprintf("OS #%d (%s, prioridade %s) vence em %s.", o.ID, o.Title, o.Priority, left))
+		return nil
+	})
+}
+
+func (m *SLAMonitor) raise(ctx context.Context, event domain.NotificationEvent, o *domain.WorkOrder, message string) {
+	if m.notifier == nil {
+		return
+	}
+	alert := &domain.Alert{SiteID: o.SiteID, Event: event, RefType: domain.AlertRefWorkOrder, RefID: o.ID, Message: message}
+	if err := m.notifier.Raise(ctx, alert); err != nil {
+		log.WithError(err).WithField("work_order_id", o.ID).Error("sla alert failed")
+	}
+}
+
+// Run executa Check a cada intervalo até o contexto ser cancelado, sinalizando hb
+// (pode ser nil) com o resultado de cada ciclo.
+func (m *SLAMonitor) Run(ctx context.Context, hb Beater) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		_, err := m.Check(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.WithError(err).Error("sla monitor check failed")
+		}
+		beat(ctx, hb, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// SLAService mantém a matriz de SLA (criticidade × tipo de OS).
+// Mudanças valem para OS abertas depois delas; prazos já calculados não mudam.
+type SLAService struct {
+	repo repository.SLARepository
+}
+
+func NewSLAService(r repository.SLARepository) *SLAService {
+	return &SLAService{repo: r}
+}
+
+func (s *SLAService) List(ctx context.Context) ([]domain.SLAPolicy, error) {
+	ctx, span := tracer.Start(ctx, "SLAService.List")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
+
+// Replace troca a matriz inteira; células ausentes deixam de ter SLA.
+func (s *SLAService) Replace(ctx context.Context, policies []domain.SLAPolicy) error {
+	ctx, span := tracer.Start(ctx, "SLAService.Replace")
+	defer span.End()
+
+	type cell struct {
+		criticality domain.Criticality
+		woType      domain.WorkOrderType
+	}
+	seen := map[cell]bool{}
+	for i := range policies {
+		p := &policies[i]
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		c := cell{p.Criticality, p.Type}
+		if seen[c] {
+			return domain.ErrInvalidInput
+		}
+		seen[c] = true
+	}
+	return s.repo.ReplaceAll(ctx, policies)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	log "github.com/sirupsen/logrus"
+)
+
+// SparePartService mantém o estoque de peças e dispara o alerta de estoque baixo.
+type SparePartService struct {
+	repo     repository.SparePartRepository
+	notifier Notifier // opcional
+}
+
+func NewSparePartService(r repository.SparePartRepository, n Notifier) *SparePartService {
+	return &SparePartService{repo: r, notifier: n}
+}
+
+func (s *SparePartService) Create(ctx context.Context, p *domain.SparePart) error {
+	ctx, span := tracer.Start(ctx, "SparePartService.Create")
+	defer span.End()
+
+	p.Normalize()
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	if err := s.repo.Create(ctx, p); err != nil {
+		return err
+	}
+	s.checkStock(ctx, p)
+	return nil
+}
+
+// SparePartPatch traz apenas os campos a alterar; nil mantém o valor atual.
+type SparePartPatch struct {
+	Name        *string
+	Unit        *string
+	MinQuantity *float64
+}
+
+func (s *SparePartService) Update(ctx context.Context, id int64, patch SparePartPatch) (*domain.SparePart, error) {
+	ctx, span := tracer.Start(ctx, "SparePartService.Update")
+	defer span.End()
+
+	p, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if patch.Name != nil {
+		p.Name = *patch.Name
+	}
+	if patch.Unit != nil {
+		p.Unit = *patch.Unit
+	}
+	if patch.MinQuantity != nil {
+		p.MinQuantity = *patch.MinQuantity
+	}
+	p.Normalize()
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, p); err != nil {
+		return nil, err
+	}
+	s.checkStock(ctx, p)
+	return p, nil
+}
+
+// AdjustStock registra entrada (delta > 0) ou saída (delta < 0) de estoque.
+func (s *SparePartService) AdjustStock(ctx context.Context, id int64, delta float64) (*domain.SparePart, error) {
+	ctx, span := tracer.Start(ctx, "SparePartService.AdjustStock")
+	defer span.End()
+
+	if delta == 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	p, err := s.repo.AdjustStock(ctx, id, delta)
+	if err != nil {
+		return nil, err
+	}
+	s.checkStock(ctx, p)
+	return p, nil
+}
+
+func (s *SparePartService) List(ctx context.Context) ([]domain.SparePart, error) {
+	ctx, span := tracer.Start(ctx, "SparePartService.List")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
+
+// checkStock abre o alerta de estoque baixo ou o encerra quando o saldo volta
+// ao mínimo; falhas de notificação não desfazem a movimentação.
+func (s *SparePartService) checkStock(ctx context.Context, p *domain.SparePart) {
+	if s.notifier == nil {
+		return
+	}
+	var err error
+	if p.IsLow() {
+		err = s.notifier.Raise(ctx, &domain.Alert{
+			SiteID:  p.SiteID,
+			Event:   domain.EventLowStock,
+			RefType: domain.AlertRefSparePart,
+			RefID:   p.ID,
+			Message: fmt.Sprintf("Peça %s (%s): saldo %g %s, mínimo %g.", p.Code, p.Name, p.Quantity, p.Unit, p.MinQuantity),
+		})
+	} else {
+		err = s.notifier.Resolve(ctx, domain.AlertRefSparePart, p.ID)
+	}
+	if err != nil {
+		log.WithError(err).WithField("spare_part_id", p.ID).Error("low stock alert failed")
+	}
+}
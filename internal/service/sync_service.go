@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	DefaultSyncLimit = 500
+	MaxSyncLimit     = 1000
+)
+
+// SyncService atende o app offline dos técnicos: entrega o delta desde a última
+// revisão vista e aplica as alterações feitas sem rede, detectando conflitos.
+type SyncService struct {
+	changes     repository.SyncRepository
+	orders      repository.WorkOrderRepository
+	checklists  repository.ChecklistRepository
+	workOrders  *WorkOrderService
+	technicians repository.TechnicianRepository
+}
+
+// SyncOption configura o SyncService.
+type SyncOption func(*SyncService)
+
+// WithTechnicianScope restringe o delta de quem é técnico (usuário vinculado a
+// um técnico) às OS atribuídas a ele. Sem a opção, ou para usuários sem
+// vínculo (supervisores), o delta é o do site inteiro.
+func WithTechnicianScope(r repository.TechnicianRepository) SyncOption {
+	return func(s *SyncService) { s.technicians = r }
+}
+
+func NewSyncService(changes repository.SyncRepository, orders repository.WorkOrderRepository,
+	checklists repository.ChecklistRepository, workOrders *WorkOrderService, opts ...SyncOption) *SyncService {
+	s := &SyncService{changes: changes, orders: orders, checklists: checklists, workOrders: workOrders}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Changes devolve até limit alterações com revisão maior que since. OS que
+// saíram do backlog (ou do técnico) vão como Deleted; since = 0 é a carga inicial.
+func (s *SyncService) Changes(ctx context.Context, since int64, limit int) (*domain.SyncPage, error) {
+	ctx, span := tracer.Start(ctx, "SyncService.Changes")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("sync.since", since))
+
+	if since < 0 || limit < 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = DefaultSyncLimit
+	}
+	limit = min(limit, MaxSyncLimit)
+
+	technicianID, err := s.technician(ctx)
+	if err != nil {
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int64("sync.technician_id", technicianID))
+	changes, err := s.changes.Changes(ctx, since, technicianID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &domain.SyncPage{Changes: changes, Next: since}
+	if len(changes) > limit {
+		page.Changes, page.HasMore = changes[:limit], true
+	}
+	for i := range page.Changes {
+		ch := &page.Changes[i]
+		if ch.WorkOrder != nil && !ch.WorkOrder.IsOpen() {
+			ch.Deleted = true
+		}
+		page.Next = ch.Revision
+	}
+	return page, nil
+}
+
+// technician devolve o técnico vinculado ao usuário da chamada (0 quando não há
+// vínculo ou WithTechnicianScope).
+func (s *SyncService) technician(ctx context.Context) (int64, error) {
+	p, ok := tenant.PrincipalFrom(ctx)
+	if s.technicians == nil || !ok {
+		return 0, nil
+	}
+	t, err := s.technicians.FindByUser(ctx, p.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return t.ID, nil
+}
+
+// SyncOp é a operação de uma alteração feita offline.
+type SyncOp string
+
+const (
+	SyncCreate     SyncOp = "create"     // work_order: abre a OS
+	SyncTransition SyncOp = "transition" // work_order: muda o status
+	SyncUpdate     SyncOp = "update"     // checklist_item: aponta o passo
+)
+
+// SyncMutation é uma alteração feita no aparelho. BaseRevision é a revisão do
+// registro quando o aparelho o baixou e UpdatedAt a hora da alteração no aparelho.
+type SyncMutation struct {
+	ClientID     string
+	Entity       domain.SyncEntity
+	Op           SyncOp
+	ID           int64 // OS (também nos passos de checklist)
+	Step         int
+	BaseRevision int64
+	UpdatedAt    time.Time
+
+	WorkOrder  *domain.WorkOrder
+	Transition *TransitionRequest
+	Checklist  *ChecklistUpdate
+}
+
+// SyncStatus é o resultado de uma alteração do lote.
+type SyncStatus string
+
+const (
+	SyncApplied  SyncStatus = "applied"
+	SyncConflict SyncStatus = "conflict"
+	SyncRejected SyncStatus = "rejected"
+)
+
+// SyncResult diz o que aconteceu com cada alteração. Current é a versão do
+// servidor: a gravada (applied) ou a que venceu o conflito; Err explica a recusa.
+type SyncResult struct {
+	ClientID string
+	Status   SyncStatus
+	ID       int64
+	Current  *domain.SyncChange
+	Err      error
+}
+
+// Push aplica as alterações na ordem do lote, cada uma por conta própria: uma
+// recusa não desfaz nem impede as demais. Se o registro mudou no servidor depois
+// da BaseRevision, vale a alteração mais recente pelo UpdatedAt; se a do
+// servidor for mais nova, a do aparelho volta como conflito.
+func (s *SyncService) Push(ctx context.Context, mutations []SyncMutation) []SyncResult {
+	ctx, span := tracer.Start(ctx, "SyncService.Push")
+	defer span.End()
+	span.SetAttributes(attribute.Int("sync.mutations", len(mutations)))
+
+	results := make([]SyncResult, 0, len(mutations))
+	for _, m := range mutations {
+		res := s.apply(ctx, m)
+		res.ClientID = m.ClientID
+		results = append(results, res)
+	}
+	return results
+}
+
+func (s *SyncService) apply(ctx context.Context, m SyncMutation) SyncResult {
+	switch {
+	case m.Entity == domain.SyncWorkOrder && m.Op == SyncCreate && m.WorkOrder != nil:
+		return s.createWorkOrder(ctx, m)
+	case m.Entity == domain.SyncWorkOrder && m.Op == SyncTransition && m.Transition != nil:
+		return s.transition(ctx, m)
+	case m.Entity == domain.SyncChecklistItem && m.Op == SyncUpdate && m.Checklist != nil:
+		return s.updateChecklist(ctx, m)
+	}
+	return rejected(m.ID, fmt.Errorf("%w: unsupported %s %s", domain.ErrInvalidInput, m.Entity, m.Op))
+}
+
+func (s *SyncService) createWorkOrder(ctx context.Context, m SyncMutation) SyncResult {
+	o := *m.WorkOrder
+	o.ID = 0
+	if err := s.workOrders.Create(ctx, &o); err != nil {
+		return rejected(0, err)
+	}
+	return SyncResult{Status: SyncApplied, ID: o.ID, Current: workOrderChange(&o)}
+}
+
+func (s *SyncService) transition(ctx context.Context, m SyncMutation) SyncResult {
+	cur, err := s.orders.FindByID(ctx, m.ID)
+	if err != nil {
+		return rejected(m.ID, err)
+	}
+	if stale(m, cur.Revision, cur.UpdatedAt) {
+		return SyncResult{Status: SyncConflict, ID: m.ID, Current: workOrderChange(cur)}
+	}
+	o, err := s.workOrders.Transition(ctx, m.ID, *m.Transition)
+	if err != nil {
+		return rejected(m.ID, err)
+	}
+	return SyncResult{Status: SyncApplied, ID: m.ID, Current: workOrderChange(o)}
+}
+
+func (s *SyncService) updateChecklist(ctx context.Context, m SyncMutation) SyncResult {
+	items, err := s.workOrders.Checklist(ctx, m.ID)
+	if err != nil {
+		return rejected(m.ID, err)
+	}
+	var cur *domain.ChecklistItem
+	for i := range items {
+		if items[i].Step == m.Step {
+			cur = &items[i]
+		}
+	}
+	if cur == nil {
+		return rejected(m.ID, domain.ErrNotFound)
+	}
+	if stale(m, cur.Revision, cur.UpdatedAt) {
+		return SyncResult{Status: SyncConflict, ID: m.ID, Current: checklistChange(m.ID, cur)}
+	}
+	it, err := s.workOrders.UpdateChecklistStep(ctx, m.ID, m.Step, *m.Checklist)
+	if err != nil {
+		return rejected(m.ID, err)
+	}
+	return SyncResult{Status: SyncApplied, ID: m.ID, Current: checklistChange(m.ID, it)}
+}
+
+// stale indica conflito: o registro mudou desde a BaseRevision e a versão do
+// servidor não é mais antiga que a alteração do aparelho.
+func stale(m SyncMutation, revision int64, updatedAt time.Time) bool {
+	return m.BaseRevision != revision && !m.UpdatedAt.After(updatedAt)
+}
+
+func rejected(id int64, err error) SyncResult {
+	return SyncResult{Status: SyncRejected, ID: id, Err: err}
+}
+
+func workOrderChange(o *domain.WorkOrder) *domain.SyncChange {
+	return &domain.SyncChange{Entity: domain.SyncWorkOrder, Revision: o.Revision, Deleted: !o.IsOpen(), WorkOrder: o}
+}
+
+func checklistChange(workOrderID int64, it *domain.ChecklistItem) *domain.SyncChange {
+	return &domain.SyncChange{Entity: domain.SyncChecklistItem, Revision: it.Revision, WorkOrderID: workOrderID, ChecklistItem: it}
+}
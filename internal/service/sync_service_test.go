@@ -0,0 +1,208 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/service"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+func TestSync_ChangesAndPushConflicts(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	jobPlans := memory.NewJobPlanMemoryRepo()
+	checklists := memory.NewChecklistMemoryRepo(orders)
+	workOrders := service.NewWorkOrderService(orders, service.WithAssets(assets), service.WithChecklists(jobPlans, checklists))
+	svc := service.NewSyncService(memory.NewSyncMemoryRepo(assets, orders, checklists), orders, checklists, workOrders)
+
+	jp := domain.JobPlan{Name: "Inspeção", Steps: []domain.JobPlanStep{{Seq: 1, Description: "Folga do cilindro"}}}
+	if err := jobPlans.Create(ctx, &jp); err != nil {
+		t.Fatalf("create job plan: %v", err)
+	}
+	asset := domain.Asset{Name: "Prensa", Criticality: domain.CriticalityB}
+	other := domain.Asset{Name: "Torno"}
+	if err := assets.Create(ctx, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	if err := assets.Create(onSite(2), &other); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	wo := domain.WorkOrder{AssetID: asset.ID, Title: "Inspeção da prensa", JobPlanID: &jp.ID}
+	if err := workOrders.Create(ctx, &wo); err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	// Carga inicial: ativo, OS e passo do site 1, em ordem de revisão.
+	page, err := svc.Changes(ctx, 0, 0)
+	if err != nil || len(page.Changes) != 3 || page.HasMore {
+		t.Fatalf("Changes(0) = %+v, %v", page, err)
+	}
+	want := []domain.SyncEntity{domain.SyncAsset, domain.SyncWorkOrder, domain.SyncChecklistItem}
+	for i, ch := range page.Changes {
+		if ch.Entity != want[i] || (i > 0 && ch.Revision <= page.Changes[i-1].Revision) {
+			t.Fatalf("change %d = %+v, want %s in revision order", i, ch, want[i])
+		}
+	}
+	if page.Next != page.Changes[2].Revision {
+		t.Fatalf("Next = %d, want %d", page.Next, page.Changes[2].Revision)
+	}
+	first, err := svc.Changes(ctx, 0, 1)
+	if err != nil || len(first.Changes) != 1 || !first.HasMore || first.Next != page.Changes[0].Revision {
+		t.Fatalf("Changes(limit 1) = %+v, %v", first, err)
+	}
+	// O contador é por site: o delta não existe em escopo de todos os sites.
+	if _, err := svc.Changes(tenant.System(context.Background()), 0, 0); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("Changes(all sites) error = %v, want ErrInvalidInput", err)
+	}
+	since := page.Next
+
+	base := page.Changes[1].Revision
+	res := svc.Push(ctx, []service.SyncMutation{
+		{ClientID: "a", Entity: domain.SyncWorkOrder, Op: service.SyncTransition, ID: wo.ID, BaseRevision: base,
+			UpdatedAt: time.Now(), Transition: &service.TransitionRequest{Status: domain.WOStatusInProgress}},
+		// Mesma revisão de base, mas alterada antes da versão que já está no servidor.
+		{ClientID: "b", Entity: domain.SyncWorkOrder, Op: service.SyncTransition, ID: wo.ID, BaseRevision: base,
+			UpdatedAt: time.Now().Add(-time.Hour), Transition: &service.TransitionRequest{Status: domain.WOStatusCanceled}},
+		// Revisão antiga, mas alteração mais nova que a do servidor: vale a do aparelho.
+		{ClientID: "c", Entity: domain.SyncChecklistItem, Op: service.SyncUpdate, ID: wo.ID, Step: 1, BaseRevision: 1,
+			UpdatedAt: time.Now().Add(time.Minute), Checklist: &service.ChecklistUpdate{Done: true, Note: "Sem folga"}},
+		{ClientID: "d", Entity: domain.SyncAsset, Op: service.SyncUpdate, ID: asset.ID},
+	})
+	if len(res) != 4 {
+		t.Fatalf("Push() = %+v", res)
+	}
+	if res[0].ClientID != "a" || res[0].Status != service.SyncApplied || res[0].Current.Revision <= base {
+		t.Fatalf("transition should apply: %+v", res[0])
+	}
+	if res[1].Status != service.SyncConflict || res[1].Current.WorkOrder.Status != domain.WOStatusInProgress {
+		t.Fatalf("older change should conflict with the server version: %+v", res[1])
+	}
+	if res[2].Status != service.SyncApplied || !res[2].Current.ChecklistItem.Done {
+		t.Fatalf("newer checklist change should apply: %+v", res[2])
+	}
+	if res[3].Status != service.SyncRejected || !errors.Is(res[3].Err, domain.ErrInvalidInput) {
+		t.Fatalf("unsupported mutation should be rejected: %+v", res[3])
+	}
+
+	// Cancelada, a OS sai do aparelho e o checklist dela não é mais enviado.
+	if _, err := workOrders.Transition(ctx, wo.ID, service.TransitionRequest{Status: domain.WOStatusCanceled}); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	delta, err := svc.Changes(ctx, since, 0)
+	if err != nil || len(delta.Changes) != 1 {
+		t.Fatalf("Changes(since) = %+v, %v", delta, err)
+	}
+	if ch := delta.Changes[0]; ch.Entity != domain.SyncWorkOrder || !ch.Deleted || ch.WorkOrder.ID != wo.ID {
+		t.Fatalf("canceled work order should be a deletion: %+v", ch)
+	}
+	if _, err := svc.Changes(ctx, -1, 0); err != domain.ErrInvalidInput {
+		t.Fatalf("negative token expected ErrInvalidInput, got %v", err)
+	}
+}
+
+func TestSync_TechnicianGetsOnlyAssignedOrders(t *testing.T) {
+	supervisor := onSite(1)
+	ana := tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 5, SiteID: 1})
+	bruno := tenant.WithPrincipal(context.Background(), tenant.Principal{UserID: 6, SiteID: 1})
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	jobPlans := memory.NewJobPlanMemoryRepo()
+	checklists := memory.NewChecklistMemoryRepo(orders)
+	technicians := memory.NewTechnicianMemoryRepo()
+	workOrders := service.NewWorkOrderService(orders, service.WithAssets(assets),
+		service.WithChecklists(jobPlans, checklists), service.WithAssignees(technicians))
+	svc := service.NewSyncService(memory.NewSyncMemoryRepo(assets, orders, checklists), orders, checklists, workOrders,
+		service.WithTechnicianScope(technicians))
+
+	techs := map[string]*domain.Technician{}
+	for _, tc := range []struct {
+		name   string
+		ctx    context.Context
+		user   int64
+		active bool
+	}{
+		{"ana", supervisor, 5, true}, {"bruno", supervisor, 6, true},
+		{"inativo", supervisor, 7, false}, {"outro site", onSite(2), 8, true},
+	} {
+		tech := &domain.Technician{Name: tc.name, ShiftID: 1, Active: tc.active, UserID: &tc.user}
+		if err := technicians.Create(tc.ctx, tech); err != nil {
+			t.Fatalf("create technician %s: %v", tc.name, err)
+		}
+		techs[tc.name] = tech
+	}
+	jp := domain.JobPlan{Name: "Inspeção", Steps: []domain.JobPlanStep{{Seq: 1, Description: "Folga do cilindro"}}}
+	if err := jobPlans.Create(supervisor, &jp); err != nil {
+		t.Fatalf("create job plan: %v", err)
+	}
+	asset := domain.Asset{Name: "Prensa"}
+	if err := assets.Create(supervisor, &asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	mine := domain.WorkOrder{AssetID: asset.ID, Title: "Inspeção da prensa", JobPlanID: &jp.ID, AssigneeID: &techs["ana"].ID}
+	unassigned := domain.WorkOrder{AssetID: asset.ID, Title: "Troca de óleo", JobPlanID: &jp.ID}
+	for _, wo := range []*domain.WorkOrder{&mine, &unassigned} {
+		if err := workOrders.Create(supervisor, wo); err != nil {
+			t.Fatalf("create work order: %v", err)
+		}
+	}
+
+	// O técnico baixa os ativos do site e só a OS dele, com o checklist; o
+	// supervisor (sem técnico vinculado) baixa o site inteiro.
+	page, err := svc.Changes(ana, 0, 0)
+	if err != nil || len(page.Changes) != 3 {
+		t.Fatalf("Changes(ana) = %+v, %v", page, err)
+	}
+	for _, ch := range page.Changes {
+		if (ch.WorkOrder != nil && ch.WorkOrder.ID != mine.ID) || (ch.ChecklistItem != nil && ch.WorkOrderID != mine.ID) {
+			t.Fatalf("technician got an order that is not theirs: %+v", ch)
+		}
+	}
+	if all, err := svc.Changes(supervisor, 0, 0); err != nil || len(all.Changes) != 5 {
+		t.Fatalf("Changes(supervisor) = %+v, %v", all, err)
+	}
+	initial, err := svc.Changes(bruno, 0, 0)
+	if err != nil || len(initial.Changes) != 1 || initial.Changes[0].Entity != domain.SyncAsset {
+		t.Fatalf("Changes(bruno) = %+v, %v", initial, err)
+	}
+
+	// Passada a outro técnico, a OS sai do aparelho de quem a tinha e chega no
+	// do novo responsável com o checklist.
+	if _, err := workOrders.Assign(supervisor, mine.ID, &techs["bruno"].ID); err != nil {
+		t.Fatalf("assign: %v", err)
+	}
+	gone, err := svc.Changes(ana, page.Next, 0)
+	if err != nil || len(gone.Changes) != 1 {
+		t.Fatalf("Changes(ana, since) = %+v, %v", gone, err)
+	}
+	if ch := gone.Changes[0]; ch.Entity != domain.SyncWorkOrder || !ch.Deleted || ch.WorkOrder.ID != mine.ID {
+		t.Fatalf("reassigned order should be a deletion: %+v", ch)
+	}
+	if again, err := svc.Changes(ana, gone.Next, 0); err != nil || len(again.Changes) != 0 {
+		t.Fatalf("deletion should be sent once: %+v, %v", again, err)
+	}
+	got, err := svc.Changes(bruno, initial.Next, 0)
+	if err != nil || len(got.Changes) != 2 {
+		t.Fatalf("Changes(bruno, since) = %+v, %v", got, err)
+	}
+	if got.Changes[0].WorkOrder == nil || got.Changes[0].Deleted || got.Changes[1].ChecklistItem == nil {
+		t.Fatalf("new assignee should get the order and its checklist: %+v", got.Changes)
+	}
+
+	for _, name := range []string{"inativo", "outro site"} {
+		if _, err := workOrders.Assign(supervisor, unassigned.ID, &techs[name].ID); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Fatalf("assign to %s: error = %v, want ErrInvalidInput", name, err)
+		}
+	}
+	if _, err := workOrders.Transition(supervisor, unassigned.ID, service.TransitionRequest{Status: domain.WOStatusCanceled}); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+	if _, err := workOrders.Assign(supervisor, unassigned.ID, nil); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("assign a closed order: error = %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+)
+
+// TechnicianService mantém os técnicos (especialidades e turno) do planejamento.
+type TechnicianService struct {
+	repo   repository.TechnicianRepository
+	shifts repository.ShiftRepository
+}
+
+func NewTechnicianService(r repository.TechnicianRepository, shifts repository.ShiftRepository) *TechnicianService {
+	return &TechnicianService{repo: r, shifts: shifts}
+}
+
+func (s *TechnicianService) Create(ctx context.Context, t *domain.Technician) error {
+	ctx, span := tracer.Start(ctx, "TechnicianService.Create")
+	defer span.End()
+
+	t.Normalize()
+	if err := s.validate(ctx, t); err != nil {
+		return err
+	}
+	t.Active = true
+	return s.repo.Create(ctx, t)
+}
+
+// TechnicianPatch traz apenas os campos a alterar; Skills substitui a lista
+// inteira e UserID 0 desfaz o vínculo com o usuário.
+type TechnicianPatch struct {
+	Name    *string
+	Skills  []string
+	ShiftID *int64
+	Active  *bool
+	UserID  *int64
+}
+
+func (s *TechnicianService) Update(ctx context.Context, id int64, patch TechnicianPatch) (*domain.Technician, error) {
+	ctx, span := tracer.Start(ctx, "TechnicianService.Update")
+	defer span.End()
+
+	t, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if patch.Name != nil {
+		t.Name = *patch.Name
+	}
+	if patch.Skills != nil {
+		t.Skills = patch.Skills
+	}
+	if patch.ShiftID != nil {
+		t.ShiftID = *patch.ShiftID
+	}
+	if patch.Active != nil {
+		t.Active = *patch.Active
+	}
+	if patch.UserID != nil {
+		t.UserID = nil
+		if *patch.UserID != 0 {
+			t.UserID = patch.UserID
+		}
+	}
+	t.Normalize()
+	if err := s.validate(ctx, t); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// validate exige um turno cadastrado e visível (a capacidade do técnico sai
+// dele). O técnico fica no site do turno; trocar de turno não muda o site.
+func (s *TechnicianService) validate(ctx context.Context, t *domain.Technician) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	shifts, err := s.shifts.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, sh := range shifts {
+		if sh.ID != t.ShiftID {
+			continue
+		}
+		if t.SiteID != 0 && t.SiteID != sh.SiteID {
+			return domain.ErrInvalidInput
+		}
+		t.SiteID = sh.SiteID
+		return nil
+	}
+	return domain.ErrInvalidInput
+}
+
+func (s *TechnicianService) List(ctx context.Context) ([]domain.Technician, error) {
+	ctx, span := tracer.Start(ctx, "TechnicianService.List")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
@@ -0,0 +1,6 @@
+package service
+
+import "go.opentelemetry.io/otel"
+
+// tracer gera os spans das chamadas de serviço (entre o span HTTP e os de SQL).
+var tracer = otel.Tracer("github.com/maxwellsouza/go-factory-maintenance/internal/service")
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	"github.com/maxwellsouza/go-factory-maintenance/internal/tenant"
+)
+
+// UserService mantém os usuários (destinatários de notificações e quem acessa a
+// API) e suas preferências.
+type UserService struct {
+	repo repository.UserRepository
+}
+
+func NewUserService(r repository.UserRepository) *UserService {
+	return &UserService{repo: r}
+}
+
+// Create cadastra o usuário no site do principal. SiteID de outro site e o
+// perfil corporativo só podem ser dados por usuários corporativos (ErrForbidden).
+func (s *UserService) Create(ctx context.Context, u *domain.User) error {
+	ctx, span := tracer.Start(ctx, "UserService.Create")
+	defer span.End()
+
+	u.Normalize()
+	if err := u.Validate(); err != nil {
+		return err
+	}
+	if u.Corporate {
+		if _, err := tenant.AllSites(ctx); err != nil {
+			return err
+		}
+	}
+	if u.SiteID != 0 {
+		var err error
+		if ctx, err = tenant.OnSite(ctx, u.SiteID); err != nil {
+			return err
+		}
+	}
+	u.Active = true
+	return s.repo.Create(ctx, u)
+}
+
+// UserPatch traz apenas os campos a alterar; nil mantém o valor atual.
+// Preferences substitui o mapa inteiro.
+type UserPatch struct {
+	Name        *string
+	Email       *string
+	Phone       *string
+	ChatID      *string
+	Active      *bool
+	Corporate   *bool // só usuários corporativos alteram
+	Preferences map[domain.NotificationEvent][]domain.NotificationChannel
+}
+
+func (s *UserService) Update(ctx context.Context, id int64, patch UserPatch) (*domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Update")
+	defer span.End()
+
+	u, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if patch.Name != nil {
+		u.Name = *patch.Name
+	}
+	if patch.Email != nil {
+		u.Email = *patch.Email
+	}
+	if patch.Phone != nil {
+		u.Phone = *patch.Phone
+	}
+	if patch.ChatID != nil {
+		u.ChatID = *patch.ChatID
+	}
+	if patch.Active != nil {
+		u.Active = *patch.Active
+	}
+	if patch.Corporate != nil && *patch.Corporate != u.Corporate {
+		if _, err := tenant.AllSites(ctx); err != nil {
+			return nil, err
+		}
+		u.Corporate = *patch.Corporate
+	}
+	if patch.Preferences != nil {
+		u.Preferences = patch.Preferences
+	}
+	u.Normalize()
+	if err := u.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+func (s *UserService) List(ctx context.Context) ([]domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.List")
+	defer span.End()
+
+	return s.repo.FindAll(ctx)
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Assign troca o técnico responsável pela OS em aberto (nil retira o
+// responsável). OS fechada é ErrConflict. O técnico anterior recebe a OS como
+// removida na próxima sincronização e o novo, a OS com o checklist.
+func (s *WorkOrderService) Assign(ctx context.Context, id int64, technicianID *int64) (*domain.WorkOrder, error) {
+	ctx, span := tracer.Start(ctx, "WorkOrderService.Assign")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("work_order.id", id))
+
+	o, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !o.IsOpen() {
+		return nil, domain.ErrConflict
+	}
+	o.AssigneeID = technicianID
+	if err := s.checkAssignee(ctx, o); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// checkAssignee exige um técnico ativo do site da OS. Sem WithAssignees, a OS
+// não pode ter responsável.
+func (s *WorkOrderService) checkAssignee(ctx context.Context, o *domain.WorkOrder) error {
+	if o.AssigneeID == nil {
+		return nil
+	}
+	if s.assignees == nil {
+		return errNotConfigured
+	}
+	t, err := s.assignees.FindByID(ctx, *o.AssigneeID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidInput
+	}
+	if err != nil {
+		return err
+	}
+	if !t.Active || (o.SiteID != 0 && t.SiteID != o.SiteID) {
+		return domain.ErrInvalidInput
+	}
+	return nil
+}
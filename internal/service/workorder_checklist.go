@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// applyJobPlan carrega o roteiro informado na OS e copia peças, especialidade e
+// estimativa (se a OS não trouxer as suas). Sem job_plan_id não faz nada.
+func (s *WorkOrderService) applyJobPlan(ctx context.Context, o *domain.WorkOrder) (*domain.JobPlan, error) {
+	if o.JobPlanID == nil {
+		return nil, nil
+	}
+	if s.jobPlans == nil || s.checklists == nil {
+		return nil, errNotConfigured
+	}
+	jp, err := s.jobPlans.FindByID(ctx, *o.JobPlanID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain.ErrInvalidInput
+	}
+	if err != nil {
+		return nil, err
+	}
+	o.RequiredParts = append([]domain.JobPlanPart{}, jp.Parts...)
+	if o.Trade == "" {
+		o.Trade = jp.Trade
+	}
+	if o.EstimatedMinutes == nil && jp.EstimatedMinutes != nil {
+		est := *jp.EstimatedMinutes
+		o.EstimatedMinutes = &est
+	}
+	return jp, nil
+}
+
+// checkChecklist impede concluir a OS com passos pendentes (ErrPrecondition).
+func (s *WorkOrderService) checkChecklist(ctx context.Context, workOrderID int64) error {
+	if s.checklists == nil {
+		return nil
+	}
+	items, err := s.checklists.FindByWorkOrder(ctx, workOrderID)
+	if err != nil {
+		return err
+	}
+	if !domain.ChecklistComplete(items) {
+		return domain.ErrPrecondition
+	}
+	return nil
+}
+
+// Checklist retorna os passos da OS (lista vazia se ela não tiver roteiro).
+func (s *WorkOrderService) Checklist(ctx context.Context, id int64) ([]domain.ChecklistItem, error) {
+	ctx, span := tracer.Start(ctx, "WorkOrderService.Checklist")
+	defer span.End()
+
+	if s.checklists == nil {
+		return nil, errNotConfigured
+	}
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.checklists.FindByWorkOrder(ctx, id)
+}
+
+// ChecklistUpdate é o apontamento de um passo; Value é obrigatório para concluir passos com medição.
+type ChecklistUpdate struct {
+	Done  bool
+	Value *float64
+	Note  string
+}
+
+// UpdateChecklistStep registra o passo de uma OS em aberto (ErrConflict se já fechada).
+// Medição fora da tolerância em passo com follow_up abre uma corretiva para o mesmo ativo, uma única vez:
+// a corretiva e o apontamento são gravados na mesma transação, e o apontamento
+// concorrente que também a abriria falha com ErrConflict.
+func (s *WorkOrderService) UpdateChecklistStep(ctx context.Context, id int64, step int, upd ChecklistUpdate) (*domain.ChecklistItem, error) {
+	ctx, span := tracer.Start(ctx, "WorkOrderService.UpdateChecklistStep")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("work_order.id", id), attribute.Int("checklist.step", step))
+
+	if s.checklists == nil {
+		return nil, errNotConfigured
+	}
+	var (
+		item     *domain.ChecklistItem
+		followUp *domain.WorkOrder
+	)
+	err := s.inTx(ctx, func(ctx context.Context) error {
+		var err error
+		item, followUp, err = s.recordStep(ctx, id, step, upd)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if followUp != nil {
+		log.WithFields(log.Fields{
+			"work_order_id": id,
+			"step":          item.Step,
+			"follow_up_id":  followUp.ID,
+		}).Info("out of tolerance measurement, follow-up work order created")
+		s.notifyCreated(ctx, followUp)
+	}
+	return item, nil
+}
+
+// recordStep grava o apontamento e, se a medição pedir, abre a corretiva (devolvida
+// para o alerta sair só depois da gravação).
+func (s *WorkOrderService) recordStep(ctx context.Context, id int64, step int, upd ChecklistUpdate) (*domain.ChecklistItem, *domain.WorkOrder, error) {
+	o, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !o.IsOpen() {
+		return nil, nil, domain.ErrConflict
+	}
+	items, err := s.checklists.FindByWorkOrder(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	var item *domain.ChecklistItem
+	for i := range items {
+		if items[i].Step == step {
+			item = &items[i]
+		}
+	}
+	if item == nil {
+		return nil, nil, domain.ErrNotFound
+	}
+
+	if err := item.Record(upd.Done, upd.Value, upd.Note, s.now()); err != nil {
+		return nil, nil, err
+	}
+	var followUp *domain.WorkOrder
+	if item.OutOfTolerance && item.Measurement.FollowUp && item.FollowUpID == nil {
+		followUp = &domain.WorkOrder{
+			AssetID:     o.AssetID,
+			Type:        domain.WOTypeCorrective,
+			Title:       fmt.Sprintf("Fora de tolerância na OS #%d: %s", o.ID, item.Description),
+			Description: fmt.Sprintf("Passo %d: medido %s, faixa %s.", item.Step, formatMeasure(*item.Value, item.Measurement.Unit), toleranceLabel(item.Measurement)),
+			Trade:       o.Trade,
+		}
+		if err := s.create(ctx, followUp); err != nil {
+			return nil, nil, err
+		}
+		item.FollowUpID = &followUp.ID
+	}
+	if err := s.checklists.UpdateItem(ctx, id, item); err != nil {
+		return nil, nil, err
+	}
+	return item, followUp, nil
+}
+
+func formatMeasure(v float64, unit string) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if unit != "" {
+		s += " " + unit
+	}
+	return s
+}
+
+func toleranceLabel(m *domain.MeasurementSpec) string {
+	switch {
+	case m.Min != nil && m.Max != nil:
+		return formatMeasure(*m.Min, "") + " a " + formatMeasure(*m.Max, m.Unit)
+	case m.Min != nil:
+		return "mínimo " + formatMeasure(*m.Min, m.Unit)
+	case m.Max != nil:
+		return "máximo " + formatMeasure(*m.Max, m.Unit)
+	}
+	return "livre"
+}
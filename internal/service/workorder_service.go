@@ -1,26 +1,451 @@
 package service
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/repository"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// errNotConfigured indica uma dependência opcional do serviço que não foi injetada.
+var errNotConfigured = errors.New("dependency not configured")
+
 type WorkOrderService struct {
-	repo repository.WorkOrderRepository
+	repo       repository.WorkOrderRepository
+	assets     repository.AssetRepository
+	codes      repository.FailureCodeRepository
+	sla        repository.SLARepository
+	notifier   Notifier
+	calendar   CalendarSource
+	plans      repository.MaintenancePlanRepository
+	jobPlans   repository.JobPlanRepository
+	checklists repository.ChecklistRepository
+	assignees  repository.TechnicianRepository
+	feed       repository.SyncRepository
+	feedEvery  time.Duration
+	tx         repository.Transactor
+	now        func() time.Time
+}
+
+// WorkOrderOption injeta dependências usadas só por parte das operações
+// (fechamento com códigos de falha, regras por criticidade do ativo).
+type WorkOrderOption func(*WorkOrderService)
+
+// WithAssets habilita as regras que dependem do ativo (ex: criticidade no fechamento).
+func WithAssets(r repository.AssetRepository) WorkOrderOption {
+	return func(s *WorkOrderService) { s.assets = r }
+}
+
+// WithFailureCodes habilita a validação dos códigos de falha no fechamento.
+func WithFailureCodes(r repository.FailureCodeRepository) WorkOrderOption {
+	return func(s *WorkOrderService) { s.codes = r }
+}
+
+// WithSLA calcula prioridade e prazos na abertura a partir da matriz de SLA
+// (exige WithAssets para saber a criticidade do ativo).
+func WithSLA(r repository.SLARepository) WorkOrderOption {
+	return func(s *WorkOrderService) { s.sla = r }
+}
+
+// WithNotifier dispara o alerta de quebra em ativo crítico na abertura e encerra
+// os alertas da OS no fechamento (exige WithAssets).
+func WithNotifier(n Notifier) WorkOrderOption {
+	return func(s *WorkOrderService) { s.notifier = n }
+}
+
+// WithCalendar conta os prazos de SLA em tempo útil do calendário da planta
+// (fora de fins de semana, feriados e paradas programadas).
+func WithCalendar(c CalendarSource) WorkOrderOption {
+	return func(s *WorkOrderService) { s.calendar = c }
+}
+
+// WithPlans registra a execução do plano de preventiva quando a OS gerada por ele é concluída.
+func WithPlans(r repository.MaintenancePlanRepository) WorkOrderOption {
+	return func(s *WorkOrderService) { s.plans = r }
+}
+
+// WithChecklists copia o roteiro (job_plan_id) para o checklist da OS na abertura
+// e exige o checklist completo para concluir a OS.
+func WithChecklists(jobPlans repository.JobPlanRepository, checklists repository.ChecklistRepository) WorkOrderOption {
+	return func(s *WorkOrderService) { s.jobPlans, s.checklists = jobPlans, checklists }
+}
+
+// WithAssignees habilita a atribuição de OS a técnicos (AssigneeID e Assign).
+func WithAssignees(r repository.TechnicianRepository) WorkOrderOption {
+	return func(s *WorkOrderService) { s.assignees = r }
+}
+
+// WithChangeFeed habilita o Watch, que consulta as revisões da sincronização a cada intervalo.
+func WithChangeFeed(r repository.SyncRepository, every time.Duration) WorkOrderOption {
+	return func(s *WorkOrderService) { s.feed, s.feedEvery = r, every }
+}
+
+// WithTransactions grava a OS e o checklist copiado do roteiro juntos, e o
+// apontamento de passo junto com a corretiva que ele abre.
+func WithTransactions(tx repository.Transactor) WorkOrderOption {
+	return func(s *WorkOrderService) { s.tx = tx }
+}
+
+func NewWorkOrderService(r repository.WorkOrderRepository, opts ...WorkOrderOption) *WorkOrderService {
+	s := &WorkOrderService{repo: r, now: time.Now}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func NewWorkOrderService(r repository.WorkOrderRepository) *WorkOrderService {
-	return &WorkOrderService{repo: r}
+func (s *WorkOrderService) Create(ctx context.Context, order *domain.WorkOrder) error {
+	ctx, span := tracer.Start(ctx, "WorkOrderService.Create")
+	defer span.End()
+
+	if err := s.create(ctx, order); err != nil {
+		return err
+	}
+	s.notifyCreated(ctx, order)
+	return nil
 }
 
-func (s *WorkOrderService) Create(order *domain.WorkOrder) error {
+// inTx executa fn na transação de WithTransactions (sem ela, direto).
+func (s *WorkOrderService) inTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	return s.tx.InTx(ctx, fn)
+}
+
+// create valida e grava a OS com o checklist do roteiro, sem disparar alertas.
+func (s *WorkOrderService) create(ctx context.Context, order *domain.WorkOrder) error {
 	order.Normalize()
-	return s.repo.Create(order)
+	if err := s.assignSite(ctx, order); err != nil {
+		return err
+	}
+	if err := s.checkAssignee(ctx, order); err != nil {
+		return err
+	}
+	now := s.now()
+	jobPlan, err := s.applyJobPlan(ctx, order)
+	if err != nil {
+		return err
+	}
+	if err := s.applySLA(ctx, order, now); err != nil {
+		return err
+	}
+	if order.Status != domain.WOStatusOpen {
+		order.RespondedAt = &now
+	}
+	if order.Status == domain.WOStatusDone || order.Status == domain.WOStatusCanceled {
+		if err := s.checkClosure(ctx, order, order.Status); err != nil {
+			return err
+		}
+		order.ClosedAt = &now
+	}
+	return s.inTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Create(ctx, order); err != nil {
+			return err
+		}
+		if jobPlan == nil {
+			return nil
+		}
+		return s.checklists.Create(ctx, order.ID, jobPlan.Checklist())
+	})
+}
+
+// assignSite coloca a OS no site do ativo. Sem repositório de ativos, o
+// repositório de OS usa o site do contexto.
+func (s *WorkOrderService) assignSite(ctx context.Context, order *domain.WorkOrder) error {
+	if s.assets == nil {
+		return nil
+	}
+	asset, err := s.assets.FindByID(ctx, order.AssetID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidInput
+	}
+	if err != nil {
+		return err
+	}
+	order.SiteID = asset.SiteID
+	return nil
 }
 
-func (s *WorkOrderService) List(status string) ([]domain.WorkOrder, error) {
-	if status == "" {
-		return s.repo.FindAll()
+// notifyCreated abre o alerta de corretiva em ativo criticidade A, contando o
+// escalonamento a partir da quebra; o envio sai pelo escalonamento, fora da
+// requisição. Falhas de notificação não desfazem a abertura.
+func (s *WorkOrderService) notifyCreated(ctx context.Context, o *domain.WorkOrder) {
+	if s.notifier == nil || s.assets == nil || o.Type != domain.WOTypeCorrective || !o.IsOpen() {
+		return
+	}
+	asset, err := s.assets.FindByID(ctx, o.AssetID)
+	if err != nil {
+		log.WithError(err).WithField("work_order_id", o.ID).Error("critical breakdown alert: load asset")
+		return
+	}
+	if asset.Criticality != domain.CriticalityA {
+		return
+	}
+	alert := &domain.Alert{
+		SiteID:  o.SiteID,
+		Event:   domain.EventCriticalBreakdown,
+		RefType: domain.AlertRefWorkOrder,
+		RefID:   o.ID,
+		Message: fmt.Sprintf("OS #%d corretiva no ativo crítico %s (%s): %s", o.ID, asset.Name, asset.Location, o.Title),
+	}
+	if o.BreakdownAt != nil {
+		alert.TriggeredAt = *o.BreakdownAt
+	}
+	if err := s.notifier.Raise(ctx, alert); err != nil {
+		log.WithError(err).WithField("work_order_id", o.ID).Error("critical breakdown alert failed")
+	}
+}
+
+// applySLA define prioridade e prazos pela célula criticidade × tipo.
+// Sem matriz configurada, ou sem a célula, a OS fica sem SLA.
+func (s *WorkOrderService) applySLA(ctx context.Context, order *domain.WorkOrder, openedAt time.Time) error {
+	if s.sla == nil {
+		return nil
+	}
+	if s.assets == nil {
+		return errNotConfigured
+	}
+	asset, err := s.assets.FindByID(ctx, order.AssetID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return domain.ErrInvalidInput
+	}
+	if err != nil {
+		return err
+	}
+	policy, err := s.sla.Find(ctx, asset.Criticality, order.Type)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var cal *domain.Calendar
+	if s.calendar != nil {
+		if cal, err = siteCalendar(ctx, s.calendar, asset.SiteID); err != nil {
+			return err
+		}
+	}
+	policy.Apply(order, openedAt, cal)
+	return nil
+}
+
+// TransitionRequest muda o status da OS; códigos de falha, causa e solução
+// só são aceitos no fechamento (done).
+type TransitionRequest struct {
+	Status          domain.WorkOrderStatus
+	FailureModeID   *int64
+	FailureCauseID  *int64
+	FailureActionID *int64
+	Cause           string
+	Solution        string
+}
+
+// Transition aplica a mudança de status. Transições inválidas retornam ErrConflict;
+// OS corretiva de ativo criticidade A sem modo de falha não fecha (ErrPrecondition).
+func (s *WorkOrderService) Transition(ctx context.Context, id int64, req TransitionRequest) (*domain.WorkOrder, error) {
+	ctx, span := tracer.Start(ctx, "WorkOrderService.Transition")
+	defer span.End()
+	span.SetAttributes(attribute.Int64("work_order.id", id), attribute.String("work_order.status", string(req.Status)))
+
+	o, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !o.CanTransition(req.Status) {
+		return nil, domain.ErrConflict
+	}
+
+	closing := req.Status == domain.WOStatusDone
+	hasCodes := req.FailureModeID != nil || req.FailureCauseID != nil || req.FailureActionID != nil
+	if !closing && (hasCodes || req.Cause != "" || req.Solution != "") {
+		return nil, domain.ErrInvalidInput
+	}
+
+	o.Status = req.Status
+	if closing {
+		o.FailureModeID, o.FailureCauseID, o.FailureActionID = req.FailureModeID, req.FailureCauseID, req.FailureActionID
+		if req.Cause != "" {
+			o.Cause = req.Cause
+		}
+		if req.Solution != "" {
+			o.Solution = req.Solution
+		}
+	}
+	if err := s.checkClosure(ctx, o, req.Status); err != nil {
+		return nil, err
+	}
+	if closing {
+		if err := s.checkChecklist(ctx, o.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	if o.RespondedAt == nil && (req.Status == domain.WOStatusInProgress || req.Status == domain.WOStatusDone) {
+		o.RespondedAt = &now // início do atendimento (prazo de resposta do SLA)
+	}
+	switch req.Status {
+	case domain.WOStatusDone, domain.WOStatusCanceled:
+		o.ClosedAt = &now
+	default:
+		o.ClosedAt = nil
+	}
+	if err := s.repo.Update(ctx, o); err != nil {
+		return nil, err
+	}
+	if req.Status == domain.WOStatusDone && o.PlanID != nil && s.plans != nil {
+		if err := s.plans.SetLastExecution(ctx, *o.PlanID, now); err != nil {
+			log.WithError(err).WithField("plan_id", *o.PlanID).Error("register plan execution")
+		}
+	}
+	if o.ClosedAt != nil && s.notifier != nil {
+		if err := s.notifier.Resolve(ctx, domain.AlertRefWorkOrder, o.ID); err != nil {
+			log.WithError(err).WithField("work_order_id", o.ID).Error("resolve work order alerts")
+		}
+	}
+	return o, nil
+}
+
+// checkClosure valida os códigos de falha informados e exige o modo de falha
+// para corretivas de ativos criticidade A concluídas.
+func (s *WorkOrderService) checkClosure(ctx context.Context, o *domain.WorkOrder, status domain.WorkOrderStatus) error {
+	if err := s.checkFailureCodes(ctx, o); err != nil {
+		return err
+	}
+	if status != domain.WOStatusDone || o.Type != domain.WOTypeCorrective || o.FailureModeID != nil {
+		return nil
+	}
+	if s.assets == nil {
+		return errNotConfigured
+	}
+	asset, err := s.assets.FindByID(ctx, o.AssetID)
+	if err != nil {
+		return err
+	}
+	if asset.Criticality == domain.CriticalityA {
+		return domain.ErrPrecondition
+	}
+	return nil
+}
+
+// checkFailureCodes garante a cadeia modo → causa → ação do catálogo.
+// Códigos inativos não podem ser usados em novos fechamentos.
+func (s *WorkOrderService) checkFailureCodes(ctx context.Context, o *domain.WorkOrder) error {
+	chain := []struct {
+		id    *int64
+		level domain.FailureCodeLevel
+	}{
+		{o.FailureModeID, domain.FailureLevelMode},
+		{o.FailureCauseID, domain.FailureLevelCause},
+		{o.FailureActionID, domain.FailureLevelAction},
+	}
+
+	var parent *int64
+	for i, link := range chain {
+		if link.id == nil {
+			// Nenhum nível abaixo pode vir sem o de cima.
+			for _, rest := range chain[i+1:] {
+				if rest.id != nil {
+					return domain.ErrInvalidInput
+				}
+			}
+			return nil
+		}
+		if s.codes == nil {
+			return errNotConfigured
+		}
+		fc, err := s.codes.FindByID(ctx, *link.id)
+		if errors.Is(err, domain.ErrNotFound) {
+			return domain.ErrInvalidInput
+		}
+		if err != nil {
+			return err
+		}
+		if fc.Level != link.level || !fc.Active {
+			return domain.ErrInvalidInput
+		}
+		if parent != nil && (fc.ParentID == nil || *fc.ParentID != *parent) {
+			return domain.ErrInvalidInput
+		}
+		parent = link.id
+	}
+	return nil
+}
+
+func (s *WorkOrderService) Get(ctx context.Context, id int64) (*domain.WorkOrder, error) {
+	ctx, span := tracer.Start(ctx, "WorkOrderService.Get")
+	defer span.End()
+
+	return s.repo.FindByID(ctx, id)
+}
+
+func (s *WorkOrderService) List(ctx context.Context, filter domain.WorkOrderFilter) ([]domain.WorkOrder, error) {
+	ctx, span := tracer.Start(ctx, "WorkOrderService.List")
+	defer span.End()
+	span.SetAttributes(attribute.String("work_order.status", string(filter.Status)))
+
+	var list []domain.WorkOrder
+	err := s.repo.Stream(ctx, filter, func(o *domain.WorkOrder) error {
+		list = append(list, *o)
+		return nil
+	})
+	return list, err
+}
+
+// Stream entrega as OS uma a uma (exportações grandes sem acumular em memória).
+func (s *WorkOrderService) Stream(ctx context.Context, filter domain.WorkOrderFilter, fn func(*domain.WorkOrder) error) error {
+	ctx, span := tracer.Start(ctx, "WorkOrderService.Stream")
+	defer span.End()
+
+	return s.repo.Stream(ctx, filter, fn)
+}
+
+// watchBatch é quantas OS alteradas o Watch lê por consulta.
+const watchBatch = 500
+
+// Watch entrega as OS em aberto e, depois, cada OS alterada (inclusive as
+// fechadas) em ordem de revisão, até ctx terminar ou fn falhar. since retoma
+// depois da última revisão recebida; filter vale para cada OS entregue.
+func (s *WorkOrderService) Watch(ctx context.Context, since int64, filter domain.WorkOrderFilter, fn func(*domain.WorkOrder) error) error {
+	ctx, span := tracer.Start(ctx, "WorkOrderService.Watch")
+	defer span.End()
+
+	if s.feed == nil {
+		return errNotConfigured
+	}
+	if since < 0 {
+		return domain.ErrInvalidInput
+	}
+	ticker := time.NewTicker(s.feedEvery)
+	defer ticker.Stop()
+	for {
+		list, err := s.feed.WorkOrderChanges(ctx, since, watchBatch)
+		if err != nil {
+			return err
+		}
+		for i := range list {
+			o := &list[i]
+			since = o.Revision
+			if !filter.Match(o) {
+				continue
+			}
+			if err := fn(o); err != nil {
+				return err
+			}
+		}
+		if len(list) == watchBatch {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
 	}
-	return s.repo.FindByStatus(domain.WorkOrderStatus(status))
 }
@@ -1,7 +1,9 @@
 package service_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
 	"github.com/maxwellsouza/go-factory-maintenance/internal/repository/memory"
@@ -34,7 +36,7 @@ func TestWorkOrderService_CreateAndListByStatus(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			o := tc.input
-			if err := svc.Create(&o); err != nil {
+			if err := svc.Create(onSite(1), &o); err != nil {
 				t.Fatalf("Create() error = %v", err)
 			}
 			if o.ID == 0 {
@@ -44,7 +46,7 @@ func TestWorkOrderService_CreateAndListByStatus(t *testing.T) {
 	}
 
 	// List sem filtro → todos
-	all, err := svc.List("")
+	all, err := svc.List(onSite(1), domain.WorkOrderFilter{})
 	if err != nil {
 		t.Fatalf("List(\"\") error = %v", err)
 	}
@@ -53,7 +55,7 @@ func TestWorkOrderService_CreateAndListByStatus(t *testing.T) {
 	}
 
 	// Filtro por status open
-	open, err := svc.List(string(domain.WOStatusOpen))
+	open, err := svc.List(onSite(1), domain.WorkOrderFilter{Status: domain.WOStatusOpen})
 	if err != nil {
 		t.Fatalf("List(open) error = %v", err)
 	}
@@ -69,3 +71,156 @@ func TestWorkOrderService_CreateAndListByStatus(t *testing.T) {
 		t.Fatalf("expected default status open, got %s", all[0].Status)
 	}
 }
+
+func TestWorkOrderService_ListPerAssetByCreationDate(t *testing.T) {
+	repo := memory.NewWorkOrderMemoryRepo()
+	svc := service.NewWorkOrderService(repo)
+	ctx := onSite(1)
+
+	// Histórico importado depois da OS nova: id maior, criação mais antiga.
+	if err := svc.Create(ctx, &domain.WorkOrder{AssetID: 1, Title: "Atual"}); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	old := time.Now().AddDate(-1, 0, 0)
+	history := []domain.WorkOrder{
+		{AssetID: 1, Type: domain.WOTypeCorrective, Status: domain.WOStatusDone, Title: "Histórico 1", CreatedAt: old},
+		{AssetID: 1, Type: domain.WOTypeCorrective, Status: domain.WOStatusDone, Title: "Histórico 2", CreatedAt: old.Add(time.Hour)},
+	}
+	if _, err := repo.CreateBatch(ctx, history); err != nil {
+		t.Fatalf("CreateBatch() error = %v", err)
+	}
+
+	list, err := svc.List(ctx, domain.WorkOrderFilter{AssetIDs: []int64{1}, PerAsset: 2})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	var titles []string
+	for _, o := range list {
+		titles = append(titles, o.Title)
+	}
+	if len(titles) != 2 || titles[0] != "Atual" || titles[1] != "Histórico 2" {
+		t.Fatalf("List(PerAsset: 2) = %v, want the two most recently created", titles)
+	}
+}
+
+func TestWorkOrderService_TransitionRequiresFailureCodeOnCriticalAssets(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	codeRepo := memory.NewFailureCodeMemoryRepo()
+	codes := service.NewFailureCodeService(codeRepo)
+	svc := service.NewWorkOrderService(orders, service.WithAssets(assets), service.WithFailureCodes(codeRepo))
+
+	critical := domain.Asset{Name: "Caldeira", Criticality: domain.CriticalityA}
+	if err := assets.Create(ctx, &critical); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+	mode := domain.FailureCode{Code: "vaz", Name: "Vazamento", Level: domain.FailureLevelMode}
+	otherMode := domain.FailureCode{Code: "RUI", Name: "Ruído", Level: domain.FailureLevelMode}
+	for _, fc := range []*domain.FailureCode{&mode, &otherMode} {
+		if err := codes.Create(ctx, fc); err != nil {
+			t.Fatalf("create mode: %v", err)
+		}
+	}
+	cause := domain.FailureCode{Code: "VAZ-VED", Name: "Vedação desgastada", Level: domain.FailureLevelCause, ParentID: &mode.ID}
+	if err := codes.Create(ctx, &cause); err != nil {
+		t.Fatalf("create cause: %v", err)
+	}
+	if mode.Code != "VAZ" {
+		t.Fatalf("expected code normalized to upper case, got %q", mode.Code)
+	}
+	// Ação pendurada direto no modo quebra a hierarquia.
+	if err := codes.Create(ctx, &domain.FailureCode{Code: "X", Name: "X", Level: domain.FailureLevelAction, ParentID: &mode.ID}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("expected ErrInvalidInput for action under mode, got %v", err)
+	}
+
+	wo := domain.WorkOrder{AssetID: critical.ID, Title: "Vazamento na linha de vapor"}
+	if err := svc.Create(ctx, &wo); err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	if _, err := svc.Transition(ctx, wo.ID, service.TransitionRequest{Status: domain.WOStatusDone}); !errors.Is(err, domain.ErrPrecondition) {
+		t.Fatalf("done without failure mode: expected ErrPrecondition, got %v", err)
+	}
+	if _, err := svc.Transition(ctx, wo.ID, service.TransitionRequest{Status: domain.WOStatusDone, FailureModeID: &otherMode.ID, FailureCauseID: &cause.ID}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("cause from another mode: expected ErrInvalidInput, got %v", err)
+	}
+
+	done, err := svc.Transition(ctx, wo.ID, service.TransitionRequest{Status: domain.WOStatusDone, FailureModeID: &mode.ID, FailureCauseID: &cause.ID, Solution: "Troca da vedação"})
+	if err != nil {
+		t.Fatalf("Transition(done) error = %v", err)
+	}
+	if done.ClosedAt == nil || done.FailureCauseID == nil || *done.FailureCauseID != cause.ID {
+		t.Fatalf("unexpected closed work order: %+v", done)
+	}
+	if _, err := svc.Transition(ctx, wo.ID, service.TransitionRequest{Status: domain.WOStatusOpen}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("reopen done order: expected ErrConflict, got %v", err)
+	}
+}
+
+func TestWorkOrderService_SLADeadlinesAndBreachDetection(t *testing.T) {
+	ctx := onSite(1)
+	assets := memory.NewAssetMemoryRepo()
+	orders := memory.NewWorkOrderMemoryRepo()
+	svc := service.NewWorkOrderService(orders, service.WithAssets(assets), service.WithSLA(memory.NewSLAMemoryRepo()))
+
+	critical := domain.Asset{Name: "Compressor", Criticality: domain.CriticalityA}
+	minor := domain.Asset{Name: "Ventilador", Criticality: domain.CriticalityC}
+	for _, a := range []*domain.Asset{&critical, &minor} {
+		if err := assets.Create(ctx, a); err != nil {
+			t.Fatalf("create asset: %v", err)
+		}
+	}
+
+	breakdown := time.Now().Add(-5 * time.Hour)
+	late := domain.WorkOrder{AssetID: critical.ID, Title: "Compressor parado", BreakdownAt: &breakdown}
+	onTime := domain.WorkOrder{AssetID: minor.ID, Title: "Ruído no ventilador"}
+	for _, wo := range []*domain.WorkOrder{&late, &onTime} {
+		if err := svc.Create(ctx, wo); err != nil {
+			t.Fatalf("create work order: %v", err)
+		}
+	}
+
+	if late.Priority != domain.PriorityUrgent {
+		t.Fatalf("expected urgent priority for corrective on A asset, got %q", late.Priority)
+	}
+	if late.ResponseDueAt == nil || !late.ResponseDueAt.Equal(breakdown.Add(30*time.Minute)) {
+		t.Fatalf("response due should count from breakdown, got %v", late.ResponseDueAt)
+	}
+	if late.ResolutionDueAt == nil || !late.ResolutionDueAt.Equal(breakdown.Add(4*time.Hour)) {
+		t.Fatalf("unexpected resolution due: %v", late.ResolutionDueAt)
+	}
+	if onTime.Priority != domain.PriorityNormal {
+		t.Fatalf("expected normal priority for corrective on C asset, got %q", onTime.Priority)
+	}
+
+	now := time.Now()
+	overdue, err := svc.List(ctx, domain.WorkOrderFilter{OverdueAt: &now})
+	if err != nil {
+		t.Fatalf("List(overdue) error = %v", err)
+	}
+	if len(overdue) != 1 || overdue[0].ID != late.ID {
+		t.Fatalf("expected only the late order as overdue, got %+v", overdue)
+	}
+
+	monitor := service.NewSLAMonitor(orders, time.Minute)
+	breached, err := monitor.Check(ctx)
+	if err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+	if len(breached) != 1 || breached[0].ID != late.ID || breached[0].SLABreachedAt == nil {
+		t.Fatalf("expected late order marked as breached, got %+v", breached)
+	}
+	// A marcação é feita uma vez só; as próximas varreduras ignoram a OS.
+	if again, err := monitor.Check(ctx); err != nil || len(again) != 0 {
+		t.Fatalf("second Check() = %+v, %v; want nothing new", again, err)
+	}
+
+	started, err := svc.Transition(ctx, onTime.ID, service.TransitionRequest{Status: domain.WOStatusInProgress})
+	if err != nil {
+		t.Fatalf("Transition(in_progress) error = %v", err)
+	}
+	if started.RespondedAt == nil {
+		t.Fatalf("expected RespondedAt set when work starts")
+	}
+}
@@ -0,0 +1,122 @@
+// Package tenant carrega no contexto quem está chamando (o principal autenticado)
+// e o site (planta) cujos dados a chamada pode ver. Os repositórios filtram toda
+// leitura e escrita por Site(ctx); contexto sem escopo é recusado.
+package tenant
+
+import (
+	"context"
+
+	"github.com/maxwellsouza/go-factory-maintenance/internal/domain"
+)
+
+// Principal é o usuário autenticado. Usuários corporativos podem consultar
+// relatórios de todos os sites; os demais só enxergam o próprio site.
+type Principal struct {
+	UserID    int64
+	SiteID    int64
+	Corporate bool
+}
+
+type principalKey struct{}
+
+// scope é o site visível na chamada; 0 = todos os sites.
+type scope struct{ site int64 }
+
+type scopeKey struct{}
+
+// WithPrincipal registra o principal e restringe a chamada ao site dele.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	ctx = context.WithValue(ctx, principalKey{}, p)
+	return context.WithValue(ctx, scopeKey{}, scope{site: p.SiteID})
+}
+
+func PrincipalFrom(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// System libera todos os sites para os processos internos (agendador de
+// preventivas, monitor de SLA, escalonamento, métricas), que não têm principal.
+func System(ctx context.Context) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope{})
+}
+
+// AllSites amplia a chamada para todos os sites; só usuários corporativos (ou o
+// sistema) podem, os demais recebem ErrForbidden.
+func AllSites(ctx context.Context) (context.Context, error) {
+	if !crossSite(ctx) {
+		return nil, domain.ErrForbidden
+	}
+	return context.WithValue(ctx, scopeKey{}, scope{}), nil
+}
+
+// OnSite restringe a chamada a site. Fora do próprio site, só usuários
+// corporativos (ou o sistema).
+func OnSite(ctx context.Context, site int64) (context.Context, error) {
+	if site <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+	if p, ok := PrincipalFrom(ctx); ok && p.SiteID == site {
+		return context.WithValue(ctx, scopeKey{}, scope{site: site}), nil
+	}
+	if !crossSite(ctx) {
+		return nil, domain.ErrForbidden
+	}
+	return context.WithValue(ctx, scopeKey{}, scope{site: site}), nil
+}
+
+func crossSite(ctx context.Context) bool {
+	if p, ok := PrincipalFrom(ctx); ok {
+		return p.Corporate
+	}
+	_, system := ctx.Value(scopeKey{}).(scope)
+	return system
+}
+
+// Site devolve o site visível na chamada (0 = todos). Sem principal nem System,
+// a chamada não tem escopo e recebe ErrUnauthorized.
+func Site(ctx context.Context) (int64, error) {
+	s, ok := ctx.Value(scopeKey{}).(scope)
+	if !ok {
+		return 0, domain.ErrUnauthorized
+	}
+	return s.site, nil
+}
+
+// SingleSite devolve o site da chamada quando ela está restrita a um só site;
+// em escopo de todos os sites devolve ErrInvalidInput.
+func SingleSite(ctx context.Context) (int64, error) {
+	site, err := Site(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if site == 0 {
+		return 0, domain.ErrInvalidInput
+	}
+	return site, nil
+}
+
+// Visible diz se um registro do site owner pode ser visto no escopo site.
+func Visible(site, owner int64) bool {
+	return site == 0 || site == owner
+}
+
+// Assign define o site de um registro novo: o do escopo, quando restrito a um
+// site (informar outro é ErrForbidden); em escopo de todos os sites, o site
+// precisa vir informado.
+func Assign(ctx context.Context, owner *int64) error {
+	site, err := Site(ctx)
+	if err != nil {
+		return err
+	}
+	switch {
+	case site == 0 && *owner == 0:
+		return domain.ErrInvalidInput
+	case site == 0:
+		return nil
+	case *owner != 0 && *owner != site:
+		return domain.ErrForbidden
+	}
+	*owner = site
+	return nil
+}
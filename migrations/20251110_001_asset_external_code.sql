@@ -0,0 +1,12 @@
+-- +goose Up
+-- Código legado do ativo, usado para resolver referências em importações de planilhas.
+
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS external_code TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_assets_external_code ON assets (external_code);
+CREATE INDEX IF NOT EXISTS idx_assets_lower_name ON assets (LOWER(name));
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_assets_lower_name;
+DROP INDEX IF EXISTS idx_assets_external_code;
+ALTER TABLE assets DROP COLUMN IF EXISTS external_code;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Paradas de ativos independentes das OS (várias por OS, parciais ou sem OS).
+
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE IF NOT EXISTS downtime_events (
+    id              BIGSERIAL PRIMARY KEY,
+    asset_id        BIGINT NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    work_order_id   BIGINT REFERENCES work_orders(id) ON DELETE SET NULL,
+    started_at      TIMESTAMPTZ NOT NULL,
+    ended_at        TIMESTAMPTZ,
+    reason_code     TEXT NOT NULL,
+    planned         BOOLEAN NOT NULL DEFAULT FALSE,
+    notes           TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ck_downtime_end_after_start CHECK (ended_at IS NULL OR ended_at > started_at),
+    -- Um ativo não pode ter duas paradas sobrepostas (aberta = até o infinito).
+    CONSTRAINT ex_downtime_no_overlap EXCLUDE USING gist (
+        asset_id WITH =,
+        tstzrange(started_at, COALESCE(ended_at, 'infinity'::timestamptz)) WITH &&
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_downtime_events_work_order ON downtime_events (work_order_id);
+CREATE INDEX IF NOT EXISTS idx_downtime_events_started_at ON downtime_events (started_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS downtime_events;
@@ -0,0 +1,35 @@
+-- +goose Up
+-- Calendário de turnos, cadência ideal e apontamentos de produção para o OEE.
+
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS ideal_rate_per_hour NUMERIC(12,3)
+    CHECK (ideal_rate_per_hour IS NULL OR ideal_rate_per_hour > 0);
+
+CREATE TABLE IF NOT EXISTS shifts (
+    id              BIGSERIAL PRIMARY KEY,
+    name            TEXT NOT NULL,
+    location        TEXT,
+    start_time      TIME NOT NULL,
+    end_time        TIME NOT NULL,
+    weekdays        SMALLINT[] NOT NULL,
+    break_minutes   INTEGER NOT NULL DEFAULT 0 CHECK (break_minutes >= 0),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ck_shifts_weekdays CHECK (weekdays <@ ARRAY[0,1,2,3,4,5,6]::smallint[])
+);
+
+CREATE TABLE IF NOT EXISTS production_counts (
+    id              BIGSERIAL PRIMARY KEY,
+    asset_id        BIGINT NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    period_start    TIMESTAMPTZ NOT NULL,
+    period_end      TIMESTAMPTZ NOT NULL,
+    total_count     BIGINT NOT NULL CHECK (total_count >= 0),
+    good_count      BIGINT NOT NULL CHECK (good_count >= 0 AND good_count <= total_count),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ck_production_period CHECK (period_end > period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_production_counts_asset_period ON production_counts (asset_id, period_start);
+
+-- +goose Down
+DROP TABLE IF EXISTS production_counts;
+DROP TABLE IF EXISTS shifts;
+ALTER TABLE assets DROP COLUMN IF EXISTS ideal_rate_per_hour;
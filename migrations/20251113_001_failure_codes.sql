@@ -0,0 +1,31 @@
+-- +goose Up
+-- Catálogo hierárquico de falhas (modo → causa → ação) e códigos no fechamento da OS.
+
+CREATE TABLE IF NOT EXISTS failure_codes (
+    id          BIGSERIAL PRIMARY KEY,
+    code        TEXT NOT NULL UNIQUE,
+    name        TEXT NOT NULL,
+    level       TEXT NOT NULL CHECK (level IN ('mode','cause','action')),
+    parent_id   BIGINT REFERENCES failure_codes(id),
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ck_failure_codes_parent CHECK ((level = 'mode') = (parent_id IS NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_failure_codes_parent ON failure_codes (parent_id);
+
+ALTER TABLE work_orders
+    ADD COLUMN IF NOT EXISTS failure_mode_id   BIGINT REFERENCES failure_codes(id),
+    ADD COLUMN IF NOT EXISTS failure_cause_id  BIGINT REFERENCES failure_codes(id),
+    ADD COLUMN IF NOT EXISTS failure_action_id BIGINT REFERENCES failure_codes(id);
+
+CREATE INDEX IF NOT EXISTS idx_work_orders_failure_mode ON work_orders (failure_mode_id) WHERE failure_mode_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_work_orders_failure_mode;
+ALTER TABLE work_orders
+    DROP COLUMN IF EXISTS failure_action_id,
+    DROP COLUMN IF EXISTS failure_cause_id,
+    DROP COLUMN IF EXISTS failure_mode_id;
+DROP TABLE IF EXISTS failure_codes;
@@ -0,0 +1,50 @@
+-- +goose Up
+-- Prioridade e prazos de SLA das OS, a partir da matriz criticidade × tipo.
+
+CREATE TABLE IF NOT EXISTS sla_policies (
+    criticality         TEXT NOT NULL CHECK (criticality IN ('A','B','C')),
+    type                TEXT NOT NULL CHECK (type IN ('corrective','preventive','condition','improvement')),
+    priority            TEXT NOT NULL CHECK (priority IN ('urgent','high','normal','low')),
+    response_minutes    BIGINT NOT NULL CHECK (response_minutes > 0),
+    resolution_minutes  BIGINT NOT NULL,
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (criticality, type),
+    CONSTRAINT ck_sla_resolution_after_response CHECK (resolution_minutes >= response_minutes)
+);
+
+-- Mesma matriz de domain.DefaultSLAMatrix.
+INSERT INTO sla_policies (criticality, type, priority, response_minutes, resolution_minutes) VALUES
+    ('A', 'corrective', 'urgent', 30, 240),
+    ('A', 'condition', 'high', 120, 1440),
+    ('A', 'preventive', 'normal', 1440, 4320),
+    ('A', 'improvement', 'low', 2880, 20160),
+    ('B', 'corrective', 'high', 120, 1440),
+    ('B', 'condition', 'normal', 480, 2880),
+    ('B', 'preventive', 'normal', 2880, 10080),
+    ('B', 'improvement', 'low', 4320, 20160),
+    ('C', 'corrective', 'normal', 480, 4320),
+    ('C', 'condition', 'low', 1440, 10080),
+    ('C', 'preventive', 'low', 4320, 20160),
+    ('C', 'improvement', 'low', 10080, 43200)
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE work_orders
+    ADD COLUMN IF NOT EXISTS priority          TEXT CHECK (priority IN ('urgent','high','normal','low')),
+    ADD COLUMN IF NOT EXISTS response_due_at   TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS resolution_due_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS responded_at      TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS sla_breached_at   TIMESTAMPTZ;
+
+-- Busca do detector de atrasos e do filtro overdue=true.
+CREATE INDEX IF NOT EXISTS idx_work_orders_open_due ON work_orders (resolution_due_at)
+    WHERE status IN ('open','in_progress');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_work_orders_open_due;
+ALTER TABLE work_orders
+    DROP COLUMN IF EXISTS sla_breached_at,
+    DROP COLUMN IF EXISTS responded_at,
+    DROP COLUMN IF EXISTS resolution_due_at,
+    DROP COLUMN IF EXISTS response_due_at,
+    DROP COLUMN IF EXISTS priority;
+DROP TABLE IF EXISTS sla_policies;
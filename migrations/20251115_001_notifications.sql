@@ -0,0 +1,59 @@
+-- +goose Up
+-- Notificações: destinatários, escalonamento por evento, alertas e estoque de peças.
+
+CREATE TABLE IF NOT EXISTS users (
+    id          BIGSERIAL PRIMARY KEY,
+    name        TEXT NOT NULL,
+    email       TEXT,
+    phone       TEXT,
+    chat_id     TEXT,
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    preferences JSONB NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS escalation_rules (
+    event         TEXT NOT NULL CHECK (event IN ('critical_breakdown','sla_at_risk','preventive_overdue','low_stock')),
+    tier          INT NOT NULL CHECK (tier >= 1),
+    delay_minutes BIGINT NOT NULL CHECK (delay_minutes >= 0),
+    user_ids      BIGINT[] NOT NULL,
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event, tier)
+);
+
+CREATE TABLE IF NOT EXISTS alerts (
+    id           BIGSERIAL PRIMARY KEY,
+    event        TEXT NOT NULL,
+    ref_type     TEXT NOT NULL CHECK (ref_type IN ('work_order','spare_part')),
+    ref_id       BIGINT NOT NULL,
+    message      TEXT NOT NULL,
+    triggered_at TIMESTAMPTZ NOT NULL,
+    tier         INT NOT NULL DEFAULT 0,
+    acked_at     TIMESTAMPTZ,
+    acked_by     BIGINT REFERENCES users(id),
+    resolved_at  TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Um alerta não resolvido por evento e referência (gatilhos repetidos não duplicam).
+CREATE UNIQUE INDEX IF NOT EXISTS ux_alerts_unresolved ON alerts (event, ref_type, ref_id)
+    WHERE resolved_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS spare_parts (
+    id           BIGSERIAL PRIMARY KEY,
+    code         TEXT NOT NULL UNIQUE,
+    name         TEXT NOT NULL,
+    unit         TEXT NOT NULL DEFAULT 'un',
+    quantity     NUMERIC(14,3) NOT NULL DEFAULT 0 CHECK (quantity >= 0),
+    min_quantity NUMERIC(14,3) NOT NULL DEFAULT 0 CHECK (min_quantity >= 0),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS spare_parts;
+DROP INDEX IF EXISTS ux_alerts_unresolved;
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS escalation_rules;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,43 @@
+-- +goose Up
+-- Calendário da planta (dias úteis, feriados, paradas programadas) e preventivas agendadas.
+
+CREATE TABLE IF NOT EXISTS plant_calendar (
+    id           SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    working_days SMALLINT[] NOT NULL DEFAULT '{1,2,3,4,5}',
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ck_plant_calendar_days CHECK (working_days <@ ARRAY[0,1,2,3,4,5,6]::smallint[])
+);
+INSERT INTO plant_calendar (id) VALUES (1) ON CONFLICT DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS holidays (
+    id         BIGSERIAL PRIMARY KEY,
+    date       DATE NOT NULL UNIQUE,
+    name       TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS shutdowns (
+    id         BIGSERIAL PRIMARY KEY,
+    starts_at  TIMESTAMPTZ NOT NULL,
+    ends_at    TIMESTAMPTZ NOT NULL,
+    reason     TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT ck_shutdowns_range CHECK (ends_at > starts_at)
+);
+
+ALTER TABLE work_orders
+    ADD COLUMN IF NOT EXISTS plan_id       BIGINT REFERENCES maintenance_plans(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS scheduled_for TIMESTAMPTZ;
+
+-- Uma preventiva em aberto por plano (o agendador pode rodar em mais de uma réplica).
+CREATE UNIQUE INDEX IF NOT EXISTS ux_work_orders_open_plan ON work_orders (plan_id)
+    WHERE plan_id IS NOT NULL AND status IN ('open','in_progress');
+
+-- +goose Down
+DROP INDEX IF EXISTS ux_work_orders_open_plan;
+ALTER TABLE work_orders
+    DROP COLUMN IF EXISTS scheduled_for,
+    DROP COLUMN IF EXISTS plan_id;
+DROP TABLE IF EXISTS shutdowns;
+DROP TABLE IF EXISTS holidays;
+DROP TABLE IF EXISTS plant_calendar;
@@ -0,0 +1,28 @@
+-- +goose Up
+-- Planejamento de capacidade: especialidade e esforço estimado das OS/planos e cadastro de técnicos.
+
+ALTER TABLE work_orders
+    ADD COLUMN IF NOT EXISTS trade             TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS estimated_minutes INT CHECK (estimated_minutes > 0);
+
+ALTER TABLE maintenance_plans
+    ADD COLUMN IF NOT EXISTS trade             TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS estimated_minutes INT CHECK (estimated_minutes > 0);
+
+CREATE TABLE IF NOT EXISTS technicians (
+    id          BIGSERIAL PRIMARY KEY,
+    name        TEXT NOT NULL,
+    skills      TEXT[] NOT NULL DEFAULT '{}',
+    shift_id    BIGINT NOT NULL REFERENCES shifts(id),
+    active      BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_work_orders_open_trade ON work_orders (trade) WHERE status IN ('open','in_progress');
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_work_orders_open_trade;
+DROP TABLE IF EXISTS technicians;
+ALTER TABLE maintenance_plans DROP COLUMN IF EXISTS estimated_minutes, DROP COLUMN IF EXISTS trade;
+ALTER TABLE work_orders DROP COLUMN IF EXISTS estimated_minutes, DROP COLUMN IF EXISTS trade;
@@ -0,0 +1,41 @@
+-- +goose Up
+-- Roteiros padrão (job plans) das preventivas e checklist das OS.
+
+CREATE TABLE IF NOT EXISTS job_plans (
+    id                 BIGSERIAL PRIMARY KEY,
+    name               TEXT NOT NULL,
+    description        TEXT,
+    trade              TEXT NOT NULL DEFAULT '',
+    estimated_minutes  INT CHECK (estimated_minutes > 0),
+    steps              JSONB NOT NULL DEFAULT '[]',
+    parts              JSONB NOT NULL DEFAULT '[]',
+    created_at         TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE maintenance_plans
+    ADD COLUMN IF NOT EXISTS job_plan_id BIGINT REFERENCES job_plans(id) ON DELETE SET NULL;
+
+ALTER TABLE work_orders
+    ADD COLUMN IF NOT EXISTS job_plan_id    BIGINT REFERENCES job_plans(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS required_parts JSONB NOT NULL DEFAULT '[]';
+
+CREATE TABLE IF NOT EXISTS work_order_checklist (
+    work_order_id     BIGINT NOT NULL REFERENCES work_orders(id) ON DELETE CASCADE,
+    step              INT NOT NULL CHECK (step > 0),
+    description       TEXT NOT NULL,
+    measurement       JSONB,
+    done              BOOLEAN NOT NULL DEFAULT FALSE,
+    value             DOUBLE PRECISION,
+    note              TEXT,
+    out_of_tolerance  BOOLEAN NOT NULL DEFAULT FALSE,
+    follow_up_id      BIGINT REFERENCES work_orders(id) ON DELETE SET NULL,
+    completed_at      TIMESTAMPTZ,
+    PRIMARY KEY (work_order_id, step)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS work_order_checklist;
+ALTER TABLE work_orders DROP COLUMN IF EXISTS required_parts, DROP COLUMN IF EXISTS job_plan_id;
+ALTER TABLE maintenance_plans DROP COLUMN IF EXISTS job_plan_id;
+DROP TABLE IF EXISTS job_plans;
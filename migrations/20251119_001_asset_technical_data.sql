@@ -0,0 +1,39 @@
+-- +goose Up
+-- Dados de placa, classes de ativo e atributos customizados (JSONB).
+-- external_code passa a ser a tag única do ativo (sem diferenciar maiúsculas).
+
+CREATE TABLE IF NOT EXISTS asset_classes (
+    id          BIGSERIAL PRIMARY KEY,
+    code        TEXT NOT NULL UNIQUE,
+    name        TEXT NOT NULL,
+    attributes  JSONB NOT NULL DEFAULT '[]',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE assets
+    ADD COLUMN IF NOT EXISTS manufacturer   TEXT,
+    ADD COLUMN IF NOT EXISTS model          TEXT,
+    ADD COLUMN IF NOT EXISTS serial_number  TEXT,
+    ADD COLUMN IF NOT EXISTS installed_on   DATE,
+    ADD COLUMN IF NOT EXISTS warranty_until DATE,
+    ADD COLUMN IF NOT EXISTS class          TEXT REFERENCES asset_classes(code) ON UPDATE CASCADE,
+    ADD COLUMN IF NOT EXISTS attributes     JSONB NOT NULL DEFAULT '{}';
+
+DROP INDEX IF EXISTS idx_assets_external_code;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_assets_external_code ON assets (LOWER(external_code));
+CREATE INDEX IF NOT EXISTS idx_assets_class ON assets (class);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_assets_class;
+DROP INDEX IF EXISTS uq_assets_external_code;
+CREATE INDEX IF NOT EXISTS idx_assets_external_code ON assets (external_code);
+ALTER TABLE assets
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS class,
+    DROP COLUMN IF EXISTS warranty_until,
+    DROP COLUMN IF EXISTS installed_on,
+    DROP COLUMN IF EXISTS serial_number,
+    DROP COLUMN IF EXISTS model,
+    DROP COLUMN IF EXISTS manufacturer;
+DROP TABLE IF EXISTS asset_classes;
@@ -0,0 +1,29 @@
+-- +goose Up
+-- Portal de solicitações: pedidos do chão de fábrica triados antes de virar OS.
+
+CREATE TABLE IF NOT EXISTS maintenance_requests (
+    id             BIGSERIAL PRIMARY KEY,
+    asset_id       BIGINT NOT NULL REFERENCES assets(id),
+    title          TEXT NOT NULL,
+    description    TEXT,
+    requested_by   TEXT,
+    breakdown_at   TIMESTAMPTZ,
+    status         TEXT NOT NULL DEFAULT 'submitted'
+                   CHECK (status IN ('submitted','triaged','accepted','rejected','merged')),
+    triage_note    TEXT,
+    resolution     TEXT,
+    work_order_id  BIGINT REFERENCES work_orders(id) ON DELETE SET NULL,
+    triaged_at     TIMESTAMPTZ,
+    closed_at      TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_maintenance_requests_status ON maintenance_requests (status, asset_id);
+
+ALTER TABLE work_orders
+    ADD COLUMN IF NOT EXISTS request_id BIGINT REFERENCES maintenance_requests(id) ON DELETE SET NULL;
+
+-- +goose Down
+ALTER TABLE work_orders DROP COLUMN IF EXISTS request_id;
+DROP TABLE IF EXISTS maintenance_requests;
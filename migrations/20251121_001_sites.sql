@@ -0,0 +1,138 @@
+-- +goose Up
+-- Multi-site: ativos, OS, planos, usuários, solicitações, turnos, técnicos, peças
+-- alertas, regras de escalonamento, calendário e roteiros passam a pertencer a uma planta.
+-- Paradas, leituras, apontamentos de produção e checklists seguem o site do ativo ou da OS.
+-- Os dados existentes ficam no site 1 (a planta que já usava o sistema).
+
+CREATE TABLE IF NOT EXISTS sites (
+    id         BIGSERIAL PRIMARY KEY,
+    code       TEXT NOT NULL,
+    name       TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_sites_code ON sites (UPPER(code));
+
+INSERT INTO sites (id, code, name) VALUES (1, 'MATRIZ', 'Planta matriz') ON CONFLICT (id) DO NOTHING;
+SELECT setval(pg_get_serial_sequence('sites', 'id'), (SELECT MAX(id) FROM sites));
+
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS site_id BIGINT NOT NULL DEFAULT 1 REFERENCES sites(id);
+ALTER TABLE assets ALTER COLUMN site_id DROP DEFAULT;
+-- (id, site_id) é o alvo das FKs compostas: OS e plano sempre no site do ativo.
+ALTER TABLE assets ADD CONSTRAINT uq_assets_id_site UNIQUE (id, site_id);
+
+-- A tag/plaqueta passa a ser única por site (as plantas numeram de forma independente).
+DROP INDEX IF EXISTS uq_assets_external_code;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_assets_site_external_code ON assets (site_id, LOWER(external_code));
+
+ALTER TABLE work_orders ADD COLUMN IF NOT EXISTS site_id BIGINT;
+UPDATE work_orders wo SET site_id = a.site_id FROM assets a WHERE a.id = wo.asset_id;
+ALTER TABLE work_orders ALTER COLUMN site_id SET NOT NULL;
+ALTER TABLE work_orders ADD CONSTRAINT fk_work_orders_asset_site
+    FOREIGN KEY (asset_id, site_id) REFERENCES assets (id, site_id);
+CREATE INDEX IF NOT EXISTS idx_work_orders_site_status ON work_orders (site_id, status);
+
+ALTER TABLE maintenance_plans ADD COLUMN IF NOT EXISTS site_id BIGINT;
+UPDATE maintenance_plans p SET site_id = a.site_id FROM assets a WHERE a.id = p.asset_id;
+ALTER TABLE maintenance_plans ALTER COLUMN site_id SET NOT NULL;
+ALTER TABLE maintenance_plans ADD CONSTRAINT fk_maintenance_plans_asset_site
+    FOREIGN KEY (asset_id, site_id) REFERENCES assets (id, site_id);
+CREATE INDEX IF NOT EXISTS idx_maintenance_plans_site ON maintenance_plans (site_id);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS site_id BIGINT NOT NULL DEFAULT 1 REFERENCES sites(id);
+ALTER TABLE users ALTER COLUMN site_id DROP DEFAULT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS corporate BOOLEAN NOT NULL DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS idx_users_site ON users (site_id);
+
+-- Turnos, técnicos e estoque são de cada planta; o técnico fica no site do turno.
+ALTER TABLE shifts ADD COLUMN IF NOT EXISTS site_id BIGINT NOT NULL DEFAULT 1 REFERENCES sites(id);
+ALTER TABLE shifts ALTER COLUMN site_id DROP DEFAULT;
+ALTER TABLE shifts ADD CONSTRAINT uq_shifts_id_site UNIQUE (id, site_id);
+
+ALTER TABLE technicians ADD COLUMN IF NOT EXISTS site_id BIGINT;
+UPDATE technicians t SET site_id = s.site_id FROM shifts s WHERE s.id = t.shift_id;
+ALTER TABLE technicians ALTER COLUMN site_id SET NOT NULL;
+ALTER TABLE technicians ADD CONSTRAINT fk_technicians_shift_site
+    FOREIGN KEY (shift_id, site_id) REFERENCES shifts (id, site_id);
+CREATE INDEX IF NOT EXISTS idx_technicians_site ON technicians (site_id);
+
+ALTER TABLE spare_parts ADD COLUMN IF NOT EXISTS site_id BIGINT NOT NULL DEFAULT 1 REFERENCES sites(id);
+ALTER TABLE spare_parts ALTER COLUMN site_id DROP DEFAULT;
+-- O código da peça passa a ser único por site, como a tag do ativo.
+ALTER TABLE spare_parts DROP CONSTRAINT IF EXISTS spare_parts_code_key;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_spare_parts_site_code ON spare_parts (site_id, code);
+
+ALTER TABLE maintenance_requests ADD COLUMN IF NOT EXISTS site_id BIGINT;
+UPDATE maintenance_requests r SET site_id = a.site_id FROM assets a WHERE a.id = r.asset_id;
+ALTER TABLE maintenance_requests ALTER COLUMN site_id SET NOT NULL;
+ALTER TABLE maintenance_requests ADD CONSTRAINT fk_maintenance_requests_asset_site
+    FOREIGN KEY (asset_id, site_id) REFERENCES assets (id, site_id);
+CREATE INDEX IF NOT EXISTS idx_maintenance_requests_site_status ON maintenance_requests (site_id, status);
+
+-- O alerta fica no site da referência (OS ou peça).
+ALTER TABLE alerts ADD COLUMN IF NOT EXISTS site_id BIGINT REFERENCES sites(id);
+UPDATE alerts al SET site_id = wo.site_id FROM work_orders wo WHERE al.ref_type = 'work_order' AND wo.id = al.ref_id;
+UPDATE alerts al SET site_id = sp.site_id FROM spare_parts sp WHERE al.ref_type = 'spare_part' AND sp.id = al.ref_id;
+UPDATE alerts SET site_id = 1 WHERE site_id IS NULL;
+ALTER TABLE alerts ALTER COLUMN site_id SET NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_alerts_site ON alerts (site_id);
+
+-- Calendário por planta (dias úteis, feriados e paradas); o atual fica com o site 1.
+ALTER TABLE plant_calendar ADD COLUMN IF NOT EXISTS site_id BIGINT NOT NULL DEFAULT 1 REFERENCES sites(id);
+ALTER TABLE plant_calendar ALTER COLUMN site_id DROP DEFAULT;
+ALTER TABLE plant_calendar DROP COLUMN IF EXISTS id;
+ALTER TABLE plant_calendar ADD PRIMARY KEY (site_id);
+
+ALTER TABLE holidays ADD COLUMN IF NOT EXISTS site_id BIGINT NOT NULL DEFAULT 1 REFERENCES sites(id);
+ALTER TABLE holidays ALTER COLUMN site_id DROP DEFAULT;
+ALTER TABLE holidays DROP CONSTRAINT IF EXISTS holidays_date_key;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_holidays_site_date ON holidays (site_id, date);
+
+ALTER TABLE shutdowns ADD COLUMN IF NOT EXISTS site_id BIGINT NOT NULL DEFAULT 1 REFERENCES sites(id);
+ALTER TABLE shutdowns ALTER COLUMN site_id DROP DEFAULT;
+CREATE INDEX IF NOT EXISTS idx_shutdowns_site ON shutdowns (site_id, starts_at);
+
+-- Roteiros citam peças do estoque, que é de cada planta.
+ALTER TABLE job_plans ADD COLUMN IF NOT EXISTS site_id BIGINT NOT NULL DEFAULT 1 REFERENCES sites(id);
+ALTER TABLE job_plans ALTER COLUMN site_id DROP DEFAULT;
+CREATE INDEX IF NOT EXISTS idx_job_plans_site ON job_plans (site_id);
+
+-- Cada planta tem a sua escala de plantão; as regras existentes ficam no site 1.
+ALTER TABLE escalation_rules ADD COLUMN IF NOT EXISTS site_id BIGINT NOT NULL DEFAULT 1 REFERENCES sites(id);
+ALTER TABLE escalation_rules ALTER COLUMN site_id DROP DEFAULT;
+ALTER TABLE escalation_rules DROP CONSTRAINT IF EXISTS escalation_rules_pkey;
+ALTER TABLE escalation_rules ADD PRIMARY KEY (site_id, event, tier);
+
+-- +goose Down
+ALTER TABLE job_plans DROP COLUMN IF EXISTS site_id;
+DELETE FROM escalation_rules WHERE site_id <> 1;
+ALTER TABLE escalation_rules DROP CONSTRAINT IF EXISTS escalation_rules_pkey;
+ALTER TABLE escalation_rules DROP COLUMN IF EXISTS site_id;
+ALTER TABLE escalation_rules ADD PRIMARY KEY (event, tier);
+DELETE FROM shutdowns WHERE site_id <> 1;
+ALTER TABLE shutdowns DROP COLUMN IF EXISTS site_id;
+DELETE FROM holidays WHERE site_id <> 1;
+DROP INDEX IF EXISTS uq_holidays_site_date;
+ALTER TABLE holidays DROP COLUMN IF EXISTS site_id;
+ALTER TABLE holidays ADD CONSTRAINT holidays_date_key UNIQUE (date);
+DELETE FROM plant_calendar WHERE site_id <> 1;
+ALTER TABLE plant_calendar DROP CONSTRAINT IF EXISTS plant_calendar_pkey;
+ALTER TABLE plant_calendar DROP COLUMN IF EXISTS site_id;
+ALTER TABLE plant_calendar ADD COLUMN id SMALLINT PRIMARY KEY DEFAULT 1 CHECK (id = 1);
+ALTER TABLE alerts DROP COLUMN IF EXISTS site_id;
+ALTER TABLE maintenance_requests DROP COLUMN IF EXISTS site_id;
+DROP INDEX IF EXISTS uq_spare_parts_site_code;
+ALTER TABLE spare_parts DROP COLUMN IF EXISTS site_id;
+ALTER TABLE spare_parts ADD CONSTRAINT spare_parts_code_key UNIQUE (code);
+ALTER TABLE technicians DROP COLUMN IF EXISTS site_id;
+ALTER TABLE shifts DROP CONSTRAINT IF EXISTS uq_shifts_id_site;
+ALTER TABLE shifts DROP COLUMN IF EXISTS site_id;
+ALTER TABLE users DROP COLUMN IF EXISTS corporate;
+ALTER TABLE users DROP COLUMN IF EXISTS site_id;
+ALTER TABLE maintenance_plans DROP COLUMN IF EXISTS site_id;
+ALTER TABLE work_orders DROP COLUMN IF EXISTS site_id;
+DROP INDEX IF EXISTS uq_assets_site_external_code;
+ALTER TABLE assets DROP CONSTRAINT IF EXISTS uq_assets_id_site;
+ALTER TABLE assets DROP COLUMN IF EXISTS site_id;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_assets_external_code ON assets (LOWER(external_code));
+DROP TABLE IF EXISTS sites;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- Respostas dos POST com Idempotency-Key, por usuário. status NULL = em andamento;
+-- expires_at é o prazo da reserva enquanto roda e o fim da retenção depois.
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id       BIGINT NOT NULL,
+    key           TEXT NOT NULL,
+    request_hash  TEXT NOT NULL,
+    status        INT,
+    content_type  TEXT,
+    body          BYTEA,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at    TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires ON idempotency_keys (expires_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS idempotency_keys;
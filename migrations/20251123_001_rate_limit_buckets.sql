@@ -0,0 +1,15 @@
+-- +goose Up
+-- Baldes do limitador de requisições compartilhados entre as réplicas
+-- (RATE_LIMIT_STORE=postgres). tokens é o saldo em updated_at. UNLOGGED: perder
+-- os saldos numa queda do banco só reinicia as cotas.
+
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limit_buckets (
+    key         TEXT PRIMARY KEY,
+    tokens      DOUBLE PRECISION NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_rate_limit_buckets_updated ON rate_limit_buckets (updated_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS rate_limit_buckets;
@@ -0,0 +1,112 @@
+-- +goose Up
+-- Sincronização offline: todo INSERT/UPDATE em ativos, OS e passos de checklist
+-- recebe uma revisão (xid8 << 20) | n, onde xid8 é o da transação e n conta as
+-- linhas gravadas por ela (variável local da transação, sem tabela nem lock).
+-- Revisões de transações diferentes nunca se repetem e escritas concorrentes não
+-- se esperam: não há linha de contador para disputar (nem deadlock com updates
+-- em lote como MarkSLABreached).
+--
+-- A ordem dos xids não é a de commit, então quem lê só entrega revisões abaixo
+-- da marca d'água pg_snapshot_xmin(pg_current_snapshot()) << 20: toda transação
+-- com xid menor já terminou, e qualquer escrita que ainda vá aparecer terá
+-- revisão maior que o token já entregue.
+--
+-- Custo: uma transação longa (de qualquer base do cluster) segura o xmin e
+-- atrasa o delta até terminar; nada se perde, só demora. Use
+-- idle_in_transaction_session_timeout. Uma transação grava no máximo 2^20 - 1
+-- linhas sincronizadas (os lotes de importação são de 500) e o token cabe em
+-- 53 bits enquanto o xid8 do cluster estiver abaixo de 2^33.
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION bump_sync_revision() RETURNS trigger AS $$
+DECLARE
+    n BIGINT;
+BEGIN
+    n := COALESCE(NULLIF(current_setting('sync.rows', true), '')::bigint, 0) + 1;
+    IF n >= 1048576 THEN
+        RAISE EXCEPTION 'sync revision: more than % synced rows in one transaction', n - 1;
+    END IF;
+    PERFORM set_config('sync.rows', n::text, true);
+    NEW.revision := (pg_current_xact_id()::text::bigint << 20) | n;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+ALTER TABLE assets ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE work_orders ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE work_order_checklist ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE work_order_checklist ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+CREATE TRIGGER trg_assets_sync_revision BEFORE INSERT OR UPDATE ON assets
+    FOR EACH ROW EXECUTE FUNCTION bump_sync_revision();
+CREATE TRIGGER trg_work_orders_sync_revision BEFORE INSERT OR UPDATE ON work_orders
+    FOR EACH ROW EXECUTE FUNCTION bump_sync_revision();
+CREATE TRIGGER trg_work_order_checklist_sync_revision BEFORE INSERT OR UPDATE ON work_order_checklist
+    FOR EACH ROW EXECUTE FUNCTION bump_sync_revision();
+
+-- Os registros existentes recebem revisão pelo próprio trigger.
+UPDATE assets SET revision = 0;
+UPDATE work_orders SET revision = 0;
+UPDATE work_order_checklist SET revision = 0;
+
+CREATE INDEX IF NOT EXISTS idx_assets_site_revision ON assets (site_id, revision);
+CREATE INDEX IF NOT EXISTS idx_work_orders_site_revision ON work_orders (site_id, revision);
+CREATE INDEX IF NOT EXISTS idx_work_order_checklist_revision ON work_order_checklist (revision);
+
+-- O app offline do técnico só recebe as OS atribuídas a ele. O técnico é
+-- vinculado ao usuário que entra no app; o responsável fica no site da OS.
+ALTER TABLE technicians ADD COLUMN IF NOT EXISTS user_id BIGINT UNIQUE REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE technicians ADD CONSTRAINT uq_technicians_id_site UNIQUE (id, site_id);
+ALTER TABLE work_orders ADD COLUMN IF NOT EXISTS assignee_id BIGINT;
+ALTER TABLE work_orders ADD CONSTRAINT fk_work_orders_assignee_site
+    FOREIGN KEY (assignee_id, site_id) REFERENCES technicians (id, site_id);
+CREATE INDEX IF NOT EXISTS idx_work_orders_assignee_revision ON work_orders (assignee_id, revision);
+
+-- Quem perdeu a OS recebe a remoção pela revisão da passagem; quem ganhou
+-- recebe o checklist inteiro de novo (os passos ganham revisão nova).
+CREATE TABLE IF NOT EXISTS work_order_handoffs (
+    work_order_id BIGINT NOT NULL REFERENCES work_orders(id) ON DELETE CASCADE,
+    technician_id BIGINT NOT NULL REFERENCES technicians(id) ON DELETE CASCADE,
+    revision BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (work_order_id, technician_id)
+);
+CREATE INDEX IF NOT EXISTS idx_work_order_handoffs_technician_revision ON work_order_handoffs (technician_id, revision);
+
+CREATE TRIGGER trg_work_order_handoffs_sync_revision BEFORE INSERT OR UPDATE ON work_order_handoffs
+    FOR EACH ROW EXECUTE FUNCTION bump_sync_revision();
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION record_work_order_handoff() RETURNS trigger AS $$
+BEGIN
+    IF OLD.assignee_id IS NOT NULL AND OLD.assignee_id IS DISTINCT FROM NEW.assignee_id THEN
+        INSERT INTO work_order_handoffs (work_order_id, technician_id) VALUES (NEW.id, OLD.assignee_id)
+        ON CONFLICT (work_order_id, technician_id) DO UPDATE SET revision = 0;
+    END IF;
+    IF NEW.assignee_id IS NOT NULL AND NEW.assignee_id IS DISTINCT FROM OLD.assignee_id THEN
+        UPDATE work_order_checklist SET revision = 0 WHERE work_order_id = NEW.id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_work_orders_handoff AFTER UPDATE OF assignee_id ON work_orders
+    FOR EACH ROW EXECUTE FUNCTION record_work_order_handoff();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_work_orders_handoff ON work_orders;
+DROP FUNCTION IF EXISTS record_work_order_handoff();
+DROP TABLE IF EXISTS work_order_handoffs;
+DROP INDEX IF EXISTS idx_work_orders_assignee_revision;
+ALTER TABLE work_orders DROP CONSTRAINT IF EXISTS fk_work_orders_assignee_site;
+ALTER TABLE work_orders DROP COLUMN IF EXISTS assignee_id;
+ALTER TABLE technicians DROP CONSTRAINT IF EXISTS uq_technicians_id_site;
+ALTER TABLE technicians DROP COLUMN IF EXISTS user_id;
+DROP TRIGGER IF EXISTS trg_work_order_checklist_sync_revision ON work_order_checklist;
+DROP TRIGGER IF EXISTS trg_work_orders_sync_revision ON work_orders;
+DROP TRIGGER IF EXISTS trg_assets_sync_revision ON assets;
+ALTER TABLE work_order_checklist DROP COLUMN IF EXISTS updated_at, DROP COLUMN IF EXISTS revision;
+ALTER TABLE work_orders DROP COLUMN IF EXISTS revision;
+ALTER TABLE assets DROP COLUMN IF EXISTS revision;
+DROP FUNCTION IF EXISTS bump_sync_revision();
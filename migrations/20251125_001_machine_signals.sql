@@ -0,0 +1,41 @@
+-- +goose Up
+-- Sinais de máquina (cmd/ingest): leituras do contador de uso, medições de
+-- condição e limites de alarme dos planos por condição.
+
+CREATE TABLE IF NOT EXISTS meter_readings (
+    id          BIGSERIAL PRIMARY KEY,
+    asset_id    BIGINT NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    value       BIGINT NOT NULL CHECK (value >= 0),
+    read_at     TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_meter_readings_asset_read_at ON meter_readings (asset_id, read_at);
+
+CREATE TABLE IF NOT EXISTS condition_readings (
+    id          BIGSERIAL PRIMARY KEY,
+    asset_id    BIGINT NOT NULL REFERENCES assets(id) ON DELETE CASCADE,
+    parameter   TEXT NOT NULL,
+    value       DOUBLE PRECISION NOT NULL,
+    read_at     TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_condition_readings_asset_parameter ON condition_readings (asset_id, parameter, read_at);
+
+ALTER TABLE maintenance_plans ADD COLUMN IF NOT EXISTS condition_parameter TEXT;
+ALTER TABLE maintenance_plans ADD COLUMN IF NOT EXISTS condition_min DOUBLE PRECISION;
+ALTER TABLE maintenance_plans ADD COLUMN IF NOT EXISTS condition_max DOUBLE PRECISION;
+ALTER TABLE maintenance_plans ADD CONSTRAINT ck_condition_limits CHECK (
+    (condition_min IS NULL AND condition_max IS NULL)
+    OR (rule_type = 'condition' AND condition_parameter IS NOT NULL
+        AND (condition_min IS NULL OR condition_max IS NULL OR condition_min < condition_max))
+);
+
+-- +goose Down
+ALTER TABLE maintenance_plans DROP CONSTRAINT IF EXISTS ck_condition_limits;
+ALTER TABLE maintenance_plans DROP COLUMN IF EXISTS condition_max;
+ALTER TABLE maintenance_plans DROP COLUMN IF EXISTS condition_min;
+ALTER TABLE maintenance_plans DROP COLUMN IF EXISTS condition_parameter;
+DROP TABLE IF EXISTS condition_readings;
+DROP TABLE IF EXISTS meter_readings;
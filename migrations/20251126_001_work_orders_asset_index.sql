@@ -0,0 +1,6 @@
+-- +goose Up
+-- Últimas OS de cada ativo (campo workOrders do ativo no GraphQL e históricos).
+CREATE INDEX IF NOT EXISTS idx_work_orders_asset_created ON work_orders (asset_id, created_at DESC, id DESC);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_work_orders_asset_created;
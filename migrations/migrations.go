@@ -0,0 +1,35 @@
+// Package migrations embute os arquivos SQL (formato goose) no binário.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.sql
+var FS embed.FS
+
+// LatestVersion retorna a maior versão entre os arquivos embutidos.
+// A versão é o prefixo numérico do nome, como no goose (ex: 20251103_001_init.sql → 20251103).
+func LatestVersion() (int64, error) {
+	entries, err := fs.ReadDir(FS, ".")
+	if err != nil {
+		return 0, fmt.Errorf("read migrations: %w", err)
+	}
+
+	var latest int64
+	for _, e := range entries {
+		prefix, _, _ := strings.Cut(e.Name(), "_")
+		v, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("migration %q: invalid version prefix", e.Name())
+		}
+		if v > latest {
+			latest = v
+		}
+	}
+	return latest, nil
+}